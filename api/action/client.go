@@ -42,8 +42,13 @@ func (c *Client) FindActionTagsByPrefix(arg params.FindTags) (params.FindTagsRes
 // the designated ActionReceiver, returning the params.Action for each
 // queued Action, or an error if there was a problem queueing up the
 // Action.
+// Limiting the parallelism or setting the ordering of the actions
+// requires version 3 of the facade.
 func (c *Client) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	results := params.ActionResults{}
+	if (arg.Parallelism != 0 || arg.Ordering != "") && c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("limiting the parallelism or ordering of actions on this juju controller")
+	}
 	err := c.facade.FacadeCall("Enqueue", arg, &results)
 	return results, err
 }
@@ -6,11 +6,13 @@ package action_test
 import (
 	"errors"
 
+	jujuerrors "github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -127,3 +129,44 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestEnqueueWithPolicy(c *gc.C) {
+	args := params.Actions{
+		Actions:     []params.Action{{Receiver: "unit-mysql-0", Name: "restart"}},
+		Parallelism: 1,
+		Ordering:    "leader-last",
+	}
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(request, gc.Equals, "Enqueue")
+			c.Check(arg, jc.DeepEquals, args)
+			*(result.(*params.ActionResults)) = params.ActionResults{
+				Results: []params.ActionResult{{Status: "pending"}},
+			}
+			return nil
+		},
+		BestVersion: 3,
+	}
+	results, err := action.NewClient(apiCaller).Enqueue(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+	c.Check(results.Results, gc.HasLen, 1)
+}
+
+func (s *actionSuite) TestEnqueueWithPolicyNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 2,
+	}
+	_, err := action.NewClient(apiCaller).Enqueue(params.Actions{
+		Actions:     []params.Action{{Receiver: "unit-mysql-0", Name: "restart"}},
+		Parallelism: 1,
+	})
+	c.Assert(err, jc.Satisfies, jujuerrors.IsNotSupported)
+}
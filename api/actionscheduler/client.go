@@ -0,0 +1,27 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+)
+
+// Client provides access to the ActionScheduler facade, used to queue
+// scheduled actions when they are due.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ActionScheduler client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "ActionScheduler")}
+}
+
+// RunDueSchedules queues the actions of all action schedules in the
+// model that are due to run.
+func (c *Client) RunDueSchedules() error {
+	return errors.Trace(c.facade.FacadeCall("RunDueSchedules", nil, nil))
+}
@@ -0,0 +1,35 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionscheduler"
+	apitesting "github.com/juju/juju/api/base/testing"
+)
+
+var _ = gc.Suite(&ActionSchedulerSuite{})
+
+type ActionSchedulerSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *ActionSchedulerSuite) TestRunDueSchedules(c *gc.C) {
+	called := false
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionScheduler")
+		c.Check(request, gc.Equals, "RunDueSchedules")
+		c.Check(arg, gc.IsNil)
+		called = true
+		return errors.New("boom")
+	})
+
+	client := actionscheduler.NewClient(apiCaller)
+	err := client.RunDueSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, gc.Equals, true)
+}
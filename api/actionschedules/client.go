@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the schedules that actions are
+// periodically queued on.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ActionSchedules client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ActionSchedules")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddSchedule adds a schedule that queues an action on units of the
+// model.
+func (c *Client) AddSchedule(schedule params.AddActionSchedule) error {
+	args := params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{schedule},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListSchedules returns all of the action schedules in the model.
+func (c *Client) ListSchedules() ([]params.ActionSchedule, error) {
+	var result params.ActionSchedules
+	if err := c.facade.FacadeCall("ListSchedules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Schedules, nil
+}
+
+// RemoveSchedules removes the named action schedules.
+func (c *Client) RemoveSchedules(names ...string) error {
+	args := params.ActionScheduleNames{Names: names}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveSchedules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(names) {
+		return errors.Errorf("expected %d results, got %d", len(names), len(results.Results))
+	}
+	return results.Combine()
+}
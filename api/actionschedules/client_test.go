@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/actionschedules"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var _ = gc.Suite(&ActionSchedulesSuite{})
+
+type ActionSchedulesSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *ActionSchedulesSuite) TestAddSchedule(c *gc.C) {
+	schedule := params.AddActionSchedule{
+		Name:       "nightly",
+		Schedule:   "0 2 * * *",
+		Leaders:    []string{"postgresql"},
+		ActionName: "backup",
+	}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionSchedules")
+		c.Check(request, gc.Equals, "AddSchedules")
+		c.Check(arg, jc.DeepEquals, params.AddActionSchedules{
+			Schedules: []params.AddActionSchedule{schedule},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	client := actionschedules.NewClient(apiCaller)
+	err := client.AddSchedule(schedule)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ActionSchedulesSuite) TestListSchedules(c *gc.C) {
+	schedules := []params.ActionSchedule{{
+		Name:       "nightly",
+		Schedule:   "0 2 * * *",
+		Units:      []string{"postgresql/0"},
+		ActionName: "backup",
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionSchedules")
+		c.Check(request, gc.Equals, "ListSchedules")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ActionSchedules{})
+		*(result.(*params.ActionSchedules)) = params.ActionSchedules{Schedules: schedules}
+		return nil
+	})
+
+	client := actionschedules.NewClient(apiCaller)
+	result, err := client.ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, schedules)
+}
+
+func (s *ActionSchedulesSuite) TestRemoveSchedules(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ActionSchedules")
+		c.Check(request, gc.Equals, "RemoveSchedules")
+		c.Check(arg, jc.DeepEquals, params.ActionScheduleNames{Names: []string{"a", "b"}})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: `action schedule "b" not found`}}},
+		}
+		return nil
+	})
+
+	client := actionschedules.NewClient(apiCaller)
+	err := client.RemoveSchedules("a", "b")
+	c.Assert(err, gc.ErrorMatches, `action schedule "b" not found`)
+}
+
+func (s *ActionSchedulesSuite) TestCallError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+
+	client := actionschedules.NewClient(apiCaller)
+	_, err := client.ListSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
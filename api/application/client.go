@@ -282,10 +282,18 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// RollingUpgrade, if set, releases the upgrade to the application's
+	// existing units in batches. This field is only understood by
+	// Application facade version 9 and greater.
+	RollingUpgrade *params.RollingUpgradeParams
 }
 
 // SetCharm sets the charm for a given application.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.RollingUpgrade != nil && c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("rolling upgrades on this controller")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -315,10 +323,40 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		RollingUpgrade:     cfg.RollingUpgrade,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// ResumeRollingUpgrade resumes the paused rolling charm upgrade of the
+// given application.
+func (c *Client) ResumeRollingUpgrade(application string) error {
+	return c.updateRollingUpgrade("ResumeRollingUpgrades", application)
+}
+
+// AbortRollingUpgrade aborts the rolling charm upgrade of the given
+// application.
+func (c *Client) AbortRollingUpgrade(application string) error {
+	return c.updateRollingUpgrade("AbortRollingUpgrades", application)
+}
+
+func (c *Client) updateRollingUpgrade(method, application string) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("rolling upgrades on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag(application).String()},
+	}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -614,6 +652,72 @@ func (c *Client) ScaleApplication(in ScaleApplicationParams) (params.ScaleApplic
 	return results.Results[0], nil
 }
 
+// SetAutoscalePolicy hands the scaling of the given application over
+// to the substrate, according to the specified policy.
+func (c *Client) SetAutoscalePolicy(application string, policy params.AutoscalePolicy) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("autoscaling applications on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.SetAutoscalePoliciesParams{
+		Applications: []params.SetAutoscalePolicyParams{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAutoscalePolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveAutoscalePolicy returns the scaling of the given
+// application to the operator.
+func (c *Client) RemoveAutoscalePolicy(application string) error {
+	if c.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("autoscaling applications on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: names.NewApplicationTag(application).String()},
+	}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAutoscalePolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// TransferLeadership hands the leadership of the given application to
+// the given unit, which must be an alive unit of the application.
+func (c *Client) TransferLeadership(application, unitName string) error {
+	if c.BestAPIVersion() < 11 {
+		return errors.NotSupportedf("transferring leadership on this controller")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	if !names.IsValidUnit(unitName) {
+		return errors.NotValidf("unit name %q", unitName)
+	}
+	args := params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			UnitTag:        names.NewUnitTag(unitName).String(),
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("TransferLeadership", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // GetConstraints returns the constraints for the given applications.
 func (c *Client) GetConstraints(applications ...string) ([]constraints.Value, error) {
 	var allConstraints []constraints.Value
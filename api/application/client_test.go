@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -30,7 +32,7 @@ type applicationSuite struct {
 var _ = gc.Suite(&applicationSuite{})
 
 func newClient(f basetesting.APICallerFunc) *application.Client {
-	return application.NewClient(basetesting.BestVersionCaller{f, 8})
+	return application.NewClient(basetesting.BestVersionCaller{f, 11})
 }
 
 func newClientV4(f basetesting.APICallerFunc) *application.Client {
@@ -296,6 +298,157 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	rolling := &params.RollingUpgradeParams{
+		BatchSize:    2,
+		BatchTimeout: 10 * time.Minute,
+		OnError:      "pause",
+	}
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetCharm")
+		args, ok := a.(params.ApplicationSetCharm)
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(args.RollingUpgrade, jc.DeepEquals, rolling)
+		return nil
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		RollingUpgrade: rolling,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetCharmRollingUpgradeNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 8,
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		RollingUpgrade: &params.RollingUpgradeParams{BatchSize: 1},
+	})
+	c.Assert(err, gc.ErrorMatches, "rolling upgrades on this controller not supported")
+}
+
+func (s *applicationSuite) TestResumeRollingUpgrade(c *gc.C) {
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ResumeRollingUpgrades")
+		c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "application-foo"}}})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		return nil
+	})
+	err := client.ResumeRollingUpgrade("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestAbortRollingUpgrade(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "AbortRollingUpgrades")
+		c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "application-foo"}}})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.AbortRollingUpgrade("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetAutoscalePolicy(c *gc.C) {
+	policy := params.AutoscalePolicy{MinUnits: 1, MaxUnits: 4, TargetCPUPercent: 70}
+	var called bool
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetAutoscalePolicies")
+		c.Assert(a, jc.DeepEquals, params.SetAutoscalePoliciesParams{
+			Applications: []params.SetAutoscalePolicyParams{{
+				ApplicationTag: "application-foo",
+				Policy:         policy,
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = make([]params.ErrorResult, 1)
+		return nil
+	})
+	err := client.SetAutoscalePolicy("foo", policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetAutoscalePolicyNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 9,
+	})
+	err := client.SetAutoscalePolicy("foo", params.AutoscalePolicy{})
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications on this controller not supported")
+}
+
+func (s *applicationSuite) TestRemoveAutoscalePolicy(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "RemoveAutoscalePolicies")
+		c.Assert(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "application-foo"}}})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.RemoveAutoscalePolicy("foo")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestTransferLeadership(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "TransferLeadership")
+		c.Assert(a, jc.DeepEquals, params.TransferLeadershipArgs{
+			Args: []params.TransferLeadershipArg{{
+				ApplicationTag: "application-foo",
+				UnitTag:        "unit-foo-1",
+			}},
+		})
+		result := response.(*params.ErrorResults)
+		result.Results = []params.ErrorResult{{Error: &params.Error{Message: "boom"}}}
+		return nil
+	})
+	err := client.TransferLeadership("foo", "foo/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestTransferLeadershipNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 10,
+	})
+	err := client.TransferLeadership("foo", "foo/1")
+	c.Assert(err, gc.ErrorMatches, "transferring leadership on this controller not supported")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -0,0 +1,33 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the controller's audit log.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AuditLog client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit log entries matching the query, most recent
+// first.
+func (c *Client) Query(query params.AuditLogQuery) ([]params.AuditLogEntry, error) {
+	var result params.AuditLogEntries
+	if err := c.facade.FacadeCall("Query", query, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+type AuditLogSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *AuditLogSuite) TestQuery(c *gc.C) {
+	query := params.AuditLogQuery{User: "bob", Limit: 10}
+	entries := []params.AuditLogEntry{{
+		ConversationID: "aaaa",
+		Who:            "bob",
+		Facade:         "Application",
+		Method:         "Deploy",
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AuditLog")
+		c.Check(request, gc.Equals, "Query")
+		c.Check(arg, jc.DeepEquals, query)
+		c.Assert(result, gc.FitsTypeOf, &params.AuditLogEntries{})
+		*(result.(*params.AuditLogEntries)) = params.AuditLogEntries{Entries: entries}
+		return nil
+	})
+
+	client := auditlog.NewClient(apiCaller)
+	result, err := client.Query(query)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, entries)
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+
+	client := auditlog.NewClient(apiCaller)
+	_, err := client.Query(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,23 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ScheduleInfo returns the controller's backup schedule and retention
+// policy, and the state of the scheduled backups.
+func (c *Client) ScheduleInfo() (*params.BackupsScheduleInfo, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("backup schedules on this version of Juju")
+	}
+	var result params.BackupsScheduleInfo
+	if err := c.facade.FacadeCall("ScheduleInfo", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &result, nil
+}
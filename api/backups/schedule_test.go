@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/backups"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type scheduleSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+func (s *scheduleSuite) TestScheduleInfo(c *gc.C) {
+	next := time.Date(2018, 10, 18, 2, 0, 0, 0, time.UTC)
+	expected := params.BackupsScheduleInfo{
+		Schedule:     "0 2 * * *",
+		RetainDaily:  7,
+		RetainWeekly: 4,
+		NextBackup:   &next,
+	}
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 3,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "ScheduleInfo")
+			c.Check(paramsIn, gc.IsNil)
+			if result, ok := resp.(*params.BackupsScheduleInfo); ok {
+				*result = expected
+			} else {
+				c.Fatalf("wrong output structure")
+			}
+			return nil
+		},
+	)
+	defer cleanup()
+
+	result, err := s.client.ScheduleInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*result, jc.DeepEquals, expected)
+}
+
+func (s *scheduleSuite) TestScheduleInfoNotSupported(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCallVersion(s.client, 2,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Fatalf("unexpected call to %s", req)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.ScheduleInfo()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
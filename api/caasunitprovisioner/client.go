@@ -150,6 +150,7 @@ type ProvisioningInfo struct {
 	Filesystems []storage.KubernetesFilesystemParams
 	Devices     []devices.KubernetesDeviceParams
 	Tags        map[string]string
+	Autoscale   *application.AutoscalePolicy
 }
 
 // ProvisioningInfo returns the provisioning info for the specified CAAS
@@ -196,6 +197,16 @@ func (c *Client) ProvisioningInfo(appName string) (*ProvisioningInfo, error) {
 		})
 	}
 	info.Devices = devs
+
+	if policy := result.Autoscale; policy != nil {
+		info.Autoscale = &application.AutoscalePolicy{
+			MinUnits:         policy.MinUnits,
+			MaxUnits:         policy.MaxUnits,
+			TargetCPUPercent: policy.TargetCPUPercent,
+			Metric:           policy.Metric,
+			MetricTarget:     policy.MetricTarget,
+		}
+	}
 	return info, nil
 }
 
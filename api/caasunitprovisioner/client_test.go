@@ -68,6 +68,9 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 							Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
 						},
 					},
+					Autoscale: &params.AutoscalePolicy{
+						MinUnits: 1, MaxUnits: 3, Metric: "requests", MetricTarget: "10",
+					},
 				},
 			}},
 		}
@@ -101,6 +104,9 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 			Count:      3,
 			Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
 		}},
+		Autoscale: &application.AutoscalePolicy{
+			MinUnits: 1, MaxUnits: 3, Metric: "requests", MetricTarget: "10",
+		},
 	})
 }
 
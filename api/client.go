@@ -517,6 +517,13 @@ func (c *Client) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
 	return openURI(c.st, uri, query)
 }
 
+// OpenResource streams out the named resource of the application from
+// the controller via the API.
+func (c *Client) OpenResource(application, name string) (io.ReadCloser, error) {
+	uri := fmt.Sprintf("/applications/%s/resources/%s", url.PathEscape(application), url.PathEscape(name))
+	return openURI(c.st, uri, nil)
+}
+
 func openURI(apiCaller base.APICaller, uri string, query url.Values) (io.ReadCloser, error) {
 	// The returned httpClient sets the base url to /model/<uuid> if it can.
 	httpClient, err := apiCaller.HTTPClient()
@@ -11,6 +11,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -71,6 +73,30 @@ func (e *ModelWatcher) LogForwardConfig() (*syslog.RawConfig, bool, error) {
 	return cfg, ok, nil
 }
 
+// LogForwardHTTPConfig returns the current HTTP log forward configuration.
+func (e *ModelWatcher) LogForwardHTTPConfig() (*httpjson.RawConfig, bool, error) {
+	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
+	// For now, we'll piggyback off the ModelConfig API.
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdHTTP()
+	return cfg, ok, nil
+}
+
+// LogForwardFileConfig returns the current file log forward configuration.
+func (e *ModelWatcher) LogForwardFileConfig() (*logfile.RawConfig, bool, error) {
+	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
+	// For now, we'll piggyback off the ModelConfig API.
+	modelConfig, err := e.ModelConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := modelConfig.LogFwdFile()
+	return cfg, ok, nil
+}
+
 // UpdateStatusHookInterval returns the current update status hook interval.
 func (e *ModelWatcher) UpdateStatusHookInterval() (time.Duration, error) {
 	// TODO(wallyworld) - lp:1602237 - this needs to have it's own backend implementation.
@@ -11,19 +11,22 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
 	"ActionPruner":                 1,
+	"ActionScheduler":              1,
+	"ActionSchedules":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  8,
+	"Application":                  11,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
+	"AuditLog":                     1,
+	"Backups":                      3,
 	"Block":                        2,
-	"Bundle":                       2,
+	"Bundle":                       3,
 	"CAASAgent":                    1,
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
@@ -55,12 +58,13 @@ var facadeVersions = map[string]int{
 	"InstancePoller":               3,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
+	"LeadershipPinning":            1,
 	"LeadershipService":            2,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               5,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -68,10 +72,10 @@ var facadeVersions = map[string]int{
 	"MetricsDebug":                 2,
 	"MetricsManager":               1,
 	"MigrationFlag":                1,
-	"MigrationMaster":              1,
+	"MigrationMaster":              2,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  2,
 	"ModelManager":                 4,
 	"ModelUpgrader":                1,
@@ -80,7 +84,7 @@ var facadeVersions = map[string]int{
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
-	"Provisioner":                  6,
+	"Provisioner":                  7,
 	"ProxyUpdater":                 2,
 	"Reboot":                       2,
 	"RelationStatusWatcher":        1,
@@ -90,6 +94,8 @@ var facadeVersions = map[string]int{
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"RollingUpgrader":              1,
+	"Secrets":                      1,
 	"Singular":                     2,
 	"Spaces":                       3,
 	"SSHClient":                    2,
@@ -100,7 +106,7 @@ var facadeVersions = map[string]int{
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       8,
+	"Uniter":                       10,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
@@ -0,0 +1,75 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinning
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the pinning of application leadership in
+// a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new LeadershipPinning client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "LeadershipPinning")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// PinnedLeadership returns the names of applications in the model with
+// pinned leadership, each with the entities that pinned it.
+func (c *Client) PinnedLeadership() (map[string][]string, error) {
+	var result params.PinnedLeadershipResult
+	if err := c.facade.FacadeCall("PinnedLeadership", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Result, nil
+}
+
+// PinApplicationLeaders pins the leadership of the named applications,
+// returning the result for each of them.
+func (c *Client) PinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error) {
+	return c.pinOps("PinApplicationLeaders", appNames)
+}
+
+// UnpinApplicationLeaders removes the current user's pins on the
+// leadership of the named applications, returning the result for each
+// of them.
+func (c *Client) UnpinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error) {
+	return c.pinOps("UnpinApplicationLeaders", appNames)
+}
+
+// ForceUnpinApplicationLeaders removes all pins on the leadership of the
+// named applications, whoever made them, returning the result for each
+// of them. It requires admin access to the model.
+func (c *Client) ForceUnpinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error) {
+	return c.pinOps("ForceUnpinApplicationLeaders", appNames)
+}
+
+func (c *Client) pinOps(method string, appNames []string) ([]params.PinApplicationResult, error) {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(appNames)),
+	}
+	for i, appName := range appNames {
+		if !names.IsValidApplication(appName) {
+			return nil, errors.NotValidf("application name %q", appName)
+		}
+		args.Entities[i].Tag = names.NewApplicationTag(appName).String()
+	}
+	var results params.PinApplicationsResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(appNames) {
+		return nil, errors.Errorf("expected %d results, got %d", len(appNames), len(results.Results))
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinning_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/leadershippinning"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var _ = gc.Suite(&PinningSuite{})
+
+type PinningSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *PinningSuite) TestPinnedLeadership(c *gc.C) {
+	expected := map[string][]string{"mysql": {"user-admin"}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LeadershipPinning")
+		c.Check(request, gc.Equals, "PinnedLeadership")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.PinnedLeadershipResult{})
+		*(result.(*params.PinnedLeadershipResult)) = params.PinnedLeadershipResult{Result: expected}
+		return nil
+	})
+
+	client := leadershippinning.NewClient(apiCaller)
+	pinned, err := client.PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pinned, jc.DeepEquals, expected)
+}
+
+func (s *PinningSuite) TestPinApplicationLeaders(c *gc.C) {
+	s.testPinOps(c, "PinApplicationLeaders", (*leadershippinning.Client).PinApplicationLeaders)
+}
+
+func (s *PinningSuite) TestUnpinApplicationLeaders(c *gc.C) {
+	s.testPinOps(c, "UnpinApplicationLeaders", (*leadershippinning.Client).UnpinApplicationLeaders)
+}
+
+func (s *PinningSuite) TestForceUnpinApplicationLeaders(c *gc.C) {
+	s.testPinOps(c, "ForceUnpinApplicationLeaders", (*leadershippinning.Client).ForceUnpinApplicationLeaders)
+}
+
+func (s *PinningSuite) testPinOps(
+	c *gc.C, method string,
+	op func(*leadershippinning.Client, []string) ([]params.PinApplicationResult, error),
+) {
+	expected := []params.PinApplicationResult{
+		{ApplicationName: "mysql"},
+		{ApplicationName: "wordpress", Error: &params.Error{Message: "boom"}},
+	}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LeadershipPinning")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{
+				{Tag: "application-mysql"},
+				{Tag: "application-wordpress"},
+			},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.PinApplicationsResults{})
+		*(result.(*params.PinApplicationsResults)) = params.PinApplicationsResults{Results: expected}
+		return nil
+	})
+
+	client := leadershippinning.NewClient(apiCaller)
+	results, err := op(client, []string{"mysql", "wordpress"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *PinningSuite) TestPinInvalidApplication(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call")
+		return nil
+	})
+
+	client := leadershippinning.NewClient(apiCaller)
+	_, err := client.PinApplicationLeaders([]string{"mysql/0"})
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
@@ -270,3 +270,81 @@ func (client *Client) GetUpgradeSeriesMessages(machineName, watcherId string) ([
 
 	return result.Result, nil
 }
+
+// UpgradeSeriesApplicationPrepare starts upgrading the series of the
+// machines hosting the application, one machine at a time with the
+// machine hosting the application's leader last, and prepares the first
+// machine. It returns the progress of the upgrade.
+func (client *Client) UpgradeSeriesApplicationPrepare(application, series string, force bool) (*params.ApplicationUpgradeSeries, error) {
+	if client.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("upgrading the series of an application")
+	}
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag(application).String()},
+			Series: series,
+			Force:  force,
+		}},
+	}
+	var results params.ApplicationUpgradeSeriesResults
+	if err := client.facade.FacadeCall("UpgradeSeriesApplicationPrepare", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationUpgradeSeriesResult(results)
+}
+
+// UpgradeSeriesApplicationComplete starts completing the application's
+// prepared machine, once its operating system has been upgraded. The
+// next machine is prepared when it has completed.
+func (client *Client) UpgradeSeriesApplicationComplete(application string) (*params.ApplicationUpgradeSeries, error) {
+	return client.applicationUpgradeSeriesCall("UpgradeSeriesApplicationComplete", application)
+}
+
+// UpgradeSeriesApplicationPause stops any more of the application's
+// machines from being prepared for series upgrade.
+func (client *Client) UpgradeSeriesApplicationPause(application string) (*params.ApplicationUpgradeSeries, error) {
+	return client.applicationUpgradeSeriesCall("UpgradeSeriesApplicationPause", application)
+}
+
+// UpgradeSeriesApplicationResume resumes the paused series upgrade of
+// the application's machines.
+func (client *Client) UpgradeSeriesApplicationResume(application string) (*params.ApplicationUpgradeSeries, error) {
+	return client.applicationUpgradeSeriesCall("UpgradeSeriesApplicationResume", application)
+}
+
+// UpgradeSeriesApplicationRollback abandons the series upgrade of the
+// application's machines, releasing any machine being prepared.
+func (client *Client) UpgradeSeriesApplicationRollback(application string) (*params.ApplicationUpgradeSeries, error) {
+	return client.applicationUpgradeSeriesCall("UpgradeSeriesApplicationRollback", application)
+}
+
+// UpgradeSeriesApplicationStatus returns the progress of the series
+// upgrade of the application's machines.
+func (client *Client) UpgradeSeriesApplicationStatus(application string) (*params.ApplicationUpgradeSeries, error) {
+	return client.applicationUpgradeSeriesCall("UpgradeSeriesApplicationStatus", application)
+}
+
+func (client *Client) applicationUpgradeSeriesCall(method, application string) (*params.ApplicationUpgradeSeries, error) {
+	if client.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("upgrading the series of an application")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ApplicationUpgradeSeriesResults
+	if err := client.facade.FacadeCall(method, args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationUpgradeSeriesResult(results)
+}
+
+func applicationUpgradeSeriesResult(results params.ApplicationUpgradeSeriesResults) (*params.ApplicationUpgradeSeries, error) {
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
@@ -14,6 +14,7 @@ package machinemanager_test
 
 import (
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -71,3 +72,73 @@ func (s *NewMachineManagerSuite) TestUpgradeSeriesValidate(c *gc.C) {
 
 	c.Assert(unitNames, gc.DeepEquals, result.UnitNames)
 }
+
+func (s *NewMachineManagerSuite) TestUpgradeSeriesApplicationPrepare(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	fFacade := mocks.NewMockClientFacade(ctrl)
+	fCaller := mocks.NewMockFacadeCaller(ctrl)
+
+	args := params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: "application-ubuntu"},
+			Series: "bionic",
+			Force:  true,
+		}},
+	}
+	upgrade := &params.ApplicationUpgradeSeries{
+		Application: "ubuntu",
+		ToSeries:    "bionic",
+		Status:      "running",
+		Machines: []params.ApplicationUpgradeSeriesMachine{{
+			Tag:        "machine-1",
+			FromSeries: "xenial",
+			Status:     "preparing",
+		}},
+	}
+	results := params.ApplicationUpgradeSeriesResults{
+		Results: []params.ApplicationUpgradeSeriesResult{{Result: upgrade}},
+	}
+
+	fFacade.EXPECT().BestAPIVersion().Return(6)
+	fCaller.EXPECT().FacadeCall("UpgradeSeriesApplicationPrepare", args, gomock.Any()).SetArg(2, results)
+	client := machinemanager.ConstructClient(fFacade, fCaller)
+
+	result, err := client.UpgradeSeriesApplicationPrepare("ubuntu", "bionic", true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, upgrade)
+}
+
+func (s *NewMachineManagerSuite) TestUpgradeSeriesApplicationPause(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	fFacade := mocks.NewMockClientFacade(ctrl)
+	fCaller := mocks.NewMockFacadeCaller(ctrl)
+
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-ubuntu"}}}
+	results := params.ApplicationUpgradeSeriesResults{
+		Results: []params.ApplicationUpgradeSeriesResult{{
+			Error: &params.Error{Message: `series upgrade of application "ubuntu" is completed`},
+		}},
+	}
+
+	fFacade.EXPECT().BestAPIVersion().Return(6)
+	fCaller.EXPECT().FacadeCall("UpgradeSeriesApplicationPause", args, gomock.Any()).SetArg(2, results)
+	client := machinemanager.ConstructClient(fFacade, fCaller)
+
+	_, err := client.UpgradeSeriesApplicationPause("ubuntu")
+	c.Assert(err, gc.ErrorMatches, `series upgrade of application "ubuntu" is completed`)
+}
+
+func (s *NewMachineManagerSuite) TestUpgradeSeriesApplicationNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	fFacade := mocks.NewMockClientFacade(ctrl)
+	fCaller := mocks.NewMockFacadeCaller(ctrl)
+
+	fFacade.EXPECT().BestAPIVersion().Return(5)
+	client := machinemanager.ConstructClient(fFacade, fCaller)
+
+	_, err := client.UpgradeSeriesApplicationStatus("ubuntu")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
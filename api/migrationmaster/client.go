@@ -183,10 +183,11 @@ func (c *Client) Export() (migration.SerializedModel, error) {
 	}
 
 	return migration.SerializedModel{
-		Bytes:     serialized.Bytes,
-		Charms:    serialized.Charms,
-		Tools:     tools,
-		Resources: resources,
+		Bytes:       serialized.Bytes,
+		Charms:      serialized.Charms,
+		Tools:       tools,
+		Resources:   resources,
+		CharmStates: serialized.CharmStates,
 	}, nil
 }
 
@@ -274,6 +274,9 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 					},
 				},
 			}},
+			CharmStates: map[string]map[string]string{
+				"fooapp/0": {"foo": "bar"},
+			},
 		}
 		return nil
 	})
@@ -342,6 +345,9 @@ func (s *ClientSuite) TestExport(c *gc.C) {
 				},
 			},
 		}},
+		CharmStates: map[string]map[string]string{
+			"fooapp/0": {"foo": "bar"},
+		},
 	})
 }
 
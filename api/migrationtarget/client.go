@@ -54,9 +54,17 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 }
 
 // Import takes a serialized model and imports it into the target
-// controller.
-func (c *Client) Import(bytes []byte) error {
-	serialized := params.SerializedModel{Bytes: bytes}
+// controller. Charm state can only be imported by version 2 of the
+// MigrationTarget facade; a model with charm state is refused by an
+// older target controller rather than losing it.
+func (c *Client) Import(model coremigration.SerializedModel) error {
+	if len(model.CharmStates) > 0 && c.caller.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("migrating charm state to this target controller")
+	}
+	serialized := params.SerializedModel{
+		Bytes:       model.Bytes,
+		CharmStates: model.CharmStates,
+	}
 	return c.caller.FacadeCall("Import", serialized, nil)
 }
 
@@ -40,11 +40,18 @@ type ClientSuite struct {
 var _ = gc.Suite(&ClientSuite{})
 
 func (s *ClientSuite) getClientAndStub(c *gc.C) (*migrationtarget.Client, *jujutesting.Stub) {
+	return s.getClientAndStubVersion(c, 2)
+}
+
+func (s *ClientSuite) getClientAndStubVersion(c *gc.C, bestVersion int) (*migrationtarget.Client, *jujutesting.Stub) {
 	var stub jujutesting.Stub
-	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
-		stub.AddCall(objType+"."+request, id, arg)
-		return errors.New("boom")
-	})
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return errors.New("boom")
+		}),
+		BestVersion: bestVersion,
+	}
 	client := migrationtarget.NewClient(apiCaller)
 	return client, &stub
 }
@@ -80,15 +87,43 @@ func (s *ClientSuite) TestPrechecks(c *gc.C) {
 func (s *ClientSuite) TestImport(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
-	err := client.Import([]byte("foo"))
+	err := client.Import(coremigration.SerializedModel{
+		Bytes:       []byte("foo"),
+		CharmStates: map[string]map[string]string{"foo/0": {"bar": "baz"}},
+	})
 
-	expectedArg := params.SerializedModel{Bytes: []byte("foo")}
+	expectedArg := params.SerializedModel{
+		Bytes:       []byte("foo"),
+		CharmStates: map[string]map[string]string{"foo/0": {"bar": "baz"}},
+	}
 	stub.CheckCalls(c, []jujutesting.StubCall{
 		{"MigrationTarget.Import", []interface{}{"", expectedArg}},
 	})
 	c.Assert(err, gc.ErrorMatches, "boom")
 }
 
+func (s *ClientSuite) TestImportCharmStateV1(c *gc.C) {
+	client, stub := s.getClientAndStubVersion(c, 1)
+
+	err := client.Import(coremigration.SerializedModel{
+		Bytes:       []byte("foo"),
+		CharmStates: map[string]map[string]string{"foo/0": {"bar": "baz"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "migrating charm state to this target controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportNoCharmStateV1(c *gc.C) {
+	client, stub := s.getClientAndStubVersion(c, 1)
+
+	err := client.Import(coremigration.SerializedModel{Bytes: []byte("foo")})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.Import", []interface{}{"", params.SerializedModel{Bytes: []byte("foo")}}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
 func (s *ClientSuite) TestAbort(c *gc.C) {
 	client, stub := s.getClientAndStub(c)
 
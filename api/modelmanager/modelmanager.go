@@ -313,6 +313,34 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 		return c.dumpModelV2(model)
 	}
 
+	out, err := c.dumpModel(model, simplified)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Parse back into a map.
+	var asMap map[string]interface{}
+	err = yaml.Unmarshal([]byte(out), &asMap)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return asMap, nil
+}
+
+// ExportModel returns the serialized model, in the form used to migrate
+// the model to another controller.
+func (c *Client) ExportModel(model names.ModelTag) ([]byte, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("exporting models with this controller")
+	}
+	out, err := c.dumpModel(model, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return []byte(out), nil
+}
+
+func (c *Client) dumpModel(model names.ModelTag, simplified bool) (string, error) {
 	var results params.StringResults
 	entities := params.DumpModelRequest{
 		Entities:   []params.Entity{{Tag: model.String()}},
@@ -321,23 +349,16 @@ func (c *Client) DumpModel(model names.ModelTag, simplified bool) (map[string]in
 
 	err := c.facade.FacadeCall("DumpModels", entities, &results)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return "", errors.Trace(err)
 	}
 	if count := len(results.Results); count != 1 {
-		return nil, errors.Errorf("unexpected result count: %d", count)
+		return "", errors.Errorf("unexpected result count: %d", count)
 	}
 	result := results.Results[0]
 	if result.Error != nil {
-		return nil, result.Error
-	}
-	// Parse back into a map.
-	var asMap map[string]interface{}
-	err = yaml.Unmarshal([]byte(result.Result), &asMap)
-	if err != nil {
-		return nil, errors.Trace(err)
+		return "", result.Error
 	}
-
-	return asMap, nil
+	return result.Result, nil
 }
 
 func (c *Client) dumpModelV2(model names.ModelTag) (map[string]interface{}, error) {
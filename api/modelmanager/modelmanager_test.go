@@ -639,6 +639,45 @@ func (s *dumpModelSuite) TestDumpModelErrorV2(c *gc.C) {
 	c.Assert(out, gc.IsNil)
 }
 
+func (s *dumpModelSuite) TestExportModel(c *gc.C) {
+	results := params.StringResults{Results: []params.StringResult{{
+		Result: "model-uuid: some-uuid\n",
+	}}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, args, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "DumpModels")
+				c.Assert(args, gc.DeepEquals, params.DumpModelRequest{
+					Entities: []params.Entity{{coretesting.ModelTag.String()}},
+				})
+				res, ok := result.(*params.StringResults)
+				c.Assert(ok, jc.IsTrue)
+				*res = results
+				return nil
+			}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	out, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "model-uuid: some-uuid\n")
+}
+
+func (s *dumpModelSuite) TestExportModelV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, args, result interface{}) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			}),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.ExportModel(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *dumpModelSuite) TestDumpModelDB(c *gc.C) {
 	expected := map[string]interface{}{
 		"models": []map[string]interface{}{{
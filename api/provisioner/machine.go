@@ -116,6 +116,19 @@ type MachineProvisioner interface {
 
 	// SupportsNoContainers records the fact that this machine doesn't support any containers.
 	SupportsNoContainers() error
+
+	// WatchContainerCharmProfileChanges returns a StringsWatcher that
+	// notifies of the ids of LXD containers on the machine whose required
+	// charm profiles may have changed.
+	WatchContainerCharmProfileChanges() (watcher.StringsWatcher, error)
+
+	// CharmProfiles returns the charm LXD profiles required by the units
+	// on the machine, and the names of those applied to its instance.
+	CharmProfiles() (*params.CharmProfilesResult, error)
+
+	// SetCharmProfiles records the names of the charm LXD profiles
+	// applied to the machine's instance.
+	SetCharmProfiles(profiles []string) error
 }
 
 // Machine represents a juju machine as seen by the provisioner worker.
@@ -529,3 +542,60 @@ func (m *Machine) SetSupportedContainers(containerTypes ...instance.ContainerTyp
 func (m *Machine) SupportsNoContainers() error {
 	return m.SetSupportedContainers([]instance.ContainerType{}...)
 }
+
+// WatchContainerCharmProfileChanges implements MachineProvisioner.WatchContainerCharmProfileChanges.
+func (m *Machine) WatchContainerCharmProfileChanges() (watcher.StringsWatcher, error) {
+	var results params.StringsWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("WatchContainerCharmProfileChanges", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(m.st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// CharmProfiles implements MachineProvisioner.CharmProfiles.
+func (m *Machine) CharmProfiles() (*params.CharmProfilesResult, error) {
+	var results params.CharmProfilesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("CharmProfiles", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return &result, nil
+}
+
+// SetCharmProfiles implements MachineProvisioner.SetCharmProfiles.
+func (m *Machine) SetCharmProfiles(profiles []string) error {
+	var results params.ErrorResults
+	args := params.SetCharmProfilesArgs{
+		Args: []params.SetCharmProfilesArg{{
+			Entity:   params.Entity{Tag: m.tag.String()},
+			Profiles: profiles,
+		}},
+	}
+	err := m.st.facade.FacadeCall("SetCharmProfiles", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -108,6 +108,22 @@ func (st *State) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchModelMachineCharmProfileChanges returns a StringsWatcher that
+// notifies of the machines (but not containers) in the current model
+// whose required charm profiles may have changed.
+func (st *State) WatchModelMachineCharmProfileChanges() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := st.facade.FacadeCall("WatchModelMachineCharmProfileChanges", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 func (st *State) WatchMachineErrorRetry() (watcher.NotifyWatcher, error) {
 	var result params.NotifyWatchResult
 	err := st.facade.FacadeCall("WatchMachineErrorRetry", nil, &result)
@@ -719,6 +719,68 @@ func (s *provisionerSuite) TestSupportsNoContainers(c *gc.C) {
 	c.Assert(containers, gc.DeepEquals, []instance.ContainerType{})
 }
 
+func (s *provisionerSuite) TestCharmProfiles(c *gc.C) {
+	apiMachine := s.assertGetOneMachine(c, s.machine.MachineTag())
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	container, err := s.State.AddMachineInsideMachine(template, s.machine.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := apiMachine.WatchContainerCharmProfileChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	ch := s.AddTestingCharm(c, "lxd-profile")
+	app := s.AddTestingApplication(c, "lxd-profile", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(container)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(container.Id())
+	wc.AssertNoChange()
+
+	apiContainer := s.assertGetOneMachine(c, container.MachineTag())
+	_, err = apiContainer.CharmProfiles()
+	c.Assert(err, jc.Satisfies, params.IsCodeNotProvisioned)
+	err = container.SetProvisioned("i-container", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	profileName := fmt.Sprintf("juju-controller-lxd-profile-%d", ch.Revision())
+	err = apiContainer.SetCharmProfiles([]string{profileName})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := apiContainer.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.InstanceId, gc.Equals, "i-container")
+	c.Assert(result.Applied, jc.DeepEquals, []string{profileName})
+	c.Assert(result.Required, gc.HasLen, 1)
+	c.Assert(result.Required[0].Name, gc.Equals, profileName)
+	c.Assert(result.Required[0].Profile.Config, jc.DeepEquals, ch.LXDProfile().Config)
+}
+
+func (s *provisionerSuite) TestWatchModelMachineCharmProfileChanges(c *gc.C) {
+	w, err := s.provisioner.WatchModelMachineCharmProfileChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.AddTestingApplication(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(machine.Id())
+	wc.AssertNoChange()
+}
+
 func (s *provisionerSuite) TestFindToolsNoArch(c *gc.C) {
 	s.testFindTools(c, false, nil, nil)
 }
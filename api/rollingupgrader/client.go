@@ -0,0 +1,44 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Client provides access to the RollingUpgrader facade, used to release
+// rolling charm upgrades to units in batches.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new RollingUpgrader client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{facade: base.NewFacadeCaller(caller, "RollingUpgrader")}
+}
+
+// ProgressRollingUpgrades checks the health of the units released by
+// each running rolling upgrade in the model, and releases the next
+// batch of units, or pauses or aborts the upgrade, accordingly.
+func (c *Client) ProgressRollingUpgrades() error {
+	return errors.Trace(c.facade.FacadeCall("ProgressRollingUpgrades", nil, nil))
+}
+
+// WatchRollingUpgrades returns a NotifyWatcher that triggers when the
+// model's rolling upgrades may need to be progressed.
+func (c *Client) WatchRollingUpgrades() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchRollingUpgrades", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var _ = gc.Suite(&RollingUpgraderSuite{})
+
+type RollingUpgraderSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *RollingUpgraderSuite) TestProgressRollingUpgrades(c *gc.C) {
+	called := false
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "RollingUpgrader")
+		c.Check(request, gc.Equals, "ProgressRollingUpgrades")
+		c.Check(arg, gc.IsNil)
+		called = true
+		return errors.New("boom")
+	})
+
+	client := rollingupgrader.NewClient(apiCaller)
+	err := client.ProgressRollingUpgrades()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, gc.Equals, true)
+}
+
+func (s *RollingUpgraderSuite) TestWatchRollingUpgrades(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "RollingUpgrader")
+		c.Check(request, gc.Equals, "WatchRollingUpgrades")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+
+	client := rollingupgrader.NewClient(apiCaller)
+	_, err := client.WatchRollingUpgrades()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
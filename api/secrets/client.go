@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the secrets in a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Secrets client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Secrets")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AddSecret adds a secret to the model.
+func (c *Client) AddSecret(secret params.AddSecretArg) error {
+	args := params.AddSecretArgs{
+		Args: []params.AddSecretArg{secret},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddSecrets", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListSecrets returns all of the secrets in the model, without their
+// values.
+func (c *Client) ListSecrets() ([]params.Secret, error) {
+	var result params.Secrets
+	if err := c.facade.FacadeCall("ListSecrets", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Secrets, nil
+}
+
+// ShowSecret returns the named secret with its revision history. If
+// reveal is true, the values of the given revision are included; a
+// zero revision means the latest.
+func (c *Client) ShowSecret(name string, revision int, reveal bool) (*params.Secret, error) {
+	args := params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{
+			Name:     name,
+			Revision: revision,
+			Reveal:   reveal,
+		}},
+	}
+	var results params.SecretResults
+	if err := c.facade.FacadeCall("ShowSecrets", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Result, nil
+}
+
+// GrantSecret allows the named application to read the secret.
+func (c *Client) GrantSecret(name, appName string) error {
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			Name:        name,
+			Application: appName,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("GrantSecrets", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RevokeSecret stops the named application from reading the secret.
+func (c *Client) RevokeSecret(name, appName string) error {
+	args := params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{
+			Name:        name,
+			Application: appName,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RevokeSecrets", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,142 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var _ = gc.Suite(&SecretsSuite{})
+
+type SecretsSuite struct {
+	testing.IsolationSuite
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	secret := params.AddSecretArg{
+		Name: "api-key",
+		Data: map[string]string{"key": "abc"},
+	}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "AddSecrets")
+		c.Check(arg, jc.DeepEquals, params.AddSecretArgs{
+			Args: []params.AddSecretArg{secret},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+
+	client := secrets.NewClient(apiCaller)
+	err := client.AddSecret(secret)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SecretsSuite) TestListSecrets(c *gc.C) {
+	expected := []params.Secret{{
+		Name:     "api-key",
+		Revision: 1,
+	}}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ListSecrets")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.Secrets{})
+		*(result.(*params.Secrets)) = params.Secrets{Secrets: expected}
+		return nil
+	})
+
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *SecretsSuite) TestShowSecret(c *gc.C) {
+	expected := &params.Secret{
+		Name:     "api-key",
+		Revision: 2,
+		Value:    map[string]string{"key": "abc"},
+	}
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "ShowSecrets")
+		c.Check(arg, jc.DeepEquals, params.ShowSecretArgs{
+			Args: []params.ShowSecretArg{{Name: "api-key", Revision: 1, Reveal: true}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.SecretResults{})
+		*(result.(*params.SecretResults)) = params.SecretResults{
+			Results: []params.SecretResult{{Result: expected}},
+		}
+		return nil
+	})
+
+	client := secrets.NewClient(apiCaller)
+	result, err := client.ShowSecret("api-key", 1, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *SecretsSuite) TestShowSecretError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.SecretResults)) = params.SecretResults{
+			Results: []params.SecretResult{{
+				Error: &params.Error{Code: params.CodeNotFound, Message: `secret "api-key" not found`},
+			}},
+		}
+		return nil
+	})
+
+	client := secrets.NewClient(apiCaller)
+	_, err := client.ShowSecret("api-key", 0, false)
+	c.Assert(err, gc.ErrorMatches, `secret "api-key" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *SecretsSuite) TestGrantSecret(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "GrantSecrets")
+		c.Check(arg, jc.DeepEquals, params.GrantSecretArgs{
+			Args: []params.GrantSecretArg{{Name: "api-key", Application: "wordpress"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+
+	client := secrets.NewClient(apiCaller)
+	err := client.GrantSecret("api-key", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) TestRevokeSecret(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Secrets")
+		c.Check(request, gc.Equals, "RevokeSecrets")
+		c.Check(arg, jc.DeepEquals, params.GrantSecretArgs{
+			Args: []params.GrantSecretArg{{Name: "api-key", Application: "wordpress"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+
+	client := secrets.NewClient(apiCaller)
+	err := client.RevokeSecret("api-key", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
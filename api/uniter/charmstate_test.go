@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type charmStateSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&charmStateSuite{})
+
+func (s *charmStateSuite) TestCharmState(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "CharmState")
+		c.Assert(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.CharmStateResults{})
+		*(result.(*params.CharmStateResults)) = params.CharmStateResults{
+			Results: []params.CharmStateResult{{
+				Result: map[string]string{"foo": "bar"},
+			}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	charmState, err := unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *charmStateSuite) TestCharmStateError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.CharmStateResults)) = params.CharmStateResults{
+			Results: []params.CharmStateResult{{
+				Error: &params.Error{Message: "yoink"},
+			}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	_, err := unit.CharmState()
+	c.Assert(err, gc.ErrorMatches, "yoink")
+}
+
+func (s *charmStateSuite) TestUpdateCharmState(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "UpdateCharmState")
+		c.Assert(arg, gc.DeepEquals, params.SetCharmStateArgs{
+			Args: []params.SetCharmStateArg{{
+				Tag:   "unit-mysql-0",
+				Set:   map[string]string{"foo": "bar"},
+				Unset: []string{"baz"},
+			}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "yoink"},
+			}},
+		}
+		called = true
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	err := unit.UpdateCharmState(map[string]string{"foo": "bar"}, []string{"baz"})
+	c.Assert(err, gc.ErrorMatches, "yoink")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *charmStateSuite) TestCharmStateNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	st := uniter.NewStateV8(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	_, err := unit.CharmState()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = unit.UpdateCharmState(map[string]string{"foo": "bar"}, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -51,3 +51,7 @@ func CreateUnit(st *State, tag names.UnitTag) *Unit {
 }
 
 var NewStateV4 = newStateForVersionFn(4)
+
+var NewStateV8 = newStateForVersionFn(8)
+
+var NewStateV9 = newStateForVersionFn(9)
@@ -0,0 +1,110 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// GetSecretValue returns the values of the given revision of the named
+// secret, which the unit's application must be able to read. A zero
+// revision means the latest revision.
+func (u *Unit) GetSecretValue(name string, revision int) (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("GetSecretValue() (need V10+)")
+	}
+	var results params.SecretValueResults
+	args := params.GetSecretValueArgs{
+		UnitTag: u.tag.String(),
+		Args: []params.GetSecretValueArg{{
+			Name:     name,
+			Revision: revision,
+		}},
+	}
+	err := u.st.facade.FacadeCall("GetSecretValues", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Data, nil
+}
+
+// SetSecret creates or updates a secret owned by the unit's
+// application. The unit must be the leader of the application.
+func (u *Unit) SetSecret(arg params.SetSecretArg) error {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("SetSecret() (need V10+)")
+	}
+	var results params.ErrorResults
+	args := params.SetSecretArgs{
+		UnitTag: u.tag.String(),
+		Args:    []params.SetSecretArg{arg},
+	}
+	err := u.st.facade.FacadeCall("SetSecrets", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SecretRotations returns when each of the secrets owned by the unit's
+// application that are rotated is next due to be rotated.
+func (u *Unit) SecretRotations() ([]params.SecretRotation, error) {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("SecretRotations() (need V10+)")
+	}
+	var results params.SecretRotationsResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("SecretRotations", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Rotations, nil
+}
+
+// WatchSecretRotations returns a watcher that triggers when the
+// rotations of the secrets owned by the unit's application may have
+// changed.
+func (u *Unit) WatchSecretRotations() (watcher.NotifyWatcher, error) {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("WatchSecretRotations() (need V10+)")
+	}
+	return getSettingsWatcher(u, "WatchSecretRotations")
+}
+
+// SecretRotated records that the unit, which must be the leader of
+// its application, has rotated the named secret.
+func (u *Unit) SecretRotated(name string) error {
+	if u.st.facade.BestAPIVersion() < 10 {
+		return errors.NotSupportedf("SecretRotated() (need V10+)")
+	}
+	var results params.ErrorResults
+	args := params.SecretRotatedArgs{
+		UnitTag: u.tag.String(),
+		Names:   []string{name},
+	}
+	err := u.st.facade.FacadeCall("SecretRotated", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/uniter"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) TestGetSecretValue(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(id, gc.Equals, "")
+		c.Assert(request, gc.Equals, "GetSecretValues")
+		c.Assert(arg, gc.DeepEquals, params.GetSecretValueArgs{
+			UnitTag: "unit-mysql-0",
+			Args:    []params.GetSecretValueArg{{Name: "password", Revision: 2}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.SecretValueResults{})
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			Results: []params.SecretValueResult{{
+				Data: map[string]string{"foo": "bar"},
+			}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	value, err := unit.GetSecretValue("password", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *secretsSuite) TestGetSecretValueError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.SecretValueResults)) = params.SecretValueResults{
+			Results: []params.SecretValueResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	_, err := unit.GetSecretValue("password", 0)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestSetSecret(c *gc.C) {
+	interval := time.Hour
+	secret := params.SetSecretArg{
+		Name:           "password",
+		Data:           map[string]string{"foo": "bar"},
+		RotateInterval: &interval,
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(request, gc.Equals, "SetSecrets")
+		c.Assert(arg, gc.DeepEquals, params.SetSecretArgs{
+			UnitTag: "unit-mysql-0",
+			Args:    []params.SetSecretArg{secret},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				Error: &params.Error{Message: "yoink"},
+			}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	err := unit.SetSecret(secret)
+	c.Assert(err, gc.ErrorMatches, "yoink")
+}
+
+func (s *secretsSuite) TestSecretRotations(c *gc.C) {
+	rotations := []params.SecretRotation{{
+		Name:           "password",
+		RotateInterval: time.Hour,
+		NextRotateTime: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(request, gc.Equals, "SecretRotations")
+		c.Assert(arg, gc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.SecretRotationsResults{})
+		*(result.(*params.SecretRotationsResults)) = params.SecretRotationsResults{
+			Results: []params.SecretRotationsResult{{Rotations: rotations}},
+		}
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	result, err := unit.SecretRotations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, rotations)
+}
+
+func (s *secretsSuite) TestSecretRotated(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Assert(objType, gc.Equals, "Uniter")
+		c.Assert(version, gc.Equals, expectedAPIVersion)
+		c.Assert(request, gc.Equals, "SecretRotated")
+		c.Assert(arg, gc.DeepEquals, params.SecretRotatedArgs{
+			UnitTag: "unit-mysql-0",
+			Names:   []string{"password"},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		called = true
+		return nil
+	})
+	st := uniter.NewState(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	err := unit.SecretRotated("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *secretsSuite) TestSecretsNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected API call %q", request)
+		return nil
+	})
+	st := uniter.NewStateV9(apiCaller, names.NewUnitTag("mysql/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("mysql/0"))
+	_, err := unit.GetSecretValue("password", 0)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = unit.SetSecret(params.SetSecretArg{Name: "password"})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = unit.SecretRotations()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = unit.WatchSecretRotations()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	err = unit.SecretRotated("password")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -799,6 +799,54 @@ func (u *Unit) AddStorage(constraints map[string][]params.StorageConstraints) er
 	return results.Combine()
 }
 
+// CharmState returns the key/value pairs that the unit's charm keeps
+// on the controller.
+func (u *Unit) CharmState() (map[string]string, error) {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return nil, errors.NotSupportedf("CharmState() (need V9+)")
+	}
+	var results params.CharmStateResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("CharmState", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.Result == nil {
+		return map[string]string{}, nil
+	}
+	return result.Result, nil
+}
+
+// UpdateCharmState sets the given keys of the unit's charm state, and
+// removes the unset keys.
+func (u *Unit) UpdateCharmState(set map[string]string, unset []string) error {
+	if u.st.facade.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("UpdateCharmState() (need V9+)")
+	}
+	var results params.ErrorResults
+	args := params.SetCharmStateArgs{
+		Args: []params.SetCharmStateArg{{
+			Tag:   u.tag.String(),
+			Set:   set,
+			Unset: unset,
+		}},
+	}
+	err := u.st.facade.FacadeCall("UpdateCharmState", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // NetworkInfo returns network interfaces/addresses for specified bindings.
 func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]params.NetworkInfoResult, error) {
 	var results params.NetworkInfoResults
@@ -25,7 +25,7 @@ var newBackups = func(st *state.State, m *state.Model) (backups.Backups, io.Clos
 		*state.Model
 	}{st, m}
 	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+	return backups.NewEncryptedBackups(stor, backups.ControllerEncryptionKey(backend)), stor
 }
 
 // backupHandler handles backup requests.
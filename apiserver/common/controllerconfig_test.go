@@ -40,10 +40,13 @@ func (f *fakeControllerAccessor) ControllerConfig() (controller.Config, error) {
 		return nil, f.controllerConfigError
 	}
 	return map[string]interface{}{
-		controller.ControllerUUIDKey: testing.ControllerTag.Id(),
-		controller.CACertKey:         testing.CACert,
-		controller.APIPort:           4321,
-		controller.StatePort:         1234,
+		controller.ControllerUUIDKey:   testing.ControllerTag.Id(),
+		controller.CACertKey:           testing.CACert,
+		controller.APIPort:             4321,
+		controller.StatePort:           1234,
+		controller.BackupEncryptionKey: "c2VjcmV0",
+		controller.BackupS3AccessKey:   "access",
+		controller.BackupS3SecretKey:   "secret",
 	}, nil
 }
 
@@ -73,6 +76,18 @@ func (*controllerConfigSuite) TestControllerConfigSuccess(c *gc.C) {
 	})
 }
 
+func (*controllerConfigSuite) TestControllerConfigWithoutSecrets(c *gc.C) {
+	cc := common.NewControllerConfig(
+		&fakeControllerAccessor{},
+	)
+	result, err := cc.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, key := range controller.SecretAttributes.Values() {
+		_, ok := result.Config[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+}
+
 func (*controllerConfigSuite) TestControllerConfigFetchError(c *gc.C) {
 	cc := common.NewControllerConfig(
 		&fakeControllerAccessor{
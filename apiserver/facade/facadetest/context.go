@@ -20,9 +20,11 @@ type Context struct {
 	StatePool_ *state.StatePool
 	ID_        string
 
-	LeadershipClaimer_ leadership.Claimer
-	LeadershipChecker_ leadership.Checker
-	SingularClaimer_   lease.Claimer
+	LeadershipClaimer_     leadership.Claimer
+	LeadershipChecker_     leadership.Checker
+	LeadershipPinner_      leadership.Pinner
+	LeadershipTransferrer_ leadership.Transferrer
+	SingularClaimer_       lease.Claimer
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -84,6 +86,16 @@ func (context Context) LeadershipChecker() (leadership.Checker, error) {
 	return context.LeadershipChecker_, nil
 }
 
+// LeadershipPinner implements facade.Context.
+func (context Context) LeadershipPinner() (leadership.Pinner, error) {
+	return context.LeadershipPinner_, nil
+}
+
+// LeadershipTransferrer implements facade.Context.
+func (context Context) LeadershipTransferrer() (leadership.Transferrer, error) {
+	return context.LeadershipTransferrer_, nil
+}
+
 // SingularClaimer implements facade.Context.
 func (context Context) SingularClaimer() (lease.Claimer, error) {
 	return context.SingularClaimer_, nil
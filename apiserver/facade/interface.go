@@ -87,6 +87,14 @@ type Context interface {
 	// context's model.
 	LeadershipChecker() (leadership.Checker, error)
 
+	// LeadershipPinner returns a leadership.Pinner for this
+	// context's model.
+	LeadershipPinner() (leadership.Pinner, error)
+
+	// LeadershipTransferrer returns a leadership.Transferrer for this
+	// context's model.
+	LeadershipTransferrer() (leadership.Transferrer, error)
+
 	// SingularClaimer returns a lease.Claimer for singular leases for
 	// this context's model.
 	SingularClaimer() (lease.Claimer, error)
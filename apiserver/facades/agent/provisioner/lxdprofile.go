@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// WatchContainerCharmProfileChanges isn't on the v6 API.
+func (p *ProvisionerAPIV6) WatchContainerCharmProfileChanges(_, _ struct{}) {}
+
+// WatchModelMachineCharmProfileChanges isn't on the v6 API.
+func (p *ProvisionerAPIV6) WatchModelMachineCharmProfileChanges(_, _ struct{}) {}
+
+// CharmProfiles isn't on the v6 API.
+func (p *ProvisionerAPIV6) CharmProfiles(_, _ struct{}) {}
+
+// SetCharmProfiles isn't on the v6 API.
+func (p *ProvisionerAPIV6) SetCharmProfiles(_, _ struct{}) {}
+
+// WatchContainerCharmProfileChanges starts a StringsWatcher for each
+// given machine, notifying of the LXD containers it hosts whose required
+// charm profiles may have changed.
+func (p *ProvisionerAPI) WatchContainerCharmProfileChanges(args params.Entities) (params.StringsWatchResults, error) {
+	result := params.StringsWatchResults{
+		Results: make([]params.StringsWatchResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		watcherResult, err := p.watchOneContainerCharmProfileChanges(canAccess, entity.Tag)
+		result.Results[i] = watcherResult
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (p *ProvisionerAPI) watchOneContainerCharmProfileChanges(canAccess common.AuthFunc, tagString string) (params.StringsWatchResult, error) {
+	nothing := params.StringsWatchResult{}
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nothing, common.ErrPerm
+	}
+	machine, err := p.getMachine(canAccess, tag)
+	if err != nil {
+		return nothing, err
+	}
+	watch := machine.WatchContainerCharmProfileChanges()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: p.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return nothing, watcher.EnsureErr(watch)
+}
+
+// WatchModelMachineCharmProfileChanges returns a StringsWatcher that
+// notifies of the machines (but not containers) in the model whose
+// required charm profiles may have changed.
+func (p *ProvisionerAPI) WatchModelMachineCharmProfileChanges() (params.StringsWatchResult, error) {
+	result := params.StringsWatchResult{}
+	if !p.authorizer.AuthController() {
+		return result, common.ErrPerm
+	}
+	watch := p.st.WatchModelMachineCharmProfileChanges()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		result.StringsWatcherId = p.resources.Register(watch)
+		result.Changes = changes
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// CharmProfiles returns, for each given machine, the LXD profiles
+// supplied by the charms of the applications with units on it, along
+// with the names of the profiles applied to its instance.
+func (p *ProvisionerAPI) CharmProfiles(args params.Entities) (params.CharmProfilesResults, error) {
+	result := params.CharmProfilesResults{
+		Results: make([]params.CharmProfilesResult, len(args.Entities)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		profilesResult, err := p.oneMachineCharmProfiles(canAccess, entity.Tag)
+		result.Results[i] = profilesResult
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (p *ProvisionerAPI) oneMachineCharmProfiles(canAccess common.AuthFunc, tagString string) (params.CharmProfilesResult, error) {
+	nothing := params.CharmProfilesResult{}
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nothing, common.ErrPerm
+	}
+	machine, err := p.getMachine(canAccess, tag)
+	if err != nil {
+		return nothing, err
+	}
+	instanceId, err := machine.InstanceId()
+	if err != nil {
+		return nothing, err
+	}
+	applied, err := machine.CharmProfiles()
+	if err != nil {
+		return nothing, err
+	}
+	required, err := machine.RequiredCharmProfiles()
+	if err != nil {
+		return nothing, err
+	}
+	result := params.CharmProfilesResult{
+		InstanceId: string(instanceId),
+		Applied:    applied,
+		Required:   make([]params.CharmProfile, len(required)),
+	}
+	for i, profile := range required {
+		result.Required[i] = params.CharmProfile{
+			Name:    profile.Name,
+			Profile: convertCharmLXDProfile(profile.Profile),
+		}
+	}
+	return result, nil
+}
+
+func convertCharmLXDProfile(profile *charm.LXDProfile) params.CharmLXDProfile {
+	return params.CharmLXDProfile{
+		Description: profile.Description,
+		Config:      profile.Config,
+		Devices:     profile.Devices,
+	}
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles applied
+// to the instances of the given machines.
+func (p *ProvisionerAPI) SetCharmProfiles(args params.SetCharmProfilesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := p.getAuthFunc()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseMachineTag(arg.Entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := p.getMachine(canAccess, tag)
+		if err == nil {
+			err = machine.SetCharmProfiles(arg.Profiles)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/agent/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type lxdProfileSuite struct {
+	provisionerSuite
+	container   *state.Machine
+	application *state.Application
+	api         *provisioner.ProvisionerAPIV7
+}
+
+var _ = gc.Suite(&lxdProfileSuite{})
+
+func (s *lxdProfileSuite) SetUpTest(c *gc.C) {
+	s.setUpTest(c, false)
+	s.container = addContainerToMachine(c, s.State, s.machines[0])
+	s.application = s.AddTestingApplication(c, "lxd-profile", s.AddTestingCharm(c, "lxd-profile"))
+
+	anAuthorizer := s.authorizer
+	anAuthorizer.Controller = false
+	anAuthorizer.Tag = s.machines[0].Tag()
+	var err error
+	s.api, err = provisioner.NewProvisionerAPIV7(s.State, s.resources, anAuthorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lxdProfileSuite) addUnit(c *gc.C) {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.container)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lxdProfileSuite) TestWatchContainerCharmProfileChanges(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+		{Tag: "unit-foo-0"},
+	}}
+	result, err := s.api.WatchContainerCharmProfileChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringsWatchResults{
+		Results: []params.StringsWatchResult{
+			{StringsWatcherId: "1", Changes: []string{}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	s.addUnit(c)
+	wc.AssertChange(s.container.Id())
+	wc.AssertNoChange()
+}
+
+func (s *lxdProfileSuite) TestWatchModelMachineCharmProfileChanges(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	api, err := provisioner.NewProvisionerAPIV7(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.WatchModelMachineCharmProfileChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.machines[1])
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.machines[1].Id())
+	wc.AssertNoChange()
+}
+
+func (s *lxdProfileSuite) TestWatchModelMachineCharmProfileChangesPermission(c *gc.C) {
+	_, err := s.api.WatchModelMachineCharmProfileChanges()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *lxdProfileSuite) TestCharmProfiles(c *gc.C) {
+	s.addUnit(c)
+	err := s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.container.SetCharmProfiles([]string{"juju-controller-old-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	other := addContainerToMachine(c, s.State, s.machines[0])
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.container.Tag().String()},
+		{Tag: other.Tag().String()},
+		{Tag: s.machines[1].Tag().String()},
+	}}
+	result, err := s.api.CharmProfiles(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+
+	ch, _, err := s.application.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	profile := ch.LXDProfile()
+	c.Check(result.Results[0], jc.DeepEquals, params.CharmProfilesResult{
+		InstanceId: "inst-0",
+		Applied:    []string{"juju-controller-old-0"},
+		Required: []params.CharmProfile{{
+			Name: fmt.Sprintf("juju-controller-lxd-profile-%d", ch.Revision()),
+			Profile: params.CharmLXDProfile{
+				Description: profile.Description,
+				Config:      profile.Config,
+				Devices:     profile.Devices,
+			},
+		}},
+	})
+	c.Check(result.Results[1].Error, jc.Satisfies, params.IsCodeNotProvisioned)
+	c.Check(result.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *lxdProfileSuite) TestSetCharmProfiles(c *gc.C) {
+	err := s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SetCharmProfilesArgs{Args: []params.SetCharmProfilesArg{{
+		Entity:   params.Entity{Tag: s.container.Tag().String()},
+		Profiles: []string{"juju-controller-lxd-profile-0"},
+	}, {
+		Entity:   params.Entity{Tag: s.machines[1].Tag().String()},
+		Profiles: []string{"juju-controller-lxd-profile-0"},
+	}}}
+	result, err := s.api.SetCharmProfiles(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	err = s.container.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	profiles, err := s.container.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, []string{"juju-controller-lxd-profile-0"})
+}
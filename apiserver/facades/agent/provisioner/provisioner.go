@@ -157,6 +157,11 @@ type ProvisionerAPIV5 struct {
 
 // ProvisionerAPIV6 provides v6 of the provisioner facade.
 type ProvisionerAPIV6 struct {
+	*ProvisionerAPIV7
+}
+
+// ProvisionerAPIV7 provides v7 of the provisioner facade.
+type ProvisionerAPIV7 struct {
 	*ProvisionerAPI
 }
 
@@ -180,13 +185,22 @@ func NewProvisionerAPIV5(st *state.State, resources facade.Resources, authorizer
 
 // NewProvisionerAPIV6 creates a new server-side Provisioner API facade.
 func NewProvisionerAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV6, error) {
-	provisionerAPI, err := NewProvisionerAPI(st, resources, authorizer)
+	provisionerAPI, err := NewProvisionerAPIV7(st, resources, authorizer)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &ProvisionerAPIV6{provisionerAPI}, nil
 }
 
+// NewProvisionerAPIV7 creates a new server-side Provisioner API facade.
+func NewProvisionerAPIV7(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ProvisionerAPIV7, error) {
+	provisionerAPI, err := NewProvisionerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ProvisionerAPIV7{provisionerAPI}, nil
+}
+
 func (p *ProvisionerAPI) getMachine(canAccess common.AuthFunc, tag names.MachineTag) (*state.Machine, error) {
 	if !canAccess(tag) {
 		return nil, common.ErrPerm
@@ -107,7 +107,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     imageMetadata,
-		ControllerConfig:  controllerCfg,
+		ControllerConfig:  controllerCfg.WithoutSecrets(),
 		CloudInitUserData: env.Config().CloudInitUserData(),
 	}, nil
 }
@@ -4,6 +4,8 @@
 package provisioner_test
 
 import (
+	"encoding/base64"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -12,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
@@ -416,6 +419,30 @@ func (s *withoutControllerSuite) TestProviderInfoCloudInitUserData(c *gc.C) {
 		"package_upgrade": false})
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithoutSecrets(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.BackupEncryptionKey: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.provisioner.ProvisioningInfo(params.Entities{Entities: []params.Entity{
+		{Tag: m.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	controllerCfg := result.Results[0].Result.ControllerConfig
+	c.Check(controllerCfg[controller.ControllerUUIDKey], gc.Equals, coretesting.ControllerTag.Id())
+	for _, key := range controller.SecretAttributes.Values() {
+		_, ok := controllerCfg[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+}
+
 var validCloudInitUserData = `
 packages:
   - 'python-keystoneclient'
@@ -0,0 +1,108 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/agent/uniter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+// upgradeLXDProfileUnit deploys a charm with an LXD profile to a
+// provisioned LXD container and upgrades the charm, returning the
+// container, the upgraded charm and a uniter facade for the unit.
+func (s *uniterSuite) upgradeLXDProfileUnit(c *gc.C) (*state.Machine, *state.Charm, *uniter.UniterAPI) {
+	container, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.machine1.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	oldCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "lxd-profile",
+		URL:  "cs:quantal/lxd-profile-1",
+	})
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: oldCharm})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: app,
+		Machine:     container,
+		SetCharmURL: true,
+	})
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetCharmProfiles([]string{lxdprofile.Name(m.Name(), app.Name(), 1)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "lxd-profile",
+		URL:  "cs:quantal/lxd-profile-2",
+	})
+	err = app.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+
+	authorizer := s.authorizer
+	authorizer.Tag = unit.Tag()
+	api, err := uniter.NewUniterAPI(facadetest.Context{
+		State_:             s.State,
+		Resources_:         s.resources,
+		Auth_:              authorizer,
+		LeadershipChecker_: s.State.LeadershipChecker(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return container, newCharm, api
+}
+
+func (s *uniterSuite) TestCharmURLWaitsForCharmProfile(c *gc.C) {
+	container, newCharm, api := s.upgradeLXDProfileUnit(c)
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-lxd-profile"}}}
+
+	// The unit is held at its current charm until the new charm's
+	// profile is applied to its machine.
+	result, err := api.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: "cs:quantal/lxd-profile-1"}},
+	})
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetCharmProfiles([]string{lxdprofile.Name(m.Name(), "lxd-profile", 2)})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = api.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: newCharm.String()}},
+	})
+}
+
+func (s *uniterSuite) TestWatchApplicationCharmProfile(c *gc.C) {
+	container, _, api := s.upgradeLXDProfileUnit(c)
+	result, err := api.Watch(params.Entities{Entities: []params.Entity{
+		{Tag: "application-lxd-profile"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}},
+	})
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = container.SetCharmProfiles([]string{lxdprofile.Name(m.Name(), "lxd-profile", 2)})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
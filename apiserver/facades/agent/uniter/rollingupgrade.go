@@ -0,0 +1,142 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// charmURL returns the charm URL of the given unit or application. A
+// unit agent asking for its application's charm URL is given the charm
+// its unit should run, which is its current charm while a rolling
+// upgrade holds the unit back, or while the LXD profile supplied by
+// the new charm has yet to be applied to the unit's machine.
+func (u *UniterAPI) charmURL(tag names.Tag) (*charm.URL, bool, error) {
+	entity, err := u.st.FindEntity(tag)
+	if err != nil {
+		return nil, false, err
+	}
+	app, isApp := entity.(*state.Application)
+	unitTag, isUnit := u.auth.GetAuthTag().(names.UnitTag)
+	if !isApp || !isUnit {
+		charmURLer := entity.(interface {
+			CharmURL() (*charm.URL, bool)
+		})
+		curl, force := charmURLer.CharmURL()
+		return curl, force, nil
+	}
+	unit, err := u.getUnit(unitTag)
+	if err != nil {
+		return nil, false, err
+	}
+	curl, force, err := app.CharmURLForUnit(unit)
+	if err != nil {
+		return nil, false, err
+	}
+	unitURL, ok := unit.CharmURL()
+	if !ok || *unitURL == *curl {
+		return curl, force, nil
+	}
+	applied, err := unit.CharmProfileApplied()
+	if err != nil {
+		return nil, false, err
+	}
+	if !applied {
+		return unitURL, false, nil
+	}
+	return curl, force, nil
+}
+
+// Watch starts a NotifyWatcher for each given entity. Watchers of an
+// application also trigger when the application's rolling upgrade
+// changes, so that units are told when they are released to upgrade,
+// and when a unit agent's machine has new charm profiles applied, so
+// that the unit is told when its charm profile is ready.
+func (u *UniterAPI) Watch(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessApplication()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			agentResult, err := u.AgentEntityWatcher.Watch(params.Entities{
+				Entities: []params.Entity{entity},
+			})
+			if err != nil {
+				return params.NotifyWatchResults{}, err
+			}
+			result.Results[i] = agentResult.Results[0]
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchApplication(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchApplication(tag names.ApplicationTag) (string, error) {
+	app, err := u.getApplication(tag)
+	if err != nil {
+		return "", err
+	}
+	watchers := []state.NotifyWatcher{app.Watch(), app.WatchRollingUpgrade()}
+	if unitTag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+		machineWatcher, err := u.watchUnitMachineCharmProfiles(unitTag)
+		if err != nil {
+			for _, w := range watchers {
+				w.Kill()
+			}
+			return "", err
+		}
+		if machineWatcher != nil {
+			watchers = append(watchers, machineWatcher)
+		}
+	}
+	w := common.NewMultiNotifyWatcher(watchers...)
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-w.Changes(); ok {
+		return u.resources.Register(w), nil
+	}
+	return "", watcher.EnsureErr(w)
+}
+
+// watchUnitMachineCharmProfiles returns a watcher of the charm profiles
+// applied to the machine hosting the unit, or nil if the unit is not
+// assigned to a machine.
+func (u *UniterAPI) watchUnitMachineCharmProfiles(tag names.UnitTag) (state.NotifyWatcher, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, err
+	}
+	machineId, err := unit.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	machine, err := u.st.Machine(machineId)
+	if err != nil {
+		return nil, err
+	}
+	return machine.WatchCharmProfiles(), nil
+}
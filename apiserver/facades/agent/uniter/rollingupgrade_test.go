@@ -0,0 +1,80 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *uniterSuite) rollingUpgradeWordpress(c *gc.C) *state.Charm {
+	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err = s.wordpress.SetCharm(state.SetCharmConfig{
+		Charm: newCharm,
+		RollingUpgrade: &state.RollingUpgradeArgs{
+			BatchSize:    1,
+			BatchTimeout: time.Minute,
+			OnError:      state.RollingUpgradePause,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return newCharm
+}
+
+func (s *uniterSuite) TestCharmURLRollingUpgrade(c *gc.C) {
+	newCharm := s.rollingUpgradeWordpress(c)
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+
+	// The unit is held at its current charm until it is released.
+	result, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: s.wpCharm.String()}},
+	})
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.StringBoolResults{
+		Results: []params.StringBoolResult{{Result: newCharm.String()}},
+	})
+}
+
+func (s *uniterSuite) TestWatchApplicationRollingUpgrade(c *gc.C) {
+	result, err := s.uniter.Watch(params.Entities{Entities: []params.Entity{
+		{Tag: "application-wordpress"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{{NotifyWatcherId: "1"}},
+	})
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.rollingUpgradeWordpress(c)
+	wc.AssertOneChange()
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
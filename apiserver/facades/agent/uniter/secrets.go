@@ -0,0 +1,206 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// secretsUnit returns the unit with the given tag, if the caller may
+// act on its behalf.
+func (u *UniterAPI) secretsUnit(unitTag string) (*state.Unit, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tag, err := names.ParseUnitTag(unitTag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	if !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	return u.getUnit(tag)
+}
+
+// secretsLeader returns the unit with the given tag, if the caller may
+// act on its behalf and it is the leader of its application.
+func (u *UniterAPI) secretsLeader(unitTag string) (*state.Unit, error) {
+	unit, err := u.secretsUnit(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	token := u.leadershipChecker.LeadershipCheck(unit.ApplicationName(), unit.Name())
+	if err := token.Check(nil); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit, nil
+}
+
+// GetSecretValues returns the values of secrets that the unit's
+// application may read.
+func (u *UniterAPI) GetSecretValues(args params.GetSecretValueArgs) (params.SecretValueResults, error) {
+	unit, err := u.secretsUnit(args.UnitTag)
+	if err != nil {
+		return params.SecretValueResults{}, errors.Trace(err)
+	}
+	results := params.SecretValueResults{
+		Results: make([]params.SecretValueResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		data, err := u.getSecretValue(unit.ApplicationName(), arg)
+		results.Results[i].Data = data
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (u *UniterAPI) getSecretValue(appName string, arg params.GetSecretValueArg) (map[string]string, error) {
+	secret, err := u.m.Secret(arg.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !secret.CanRead(appName) {
+		return nil, common.ErrPerm
+	}
+	return u.m.SecretValue(arg.Name, arg.Revision)
+}
+
+// SetSecrets creates or updates secrets owned by the unit's
+// application. Only the leader of the application may set secrets.
+func (u *UniterAPI) SetSecrets(args params.SetSecretArgs) (params.ErrorResults, error) {
+	unit, err := u.secretsLeader(args.UnitTag)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := u.setSecret(unit.ApplicationName(), arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (u *UniterAPI) setSecret(appName string, arg params.SetSecretArg) error {
+	secret, err := u.m.Secret(arg.Name)
+	if errors.IsNotFound(err) {
+		addArgs := state.AddSecretArgs{
+			Name:  arg.Name,
+			Owner: appName,
+			Data:  arg.Data,
+		}
+		if arg.Description != nil {
+			addArgs.Description = *arg.Description
+		}
+		if arg.RotateInterval != nil {
+			addArgs.RotateInterval = *arg.RotateInterval
+		}
+		_, err := u.m.AddSecret(addArgs)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if secret.Owner() != appName {
+		return common.ErrPerm
+	}
+	return u.m.UpdateSecret(arg.Name, state.UpdateSecretArgs{
+		Description:    arg.Description,
+		Data:           arg.Data,
+		RotateInterval: arg.RotateInterval,
+	})
+}
+
+// SecretRotations returns when each of the secrets owned by the
+// application of each given unit is next due to be rotated.
+func (u *UniterAPI) SecretRotations(args params.Entities) (params.SecretRotationsResults, error) {
+	results := params.SecretRotationsResults{
+		Results: make([]params.SecretRotationsResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		rotations, err := u.secretRotations(entity.Tag)
+		results.Results[i].Rotations = rotations
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (u *UniterAPI) secretRotations(unitTag string) ([]params.SecretRotation, error) {
+	unit, err := u.secretsUnit(unitTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secrets, err := u.m.ApplicationSecrets(unit.ApplicationName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rotations []params.SecretRotation
+	for _, secret := range secrets {
+		if secret.RotateInterval() == 0 {
+			continue
+		}
+		rotations = append(rotations, params.SecretRotation{
+			Name:           secret.Name(),
+			RotateInterval: secret.RotateInterval(),
+			NextRotateTime: secret.NextRotateTime(),
+		})
+	}
+	return rotations, nil
+}
+
+// WatchSecretRotations returns a NotifyWatcher for each given unit
+// that triggers when the secrets in the model change, so that the
+// unit can reread the rotations of its application's secrets.
+func (u *UniterAPI) WatchSecretRotations(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		if _, err := u.secretsUnit(entity.Tag); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		w := u.m.WatchSecrets()
+		// Consume the initial event. Technically, API
+		// calls to Watch 'transmit' the initial event
+		// in the Watch response. But NotifyWatchers
+		// have no state to transmit.
+		if _, ok := <-w.Changes(); ok {
+			results.Results[i].NotifyWatcherId = u.resources.Register(w)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(w))
+		}
+	}
+	return results, nil
+}
+
+// SecretRotated records that the leader of the application that owns
+// each of the named secrets has been asked to rotate it.
+func (u *UniterAPI) SecretRotated(args params.SecretRotatedArgs) (params.ErrorResults, error) {
+	unit, err := u.secretsLeader(args.UnitTag)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		secret, err := u.m.Secret(name)
+		if err == nil && secret.Owner() != unit.ApplicationName() {
+			err = common.ErrPerm
+		}
+		if err == nil {
+			err = u.m.SecretRotated(name)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *uniterSuite) addSecret(c *gc.C, args state.AddSecretArgs) {
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m.AddSecret(args)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) claimWordpressLeadership(c *gc.C) {
+	err := s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *uniterSuite) TestGetSecretValues(c *gc.C) {
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "owned",
+		Owner: "wordpress",
+		Data:  map[string]string{"a": "b"},
+	})
+	s.addSecret(c, state.AddSecretArgs{
+		Name: "granted",
+		Data: map[string]string{"c": "d"},
+	})
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "private",
+		Owner: "mysql",
+		Data:  map[string]string{"e": "f"},
+	})
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.GrantSecret("granted", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.GetSecretValues(params.GetSecretValueArgs{
+		UnitTag: "unit-wordpress-0",
+		Args: []params.GetSecretValueArg{
+			{Name: "owned"},
+			{Name: "granted", Revision: 1},
+			{Name: "private"},
+			{Name: "missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretValueResults{
+		Results: []params.SecretValueResult{
+			{Data: map[string]string{"a": "b"}},
+			{Data: map[string]string{"c": "d"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: `secret "missing" not found`}},
+		},
+	})
+}
+
+func (s *uniterSuite) TestGetSecretValuesOtherUnit(c *gc.C) {
+	_, err := s.uniter.GetSecretValues(params.GetSecretValueArgs{UnitTag: "unit-mysql-0"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *uniterSuite) TestSetSecrets(c *gc.C) {
+	s.claimWordpressLeadership(c)
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "existing",
+		Owner: "wordpress",
+		Data:  map[string]string{"a": "b"},
+	})
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "private",
+		Owner: "mysql",
+		Data:  map[string]string{"e": "f"},
+	})
+
+	interval := time.Hour
+	result, err := s.uniter.SetSecrets(params.SetSecretArgs{
+		UnitTag: "unit-wordpress-0",
+		Args: []params.SetSecretArg{
+			{Name: "new", Data: map[string]string{"x": "y"}, RotateInterval: &interval},
+			{Name: "existing", Data: map[string]string{"a": "c"}},
+			{Name: "private", Data: map[string]string{"e": "g"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := m.Secret("new")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Owner(), gc.Equals, "wordpress")
+	c.Check(secret.RotateInterval(), gc.Equals, time.Hour)
+	value, err := m.SecretValue("existing", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"a": "c"})
+}
+
+func (s *uniterSuite) TestSetSecretsNotLeader(c *gc.C) {
+	_, err := s.uniter.SetSecrets(params.SetSecretArgs{
+		UnitTag: "unit-wordpress-0",
+		Args:    []params.SetSecretArg{{Name: "new", Data: map[string]string{"x": "y"}}},
+	})
+	c.Assert(err, gc.ErrorMatches, `"wordpress/0" is not leader of "wordpress"`)
+}
+
+func (s *uniterSuite) TestSecretRotations(c *gc.C) {
+	s.addSecret(c, state.AddSecretArgs{
+		Name:           "rotated",
+		Owner:          "wordpress",
+		Data:           map[string]string{"a": "b"},
+		RotateInterval: time.Hour,
+	})
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "static",
+		Owner: "wordpress",
+		Data:  map[string]string{"a": "b"},
+	})
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := m.Secret("rotated")
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.uniter.SecretRotations(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecretRotationsResults{
+		Results: []params.SecretRotationsResult{{
+			Rotations: []params.SecretRotation{{
+				Name:           "rotated",
+				RotateInterval: time.Hour,
+				NextRotateTime: secret.NextRotateTime(),
+			}},
+		}, {
+			Error: apiservertesting.ErrUnauthorized,
+		}},
+	})
+}
+
+func (s *uniterSuite) TestWatchSecretRotations(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.uniter.WatchSecretRotations(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+		},
+	})
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "owned",
+		Owner: "wordpress",
+		Data:  map[string]string{"a": "b"},
+	})
+	wc.AssertOneChange()
+}
+
+func (s *uniterSuite) TestSecretRotated(c *gc.C) {
+	s.claimWordpressLeadership(c)
+	s.addSecret(c, state.AddSecretArgs{
+		Name:           "rotated",
+		Owner:          "wordpress",
+		Data:           map[string]string{"a": "b"},
+		RotateInterval: time.Hour,
+	})
+	s.addSecret(c, state.AddSecretArgs{
+		Name:  "private",
+		Owner: "mysql",
+		Data:  map[string]string{"e": "f"},
+	})
+
+	result, err := s.uniter.SecretRotated(params.SecretRotatedArgs{
+		UnitTag: "unit-wordpress-0",
+		Names:   []string{"rotated", "private"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+}
@@ -35,7 +35,7 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v8) of the Uniter API.
+// UniterAPI implements the latest version (v10) of the Uniter API.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -64,9 +64,21 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV9 doesn't have the GetSecretValues, SetSecrets,
+// SecretRotations, WatchSecretRotations and SecretRotated methods.
+type UniterAPIV9 struct {
+	UniterAPI
+}
+
+// UniterAPIV8 doesn't have the CharmState and UpdateCharmState
+// methods.
+type UniterAPIV8 struct {
+	UniterAPIV9
+}
+
 // UniterAPIV7 adds CMR support to NetworkInfo.
 type UniterAPIV7 struct {
-	UniterAPI
+	UniterAPIV8
 }
 
 // UniterAPIV6 adds NetworkInfo as a preferred method to calling NetworkConfig.
@@ -253,14 +265,36 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV9 creates an instance of the V9 uniter API.
+func NewUniterAPIV9(context facade.Context) (*UniterAPIV9, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV9{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV8 creates an instance of the V8 uniter API.
+func NewUniterAPIV8(context facade.Context) (*UniterAPIV8, error) {
+	uniterAPI, err := NewUniterAPIV9(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV8{
+		UniterAPIV9: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV7 creates an instance of the V7 uniter API.
 func NewUniterAPIV7(context facade.Context) (*UniterAPIV7, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV8(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV7{
-		UniterAPI: *uniterAPI,
+		UniterAPIV8: *uniterAPI,
 	}, nil
 }
 
@@ -751,17 +785,12 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 		}
 		err = common.ErrPerm
 		if canAccess(tag) {
-			var unitOrApplication state.Entity
-			unitOrApplication, err = u.st.FindEntity(tag)
-			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
-				if curl != nil {
-					result.Results[i].Result = curl.String()
-					result.Results[i].Ok = ok
-				}
+			var curl *charm.URL
+			var ok bool
+			curl, ok, err = u.charmURL(tag)
+			if err == nil && curl != nil {
+				result.Results[i].Result = curl.String()
+				result.Results[i].Ok = ok
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -871,6 +900,76 @@ func (u *UniterAPI) SetWorkloadVersion(args params.EntityWorkloadVersions) (para
 	return result, nil
 }
 
+// CharmState returns the charm state of each given unit.
+func (u *UniterAPI) CharmState(args params.Entities) (params.CharmStateResults, error) {
+	result := params.CharmStateResults{
+		Results: make([]params.CharmStateResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.CharmStateResults{}, err
+	}
+	for i, entity := range args.Entities {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		charmState, err := unit.CharmState()
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		resultItem.Result = charmState
+	}
+	return result, nil
+}
+
+// UpdateCharmState sets and unsets keys of the charm state of each
+// given unit. An error will be returned if a unit is dead, or if its
+// charm state would exceed the size limits.
+func (u *UniterAPI) UpdateCharmState(args params.SetCharmStateArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.Args {
+		resultItem := &result.Results[i]
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		if !canAccess(tag) {
+			resultItem.Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		unit, err := u.getUnit(tag)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+			continue
+		}
+		err = unit.UpdateCharmState(arg.Set, arg.Unset)
+		if err != nil {
+			resultItem.Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
 // OpenPorts sets the policy of the port range with protocol to be
 // opened, for all given units.
 func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
@@ -2439,6 +2538,35 @@ func (u *UniterAPIV6) NetworkInfo(args params.NetworkInfoParams) (params.Network
 // SetPodSpec isn't on the v7 API.
 func (u *UniterAPIV7) SetPodSpec(_, _ struct{}) {}
 
+// Mask the charm state methods from the v8 API. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the methods as far as the RPC machinery is concerned.
+
+// CharmState isn't on the v8 API.
+func (u *UniterAPIV8) CharmState(_, _ struct{}) {}
+
+// UpdateCharmState isn't on the v8 API.
+func (u *UniterAPIV8) UpdateCharmState(_, _ struct{}) {}
+
+// Mask the secrets methods from the v9 API. The API reflection code
+// in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
+// this removes the methods as far as the RPC machinery is concerned.
+
+// GetSecretValues isn't on the v9 API.
+func (u *UniterAPIV9) GetSecretValues(_, _ struct{}) {}
+
+// SetSecrets isn't on the v9 API.
+func (u *UniterAPIV9) SetSecrets(_, _ struct{}) {}
+
+// SecretRotations isn't on the v9 API.
+func (u *UniterAPIV9) SecretRotations(_, _ struct{}) {}
+
+// WatchSecretRotations isn't on the v9 API.
+func (u *UniterAPIV9) WatchSecretRotations(_, _ struct{}) {}
+
+// SecretRotated isn't on the v9 API.
+func (u *UniterAPIV9) SecretRotated(_, _ struct{}) {}
+
 // SetPodSpec sets the pod specs for a set of applications.
 func (u *UniterAPI) SetPodSpec(args params.SetPodSpecParams) (params.ErrorResults, error) {
 	results := params.ErrorResults{
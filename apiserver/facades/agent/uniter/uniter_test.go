@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -912,6 +913,63 @@ func (s *uniterSuite) TestSetWorkloadVersion(c *gc.C) {
 	c.Assert(newVersion, gc.Equals, "shiro")
 }
 
+func (s *uniterSuite) TestCharmState(c *gc.C) {
+	err := s.wordpressUnit.UpdateCharmState(map[string]string{"foo": "bar"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+		{Tag: "application-wordpress"},
+	}}
+	result, err := s.uniter.CharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.CharmStateResults{
+		Results: []params.CharmStateResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: map[string]string{"foo": "bar"}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: common.ServerError(errors.New(`"application-wordpress" is not a valid unit tag`))},
+		},
+	})
+}
+
+func (s *uniterSuite) TestUpdateCharmState(c *gc.C) {
+	err := s.wordpressUnit.UpdateCharmState(map[string]string{"foo": "bar", "baz": "qux"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SetCharmStateArgs{Args: []params.SetCharmStateArg{
+		{Tag: "unit-mysql-0", Set: map[string]string{"a": "b"}},
+		{Tag: "unit-wordpress-0", Set: map[string]string{"foo": "quux"}, Unset: []string{"baz"}},
+		{Tag: "unit-foo-42", Set: map[string]string{"a": "b"}},
+	}}
+	result, err := s.uniter.UpdateCharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	charmState, err := s.wordpressUnit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "quux"})
+}
+
+func (s *uniterSuite) TestUpdateCharmStateTooLarge(c *gc.C) {
+	args := params.SetCharmStateArgs{Args: []params.SetCharmStateArg{{
+		Tag: "unit-wordpress-0",
+		Set: map[string]string{"foo": strings.Repeat("x", state.MaxCharmStateSize)},
+	}}}
+	result, err := s.uniter.UpdateCharmState(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `cannot update charm state for unit "wordpress/0": charm state of 65539 bytes exceeds the limit of 65536 bytes`)
+}
+
 func (s *uniterSuite) TestCharmModifiedVersion(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "application-mysql"},
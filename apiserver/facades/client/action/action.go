@@ -23,6 +23,29 @@ type ActionAPI struct {
 	check      *common.BlockChecker
 }
 
+// ActionAPIV2 implements version 2 of the Action API, which queues
+// actions without any parallelism limit or ordering.
+type ActionAPIV2 struct {
+	*ActionAPI
+}
+
+// NewActionAPIV2 returns an initialized ActionAPIV2.
+func NewActionAPIV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPIV2, error) {
+	api, err := NewActionAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionAPIV2{api}, nil
+}
+
+// Enqueue queues the actions, ignoring the parallelism and ordering
+// that version 2 clients don't know about.
+func (a *ActionAPIV2) Enqueue(arg params.Actions) (params.ActionResults, error) {
+	arg.Parallelism = 0
+	arg.Ordering = ""
+	return a.ActionAPI.Enqueue(arg)
+}
+
 // NewActionAPI returns an initialized ActionAPI
 func NewActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
@@ -184,8 +207,20 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 		return params.ActionResults{}, errors.Trace(err)
 	}
 
-	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
+	policy := state.ActionQueuePolicy{
+		Parallelism: arg.Parallelism,
+		Ordering:    state.ActionOrdering(arg.Ordering),
+	}
+	if err := policy.Validate(); err != nil {
+		return params.ActionResults{}, errors.Trace(err)
+	}
+
 	response := params.ActionResults{Results: make([]params.ActionResult, len(arg.Actions))}
+	if policy != (state.ActionQueuePolicy{}) {
+		a.enqueueWithPolicy(arg.Actions, policy, response.Results)
+		return response, nil
+	}
+	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
 	for i, action := range arg.Actions {
 		currentResult := &response.Results[i]
 		receiver, err := tagToActionReceiver(action.Receiver)
@@ -204,6 +239,56 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 	return response, nil
 }
 
+// actionQueueKey identifies the actions of a request that are queued
+// together: those with the same name on units of the same application.
+type actionQueueKey struct {
+	application string
+	name        string
+}
+
+// enqueueWithPolicy queues the actions on the units of each application
+// together, so that the policy is applied to each application.
+func (a *ActionAPI) enqueueWithPolicy(actions []params.Action, policy state.ActionQueuePolicy, results []params.ActionResult) {
+	tagToActionReceiver := common.TagToActionReceiverFn(a.state.FindEntity)
+	var keys []actionQueueKey
+	indices := make(map[actionQueueKey][]int)
+	receivers := make([]state.ActionReceiver, len(actions))
+	for i, action := range actions {
+		receiver, err := tagToActionReceiver(action.Receiver)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		receivers[i] = receiver
+		key := actionQueueKey{name: action.Name}
+		if unitTag, ok := receiver.Tag().(names.UnitTag); ok {
+			key.application, _ = names.UnitApplication(unitTag.Id())
+		}
+		if _, ok := indices[key]; !ok {
+			keys = append(keys, key)
+		}
+		indices[key] = append(indices[key], i)
+	}
+	for _, key := range keys {
+		args := make([]state.EnqueueActionArgs, len(indices[key]))
+		for j, i := range indices[key] {
+			args[j] = state.EnqueueActionArgs{
+				Receiver:   receivers[i],
+				Name:       actions[i].Name,
+				Parameters: actions[i].Parameters,
+			}
+		}
+		enqueued, err := a.model.EnqueueActions(args, policy)
+		for j, i := range indices[key] {
+			if err != nil {
+				results[i].Error = common.ServerError(err)
+				continue
+			}
+			results[i] = common.MakeActionResult(receivers[i].Tag(), enqueued[j])
+		}
+	}
+}
+
 // ListAll takes a list of Entities representing ActionReceivers and
 // returns all of the Actions that have been enqueued or run by each of
 // those Entities.
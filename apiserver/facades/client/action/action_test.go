@@ -19,6 +19,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/testing/factory"
 )
@@ -271,6 +272,65 @@ func (s *actionSuite) TestEnqueue(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *actionSuite) TestEnqueueWithParallelism(c *gc.C) {
+	wordpressUnit2 := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine0,
+	})
+	arg := params.Actions{
+		Actions: []params.Action{
+			{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: wordpressUnit2.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.mysqlUnit.Tag().String(), Name: "fakeaction"},
+			{Receiver: s.wordpress.Tag().String(), Name: "fakeaction"},
+		},
+		Parallelism: 1,
+	}
+	res, err := s.action.Enqueue(arg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 4)
+	for i, result := range res.Results[:3] {
+		c.Assert(result.Error, gc.IsNil)
+		c.Check(result.Action.Receiver, gc.Equals, arg.Actions[i].Receiver)
+	}
+	c.Check(res.Results[3].Error, gc.ErrorMatches, "action receiver interface on entity .* not implemented")
+
+	// The limit applies to the units of each application, so only the
+	// second wordpress unit's action is held.
+	for i, unit := range []*state.Unit{s.wordpressUnit, wordpressUnit2, s.mysqlUnit} {
+		w := unit.WatchActionNotifications()
+		wc := statetesting.NewStringsWatcherC(c, s.State, w)
+		if i == 1 {
+			wc.AssertChange()
+		} else {
+			tag, err := names.ParseActionTag(res.Results[i].Action.Tag)
+			c.Assert(err, jc.ErrorIsNil)
+			wc.AssertChange(tag.Id())
+		}
+		statetesting.AssertStop(c, w)
+	}
+}
+
+func (s *actionSuite) TestEnqueueInvalidOrdering(c *gc.C) {
+	_, err := s.action.Enqueue(params.Actions{
+		Actions:  []params.Action{{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"}},
+		Ordering: "random",
+	})
+	c.Assert(err, gc.ErrorMatches, `action ordering "random" not valid`)
+}
+
+func (s *actionSuite) TestEnqueueV2IgnoresPolicy(c *gc.C) {
+	api, err := action.NewActionAPIV2(s.State, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	res, err := api.Enqueue(params.Actions{
+		Actions:  []params.Action{{Receiver: s.wordpressUnit.Tag().String(), Name: "fakeaction"}},
+		Ordering: "random",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 1)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+}
+
 type testCaseAction struct {
 	Name       string
 	Parameters map[string]interface{}
@@ -0,0 +1,193 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionschedules provides the API facade for managing the
+// schedules that actions are periodically queued on.
+package actionschedules
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods needed by the ActionSchedules
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddActionSchedule(state.AddActionScheduleArgs) error
+	AllActionSchedules() ([]ActionSchedule, error)
+	RemoveActionSchedule(name string) error
+}
+
+// ActionSchedule describes an action schedule, as provided by
+// *state.ActionSchedule.
+type ActionSchedule interface {
+	Name() string
+	Schedule() string
+	Units() []string
+	Leaders() []string
+	Applications() []string
+	ActionName() string
+	Parameters() map[string]interface{}
+	Created() time.Time
+	NextRun() time.Time
+	Runs() []state.ActionScheduleRun
+}
+
+// API implements the ActionSchedules facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      *common.BlockChecker
+}
+
+// NewAPI returns a new ActionSchedules facade for the model.
+func NewAPI(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newAPI(stateBackend{m}, st, ctx.Auth())
+}
+
+func newAPI(backend Backend, blocks common.BlockGetter, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      common.NewBlockChecker(blocks),
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddSchedules adds schedules that queue actions on units of the
+// model. Adding a schedule needs the same access as queueing the
+// action.
+func (api *API) AddSchedules(args params.AddActionSchedules) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Schedules)),
+	}
+	for i, arg := range args.Schedules {
+		err := api.backend.AddActionSchedule(state.AddActionScheduleArgs{
+			Name:         arg.Name,
+			Schedule:     arg.Schedule,
+			Units:        arg.Units,
+			Leaders:      arg.Leaders,
+			Applications: arg.Applications,
+			ActionName:   arg.ActionName,
+			Parameters:   arg.Parameters,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListSchedules returns all of the action schedules in the model,
+// with their recent runs.
+func (api *API) ListSchedules() (params.ActionSchedules, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	schedules, err := api.backend.AllActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{
+		Schedules: make([]params.ActionSchedule, len(schedules)),
+	}
+	for i, schedule := range schedules {
+		result.Schedules[i] = toParams(schedule)
+	}
+	return result, nil
+}
+
+func toParams(schedule ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Name:         schedule.Name(),
+		Schedule:     schedule.Schedule(),
+		Units:        schedule.Units(),
+		Leaders:      schedule.Leaders(),
+		Applications: schedule.Applications(),
+		ActionName:   schedule.ActionName(),
+		Parameters:   schedule.Parameters(),
+		Created:      schedule.Created(),
+	}
+	if next := schedule.NextRun(); !next.IsZero() {
+		result.NextRun = &next
+	}
+	for _, run := range schedule.Runs() {
+		result.Runs = append(result.Runs, params.ActionScheduleRun{
+			Time:      run.Time,
+			ActionIds: run.ActionIds,
+			Errors:    run.Errors,
+		})
+	}
+	return result
+}
+
+// RemoveSchedules removes the named action schedules. Actions already
+// queued by them are not affected.
+func (api *API) RemoveSchedules(args params.ActionScheduleNames) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	for i, name := range args.Names {
+		results.Results[i].Error = common.ServerError(api.backend.RemoveActionSchedule(name))
+	}
+	return results, nil
+}
+
+// stateBackend adapts *state.Model to Backend.
+type stateBackend struct {
+	*state.Model
+}
+
+func (b stateBackend) AddActionSchedule(args state.AddActionScheduleArgs) error {
+	_, err := b.Model.AddActionSchedule(args)
+	return err
+}
+
+func (b stateBackend) AllActionSchedules() ([]ActionSchedule, error) {
+	schedules, err := b.Model.AllActionSchedules()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ActionSchedule, len(schedules))
+	for i, schedule := range schedules {
+		result[i] = schedule
+	}
+	return result, nil
+}
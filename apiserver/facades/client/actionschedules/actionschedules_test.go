@@ -0,0 +1,225 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionschedules_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/actionschedules"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type actionSchedulesSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&actionSchedulesSuite{})
+
+func (s *actionSchedulesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+}
+
+func (s *actionSchedulesSuite) newAPI(c *gc.C) *actionschedules.API {
+	api, err := actionschedules.NewAPIForTest(s.backend, s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *actionSchedulesSuite) TestNonClientDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := actionschedules.NewAPIForTest(s.backend, s.backend, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionSchedulesSuite) TestAddSchedules(c *gc.C) {
+	s.backend.SetErrors(nil, nil, nil, errors.AlreadyExistsf(`action schedule "hourly"`))
+	results, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{
+		Schedules: []params.AddActionSchedule{{
+			Name:       "nightly",
+			Schedule:   "0 2 * * *",
+			Leaders:    []string{"postgresql"},
+			ActionName: "backup",
+			Parameters: map[string]interface{}{"compress": true},
+		}, {
+			Name:       "hourly",
+			Schedule:   "@hourly",
+			Units:      []string{"postgresql/0"},
+			ActionName: "vacuum",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `action schedule "hourly" already exists`)
+	s.backend.CheckCallNames(c, "ModelTag", "GetBlockForType", "AddActionSchedule", "AddActionSchedule")
+	s.backend.CheckCall(c, 2, "AddActionSchedule", state.AddActionScheduleArgs{
+		Name:       "nightly",
+		Schedule:   "0 2 * * *",
+		Leaders:    []string{"postgresql"},
+		ActionName: "backup",
+		Parameters: map[string]interface{}{"compress": true},
+	})
+}
+
+func (s *actionSchedulesSuite) TestAddSchedulesNeedsWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionSchedulesSuite) TestAddSchedulesBlocked(c *gc.C) {
+	s.backend.blocked = true
+	_, err := s.newAPI(c).AddSchedules(params.AddActionSchedules{})
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+}
+
+func (s *actionSchedulesSuite) TestListSchedules(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	next := time.Date(2018, 10, 2, 2, 0, 0, 0, time.UTC)
+	s.backend.schedules = []actionschedules.ActionSchedule{
+		&fakeSchedule{
+			name:     "nightly",
+			schedule: "0 2 * * *",
+			leaders:  []string{"postgresql"},
+			action:   "backup",
+			created:  created,
+			next:     next,
+			runs: []state.ActionScheduleRun{{
+				Time:      created,
+				ActionIds: []string{"1"},
+				Errors:    []string{"boom"},
+			}},
+		},
+		&fakeSchedule{
+			name:     "never",
+			schedule: "0 0 30 2 *",
+			units:    []string{"postgresql/0"},
+			action:   "vacuum",
+			created:  created,
+		},
+	}
+	result, err := s.newAPI(c).ListSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ActionSchedules{
+		Schedules: []params.ActionSchedule{{
+			Name:       "nightly",
+			Schedule:   "0 2 * * *",
+			Leaders:    []string{"postgresql"},
+			ActionName: "backup",
+			Created:    created,
+			NextRun:    &next,
+			Runs: []params.ActionScheduleRun{{
+				Time:      created,
+				ActionIds: []string{"1"},
+				Errors:    []string{"boom"},
+			}},
+		}, {
+			Name:       "never",
+			Schedule:   "0 0 30 2 *",
+			Units:      []string{"postgresql/0"},
+			ActionName: "vacuum",
+			Created:    created,
+		}},
+	})
+}
+
+func (s *actionSchedulesSuite) TestListSchedulesNeedsRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).ListSchedules()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionSchedulesSuite) TestRemoveSchedules(c *gc.C) {
+	s.backend.SetErrors(nil, nil, nil, nil, errors.NotFoundf(`action schedule "missing"`))
+	results, err := s.newAPI(c).RemoveSchedules(params.ActionScheduleNames{
+		Names: []string{"nightly", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	s.backend.CheckCallNames(c,
+		"ModelTag", "GetBlockForType", "GetBlockForType", "RemoveActionSchedule", "RemoveActionSchedule")
+	s.backend.CheckCall(c, 3, "RemoveActionSchedule", "nightly")
+}
+
+type fakeBackend struct {
+	testing.Stub
+	schedules []actionschedules.ActionSchedule
+	blocked   bool
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	b.MethodCall(b, "GetBlockForType", t)
+	b.PopNoErr()
+	if b.blocked {
+		return fakeBlock{}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (b *fakeBackend) AddActionSchedule(args state.AddActionScheduleArgs) error {
+	b.MethodCall(b, "AddActionSchedule", args)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) AllActionSchedules() ([]actionschedules.ActionSchedule, error) {
+	b.MethodCall(b, "AllActionSchedules")
+	return b.schedules, b.NextErr()
+}
+
+func (b *fakeBackend) RemoveActionSchedule(name string) error {
+	b.MethodCall(b, "RemoveActionSchedule", name)
+	return b.NextErr()
+}
+
+type fakeBlock struct {
+	state.Block
+}
+
+func (fakeBlock) Message() string {
+	return "no changes"
+}
+
+type fakeSchedule struct {
+	name, schedule, action string
+	units, leaders, apps   []string
+	params                 map[string]interface{}
+	created, next          time.Time
+	runs                   []state.ActionScheduleRun
+}
+
+func (s *fakeSchedule) Name() string                       { return s.name }
+func (s *fakeSchedule) Schedule() string                   { return s.schedule }
+func (s *fakeSchedule) Units() []string                    { return s.units }
+func (s *fakeSchedule) Leaders() []string                  { return s.leaders }
+func (s *fakeSchedule) Applications() []string             { return s.apps }
+func (s *fakeSchedule) ActionName() string                 { return s.action }
+func (s *fakeSchedule) Parameters() map[string]interface{} { return s.params }
+func (s *fakeSchedule) Created() time.Time                 { return s.created }
+func (s *fakeSchedule) NextRun() time.Time                 { return s.next }
+func (s *fakeSchedule) Runs() []state.ActionScheduleRun    { return s.runs }
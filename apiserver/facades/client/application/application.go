@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
@@ -63,6 +64,21 @@ type APIv7 struct {
 
 // APIv8 provides the Application API facade for version 8.
 type APIv8 struct {
+	*APIv9
+}
+
+// APIv9 provides the Application API facade for version 9.
+type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
 	*APIBase
 }
 
@@ -89,6 +105,7 @@ type APIBase struct {
 	storagePoolManager    poolmanager.PoolManager
 	deployApplicationFunc func(ApplicationDeployer, DeployApplicationParams) (Application, error)
 	getEnviron            stateenvirons.NewEnvironFunc
+	leadership            leadership.Transferrer
 }
 
 // NewFacadeV4 provides the signature required for facade registration
@@ -131,14 +148,46 @@ func NewFacadeV7(ctx facade.Context) (*APIv7, error) {
 	return &APIv7{api}, nil
 }
 
+// NewFacadeV8 provides the signature required for facade registration
+// for version 8.
 func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV9(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
+func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
+	api, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv9{api}, nil
+}
+
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
+// NewFacadeV11 provides the signature required for facade registration
+// for version 11.
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -150,6 +199,10 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	}
 	blockChecker := common.NewBlockChecker(ctx.State())
 	stateCharm := CharmToStateCharm
+	leadershipTransferrer, err := ctx.LeadershipTransferrer()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting leadership transferrer")
+	}
 
 	var storagePoolManager poolmanager.PoolManager
 	if model.Type() == state.ModelTypeCAAS {
@@ -171,6 +224,7 @@ func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 		stateCharm,
 		DeployApplication,
 		storagePoolManager,
+		leadershipTransferrer,
 	)
 }
 
@@ -185,6 +239,7 @@ func NewAPIBase(
 	stateCharm func(Charm) *state.Charm,
 	deployApplication func(ApplicationDeployer, DeployApplicationParams) (Application, error),
 	storagePoolManager poolmanager.PoolManager,
+	leadershipTransferrer leadership.Transferrer,
 ) (*APIBase, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -199,6 +254,7 @@ func NewAPIBase(
 		stateCharm:            stateCharm,
 		deployApplicationFunc: deployApplication,
 		storagePoolManager:    storagePoolManager,
+		leadership:            leadershipTransferrer,
 	}, nil
 }
 
@@ -457,6 +513,9 @@ func deployApplication(
 	if err := checkMinVersion(ch); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkLXDProfile(ch); err != nil {
+		return errors.Trace(err)
+	}
 
 	appConfigAttrs, charmConfig, err := splitApplicationAndCharmConfig(modelType, args.Config)
 	if err != nil {
@@ -604,6 +663,7 @@ func (api *APIBase) Update(args params.ApplicationUpdate) error {
 			args.ForceCharmURL,
 			nil, // resource IDs
 			nil, // storage constraints
+			nil, // rolling upgrade
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -704,6 +764,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.RollingUpgrade,
 	)
 }
 
@@ -761,6 +822,7 @@ func (api *APIBase) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	rollingUpgrade *params.RollingUpgradeParams,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -770,6 +832,9 @@ func (api *APIBase) applicationSetCharm(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkLXDProfile(sch); err != nil {
+		return errors.Trace(err)
+	}
 	var settings charm.Settings
 	if configSettingsYAML != "" {
 		settings, err = sch.Config().ParseSettingsYAML([]byte(configSettingsYAML), appName)
@@ -802,6 +867,13 @@ func (api *APIBase) applicationSetCharm(
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
 	}
+	if rollingUpgrade != nil {
+		cfg.RollingUpgrade = &state.RollingUpgradeArgs{
+			BatchSize:    rollingUpgrade.BatchSize,
+			BatchTimeout: rollingUpgrade.BatchTimeout,
+			OnError:      state.RollingUpgradeOnError(rollingUpgrade.OnError),
+		}
+	}
 	return application.SetCharm(cfg)
 }
 
@@ -1369,6 +1441,9 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if app.AutoscalePolicy() != nil {
+			return nil, errors.Errorf("application %q is autoscaled, remove its autoscale policy first", name)
+		}
 		var info params.ScaleApplicationInfo
 		if err := app.Scale(arg.Scale); err != nil {
 			return nil, errors.Trace(err)
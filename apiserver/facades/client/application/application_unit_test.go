@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -41,8 +43,9 @@ type ApplicationSuite struct {
 
 	env          environs.Environ
 	blockChecker mockBlockChecker
+	leadership   mockLeadershipTransferrer
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv8
+	api          *application.APIv11
 }
 
 var _ = gc.Suite(&ApplicationSuite{})
@@ -64,9 +67,10 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 			return nil, nil
 		},
 		s.storagePoolManager,
+		&s.leadership,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv8{api}
+	s.api = &application.APIv11{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -168,6 +172,7 @@ func (s *ApplicationSuite) SetUpTest(c *gc.C) {
 		},
 	}
 	s.blockChecker = mockBlockChecker{}
+	s.leadership = mockLeadershipTransferrer{}
 	s.setAPIUser(c, names.NewUserTag("admin"))
 }
 
@@ -205,6 +210,83 @@ func (s *ApplicationSuite) TestSetCharmStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmInvalidLXDProfile(c *gc.C) {
+	s.backend.charm.lxdProfile = &charm.LXDProfile{
+		Config: map[string]string{"raw.lxc": "lxc.aa_profile=unconfined"},
+	}
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid lxd-profile.yaml: contains config value "raw.lxc"`)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetCharmRollingUpgrade(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		RollingUpgrade: &params.RollingUpgradeParams{
+			BatchSize:    2,
+			BatchTimeout: 10 * time.Minute,
+			OnError:      "abort",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm: &state.Charm{},
+		RollingUpgrade: &state.RollingUpgradeArgs{
+			BatchSize:    2,
+			BatchTimeout: 10 * time.Minute,
+			OnError:      state.RollingUpgradeAbort,
+		},
+	})
+}
+
+func (s *ApplicationSuite) TestResumeRollingUpgrades(c *gc.C) {
+	results, err := s.api.ResumeRollingUpgrades(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+		{Tag: "application-missing"},
+		{Tag: "unit-postgresql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckCallNames(c, "ResumeRollingUpgrade")
+}
+
+func (s *ApplicationSuite) TestAbortRollingUpgrades(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.SetErrors(errors.New(`rolling upgrade of "postgresql" is aborted`))
+	results, err := s.api.AbortRollingUpgrades(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, `rolling upgrade of "postgresql" is aborted`)
+	app.CheckCallNames(c, "AbortRollingUpgrade")
+}
+
+func (s *ApplicationSuite) TestBlockAbortRollingUpgrades(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.AbortRollingUpgrades(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAbortRollingUpgradesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.AbortRollingUpgrades(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -620,7 +702,76 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "Scale", 5)
+	app.CheckCallNames(c, "AutoscalePolicy", "Scale")
+	app.CheckCall(c, 1, "Scale", 5)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsAutoscaled(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	app := s.backend.applications["postgresql"]
+	app.autoscale = &coreapplication.AutoscalePolicy{MinUnits: 1, MaxUnits: 3, TargetCPUPercent: 80}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `application "postgresql" is autoscaled, remove its autoscale policy first`)
+	app.CheckCallNames(c, "AutoscalePolicy")
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePolicies(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	results, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesParams{
+		Applications: []params.SetAutoscalePolicyParams{{
+			ApplicationTag: "application-postgresql",
+			Policy: params.AutoscalePolicy{
+				MinUnits:     2,
+				MaxUnits:     4,
+				Metric:       "requests",
+				MetricTarget: "100",
+			},
+		}, {
+			ApplicationTag: "application-missing",
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "SetAutoscalePolicy", coreapplication.AutoscalePolicy{
+		MinUnits:     2,
+		MaxUnits:     4,
+		Metric:       "requests",
+		MetricTarget: "100",
+	})
+}
+
+func (s *ApplicationSuite) TestSetAutoscalePoliciesIAASModel(c *gc.C) {
+	_, err := s.api.SetAutoscalePolicies(params.SetAutoscalePoliciesParams{})
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications on a non-container model not supported")
+}
+
+func (s *ApplicationSuite) TestRemoveAutoscalePolicies(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	results, err := s.api.RemoveAutoscalePolicies(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCallNames(c, "RemoveAutoscalePolicy")
+}
+
+func (s *ApplicationSuite) TestBlockRemoveAutoscalePolicies(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.RemoveAutoscalePolicies(params.Entities{Entities: []params.Entity{
+		{Tag: "application-postgresql"},
+	}})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsIAASModel(c *gc.C) {
@@ -1291,3 +1442,63 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
+
+func (s *ApplicationSuite) TestTransferLeadership(c *gc.C) {
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.leadership.CheckCalls(c, []testing.StubCall{
+		{"TransferLeadership", []interface{}{"postgresql", "postgresql/1", time.Minute}},
+	})
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipErrors(c *gc.C) {
+	s.backend.applications["postgresql"].units[1].life = state.Dying
+	s.leadership.SetErrors(errors.NotFoundf(`leader of application "postgresql"`))
+	results, err := s.api.TransferLeadership(params.TransferLeadershipArgs{
+		Args: []params.TransferLeadershipArg{{
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-subordinate-0",
+		}, {
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-1",
+		}, {
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "unit-postgresql-0",
+		}, {
+			ApplicationTag: "application-postgresql",
+			UnitTag:        "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `unit "postgresql-subordinate/0" of application "postgresql" not valid`)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `unit "postgresql/1" is not alive`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches,
+		`transferring leadership of "postgresql" to "postgresql/0": leader of application "postgresql" not found`)
+	c.Check(results.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"application-postgresql" is not a valid unit tag`)
+	s.leadership.CheckCallNames(c, "TransferLeadership")
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.TransferLeadership(params.TransferLeadershipArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.leadership.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestTransferLeadershipPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.TransferLeadership(params.TransferLeadershipArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.leadership.CheckNoCalls(c)
+}
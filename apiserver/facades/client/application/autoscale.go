@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+)
+
+// SetAutoscalePolicies isn't on the V9 API.
+func (u *APIv9) SetAutoscalePolicies(_, _ struct{}) {}
+
+// RemoveAutoscalePolicies isn't on the V9 API.
+func (u *APIv9) RemoveAutoscalePolicies(_, _ struct{}) {}
+
+// SetAutoscalePolicies hands the scaling of the given applications
+// over to the substrate, according to the specified policies.
+func (api *APIBase) SetAutoscalePolicies(args params.SetAutoscalePoliciesParams) (params.ErrorResults, error) {
+	if err := api.checkCanAutoscale(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Applications)),
+	}
+	for i, arg := range args.Applications {
+		err := api.updateAutoscalePolicy(arg.ApplicationTag, func(app Application) error {
+			return app.SetAutoscalePolicy(application.AutoscalePolicy{
+				MinUnits:         arg.Policy.MinUnits,
+				MaxUnits:         arg.Policy.MaxUnits,
+				TargetCPUPercent: arg.Policy.TargetCPUPercent,
+				Metric:           arg.Policy.Metric,
+				MetricTarget:     arg.Policy.MetricTarget,
+			})
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveAutoscalePolicies returns the scaling of the given
+// applications to the operator.
+func (api *APIBase) RemoveAutoscalePolicies(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkCanAutoscale(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := api.updateAutoscalePolicy(entity.Tag, Application.RemoveAutoscalePolicy)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) checkCanAutoscale() error {
+	if api.modelType != state.ModelTypeCAAS {
+		return errors.NotSupportedf("autoscaling applications on a non-container model")
+	}
+	if err := api.checkCanWrite(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.check.ChangeAllowed())
+}
+
+func (api *APIBase) updateAutoscalePolicy(appTag string, update func(Application) error) error {
+	tag, err := names.ParseApplicationTag(appTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return update(app)
+}
@@ -81,6 +81,11 @@ type Application interface {
 	ApplicationConfig() (application.ConfigAttributes, error)
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
 	Scale(int) error
+	AutoscalePolicy() *application.AutoscalePolicy
+	SetAutoscalePolicy(application.AutoscalePolicy) error
+	RemoveAutoscalePolicy() error
+	ResumeRollingUpgrade() error
+	AbortRollingUpgrade() error
 }
 
 // Charm defines a subset of the functionality provided by the
@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -180,6 +181,15 @@ func checkMinVersion(ch charm.Charm) error {
 	return nil
 }
 
+// checkLXDProfile returns an error if the charm supplies an LXD profile
+// that juju does not allow.
+func checkLXDProfile(ch charm.Charm) error {
+	if profiler, ok := ch.(charm.LXDProfiler); ok {
+		return errors.Trace(lxdprofile.Validate(profiler.LXDProfile()))
+	}
+	return nil
+}
+
 type minJujuVersionErr struct {
 	*errors.Err
 }
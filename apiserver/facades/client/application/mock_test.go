@@ -7,6 +7,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -53,14 +54,19 @@ type mockCharm struct {
 	jtesting.Stub
 
 	charm.Charm
-	config *charm.Config
-	meta   *charm.Meta
+	config     *charm.Config
+	meta       *charm.Meta
+	lxdProfile *charm.LXDProfile
 }
 
 func (m *mockCharm) Meta() *charm.Meta {
 	return m.meta
 }
 
+func (m *mockCharm) LXDProfile() *charm.LXDProfile {
+	return m.lxdProfile
+}
+
 func (c *mockCharm) Config() *charm.Config {
 	c.MethodCall(c, "Config")
 	c.PopNoErr()
@@ -81,6 +87,7 @@ type mockApplication struct {
 	units       []*mockUnit
 	addedUnit   mockUnit
 	config      coreapplication.ConfigAttributes
+	autoscale   *coreapplication.AutoscalePolicy
 }
 
 func (m *mockApplication) Name() string {
@@ -141,6 +148,32 @@ func (a *mockApplication) Scale(scale int) error {
 	return nil
 }
 
+func (a *mockApplication) AutoscalePolicy() *coreapplication.AutoscalePolicy {
+	a.MethodCall(a, "AutoscalePolicy")
+	a.PopNoErr()
+	return a.autoscale
+}
+
+func (a *mockApplication) SetAutoscalePolicy(policy coreapplication.AutoscalePolicy) error {
+	a.MethodCall(a, "SetAutoscalePolicy", policy)
+	return a.NextErr()
+}
+
+func (a *mockApplication) RemoveAutoscalePolicy() error {
+	a.MethodCall(a, "RemoveAutoscalePolicy")
+	return a.NextErr()
+}
+
+func (a *mockApplication) ResumeRollingUpgrade() error {
+	a.MethodCall(a, "ResumeRollingUpgrade")
+	return a.NextErr()
+}
+
+func (a *mockApplication) AbortRollingUpgrade() error {
+	a.MethodCall(a, "AbortRollingUpgrade")
+	return a.NextErr()
+}
+
 func (a *mockApplication) IsPrincipal() bool {
 	a.MethodCall(a, "IsPrincipal")
 	a.PopNoErr()
@@ -484,6 +517,15 @@ func (m *mockBackend) SaveController(controllerInfo crossmodel.ControllerInfo, m
 	return &mockExternalController{controllerInfo.ControllerTag.Id(), controllerInfo}, nil
 }
 
+type mockLeadershipTransferrer struct {
+	jtesting.Stub
+}
+
+func (t *mockLeadershipTransferrer) TransferLeadership(applicationId, unitId string, duration time.Duration) error {
+	t.MethodCall(t, "TransferLeadership", applicationId, unitId, duration)
+	return t.NextErr()
+}
+
 type mockBlockChecker struct {
 	jtesting.Stub
 }
@@ -545,13 +587,20 @@ func (r *mockRelation) Destroy() error {
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
-	tag names.UnitTag
+	tag  names.UnitTag
+	life state.Life
 }
 
 func (u *mockUnit) UnitTag() names.UnitTag {
 	return u.tag
 }
 
+func (u *mockUnit) Life() state.Life {
+	u.MethodCall(u, "Life")
+	u.PopNoErr()
+	return u.life
+}
+
 func (u *mockUnit) IsPrincipal() bool {
 	u.MethodCall(u, "IsPrincipal")
 	u.PopNoErr()
@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ResumeRollingUpgrades isn't on the V8 API.
+func (u *APIv8) ResumeRollingUpgrades(_, _ struct{}) {}
+
+// AbortRollingUpgrades isn't on the V8 API.
+func (u *APIv8) AbortRollingUpgrades(_, _ struct{}) {}
+
+// ResumeRollingUpgrades resumes the paused rolling charm upgrades of
+// the given applications.
+func (api *APIBase) ResumeRollingUpgrades(args params.Entities) (params.ErrorResults, error) {
+	return api.updateRollingUpgrades(args, Application.ResumeRollingUpgrade)
+}
+
+// AbortRollingUpgrades aborts the rolling charm upgrades of the given
+// applications. Units that have not been released keep running their
+// current charm.
+func (api *APIBase) AbortRollingUpgrades(args params.Entities) (params.ErrorResults, error) {
+	return api.updateRollingUpgrades(args, Application.AbortRollingUpgrade)
+}
+
+func (api *APIBase) updateRollingUpgrades(
+	args params.Entities, update func(Application) error,
+) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := api.updateRollingUpgrade(entity.Tag, update)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) updateRollingUpgrade(appTag string, update func(Application) error) error {
+	tag, err := names.ParseApplicationTag(appTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return update(app)
+}
@@ -0,0 +1,73 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// transferLeadershipDuration is the time for which a unit is guaranteed
+// to hold leadership handed to it, once the previous leader's term has
+// run out. It matches the lease duration requested by unit agents, so
+// the new leader has time to notice and extend its claim.
+const transferLeadershipDuration = time.Minute
+
+// TransferLeadership isn't on the V10 API.
+func (u *APIv10) TransferLeadership(_, _ struct{}) {}
+
+// TransferLeadership hands the leadership of each given application to
+// the given unit. The unit must be an alive unit of the application.
+func (api *APIBase) TransferLeadership(args params.TransferLeadershipArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.transferLeadership(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) transferLeadership(arg params.TransferLeadershipArg) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitTag, err := names.ParseUnitTag(arg.UnitTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if appName != appTag.Id() {
+		return errors.NotValidf("unit %q of application %q", unitTag.Id(), appTag.Id())
+	}
+	if _, err := api.backend.Application(appTag.Id()); err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(unitTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if unit.Life() != state.Alive {
+		return errors.Errorf("unit %q is not alive", unitTag.Id())
+	}
+	err = api.leadership.TransferLeadership(appTag.Id(), unitTag.Id(), transferLeadershipDuration)
+	return errors.Annotatef(err, "transferring leadership of %q to %q", appTag.Id(), unitTag.Id())
+}
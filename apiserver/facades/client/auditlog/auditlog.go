@@ -0,0 +1,118 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides the API facade for searching the audit
+// records held by the controller's database audit log backend.
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/permission"
+)
+
+// Backend defines the state methods needed by the AuditLog facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	ControllerConfig() (controller.Config, error)
+	QueryAuditLog(coreauditlog.Query) ([]coreauditlog.Entry, error)
+}
+
+// API implements the AuditLog facade.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new AuditLog facade for the controller.
+func NewAPI(ctx facade.Context) (*API, error) {
+	return newAPI(ctx.StatePool().SystemState(), ctx.Auth())
+}
+
+func newAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	// The audit log covers every model, so only controller
+	// superusers can read it.
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// Query returns the audit log entries matching the query, most recent
+// first. It fails if the controller isn't recording audit records in
+// the database.
+func (api *API) Query(args params.AuditLogQuery) (params.AuditLogEntries, error) {
+	var result params.AuditLogEntries
+	cfg, err := api.backend.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !cfg.AuditLogBackends().Contains(controller.AuditLogBackendDatabase) {
+		return result, errors.NotSupportedf(
+			"querying the audit log without the %q backend", controller.AuditLogBackendDatabase,
+		)
+	}
+	query := coreauditlog.Query{
+		User:   args.User,
+		Model:  args.Model,
+		Facade: args.Facade,
+		Method: args.Method,
+		Limit:  args.Limit,
+	}
+	if args.From != nil {
+		query.From = *args.From
+	}
+	if args.To != nil {
+		query.To = *args.To
+	}
+	entries, err := api.backend.QueryAuditLog(query)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Entries = make([]params.AuditLogEntry, len(entries))
+	for i, entry := range entries {
+		result.Entries[i] = entryToParams(entry)
+	}
+	return result, nil
+}
+
+func entryToParams(entry coreauditlog.Entry) params.AuditLogEntry {
+	// The request times were written by the recorder, so they are
+	// always well formed.
+	when, _ := time.Parse(time.RFC3339, entry.Request.When)
+	result := params.AuditLogEntry{
+		ConversationID: entry.Conversation.ConversationID,
+		ConnectionID:   entry.Conversation.ConnectionID,
+		RequestID:      entry.Request.RequestID,
+		Who:            entry.Conversation.Who,
+		What:           entry.Conversation.What,
+		ModelName:      entry.Conversation.ModelName,
+		ModelUUID:      entry.Conversation.ModelUUID,
+		When:           when,
+		Facade:         entry.Request.Facade,
+		Method:         entry.Request.Method,
+		Version:        entry.Request.Version,
+		Args:           entry.Request.Args,
+	}
+	for _, e := range entry.Errors {
+		result.Errors = append(result.Errors, params.AuditLogErrorInfo{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
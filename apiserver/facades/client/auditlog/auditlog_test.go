@@ -0,0 +1,161 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	coreauditlog "github.com/juju/juju/core/auditlog"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		config: controller.Config{
+			controller.AuditLogBackends: []interface{}{"file", "database"},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser"),
+	}
+}
+
+func (s *auditLogSuite) newAPI(c *gc.C) *auditlog.API {
+	api, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *auditLogSuite) TestNonClientDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestNonSuperuserDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := auditlog.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	from := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	s.backend.entries = []coreauditlog.Entry{{
+		Conversation: coreauditlog.Conversation{
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			When:           "2018-05-01T10:00:00Z",
+			ModelName:      "admin/default",
+			ModelUUID:      coretesting.ModelTag.Id(),
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+		},
+		Request: coreauditlog.Request{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      3,
+			When:           "2018-05-01T10:00:01Z",
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        8,
+		},
+		Errors: []*coreauditlog.Error{{Message: "blocked", Code: "operation is blocked"}},
+	}}
+
+	result, err := s.newAPI(c).Query(params.AuditLogQuery{
+		User:   "bob",
+		Model:  "admin/default",
+		Facade: "Application",
+		Method: "DestroyApplication",
+		From:   &from,
+		To:     &to,
+		Limit:  5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogEntries{
+		Entries: []params.AuditLogEntry{{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      3,
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			ModelName:      "admin/default",
+			ModelUUID:      coretesting.ModelTag.Id(),
+			When:           time.Date(2018, 5, 1, 10, 0, 1, 0, time.UTC),
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        8,
+			Errors: []params.AuditLogErrorInfo{
+				{Message: "blocked", Code: "operation is blocked"},
+			},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"ControllerConfig", nil},
+		{"QueryAuditLog", []interface{}{coreauditlog.Query{
+			User:   "bob",
+			Model:  "admin/default",
+			Facade: "Application",
+			Method: "DestroyApplication",
+			From:   from,
+			To:     to,
+			Limit:  5,
+		}}},
+	})
+}
+
+func (s *auditLogSuite) TestQueryDatabaseBackendDisabled(c *gc.C) {
+	s.backend.config = controller.Config{}
+	_, err := s.newAPI(c).Query(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, `querying the audit log without the "database" backend not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	s.backend.stub.CheckCallNames(c, "ControllerConfig")
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.New("boom"))
+	_, err := s.newAPI(c).Query(params.AuditLogQuery{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeBackend struct {
+	stub    testing.Stub
+	config  controller.Config
+	entries []coreauditlog.Entry
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.stub.AddCall("ControllerConfig")
+	return b.config, b.stub.NextErr()
+}
+
+func (b *fakeBackend) QueryAuditLog(q coreauditlog.Query) ([]coreauditlog.Entry, error) {
+	b.stub.AddCall("QueryAuditLog", q)
+	return b.entries, b.stub.NextErr()
+}
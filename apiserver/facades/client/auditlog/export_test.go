@@ -0,0 +1,12 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/juju/apiserver/facade"
+)
+
+func NewAPIForTest(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(backend, authorizer)
+}
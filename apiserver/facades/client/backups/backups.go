@@ -6,6 +6,7 @@ package backups
 import (
 	"io"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -45,6 +46,8 @@ type API struct {
 
 	// machineID is the ID of the machine where the API server is running.
 	machineID string
+
+	clock clock.Clock
 }
 
 // APIv2 serves backup-specific API methods for version 2.
@@ -52,6 +55,12 @@ type APIv2 struct {
 	*API
 }
 
+// APIv3 serves backup-specific API methods for version 3, which adds
+// ScheduleInfo.
+type APIv3 struct {
+	*APIv2
+}
+
 func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	api, err := NewAPI(backend, resources, authorizer)
 	if err != nil {
@@ -60,6 +69,14 @@ func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Aut
 	return &APIv2{api}, nil
 }
 
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
@@ -107,6 +124,7 @@ func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Autho
 		backend:   backend,
 		paths:     &paths,
 		machineID: machineID,
+		clock:     clock.WallClock,
 	}
 	return &b, nil
 }
@@ -126,7 +144,7 @@ func extractResourceValue(resources facade.Resources, key string) (string, error
 
 var newBackups = func(backend Backend) (backups.Backups, io.Closer) {
 	stor := backups.NewStorage(backend)
-	return backups.NewBackups(stor), stor
+	return backups.NewEncryptedBackups(stor, backups.ControllerEncryptionKey(backend)), stor
 }
 
 // CreateResult updates the result with the information in the
@@ -165,6 +183,9 @@ func CreateResult(meta *backups.Metadata, filename string) params.BackupsMetadat
 	result.CAPrivateKey = meta.CAPrivateKey
 	result.Filename = filename
 
+	result.Scheduled = meta.Scheduled
+	result.Encrypted = meta.Encryption != nil
+
 	return result
 }
 
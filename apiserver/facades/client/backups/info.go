@@ -24,5 +24,15 @@ func (a *API) Info(args params.BackupsInfoArgs) (params.BackupsMetadataResult, e
 		defer file.Close()
 	}
 
-	return CreateResult(meta, ""), nil
+	result := []params.BackupsMetadataResult{CreateResult(meta, "")}
+	if meta.Scheduled {
+		metaList, err := backups.List()
+		if err != nil {
+			return params.BackupsMetadataResult{}, errors.Trace(err)
+		}
+		if err := a.setRetention(result, metaList); err != nil {
+			return params.BackupsMetadataResult{}, errors.Trace(err)
+		}
+	}
+	return result[0], nil
 }
@@ -25,6 +25,9 @@ func (a *API) List(args params.BackupsListArgs) (params.BackupsListResult, error
 	for i, meta := range metaList {
 		result.List[i] = CreateResult(meta, "")
 	}
+	if err := a.setRetention(result.List, metaList); err != nil {
+		return result, errors.Trace(err)
+	}
 
 	return result, nil
 }
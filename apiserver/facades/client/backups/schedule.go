@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state/backups"
+)
+
+// ScheduleInfo returns the controller's backup schedule and retention
+// policy, and the state of the scheduled backups.
+func (a *APIv3) ScheduleInfo() (params.BackupsScheduleInfo, error) {
+	var result params.BackupsScheduleInfo
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Schedule = cfg.BackupSchedule()
+	result.RetainDaily = cfg.BackupRetainDaily()
+	result.RetainWeekly = cfg.BackupRetainWeekly()
+	result.Encrypted = cfg.BackupEncryptionKey() != nil
+	if result.Schedule != "" {
+		schedule, err := actions.ParseSchedule(result.Schedule)
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		next := schedule.Next(a.clock.Now())
+		result.NextBackup = &next
+	}
+
+	backupsMethods, closer := newBackups(a.backend)
+	defer closer.Close()
+	metaList, err := backupsMethods.List()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, meta := range metaList {
+		if !meta.Scheduled {
+			continue
+		}
+		result.ScheduledBackups++
+		if result.LastBackup == nil || meta.Started.After(*result.LastBackup) {
+			started := meta.Started
+			result.LastBackupID = meta.ID()
+			result.LastBackup = &started
+		}
+	}
+	return result, nil
+}
+
+// retentionPolicy returns the controller's backup retention policy.
+func retentionPolicy(cfg controller.Config) backups.RetentionPolicy {
+	return backups.RetentionPolicy{
+		Daily:  cfg.BackupRetainDaily(),
+		Weekly: cfg.BackupRetainWeekly(),
+	}
+}
+
+// setRetention records in the results the reasons for which the
+// retention policy keeps the scheduled backups, given all the stored
+// backups.
+func (a *API) setRetention(results []params.BackupsMetadataResult, metaList []*backups.Metadata) error {
+	cfg, err := a.backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	retained := retentionPolicy(cfg).Retained(metaList)
+	for i, result := range results {
+		if result.Scheduled {
+			results[i].Retention = retained[result.ID]
+		}
+	}
+	return nil
+}
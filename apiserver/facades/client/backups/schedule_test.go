@@ -0,0 +1,119 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+func (s *backupsSuite) setScheduledBackups(c *gc.C) *backupstesting.FakeBackups {
+	newBackup := func(id string, started time.Time, scheduled bool) *backups.Metadata {
+		meta := backupstesting.NewMetadataStarted()
+		meta.SetID(id)
+		meta.Started = started
+		meta.Scheduled = scheduled
+		return meta
+	}
+	fake := &backupstesting.FakeBackups{
+		MetaList: []*backups.Metadata{
+			newBackup("manual", time.Date(2018, 10, 17, 12, 0, 0, 0, time.UTC), false),
+			newBackup("old", time.Date(2018, 10, 15, 2, 0, 0, 0, time.UTC), true),
+			newBackup("yesterday", time.Date(2018, 10, 16, 2, 0, 0, 0, time.UTC), true),
+			newBackup("today", time.Date(2018, 10, 17, 2, 0, 0, 0, time.UTC), true),
+		},
+	}
+	s.PatchValue(backupsAPI.NewBackups,
+		func(backupsAPI.Backend) (backups.Backups, io.Closer) {
+			return fake, ioutil.NopCloser(nil)
+		},
+	)
+	return fake
+}
+
+func (s *backupsSuite) TestListRetention(c *gc.C) {
+	s.setScheduledBackups(c)
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-retain-daily":  2,
+		"backup-retain-weekly": 0,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.List(params.BackupsListArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	retention := make(map[string]string)
+	for _, item := range result.List {
+		retention[item.ID] = item.Retention
+	}
+	c.Check(retention, jc.DeepEquals, map[string]string{
+		"manual":    "",
+		"old":       "",
+		"yesterday": backups.RetainedDaily,
+		"today":     backups.RetainedDaily,
+	})
+}
+
+func (s *backupsSuite) TestInfoRetention(c *gc.C) {
+	fake := s.setScheduledBackups(c)
+	fake.Meta = fake.MetaList[3]
+
+	result, err := s.api.Info(params.BackupsInfoArgs{ID: "today"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Scheduled, jc.IsTrue)
+	c.Check(result.Retention, gc.Equals, backups.RetainedDaily)
+}
+
+func (s *backupsSuite) TestScheduleInfo(c *gc.C) {
+	s.setScheduledBackups(c)
+	key := base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-schedule":       "30 2 * * *",
+		"backup-retain-weekly":  2,
+		"backup-encryption-key": key,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := backupsAPI.NewAPIv3(&stateShim{s.State, s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ScheduleInfo()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(result.NextBackup, gc.NotNil)
+	c.Check(result.NextBackup.After(time.Now()), jc.IsTrue)
+	c.Check(result.NextBackup.Hour(), gc.Equals, 2)
+	c.Check(result.NextBackup.Minute(), gc.Equals, 30)
+	result.NextBackup = nil
+	lastBackup := time.Date(2018, 10, 17, 2, 0, 0, 0, time.UTC)
+	c.Check(result, jc.DeepEquals, params.BackupsScheduleInfo{
+		Schedule:         "30 2 * * *",
+		RetainDaily:      7,
+		RetainWeekly:     2,
+		Encrypted:        true,
+		LastBackupID:     "today",
+		LastBackup:       &lastBackup,
+		ScheduledBackups: 3,
+	})
+}
+
+func (s *backupsSuite) TestScheduleInfoNotScheduled(c *gc.C) {
+	s.setBackups(c, s.meta, "")
+	api, err := backupsAPI.NewAPIv3(&stateShim{s.State, s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.ScheduleInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, params.BackupsScheduleInfo{
+		RetainDaily:  7,
+		RetainWeekly: 4,
+	})
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	corebundle "github.com/juju/juju/core/bundle"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/storage"
@@ -40,6 +41,12 @@ type APIv2 struct {
 	*BundleAPI
 }
 
+// APIv3 provides the Bundle API facade for version 3. It adds support
+// for bundle overlays to GetChanges.
+type APIv3 struct {
+	*BundleAPI
+}
+
 // BundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type BundleAPI struct {
@@ -69,6 +76,16 @@ func NewFacadeV2(ctx facade.Context) (*APIv2, error) {
 	return &APIv2{api}, nil
 }
 
+// NewFacadeV3 provides the signature required for facade registration
+// for version 3.
+func NewFacadeV3(ctx facade.Context) (*APIv3, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -127,6 +144,9 @@ func (b *BundleAPI) checkCanRead() error {
 // order.
 // V1 GetChanges did not support device.
 func (b *APIv1) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
+	if len(args.OverlaysYAML) > 0 {
+		return params.BundleChangesResults{}, errors.NotSupportedf("bundle overlays in Bundle facade version 1")
+	}
 	vs := validators{
 		verifyConstraints: func(s string) error {
 			_, err := constraints.Parse(s)
@@ -168,6 +188,13 @@ func getChanges(
 	if err != nil {
 		return results, errors.Annotate(err, "cannot read bundle YAML")
 	}
+	for i, overlayYAML := range args.OverlaysYAML {
+		overlay, err := corebundle.ParseOverlay([]byte(overlayYAML))
+		if err != nil {
+			return results, errors.Annotatef(err, "cannot read bundle overlay %d", i+1)
+		}
+		overlay.Apply(data)
+	}
 	if err := data.Verify(vs.verifyConstraints, vs.verifyStorage, vs.verifyDevices); err != nil {
 		if verificationError, ok := err.(*charm.VerificationError); ok {
 			results.Errors = make([]string, len(verificationError.Errors))
@@ -230,6 +257,15 @@ func (b *BundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	})
 }
 
+// GetChanges returns the list of changes required to deploy the given bundle
+// data. Version 2 does not support bundle overlays.
+func (b *APIv2) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
+	if len(args.OverlaysYAML) > 0 {
+		return params.BundleChangesResults{}, errors.NotSupportedf("bundle overlays in Bundle facade version 2")
+	}
+	return b.BundleAPI.GetChanges(args)
+}
+
 // ExportBundle exports the current model configuration as bundle.
 func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	fail := func(failErr error) (params.StringResult, error) {
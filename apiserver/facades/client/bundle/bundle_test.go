@@ -212,6 +212,87 @@ func (s *bundleSuite) TestGetChangesSuccessV2(c *gc.C) {
 	c.Assert(r.Errors, gc.IsNil)
 }
 
+func (s *bundleSuite) TestGetChangesWithOverlays(c *gc.C) {
+	api, err := bundle.NewBundleAPI(s.st, s.auth, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	facade := &bundle.APIv3{api}
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+                memcached:
+                    charm: cs:xenial/memcached-7
+            relations:
+                - - django
+                  - memcached
+        `,
+		OverlaysYAML: []string{`
+            applications:
+                django:
+                    options:
+                        debug: true
+        `, `
+            applications:
+                memcached:
+        `},
+	}
+	r, err := facade.GetChanges(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, gc.IsNil)
+	c.Assert(r.Changes, jc.DeepEquals, []*params.BundleChange{{
+		Id:     "addCharm-0",
+		Method: "addCharm",
+		Args:   []interface{}{"django", ""},
+	}, {
+		Id:     "deploy-1",
+		Method: "deploy",
+		Args: []interface{}{
+			"$addCharm-0",
+			"",
+			"django",
+			map[string]interface{}{"debug": true},
+			"",
+			map[string]string{},
+			map[string]string{},
+			map[string]string{},
+			map[string]int{},
+		},
+		Requires: []string{"addCharm-0"},
+	}})
+}
+
+func (s *bundleSuite) TestGetChangesOverlayError(c *gc.C) {
+	api, err := bundle.NewBundleAPI(s.st, s.auth, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	facade := &bundle.APIv3{api}
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+        `,
+		OverlaysYAML: []string{":"},
+	}
+	_, err = facade.GetChanges(args)
+	c.Assert(err, gc.ErrorMatches, `cannot read bundle overlay 1: cannot unmarshal bundle data: yaml: did not find expected key`)
+}
+
+func (s *bundleSuite) TestGetChangesOverlaysNotSupportedV2(c *gc.C) {
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                django:
+                    charm: django
+        `,
+		OverlaysYAML: []string{"series: bionic"},
+	}
+	_, err := s.facade.GetChanges(args)
+	c.Assert(err, gc.ErrorMatches, `bundle overlays in Bundle facade version 2 not supported`)
+	_, err = s.apiv1.GetChanges(args)
+	c.Assert(err, gc.ErrorMatches, `bundle overlays in Bundle facade version 1 not supported`)
+}
+
 func (s *bundleSuite) TestGetChangesSuccessV1(c *gc.C) {
 	args := params.BundleChangesParams{
 		BundleDataYAML: `
@@ -43,7 +43,11 @@ func (ctx *charmsSuiteContext) Hub() facade.Hub             { return nil }
 
 func (ctx *charmsSuiteContext) LeadershipClaimer(string) (leadership.Claimer, error) { return nil, nil }
 func (ctx *charmsSuiteContext) LeadershipChecker() (leadership.Checker, error)       { return nil, nil }
-func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error)              { return nil, nil }
+func (ctx *charmsSuiteContext) LeadershipPinner() (leadership.Pinner, error)         { return nil, nil }
+func (ctx *charmsSuiteContext) LeadershipTransferrer() (leadership.Transferrer, error) {
+	return nil, nil
+}
+func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error) { return nil, nil }
 
 func (s *charmsSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -38,6 +39,7 @@ type API struct {
 	auth          facade.Authorizer
 	resources     facade.Resources
 	presence      facade.Presence
+	leadership    leadership.Pinner
 
 	client *Client
 	// statusSetter provides common methods for updating an entity's provisioning status.
@@ -151,6 +153,10 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		return environs.GetEnviron(configGetter, environs.New)
 	}
 	blockChecker := common.NewBlockChecker(st)
+	leadershipPinner, err := ctx.LeadershipPinner()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	backend := modelconfig.NewStateBackend(model)
 	// The modelConfigAPI exposed here is V1.
 	modelConfigAPI, err := modelconfig.NewModelConfigAPI(backend, authorizer)
@@ -169,6 +175,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		toolsFinder,
 		newEnviron,
 		blockChecker,
+		leadershipPinner,
 		state.CallContext(st),
 	)
 }
@@ -185,6 +192,7 @@ func NewClient(
 	toolsFinder *common.ToolsFinder,
 	newEnviron func() (environs.Environ, error),
 	blockChecker *common.BlockChecker,
+	leadershipPinner leadership.Pinner,
 	callCtx context.ProviderCallContext,
 ) (*Client, error) {
 	if !authorizer.AuthClient() {
@@ -200,6 +208,7 @@ func NewClient(
 			presence:      presence,
 			statusSetter:  statusSetter,
 			toolsFinder:   toolsFinder,
+			leadership:    leadershipPinner,
 		},
 		newEnviron:  newEnviron,
 		check:       blockChecker,
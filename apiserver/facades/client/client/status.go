@@ -221,10 +221,16 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		if context.leaders, err = c.api.stateAccessor.ApplicationLeaders(); err != nil {
 			return noStatus, errors.Annotate(err, "could not fetch leaders")
 		}
+		if c.api.leadership != nil {
+			context.pinnedLeadership = c.api.leadership.PinnedLeadership()
+		}
 	}
 	if context.controllerTimestamp, err = c.api.stateAccessor.ControllerTimestamp(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
 	}
+	if context.rollingUpgrades, err = fetchRollingUpgrades(context.model); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch rolling upgrades")
+	}
 
 	logger.Tracef("Applications: %v", context.allAppsUnitsCharmBindings.applications)
 	logger.Tracef("Remote applications: %v", context.consumerRemoteApplications)
@@ -472,6 +478,12 @@ type statusContext struct {
 	units                     map[string]map[string]*state.Unit
 	latestCharms              map[charm.URL]*state.Charm
 	leaders                   map[string]string
+
+	// pinnedLeadership: application name -> entities pinning its leadership
+	pinnedLeadership map[string][]string
+
+	// rollingUpgrades: application name -> rolling charm upgrade
+	rollingUpgrades map[string]*state.RollingUpgrade
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -653,6 +665,20 @@ func fetchAllApplicationsAndUnits(
 	}, nil
 }
 
+// fetchRollingUpgrades returns a map from application name to the
+// application's rolling charm upgrade.
+func fetchRollingUpgrades(model *state.Model) (map[string]*state.RollingUpgrade, error) {
+	upgrades, err := model.AllRollingUpgrades()
+	if err != nil {
+		return nil, err
+	}
+	upgradeMap := make(map[string]*state.RollingUpgrade)
+	for _, upgrade := range upgrades {
+		upgradeMap[upgrade.Application()] = upgrade
+	}
+	return upgradeMap, nil
+}
+
 // fetchConsumerRemoteApplications returns a map from application name to remote application.
 func fetchConsumerRemoteApplications(st Backend) (map[string]*state.RemoteApplication, error) {
 	appMap := make(map[string]*state.RemoteApplication)
@@ -884,6 +910,15 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine) (status params
 	} else {
 		status.Hardware = hc.String()
 	}
+	// TODO: preload all charm profiles.
+	profiles, err := machine.CharmProfiles()
+	if err != nil {
+		if !errors.IsNotProvisioned(err) {
+			logger.Debugf("error fetching charm profiles for machine %q: %v", machine.Id(), err)
+		}
+	} else {
+		status.LXDProfiles = profiles
+	}
 	status.Containers = make(map[string]params.MachineStatus)
 	return
 }
@@ -1008,6 +1043,17 @@ func (context *statusContext) processApplication(application *state.Application)
 	processedStatus.Status.Data = applicationStatus.Data
 	processedStatus.Status.Since = applicationStatus.Since
 
+	if upgrade, ok := context.rollingUpgrades[application.Name()]; ok {
+		processedStatus.RollingUpgrade = &params.RollingUpgradeStatus{
+			CharmURL:  upgrade.CharmURL(),
+			Status:    string(upgrade.Status()),
+			Message:   upgrade.Message(),
+			BatchSize: upgrade.BatchSize(),
+			Released:  len(upgrade.Released()),
+			Units:     len(units),
+		}
+	}
+
 	metrics := applicationCharm.Metrics()
 	planRequired := metrics != nil && metrics.Plan != nil && metrics.Plan.Required
 	if planRequired || len(application.MetricCredentials()) > 0 {
@@ -1067,8 +1113,18 @@ func (context *statusContext) processApplication(application *state.Application)
 		}
 		scale := application.GetScale()
 		processedStatus.Scale = &scale
+		if policy := application.AutoscalePolicy(); policy != nil {
+			processedStatus.Autoscale = &params.AutoscalePolicy{
+				MinUnits:         policy.MinUnits,
+				MaxUnits:         policy.MaxUnits,
+				TargetCPUPercent: policy.TargetCPUPercent,
+				Metric:           policy.Metric,
+				MetricTarget:     policy.MetricTarget,
+			}
+		}
 	}
 
+	processedStatus.LeadershipPinned = len(context.pinnedLeadership[application.Name()]) > 0
 	processedStatus.EndpointBindings = context.allAppsUnitsCharmBindings.endpointBindings[application.Name()]
 
 	return processedStatus
@@ -137,6 +137,20 @@ func (s *statusUnitTestSuite) TestProcessMachinesWithEmbeddedContainers(c *gc.C)
 	c.Check(mStatus.Containers, gc.HasLen, 1)
 }
 
+func (s *statusUnitTestSuite) TestMachineLXDProfiles(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: instance.Id("0")})
+	err := machine.SetCharmProfiles([]string{"juju-controller-lxd-profile-1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mStatus, ok := status.Machines[machine.Id()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(mStatus.LXDProfiles, jc.DeepEquals, []string{"juju-controller-lxd-profile-1"})
+}
+
 var testUnits = []struct {
 	unitName       string
 	setStatus      *state.MeterStatus
@@ -344,6 +358,35 @@ func (s *statusUnitTestSuite) TestWorkloadVersionOkWithUnset(c *gc.C) {
 	checkUnitVersion(c, appStatus, unit, "")
 }
 
+func (s *statusUnitTestSuite) TestRollingUpgrade(c *gc.C) {
+	oldCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress", URL: "cs:quantal/wordpress-3"})
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress", URL: "cs:quantal/wordpress-4"})
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: oldCharm})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	err := application.SetCharm(state.SetCharmConfig{
+		Charm: newCharm,
+		RollingUpgrade: &state.RollingUpgradeArgs{
+			BatchSize:    1,
+			BatchTimeout: time.Minute,
+			OnError:      state.RollingUpgradePause,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	appStatus, found := status.Applications[application.Name()]
+	c.Assert(found, jc.IsTrue)
+	c.Assert(appStatus.RollingUpgrade, jc.DeepEquals, &params.RollingUpgradeStatus{
+		CharmURL:  "cs:quantal/wordpress-4",
+		Status:    "running",
+		BatchSize: 1,
+		Units:     2,
+	})
+}
+
 func (s *statusUnitTestSuite) TestMigrationInProgress(c *gc.C) {
 
 	// Create a host model because controller models can't be migrated.
@@ -43,6 +43,7 @@ func (s *statusHistoryTestSuite) SetUpTest(c *gc.C) {
 		nil,                           // toolsFinder
 		nil,                           // newEnviron
 		nil,                           // blockChecker
+		nil,                           // leadershipPinner
 		context.NewCloudCallContext(), // ProviderCallContext
 	)
 	c.Assert(err, jc.ErrorIsNil)
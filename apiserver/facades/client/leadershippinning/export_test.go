@@ -0,0 +1,13 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinning
+
+import (
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/leadership"
+)
+
+func NewAPIForTest(backend Backend, pinner leadership.Pinner, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(backend, pinner, authorizer)
+}
@@ -0,0 +1,162 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leadershippinning provides the API facade that operators use
+// to hold application leadership with its current leader, for example
+// during maintenance of the leader's machine.
+package leadershippinning
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods needed by the LeadershipPinning
+// facade.
+type Backend interface {
+	common.BlockGetter
+	ModelTag() names.ModelTag
+	Application(name string) (Application, error)
+}
+
+// Application describes an application, as provided by
+// *state.Application.
+type Application interface {
+	Name() string
+}
+
+// API implements the LeadershipPinning facade.
+type API struct {
+	backend    Backend
+	pinner     leadership.Pinner
+	authorizer facade.Authorizer
+	check      *common.BlockChecker
+}
+
+// NewAPI returns a new LeadershipPinning facade for the model.
+func NewAPI(ctx facade.Context) (*API, error) {
+	pinner, err := ctx.LeadershipPinner()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newAPI(stateBackend{ctx.State()}, pinner, ctx.Auth())
+}
+
+func newAPI(backend Backend, pinner leadership.Pinner, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		pinner:     pinner,
+		authorizer: authorizer,
+		check:      common.NewBlockChecker(backend),
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// PinnedLeadership returns the applications in the model with pinned
+// leadership, and the entities that pinned each of them.
+func (api *API) PinnedLeadership() (params.PinnedLeadershipResult, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.PinnedLeadershipResult{}, errors.Trace(err)
+	}
+	return params.PinnedLeadershipResult{Result: api.pinner.PinnedLeadership()}, nil
+}
+
+// PinApplicationLeaders pins the leadership of the input applications on
+// behalf of the authenticated user. The leaders will not change until
+// the same user unpins them.
+func (api *API) PinApplicationLeaders(args params.Entities) (params.PinApplicationsResults, error) {
+	entity := api.authorizer.GetAuthTag().String()
+	return api.pinOps(args, permission.WriteAccess, func(appName string) error {
+		return api.pinner.PinLeadership(appName, entity)
+	})
+}
+
+// UnpinApplicationLeaders removes the authenticated user's pins on the
+// leadership of the input applications.
+func (api *API) UnpinApplicationLeaders(args params.Entities) (params.PinApplicationsResults, error) {
+	entity := api.authorizer.GetAuthTag().String()
+	return api.pinOps(args, permission.WriteAccess, func(appName string) error {
+		return api.pinner.UnpinLeadership(appName, entity)
+	})
+}
+
+// ForceUnpinApplicationLeaders removes all pins on the leadership of the
+// input applications, whoever made them. It is for model admins to
+// release leadership left pinned by a user, or by a machine whose
+// series upgrade will not complete.
+func (api *API) ForceUnpinApplicationLeaders(args params.Entities) (params.PinApplicationsResults, error) {
+	return api.pinOps(args, permission.AdminAccess, func(appName string) error {
+		for _, entity := range api.pinner.PinnedLeadership()[appName] {
+			if err := api.pinner.UnpinLeadership(appName, entity); err != nil {
+				return errors.Annotatef(err, "removing pin by %q", entity)
+			}
+		}
+		return nil
+	})
+}
+
+func (api *API) pinOps(args params.Entities, access permission.Access, op func(string) error) (params.PinApplicationsResults, error) {
+	if err := api.checkAccess(access); err != nil {
+		return params.PinApplicationsResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.PinApplicationsResults{}, errors.Trace(err)
+	}
+	results := params.PinApplicationsResults{
+		Results: make([]params.PinApplicationResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		appName, err := api.applicationName(arg.Tag)
+		results.Results[i].ApplicationName = appName
+		if err == nil {
+			err = op(appName)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) applicationName(tag string) (string, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return appTag.Id(), errors.Trace(err)
+	}
+	return app.Name(), nil
+}
+
+// stateBackend adapts *state.State to Backend.
+type stateBackend struct {
+	*state.State
+}
+
+func (b stateBackend) Application(name string) (Application, error) {
+	app, err := b.State.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
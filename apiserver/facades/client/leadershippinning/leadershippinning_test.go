@@ -0,0 +1,212 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinning_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/leadershippinning"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type pinningSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	pinner     *fakePinner
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&pinningSuite{})
+
+func (s *pinningSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.pinner = &fakePinner{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+}
+
+func (s *pinningSuite) newAPI(c *gc.C) *leadershippinning.API {
+	api, err := leadershippinning.NewAPIForTest(s.backend, s.pinner, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *pinningSuite) TestNonClientDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := leadershippinning.NewAPIForTest(s.backend, s.pinner, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *pinningSuite) TestPinnedLeadership(c *gc.C) {
+	s.pinner.pinned = map[string][]string{
+		"mysql": {"machine-0", "user-write"},
+	}
+	result, err := s.newAPI(c).PinnedLeadership()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.PinnedLeadershipResult{
+		Result: map[string][]string{
+			"mysql": {"machine-0", "user-write"},
+		},
+	})
+}
+
+func (s *pinningSuite) TestPinnedLeadershipNeedsRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).PinnedLeadership()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *pinningSuite) TestPinApplicationLeaders(c *gc.C) {
+	s.backend.SetErrors(nil, nil, nil, errors.NotFoundf(`application "missing"`))
+	s.pinner.SetErrors(errors.New("boom"))
+	results, err := s.newAPI(c).PinApplicationLeaders(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-mysql"},
+			{Tag: "application-missing"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].ApplicationName, gc.Equals, "mysql")
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Check(results.Results[1].ApplicationName, gc.Equals, "missing")
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid application tag`)
+	s.backend.CheckCallNames(c, "ModelTag", "GetBlockForType", "Application", "Application")
+	s.pinner.CheckCalls(c, []testing.StubCall{
+		{"PinLeadership", []interface{}{"mysql", "user-write"}},
+	})
+}
+
+func (s *pinningSuite) TestUnpinApplicationLeaders(c *gc.C) {
+	results, err := s.newAPI(c).UnpinApplicationLeaders(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.PinApplicationsResults{
+		Results: []params.PinApplicationResult{{ApplicationName: "mysql"}},
+	})
+	s.pinner.CheckCalls(c, []testing.StubCall{
+		{"UnpinLeadership", []interface{}{"mysql", "user-write"}},
+	})
+}
+
+func (s *pinningSuite) TestForceUnpinApplicationLeaders(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.pinner.pinned = map[string][]string{
+		"mysql": {"machine-0", "user-write"},
+	}
+	results, err := s.newAPI(c).ForceUnpinApplicationLeaders(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}, {Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.PinApplicationsResults{
+		Results: []params.PinApplicationResult{
+			{ApplicationName: "mysql"},
+			{ApplicationName: "wordpress"},
+		},
+	})
+	s.pinner.CheckCalls(c, []testing.StubCall{
+		{"PinnedLeadership", nil},
+		{"UnpinLeadership", []interface{}{"mysql", "machine-0"}},
+		{"UnpinLeadership", []interface{}{"mysql", "user-write"}},
+		{"PinnedLeadership", nil},
+	})
+}
+
+func (s *pinningSuite) TestForceUnpinApplicationLeadersNeedsAdmin(c *gc.C) {
+	_, err := s.newAPI(c).ForceUnpinApplicationLeaders(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.pinner.CheckNoCalls(c)
+}
+
+func (s *pinningSuite) TestPinApplicationLeadersNeedsWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).PinApplicationLeaders(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *pinningSuite) TestPinApplicationLeadersBlocked(c *gc.C) {
+	s.backend.blocked = true
+	_, err := s.newAPI(c).PinApplicationLeaders(params.Entities{})
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.pinner.CheckNoCalls(c)
+}
+
+type fakeBackend struct {
+	testing.Stub
+	blocked bool
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	b.MethodCall(b, "GetBlockForType", t)
+	b.PopNoErr()
+	if b.blocked {
+		return fakeBlock{}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (b *fakeBackend) Application(name string) (leadershippinning.Application, error) {
+	b.MethodCall(b, "Application", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return fakeApplication{name}, nil
+}
+
+type fakeApplication struct {
+	name string
+}
+
+func (a fakeApplication) Name() string {
+	return a.name
+}
+
+type fakeBlock struct {
+	state.Block
+}
+
+func (fakeBlock) Message() string {
+	return "no changes"
+}
+
+type fakePinner struct {
+	testing.Stub
+	pinned map[string][]string
+}
+
+func (p *fakePinner) PinLeadership(applicationId, entity string) error {
+	p.MethodCall(p, "PinLeadership", applicationId, entity)
+	return p.NextErr()
+}
+
+func (p *fakePinner) UnpinLeadership(applicationId, entity string) error {
+	p.MethodCall(p, "UnpinLeadership", applicationId, entity)
+	return p.NextErr()
+}
+
+func (p *fakePinner) PinnedLeadership() map[string][]string {
+	p.MethodCall(p, "PinnedLeadership")
+	return p.pinned
+}
@@ -0,0 +1,231 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+// UpgradeSeriesApplicationPrepare isn't on the v5 API.
+func (mm *MachineManagerAPIV5) UpgradeSeriesApplicationPrepare(_, _ struct{}) {}
+
+// UpgradeSeriesApplicationComplete isn't on the v5 API.
+func (mm *MachineManagerAPIV5) UpgradeSeriesApplicationComplete(_, _ struct{}) {}
+
+// UpgradeSeriesApplicationPause isn't on the v5 API.
+func (mm *MachineManagerAPIV5) UpgradeSeriesApplicationPause(_, _ struct{}) {}
+
+// UpgradeSeriesApplicationResume isn't on the v5 API.
+func (mm *MachineManagerAPIV5) UpgradeSeriesApplicationResume(_, _ struct{}) {}
+
+// UpgradeSeriesApplicationRollback isn't on the v5 API.
+func (mm *MachineManagerAPIV5) UpgradeSeriesApplicationRollback(_, _ struct{}) {}
+
+// UpgradeSeriesApplicationStatus isn't on the v5 API.
+func (mm *MachineManagerAPIV5) UpgradeSeriesApplicationStatus(_, _ struct{}) {}
+
+// UpgradeSeriesApplicationPrepare starts upgrading the series of the
+// machines hosting each of the given applications, one machine at a
+// time with the machine hosting the application's leader last, and
+// prepares the first machine.
+func (mm *MachineManagerAPI) UpgradeSeriesApplicationPrepare(args params.UpdateSeriesArgs) (params.ApplicationUpgradeSeriesResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ApplicationUpgradeSeriesResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ApplicationUpgradeSeriesResults{}, err
+	}
+	results := make([]params.ApplicationUpgradeSeriesResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i] = mm.updateApplicationUpgradeSeries(arg.Entity.Tag, func(app Application) error {
+			if err := validateTargetSeries(arg.Series); err != nil {
+				return err
+			}
+			if err := app.StartUpgradeSeries(arg.Series, arg.Force); err != nil {
+				return err
+			}
+			// Hold the application's leadership for the duration of
+			// the upgrade; its leader's machine is upgraded last.
+			if err := mm.pinApplication(app.Name()); err != nil {
+				if err2 := app.RollbackUpgradeSeries(); err2 != nil {
+					err = errors.Annotatef(err, "%s occurred while cleaning up from", err2)
+				}
+				return err
+			}
+			return nil
+		})
+	}
+	return params.ApplicationUpgradeSeriesResults{Results: results}, nil
+}
+
+// UpgradeSeriesApplicationComplete starts completing the prepared machine
+// of each of the given applications, once its operating system has been
+// upgraded. The next machine is prepared when it has completed, unless
+// the application's upgrade is paused.
+func (mm *MachineManagerAPI) UpgradeSeriesApplicationComplete(args params.Entities) (params.ApplicationUpgradeSeriesResults, error) {
+	return mm.updateApplicationsUpgradeSeries(args, func(app Application) error {
+		if _, err := app.CompleteUpgradeSeries(); err != nil {
+			return err
+		}
+		upgrade, err := app.UpgradeSeries()
+		if err != nil {
+			return err
+		}
+		for _, m := range upgrade.Machines() {
+			if m.Status == state.UpgradeSeriesMachinePending {
+				return nil
+			}
+		}
+		// The last machine, hosting the leader, is completing.
+		return mm.unpinApplication(app.Name())
+	})
+}
+
+// UpgradeSeriesApplicationPause stops any more machines of the given
+// applications from being prepared for series upgrade.
+func (mm *MachineManagerAPI) UpgradeSeriesApplicationPause(args params.Entities) (params.ApplicationUpgradeSeriesResults, error) {
+	return mm.updateApplicationsUpgradeSeries(args, Application.PauseUpgradeSeries)
+}
+
+// UpgradeSeriesApplicationResume resumes the paused series upgrades of
+// the given applications' machines.
+func (mm *MachineManagerAPI) UpgradeSeriesApplicationResume(args params.Entities) (params.ApplicationUpgradeSeriesResults, error) {
+	return mm.updateApplicationsUpgradeSeries(args, Application.ResumeUpgradeSeries)
+}
+
+// UpgradeSeriesApplicationRollback abandons the series upgrades of the
+// given applications' machines, releasing any machine being prepared.
+func (mm *MachineManagerAPI) UpgradeSeriesApplicationRollback(args params.Entities) (params.ApplicationUpgradeSeriesResults, error) {
+	return mm.updateApplicationsUpgradeSeries(args, func(app Application) error {
+		if err := app.RollbackUpgradeSeries(); err != nil {
+			return err
+		}
+		return mm.unpinApplication(app.Name())
+	})
+}
+
+// UpgradeSeriesApplicationStatus returns the progress of the series
+// upgrades of the given applications' machines.
+func (mm *MachineManagerAPI) UpgradeSeriesApplicationStatus(args params.Entities) (params.ApplicationUpgradeSeriesResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.ApplicationUpgradeSeriesResults{}, err
+	}
+	results := make([]params.ApplicationUpgradeSeriesResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		results[i] = mm.updateApplicationUpgradeSeries(entity.Tag, nil)
+	}
+	return params.ApplicationUpgradeSeriesResults{Results: results}, nil
+}
+
+func (mm *MachineManagerAPI) updateApplicationsUpgradeSeries(
+	args params.Entities, update func(Application) error,
+) (params.ApplicationUpgradeSeriesResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ApplicationUpgradeSeriesResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ApplicationUpgradeSeriesResults{}, err
+	}
+	results := make([]params.ApplicationUpgradeSeriesResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		results[i] = mm.updateApplicationUpgradeSeries(entity.Tag, update)
+	}
+	return params.ApplicationUpgradeSeriesResults{Results: results}, nil
+}
+
+// updateApplicationUpgradeSeries calls update, if it is not nil, with the
+// tagged application, and returns the progress of the series upgrade of
+// its machines.
+func (mm *MachineManagerAPI) updateApplicationUpgradeSeries(
+	tag string, update func(Application) error,
+) params.ApplicationUpgradeSeriesResult {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return params.ApplicationUpgradeSeriesResult{Error: common.ServerError(err)}
+	}
+	app, err := mm.st.Application(appTag.Id())
+	if err != nil {
+		return params.ApplicationUpgradeSeriesResult{Error: common.ServerError(err)}
+	}
+	if update != nil {
+		if err := update(app); err != nil {
+			return params.ApplicationUpgradeSeriesResult{Error: common.ServerError(err)}
+		}
+	}
+	result, err := mm.applicationUpgradeSeries(app)
+	if err != nil {
+		return params.ApplicationUpgradeSeriesResult{Error: common.ServerError(err)}
+	}
+	return params.ApplicationUpgradeSeriesResult{Result: result}
+}
+
+// pinApplication pins the leadership of the application on behalf of the
+// series upgrade of its machines. As for a single machine's upgrade,
+// lease stores that do not support pinning are tolerated.
+func (mm *MachineManagerAPI) pinApplication(appName string) error {
+	err := mm.leadership.PinLeadership(appName, names.NewApplicationTag(appName).String())
+	if errors.IsNotImplemented(err) {
+		logger.Warningf("not pinning leadership for %q: %v", appName, err)
+		return nil
+	}
+	return errors.Annotatef(err, "pinning leadership for %q", appName)
+}
+
+// unpinApplication removes the pin on the leadership of the application
+// made by pinApplication.
+func (mm *MachineManagerAPI) unpinApplication(appName string) error {
+	err := mm.leadership.UnpinLeadership(appName, names.NewApplicationTag(appName).String())
+	if errors.IsNotImplemented(err) {
+		return nil
+	}
+	return errors.Annotatef(err, "unpinning leadership for %q", appName)
+}
+
+func (mm *MachineManagerAPI) applicationUpgradeSeries(app Application) (*params.ApplicationUpgradeSeries, error) {
+	upgrade, err := app.UpgradeSeries()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &params.ApplicationUpgradeSeries{
+		Application: upgrade.Application(),
+		ToSeries:    upgrade.ToSeries(),
+		Status:      string(upgrade.Status()),
+		Message:     upgrade.Message(),
+	}
+	for _, m := range upgrade.Machines() {
+		machine := params.ApplicationUpgradeSeriesMachine{
+			Tag:        names.NewMachineTag(m.MachineId).String(),
+			FromSeries: m.FromSeries,
+			Status:     string(m.Status),
+		}
+		switch m.Status {
+		case state.UpgradeSeriesMachinePreparing, state.UpgradeSeriesMachineCompleting:
+			if machine.UpgradeSeriesStatus, err = mm.machineUpgradeSeriesStatus(m.MachineId); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		result.Machines = append(result.Machines, machine)
+	}
+	return result, nil
+}
+
+func (mm *MachineManagerAPI) machineUpgradeSeriesStatus(id string) (model.UpgradeSeriesStatus, error) {
+	machine, err := mm.st.Machine(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	status, err := machine.UpgradeSeriesStatus()
+	if errors.IsNotFound(err) {
+		// The machine's agent finished its upgrade after the
+		// application's upgrade was read.
+		return model.UpgradeSeriesCompleted, nil
+	}
+	return status, errors.Trace(err)
+}
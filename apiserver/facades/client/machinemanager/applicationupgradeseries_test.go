@@ -0,0 +1,280 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+func (s *MachineManagerSuite) setupApplicationUpgradeSeries(c *gc.C) *mockApplication {
+	s.st.machines = map[string]*mockMachine{
+		"0": {series: "trusty", units: []string{"foo/0"}},
+		"1": {series: "trusty", units: []string{"foo/1"}, upgradeSeriesStatus: model.UpgradeSeriesPrepareCompleted},
+	}
+	app := &mockApplication{
+		name: "foo",
+		upgrade: &mockApplicationUpgradeSeries{
+			application: "foo",
+			toSeries:    "xenial",
+			status:      state.ApplicationUpgradeSeriesRunning,
+			machines: []state.ApplicationUpgradeSeriesMachine{
+				{MachineId: "1", FromSeries: "trusty", Status: state.UpgradeSeriesMachinePreparing},
+				{MachineId: "0", FromSeries: "trusty", Status: state.UpgradeSeriesMachinePending},
+			},
+		},
+	}
+	s.st.applications = map[string]*mockApplication{"foo": app}
+	return app
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationPrepare(c *gc.C) {
+	app := s.setupApplicationUpgradeSeries(c)
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	results, err := apiV6.UpgradeSeriesApplicationPrepare(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag("foo").String()},
+			Series: "xenial",
+			Force:  true,
+		}, {
+			Entity: params.Entity{Tag: names.NewApplicationTag("bar").String()},
+			Series: "xenial",
+		}, {
+			Entity: params.Entity{Tag: names.NewApplicationTag("foo").String()},
+			Series: "centos7",
+		}, {
+			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0], jc.DeepEquals, params.ApplicationUpgradeSeriesResult{
+		Result: &params.ApplicationUpgradeSeries{
+			Application: "foo",
+			ToSeries:    "xenial",
+			Status:      "running",
+			Machines: []params.ApplicationUpgradeSeriesMachine{{
+				Tag:                 "machine-1",
+				FromSeries:          "trusty",
+				Status:              "preparing",
+				UpgradeSeriesStatus: model.UpgradeSeriesPrepareCompleted,
+			}, {
+				Tag:        "machine-0",
+				FromSeries: "trusty",
+				Status:     "pending",
+			}},
+		},
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "bar" not found`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches,
+		`series "centos7" is from OS "CentOS" and is not a valid upgrade target`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+	app.CheckCalls(c, []jtesting.StubCall{
+		{"StartUpgradeSeries", []interface{}{"xenial", true}},
+		{"UpgradeSeries", nil},
+	})
+	s.leadership.CheckCalls(c, []jtesting.StubCall{
+		{"PinLeadership", []interface{}{"foo", "application-foo"}},
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationPreparePinError(c *gc.C) {
+	app := s.setupApplicationUpgradeSeries(c)
+	s.leadership.SetErrors(errors.New("boom"))
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	results, err := apiV6.UpgradeSeriesApplicationPrepare(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag("foo").String()},
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `pinning leadership for "foo": boom`)
+	app.CheckCallNames(c, "StartUpgradeSeries", "RollbackUpgradeSeries")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationPrepareError(c *gc.C) {
+	app := s.setupApplicationUpgradeSeries(c)
+	app.SetErrors(errors.AlreadyExistsf("series upgrade of application %q", "foo"))
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	results, err := apiV6.UpgradeSeriesApplicationPrepare(params.UpdateSeriesArgs{
+		Args: []params.UpdateSeriesArg{{
+			Entity: params.Entity{Tag: names.NewApplicationTag("foo").String()},
+			Series: "xenial",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeAlreadyExists)
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationUpdates(c *gc.C) {
+	for _, test := range []struct {
+		call  func(machinemanager.MachineManagerAPIV6, params.Entities) (params.ApplicationUpgradeSeriesResults, error)
+		calls []string
+		unpin bool
+	}{{
+		call:  machinemanager.MachineManagerAPIV6.UpgradeSeriesApplicationComplete,
+		calls: []string{"CompleteUpgradeSeries", "UpgradeSeries", "UpgradeSeries"},
+	}, {
+		call:  machinemanager.MachineManagerAPIV6.UpgradeSeriesApplicationPause,
+		calls: []string{"PauseUpgradeSeries", "UpgradeSeries"},
+	}, {
+		call:  machinemanager.MachineManagerAPIV6.UpgradeSeriesApplicationResume,
+		calls: []string{"ResumeUpgradeSeries", "UpgradeSeries"},
+	}, {
+		call:  machinemanager.MachineManagerAPIV6.UpgradeSeriesApplicationRollback,
+		calls: []string{"RollbackUpgradeSeries", "UpgradeSeries"},
+		unpin: true,
+	}} {
+		c.Logf("testing %s", test.calls[0])
+		s.leadership.ResetCalls()
+		app := s.setupApplicationUpgradeSeries(c)
+		apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+		results, err := test.call(apiV6, params.Entities{
+			Entities: []params.Entity{{Tag: names.NewApplicationTag("foo").String()}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(results.Results, gc.HasLen, 1)
+		c.Check(results.Results[0].Error, gc.IsNil)
+		c.Check(results.Results[0].Result.Application, gc.Equals, "foo")
+		app.CheckCallNames(c, test.calls...)
+		if test.unpin {
+			s.leadership.CheckCalls(c, []jtesting.StubCall{
+				{"UnpinLeadership", []interface{}{"foo", "application-foo"}},
+			})
+		} else {
+			s.leadership.CheckNoCalls(c)
+		}
+	}
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationCompleteLastMachine(c *gc.C) {
+	app := s.setupApplicationUpgradeSeries(c)
+	app.upgrade.machines = []state.ApplicationUpgradeSeriesMachine{
+		{MachineId: "1", FromSeries: "trusty", Status: state.UpgradeSeriesMachineCompleted},
+		{MachineId: "0", FromSeries: "trusty", Status: state.UpgradeSeriesMachineCompleting},
+	}
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	results, err := apiV6.UpgradeSeriesApplicationComplete(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag("foo").String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	s.leadership.CheckCalls(c, []jtesting.StubCall{
+		{"UnpinLeadership", []interface{}{"foo", "application-foo"}},
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationStatus(c *gc.C) {
+	app := s.setupApplicationUpgradeSeries(c)
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	results, err := apiV6.UpgradeSeriesApplicationStatus(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag("foo").String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Result.Status, gc.Equals, "running")
+	app.CheckCallNames(c, "UpgradeSeries")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationPermissionDenied(c *gc.C) {
+	s.setupApplicationUpgradeSeries(c)
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	_, err := apiV6.UpgradeSeriesApplicationPause(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag("foo").String()}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesApplicationBlockedChanges(c *gc.C) {
+	s.setupApplicationUpgradeSeries(c)
+	s.st.blockMsg = "TestUpgradeSeriesApplicationBlockedChanges"
+	s.st.block = state.ChangeBlock
+	apiV6 := machinemanager.MachineManagerAPIV6{MachineManagerAPI: s.api}
+	_, err := apiV6.UpgradeSeriesApplicationRollback(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag("foo").String()}},
+	})
+	c.Assert(params.IsCodeOperationBlocked(err), jc.IsTrue, gc.Commentf("error: %#v", err))
+}
+
+type mockApplication struct {
+	jtesting.Stub
+	name    string
+	upgrade *mockApplicationUpgradeSeries
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) StartUpgradeSeries(toSeries string, force bool) error {
+	a.MethodCall(a, "StartUpgradeSeries", toSeries, force)
+	return a.NextErr()
+}
+
+func (a *mockApplication) CompleteUpgradeSeries() (string, error) {
+	a.MethodCall(a, "CompleteUpgradeSeries")
+	return "1", a.NextErr()
+}
+
+func (a *mockApplication) PauseUpgradeSeries() error {
+	a.MethodCall(a, "PauseUpgradeSeries")
+	return a.NextErr()
+}
+
+func (a *mockApplication) ResumeUpgradeSeries() error {
+	a.MethodCall(a, "ResumeUpgradeSeries")
+	return a.NextErr()
+}
+
+func (a *mockApplication) RollbackUpgradeSeries() error {
+	a.MethodCall(a, "RollbackUpgradeSeries")
+	return a.NextErr()
+}
+
+func (a *mockApplication) UpgradeSeries() (machinemanager.ApplicationUpgradeSeries, error) {
+	a.MethodCall(a, "UpgradeSeries")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.upgrade, nil
+}
+
+type mockApplicationUpgradeSeries struct {
+	application string
+	toSeries    string
+	status      state.ApplicationUpgradeSeriesStatus
+	message     string
+	machines    []state.ApplicationUpgradeSeriesMachine
+}
+
+func (u *mockApplicationUpgradeSeries) Application() string {
+	return u.application
+}
+
+func (u *mockApplicationUpgradeSeries) ToSeries() string {
+	return u.toSeries
+}
+
+func (u *mockApplicationUpgradeSeries) Status() state.ApplicationUpgradeSeriesStatus {
+	return u.status
+}
+
+func (u *mockApplicationUpgradeSeries) Message() string {
+	return u.message
+}
+
+func (u *mockApplicationUpgradeSeries) Machines() []state.ApplicationUpgradeSeriesMachine {
+	return u.machines
+}
@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -16,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -34,6 +36,7 @@ type MachineManagerAPI struct {
 	authorizer    facade.Authorizer
 	check         *common.BlockChecker
 	resources     facade.Resources
+	leadership    leadership.Pinner
 
 	modelTag    names.ModelTag
 	callContext context.ProviderCallContext
@@ -53,7 +56,11 @@ func NewFacade(ctx facade.Context) (*MachineManagerAPI, error) {
 		return nil, errors.Trace(err)
 	}
 	pool := &poolShim{ctx.StatePool()}
-	return NewMachineManagerAPI(backend, storageAccess, pool, ctx.Auth(), model.ModelTag(), state.CallContext(st), ctx.Resources())
+	pinner, err := ctx.LeadershipPinner()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewMachineManagerAPI(backend, storageAccess, pool, ctx.Auth(), model.ModelTag(), state.CallContext(st), ctx.Resources(), pinner)
 }
 
 // Version 4 of MachineManagerAPI
@@ -66,6 +73,12 @@ type MachineManagerAPIV5 struct {
 	*MachineManagerAPI
 }
 
+// Version 6 of Machine Manager API. Adds upgrading the series of an
+// application's machines.
+type MachineManagerAPIV6 struct {
+	*MachineManagerAPI
+}
+
 // NewFacadeV4 creates a new server-side MachineManager API facade.
 func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 	machineManagerAPIV5, err := NewFacadeV5(ctx)
@@ -84,6 +97,15 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 	return &MachineManagerAPIV5{machineManagerAPI}, nil
 }
 
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPI, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPI}, nil
+}
+
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
 func NewMachineManagerAPI(
 	backend Backend,
@@ -93,6 +115,7 @@ func NewMachineManagerAPI(
 	modelTag names.ModelTag,
 	callCtx context.ProviderCallContext,
 	resources facade.Resources,
+	leadership leadership.Pinner,
 ) (*MachineManagerAPI, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
@@ -106,6 +129,7 @@ func NewMachineManagerAPI(
 		modelTag:      modelTag,
 		callContext:   callCtx,
 		resources:     resources,
+		leadership:    leadership,
 	}, nil
 }
 
@@ -428,7 +452,11 @@ func (mm *MachineManagerAPI) upgradeSeriesPrepare(arg params.UpdateSeriesArg) er
 			}
 		}
 	}()
-	return nil
+
+	// Hold the leadership of the machine's applications for the duration
+	// of the upgrade, so that it does not move while agents are stopped.
+	err = mm.pinMachineApplications(machineTag, unitNames)
+	return errors.Trace(err)
 }
 
 // UpgradeSeriesComplete marks a machine as having completed a managed series upgrade.
@@ -455,7 +483,14 @@ func (mm *MachineManagerAPI) completeUpgradeSeries(arg params.UpdateSeriesArg) e
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return machine.CompleteUpgradeSeries()
+	unitNames, err := upgradeSeriesLockUnitNames(machine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := machine.CompleteUpgradeSeries(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(mm.unpinMachineApplications(arg.Entity.Tag, unitNames))
 }
 
 func (mm *MachineManagerAPI) removeUpgradeSeriesLock(arg params.UpdateSeriesArg) error {
@@ -463,7 +498,88 @@ func (mm *MachineManagerAPI) removeUpgradeSeriesLock(arg params.UpdateSeriesArg)
 	if err != nil {
 		return errors.Trace(err)
 	}
-	return machine.RemoveUpgradeSeriesLock()
+	unitNames, err := upgradeSeriesLockUnitNames(machine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := machine.RemoveUpgradeSeriesLock(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(mm.unpinMachineApplications(arg.Entity.Tag, unitNames))
+}
+
+// upgradeSeriesLockUnitNames returns the names of the units recorded in
+// the machine's upgrade-series lock, which are those whose applications
+// were pinned when the upgrade was prepared; units added to the machine
+// since then were not.
+func upgradeSeriesLockUnitNames(machine Machine) ([]string, error) {
+	statuses, err := machine.UpgradeSeriesUnitStatuses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, 0, len(statuses))
+	for unitName := range statuses {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+	return unitNames, nil
+}
+
+// pinMachineApplications pins the leadership of the applications of the
+// input units on behalf of the machine. Lease stores that do not support
+// pinning are tolerated, as upgrades could otherwise never proceed. If
+// any application cannot be pinned, those already pinned are unpinned.
+func (mm *MachineManagerAPI) pinMachineApplications(machineTag names.MachineTag, unitNames []string) error {
+	var pinned []string
+	for _, appName := range applicationNames(unitNames) {
+		err := mm.leadership.PinLeadership(appName, machineTag.String())
+		if errors.IsNotImplemented(err) {
+			logger.Warningf("not pinning leadership for %q: %v", appName, err)
+			return nil
+		}
+		if err != nil {
+			err = errors.Annotatef(err, "pinning leadership for %q", appName)
+			for _, pinnedName := range pinned {
+				if err2 := mm.leadership.UnpinLeadership(pinnedName, machineTag.String()); err2 != nil {
+					logger.Errorf("cannot unpin leadership for %q: %v", pinnedName, err2)
+				}
+			}
+			return err
+		}
+		pinned = append(pinned, appName)
+	}
+	return nil
+}
+
+// unpinMachineApplications removes the machine's pins on the leadership of
+// the applications of the input units.
+func (mm *MachineManagerAPI) unpinMachineApplications(machineTag string, unitNames []string) error {
+	for _, appName := range applicationNames(unitNames) {
+		err := mm.leadership.UnpinLeadership(appName, machineTag)
+		if errors.IsNotImplemented(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Annotatef(err, "unpinning leadership for %q", appName)
+		}
+	}
+	return nil
+}
+
+// applicationNames returns the distinct names of the
+// applications for the input unit names, in order.
+func applicationNames(unitNames []string) []string {
+	var appNames []string
+	seen := make(map[string]bool)
+	for _, unitName := range unitNames {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil || seen[appName] {
+			continue
+		}
+		seen[appName] = true
+		appNames = append(appNames, appName)
+	}
+	return appNames
 }
 
 // WatchUpgradeSeriesNotifications returns a watcher that fires on upgrade series events.
@@ -624,23 +740,11 @@ func (mm *MachineManagerAPI) updateOneMachineSeries(arg params.UpdateSeriesArg)
 }
 
 func (mm *MachineManagerAPI) validateSeries(argumentSeries, currentSeries string, machineTag string) error {
-	if argumentSeries == "" {
-		return &params.Error{
-			Message: "series missing from args",
-			Code:    params.CodeBadRequest,
-		}
+	if err := validateTargetSeries(argumentSeries); err != nil {
+		return err
 	}
 
-	opSys, err := series.GetOSFromSeries(argumentSeries)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	if opSys != os.Ubuntu {
-		return errors.Errorf("series %q is from OS %q and is not a valid upgrade target",
-			argumentSeries, opSys.String())
-	}
-
-	opSys, err = series.GetOSFromSeries(currentSeries)
+	opSys, err := series.GetOSFromSeries(currentSeries)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -664,3 +768,23 @@ func (mm *MachineManagerAPI) validateSeries(argumentSeries, currentSeries string
 
 	return nil
 }
+
+// validateTargetSeries checks that the input series is an Ubuntu series
+// that machines can be upgraded to.
+func validateTargetSeries(argumentSeries string) error {
+	if argumentSeries == "" {
+		return &params.Error{
+			Message: "series missing from args",
+			Code:    params.CodeBadRequest,
+		}
+	}
+	opSys, err := series.GetOSFromSeries(argumentSeries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if opSys != os.Ubuntu {
+		return errors.Errorf("series %q is from OS %q and is not a valid upgrade target",
+			argumentSeries, opSys.String())
+	}
+	return nil
+}
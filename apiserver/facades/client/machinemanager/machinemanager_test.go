@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
@@ -34,6 +35,7 @@ type MachineManagerSuite struct {
 	authorizer *apiservertesting.FakeAuthorizer
 	st         *mockState
 	pool       *mockPool
+	leadership *mockLeadership
 	api        *machinemanager.MachineManagerAPI
 
 	callContext context.ProviderCallContext
@@ -41,7 +43,7 @@ type MachineManagerSuite struct {
 
 func (s *MachineManagerSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authorizer.Tag = user
-	mm, err := machinemanager.NewMachineManagerAPI(s.st, s.st, s.pool, s.authorizer, s.st.ModelTag(), s.callContext, common.NewResources())
+	mm, err := machinemanager.NewMachineManagerAPI(s.st, s.st, s.pool, s.authorizer, s.st.ModelTag(), s.callContext, common.NewResources(), s.leadership)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = mm
 }
@@ -50,10 +52,11 @@ func (s *MachineManagerSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
 	s.st = &mockState{machines: make(map[string]*mockMachine)}
 	s.pool = &mockPool{}
+	s.leadership = &mockLeadership{}
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
 	s.callContext = context.NewCloudCallContext()
 	var err error
-	s.api, err = machinemanager.NewMachineManagerAPI(s.st, s.st, s.pool, s.authorizer, s.st.ModelTag(), s.callContext, common.NewResources())
+	s.api, err = machinemanager.NewMachineManagerAPI(s.st, s.st, s.pool, s.authorizer, s.st.ModelTag(), s.callContext, common.NewResources(), s.leadership)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
@@ -110,7 +113,7 @@ func (s *MachineManagerSuite) TestAddMachines(c *gc.C) {
 func (s *MachineManagerSuite) TestNewMachineManagerAPINonClient(c *gc.C) {
 	tag := names.NewUnitTag("mysql/0")
 	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: tag}
-	_, err := machinemanager.NewMachineManagerAPI(nil, nil, nil, s.authorizer, names.ModelTag{}, s.callContext, common.NewResources())
+	_, err := machinemanager.NewMachineManagerAPI(nil, nil, nil, s.authorizer, names.ModelTag{}, s.callContext, common.NewResources(), s.leadership)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 }
 
@@ -465,6 +468,68 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
 	c.Assert(len(mach.Calls()), gc.Equals, 3)
 	mach.CheckCallNames(c, "Principals", "VerifyUnitsSeries", "CreateUpgradeSeriesLock")
 	mach.CheckCall(c, 2, "CreateUpgradeSeriesLock", []string{"foo/0", "test/0"}, "xenial")
+
+	s.leadership.CheckCalls(c, []jtesting.StubCall{
+		{"PinLeadership", []interface{}{"foo", machineTag.String()}},
+		{"PinLeadership", []interface{}{"test", machineTag.String()}},
+	})
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPreparePinNotImplemented(c *gc.C) {
+	s.setupUpdateMachineSeries(c)
+	s.st.machines["0"].unitAgentState = status.Idle
+	s.leadership.SetErrors(errors.NotImplementedf("lease pinning"))
+
+	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	result, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArg{
+			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
+			Series: "xenial",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	s.leadership.CheckCallNames(c, "PinLeadership")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPreparePinError(c *gc.C) {
+	s.setupUpdateMachineSeries(c)
+	s.st.machines["0"].unitAgentState = status.Idle
+	s.leadership.SetErrors(errors.New("boom"))
+
+	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	result, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArg{
+			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
+			Series: "xenial",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `pinning leadership for "foo": boom`)
+	s.st.machines["0"].CheckCallNames(c,
+		"Principals", "VerifyUnitsSeries", "CreateUpgradeSeriesLock", "RemoveUpgradeSeriesLock")
+}
+
+func (s *MachineManagerSuite) TestUpgradeSeriesPreparePinErrorUnpins(c *gc.C) {
+	s.setupUpdateMachineSeries(c)
+	s.st.machines["0"].unitAgentState = status.Idle
+	s.leadership.SetErrors(nil, errors.New("boom"))
+
+	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	machineTag := names.NewMachineTag("0")
+	result, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArg{
+			Entity: params.Entity{Tag: machineTag.String()},
+			Series: "xenial",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `pinning leadership for "test": boom`)
+	s.leadership.CheckCalls(c, []jtesting.StubCall{
+		{"PinLeadership", []interface{}{"foo", machineTag.String()}},
+		{"PinLeadership", []interface{}{"test", machineTag.String()}},
+		{"UnpinLeadership", []interface{}{"foo", machineTag.String()}},
+	})
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesPrepareMachineNotFound(c *gc.C) {
@@ -573,6 +638,10 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepareRemoveLockAfterFail(c *gc.
 
 func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
 	s.setupUpdateMachineSeries(c)
+	// Only the applications of the units in the upgrade-series lock
+	// were pinned; a unit added since is not unpinned.
+	s.st.machines["0"].lockedUnits = []string{"foo/0", "test/0"}
+	s.st.machines["0"].units = []string{"foo/0", "test/0", "bar/0"}
 	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
 	_, err := apiV5.UpgradeSeriesComplete(
 		params.UpdateSeriesArg{
@@ -580,6 +649,11 @@ func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
+	s.st.machines["0"].CheckCallNames(c, "UpgradeSeriesUnitStatuses", "CompleteUpgradeSeries")
+	s.leadership.CheckCalls(c, []jtesting.StubCall{
+		{"UnpinLeadership", []interface{}{"foo", "machine-0"}},
+		{"UnpinLeadership", []interface{}{"test", "machine-0"}},
+	})
 }
 
 // TestIsSeriesLessThan tests a validation method which is not very complicated
@@ -625,6 +699,7 @@ type mockState struct {
 	calls            int
 	machineTemplates []state.MachineTemplate
 	machines         map[string]*mockMachine
+	applications     map[string]*mockApplication
 	err              error
 	blockMsg         string
 	block            state.BlockType
@@ -693,6 +768,14 @@ func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
 	}
 }
 
+func (st *mockState) Application(name string) (machinemanager.Application, error) {
+	if app, ok := st.applications[name]; !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	} else {
+		return app, nil
+	}
+}
+
 func (st *mockState) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
 	return &mockStorage{
 		tag:  tag,
@@ -744,6 +827,9 @@ type mockMachine struct {
 	series         string
 	units          []string
 	unitAgentState status.Status
+
+	upgradeSeriesStatus model.UpgradeSeriesStatus
+	lockedUnits         []string
 }
 
 func (m *mockMachine) Destroy() error {
@@ -806,6 +892,39 @@ func (m *mockMachine) CompleteUpgradeSeries() error {
 	return m.NextErr()
 }
 
+func (m *mockMachine) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
+	m.MethodCall(m, "UpgradeSeriesStatus")
+	return m.upgradeSeriesStatus, m.NextErr()
+}
+
+func (m *mockMachine) UpgradeSeriesUnitStatuses() (map[string]state.UpgradeSeriesUnitStatus, error) {
+	m.MethodCall(m, "UpgradeSeriesUnitStatuses")
+	statuses := make(map[string]state.UpgradeSeriesUnitStatus)
+	for _, name := range m.lockedUnits {
+		statuses[name] = state.UpgradeSeriesUnitStatus{Status: model.UpgradeSeriesCompleteRunning}
+	}
+	return statuses, m.NextErr()
+}
+
+type mockLeadership struct {
+	jtesting.Stub
+}
+
+func (l *mockLeadership) PinLeadership(applicationId, entity string) error {
+	l.MethodCall(l, "PinLeadership", applicationId, entity)
+	return l.NextErr()
+}
+
+func (l *mockLeadership) UnpinLeadership(applicationId, entity string) error {
+	l.MethodCall(l, "UnpinLeadership", applicationId, entity)
+	return l.NextErr()
+}
+
+func (l *mockLeadership) PinnedLeadership() map[string][]string {
+	l.MethodCall(l, "PinnedLeadership")
+	return nil
+}
+
 type mockUnit struct {
 	tag names.UnitTag
 	sts status.Status
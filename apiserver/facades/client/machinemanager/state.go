@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -18,6 +19,7 @@ type Backend interface {
 	state.CloudAccessor
 
 	Machine(string) (Machine, error)
+	Application(string) (Application, error)
 	Model() (Model, error)
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
@@ -52,6 +54,30 @@ type Machine interface {
 	Principals() []string
 	WatchUpgradeSeriesNotifications() (state.NotifyWatcher, error)
 	GetUpgradeSeriesMessages() ([]string, bool, error)
+	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	UpgradeSeriesUnitStatuses() (map[string]state.UpgradeSeriesUnitStatus, error)
+}
+
+// Application represents the application whose machines have their
+// series upgraded one at a time.
+type Application interface {
+	Name() string
+	StartUpgradeSeries(toSeries string, force bool) error
+	CompleteUpgradeSeries() (string, error)
+	PauseUpgradeSeries() error
+	ResumeUpgradeSeries() error
+	RollbackUpgradeSeries() error
+	UpgradeSeries() (ApplicationUpgradeSeries, error)
+}
+
+// ApplicationUpgradeSeries represents the progress of upgrading the
+// series of an application's machines.
+type ApplicationUpgradeSeries interface {
+	Application() string
+	ToSeries() string
+	Status() state.ApplicationUpgradeSeriesStatus
+	Message() string
+	Machines() []state.ApplicationUpgradeSeriesMachine
 }
 
 type stateShim struct {
@@ -66,6 +92,14 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) Application(name string) (Application, error) {
+	app, err := s.State.Application(name)
+	if err != nil {
+		return nil, err
+	}
+	return applicationShim{app}, nil
+}
+
 func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
@@ -82,6 +116,18 @@ func (p *poolShim) GetModel(uuid string) (Model, func(), error) {
 	return m, func() { ph.Release() }, nil
 }
 
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) UpgradeSeries() (ApplicationUpgradeSeries, error) {
+	upgrade, err := a.Application.UpgradeSeries()
+	if err != nil {
+		return nil, err
+	}
+	return upgrade, nil
+}
+
 type machineShim struct {
 	*state.Machine
 }
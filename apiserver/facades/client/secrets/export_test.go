@@ -0,0 +1,13 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+)
+
+func NewAPIForTest(backend Backend, blocks common.BlockGetter, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(backend, blocks, authorizer)
+}
@@ -0,0 +1,249 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the API facade for managing the secrets
+// that applications in a model are granted access to.
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state methods needed by the Secrets facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	AddSecret(state.AddSecretArgs) error
+	AllSecrets() ([]Secret, error)
+	Secret(name string) (Secret, error)
+	SecretRevisions(name string) ([]state.SecretRevision, error)
+	SecretValue(name string, revision int) (map[string]string, error)
+	GrantSecret(name, appName string) error
+	RevokeSecret(name, appName string) error
+}
+
+// Secret describes a secret, as provided by *state.Secret.
+type Secret interface {
+	Name() string
+	Description() string
+	Owner() string
+	Revision() int
+	RotateInterval() time.Duration
+	NextRotateTime() time.Time
+	Grants() []string
+	Created() time.Time
+	Updated() time.Time
+}
+
+// API implements the Secrets facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      *common.BlockChecker
+}
+
+// NewAPI returns a new Secrets facade for the model.
+func NewAPI(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newAPI(stateBackend{m}, st, ctx.Auth())
+}
+
+func newAPI(backend Backend, blocks common.BlockGetter, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      common.NewBlockChecker(blocks),
+	}, nil
+}
+
+func (api *API) checkAccess(access permission.Access) error {
+	ok, err := api.authorizer.HasPermission(access, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// AddSecrets adds secrets to the model. Secrets added by users have no
+// owner, so they are never rotated.
+func (api *API) AddSecrets(args params.AddSecretArgs) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.backend.AddSecret(state.AddSecretArgs{
+			Name:        arg.Name,
+			Description: arg.Description,
+			Data:        arg.Data,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListSecrets returns all of the secrets in the model, without their
+// values.
+func (api *API) ListSecrets() (params.Secrets, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.Secrets{}, errors.Trace(err)
+	}
+	secrets, err := api.backend.AllSecrets()
+	if err != nil {
+		return params.Secrets{}, errors.Trace(err)
+	}
+	result := params.Secrets{
+		Secrets: make([]params.Secret, len(secrets)),
+	}
+	for i, secret := range secrets {
+		result.Secrets[i] = toParams(secret)
+	}
+	return result, nil
+}
+
+// ShowSecrets returns the named secrets with their revision history.
+// Revealing the values of a secret needs admin access to the model.
+func (api *API) ShowSecrets(args params.ShowSecretArgs) (params.SecretResults, error) {
+	if err := api.checkAccess(permission.ReadAccess); err != nil {
+		return params.SecretResults{}, errors.Trace(err)
+	}
+	results := params.SecretResults{
+		Results: make([]params.SecretResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		secret, err := api.showSecret(arg)
+		results.Results[i].Result = secret
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) showSecret(arg params.ShowSecretArg) (*params.Secret, error) {
+	if arg.Reveal {
+		if err := api.checkAccess(permission.AdminAccess); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	secret, err := api.backend.Secret(arg.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := toParams(secret)
+	revisions, err := api.backend.SecretRevisions(arg.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, revision := range revisions {
+		result.Revisions = append(result.Revisions, params.SecretRevision{
+			Revision: revision.Revision,
+			Created:  revision.Created,
+		})
+	}
+	if arg.Reveal {
+		result.Value, err = api.backend.SecretValue(arg.Name, arg.Revision)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &result, nil
+}
+
+func toParams(secret Secret) params.Secret {
+	result := params.Secret{
+		Name:           secret.Name(),
+		Description:    secret.Description(),
+		Owner:          secret.Owner(),
+		Revision:       secret.Revision(),
+		RotateInterval: secret.RotateInterval(),
+		Grants:         secret.Grants(),
+		Created:        secret.Created(),
+		Updated:        secret.Updated(),
+	}
+	if next := secret.NextRotateTime(); !next.IsZero() {
+		result.NextRotateTime = &next
+	}
+	return result
+}
+
+// GrantSecrets allows applications to read secrets. The units of an
+// application can read the secrets granted to it, so granting needs
+// the same admin access to the model as revealing a secret's value.
+func (api *API) GrantSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return api.changeGrants(args, api.backend.GrantSecret)
+}
+
+// RevokeSecrets stops applications from reading secrets they were
+// granted.
+func (api *API) RevokeSecrets(args params.GrantSecretArgs) (params.ErrorResults, error) {
+	return api.changeGrants(args, api.backend.RevokeSecret)
+}
+
+func (api *API) changeGrants(args params.GrantSecretArgs, change func(name, appName string) error) (params.ErrorResults, error) {
+	if err := api.checkAccess(permission.AdminAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := change(arg.Name, arg.Application)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// stateBackend adapts *state.Model to Backend.
+type stateBackend struct {
+	*state.Model
+}
+
+func (b stateBackend) AddSecret(args state.AddSecretArgs) error {
+	_, err := b.Model.AddSecret(args)
+	return err
+}
+
+func (b stateBackend) AllSecrets() ([]Secret, error) {
+	secrets, err := b.Model.AllSecrets()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Secret, len(secrets))
+	for i, secret := range secrets {
+		result[i] = secret
+	}
+	return result, nil
+}
+
+func (b stateBackend) Secret(name string) (Secret, error) {
+	secret, err := b.Model.Secret(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secret, nil
+}
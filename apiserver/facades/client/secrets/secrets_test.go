@@ -0,0 +1,339 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/secrets"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type secretsSuite struct {
+	testing.IsolationSuite
+
+	backend    *fakeBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&secretsSuite{})
+
+func (s *secretsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+}
+
+func (s *secretsSuite) newAPI(c *gc.C) *secrets.API {
+	api, err := secrets.NewAPIForTest(s.backend, s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *secretsSuite) TestNonClientDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := secrets.NewAPIForTest(s.backend, s.backend, s.authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestAddSecrets(c *gc.C) {
+	s.backend.SetErrors(nil, nil, nil, errors.AlreadyExistsf(`secret "api-key"`))
+	results, err := s.newAPI(c).AddSecrets(params.AddSecretArgs{
+		Args: []params.AddSecretArg{{
+			Name:        "db-password",
+			Description: "the database password",
+			Data:        map[string]string{"password": "secret"},
+		}, {
+			Name: "api-key",
+			Data: map[string]string{"key": "abc"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `secret "api-key" already exists`)
+	s.backend.CheckCallNames(c, "ModelTag", "GetBlockForType", "AddSecret", "AddSecret")
+	s.backend.CheckCall(c, 2, "AddSecret", state.AddSecretArgs{
+		Name:        "db-password",
+		Description: "the database password",
+		Data:        map[string]string{"password": "secret"},
+	})
+}
+
+func (s *secretsSuite) TestAddSecretsNeedsWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).AddSecrets(params.AddSecretArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestAddSecretsBlocked(c *gc.C) {
+	s.backend.blocked = true
+	_, err := s.newAPI(c).AddSecrets(params.AddSecretArgs{})
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+}
+
+func (s *secretsSuite) TestListSecrets(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	next := created.Add(time.Hour)
+	s.backend.secrets = []secrets.Secret{
+		&fakeSecret{
+			name:     "db-password",
+			owner:    "mysql",
+			revision: 2,
+			interval: time.Hour,
+			next:     next,
+			grants:   []string{"wordpress"},
+			created:  created,
+			updated:  created,
+		},
+		&fakeSecret{
+			name:     "api-key",
+			revision: 1,
+			created:  created,
+			updated:  created,
+		},
+	}
+	result, err := s.newAPI(c).ListSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.Secrets{
+		Secrets: []params.Secret{{
+			Name:           "db-password",
+			Owner:          "mysql",
+			Revision:       2,
+			RotateInterval: time.Hour,
+			NextRotateTime: &next,
+			Grants:         []string{"wordpress"},
+			Created:        created,
+			Updated:        created,
+		}, {
+			Name:     "api-key",
+			Revision: 1,
+			Created:  created,
+			Updated:  created,
+		}},
+	})
+}
+
+func (s *secretsSuite) TestListSecretsNeedsRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).ListSecrets()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *secretsSuite) TestShowSecrets(c *gc.C) {
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.secrets = []secrets.Secret{&fakeSecret{
+		name:     "api-key",
+		revision: 1,
+		created:  created,
+		updated:  created,
+	}}
+	s.backend.SetErrors(nil, nil, nil, errors.NotFoundf(`secret "missing"`))
+	results, err := s.newAPI(c).ShowSecrets(params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{Name: "api-key"}, {Name: "missing"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.SecretResults{
+		Results: []params.SecretResult{{
+			Result: &params.Secret{
+				Name:      "api-key",
+				Revision:  1,
+				Created:   created,
+				Updated:   created,
+				Revisions: []params.SecretRevision{{Revision: 1, Created: created}},
+			},
+		}, {
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `secret "missing" not found`,
+			},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "Secret", "SecretRevisions", "Secret")
+}
+
+func (s *secretsSuite) TestShowSecretsReveal(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.backend.secrets = []secrets.Secret{&fakeSecret{name: "api-key", revision: 2}}
+	results, err := s.newAPI(c).ShowSecrets(params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{Name: "api-key", Revision: 1, Reveal: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Result.Value, jc.DeepEquals, map[string]string{"key": "abc"})
+	s.backend.CheckCall(c, 4, "SecretValue", "api-key", 1)
+}
+
+func (s *secretsSuite) TestShowSecretsRevealNeedsAdmin(c *gc.C) {
+	results, err := s.newAPI(c).ShowSecrets(params.ShowSecretArgs{
+		Args: []params.ShowSecretArg{{Name: "api-key", Reveal: true}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag", "ModelTag")
+}
+
+func (s *secretsSuite) TestGrantSecrets(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	results, err := s.newAPI(c).GrantSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: "db-password", Application: "wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "ModelTag", "GetBlockForType", "GrantSecret")
+	s.backend.CheckCall(c, 2, "GrantSecret", "db-password", "wordpress")
+}
+
+func (s *secretsSuite) TestGrantSecretsNeedsAdmin(c *gc.C) {
+	_, err := s.newAPI(c).GrantSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: "db-password", Application: "wordpress"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *secretsSuite) TestRevokeSecrets(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("admin")
+	s.backend.SetErrors(nil, nil, nil, errors.NotFoundf(`secret "missing"`))
+	results, err := s.newAPI(c).RevokeSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{
+			{Name: "db-password", Application: "wordpress"},
+			{Name: "missing", Application: "wordpress"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `secret "missing" not found`)
+	s.backend.CheckCallNames(c, "ModelTag", "GetBlockForType", "RevokeSecret", "RevokeSecret")
+	s.backend.CheckCall(c, 2, "RevokeSecret", "db-password", "wordpress")
+}
+
+func (s *secretsSuite) TestRevokeSecretsNeedsAdmin(c *gc.C) {
+	_, err := s.newAPI(c).RevokeSecrets(params.GrantSecretArgs{
+		Args: []params.GrantSecretArg{{Name: "db-password", Application: "wordpress"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+type fakeBackend struct {
+	testing.Stub
+	secrets []secrets.Secret
+	blocked bool
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
+	b.MethodCall(b, "GetBlockForType", t)
+	b.PopNoErr()
+	if b.blocked {
+		return fakeBlock{}, true, nil
+	}
+	return nil, false, nil
+}
+
+func (b *fakeBackend) AddSecret(args state.AddSecretArgs) error {
+	b.MethodCall(b, "AddSecret", args)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) AllSecrets() ([]secrets.Secret, error) {
+	b.MethodCall(b, "AllSecrets")
+	return b.secrets, b.NextErr()
+}
+
+func (b *fakeBackend) Secret(name string) (secrets.Secret, error) {
+	b.MethodCall(b, "Secret", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.findSecret(name)
+}
+
+func (b *fakeBackend) findSecret(name string) (secrets.Secret, error) {
+	for _, secret := range b.secrets {
+		if secret.Name() == name {
+			return secret, nil
+		}
+	}
+	return nil, errors.NotFoundf("secret %q", name)
+}
+
+func (b *fakeBackend) SecretRevisions(name string) ([]state.SecretRevision, error) {
+	b.MethodCall(b, "SecretRevisions", name)
+	secret, err := b.findSecret(name)
+	if err != nil {
+		return nil, err
+	}
+	var revisions []state.SecretRevision
+	for i := 1; i <= secret.Revision(); i++ {
+		revisions = append(revisions, state.SecretRevision{
+			Revision: i,
+			Created:  secret.Created(),
+		})
+	}
+	return revisions, b.NextErr()
+}
+
+func (b *fakeBackend) SecretValue(name string, revision int) (map[string]string, error) {
+	b.MethodCall(b, "SecretValue", name, revision)
+	return map[string]string{"key": "abc"}, b.NextErr()
+}
+
+func (b *fakeBackend) GrantSecret(name, appName string) error {
+	b.MethodCall(b, "GrantSecret", name, appName)
+	return b.NextErr()
+}
+
+func (b *fakeBackend) RevokeSecret(name, appName string) error {
+	b.MethodCall(b, "RevokeSecret", name, appName)
+	return b.NextErr()
+}
+
+type fakeBlock struct {
+	state.Block
+}
+
+func (fakeBlock) Message() string {
+	return "no changes"
+}
+
+type fakeSecret struct {
+	name, description, owner string
+	revision                 int
+	interval                 time.Duration
+	next, created, updated   time.Time
+	grants                   []string
+}
+
+func (s *fakeSecret) Name() string                  { return s.name }
+func (s *fakeSecret) Description() string           { return s.description }
+func (s *fakeSecret) Owner() string                 { return s.owner }
+func (s *fakeSecret) Revision() int                 { return s.revision }
+func (s *fakeSecret) RotateInterval() time.Duration { return s.interval }
+func (s *fakeSecret) NextRotateTime() time.Time     { return s.next }
+func (s *fakeSecret) Grants() []string              { return s.grants }
+func (s *fakeSecret) Created() time.Time            { return s.created }
+func (s *fakeSecret) Updated() time.Time            { return s.updated }
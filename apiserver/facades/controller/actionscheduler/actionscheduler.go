@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides the API facade used by the action
+// scheduler worker to queue scheduled actions when they are due.
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+)
+
+// Backend defines the state methods needed by the ActionScheduler
+// facade.
+type Backend interface {
+	RunDueActionSchedules() error
+}
+
+// API implements the ActionScheduler facade.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new ActionScheduler facade for the model.
+func NewAPI(ctx facade.Context) (*API, error) {
+	m, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newAPI(m, ctx.Auth())
+}
+
+func newAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// RunDueSchedules queues the actions of all action schedules in the
+// model that are due to run.
+func (api *API) RunDueSchedules() error {
+	return errors.Trace(api.backend.RunDueActionSchedules())
+}
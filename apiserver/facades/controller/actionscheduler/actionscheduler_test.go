@@ -0,0 +1,53 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+)
+
+type actionSchedulerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) TestNonControllerDenied(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	_, err := actionscheduler.NewAPIForTest(&fakeBackend{}, authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *actionSchedulerSuite) TestRunDueSchedules(c *gc.C) {
+	backend := &fakeBackend{}
+	backend.SetErrors(nil, errors.New("boom"))
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	api, err := actionscheduler.NewAPIForTest(backend, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.RunDueSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.RunDueSchedules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	backend.CheckCallNames(c, "RunDueActionSchedules", "RunDueActionSchedules")
+}
+
+type fakeBackend struct {
+	testing.Stub
+}
+
+func (b *fakeBackend) RunDueActionSchedules() error {
+	b.MethodCall(b, "RunDueActionSchedules")
+	return b.NextErr()
+}
@@ -114,6 +114,7 @@ type mockApplication struct {
 	ops        *state.UpdateUnitsOperation
 	providerId string
 	addresses  []network.Address
+	autoscale  *application.AutoscalePolicy
 }
 
 func (*mockApplication) Tag() names.Tag {
@@ -140,6 +141,15 @@ func (a *mockApplication) GetScale() int {
 	return 5
 }
 
+func (a *mockApplication) Scale(scale int) error {
+	a.MethodCall(a, "Scale", scale)
+	return a.NextErr()
+}
+
+func (a *mockApplication) AutoscalePolicy() *application.AutoscalePolicy {
+	return a.autoscale
+}
+
 func (a *mockApplication) GetPlacement() string {
 	a.MethodCall(a, "GetPlacement")
 	return "placement"
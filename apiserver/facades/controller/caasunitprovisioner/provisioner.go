@@ -311,14 +311,24 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 		modelConfig,
 	)
 
-	return &params.KubernetesProvisioningInfo{
+	info := &params.KubernetesProvisioningInfo{
 		PodSpec:     podSpec,
 		Filesystems: filesystemParams,
 		Devices:     devices,
 		Constraints: cons,
 		Placement:   app.GetPlacement(),
 		Tags:        resourceTags,
-	}, nil
+	}
+	if policy := app.AutoscalePolicy(); policy != nil {
+		info.Autoscale = &params.AutoscalePolicy{
+			MinUnits:         policy.MinUnits,
+			MaxUnits:         policy.MaxUnits,
+			TargetCPUPercent: policy.TargetCPUPercent,
+			Metric:           policy.Metric,
+			MetricTarget:     policy.MetricTarget,
+		}
+	}
+	return info, nil
 }
 
 func filesystemParams(
@@ -489,6 +499,9 @@ func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs)
 			continue
 		}
 		err = a.updateUnitsFromCloud(app, appUpdate.Units)
+		if err == nil {
+			err = followAutoscaledScale(app, appUpdate.Units)
+		}
 		if err != nil {
 			// Mask any not found errors as the worker (caller) treats them specially
 			// and they are not relevant here.
@@ -498,6 +511,29 @@ func (a *Facade) UpdateApplicationsUnits(args params.UpdateApplicationUnitArgs)
 	return result, nil
 }
 
+// followAutoscaledScale records the number of pods the cloud is running
+// as the desired scale of an autoscaled application, so that the model
+// follows the scaling decisions made by the substrate. Pods that are
+// still starting, or are being terminated, are not counted.
+func followAutoscaledScale(app Application, units []params.ApplicationUnitParams) error {
+	policy := app.AutoscalePolicy()
+	if policy == nil || app.Life() != state.Alive {
+		return nil
+	}
+	running := 0
+	for _, u := range units {
+		if status.Status(u.Status) == status.Running {
+			running++
+		}
+	}
+	scale := policy.Clamp(running)
+	if scale == app.GetScale() {
+		return nil
+	}
+	logger.Debugf("autoscaled application %q now has scale %d", app.Name(), scale)
+	return errors.Trace(app.Scale(scale))
+}
+
 // updateStatus constructs the unit and agent status values based on the pod status.
 func (a *Facade) updateStatus(params params.ApplicationUnitParams) (
 	agentStatus *status.StatusInfo,
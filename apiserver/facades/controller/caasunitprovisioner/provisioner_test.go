@@ -19,6 +19,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
@@ -215,6 +216,25 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	s.storagePoolManager.CheckCallNames(c, "Get")
 }
 
+func (s *CAASProvisionerSuite) TestProvisioningInfoAutoscale(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", life: state.Dying},
+	}
+	s.st.application.autoscale = &application.AutoscalePolicy{
+		MinUnits: 2, MaxUnits: 5, TargetCPUPercent: 80,
+	}
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.Autoscale, jc.DeepEquals, &params.AutoscalePolicy{
+		MinUnits: 2, MaxUnits: 5, TargetCPUPercent: 80,
+	})
+}
+
 func (s *CAASProvisionerSuite) TestApplicationScale(c *gc.C) {
 	results, err := s.facade.ApplicationsScale(params.Entities{
 		Entities: []params.Entity{
@@ -377,6 +397,68 @@ func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsNotAlive(c *gc.C) {
 	s.st.application.units[2].(*mockUnit).CheckCallNames(c, "Life")
 }
 
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsAutoscaled(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", containerInfo: &mockContainerInfo{providerId: "another-uuid"}, life: state.Alive},
+	}
+	s.st.application.autoscale = &application.AutoscalePolicy{
+		MinUnits: 1, MaxUnits: 10, TargetCPUPercent: 80,
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "running", Info: "message"},
+		{ProviderId: "another-uuid", Address: "another-address", Ports: []string{"another-port"},
+			Status: "running", Info: "another message"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+		},
+	})
+	s.st.application.CheckCallNames(c, "Life", "Name", "Life", "GetScale", "Name", "Scale")
+	s.st.application.CheckCall(c, 5, "Scale", 2)
+}
+
+func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsAutoscaledCountsRunningPods(c *gc.C) {
+	s.st.application.units = []caasunitprovisioner.Unit{
+		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
+		&mockUnit{name: "gitlab/1", containerInfo: &mockContainerInfo{providerId: "another-uuid"}, life: state.Alive},
+	}
+	s.st.application.autoscale = &application.AutoscalePolicy{
+		MinUnits: 1, MaxUnits: 10, TargetCPUPercent: 80,
+	}
+
+	units := []params.ApplicationUnitParams{
+		{ProviderId: "uuid", Address: "address", Ports: []string{"port"},
+			Status: "running", Info: "message"},
+		{ProviderId: "another-uuid", Address: "another-address", Ports: []string{"another-port"},
+			Status: "allocating", Info: "another message"},
+	}
+	args := params.UpdateApplicationUnitArgs{
+		Args: []params.UpdateApplicationUnits{
+			{ApplicationTag: "application-gitlab", Units: units},
+		},
+	}
+	results, err := s.facade.UpdateApplicationsUnits(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+		},
+	})
+	s.st.application.CheckCallNames(c, "Life", "Name", "Life", "GetScale", "Name", "Scale")
+	s.st.application.CheckCall(c, 5, "Scale", 1)
+}
+
 func (s *CAASProvisionerSuite) TestUpdateApplicationsUnitsWithStorage(c *gc.C) {
 	s.st.application.units = []caasunitprovisioner.Unit{
 		&mockUnit{name: "gitlab/0", containerInfo: &mockContainerInfo{providerId: "uuid"}, life: state.Alive},
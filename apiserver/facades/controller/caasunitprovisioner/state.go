@@ -67,6 +67,8 @@ type Model interface {
 // required by the CAAS unit provisioner facade.
 type Application interface {
 	GetScale() int
+	Scale(int) error
+	AutoscalePolicy() *application.AutoscalePolicy
 	WatchScale() state.NotifyWatcher
 	ApplicationConfig() (application.ConfigAttributes, error)
 	AllUnits() (units []Unit, err error)
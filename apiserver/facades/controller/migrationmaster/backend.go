@@ -21,6 +21,7 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	AllCharmStates() (map[string]map[string]string, error)
 
 	migration.StateExporter
 }
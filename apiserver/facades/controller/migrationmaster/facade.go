@@ -32,6 +32,12 @@ type API struct {
 	presence        facade.Presence
 }
 
+// APIV1 implements version 1 of the MigrationMaster API, which can't
+// transfer charm state.
+type APIV1 struct {
+	*API
+}
+
 // NewAPI creates a new API server endpoint for the model migration
 // master worker.
 func NewAPI(
@@ -206,6 +212,28 @@ func (api *API) Export() (params.SerializedModel, error) {
 	serialized.Charms = getUsedCharms(model)
 	serialized.Tools = getUsedTools(model)
 	serialized.Resources = getUsedResources(model)
+
+	charmStates, err := api.backend.AllCharmStates()
+	if err != nil {
+		return serialized, err
+	}
+	if len(charmStates) > 0 {
+		serialized.CharmStates = charmStates
+	}
+	return serialized, nil
+}
+
+// Export serializes the model associated with the API connection.
+// Version 1 clients don't know about charm state, so a model that has
+// any is refused rather than having its charm state dropped.
+func (api *APIV1) Export() (params.SerializedModel, error) {
+	serialized, err := api.API.Export()
+	if err != nil {
+		return serialized, err
+	}
+	if len(serialized.CharmStates) > 0 {
+		return params.SerializedModel{}, errors.NotSupportedf("exporting charm state with MigrationMaster v1")
+	}
 	return serialized, nil
 }
 
@@ -247,6 +247,10 @@ func (s *Suite) TestExport(c *gc.C) {
 	})
 	unitRev := unitRes.Revision()
 
+	s.backend.charmStates = map[string]map[string]string{
+		"foo/0": {"bar": "baz"},
+	}
+
 	api := s.mustMakeAPI(c)
 	serialized, err := api.Export()
 	c.Assert(err, jc.ErrorIsNil)
@@ -257,6 +261,9 @@ func (s *Suite) TestExport(c *gc.C) {
 	c.Check(string(serialized.Bytes), jc.Contains, jujuversion.Current.String())
 
 	c.Check(serialized.Charms, gc.DeepEquals, []string{"cs:foo-0"})
+	c.Check(serialized.CharmStates, jc.DeepEquals, map[string]map[string]string{
+		"foo/0": {"bar": "baz"},
+	})
 	c.Check(serialized.Tools, jc.SameContents, []params.SerializedModelTools{
 		{tools0, "/tools/" + tools0},
 		{tools1, "/tools/" + tools1},
@@ -303,6 +310,23 @@ func (s *Suite) TestExport(c *gc.C) {
 
 }
 
+func (s *Suite) TestExportV1(c *gc.C) {
+	api := &migrationmaster.APIV1{s.mustMakeAPI(c)}
+	serialized, err := api.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(serialized.Bytes, gc.Not(gc.HasLen), 0)
+	c.Check(serialized.CharmStates, gc.IsNil)
+}
+
+func (s *Suite) TestExportV1RefusesCharmState(c *gc.C) {
+	s.backend.charmStates = map[string]map[string]string{
+		"foo/0": {"bar": "baz"},
+	}
+	api := &migrationmaster.APIV1{s.mustMakeAPI(c)}
+	_, err := api.Export()
+	c.Assert(err, gc.ErrorMatches, "exporting charm state with MigrationMaster v1 not supported")
+}
+
 func (s *Suite) TestReap(c *gc.C) {
 	api := s.mustMakeAPI(c)
 	s.backend.migration = &stubMigration{}
@@ -417,11 +441,12 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationmaster.API {
 type stubBackend struct {
 	migrationmaster.Backend
 
-	stub      *testing.Stub
-	getErr    error
-	removeErr error
-	migration *stubMigration
-	model     description.Model
+	stub        *testing.Stub
+	getErr      error
+	removeErr   error
+	migration   *stubMigration
+	model       description.Model
+	charmStates map[string]map[string]string
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -463,6 +488,11 @@ func (b *stubBackend) Export() (description.Model, error) {
 	return b.model, nil
 }
 
+func (b *stubBackend) AllCharmStates() (map[string]map[string]string, error) {
+	b.stub.AddCall("AllCharmStates")
+	return b.charmStates, nil
+}
+
 type stubMigration struct {
 	state.ModelMigration
 
@@ -31,6 +31,15 @@ func NewFacade(ctx facade.Context) (*API, error) {
 	)
 }
 
+// NewFacadeV1 is used for version 1 of the API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // backendShim wraps a *state.State to implement Backend. It is
 // untested, but is simple enough to be verified by inspection.
 type backendShim struct {
@@ -36,11 +36,26 @@ type API struct {
 	callContext context.ProviderCallContext
 }
 
+// APIV1 implements version 1 of the MigrationTarget API. Its clients
+// never send charm state to Import.
+type APIV1 struct {
+	*API
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(ctx, stateenvirons.GetNewEnvironFunc(environs.New), state.CallContext(ctx.State()))
 }
 
+// NewFacadeV1 is used for version 1 of the API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -114,6 +129,9 @@ func (api *API) Import(serialized params.SerializedModel) error {
 		return err
 	}
 	defer st.Close()
+	if err := st.ImportCharmStates(serialized.CharmStates); err != nil {
+		return errors.Trace(err)
+	}
 	// TODO(mjs) - post import checks
 	// NOTE(fwereade) - checks here would be sensible, but we will
 	// also need to check after the binaries are imported too.
@@ -73,7 +73,7 @@ func (s *Suite) SetUpTest(c *gc.C) {
 }
 
 func (s *Suite) TestFacadeRegistered(c *gc.C) {
-	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 2)
 	c.Assert(err, jc.ErrorIsNil)
 
 	api, err := factory(&facadetest.Context{
@@ -85,6 +85,19 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
+func (s *Suite) TestFacadeRegisteredV1(c *gc.C) {
+	factory, err := apiserver.AllFacades().GetFactory("MigrationTarget", 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+}
+
 func (s *Suite) TestNotUser(c *gc.C) {
 	s.authorizer.Tag = names.NewMachineTag("0")
 	_, err := s.newAPI(nil)
@@ -150,6 +163,34 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportCharmStates(c *gc.C) {
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
+			Name: "wordpress",
+		}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	err := api.Import(params.SerializedModel{
+		Bytes: bytes,
+		CharmStates: map[string]map[string]string{
+			"wordpress/0": {"foo": "bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st, err := s.StatePool.Get(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Release()
+	unit, err := st.Unit("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	charmState, err := unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
 func (s *Suite) TestImportLeadership(c *gc.C) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
@@ -0,0 +1,12 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader
+
+import (
+	"github.com/juju/juju/apiserver/facade"
+)
+
+func NewAPIForTest(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(backend, resources, authorizer)
+}
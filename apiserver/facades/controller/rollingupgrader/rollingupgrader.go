@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package rollingupgrader provides the API facade used by the rolling
+// upgrader worker to release rolling charm upgrades to units in
+// batches.
+package rollingupgrader
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend defines the state methods needed by the RollingUpgrader
+// facade.
+type Backend interface {
+	ProgressRollingUpgrades() error
+	WatchRollingUpgrades() state.NotifyWatcher
+}
+
+// API implements the RollingUpgrader facade.
+type API struct {
+	backend   Backend
+	resources facade.Resources
+}
+
+// NewAPI returns a new RollingUpgrader facade for the model.
+func NewAPI(ctx facade.Context) (*API, error) {
+	m, err := ctx.State().Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newAPI(m, ctx.Resources(), ctx.Auth())
+}
+
+func newAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:   backend,
+		resources: resources,
+	}, nil
+}
+
+// ProgressRollingUpgrades checks the health of the units released by
+// each running rolling upgrade in the model, and releases the next
+// batch of units, or pauses or aborts the upgrade, accordingly.
+func (api *API) ProgressRollingUpgrades() error {
+	return errors.Trace(api.backend.ProgressRollingUpgrades())
+}
+
+// WatchRollingUpgrades returns a NotifyWatcher that triggers when the
+// model's rolling upgrades may need to be progressed.
+func (api *API) WatchRollingUpgrades() (params.NotifyWatchResult, error) {
+	watch := api.backend.WatchRollingUpgrades()
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: api.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{
+		Error: common.ServerError(watcher.EnsureErr(watch)),
+	}, nil
+}
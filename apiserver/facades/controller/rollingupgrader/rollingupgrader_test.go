@@ -0,0 +1,79 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/rollingupgrader"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type rollingUpgraderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&rollingUpgraderSuite{})
+
+func (s *rollingUpgraderSuite) TestNonControllerDenied(c *gc.C) {
+	authorizer := apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	_, err := rollingupgrader.NewAPIForTest(&fakeBackend{}, common.NewResources(), authorizer)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *rollingUpgraderSuite) TestProgressRollingUpgrades(c *gc.C) {
+	backend := &fakeBackend{}
+	backend.SetErrors(nil, errors.New("boom"))
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	api, err := rollingupgrader.NewAPIForTest(backend, common.NewResources(), authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = api.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.ProgressRollingUpgrades()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	backend.CheckCallNames(c, "ProgressRollingUpgrades", "ProgressRollingUpgrades")
+}
+
+func (s *rollingUpgraderSuite) TestWatchRollingUpgrades(c *gc.C) {
+	backend := &fakeBackend{}
+	resources := common.NewResources()
+	s.AddCleanup(func(*gc.C) { resources.StopAll() })
+	authorizer := apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	api, err := rollingupgrader.NewAPIForTest(backend, resources, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := api.WatchRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(resources.Get("1"), gc.NotNil)
+	backend.CheckCallNames(c, "WatchRollingUpgrades")
+}
+
+type fakeBackend struct {
+	testing.Stub
+}
+
+func (b *fakeBackend) WatchRollingUpgrades() state.NotifyWatcher {
+	b.MethodCall(b, "WatchRollingUpgrades")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (b *fakeBackend) ProgressRollingUpgrades() error {
+	b.MethodCall(b, "ProgressRollingUpgrades")
+	return b.NextErr()
+}
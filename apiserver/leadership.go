@@ -65,3 +65,21 @@ func (m leadershipClaimer) BlockUntilLeadershipReleased(applicationname string,
 	}
 	return errors.Trace(err)
 }
+
+// leadershipTransferrer implements leadership.Transferrer by wrapping a
+// lease.Transferrer.
+type leadershipTransferrer struct {
+	transferrer lease.Transferrer
+}
+
+// TransferLeadership is part of the leadership.Transferrer interface.
+func (m leadershipTransferrer) TransferLeadership(applicationname, unitName string, duration time.Duration) error {
+	err := m.transferrer.Transfer(applicationname, unitName, duration)
+	switch errors.Cause(err) {
+	case lease.ErrNotHeld:
+		return errors.NotFoundf("leader of application %q", applicationname)
+	case lease.ErrPinned:
+		return errors.Errorf("leadership of application %q is pinned", applicationname)
+	}
+	return errors.Trace(err)
+}
@@ -30,6 +30,17 @@ const (
 // Actions is a slice of Action for bulk requests.
 type Actions struct {
 	Actions []Action `json:"actions,omitempty"`
+
+	// Parallelism, if non-zero, limits the number of units of each
+	// application that may run the same action at once, including
+	// actions queued by earlier requests. The rest of the actions are
+	// held until others finish.
+	Parallelism int `json:"parallelism,omitempty"`
+
+	// Ordering determines the order in which the actions on the units
+	// of each application are released: in the order given, or with
+	// the leader unit "leader-first" or "leader-last".
+	Ordering string `json:"ordering,omitempty"`
 }
 
 // Action describes an Action that will be or has been queued up.
@@ -143,3 +154,51 @@ type ActionPruneArgs struct {
 	MaxHistoryTime time.Duration `json:"max-history-time"`
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
+
+// AddActionSchedule holds the details of an action schedule to add.
+type AddActionSchedule struct {
+	Name         string                 `json:"name"`
+	Schedule     string                 `json:"schedule"`
+	Units        []string               `json:"units,omitempty"`
+	Leaders      []string               `json:"leaders,omitempty"`
+	Applications []string               `json:"applications,omitempty"`
+	ActionName   string                 `json:"action-name"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// AddActionSchedules holds the action schedules to add.
+type AddActionSchedules struct {
+	Schedules []AddActionSchedule `json:"schedules"`
+}
+
+// ActionSchedule describes an action that is queued on a set of units
+// on a cron-style schedule.
+type ActionSchedule struct {
+	Name         string                 `json:"name"`
+	Schedule     string                 `json:"schedule"`
+	Units        []string               `json:"units,omitempty"`
+	Leaders      []string               `json:"leaders,omitempty"`
+	Applications []string               `json:"applications,omitempty"`
+	ActionName   string                 `json:"action-name"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Created      time.Time              `json:"created"`
+	NextRun      *time.Time             `json:"next-run,omitempty"`
+	Runs         []ActionScheduleRun    `json:"runs,omitempty"`
+}
+
+// ActionScheduleRun records a run of an action schedule.
+type ActionScheduleRun struct {
+	Time      time.Time `json:"time"`
+	ActionIds []string  `json:"action-ids,omitempty"`
+	Errors    []string  `json:"errors,omitempty"`
+}
+
+// ActionSchedules holds a list of action schedules.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleNames holds the names of action schedules.
+type ActionScheduleNames struct {
+	Names []string `json:"names"`
+}
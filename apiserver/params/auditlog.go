@@ -0,0 +1,60 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// AuditLogQuery holds the parameters for searching the controller's
+// audit log. Empty fields match every record.
+type AuditLogQuery struct {
+	// User is the name of the user who made the requests.
+	User string `json:"user,omitempty"`
+
+	// Model is the name ("owner/name") or UUID of the model the
+	// requests were made against.
+	Model string `json:"model,omitempty"`
+
+	Facade string `json:"facade,omitempty"`
+	Method string `json:"method,omitempty"`
+
+	// From and To bound the times the requests were made.
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+
+	// Limit is the maximum number of entries to return. Zero means
+	// no limit.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry describes an API request recorded in the audit log.
+type AuditLogEntry struct {
+	ConversationID string              `json:"conversation-id"`
+	ConnectionID   string              `json:"connection-id"`
+	RequestID      uint64              `json:"request-id"`
+	Who            string              `json:"who"`
+	What           string              `json:"what"`
+	ModelName      string              `json:"model-name"`
+	ModelUUID      string              `json:"model-uuid"`
+	When           time.Time           `json:"when"`
+	Facade         string              `json:"facade"`
+	Method         string              `json:"method"`
+	Version        int                 `json:"version"`
+	Args           string              `json:"args,omitempty"`
+	Errors         []AuditLogErrorInfo `json:"errors,omitempty"`
+}
+
+// AuditLogErrorInfo holds an error returned in response to an audited
+// request.
+type AuditLogErrorInfo struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// AuditLogEntries holds the results of an audit log query, most recent
+// first.
+type AuditLogEntries struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
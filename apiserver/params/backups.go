@@ -73,6 +73,46 @@ type BackupsMetadataResult struct {
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 	Filename     string `json:"filename"`
+
+	// Scheduled is true if the backup was taken by the backup
+	// scheduler.
+	Scheduled bool `json:"scheduled,omitempty"`
+
+	// Encrypted is true if the backup archive is stored encrypted.
+	Encrypted bool `json:"encrypted,omitempty"`
+
+	// Retention holds the reason for which the controller's backup
+	// retention policy keeps a scheduled backup: "daily", "weekly" or
+	// "latest". It is empty for backups that were not scheduled, and
+	// for scheduled backups that will be removed.
+	Retention string `json:"retention,omitempty"`
+}
+
+// BackupsScheduleInfo holds the controller's backup schedule and
+// retention policy, and the state of the scheduled backups.
+type BackupsScheduleInfo struct {
+	// Schedule is the cron-style schedule on which backups are taken,
+	// or empty if backups are not scheduled.
+	Schedule string `json:"schedule,omitempty"`
+
+	// RetainDaily and RetainWeekly are the number of days and weeks
+	// for which scheduled backups are kept.
+	RetainDaily  int `json:"retain-daily"`
+	RetainWeekly int `json:"retain-weekly"`
+
+	// Encrypted is true if stored backup archives are encrypted.
+	Encrypted bool `json:"encrypted"`
+
+	// NextBackup is when the next scheduled backup is due.
+	NextBackup *time.Time `json:"next-backup,omitempty"`
+
+	// LastBackupID and LastBackup are the ID and start time of the
+	// latest stored scheduled backup.
+	LastBackupID string     `json:"last-backup-id,omitempty"`
+	LastBackup   *time.Time `json:"last-backup,omitempty"`
+
+	// ScheduledBackups is the number of stored scheduled backups.
+	ScheduledBackups int `json:"scheduled-backups"`
 }
 
 // RestoreArgs Holds the backup file or id
@@ -366,6 +366,30 @@ type EntityWorkloadVersions struct {
 	Entities []EntityWorkloadVersion `json:"entities"`
 }
 
+// CharmStateResult holds the charm state of a unit, or an error.
+type CharmStateResult struct {
+	Result map[string]string `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// CharmStateResults holds the results of a CharmState API call.
+type CharmStateResults struct {
+	Results []CharmStateResult `json:"results"`
+}
+
+// SetCharmStateArg holds the changes to make to a unit's charm state.
+type SetCharmStateArg struct {
+	Tag   string            `json:"tag"`
+	Set   map[string]string `json:"set,omitempty"`
+	Unset []string          `json:"unset,omitempty"`
+}
+
+// SetCharmStateArgs holds the parameters for making an
+// UpdateCharmState API call.
+type SetCharmStateArgs struct {
+	Args []SetCharmStateArg `json:"args"`
+}
+
 // BytesResult holds the result of an API call that returns a slice
 // of bytes.
 type BytesResult struct {
@@ -701,6 +725,40 @@ type ProvisioningInfoResults struct {
 	Results []ProvisioningInfoResult `json:"results"`
 }
 
+// CharmProfile holds an LXD profile supplied by the charm of an
+// application with units on a machine.
+type CharmProfile struct {
+	Name    string          `json:"name"`
+	Profile CharmLXDProfile `json:"profile"`
+}
+
+// CharmProfilesResult holds the charm LXD profiles required by the
+// units on a machine, and the names of those applied to its instance.
+type CharmProfilesResult struct {
+	InstanceId string         `json:"instance-id"`
+	Required   []CharmProfile `json:"required"`
+	Applied    []string       `json:"applied"`
+	Error      *Error         `json:"error,omitempty"`
+}
+
+// CharmProfilesResults holds multiple charm LXD profile results.
+type CharmProfilesResults struct {
+	Results []CharmProfilesResult `json:"results"`
+}
+
+// SetCharmProfilesArg holds the names of the charm LXD profiles applied
+// to a machine's instance.
+type SetCharmProfilesArg struct {
+	Entity   Entity   `json:"entity"`
+	Profiles []string `json:"profiles"`
+}
+
+// SetCharmProfilesArgs holds the arguments for recording the charm LXD
+// profiles applied to multiple machines.
+type SetCharmProfilesArgs struct {
+	Args []SetCharmProfilesArg `json:"args"`
+}
+
 // Metric holds a single metric.
 type Metric struct {
 	Key    string            `json:"key"`
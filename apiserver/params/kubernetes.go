@@ -16,6 +16,7 @@ type KubernetesProvisioningInfo struct {
 	Filesystems []KubernetesFilesystemParams `json:"filesystems,omitempty"`
 	Volumes     []KubernetesVolumeParams     `json:"volumes,omitempty"`
 	Devices     []KubernetesDeviceParams     `json:"devices,omitempty"`
+	Autoscale   *AutoscalePolicy             `json:"autoscale,omitempty"`
 }
 
 // KubernetesProvisioningInfoResult holds unit provisioning info or an error.
@@ -88,3 +88,39 @@ type MergeLeadershipSettingsParam struct {
 	// Settings are the Leadership settings you wish to merge in.
 	Settings Settings `json:"settings"`
 }
+
+// PinApplicationResult represents the result of a request to pin or
+// unpin the leadership of a single application.
+type PinApplicationResult struct {
+	ApplicationName string `json:"application-name"`
+	Error           *Error `json:"error,omitempty"`
+}
+
+// PinApplicationsResults returns all applications for which pinning or
+// unpinning was attempted, including any errors.
+type PinApplicationsResults struct {
+	Results []PinApplicationResult `json:"results"`
+}
+
+// PinnedLeadershipResult holds the names of applications with pinned
+// leadership, each with the entities that pinned it.
+type PinnedLeadershipResult struct {
+	Result map[string][]string `json:"result,omitempty"`
+}
+
+// TransferLeadershipArgs holds the parameters for making a bulk
+// Application.TransferLeadership call.
+type TransferLeadershipArgs struct {
+	Args []TransferLeadershipArg `json:"args"`
+}
+
+// TransferLeadershipArg holds the parameters for handing the leadership
+// of an application to one of its units.
+type TransferLeadershipArg struct {
+	// ApplicationTag is the application whose leadership is to be
+	// transferred.
+	ApplicationTag string `json:"application-tag"`
+
+	// UnitTag is the unit that is to become the application's leader.
+	UnitTag string `json:"unit-tag"`
+}
@@ -71,6 +71,11 @@ type SerializedModel struct {
 	Charms    []string                  `json:"charms"`
 	Tools     []SerializedModelTools    `json:"tools"`
 	Resources []SerializedModelResource `json:"resources"`
+
+	// CharmStates holds the charm state of each unit in the model
+	// that has any, keyed by unit name. It isn't part of the model
+	// description, so it's transferred alongside it.
+	CharmStates map[string]map[string]string `json:"charm-states,omitempty"`
 }
 
 // SerializedModelTools holds the version and URI for a given tools
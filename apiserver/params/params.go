@@ -385,6 +385,26 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// RollingUpgrade, if set, releases the upgrade to the application's
+	// existing units in batches. This field is only understood by
+	// Application facade version 9 and greater.
+	RollingUpgrade *RollingUpgradeParams `json:"rolling-upgrade,omitempty"`
+}
+
+// RollingUpgradeParams holds the parameters for releasing a charm
+// upgrade to an application's units in batches.
+type RollingUpgradeParams struct {
+	// BatchSize is the number of units released to upgrade at a time.
+	BatchSize int `json:"batch-size"`
+
+	// BatchTimeout is how long a batch of units has to return to
+	// active/idle before the upgrade fails.
+	BatchTimeout time.Duration `json:"batch-timeout"`
+
+	// OnError is either "pause" or "abort", and determines what
+	// happens to the upgrade when a batch of units fails.
+	OnError string `json:"on-error"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
@@ -1105,6 +1125,11 @@ type BundleChangesParams struct {
 	// BundleDataYAML is the YAML-encoded charm bundle data
 	// (see "github.com/juju/charm.BundleData").
 	BundleDataYAML string `json:"yaml"`
+
+	// OverlaysYAML holds the YAML-encoded bundle overlays to apply to
+	// the bundle, in order. Any include directives in the overlays must
+	// already have been resolved.
+	OverlaysYAML []string `json:"overlays,omitempty"`
 }
 
 // BundleChangesResults holds results of the Bundle.GetChanges call.
@@ -1308,6 +1333,29 @@ type ScaleApplicationInfo struct {
 	Scale int `json:"num-units"`
 }
 
+// AutoscalePolicy holds the policy by which the substrate
+// scales a CAAS application.
+type AutoscalePolicy struct {
+	MinUnits         int    `json:"min-units"`
+	MaxUnits         int    `json:"max-units"`
+	TargetCPUPercent int    `json:"target-cpu-percent,omitempty"`
+	Metric           string `json:"metric,omitempty"`
+	MetricTarget     string `json:"metric-target,omitempty"`
+}
+
+// SetAutoscalePoliciesParams holds parameters for the
+// Application.SetAutoscalePolicies call.
+type SetAutoscalePoliciesParams struct {
+	Applications []SetAutoscalePolicyParams `json:"applications"`
+}
+
+// SetAutoscalePolicyParams holds the autoscale policy to set
+// for an application.
+type SetAutoscalePolicyParams struct {
+	ApplicationTag string          `json:"application-tag"`
+	Policy         AutoscalePolicy `json:"policy"`
+}
+
 // DumpModelRequest wraps the request for a dump-model call.
 // A simplified dump will not contain a complete export, but instead
 // a reduced set that is determined by the server.
@@ -1359,6 +1407,41 @@ type UpgradeSeriesNotificationParam struct {
 	WatcherId string `json:"watcher-id"`
 }
 
+// ApplicationUpgradeSeriesMachine holds the progress of upgrading the
+// series of one of the machines hosting an application.
+type ApplicationUpgradeSeriesMachine struct {
+	Tag        string `json:"tag"`
+	FromSeries string `json:"from-series"`
+	Status     string `json:"status"`
+
+	// UpgradeSeriesStatus holds the status of the machine's
+	// upgrade-series lock, while it is being prepared or completed.
+	UpgradeSeriesStatus model.UpgradeSeriesStatus `json:"upgrade-series-status,omitempty"`
+}
+
+// ApplicationUpgradeSeries holds the progress of upgrading the series of
+// the machines hosting an application, in the order they are upgraded.
+type ApplicationUpgradeSeries struct {
+	Application string                            `json:"application"`
+	ToSeries    string                            `json:"to-series"`
+	Status      string                            `json:"status"`
+	Message     string                            `json:"message,omitempty"`
+	Machines    []ApplicationUpgradeSeriesMachine `json:"machines"`
+}
+
+// ApplicationUpgradeSeriesResult holds the series upgrade of an
+// application's machines, or an error.
+type ApplicationUpgradeSeriesResult struct {
+	Result *ApplicationUpgradeSeries `json:"result,omitempty"`
+	Error  *Error                    `json:"error,omitempty"`
+}
+
+// ApplicationUpgradeSeriesResults holds the results of calls operating on
+// the series upgrades of applications' machines.
+type ApplicationUpgradeSeriesResults struct {
+	Results []ApplicationUpgradeSeriesResult `json:"results"`
+}
+
 // UpgradeSeriesUnitsResults contains the units affected by a series per
 // machine entity.
 type UpgradeSeriesUnitsResults struct {
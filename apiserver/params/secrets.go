@@ -0,0 +1,151 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// AddSecretArg holds the details of a secret added by a user.
+type AddSecretArg struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Data        map[string]string `json:"data"`
+}
+
+// AddSecretArgs holds the secrets to add.
+type AddSecretArgs struct {
+	Args []AddSecretArg `json:"args"`
+}
+
+// Secret describes a secret. Its values are only included when they
+// are explicitly requested.
+type Secret struct {
+	Name           string            `json:"name"`
+	Description    string            `json:"description,omitempty"`
+	Owner          string            `json:"owner,omitempty"`
+	Revision       int               `json:"revision"`
+	RotateInterval time.Duration     `json:"rotate-interval,omitempty"`
+	NextRotateTime *time.Time        `json:"next-rotate-time,omitempty"`
+	Grants         []string          `json:"grants,omitempty"`
+	Created        time.Time         `json:"created"`
+	Updated        time.Time         `json:"updated"`
+	Revisions      []SecretRevision  `json:"revisions,omitempty"`
+	Value          map[string]string `json:"value,omitempty"`
+}
+
+// SecretRevision describes a revision of a secret's values.
+type SecretRevision struct {
+	Revision int       `json:"revision"`
+	Created  time.Time `json:"created"`
+}
+
+// Secrets holds a list of secrets.
+type Secrets struct {
+	Secrets []Secret `json:"secrets"`
+}
+
+// ShowSecretArg identifies a secret to show. If Reveal is true the
+// values of the given revision, or of the latest revision if Revision
+// is zero, are included.
+type ShowSecretArg struct {
+	Name     string `json:"name"`
+	Revision int    `json:"revision,omitempty"`
+	Reveal   bool   `json:"reveal,omitempty"`
+}
+
+// ShowSecretArgs holds the secrets to show.
+type ShowSecretArgs struct {
+	Args []ShowSecretArg `json:"args"`
+}
+
+// SecretResult holds a secret or an error.
+type SecretResult struct {
+	Result *Secret `json:"result,omitempty"`
+	Error  *Error  `json:"error,omitempty"`
+}
+
+// SecretResults holds the results of a bulk secret call.
+type SecretResults struct {
+	Results []SecretResult `json:"results"`
+}
+
+// GrantSecretArg grants an application access to a secret, or
+// revokes it.
+type GrantSecretArg struct {
+	Name        string `json:"name"`
+	Application string `json:"application"`
+}
+
+// GrantSecretArgs holds the grants to make or revoke.
+type GrantSecretArgs struct {
+	Args []GrantSecretArg `json:"args"`
+}
+
+// GetSecretValueArg identifies a revision of a secret to get the
+// values of. A zero Revision means the latest revision.
+type GetSecretValueArg struct {
+	Name     string `json:"name"`
+	Revision int    `json:"revision,omitempty"`
+}
+
+// GetSecretValueArgs holds the secret values to get for a unit. The
+// unit's application must be able to read the secrets.
+type GetSecretValueArgs struct {
+	UnitTag string              `json:"unit-tag"`
+	Args    []GetSecretValueArg `json:"args"`
+}
+
+// SecretValueResult holds the values of a secret or an error.
+type SecretValueResult struct {
+	Data  map[string]string `json:"data,omitempty"`
+	Error *Error            `json:"error,omitempty"`
+}
+
+// SecretValueResults holds the results of a bulk secret value call.
+type SecretValueResults struct {
+	Results []SecretValueResult `json:"results"`
+}
+
+// SetSecretArg creates or updates a secret owned by the application
+// of a unit. Nil fields are left unchanged when the secret already
+// exists.
+type SetSecretArg struct {
+	Name           string            `json:"name"`
+	Description    *string           `json:"description,omitempty"`
+	Data           map[string]string `json:"data,omitempty"`
+	RotateInterval *time.Duration    `json:"rotate-interval,omitempty"`
+}
+
+// SetSecretArgs holds the secrets to set for a unit, which must be
+// the leader of its application.
+type SetSecretArgs struct {
+	UnitTag string         `json:"unit-tag"`
+	Args    []SetSecretArg `json:"args"`
+}
+
+// SecretRotation describes when a secret is next due to be rotated.
+type SecretRotation struct {
+	Name           string        `json:"name"`
+	RotateInterval time.Duration `json:"rotate-interval"`
+	NextRotateTime time.Time     `json:"next-rotate-time"`
+}
+
+// SecretRotationsResult holds the rotations of the secrets owned by an
+// application, or an error.
+type SecretRotationsResult struct {
+	Rotations []SecretRotation `json:"rotations,omitempty"`
+	Error     *Error           `json:"error,omitempty"`
+}
+
+// SecretRotationsResults holds the results of a bulk secret rotations
+// call.
+type SecretRotationsResults struct {
+	Results []SecretRotationsResult `json:"results"`
+}
+
+// SecretRotatedArgs records that a unit, which must be the leader of
+// the application that owns the named secrets, has rotated them.
+type SecretRotatedArgs struct {
+	UnitTag string   `json:"unit-tag"`
+	Names   []string `json:"names"`
+}
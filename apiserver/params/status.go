@@ -110,6 +110,10 @@ type MachineStatus struct {
 	// hardware specification datum.
 	Hardware string `json:"hardware"`
 
+	// LXDProfiles holds the names of the charm LXD profiles applied to
+	// this machine's instance.
+	LXDProfiles []string `json:"lxd-profiles,omitempty"`
+
 	Jobs      []multiwatcher.MachineJob `json:"jobs"`
 	HasVote   bool                      `json:"has-vote"`
 	WantsVote bool                      `json:"wants-vote"`
@@ -136,6 +140,29 @@ type ApplicationStatus struct {
 	Scale         *int   `json:"int,omitempty"`
 	ProviderId    string `json:"provider-id,omitempty"`
 	PublicAddress string `json:"public-address"`
+
+	// RollingUpgrade holds the progress of the application's rolling
+	// charm upgrade, if it has one.
+	RollingUpgrade *RollingUpgradeStatus `json:"rolling-upgrade,omitempty"`
+
+	// Autoscale holds the policy by which the substrate scales
+	// the application, if it has one.
+	Autoscale *AutoscalePolicy `json:"autoscale,omitempty"`
+
+	// LeadershipPinned is true if the application's leadership is
+	// held with its current leader regardless of the leader's liveness.
+	LeadershipPinned bool `json:"leadership-pinned,omitempty"`
+}
+
+// RollingUpgradeStatus holds the progress of a charm upgrade that is
+// released to an application's units in batches.
+type RollingUpgradeStatus struct {
+	CharmURL  string `json:"charm-url"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	BatchSize int    `json:"batch-size"`
+	Released  int    `json:"released"`
+	Units     int    `json:"units"`
 }
 
 // RemoteApplicationStatus holds status info about a remote application.
@@ -471,6 +471,36 @@ func (ctx *facadeContext) LeadershipChecker() (leadership.Checker, error) {
 	return leadershipChecker{checker}, nil
 }
 
+// LeadershipPinner is part of the facade.Context interface.
+func (ctx *facadeContext) LeadershipPinner() (leadership.Pinner, error) {
+	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
+		return ctx.State().LeadershipPinner(), nil
+	}
+	pinner, err := ctx.r.shared.leaseManager.Pinner(
+		lease.ApplicationLeadershipNamespace,
+		ctx.State().ModelUUID(),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return leadership.NewPinner(pinner), nil
+}
+
+// LeadershipTransferrer is part of the facade.Context interface.
+func (ctx *facadeContext) LeadershipTransferrer() (leadership.Transferrer, error) {
+	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
+		return ctx.State().LeadershipTransferrer(), nil
+	}
+	transferrer, err := ctx.r.shared.leaseManager.Transferrer(
+		lease.ApplicationLeadershipNamespace,
+		ctx.State().ModelUUID(),
+	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return leadershipTransferrer{transferrer}, nil
+}
+
 // SingularClaimer is part of the facade.Context interface.
 func (ctx *facadeContext) SingularClaimer() (lease.Claimer, error) {
 	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
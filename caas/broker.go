@@ -89,6 +89,10 @@ type ServiceParams struct {
 
 	// Devices is a set of parameters for Devices that is required.
 	Devices []devices.KubernetesDeviceParams
+
+	// Autoscale, if set, is the policy by which the substrate
+	// scales the service between a minimum and maximum size.
+	Autoscale *application.AutoscalePolicy
 }
 
 // Broker instances interact with the CAAS substrate.
@@ -35,6 +35,11 @@ type ProviderContainer interface {
 	Validate() error
 }
 
+// ProviderPod defines a provider specific pod.
+type ProviderPod interface {
+	Validate() error
+}
+
 // ContainerSpec defines the data values used to configure
 // a container on the CAAS substrate.
 type ContainerSpec struct {
@@ -61,6 +66,9 @@ type PodSpec struct {
 	Containers                []ContainerSpec            `yaml:"-"`
 	OmitServiceFrontend       bool                       `yaml:"omitServiceFrontend"`
 	CustomResourceDefinitions []CustomResourceDefinition `yaml:"customResourceDefinition,omitempty"`
+
+	// ProviderPod defines config which is specific to a substrate, eg k8s
+	ProviderPod `yaml:"-"`
 }
 
 // CustomResourceDefinitionValidation defines the custom resource definition validation schema.
@@ -106,6 +114,9 @@ func (spec *PodSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	if spec.ProviderPod != nil {
+		return spec.ProviderPod.Validate()
+	}
 	return nil
 }
 
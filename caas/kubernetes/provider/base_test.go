@@ -41,6 +41,10 @@ type BaseSuite struct {
 	mockStorage                *mocks.MockStorageV1Interface
 	mockStorageClass           *mocks.MockStorageClassInterface
 	mockIngressInterface       *mocks.MockIngressInterface
+	mockPolicy                 *mocks.MockPolicyV1beta1Interface
+	mockPodDisruptionBudgets   *mocks.MockPodDisruptionBudgetInterface
+	mockAutoscaling            *mocks.MockAutoscalingV2beta1Interface
+	mockAutoscalers            *mocks.MockHorizontalPodAutoscalerInterface
 
 	mockApiextensionsV1          *mocks.MockApiextensionsV1beta1Interface
 	mockApiextensionsClient      *mocks.MockApiExtensionsClientInterface
@@ -106,6 +110,16 @@ func (s *BaseSuite) setupBroker(c *gc.C) *gomock.Controller {
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
 	s.mockStorage.EXPECT().StorageClasses().AnyTimes().Return(s.mockStorageClass)
 
+	s.mockPolicy = mocks.NewMockPolicyV1beta1Interface(ctrl)
+	s.mockPodDisruptionBudgets = mocks.NewMockPodDisruptionBudgetInterface(ctrl)
+	s.k8sClient.EXPECT().PolicyV1beta1().AnyTimes().Return(s.mockPolicy)
+	s.mockPolicy.EXPECT().PodDisruptionBudgets(testNamespace).AnyTimes().Return(s.mockPodDisruptionBudgets)
+
+	s.mockAutoscaling = mocks.NewMockAutoscalingV2beta1Interface(ctrl)
+	s.mockAutoscalers = mocks.NewMockHorizontalPodAutoscalerInterface(ctrl)
+	s.k8sClient.EXPECT().AutoscalingV2beta1().AnyTimes().Return(s.mockAutoscaling)
+	s.mockAutoscaling.EXPECT().HorizontalPodAutoscalers(testNamespace).AnyTimes().Return(s.mockAutoscalers)
+
 	s.mockApiextensionsClient = mocks.NewMockApiExtensionsClientInterface(ctrl)
 	s.mockApiextensionsV1 = mocks.NewMockApiextensionsV1beta1Interface(ctrl)
 	s.mockCustomResourceDefinition = mocks.NewMockCustomResourceDefinitionInterface(ctrl)
@@ -27,6 +27,11 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+
+	updateStrategyKey       = "kubernetes-update-strategy"
+	updateMaxSurgeKey       = "kubernetes-update-max-surge"
+	updateMaxUnavailableKey = "kubernetes-update-max-unavailable"
+	updatePartitionKey      = "kubernetes-update-partition"
 )
 
 var configFields = environschema.Fields{
@@ -80,6 +85,26 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	updateStrategyKey: {
+		Description: "how pods are replaced when the application changes: RollingUpdate, Recreate or OnDelete",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updateMaxSurgeKey: {
+		Description: "number or percentage of pods which may be created above the desired number during a rolling update",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updateMaxUnavailableKey: {
+		Description: "number or percentage of pods which may be unavailable during a rolling update",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	updatePartitionKey: {
+		Description: "ordinal at or above which stateful set pods are updated during a rolling update",
+		Type:        environschema.Tint,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
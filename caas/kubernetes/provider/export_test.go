@@ -5,6 +5,7 @@ package provider
 
 import (
 	core "k8s.io/api/core/v1"
+	policy "k8s.io/api/policy/v1beta1"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/juju/juju/caas"
@@ -32,6 +33,11 @@ func StorageProvider(k8sClient kubernetes.Interface, namespace string) storage.P
 	return &storageProvider{&kubernetesClient{Interface: k8sClient, namespace: namespace}}
 }
 
+func EnsurePodDisruptionBudget(k8sClient kubernetes.Interface, namespace string, spec *policy.PodDisruptionBudget) error {
+	client := &kubernetesClient{Interface: k8sClient, namespace: namespace}
+	return client.ensurePodDisruptionBudget(spec)
+}
+
 func StorageClass(cfg *storageConfig) string {
 	return cfg.storageClass
 }
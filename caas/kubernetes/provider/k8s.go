@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"strconv"
 	"strings"
@@ -18,8 +19,10 @@ import (
 	"github.com/juju/utils/keyvalues"
 	"gopkg.in/juju/names.v2"
 	apps "k8s.io/api/apps/v1"
+	autoscaling "k8s.io/api/autoscaling/v2beta1"
 	core "k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
+	policy "k8s.io/api/policy/v1beta1"
 	k8sstorage "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
@@ -77,6 +80,8 @@ type kubernetesClient struct {
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
+//go:generate mockgen -package mocks -destination mocks/policyv1beta1_mock.go k8s.io/client-go/kubernetes/typed/policy/v1beta1 PolicyV1beta1Interface,PodDisruptionBudgetInterface
+//go:generate mockgen -package mocks -destination mocks/autoscalingv2beta1_mock.go k8s.io/client-go/kubernetes/typed/autoscaling/v2beta1 AutoscalingV2beta1Interface,HorizontalPodAutoscalerInterface
 
 // NewK8sClientFunc defines a function which returns a k8s client based on the supplied config.
 type NewK8sClientFunc func(c *rest.Config) (kubernetes.Interface, apiextensionsclientset.Interface, error)
@@ -586,6 +591,12 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteService(appName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deletePodDisruptionBudget(appName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteHorizontalPodAutoscaler(appName); err != nil {
+		return errors.Trace(err)
+	}
 	deploymentName := deploymentName(appName)
 	if err := k.deleteStatefulSet(deploymentName); err != nil {
 		return errors.Trace(err)
@@ -711,6 +722,10 @@ func (k *kubernetesClient) EnsureService(
 		}
 		unitSpec.Pod.NodeSelector = affinityLabels
 	}
+	strategy, err := updateStrategy(params.PodSpec, config)
+	if err != nil {
+		return errors.Annotatef(err, "configuring update strategy for %s", appName)
+	}
 
 	for _, c := range params.PodSpec.Containers {
 		if c.ImageDetails.Password == "" {
@@ -739,22 +754,43 @@ func (k *kubernetesClient) EnsureService(
 	}
 
 	numPods := int32(numUnits)
+	replicas := &numPods
+	if params.Autoscale != nil {
+		// The horizontal pod autoscaler manages the replicas of an
+		// existing deployment or stateful set, so the number of units
+		// only sets them when it is created.
+		existing, err := k.existingReplicas(appName, useStatefulSet)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if existing != nil {
+			replicas = existing
+		}
+	}
 	resourceTags := make(map[string]string)
 	for k, v := range params.ResourceTags {
 		resourceTags[k] = v
 	}
 	resourceTags[labelApplication] = appName
+	scaleTargetKind := "Deployment"
 	if useStatefulSet {
-		if err := k.configureStatefulSet(appName, resourceTags, unitSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+		scaleTargetKind = "StatefulSet"
+		if err := k.configureStatefulSet(appName, resourceTags, unitSpec, params.PodSpec.Containers, replicas, params.Filesystems, strategy); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	} else {
-		if err := k.configureDeployment(appName, deploymentName(appName), resourceTags, unitSpec, params.PodSpec.Containers, &numPods); err != nil {
+		if err := k.configureDeployment(appName, deploymentName(appName), resourceTags, unitSpec, params.PodSpec.Containers, replicas, strategy); err != nil {
 			return errors.Annotate(err, "creating or updating DeploymentController")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
 	}
+	if err := k.configurePodDisruptionBudget(appName, resourceTags, params.PodSpec); err != nil {
+		return errors.Annotatef(err, "creating or updating pod disruption budget for %v", appName)
+	}
+	if err := k.configureHorizontalPodAutoscaler(appName, resourceTags, scaleTargetKind, params.Autoscale); err != nil {
+		return errors.Annotatef(err, "creating or updating horizontal pod autoscaler for %v", appName)
+	}
 
 	var ports []core.ContainerPort
 	for _, c := range unitSpec.Pod.Containers {
@@ -773,6 +809,27 @@ func (k *kubernetesClient) EnsureService(
 	return nil
 }
 
+// existingReplicas returns the replicas of the application's deployment
+// or stateful set, or nil if it has not been created.
+func (k *kubernetesClient) existingReplicas(appName string, useStatefulSet bool) (*int32, error) {
+	if useStatefulSet {
+		statefulSet, err := k.AppsV1().StatefulSets(k.namespace).Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
+		if k8serrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return statefulSet.Spec.Replicas, nil
+	}
+	deployment, err := k.AppsV1().Deployments(k.namespace).Get(deploymentName(appName), v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return deployment.Spec.Replicas, nil
+}
+
 func (k *kubernetesClient) deleteAllPods(appName string) error {
 	zero := int32(0)
 	statefulsets := k.AppsV1().StatefulSets(k.namespace)
@@ -918,6 +975,7 @@ func (k *kubernetesClient) configurePodFiles(podSpec *core.PodSpec, containers [
 
 func (k *kubernetesClient) configureDeployment(
 	appName, deploymentName string, labels map[string]string, unitSpec *unitSpec, containers []caas.ContainerSpec, replicas *int32,
+	strategy *K8sUpdateStrategy,
 ) error {
 	logger.Debugf("creating/updating deployment for %s", appName)
 
@@ -948,9 +1006,38 @@ func (k *kubernetesClient) configureDeployment(
 			},
 		},
 	}
+	if strategy != nil {
+		deploymentStrategy, err := deploymentStrategy(strategy)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		deployment.Spec.Strategy = deploymentStrategy
+	}
 	return k.ensureDeployment(deployment)
 }
 
+// deploymentStrategy returns the deployment strategy
+// corresponding to the specified update strategy.
+func deploymentStrategy(strategy *K8sUpdateStrategy) (apps.DeploymentStrategy, error) {
+	switch strategy.Type {
+	case "", UpdateStrategyRollingUpdate:
+		if strategy.Partition != nil {
+			return apps.DeploymentStrategy{}, errors.NotValidf("partition for a deployment")
+		}
+		result := apps.DeploymentStrategy{Type: apps.RollingUpdateDeploymentStrategyType}
+		if strategy.MaxSurge != nil || strategy.MaxUnavailable != nil {
+			result.RollingUpdate = &apps.RollingUpdateDeployment{
+				MaxSurge:       strategy.MaxSurge,
+				MaxUnavailable: strategy.MaxUnavailable,
+			}
+		}
+		return result, nil
+	case UpdateStrategyRecreate:
+		return apps.DeploymentStrategy{Type: apps.RecreateDeploymentStrategyType}, nil
+	}
+	return apps.DeploymentStrategy{}, errors.NotValidf("update strategy %q for a deployment", strategy.Type)
+}
+
 func (k *kubernetesClient) ensureDeployment(spec *apps.Deployment) error {
 	deployments := k.AppsV1().Deployments(k.namespace)
 	_, err := deployments.Update(spec)
@@ -974,6 +1061,7 @@ func (k *kubernetesClient) deleteDeployment(name string) error {
 func (k *kubernetesClient) configureStatefulSet(
 	appName string, labels map[string]string, unitSpec *unitSpec,
 	containers []caas.ContainerSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
+	strategy *K8sUpdateStrategy,
 ) error {
 	logger.Debugf("creating/updating stateful set for %s", appName)
 
@@ -998,6 +1086,13 @@ func (k *kubernetesClient) configureStatefulSet(
 			PodManagementPolicy: apps.ParallelPodManagement,
 		},
 	}
+	if strategy != nil {
+		updateStrategy, err := statefulSetUpdateStrategy(strategy)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		statefulset.Spec.UpdateStrategy = updateStrategy
+	}
 	podSpec := unitSpec.Pod
 	if err := k.configurePodFiles(&podSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
@@ -1012,6 +1107,27 @@ func (k *kubernetesClient) configureStatefulSet(
 	return k.ensureStatefulSet(statefulset, existingPodSpec)
 }
 
+// statefulSetUpdateStrategy returns the stateful set update
+// strategy corresponding to the specified update strategy.
+func statefulSetUpdateStrategy(strategy *K8sUpdateStrategy) (apps.StatefulSetUpdateStrategy, error) {
+	switch strategy.Type {
+	case "", UpdateStrategyRollingUpdate:
+		if strategy.MaxSurge != nil || strategy.MaxUnavailable != nil {
+			return apps.StatefulSetUpdateStrategy{}, errors.NotValidf("max surge or max unavailable for a stateful set")
+		}
+		result := apps.StatefulSetUpdateStrategy{Type: apps.RollingUpdateStatefulSetStrategyType}
+		if strategy.Partition != nil {
+			result.RollingUpdate = &apps.RollingUpdateStatefulSetStrategy{
+				Partition: strategy.Partition,
+			}
+		}
+		return result, nil
+	case UpdateStrategyOnDelete:
+		return apps.StatefulSetUpdateStrategy{Type: apps.OnDeleteStatefulSetStrategyType}, nil
+	}
+	return apps.StatefulSetUpdateStrategy{}, errors.NotValidf("update strategy %q for a stateful set", strategy.Type)
+}
+
 func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPodSpec core.PodSpec) error {
 	statefulsets := k.AppsV1().StatefulSets(k.namespace)
 	_, err := statefulsets.Update(spec)
@@ -1032,6 +1148,7 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	// TODO(caas) - allow extra storage to be added
 	existing.Spec.Replicas = spec.Spec.Replicas
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
+	existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
 	_, err = statefulsets.Update(existing)
 	return errors.Trace(err)
 }
@@ -1047,6 +1164,196 @@ func (k *kubernetesClient) deleteStatefulSet(name string) error {
 	return errors.Trace(err)
 }
 
+// updateStrategy returns the update strategy requested by the pod spec,
+// with any values set in the application config taking precedence.
+// A nil strategy is returned if neither asks for one.
+func updateStrategy(podSpec *caas.PodSpec, config application.ConfigAttributes) (*K8sUpdateStrategy, error) {
+	var strategy K8sUpdateStrategy
+	specified := false
+	if podSpec.ProviderPod != nil {
+		spec, ok := podSpec.ProviderPod.(*K8sPodSpec)
+		if !ok {
+			return nil, errors.Errorf("unexpected kubernetes pod spec type %T", podSpec.ProviderPod)
+		}
+		if spec.UpdateStrategy != nil {
+			strategy = *spec.UpdateStrategy
+			specified = true
+		}
+	}
+	if strategyType := config.GetString(updateStrategyKey, ""); strategyType != "" {
+		if strategyType != strategy.Type {
+			// A different strategy type means the pod spec's
+			// parameters no longer apply.
+			strategy = K8sUpdateStrategy{}
+		}
+		strategy.Type = strategyType
+		specified = true
+	}
+	if maxSurge := config.GetString(updateMaxSurgeKey, ""); maxSurge != "" {
+		value := intstr.Parse(maxSurge)
+		strategy.MaxSurge = &value
+		specified = true
+	}
+	if maxUnavailable := config.GetString(updateMaxUnavailableKey, ""); maxUnavailable != "" {
+		value := intstr.Parse(maxUnavailable)
+		strategy.MaxUnavailable = &value
+		specified = true
+	}
+	if config.Get(updatePartitionKey, nil) != nil {
+		partition := int32(config.GetInt(updatePartitionKey, 0))
+		strategy.Partition = &partition
+		specified = true
+	}
+	if !specified {
+		return nil, nil
+	}
+	if err := strategy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &strategy, nil
+}
+
+func (k *kubernetesClient) configurePodDisruptionBudget(
+	appName string, labels map[string]string, podSpec *caas.PodSpec,
+) error {
+	var budget *K8sPodDisruptionBudget
+	if podSpec.ProviderPod != nil {
+		spec, ok := podSpec.ProviderPod.(*K8sPodSpec)
+		if !ok {
+			return errors.Errorf("unexpected kubernetes pod spec type %T", podSpec.ProviderPod)
+		}
+		budget = spec.PodDisruptionBudget
+	}
+	if budget == nil {
+		// Remove any budget left over from a previous pod spec.
+		return errors.Trace(k.deletePodDisruptionBudget(appName))
+	}
+	logger.Debugf("creating/updating pod disruption budget for %s", appName)
+	pdb := &policy.PodDisruptionBudget{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: labels},
+		Spec: policy.PodDisruptionBudgetSpec{
+			MinAvailable:   budget.MinAvailable,
+			MaxUnavailable: budget.MaxUnavailable,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+		},
+	}
+	return k.ensurePodDisruptionBudget(pdb)
+}
+
+// ensurePodDisruptionBudget creates the budget, or replaces it if it
+// has changed. The spec of a policy/v1beta1 budget can't be updated
+// before Kubernetes 1.15, so a changed budget is deleted and created
+// again rather than updated.
+func (k *kubernetesClient) ensurePodDisruptionBudget(spec *policy.PodDisruptionBudget) error {
+	budgets := k.PolicyV1beta1().PodDisruptionBudgets(k.namespace)
+	existing, err := budgets.Get(spec.Name, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		_, err = budgets.Create(spec)
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if reflect.DeepEqual(existing.Spec, spec.Spec) && reflect.DeepEqual(existing.Labels, spec.Labels) {
+		return nil
+	}
+	// The budget has no dependents, so it is deleted in the background
+	// to have it gone before it is created again.
+	background := v1.DeletePropagationBackground
+	err = budgets.Delete(spec.Name, &v1.DeleteOptions{
+		PropagationPolicy: &background,
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	_, err = budgets.Create(spec)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deletePodDisruptionBudget(appName string) error {
+	budgets := k.PolicyV1beta1().PodDisruptionBudgets(k.namespace)
+	err := budgets.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) configureHorizontalPodAutoscaler(
+	appName string, labels map[string]string, kind string, policy *application.AutoscalePolicy,
+) error {
+	if policy == nil {
+		// Remove any autoscaler left over from a previous policy.
+		return errors.Trace(k.deleteHorizontalPodAutoscaler(appName))
+	}
+	logger.Debugf("creating/updating horizontal pod autoscaler for %s", appName)
+	var metric autoscaling.MetricSpec
+	if policy.Metric == "" {
+		utilization := int32(policy.TargetCPUPercent)
+		metric = autoscaling.MetricSpec{
+			Type: autoscaling.ResourceMetricSourceType,
+			Resource: &autoscaling.ResourceMetricSource{
+				Name:                     core.ResourceCPU,
+				TargetAverageUtilization: &utilization,
+			},
+		}
+	} else {
+		target, err := resource.ParseQuantity(policy.MetricTarget)
+		if err != nil {
+			return errors.NotValidf("metric target %q", policy.MetricTarget)
+		}
+		metric = autoscaling.MetricSpec{
+			Type: autoscaling.PodsMetricSourceType,
+			Pods: &autoscaling.PodsMetricSource{
+				MetricName:         policy.Metric,
+				TargetAverageValue: target,
+			},
+		}
+	}
+	minReplicas := int32(policy.MinUnits)
+	hpa := &autoscaling.HorizontalPodAutoscaler{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   deploymentName(appName),
+			Labels: labels},
+		Spec: autoscaling.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscaling.CrossVersionObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       kind,
+				Name:       deploymentName(appName),
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: int32(policy.MaxUnits),
+			Metrics:     []autoscaling.MetricSpec{metric},
+		},
+	}
+	return k.ensureHorizontalPodAutoscaler(hpa)
+}
+
+func (k *kubernetesClient) ensureHorizontalPodAutoscaler(spec *autoscaling.HorizontalPodAutoscaler) error {
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	_, err := autoscalers.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = autoscalers.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteHorizontalPodAutoscaler(appName string) error {
+	autoscalers := k.AutoscalingV2beta1().HorizontalPodAutoscalers(k.namespace)
+	err := autoscalers.Delete(deploymentName(appName), &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
 func (k *kubernetesClient) deleteVolumeClaims(p *core.Pod) error {
 	volumesByName := make(map[string]core.Volume)
 	for _, pv := range p.Spec.Volumes {
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/juju/juju/caas"
@@ -22,7 +23,8 @@ type k8sContainer struct {
 }
 
 type k8sContainers struct {
-	Containers []k8sContainer `json:"containers"`
+	Containers  []k8sContainer `json:"containers"`
+	*K8sPodSpec `json:",inline"`
 }
 
 // K8sContainerSpec is a subset of v1.Container which defines
@@ -38,6 +40,88 @@ func (*K8sContainerSpec) Validate() error {
 	return nil
 }
 
+// K8sPodSpec defines the pod level attributes, beyond the
+// generic pod spec, we expose for charms to set.
+type K8sPodSpec struct {
+	UpdateStrategy      *K8sUpdateStrategy      `json:"updateStrategy,omitempty"`
+	PodDisruptionBudget *K8sPodDisruptionBudget `json:"podDisruptionBudget,omitempty"`
+}
+
+// Validate is defined on ProviderPod.
+func (spec *K8sPodSpec) Validate() error {
+	if spec.UpdateStrategy != nil {
+		if err := spec.UpdateStrategy.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if spec.PodDisruptionBudget != nil {
+		if err := spec.PodDisruptionBudget.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// The update strategy types a charm may choose from. Deployments
+// support RollingUpdate and Recreate; stateful sets support
+// RollingUpdate and OnDelete.
+const (
+	UpdateStrategyRollingUpdate = "RollingUpdate"
+	UpdateStrategyRecreate      = "Recreate"
+	UpdateStrategyOnDelete      = "OnDelete"
+)
+
+// K8sUpdateStrategy defines how the pods of an application are
+// replaced when its pod spec changes. It covers both the deployment
+// and stateful set strategies, since which of the two is used for an
+// application depends on whether it has storage.
+type K8sUpdateStrategy struct {
+	Type string `json:"type,omitempty"`
+
+	// MaxSurge and MaxUnavailable apply to the rolling updates
+	// of deployments.
+	MaxSurge       *intstr.IntOrString `json:"maxSurge,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// Partition applies to the rolling updates of stateful sets;
+	// only pods with an ordinal of at least the partition are updated.
+	Partition *int32 `json:"partition,omitempty"`
+}
+
+// Validate returns an error if the update strategy is not valid.
+func (s *K8sUpdateStrategy) Validate() error {
+	switch s.Type {
+	case "", UpdateStrategyRollingUpdate:
+	case UpdateStrategyRecreate, UpdateStrategyOnDelete:
+		if s.MaxSurge != nil || s.MaxUnavailable != nil || s.Partition != nil {
+			return errors.NotValidf("rolling update parameters with %q update strategy", s.Type)
+		}
+	default:
+		return errors.NotValidf("update strategy type %q", s.Type)
+	}
+	if s.Partition != nil && *s.Partition < 0 {
+		return errors.NotValidf("negative partition %d", *s.Partition)
+	}
+	return nil
+}
+
+// K8sPodDisruptionBudget defines how many of an application's pods
+// must stay available during voluntary disruptions, such as the
+// draining of a node. Exactly one of MinAvailable and MaxUnavailable
+// must be set.
+type K8sPodDisruptionBudget struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// Validate returns an error if the pod disruption budget is not valid.
+func (b *K8sPodDisruptionBudget) Validate() error {
+	if (b.MinAvailable == nil) == (b.MaxUnavailable == nil) {
+		return errors.NotValidf("pod disruption budget without exactly one of minAvailable and maxUnavailable")
+	}
+	return nil
+}
+
 // parseK8sPodSpec parses a YAML file which defines how to
 // configure a CAAS pod. We allow for generic container
 // set up plus k8s select specific features.
@@ -60,6 +144,12 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 	}
 
 	// Compose the result.
+	if containers.K8sPodSpec != nil {
+		if err := containers.K8sPodSpec.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		spec.ProviderPod = containers.K8sPodSpec
+	}
 	spec.Containers = make([]caas.ContainerSpec, len(containers.Containers))
 	for i, c := range containers.Containers {
 		if err := c.Validate(); err != nil {
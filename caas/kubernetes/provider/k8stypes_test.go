@@ -143,3 +143,75 @@ foo: bar
 			},
 		}}})
 }
+
+func (s *ContainersSuite) TestParseUpdateStrategyAndDisruptionBudget(c *gc.C) {
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+updateStrategy:
+  type: RollingUpdate
+  maxSurge: 1
+  maxUnavailable: 25%
+podDisruptionBudget:
+  minAvailable: 2
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	maxSurge := intstr.FromInt(1)
+	maxUnavailable := intstr.FromString("25%")
+	minAvailable := intstr.FromInt(2)
+	c.Assert(spec.ProviderPod, jc.DeepEquals, &provider.K8sPodSpec{
+		UpdateStrategy: &provider.K8sUpdateStrategy{
+			Type:           "RollingUpdate",
+			MaxSurge:       &maxSurge,
+			MaxUnavailable: &maxUnavailable,
+		},
+		PodDisruptionBudget: &provider.K8sPodDisruptionBudget{
+			MinAvailable: &minAvailable,
+		},
+	})
+}
+
+func (s *ContainersSuite) TestParseInvalidUpdateStrategy(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		errMsg string
+	}{{
+		spec: `
+updateStrategy:
+  type: Sometimes
+`,
+		errMsg: `update strategy type "Sometimes" not valid`,
+	}, {
+		spec: `
+updateStrategy:
+  type: Recreate
+  maxSurge: 1
+`,
+		errMsg: `rolling update parameters with "Recreate" update strategy not valid`,
+	}, {
+		spec: `
+updateStrategy:
+  partition: -1
+`,
+		errMsg: `negative partition -1 not valid`,
+	}, {
+		spec: `
+podDisruptionBudget:
+  minAvailable: 1
+  maxUnavailable: 1
+`,
+		errMsg: `pod disruption budget without exactly one of minAvailable and maxUnavailable not valid`,
+	}} {
+		c.Logf("test %d", i)
+		specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+`[1:] + test.spec[1:]
+		_, err := provider.ParseK8sPodSpec(specStr)
+		c.Check(err, gc.ErrorMatches, test.errMsg)
+	}
+}
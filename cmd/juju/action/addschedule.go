@@ -0,0 +1,155 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/actionschedules"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+// ScheduleAPI provides access to the action schedules of a model.
+type ScheduleAPI interface {
+	Close() error
+	AddSchedule(params.AddActionSchedule) error
+	ListSchedules() ([]params.ActionSchedule, error)
+	RemoveSchedules(names ...string) error
+}
+
+var newScheduleAPIClient = func(c *ActionCommandBase) (ScheduleAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return actionschedules.NewClient(root), nil
+}
+
+func NewAddScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&addScheduleCommand{})
+}
+
+// addScheduleCommand adds a schedule that periodically queues an
+// action on the given units.
+type addScheduleCommand struct {
+	ActionCommandBase
+	name         string
+	schedule     string
+	units        []string
+	leaders      []string
+	applications []string
+	actionName   string
+	paramsYAML   cmd.FileVar
+	parseStrings bool
+	args         [][]string
+}
+
+const addScheduleDoc = `
+Add a schedule that queues an action for execution on the given units at
+the times given by a cron-style schedule. The actions queued by each run
+of the schedule can be seen with 'juju show-action-status' and
+'juju show-action-output', and the history of recent runs with
+'juju schedules --format yaml'.
+
+The schedule has the five fields "minute hour day-of-month month
+day-of-week" used by cron, or is one of the shorthands @yearly,
+@monthly, @weekly, @daily or @hourly. Each field may be "*", a value, a
+range "a-b" or a comma separated list of them, and may be followed by
+"/step". All times are in UTC. If the controller is unavailable when a
+run is due, the action is queued once when it is next available; missed
+runs are not made up.
+
+Units are given as for 'juju run-action', by name or as
+<application>/leader to select whichever unit is the leader of the
+application when the schedule runs. The --application option runs the
+action on every unit of the given applications at the time of each run.
+
+Params are given as for 'juju run-action', with the --params and
+--string-args options or as key.key.key...=value arguments.
+
+Examples:
+
+    juju add-schedule nightly-backup --schedule "0 2 * * *" mysql/leader backup
+    juju add-schedule hourly-check --schedule @hourly --application mysql check verbose=true
+    juju add-schedule weekday-report --schedule "30 6 * * mon-fri" wordpress/0 report --params report.yaml
+
+See also:
+    schedules
+    remove-schedule
+    run-action
+`
+
+// SetFlags implements cmd.Command.
+func (c *addScheduleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.StringVar(&c.schedule, "schedule", "", "A cron-style schedule, in UTC, on which to run the action")
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "One or more application names whose units should run the action")
+	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
+	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+}
+
+// Info implements cmd.Command.
+func (c *addScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-schedule",
+		Args:    "<schedule name> [<unit> ...] <action name> [key.key.key...=value]",
+		Purpose: "Queue an action for execution on a schedule.",
+		Doc:     addScheduleDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *addScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.name, args = args[0], args[1:]
+	if c.schedule == "" {
+		return errors.New("no schedule specified, use --schedule")
+	}
+	if _, err := actions.ParseSchedule(c.schedule); err != nil {
+		return errors.Trace(err)
+	}
+	target, err := parseActionTarget(args, c.applications)
+	if err != nil {
+		return err
+	}
+	c.units = target.units
+	c.leaders = target.leaders
+	c.actionName = target.actionName
+	c.args = target.args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *addScheduleCommand) Run(ctx *cmd.Context) error {
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
+	if err != nil {
+		return err
+	}
+	client, err := newScheduleAPIClient(&c.ActionCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.AddSchedule(params.AddActionSchedule{
+		Name:         c.name,
+		Schedule:     c.schedule,
+		Units:        c.units,
+		Leaders:      c.leaders,
+		Applications: c.applications,
+		ActionName:   c.actionName,
+		Parameters:   actionParams,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added schedule %q", c.name)
+	return nil
+}
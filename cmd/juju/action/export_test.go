@@ -13,8 +13,10 @@ import (
 )
 
 var (
-	NewActionAPIClient = &newAPIClient
-	AddValueToMap      = addValueToMap
+	NewActionAPIClient   = &newAPIClient
+	NewStatusAPIClient   = &newStatusAPIClient
+	NewScheduleAPIClient = &newScheduleAPIClient
+	AddValueToMap        = addValueToMap
 )
 
 type ShowOutputCommand struct {
@@ -37,6 +39,14 @@ func (c *RunCommand) UnitTags() []names.UnitTag {
 	return c.unitTags
 }
 
+func (c *RunCommand) Leaders() []string {
+	return c.leaders
+}
+
+func (c *RunCommand) Applications() []string {
+	return c.applications
+}
+
 func (c *RunCommand) ActionName() string {
 	return c.actionName
 }
@@ -95,6 +105,52 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+type AddScheduleCommand struct {
+	*addScheduleCommand
+}
+
+func (c *AddScheduleCommand) Name() string {
+	return c.name
+}
+
+func (c *AddScheduleCommand) Schedule() string {
+	return c.schedule
+}
+
+func (c *AddScheduleCommand) Units() []string {
+	return c.units
+}
+
+func (c *AddScheduleCommand) Leaders() []string {
+	return c.leaders
+}
+
+func (c *AddScheduleCommand) ActionName() string {
+	return c.actionName
+}
+
+func (c *AddScheduleCommand) Args() [][]string {
+	return c.args
+}
+
+func NewAddScheduleCommandForTest(store jujuclient.ClientStore) (cmd.Command, *AddScheduleCommand) {
+	c := &addScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c), &AddScheduleCommand{c}
+}
+
+func NewListSchedulesCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &listSchedulesCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRemoveScheduleCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &removeScheduleCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
@@ -0,0 +1,181 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+func NewListSchedulesCommand() cmd.Command {
+	return modelcmd.Wrap(&listSchedulesCommand{})
+}
+
+// listSchedulesCommand lists the action schedules of a model.
+type listSchedulesCommand struct {
+	ActionCommandBase
+	out cmd.Output
+}
+
+const listSchedulesDoc = `
+List the schedules on which actions are queued in the model, with the
+time of their next run and the result of their last run. All times are
+in UTC. The yaml and json formats also include the parameters of the
+action and the actions queued by recent runs of each schedule.
+
+See also:
+    add-schedule
+    remove-schedule
+`
+
+// SetFlags implements cmd.Command.
+func (c *listSchedulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.printTabular,
+	})
+}
+
+// Info implements cmd.Command.
+func (c *listSchedulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "schedules",
+		Purpose: "List action schedules.",
+		Doc:     listSchedulesDoc,
+		Aliases: []string{"list-schedules"},
+	}
+}
+
+// Init implements cmd.Command.
+func (c *listSchedulesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// scheduleOutput is the yaml and json output for an action schedule.
+type scheduleOutput struct {
+	Schedule     string                 `yaml:"schedule" json:"schedule"`
+	Units        []string               `yaml:"units,omitempty" json:"units,omitempty"`
+	Leaders      []string               `yaml:"leaders,omitempty" json:"leaders,omitempty"`
+	Applications []string               `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Action       string                 `yaml:"action" json:"action"`
+	Parameters   map[string]interface{} `yaml:"parameters,omitempty" json:"parameters,omitempty"`
+	Created      string                 `yaml:"created" json:"created"`
+	NextRun      string                 `yaml:"next-run,omitempty" json:"next-run,omitempty"`
+	Runs         []scheduleRunOutput    `yaml:"runs,omitempty" json:"runs,omitempty"`
+}
+
+// scheduleRunOutput is the yaml and json output for a run of an action
+// schedule.
+type scheduleRunOutput struct {
+	Time    string   `yaml:"time" json:"time"`
+	Actions []string `yaml:"actions,omitempty" json:"actions,omitempty"`
+	Errors  []string `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+// Run implements cmd.Command.
+func (c *listSchedulesCommand) Run(ctx *cmd.Context) error {
+	client, err := newScheduleAPIClient(&c.ActionCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	schedules, err := client.ListSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No action schedules in the model.")
+		return nil
+	}
+	if c.out.Name() == "tabular" {
+		return c.out.Write(ctx, schedules)
+	}
+	result := make(map[string]scheduleOutput, len(schedules))
+	for _, schedule := range schedules {
+		out := scheduleOutput{
+			Schedule:     schedule.Schedule,
+			Units:        schedule.Units,
+			Leaders:      schedule.Leaders,
+			Applications: schedule.Applications,
+			Action:       schedule.ActionName,
+			Parameters:   schedule.Parameters,
+			Created:      formatScheduleTime(schedule.Created),
+		}
+		if schedule.NextRun != nil {
+			out.NextRun = formatScheduleTime(*schedule.NextRun)
+		}
+		for _, run := range schedule.Runs {
+			out.Runs = append(out.Runs, scheduleRunOutput{
+				Time:    formatScheduleTime(run.Time),
+				Actions: run.ActionIds,
+				Errors:  run.Errors,
+			})
+		}
+		result[schedule.Name] = out
+	}
+	return c.out.Write(ctx, result)
+}
+
+// printTabular prints each schedule with its targets, next run and the
+// outcome of its last run.
+func (c *listSchedulesCommand) printTabular(writer io.Writer, value interface{}) error {
+	schedules, ok := value.([]params.ActionSchedule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schedules, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+		"Name", "Schedule", "Targets", "Action", "Next run", "Last run", "Last result")
+	for _, schedule := range schedules {
+		nextRun, lastRun, lastResult := "", "", ""
+		if schedule.NextRun != nil {
+			nextRun = formatScheduleTime(*schedule.NextRun)
+		}
+		if len(schedule.Runs) > 0 {
+			run := schedule.Runs[len(schedule.Runs)-1]
+			lastRun = formatScheduleTime(run.Time)
+			lastResult = fmt.Sprintf("%d queued", len(run.ActionIds))
+			if len(run.Errors) > 0 {
+				lastResult += fmt.Sprintf(", %d failed", len(run.Errors))
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			schedule.Name,
+			schedule.Schedule,
+			strings.Join(scheduleTargets(schedule), ","),
+			schedule.ActionName,
+			nextRun,
+			lastRun,
+			lastResult,
+		)
+	}
+	return tw.Flush()
+}
+
+// scheduleTargets returns the units a schedule runs on in the form they
+// are given to add-schedule.
+func scheduleTargets(schedule params.ActionSchedule) []string {
+	targets := append([]string(nil), schedule.Units...)
+	for _, appName := range schedule.Leaders {
+		targets = append(targets, appName+leaderSuffix)
+	}
+	return append(targets, schedule.Applications...)
+}
+
+func formatScheduleTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
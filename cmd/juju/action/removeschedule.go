@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+func NewRemoveScheduleCommand() cmd.Command {
+	return modelcmd.Wrap(&removeScheduleCommand{})
+}
+
+// removeScheduleCommand removes action schedules from a model.
+type removeScheduleCommand struct {
+	ActionCommandBase
+	names []string
+}
+
+const removeScheduleDoc = `
+Remove one or more action schedules, so that their actions are no longer
+queued. Actions already queued by the schedules are not cancelled; use
+'juju cancel-action' to cancel them.
+
+Examples:
+
+    juju remove-schedule nightly-backup
+    juju remove-schedule nightly-backup hourly-check
+
+See also:
+    add-schedule
+    schedules
+`
+
+// Info implements cmd.Command.
+func (c *removeScheduleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "remove-schedule",
+		Args:    "<schedule name> [<schedule name> ...]",
+		Purpose: "Remove action schedules.",
+		Doc:     removeScheduleDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *removeScheduleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no schedule name specified")
+	}
+	c.names = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *removeScheduleCommand) Run(ctx *cmd.Context) error {
+	client, err := newScheduleAPIClient(&c.ActionCommandBase)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	return errors.Trace(client.RemoveSchedules(c.names...))
+}
@@ -4,16 +4,22 @@
 package action
 
 import (
+	"fmt"
+	"io"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 	yaml "gopkg.in/yaml.v2"
 
+	"github.com/juju/juju/api"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -23,6 +29,10 @@ import (
 // nameRule describes the name format of an action or keyName must match to be valid.
 var nameRule = charm.GetActionNameRule()
 
+// leaderSuffix is the suffix used in place of a unit number to select
+// the leader unit of an application.
+const leaderSuffix = "/leader"
+
 func NewRunCommand() cmd.Command {
 	return modelcmd.Wrap(&runCommand{})
 }
@@ -32,9 +42,13 @@ func NewRunCommand() cmd.Command {
 type runCommand struct {
 	ActionCommandBase
 	unitTags     []names.UnitTag
+	leaders      []string
+	applications []string
 	actionName   string
 	paramsYAML   cmd.FileVar
 	parseStrings bool
+	maxParallel  int
+	ordering     string
 	wait         waitFlag
 	out          cmd.Output
 	args         [][]string
@@ -45,6 +59,12 @@ Queue an Action for execution on a given unit, with a given set of params.
 The Action ID is returned for use with 'juju show-action-output <ID>' or
 'juju show-action-status <ID>'.
 
+Units may be given by name, or as <application>/leader to select the
+current leader unit of an application. The --application option queues
+the Action on every unit of the given applications. When the Action is
+queued on many units, --wait with --format tabular shows the status, exit
+code and results of the Action on each unit, followed by a summary.
+
 Params are validated according to the charm for the unit's application.  The
 valid params can be seen using "juju actions <application> --schema".
 Params may be in a yaml file which is passed with the --params flag, or they
@@ -57,6 +77,14 @@ is a boolean true in YAML.
 If --params is passed, along with key.key...=value explicit arguments, the
 explicit arguments will override the parameter file.
 
+When the Action is queued on many units, --max-parallel limits the number
+of units of each application that run it at the same time; the Action is
+held on the other units until it finishes on one of them. The --ordering
+option determines the order in which the units run the Action: in the
+order given, or with the application leader "leader-first" or
+"leader-last". Together they allow rolling operations, such as restarts,
+to be done safely.
+
 Examples:
 
 $ juju run-action mysql/3 backup --wait
@@ -114,6 +142,19 @@ file:
 $ juju run-action sleeper/0 pause time=1000
 ...
 
+$ juju run-action mysql/leader backup
+...
+
+$ juju run-action --application mysql,wordpress backup --wait --format tabular
+Unit         Id        Status     Code  Message  Results
+mysql/0      <ID>      completed  0              file.size=873.2
+...
+3 units: 3 completed
+
+$ juju run-action --application mysql restart --max-parallel 1 --ordering leader-last
+...
+The action is run on one mysql unit at a time, with the leader last.
+
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
@@ -122,16 +163,23 @@ The value for the "time" param will be the string literal "1000".
 // SetFlags offers an option for YAML output.
 func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
-	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.printTabular,
+	})
+	f.Var(cmd.NewStringsValue(nil, &c.applications), "application", "One or more application names whose units should run the action")
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "Maximum number of units of each application to run the action at once")
+	f.StringVar(&c.ordering, "ordering", "", "Order in which to run the action on units: leader-first or leader-last")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
 }
 
 func (c *runCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "run-action",
-		Args:    "<unit> [<unit> ...] <action name> [key.key.key...=value]",
+		Args:    "[<unit> ...] <action name> [key.key.key...=value]",
 		Purpose: "Queue an action for execution.",
 		Doc:     runDoc,
 	}
@@ -139,46 +187,97 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag(s), action name and action arguments.
 func (c *runCommand) Init(args []string) error {
+	if c.maxParallel < 0 {
+		return errors.Errorf("--max-parallel must not be negative, got %d", c.maxParallel)
+	}
+	switch c.ordering {
+	case "", "leader-first", "leader-last":
+	default:
+		return errors.Errorf("--ordering must be leader-first or leader-last, got %q", c.ordering)
+	}
+	target, err := parseActionTarget(args, c.applications)
+	if err != nil {
+		return err
+	}
+	for _, unitName := range target.units {
+		c.unitTags = append(c.unitTags, names.NewUnitTag(unitName))
+	}
+	c.leaders = target.leaders
+	c.actionName = target.actionName
+	c.args = target.args
+	return nil
+}
+
+// actionTarget holds the units, action name and action arguments given
+// on the command line of commands that queue actions.
+type actionTarget struct {
+	units      []string
+	leaders    []string
+	actionName string
+	args       [][]string
+}
+
+// parseActionTarget parses "[<unit> ...] <action name> [key.key.key...=value]"
+// arguments, where units may also be given as <application>/leader.
+// The units may be omitted if applications are given.
+func parseActionTarget(args []string, applications []string) (actionTarget, error) {
+	var target actionTarget
 	var unitNames []string
 	for idx, arg := range args {
-		if names.IsValidUnit(arg) {
+		if names.IsValidUnit(arg) || isLeaderSelector(arg) {
 			unitNames = args[:idx+1]
 		} else if nameRule.MatchString(arg) {
-			c.actionName = arg
+			target.actionName = arg
 			break
 		} else {
-			return errors.Errorf("invalid unit or action name %q", arg)
+			return target, errors.Errorf("invalid unit or action name %q", arg)
 		}
 	}
-	if len(unitNames) == 0 {
-		return errors.New("no unit specified")
+	if len(unitNames) == 0 && len(applications) == 0 {
+		return target, errors.New("no unit specified")
 	}
-	if c.actionName == "" {
-		return errors.New("no action specified")
+	if target.actionName == "" {
+		return target, errors.New("no action specified")
+	}
+	for _, appName := range applications {
+		if !names.IsValidApplication(appName) {
+			return target, errors.Errorf("invalid application name %q", appName)
+		}
 	}
-	c.unitTags = make([]names.UnitTag, len(unitNames))
-	for idx, unitName := range unitNames {
-		c.unitTags[idx] = names.NewUnitTag(unitName)
+	for _, unitName := range unitNames {
+		if isLeaderSelector(unitName) {
+			target.leaders = append(target.leaders, strings.TrimSuffix(unitName, leaderSuffix))
+			continue
+		}
+		target.units = append(target.units, unitName)
 	}
 
 	// Parse CLI key-value args if they exist.
-	c.args = make([][]string, 0)
-	for _, arg := range args[len(unitNames)+1:] {
+	var err error
+	target.args, err = parseActionArgs(args[len(unitNames)+1:])
+	return target, err
+}
+
+// parseActionArgs parses key.key.key...=value arguments into slices of
+// the keys followed by the value.
+func parseActionArgs(args []string) ([][]string, error) {
+	result := make([][]string, 0)
+	for _, arg := range args {
 		thisArg := strings.SplitN(arg, "=", 2)
 		if len(thisArg) != 2 {
-			return errors.Errorf("argument %q must be of the form key...=value", arg)
+			return nil, errors.Errorf("argument %q must be of the form key...=value", arg)
 		}
 		keySlice := strings.Split(thisArg[0], ".")
 		// check each key for validity
 		for _, key := range keySlice {
 			if valid := nameRule.MatchString(key); !valid {
-				return errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
+				return nil, errors.Errorf("key %q must start and end with lowercase alphanumeric, and contain only lowercase alphanumeric and hyphens", key)
 			}
 		}
-		// c.args={..., [key, key, key, key, value]}
-		c.args = append(c.args, append(keySlice, thisArg[1]))
+		// result={..., [key, key, key, key, value]}
+		result = append(result, append(keySlice, thisArg[1]))
 	}
-	return nil
+	return result, nil
 }
 
 func (c *runCommand) Run(ctx *cmd.Context) error {
@@ -188,71 +287,31 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer api.Close()
 
-	actionParams := map[string]interface{}{}
-
-	if c.paramsYAML.Path != "" {
-		b, err := c.paramsYAML.Read(ctx)
-		if err != nil {
-			return err
-		}
-
-		err = yaml.Unmarshal(b, &actionParams)
-		if err != nil {
-			return err
-		}
-
-		conformantParams, err := common.ConformYAML(actionParams)
-		if err != nil {
-			return err
-		}
-
-		betterParams, ok := conformantParams.(map[string]interface{})
-		if !ok {
-			return errors.New("params must contain a YAML map with string keys")
-		}
-
-		actionParams = betterParams
-	}
-
-	// If we had explicit args {..., [key, key, key, key, value], ...}
-	// then iterate and set params ..., key.key.key.key=value, ...
-	for _, argSlice := range c.args {
-		valueIndex := len(argSlice) - 1
-		keys := argSlice[:valueIndex]
-		value := argSlice[valueIndex]
-		cleansedValue := interface{}(value)
-		if !c.parseStrings {
-			err := yaml.Unmarshal([]byte(value), &cleansedValue)
-			if err != nil {
-				return err
-			}
-		}
-		// Insert the value in the map.
-		addValueToMap(keys, cleansedValue, actionParams)
-	}
-
-	conformantParams, err := common.ConformYAML(actionParams)
+	actionParams, err := buildActionParams(ctx, c.paramsYAML, c.args, c.parseStrings)
 	if err != nil {
 		return err
 	}
 
-	typedConformantParams, ok := conformantParams.(map[string]interface{})
-	if !ok {
-		return errors.Errorf("params must be a map, got %T", typedConformantParams)
+	unitTags, err := c.resolveUnits()
+	if err != nil {
+		return errors.Trace(err)
 	}
-
-	actions := make([]params.Action, len(c.unitTags))
-	for i, unitTag := range c.unitTags {
+	actions := make([]params.Action, len(unitTags))
+	for i, unitTag := range unitTags {
 		actions[i].Receiver = unitTag.String()
 		actions[i].Name = c.actionName
 		actions[i].Parameters = actionParams
 	}
-	results, err := api.Enqueue(params.Actions{Actions: actions})
+	results, err := api.Enqueue(params.Actions{
+		Actions:     actions,
+		Parallelism: c.maxParallel,
+		Ordering:    c.ordering,
+	})
 	if err != nil {
 		return err
 	}
 
-	if len(results.Results) != len(c.unitTags) {
+	if len(results.Results) != len(unitTags) {
 		return errors.New("illegal number of results returned")
 	}
 
@@ -269,7 +328,7 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		}
 
 		// Legacy Juju 1.25 output format for a single unit, no wait.
-		if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 && len(results.Results) == 1 {
+		if !c.wait.forever && c.wait.d.Nanoseconds() <= 0 && len(results.Results) == 1 && c.out.Name() != "tabular" {
 			output := map[string]string{"Action queued with id": tag.Id()}
 			return c.out.Write(ctx, output)
 		}
@@ -317,6 +376,12 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		if err != nil {
 			return errors.Trace(err)
 		}
+		switch result.Status {
+		case params.ActionRunning, params.ActionPending:
+			// The wait timed out, so only fetch the current
+			// results of the remaining actions.
+			wait = time.NewTimer(0)
+		}
 		unitTag, err := names.ParseUnitTag(result.Action.Receiver)
 		if err != nil {
 			return err
@@ -328,3 +393,261 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, output)
 }
+
+// buildActionParams reads the params file, if any, and overlays the
+// explicit key.key.key...=value arguments on top of it.
+func buildActionParams(ctx *cmd.Context, paramsYAML cmd.FileVar, args [][]string, parseStrings bool) (map[string]interface{}, error) {
+	actionParams := map[string]interface{}{}
+
+	if paramsYAML.Path != "" {
+		b, err := paramsYAML.Read(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		err = yaml.Unmarshal(b, &actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		conformantParams, err := common.ConformYAML(actionParams)
+		if err != nil {
+			return nil, err
+		}
+
+		betterParams, ok := conformantParams.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("params must contain a YAML map with string keys")
+		}
+
+		actionParams = betterParams
+	}
+
+	// If we had explicit args {..., [key, key, key, key, value], ...}
+	// then iterate and set params ..., key.key.key.key=value, ...
+	for _, argSlice := range args {
+		valueIndex := len(argSlice) - 1
+		keys := argSlice[:valueIndex]
+		value := argSlice[valueIndex]
+		cleansedValue := interface{}(value)
+		if !parseStrings {
+			err := yaml.Unmarshal([]byte(value), &cleansedValue)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// Insert the value in the map.
+		addValueToMap(keys, cleansedValue, actionParams)
+	}
+
+	conformantParams, err := common.ConformYAML(actionParams)
+	if err != nil {
+		return nil, err
+	}
+
+	typedConformantParams, ok := conformantParams.(map[string]interface{})
+	if !ok {
+		return nil, errors.Errorf("params must be a map, got %T", typedConformantParams)
+	}
+	return typedConformantParams, nil
+}
+
+// isLeaderSelector returns whether the argument selects the leader unit
+// of an application, in the form <application>/leader.
+func isLeaderSelector(arg string) bool {
+	return strings.HasSuffix(arg, leaderSuffix) &&
+		names.IsValidApplication(strings.TrimSuffix(arg, leaderSuffix))
+}
+
+// StatusAPI provides the model status used to find the units of
+// applications and their leaders.
+type StatusAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+}
+
+var _ StatusAPI = (*api.Client)(nil)
+
+var newStatusAPIClient = func(c *ActionCommandBase) (StatusAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return root.Client(), nil
+}
+
+// resolveUnits returns the units the action should be queued on: the
+// units named on the command line, the leaders of any applications
+// selected with <application>/leader and all of the units of the
+// applications given with --application.
+func (c *runCommand) resolveUnits() ([]names.UnitTag, error) {
+	if len(c.leaders) == 0 && len(c.applications) == 0 {
+		return c.unitTags, nil
+	}
+	client, err := newStatusAPIClient(&c.ActionCommandBase)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+
+	appNames := set.NewStrings(c.applications...).Union(set.NewStrings(c.leaders...))
+	status, err := client.Status(appNames.SortedValues())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Subordinate units are only found under their principals.
+	appUnits := make(map[string][]string)
+	leaders := make(map[string]string)
+	addUnit := func(unitName string, unit params.UnitStatus) {
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return
+		}
+		appUnits[appName] = append(appUnits[appName], unitName)
+		if unit.Leader {
+			leaders[appName] = unitName
+		}
+	}
+	for _, app := range status.Applications {
+		for unitName, unit := range app.Units {
+			addUnit(unitName, unit)
+			for subName, sub := range unit.Subordinates {
+				addUnit(subName, sub)
+			}
+		}
+	}
+	checkApplication := func(appName string) error {
+		if _, ok := status.Applications[appName]; !ok {
+			return errors.NotFoundf("application %q", appName)
+		}
+		return nil
+	}
+
+	// Keep the units named explicitly in the order given, and add the
+	// selected units of each application after them.
+	seen := set.NewStrings()
+	var result []names.UnitTag
+	add := func(unitName string) {
+		if seen.Contains(unitName) {
+			return
+		}
+		seen.Add(unitName)
+		result = append(result, names.NewUnitTag(unitName))
+	}
+	for _, tag := range c.unitTags {
+		add(tag.Id())
+	}
+	for _, appName := range c.leaders {
+		if err := checkApplication(appName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		leader, ok := leaders[appName]
+		if !ok {
+			return nil, errors.Errorf("could not determine leader for %q", appName)
+		}
+		add(leader)
+	}
+	for _, appName := range c.applications {
+		if err := checkApplication(appName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitNames := appUnits[appName]
+		if len(unitNames) == 0 {
+			return nil, errors.Errorf("application %q has no units", appName)
+		}
+		naturalsort.Sort(unitNames)
+		for _, unitName := range unitNames {
+			add(unitName)
+		}
+	}
+	return result, nil
+}
+
+// printTabular prints the action queued or run on each unit, followed
+// by a summary of their status.
+func (c *runCommand) printTabular(writer io.Writer, value interface{}) error {
+	results, ok := value.(map[string]interface{})
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", results, value)
+	}
+	var unitNames []string
+	byUnit := make(map[string]map[string]interface{})
+	for _, result := range results {
+		var entry map[string]interface{}
+		switch result := result.(type) {
+		case map[string]interface{}:
+			entry = result
+		case map[string]string:
+			entry = make(map[string]interface{})
+			for k, v := range result {
+				entry[k] = v
+			}
+		default:
+			return errors.Errorf("unexpected result of type %T", result)
+		}
+		unitName := fmt.Sprint(entry["unit"])
+		unitNames = append(unitNames, unitName)
+		byUnit[unitName] = entry
+	}
+	naturalsort.Sort(unitNames)
+
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Unit\tId\tStatus\tCode\tMessage\tResults")
+	counts := make(map[string]int)
+	for _, unitName := range unitNames {
+		entry := byUnit[unitName]
+		status, _ := entry["status"].(string)
+		if status == "" {
+			// The results were not waited for.
+			status = "queued"
+		}
+		counts[status]++
+		message, _ := entry["message"].(string)
+		var code string
+		var resultValues []string
+		if results, ok := entry["results"].(map[string]interface{}); ok {
+			if value, ok := results["Code"]; ok {
+				code = fmt.Sprint(value)
+			}
+			resultValues = flattenResults("", results)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			unitName, entry["id"], status, code, message, strings.Join(resultValues, " "))
+	}
+	tw.Flush()
+
+	var statuses []string
+	for status := range counts {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	summary := make([]string, len(statuses))
+	for i, status := range statuses {
+		summary[i] = fmt.Sprintf("%d %s", counts[status], status)
+	}
+	units := "units"
+	if len(unitNames) == 1 {
+		units = "unit"
+	}
+	fmt.Fprintf(writer, "\n%d %s: %s\n", len(unitNames), units, strings.Join(summary, ", "))
+	return nil
+}
+
+// flattenResults returns the results of an action as sorted key=value
+// strings, with nested keys joined by dots. The exit code is left out
+// as it is shown in its own column.
+func flattenResults(prefix string, results map[string]interface{}) []string {
+	var flattened []string
+	for key, value := range results {
+		if prefix == "" && key == "Code" {
+			continue
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattened = append(flattened, flattenResults(prefix+key+".", nested)...)
+			continue
+		}
+		flattened = append(flattened, fmt.Sprintf("%s%s=%v", prefix, key, value))
+	}
+	sort.Strings(flattened)
+	return flattened
+}
@@ -7,9 +7,11 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -19,6 +21,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
+	coretesting "github.com/juju/juju/testing"
 )
 
 var (
@@ -60,6 +63,8 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		should               string
 		args                 []string
 		expectUnits          []names.UnitTag
+		expectLeaders        []string
+		expectApplications   []string
 		expectAction         string
 		expectParamsYamlPath string
 		expectParseStrings   bool
@@ -92,6 +97,39 @@ func (s *RunSuite) TestInit(c *gc.C) {
 		expectUnits:  []names.UnitTag{names.NewUnitTag(validUnitId), names.NewUnitTag(validUnitId2)},
 		expectAction: "valid-action-name",
 		expectKVArgs: [][]string{},
+	}, {
+		should:        "work with application leaders",
+		args:          []string{validUnitId, "wordpress/leader", "valid-action-name"},
+		expectUnits:   []names.UnitTag{names.NewUnitTag(validUnitId)},
+		expectLeaders: []string{"wordpress"},
+		expectAction:  "valid-action-name",
+		expectKVArgs:  [][]string{},
+	}, {
+		should:      "fail with invalid application leader",
+		args:        []string{"Wordpress/leader", "valid-action-name"},
+		expectError: "invalid unit or action name \"Wordpress/leader\"",
+	}, {
+		should:             "work with applications and no units",
+		args:               []string{"--application", "mysql,wordpress", "valid-action-name", "foo=bar"},
+		expectApplications: []string{"mysql", "wordpress"},
+		expectAction:       "valid-action-name",
+		expectKVArgs:       [][]string{{"foo", "bar"}},
+	}, {
+		should:      "fail with invalid application name",
+		args:        []string{"--application", invalidApplicationId, "valid-action-name"},
+		expectError: "invalid application name \"something-strange-\"",
+	}, {
+		should:      "fail with negative parallelism",
+		args:        []string{"--max-parallel", "-1", validUnitId, "valid-action-name"},
+		expectError: "--max-parallel must not be negative, got -1",
+	}, {
+		should:      "fail with invalid ordering",
+		args:        []string{"--ordering", "random", validUnitId, "valid-action-name"},
+		expectError: "--ordering must be leader-first or leader-last, got \"random\"",
+	}, {
+		should:      "fail with applications and no action",
+		args:        []string{"--application", "mysql"},
+		expectError: "no action specified",
 	}, {}, {
 		should:      "fail with invalid action name",
 		args:        []string{validUnitId, "BadName"},
@@ -202,6 +240,8 @@ func (s *RunSuite) TestInit(c *gc.C) {
 			err := cmdtesting.InitCommand(wrappedCommand, args)
 			if t.expectError == "" {
 				c.Check(command.UnitTags(), gc.DeepEquals, t.expectUnits)
+				c.Check(command.Leaders(), gc.DeepEquals, t.expectLeaders)
+				c.Check(command.Applications(), gc.DeepEquals, t.expectApplications)
 				c.Check(command.ActionName(), gc.Equals, t.expectAction)
 				c.Check(command.ParamsYAML().Path, gc.Equals, t.expectParamsYamlPath)
 				c.Check(command.Args(), jc.DeepEquals, t.expectKVArgs)
@@ -432,3 +472,183 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+type fakeStatusAPIClient struct {
+	status   *params.FullStatus
+	patterns []string
+}
+
+func (c *fakeStatusAPIClient) Close() error {
+	return nil
+}
+
+func (c *fakeStatusAPIClient) Status(patterns []string) (*params.FullStatus, error) {
+	c.patterns = patterns
+	return c.status, nil
+}
+
+func (s *RunSuite) patchStatusAPIClient(client *fakeStatusAPIClient) func() {
+	return jujutesting.PatchValue(action.NewStatusAPIClient,
+		func(c *action.ActionCommandBase) (action.StatusAPI, error) {
+			return client, nil
+		},
+	)
+}
+
+var runStatus = &params.FullStatus{
+	Applications: map[string]params.ApplicationStatus{
+		"mysql": {
+			Units: map[string]params.UnitStatus{
+				"mysql/0":  {},
+				"mysql/1":  {Leader: true},
+				"mysql/10": {},
+			},
+		},
+		"wordpress": {
+			Units: map[string]params.UnitStatus{
+				"wordpress/0": {
+					Leader: true,
+					Subordinates: map[string]params.UnitStatus{
+						"logging/0": {Leader: true},
+					},
+				},
+			},
+		},
+		"logging": {},
+		"empty":   {},
+	},
+}
+
+func (s *RunSuite) TestRunApplicationsAndLeaders(c *gc.C) {
+	statusClient := &fakeStatusAPIClient{status: runStatus}
+	restoreStatus := s.patchStatusAPIClient(statusClient)
+	defer restoreStatus()
+	fakeClient := &fakeAPIClient{}
+	for i := 0; i < 4; i++ {
+		fakeClient.actionResults = append(fakeClient.actionResults, params.ActionResult{
+			Action: &params.Action{Tag: validActionTagString},
+		})
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "--application", "mysql", "mysql/0", "logging/leader", "some-action")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(statusClient.patterns, jc.DeepEquals, []string{"logging", "mysql"})
+	var receivers []string
+	for _, enqueued := range fakeClient.EnqueuedActions().Actions {
+		c.Check(enqueued.Name, gc.Equals, "some-action")
+		receivers = append(receivers, enqueued.Receiver)
+	}
+	c.Check(receivers, jc.DeepEquals, []string{
+		"unit-mysql-0", "unit-logging-0", "unit-mysql-1", "unit-mysql-10",
+	})
+}
+
+func (s *RunSuite) TestRunWithParallelism(c *gc.C) {
+	statusClient := &fakeStatusAPIClient{status: runStatus}
+	restoreStatus := s.patchStatusAPIClient(statusClient)
+	defer restoreStatus()
+	fakeClient := &fakeAPIClient{}
+	for i := 0; i < 3; i++ {
+		fakeClient.actionResults = append(fakeClient.actionResults, params.ActionResult{
+			Action: &params.Action{Tag: validActionTagString},
+		})
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "--application", "mysql", "--max-parallel", "1", "--ordering", "leader-last", "some-action")
+	c.Assert(err, jc.ErrorIsNil)
+
+	enqueued := fakeClient.EnqueuedActions()
+	c.Check(enqueued.Actions, gc.HasLen, 3)
+	c.Check(enqueued.Parallelism, gc.Equals, 1)
+	c.Check(enqueued.Ordering, gc.Equals, "leader-last")
+}
+
+func (s *RunSuite) TestRunApplicationErrors(c *gc.C) {
+	restoreStatus := s.patchStatusAPIClient(&fakeStatusAPIClient{status: runStatus})
+	defer restoreStatus()
+	restore := s.patchAPIClient(&fakeAPIClient{})
+	defer restore()
+
+	for i, t := range []struct {
+		args        []string
+		expectedErr string
+	}{{
+		args:        []string{"--application", "missing", "some-action"},
+		expectedErr: `application "missing" not found`,
+	}, {
+		args:        []string{"missing/leader", "some-action"},
+		expectedErr: `application "missing" not found`,
+	}, {
+		args:        []string{"--application", "empty", "some-action"},
+		expectedErr: `application "empty" has no units`,
+	}, {
+		args:        []string{"empty/leader", "some-action"},
+		expectedErr: `could not determine leader for "empty"`,
+	}} {
+		c.Logf("test %d: juju run-action %s", i, strings.Join(t.args, " "))
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+		_, err := cmdtesting.RunCommand(c, wrappedCommand, append([]string{"-m", "admin"}, t.args...)...)
+		c.Check(err, gc.ErrorMatches, t.expectedErr)
+	}
+}
+
+func (s *RunSuite) TestRunWaitTabular(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		delay:            time.NewTimer(0),
+		timeout:          time.NewTimer(coretesting.LongWait),
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{
+				"Code": "0",
+				"file": map[string]interface{}{"size": 873},
+			},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, "some-action", "--wait", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Unit     Id                                    Status     Code  Message  Results
+mysql/0  f47ac10b-58cc-4372-a567-0e02b2c3d479  completed  0              file.size=873
+
+1 unit: 1 completed
+`[1:])
+}
+
+func (s *RunSuite) TestRunQueuedTabular(c *gc.C) {
+	fakeClient := &fakeAPIClient{
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: names.NewUnitTag(validUnitId).String(),
+			},
+		}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, "some-action", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, `(?s)Unit +Id +Status +Code +Message +Results\n`+
+		`mysql/0 +f47ac10b-58cc-4372-a567-0e02b2c3d479 +queued *\n\n1 unit: 1 queued\n`)
+}
@@ -0,0 +1,236 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type ScheduleSuite struct {
+	BaseActionSuite
+	client *fakeScheduleAPIClient
+}
+
+var _ = gc.Suite(&ScheduleSuite{})
+
+func (s *ScheduleSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.client = &fakeScheduleAPIClient{}
+	restore := jujutesting.PatchValue(action.NewScheduleAPIClient,
+		func(*action.ActionCommandBase) (action.ScheduleAPI, error) {
+			return s.client, nil
+		},
+	)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *ScheduleSuite) TestAddScheduleInit(c *gc.C) {
+	for i, t := range []struct {
+		args         []string
+		expectedErr  string
+		expectedName string
+		units        []string
+		leaders      []string
+		actionName   string
+		actionArgs   [][]string
+	}{{
+		args:        []string{},
+		expectedErr: "no schedule name specified",
+	}, {
+		args:        []string{"backup", "mysql/0", "backup"},
+		expectedErr: "no schedule specified, use --schedule",
+	}, {
+		args:        []string{"backup", "--schedule", "61 * * * *", "mysql/0", "backup"},
+		expectedErr: `schedule "61 \* \* \* \*": minute "61" not valid`,
+	}, {
+		args:        []string{"backup", "--schedule", "@daily", "backup"},
+		expectedErr: "no unit specified",
+	}, {
+		args:        []string{"backup", "--schedule", "@daily", "mysql/0"},
+		expectedErr: "no action specified",
+	}, {
+		args:        []string{"backup", "--schedule", "@daily", "mysql/0", "backup", "out"},
+		expectedErr: `argument "out" must be of the form key...=value`,
+	}, {
+		args:         []string{"nightly", "--schedule", "0 2 * * *", "mysql/0", "mysql/leader", "backup", "out=x.tar"},
+		expectedName: "nightly",
+		units:        []string{"mysql/0"},
+		leaders:      []string{"mysql"},
+		actionName:   "backup",
+		actionArgs:   [][]string{{"out", "x.tar"}},
+	}, {
+		args:         []string{"nightly", "--schedule", "@daily", "--application", "mysql", "backup"},
+		expectedName: "nightly",
+		actionName:   "backup",
+		actionArgs:   [][]string{},
+	}} {
+		c.Logf("test %d: juju add-schedule %s", i, strings.Join(t.args, " "))
+		wrappedCommand, command := action.NewAddScheduleCommandForTest(s.store)
+		err := cmdtesting.InitCommand(wrappedCommand, t.args)
+		if t.expectedErr != "" {
+			c.Check(err, gc.ErrorMatches, t.expectedErr)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(command.Name(), gc.Equals, t.expectedName)
+		c.Check(command.Units(), jc.DeepEquals, t.units)
+		c.Check(command.Leaders(), jc.DeepEquals, t.leaders)
+		c.Check(command.ActionName(), gc.Equals, t.actionName)
+		c.Check(command.Args(), jc.DeepEquals, t.actionArgs)
+	}
+}
+
+func (s *ScheduleSuite) TestAddSchedule(c *gc.C) {
+	wrappedCommand, _ := action.NewAddScheduleCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "nightly", "--schedule", "0 2 * * *",
+		"--application", "wordpress", "mysql/0", "mysql/leader",
+		"backup", "file.kind=xz", "count=3",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Added schedule \"nightly\"\n")
+	s.client.CheckCallNames(c, "AddSchedule", "Close")
+	s.client.CheckCall(c, 0, "AddSchedule", params.AddActionSchedule{
+		Name:         "nightly",
+		Schedule:     "0 2 * * *",
+		Units:        []string{"mysql/0"},
+		Leaders:      []string{"mysql"},
+		Applications: []string{"wordpress"},
+		ActionName:   "backup",
+		Parameters: map[string]interface{}{
+			"file":  map[string]interface{}{"kind": "xz"},
+			"count": 3,
+		},
+	})
+}
+
+func (s *ScheduleSuite) TestAddScheduleError(c *gc.C) {
+	s.client.SetErrors(errors.New("boom"))
+	wrappedCommand, _ := action.NewAddScheduleCommandForTest(s.store)
+	_, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", "nightly", "--schedule", "@daily", "mysql/0", "backup")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ScheduleSuite) TestListSchedulesEmpty(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No action schedules in the model.\n")
+}
+
+func (s *ScheduleSuite) setSchedules() {
+	created := time.Date(2018, 9, 1, 12, 0, 0, 0, time.UTC)
+	next := time.Date(2018, 9, 3, 2, 0, 0, 0, time.UTC)
+	s.client.schedules = []params.ActionSchedule{{
+		Name:       "hourly",
+		Schedule:   "@hourly",
+		Units:      []string{"mysql/0"},
+		ActionName: "check",
+		Created:    created,
+	}, {
+		Name:         "nightly",
+		Schedule:     "0 2 * * *",
+		Leaders:      []string{"mysql"},
+		Applications: []string{"wordpress"},
+		ActionName:   "backup",
+		Parameters:   map[string]interface{}{"out": "x.tar"},
+		Created:      created,
+		NextRun:      &next,
+		Runs: []params.ActionScheduleRun{{
+			Time:      time.Date(2018, 9, 2, 2, 0, 0, 0, time.UTC),
+			ActionIds: []string{"1", "2"},
+			Errors:    []string{`application "mysql" has no leader`},
+		}},
+	}}
+}
+
+func (s *ScheduleSuite) TestListSchedulesTabular(c *gc.C) {
+	s.setSchedules()
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Name     Schedule   Targets                 Action  Next run             Last run             Last result
+hourly   @hourly    mysql/0                 check                                             
+nightly  0 2 * * *  mysql/leader,wordpress  backup  2018-09-03 02:00:00  2018-09-02 02:00:00  2 queued, 1 failed
+`[1:])
+}
+
+func (s *ScheduleSuite) TestListSchedulesYAML(c *gc.C) {
+	s.setSchedules()
+	ctx, err := cmdtesting.RunCommand(c, action.NewListSchedulesCommandForTest(s.store), "-m", "admin", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+hourly:
+  schedule: '@hourly'
+  units:
+  - mysql/0
+  action: check
+  created: "2018-09-01 12:00:00"
+nightly:
+  schedule: 0 2 * * *
+  leaders:
+  - mysql
+  applications:
+  - wordpress
+  action: backup
+  parameters:
+    out: x.tar
+  created: "2018-09-01 12:00:00"
+  next-run: "2018-09-03 02:00:00"
+  runs:
+  - time: "2018-09-02 02:00:00"
+    actions:
+    - "1"
+    - "2"
+    errors:
+    - application "mysql" has no leader
+`[1:])
+}
+
+func (s *ScheduleSuite) TestRemoveSchedule(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, action.NewRemoveScheduleCommandForTest(s.store), "-m", "admin", "nightly", "hourly")
+	c.Assert(err, jc.ErrorIsNil)
+	s.client.CheckCall(c, 0, "RemoveSchedules", []string{"nightly", "hourly"})
+}
+
+func (s *ScheduleSuite) TestRemoveScheduleNoName(c *gc.C) {
+	err := cmdtesting.InitCommand(action.NewRemoveScheduleCommandForTest(s.store), nil)
+	c.Assert(err, gc.ErrorMatches, "no schedule name specified")
+}
+
+type fakeScheduleAPIClient struct {
+	jujutesting.Stub
+	schedules []params.ActionSchedule
+}
+
+func (c *fakeScheduleAPIClient) Close() error {
+	c.MethodCall(c, "Close")
+	return c.NextErr()
+}
+
+func (c *fakeScheduleAPIClient) AddSchedule(schedule params.AddActionSchedule) error {
+	c.MethodCall(c, "AddSchedule", schedule)
+	return c.NextErr()
+}
+
+func (c *fakeScheduleAPIClient) ListSchedules() ([]params.ActionSchedule, error) {
+	c.MethodCall(c, "ListSchedules")
+	return c.schedules, c.NextErr()
+}
+
+func (c *fakeScheduleAPIClient) RemoveSchedules(names ...string) error {
+	c.MethodCall(c, "RemoveSchedules", names)
+	return c.NextErr()
+}
@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var abortCharmUpgradeHelpSummary = `
+Aborts a rolling charm upgrade.`[1:]
+
+var abortCharmUpgradeHelpDetails = `
+Stops a rolling charm upgrade, started with "juju upgrade-charm --batch-size",
+from releasing any more units. Units that have already been released keep the
+new charm, and the remaining units keep running their current charm until the
+application's charm is next upgraded.
+
+Examples:
+    juju abort-charm-upgrade wordpress
+
+See also: 
+    resume-charm-upgrade
+    status
+    upgrade-charm`[1:]
+
+// NewAbortCharmUpgradeCommand returns a command to abort a rolling
+// charm upgrade.
+func NewAbortCharmUpgradeCommand() modelcmd.ModelCommand {
+	cmd := &abortCharmUpgradeCommand{}
+	cmd.newAPIFunc = func() (RollingUpgradeAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type abortCharmUpgradeCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	newAPIFunc      func() (RollingUpgradeAPI, error)
+}
+
+func (c *abortCharmUpgradeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "abort-charm-upgrade",
+		Args:    "<application name>",
+		Purpose: abortCharmUpgradeHelpSummary,
+		Doc:     abortCharmUpgradeHelpDetails,
+	}
+}
+
+func (c *abortCharmUpgradeCommand) Init(args []string) error {
+	name, err := rollingUpgradeApplication(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.applicationName = name
+	return nil
+}
+
+func (c *abortCharmUpgradeCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 9 {
+		return errors.New("aborting a charm upgrade is not supported by this version of Juju")
+	}
+	err = client.AbortRollingUpgrade(c.applicationName)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
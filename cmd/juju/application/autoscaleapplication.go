@@ -0,0 +1,173 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAutoscaleApplicationCommand returns a command which sets or
+// removes an application's autoscale policy.
+func NewAutoscaleApplicationCommand() modelcmd.ModelCommand {
+	cmd := &autoscaleApplicationCommand{}
+	cmd.newAPIFunc = func() (autoscaleApplicationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// autoscaleApplicationCommand is responsible for setting and
+// removing application autoscale policies.
+type autoscaleApplicationCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.CAASOnlyCommand
+
+	newAPIFunc      func() (autoscaleApplicationAPI, error)
+	applicationName string
+
+	minUnits int
+	maxUnits int
+	cpu      int
+	metric   string
+	remove   bool
+	policy   params.AutoscalePolicy
+}
+
+const autoscaleApplicationDoc = `
+Hand the scaling of a Kubernetes application over to the cluster. The
+cluster keeps the number of units between --min and --max, adding units
+when the load on the application's pods rises above the target and
+removing them when it falls below it. The target is either an average CPU
+utilisation of the pods, given as a percentage of the CPU they request,
+or an average value of a custom per-pod metric served by the cluster's
+metrics API, given as <metric>=<value>.
+
+While an application is autoscaled, its unit count follows the cluster
+and it cannot be scaled with scale-application. Use --remove to return
+the scaling of the application to the operator; it keeps its current
+number of units.
+
+Examples:
+
+    juju autoscale-application mariadb --min 2 --max 5 --cpu 80
+    juju autoscale-application gitlab --min 1 --max 10 --metric requests_per_second=100
+    juju autoscale-application mariadb --remove
+
+See also:
+    scale-application
+    status
+`
+
+// Info implements cmd.Command.
+func (c *autoscaleApplicationCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "autoscale-application",
+		Args:    "<application>",
+		Purpose: "Set or remove an application's autoscale policy.",
+		Doc:     autoscaleApplicationDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *autoscaleApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.minUnits, "min", 0, "The minimum number of units")
+	f.IntVar(&c.maxUnits, "max", 0, "The maximum number of units")
+	f.IntVar(&c.cpu, "cpu", 0, "The average CPU utilisation to target, as a percentage")
+	f.StringVar(&c.metric, "metric", "", "The average value of a custom metric to target, as <metric>=<value>")
+	f.BoolVar(&c.remove, "remove", false, "Remove the application's autoscale policy")
+}
+
+func (c *autoscaleApplicationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	c.applicationName = args[0]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if err := cmd.CheckEmpty(args[1:]); err != nil {
+		return err
+	}
+	if c.remove {
+		if c.minUnits != 0 || c.maxUnits != 0 || c.cpu != 0 || c.metric != "" {
+			return errors.New("--remove cannot be used with a policy")
+		}
+		return nil
+	}
+	if c.minUnits < 1 {
+		return errors.New("--min must be a positive integer")
+	}
+	if c.maxUnits < c.minUnits {
+		return errors.New("--max must be at least --min")
+	}
+	c.policy = params.AutoscalePolicy{
+		MinUnits: c.minUnits,
+		MaxUnits: c.maxUnits,
+	}
+	switch {
+	case c.cpu != 0 && c.metric != "":
+		return errors.New("only one of --cpu and --metric may be specified")
+	case c.cpu < 0:
+		return errors.New("--cpu must be a positive integer")
+	case c.cpu > 0:
+		c.policy.TargetCPUPercent = c.cpu
+	case c.metric != "":
+		parts := strings.SplitN(c.metric, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return errors.Errorf("invalid metric %q, expected <metric>=<value>", c.metric)
+		}
+		c.policy.Metric = parts[0]
+		c.policy.MetricTarget = parts[1]
+	default:
+		return errors.New("one of --cpu and --metric must be specified")
+	}
+	return nil
+}
+
+type autoscaleApplicationAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetAutoscalePolicy(application string, policy params.AutoscalePolicy) error
+	RemoveAutoscalePolicy(application string) error
+}
+
+// Run implements cmd.Command.
+func (c *autoscaleApplicationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 10 {
+		return errors.New("autoscaling applications is not supported by this controller")
+	}
+	if c.remove {
+		if err := client.RemoveAutoscalePolicy(c.applicationName); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("%v is no longer autoscaled", c.applicationName)
+		return nil
+	}
+	if err := client.SetAutoscalePolicy(c.applicationName, c.policy); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("%v autoscaled between %d and %d units", c.applicationName, c.minUnits, c.maxUnits)
+	return nil
+}
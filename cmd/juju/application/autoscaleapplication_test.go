@@ -0,0 +1,145 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type AutoscaleApplicationSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockAutoscaleApplicationAPI
+}
+
+var _ = gc.Suite(&AutoscaleApplicationSuite{})
+
+type mockAutoscaleApplicationAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockAutoscaleApplicationAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockAutoscaleApplicationAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockAutoscaleApplicationAPI) SetAutoscalePolicy(application string, policy params.AutoscalePolicy) error {
+	s.MethodCall(s, "SetAutoscalePolicy", application, policy)
+	return s.NextErr()
+}
+
+func (s mockAutoscaleApplicationAPI) RemoveAutoscalePolicy(application string) error {
+	s.MethodCall(s, "RemoveAutoscalePolicy", application)
+	return s.NextErr()
+}
+
+func (s *AutoscaleApplicationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockAutoscaleApplicationAPI{Stub: &testing.Stub{}, version: 10}
+}
+
+func (s *AutoscaleApplicationSuite) runAutoscaleApplication(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	store.Models["arthur"] = &jujuclient.ControllerModels{
+		CurrentModel: "king/sword",
+		Models: map[string]jujuclient.ModelDetails{"king/sword": {
+			ModelType: model.CAAS,
+		}},
+	}
+	return cmdtesting.RunCommand(c, NewAutoscaleCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *AutoscaleApplicationSuite) TestAutoscaleCPU(c *gc.C) {
+	ctx, err := s.runAutoscaleApplication(c, "foo", "--min", "2", "--max", "5", "--cpu", "80")
+	c.Assert(err, jc.ErrorIsNil)
+	out := strings.Replace(cmdtesting.Stderr(ctx), "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo autoscaled between 2 and 5 units`)
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", params.AutoscalePolicy{
+		MinUnits:         2,
+		MaxUnits:         5,
+		TargetCPUPercent: 80,
+	})
+}
+
+func (s *AutoscaleApplicationSuite) TestAutoscaleMetric(c *gc.C) {
+	_, err := s.runAutoscaleApplication(c, "foo", "--min", "1", "--max", "3", "--metric", "requests=100")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetAutoscalePolicy", "foo", params.AutoscalePolicy{
+		MinUnits:     1,
+		MaxUnits:     3,
+		Metric:       "requests",
+		MetricTarget: "100",
+	})
+}
+
+func (s *AutoscaleApplicationSuite) TestRemove(c *gc.C) {
+	ctx, err := s.runAutoscaleApplication(c, "foo", "--remove")
+	c.Assert(err, jc.ErrorIsNil)
+	out := strings.Replace(cmdtesting.Stderr(ctx), "\n", "", -1)
+	c.Assert(out, gc.Equals, `foo is no longer autoscaled`)
+	s.mockAPI.CheckCall(c, 0, "RemoveAutoscalePolicy", "foo")
+}
+
+func (s *AutoscaleApplicationSuite) TestAutoscaleApplicationWrongModel(c *gc.C) {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, NewAutoscaleCommandForTest(s.mockAPI, store), "foo", "--remove")
+	c.Assert(err, gc.ErrorMatches, `Juju command "autoscale-application" not supported on non-container models`)
+}
+
+func (s *AutoscaleApplicationSuite) TestInvalidArgs(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: `no application specified`,
+	}, {
+		args: []string{"invalid:name"},
+		err:  `invalid application name "invalid:name"`,
+	}, {
+		args: []string{"foo", "--remove", "--min", "1"},
+		err:  `--remove cannot be used with a policy`,
+	}, {
+		args: []string{"foo", "--max", "2", "--cpu", "80"},
+		err:  `--min must be a positive integer`,
+	}, {
+		args: []string{"foo", "--min", "3", "--max", "2", "--cpu", "80"},
+		err:  `--max must be at least --min`,
+	}, {
+		args: []string{"foo", "--min", "1", "--max", "2"},
+		err:  `one of --cpu and --metric must be specified`,
+	}, {
+		args: []string{"foo", "--min", "1", "--max", "2", "--cpu", "80", "--metric", "a=1"},
+		err:  `only one of --cpu and --metric may be specified`,
+	}, {
+		args: []string{"foo", "--min", "1", "--max", "2", "--metric", "requests"},
+		err:  `invalid metric "requests", expected <metric>=<value>`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.runAutoscaleApplication(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AutoscaleApplicationSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 9
+	_, err := s.runAutoscaleApplication(c, "foo", "--remove")
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications is not supported by this controller")
+	s.mockAPI.CheckCall(c, 0, "Close")
+}
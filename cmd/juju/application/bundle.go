@@ -6,6 +6,7 @@ package application
 import (
 	"encoding/base64"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -24,13 +25,14 @@ import (
 	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v2-unstable"
-	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/bundle"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/instance"
@@ -69,6 +71,7 @@ func deployBundle(
 	bundleStorage map[string]map[string]storage.Constraints,
 	bundleDevices map[string]map[string]devices.Constraints,
 	dryRun bool,
+	dryRunOut *cmd.Output,
 	useExistingMachines bool,
 	bundleMachines map[string]string,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
@@ -76,6 +79,40 @@ func deployBundle(
 	if err := processBundleOverlay(data, bundleOverlayFile...); err != nil {
 		return nil, err
 	}
+	// Process includes in the bundle data.
+	includeDir := bundleDir
+	if includeDir == "" {
+		includeDir = ctx.Dir
+	}
+	if err := processBundleIncludes(includeDir, data); err != nil {
+		return nil, errors.Annotate(err, "unable to process includes")
+	}
+	if err := verifyBundle(data, bundleDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// TODO: move bundle parsing and checking into the handler.
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage, bundleDevices)
+	h.dryRunOut = dryRunOut
+	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.resolveCharmsAndEndpoints(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.getChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := h.handleChanges(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return h.macaroons, nil
+
+}
+
+// verifyBundle checks that the given bundle data is valid. If bundleDir
+// is not empty, local charm paths are resolved relative to it.
+func verifyBundle(data *charm.BundleData, bundleDir string) error {
 	verifyConstraints := func(s string) error {
 		_, err := constraints.Parse(s)
 		return err
@@ -90,16 +127,8 @@ func deployBundle(
 	}
 	var verifyError error
 	if bundleDir == "" {
-		// Process includes in the bundle data.
-		if err := processBundleIncludes(ctx.Dir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
-		}
 		verifyError = data.Verify(verifyConstraints, verifyStorage, verifyDevices)
 	} else {
-		// Process includes in the bundle data.
-		if err := processBundleIncludes(bundleDir, data); err != nil {
-			return nil, errors.Annotate(err, "unable to process includes")
-		}
 		verifyError = data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage, verifyDevices)
 	}
 	if verifyError != nil {
@@ -108,33 +137,21 @@ func deployBundle(
 			for i, err := range verr.Errors {
 				errs[i] = err.Error()
 			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+			return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
 		}
-		return nil, errors.Trace(verifyError)
-	}
-
-	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleStorage, bundleDevices)
-	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
-		return nil, errors.Trace(err)
+		return errors.Trace(verifyError)
 	}
-	if err := h.resolveCharmsAndEndpoints(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.getChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	if err := h.handleChanges(); err != nil {
-		return nil, errors.Trace(err)
-	}
-	return h.macaroons, nil
-
+	return nil
 }
 
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	dryRun bool
 
+	// dryRunOut is used to write the changes for a dry run. If it is
+	// nil, the changes are written as a summary.
+	dryRunOut *cmd.Output
+
 	// bundleDir is the path where the bundle file is located for local bundles.
 	bundleDir string
 	// changes holds the changes to be applied in order to deploy the bundle.
@@ -345,6 +362,10 @@ func (h *bundleHandler) getChanges() error {
 }
 
 func (h *bundleHandler) handleChanges() error {
+	if h.dryRun {
+		return errors.Trace(h.writeDryRun())
+	}
+
 	var err error
 	// Instantiate a watcher used to follow the deployment progress.
 	h.watcher, err = h.api.WatchAll()
@@ -358,11 +379,7 @@ func (h *bundleHandler) handleChanges() error {
 		return nil
 	}
 
-	if h.dryRun {
-		fmt.Fprintf(h.ctx.Stdout, "Changes to deploy bundle:\n")
-	} else {
-		fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
-	}
+	fmt.Fprintf(h.ctx.Stdout, "Executing changes:\n")
 
 	// Deploy the bundle.
 	for i, change := range h.changes {
@@ -397,13 +414,74 @@ func (h *bundleHandler) handleChanges() error {
 		}
 	}
 
-	if !h.dryRun {
-		h.ctx.Infof("Deploy of bundle completed.")
+	h.ctx.Infof("Deploy of bundle completed.")
+	return nil
+}
+
+// writeDryRun writes the changes that deploying the bundle would make,
+// without making them.
+func (h *bundleHandler) writeDryRun() error {
+	format := "summary"
+	if h.dryRunOut != nil {
+		format = h.dryRunOut.Name()
 	}
+	if len(h.changes) == 0 && (format == "summary" || format == "tabular") {
+		h.ctx.Infof("No changes to apply.")
+		return nil
+	}
+	changes := make([]bundleChange, len(h.changes))
+	for i, change := range h.changes {
+		logger.Tracef("%d: change %s", i, pretty.Sprint(change))
+		changes[i] = bundleChange{
+			Id:          change.Id(),
+			Method:      change.Method(),
+			Description: change.Description(),
+			Args:        change.GUIArgs(),
+			Requires:    change.Requires(),
+		}
+	}
+	if h.dryRunOut == nil {
+		return errors.Trace(formatBundleChangesSummary(h.ctx.Stdout, changes))
+	}
+	return errors.Trace(h.dryRunOut.Write(h.ctx, changes))
+}
 
+// bundleChange is the output representation of a change computed for
+// a bundle deployment.
+type bundleChange struct {
+	Id          string        `yaml:"id" json:"id"`
+	Method      string        `yaml:"method" json:"method"`
+	Description string        `yaml:"description" json:"description"`
+	Args        []interface{} `yaml:"args,omitempty" json:"args,omitempty"`
+	Requires    []string      `yaml:"requires,omitempty" json:"requires,omitempty"`
+}
+
+func formatBundleChangesSummary(writer io.Writer, value interface{}) error {
+	changes, ok := value.([]bundleChange)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", changes, value)
+	}
+	fmt.Fprintf(writer, "Changes to deploy bundle:\n")
+	for _, change := range changes {
+		fmt.Fprintf(writer, "- %s\n", change.Description)
+	}
 	return nil
 }
 
+func formatBundleChangesTabular(writer io.Writer, value interface{}) error {
+	changes, ok := value.([]bundleChange)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", changes, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Change", "Method", "Description", "Requires")
+	for _, change := range changes {
+		w.Println(change.Id, change.Method, change.Description, strings.Join(change.Requires, ","))
+	}
+	return tw.Flush()
+}
+
 func (h *bundleHandler) isLocalCharm(name string) bool {
 	return strings.HasPrefix(name, ".") || filepath.IsAbs(name)
 }
@@ -1153,10 +1231,6 @@ func processValue(baseDir string, v interface{}) (interface{}, bool, error) {
 	return result, true, nil
 }
 
-type bundleOverlayValueExists struct {
-	Applications map[string]map[string]interface{} `yaml:"applications"`
-}
-
 func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string) error {
 	for _, filename := range bundleOverlayFiles {
 		bundleOverlayFile, err := utils.NormalizePath(filename)
@@ -1179,175 +1253,46 @@ func processBundleOverlay(data *charm.BundleData, bundleOverlayFiles ...string)
 }
 
 func processSingleBundleOverlay(data *charm.BundleData, bundleOverlayFile string) error {
-	config, err := charmrepo.ReadBundleFile(bundleOverlayFile)
-	if err != nil {
+	// Read the file as a bundle first, so that a missing file is
+	// reported consistently with the primary bundle.
+	if _, err := charmrepo.ReadBundleFile(bundleOverlayFile); err != nil {
 		return errors.Annotatef(err, "unable to read bundle overlay file %q", bundleOverlayFile)
 	}
-
-	// From here we walk through the new bundleData, and override values
-	// in the current bundle.
-
-	// If a new application is added, the content is added.
-	// If an application is defined but with no content, that means
-	// remove the application from the parent bundle.
-	//   - if this is happening, all related relations are also removed.
-	// If the application exists in both, values here override values there.
-	// If machines are defined, they override the entire machines section.
-
 	content, err := ioutil.ReadFile(bundleOverlayFile)
 	if err != nil {
 		return errors.Annotate(err, "unable to open bundle overlay file")
 	}
-	baseDir := filepath.Dir(bundleOverlayFile)
+	overlay, err := bundle.ParseOverlay(content)
+	if err != nil {
+		return errors.Annotatef(err, "bundle overlay file %q", bundleOverlayFile)
+	}
 
-	// If this works, then this deserialisation should certainly succeed.
-	// Since we are only looking to overwrite the values in the underlying bundle
-	// for config values that are set, we need to know if they were actually set,
-	// and not just zero. The configCheck structure is a map that allows us to check
-	// if the fields were actually in the underlying YAML.
-	var configCheck bundleOverlayValueExists
-	if err := yaml.Unmarshal(content, &configCheck); err != nil {
-		return errors.Annotate(err, "unable to deserialize config structure")
-	}
-	// Here there is a possibility that the config file uses top level
-	// applications, whereas the bundleOverlayValueExists only defines the newer
-	// applications. If that is the case, we error out and tell the user.
-	if len(configCheck.Applications) == 0 && len(config.Applications) > 0 {
-		return errors.Errorf("bundle overlay file %q used deprecated 'services' key, this is not valid for bundle overlay files", bundleOverlayFile)
-	}
-
-	// We want to confirm that all the applications mentioned in the config
-	// actually exist in the bundle data.
-	for appName, bc := range config.Applications {
-		app, found := data.Applications[appName]
-		// If bc is nil, that means to remove it from data.
-		if bc == nil {
-			delete(data.Applications, appName)
-			data.Relations = removeRelations(data.Relations, appName)
-			continue
-		}
-		if !found {
-			// Add it in.
-			data.Applications[appName] = bc
+	// Includes in the overlay are relative to the overlay file, so they
+	// are resolved before the overlay is applied.
+	baseDir := filepath.Dir(bundleOverlayFile)
+	for appName, app := range overlay.Data.Applications {
+		if app == nil {
 			continue
 		}
-
-		fieldCheck := configCheck.Applications[appName]
-
-		if _, set := fieldCheck["charm"]; set {
-			app.Charm = bc.Charm
-		}
-		if _, set := fieldCheck["series"]; set {
-			app.Series = bc.Series
-		}
-		if _, set := fieldCheck["resources"]; set {
-			if app.Resources == nil {
-				app.Resources = make(map[string]interface{})
-			}
-			for key, value := range bc.Resources {
-				app.Resources[key] = value
-			}
-		}
-		if _, set := fieldCheck["num_units"]; set {
-			app.NumUnits = bc.NumUnits
-		}
-		if _, set := fieldCheck["to"]; set {
-			app.To = bc.To
-		}
-		if _, set := fieldCheck["expose"]; set {
-			app.Expose = bc.Expose
-		}
-		if _, set := fieldCheck["options"]; set {
-			if app.Options == nil {
-				app.Options = make(map[string]interface{})
-			}
-			for key, value := range bc.Options {
-				result, _, err := processValue(baseDir, value)
-				if err != nil {
-					return errors.Annotatef(err, "processing config options value %s for application %s", key, appName)
-				}
-				app.Options[key] = result
-			}
-		}
-		if _, set := fieldCheck["annotations"]; set {
-			if app.Annotations == nil {
-				app.Annotations = make(map[string]string)
-			}
-			for key, value := range bc.Annotations {
-				result, _, err := processValue(baseDir, value)
-				if err != nil {
-					return errors.Annotatef(err, "processing config annotations value %s for application %s", key, appName)
-				}
-				app.Annotations[key] = result.(string)
-			}
-		}
-		if _, set := fieldCheck["constraints"]; set {
-			app.Constraints = bc.Constraints
-		}
-		if _, set := fieldCheck["storage"]; set {
-			if app.Storage == nil {
-				app.Storage = make(map[string]string)
-			}
-			for key, value := range bc.Storage {
-				app.Storage[key] = value
-			}
-		}
-		if _, set := fieldCheck["devices"]; set {
-			if app.Devices == nil {
-				app.Devices = make(map[string]string)
-			}
-			for key, value := range bc.Devices {
-				app.Devices[key] = value
+		for key, value := range app.Options {
+			result, _, err := processValue(baseDir, value)
+			if err != nil {
+				return errors.Annotatef(err, "processing config options value %s for application %s", key, appName)
 			}
+			app.Options[key] = result
 		}
-		if _, set := fieldCheck["bindings"]; set {
-			if app.EndpointBindings == nil {
-				app.EndpointBindings = make(map[string]string)
-			}
-			for key, value := range bc.EndpointBindings {
-				app.EndpointBindings[key] = value
+		for key, value := range app.Annotations {
+			result, _, err := processValue(baseDir, value)
+			if err != nil {
+				return errors.Annotatef(err, "processing config annotations value %s for application %s", key, appName)
 			}
+			app.Annotations[key] = result.(string)
 		}
 	}
-
-	// If series is set in the config, it overrides the bundle.
-	if config.Series != "" {
-		data.Series = config.Series
-	}
-
-	// Next process relations.
-	for _, relation := range config.Relations {
-		data.Relations = append(data.Relations, relation)
-	}
-
-	// Finally, if the bundle overlay overrode the machines definition use
-	// that.
-	if config.Machines != nil {
-		data.Machines = config.Machines
-	}
-
+	overlay.Apply(data)
 	return nil
 }
 
-// removeRelations removes any relation defined in data that references
-// the application appName.
-func removeRelations(data [][]string, appName string) [][]string {
-	var result [][]string
-	for _, relation := range data {
-		// Keep the dud relation in the set, it will be caught by the bundle
-		// verify code.
-		if len(relation) == 2 {
-			left, right := relation[0], relation[1]
-			if left == appName || strings.HasPrefix(left, appName+":") ||
-				right == appName || strings.HasPrefix(right, appName+":") {
-				continue
-			}
-		}
-		result = append(result, relation)
-	}
-	return result
-}
-
 func buildModelRepresentation(
 	status *params.FullStatus,
 	apiRoot DeployAPI,
@@ -25,6 +25,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/charmrepo.v3"
 	"gopkg.in/juju/charmrepo.v3/csclient"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/constraints"
@@ -371,6 +372,83 @@ func (s *BundleDeployCharmStoreSuite) TestDryRunExistingModel(c *gc.C) {
 	c.Check(stdOut, gc.Equals, expected)
 }
 
+func (s *BundleDeployCharmStoreSuite) setupDryRunExistingModel(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name: "mysql", Series: "xenial", Revision: "42"})
+	mysql := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name: "mysql", Charm: ch})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: mysql})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDryRunTabular(c *gc.C) {
+	s.setupDryRunExistingModel(c)
+	stdOut, _, err := runDeployWithOutput(c, "bundle/wordpress-simple", "--dry-run", "--format", "tabular")
+	c.Assert(err, jc.ErrorIsNil)
+	lines := strings.Split(stdOut, "\n")
+	c.Assert(lines, gc.HasLen, 5)
+	c.Check(lines[0], gc.Matches, `Change +Method +Description +Requires`)
+	c.Check(lines[1], gc.Matches, `addCharm-\d+ +addCharm +upload charm cs:xenial/wordpress-47 for series xenial *`)
+	c.Check(lines[2], gc.Matches, `deploy-\d+ +deploy +deploy application wordpress on xenial using cs:xenial/wordpress-47 +addCharm-\d+`)
+	c.Check(lines[3], gc.Matches, `addRelation-\d+ +addRelation +add relation wordpress:db - mysql:server +deploy-\d+`)
+	c.Check(lines[4], gc.Matches, `addUnit-\d+ +addUnit +add unit wordpress/0 to new machine 1 +.*deploy-\d+.*`)
+	// Only the existing application is in the model.
+	applications, err := s.State.AllApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications, gc.HasLen, 1)
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDryRunYAML(c *gc.C) {
+	s.setupDryRunExistingModel(c)
+	stdOut, _, err := runDeployWithOutput(c, "bundle/wordpress-simple", "--dry-run", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	var changes []struct {
+		Id          string        `yaml:"id"`
+		Method      string        `yaml:"method"`
+		Description string        `yaml:"description"`
+		Args        []interface{} `yaml:"args"`
+		Requires    []string      `yaml:"requires"`
+	}
+	err = yaml.Unmarshal([]byte(stdOut), &changes)
+	c.Assert(err, jc.ErrorIsNil)
+	var methods, descriptions []string
+	for _, change := range changes {
+		methods = append(methods, change.Method)
+		descriptions = append(descriptions, change.Description)
+	}
+	c.Check(methods, jc.DeepEquals, []string{"addCharm", "deploy", "addRelation", "addUnit"})
+	c.Check(descriptions, jc.DeepEquals, []string{
+		"upload charm cs:xenial/wordpress-47 for series xenial",
+		"deploy application wordpress on xenial using cs:xenial/wordpress-47",
+		"add relation wordpress:db - mysql:server",
+		"add unit wordpress/0 to new machine 1",
+	})
+	c.Check(changes[1].Requires, jc.DeepEquals, []string{changes[0].Id})
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDryRunNoChangesJSON(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	err := runDeploy(c, "bundle/wordpress-simple")
+	c.Assert(err, jc.ErrorIsNil)
+
+	stdOut, _, err := runDeployWithOutput(c, "bundle/wordpress-simple", "--dry-run", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stdOut, gc.Equals, "[]")
+}
+
+func (s *BundleDeployCharmStoreSuite) TestFormatRequiresDryRun(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
+	testcharms.UploadBundle(c, s.client, "bundle/wordpress-simple-1", "wordpress-simple")
+	err := runDeploy(c, "bundle/wordpress-simple", "--format", "yaml")
+	c.Assert(err, gc.ErrorMatches, `.*flags provided but only supported with --dry-run: --format`)
+	s.assertApplicationsDeployed(c, map[string]applicationInfo{})
+}
+
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleGatedCharm(c *gc.C) {
 	_, mysqlch := testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
 	url, _ := testcharms.UploadCharm(c, s.client, "xenial/wordpress-47", "wordpress")
@@ -2251,61 +2329,6 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundlePassesSequences(c *gc.C) {
 	})
 }
 
-type removeRelationsSuite struct{}
-
-var (
-	_ = gc.Suite(&removeRelationsSuite{})
-
-	sampleRelations = [][]string{
-		{"kubernetes-master:kube-control", "kubernetes-worker:kube-control"},
-		{"kubernetes-master:etcd", "etcd:db"},
-		{"kubernetes-worker:kube-api-endpoint", "kubeapi-load-balancer:website"},
-		{"flannel", "etcd"}, // removed :endpoint
-		{"flannel:cni", "kubernetes-master:cni"},
-		{"flannel:cni", "kubernetes-worker:cni"},
-	}
-)
-
-func (*removeRelationsSuite) TestNil(c *gc.C) {
-	result := removeRelations(nil, "foo")
-	c.Assert(result, gc.HasLen, 0)
-}
-
-func (*removeRelationsSuite) TestEmpty(c *gc.C) {
-	result := removeRelations([][]string{}, "foo")
-	c.Assert(result, gc.HasLen, 0)
-}
-
-func (*removeRelationsSuite) TestAppNotThere(c *gc.C) {
-	result := removeRelations(sampleRelations, "foo")
-	c.Assert(result, jc.DeepEquals, sampleRelations)
-}
-
-func (*removeRelationsSuite) TestAppBadRelationsKept(c *gc.C) {
-	badRelations := [][]string{{"single value"}, {"three", "string", "values"}}
-	result := removeRelations(badRelations, "foo")
-	c.Assert(result, jc.DeepEquals, badRelations)
-}
-
-func (*removeRelationsSuite) TestRemoveFromRight(c *gc.C) {
-	result := removeRelations(sampleRelations, "etcd")
-	c.Assert(result, jc.DeepEquals, [][]string{
-		{"kubernetes-master:kube-control", "kubernetes-worker:kube-control"},
-		{"kubernetes-worker:kube-api-endpoint", "kubeapi-load-balancer:website"},
-		{"flannel:cni", "kubernetes-master:cni"},
-		{"flannel:cni", "kubernetes-worker:cni"},
-	})
-}
-
-func (*removeRelationsSuite) TestRemoveFromLeft(c *gc.C) {
-	result := removeRelations(sampleRelations, "flannel")
-	c.Assert(result, jc.DeepEquals, [][]string{
-		{"kubernetes-master:kube-control", "kubernetes-worker:kube-control"},
-		{"kubernetes-master:etcd", "etcd:db"},
-		{"kubernetes-worker:kube-api-endpoint", "kubeapi-load-balancer:website"},
-	})
-}
-
 func missingFileRegex(filename string) string {
 	text := "no such file or directory"
 	if runtime.GOOS == "windows" {
@@ -0,0 +1,574 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+)
+
+// diffModel holds the parts of the model that can be described by
+// a bundle.
+type diffModel struct {
+	status *params.FullStatus
+
+	// options holds the user-set application config, keyed by
+	// application name.
+	options map[string]map[string]interface{}
+
+	// constraints holds the application constraints, keyed by
+	// application name.
+	constraints map[string]constraints.Value
+
+	// annotations holds the annotations for applications and machines,
+	// keyed by tag. It is only populated if annotations are being
+	// compared.
+	annotations map[string]map[string]string
+}
+
+// readDiffModel reads the current state of the model from the API.
+func readDiffModel(client DiffBundleAPI, includeAnnotations bool) (*diffModel, error) {
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting model status")
+	}
+	model := &diffModel{
+		status:      status,
+		options:     make(map[string]map[string]interface{}),
+		constraints: make(map[string]constraints.Value),
+		annotations: make(map[string]map[string]string),
+	}
+
+	var appNames, principalApps, annotationTags []string
+	for name, app := range status.Applications {
+		appNames = append(appNames, name)
+		if len(app.SubordinateTo) == 0 {
+			principalApps = append(principalApps, name)
+		}
+		annotationTags = append(annotationTags, names.NewApplicationTag(name).String())
+	}
+	sort.Strings(appNames)
+	sort.Strings(principalApps)
+	for id := range status.Machines {
+		annotationTags = append(annotationTags, names.NewMachineTag(id).String())
+	}
+	if len(appNames) == 0 {
+		return model, nil
+	}
+
+	configValues, err := client.GetConfig(appNames...)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting application options")
+	}
+	for i, config := range configValues {
+		options := make(map[string]interface{})
+		for key, valueMap := range config {
+			value, err := applicationConfigValue(key, valueMap)
+			if err != nil {
+				return nil, errors.Annotatef(err, "bad application config for %q", appNames[i])
+			}
+			if value != nil {
+				options[key] = value
+			}
+		}
+		model.options[appNames[i]] = options
+	}
+
+	if len(principalApps) > 0 {
+		constraintValues, err := client.GetConstraints(principalApps...)
+		if err != nil {
+			return nil, errors.Annotate(err, "getting application constraints")
+		}
+		for i, value := range constraintValues {
+			model.constraints[principalApps[i]] = value
+		}
+	}
+
+	if !includeAnnotations {
+		return model, nil
+	}
+	sort.Strings(annotationTags)
+	results, err := client.GetAnnotations(annotationTags)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting annotations")
+	}
+	for _, result := range results {
+		if result.Error.Error != nil {
+			return nil, errors.Trace(result.Error.Error)
+		}
+		model.annotations[result.EntityTag] = result.Annotations
+	}
+	return model, nil
+}
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Machines     map[string]*machineDiff     `yaml:"machines,omitempty" json:"machines,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// The values used for Missing when an application or machine only
+// appears on one side of the comparison.
+const (
+	missingFromBundle = "bundle"
+	missingFromModel  = "model"
+)
+
+// applicationDiff describes the differences in an application.
+type applicationDiff struct {
+	Missing          string                 `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm            *stringDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series           *stringDiff            `yaml:"series,omitempty" json:"series,omitempty"`
+	NumUnits         *intDiff               `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Expose           *boolDiff              `yaml:"expose,omitempty" json:"expose,omitempty"`
+	Options          map[string]*opaqueDiff `yaml:"options,omitempty" json:"options,omitempty"`
+	Constraints      *stringDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Annotations      map[string]*stringDiff `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+	EndpointBindings map[string]*stringDiff `yaml:"bindings,omitempty" json:"bindings,omitempty"`
+}
+
+func (d *applicationDiff) empty() bool {
+	return reflect.DeepEqual(d, &applicationDiff{})
+}
+
+// machineDiff describes the differences in a top level machine.
+type machineDiff struct {
+	Missing     string                 `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Series      *stringDiff            `yaml:"series,omitempty" json:"series,omitempty"`
+	Constraints *stringDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Annotations map[string]*stringDiff `yaml:"annotations,omitempty" json:"annotations,omitempty"`
+}
+
+func (d *machineDiff) empty() bool {
+	return reflect.DeepEqual(d, &machineDiff{})
+}
+
+// relationsDiff holds the relations that only appear on one side of
+// the comparison.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+type opaqueDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// diffBundleConfig holds the options for comparing a bundle with a
+// model.
+type diffBundleConfig struct {
+	// machineMap maps bundle machine ids to model machine ids. Bundle
+	// machines that aren't mapped are compared with the model machine
+	// of the same id.
+	machineMap map[string]string
+
+	// annotations controls whether annotations are compared.
+	annotations bool
+}
+
+// diffBundle compares the bundle data with the model.
+func diffBundle(data *charm.BundleData, model *diffModel, config diffBundleConfig) *bundleDiff {
+	d := &bundleDiffer{
+		data:   data,
+		model:  model,
+		config: config,
+	}
+	return d.diff()
+}
+
+type bundleDiffer struct {
+	data   *charm.BundleData
+	model  *diffModel
+	config diffBundleConfig
+}
+
+func (d *bundleDiffer) diff() *bundleDiff {
+	result := &bundleDiff{
+		Applications: d.diffApplications(),
+		Machines:     d.diffMachines(),
+		Relations:    d.diffRelations(),
+	}
+	return result
+}
+
+func (d *bundleDiffer) diffApplications() map[string]*applicationDiff {
+	results := make(map[string]*applicationDiff)
+	for name, spec := range d.data.Applications {
+		app, found := d.model.status.Applications[name]
+		if !found {
+			results[name] = &applicationDiff{Missing: missingFromModel}
+			continue
+		}
+		if spec == nil {
+			spec = &charm.ApplicationSpec{}
+		}
+		if diff := d.diffApplication(name, spec, app); !diff.empty() {
+			results[name] = diff
+		}
+	}
+	for name := range d.model.status.Applications {
+		if _, found := d.data.Applications[name]; !found {
+			results[name] = &applicationDiff{Missing: missingFromBundle}
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+func (d *bundleDiffer) diffApplication(name string, spec *charm.ApplicationSpec, app params.ApplicationStatus) *applicationDiff {
+	result := &applicationDiff{
+		Charm:            diffCharms(spec.Charm, app.Charm),
+		Options:          diffOptions(spec.Options, d.model.options[name]),
+		EndpointBindings: diffBindings(spec.EndpointBindings, app.EndpointBindings),
+	}
+
+	if series := d.applicationSeries(spec); series != "" && series != app.Series {
+		result.Series = &stringDiff{Bundle: series, Model: app.Series}
+	}
+	if len(app.SubordinateTo) == 0 && spec.NumUnits != len(app.Units) {
+		result.NumUnits = &intDiff{Bundle: spec.NumUnits, Model: len(app.Units)}
+	}
+	if spec.Expose != app.Exposed {
+		result.Expose = &boolDiff{Bundle: spec.Expose, Model: app.Exposed}
+	}
+	if modelCons, found := d.model.constraints[name]; found {
+		result.Constraints = diffConstraints(spec.Constraints, modelCons)
+	}
+	if d.config.annotations {
+		tag := names.NewApplicationTag(name).String()
+		result.Annotations = diffAnnotations(spec.Annotations, d.model.annotations[tag])
+	}
+	return result
+}
+
+// applicationSeries returns the series the bundle expects the
+// application to be deployed with, or "" if that depends on the charm.
+func (d *bundleDiffer) applicationSeries(spec *charm.ApplicationSpec) string {
+	if spec.Series != "" {
+		return spec.Series
+	}
+	if !isLocalCharmPath(spec.Charm) {
+		if curl, err := charm.ParseURL(spec.Charm); err == nil && curl.Series != "" {
+			return curl.Series
+		}
+	}
+	return d.data.Series
+}
+
+func (d *bundleDiffer) diffMachines() map[string]*machineDiff {
+	results := make(map[string]*machineDiff)
+	compared := make(map[string]bool)
+	for bundleID, spec := range d.data.Machines {
+		modelID := bundleID
+		if mapped, found := d.config.machineMap[bundleID]; found {
+			modelID = mapped
+		}
+		machine, found := d.model.status.Machines[modelID]
+		if !found {
+			results[bundleID] = &machineDiff{Missing: missingFromModel}
+			continue
+		}
+		compared[modelID] = true
+		if spec == nil {
+			spec = &charm.MachineSpec{}
+		}
+		if diff := d.diffMachine(modelID, spec, machine); !diff.empty() {
+			results[bundleID] = diff
+		}
+	}
+	for id := range d.model.status.Machines {
+		if !compared[id] {
+			results[id] = &machineDiff{Missing: missingFromBundle}
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+func (d *bundleDiffer) diffMachine(modelID string, spec *charm.MachineSpec, machine params.MachineStatus) *machineDiff {
+	result := &machineDiff{}
+	series := spec.Series
+	if series == "" {
+		series = d.data.Series
+	}
+	if series != "" && series != machine.Series {
+		result.Series = &stringDiff{Bundle: series, Model: machine.Series}
+	}
+	if spec.Constraints != "" || machine.Constraints != "" {
+		// The model constraints come from the controller and so will
+		// always parse.
+		modelCons, _ := constraints.Parse(machine.Constraints)
+		result.Constraints = diffConstraints(spec.Constraints, modelCons)
+	}
+	if d.config.annotations {
+		tag := names.NewMachineTag(modelID).String()
+		result.Annotations = diffAnnotations(spec.Annotations, d.model.annotations[tag])
+	}
+	return result
+}
+
+// diffRelations compares the relations in the bundle with those in the
+// model. An endpoint name may be omitted in the bundle, in which case
+// it matches any endpoint of that application.
+func (d *bundleDiffer) diffRelations() *relationsDiff {
+	var modelRelations []relationEndpoints
+	for _, relation := range d.model.status.Relations {
+		// Peer relations can't be specified in a bundle.
+		if len(relation.Endpoints) != 2 {
+			continue
+		}
+		modelRelations = append(modelRelations, relationEndpoints{
+			{relation.Endpoints[0].ApplicationName, relation.Endpoints[0].Name},
+			{relation.Endpoints[1].ApplicationName, relation.Endpoints[1].Name},
+		})
+	}
+
+	result := &relationsDiff{}
+	matched := make([]bool, len(modelRelations))
+	for _, relation := range d.data.Relations {
+		if len(relation) != 2 {
+			// The bundle has been verified, so this can't happen.
+			continue
+		}
+		bundleRelation := relationEndpoints{
+			parseRelationEndpoint(relation[0]),
+			parseRelationEndpoint(relation[1]),
+		}
+		found := false
+		for i, modelRelation := range modelRelations {
+			if bundleRelation.matches(modelRelation) {
+				matched[i] = true
+				found = true
+			}
+		}
+		if !found {
+			result.BundleAdditions = append(result.BundleAdditions, bundleRelation.strings())
+		}
+	}
+	for i, modelRelation := range modelRelations {
+		if !matched[i] {
+			result.ModelAdditions = append(result.ModelAdditions, modelRelation.strings())
+		}
+	}
+	if len(result.BundleAdditions) == 0 && len(result.ModelAdditions) == 0 {
+		return nil
+	}
+	sortRelations(result.BundleAdditions)
+	sortRelations(result.ModelAdditions)
+	return result
+}
+
+type relationEndpoint struct {
+	application string
+	name        string
+}
+
+func parseRelationEndpoint(value string) relationEndpoint {
+	parts := strings.SplitN(value, ":", 2)
+	if len(parts) == 1 {
+		return relationEndpoint{application: parts[0]}
+	}
+	return relationEndpoint{application: parts[0], name: parts[1]}
+}
+
+// matches returns whether the endpoint matches the other, treating an
+// empty endpoint name as matching any name.
+func (e relationEndpoint) matches(other relationEndpoint) bool {
+	if e.application != other.application {
+		return false
+	}
+	return e.name == "" || other.name == "" || e.name == other.name
+}
+
+func (e relationEndpoint) String() string {
+	if e.name == "" {
+		return e.application
+	}
+	return e.application + ":" + e.name
+}
+
+type relationEndpoints [2]relationEndpoint
+
+func (r relationEndpoints) matches(other relationEndpoints) bool {
+	return (r[0].matches(other[0]) && r[1].matches(other[1])) ||
+		(r[0].matches(other[1]) && r[1].matches(other[0]))
+}
+
+// strings returns the endpoints as they would be written in a bundle,
+// in sorted order.
+func (r relationEndpoints) strings() []string {
+	result := []string{r[0].String(), r[1].String()}
+	sort.Strings(result)
+	return result
+}
+
+func sortRelations(relations [][]string) {
+	sort.Slice(relations, func(i, j int) bool {
+		a, b := relations[i], relations[j]
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] < b[1]
+	})
+}
+
+// isLocalCharmPath returns whether the bundle charm refers to a charm
+// on the local filesystem.
+func isLocalCharmPath(charmPath string) bool {
+	return strings.HasPrefix(charmPath, ".") || filepath.IsAbs(charmPath)
+}
+
+// diffCharms compares the charm named in the bundle with the charm URL
+// the application is using. Parts of the URL that the bundle doesn't
+// specify, such as the revision, aren't compared.
+func diffCharms(bundleCharm, modelCharm string) *stringDiff {
+	diff := &stringDiff{Bundle: bundleCharm, Model: modelCharm}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return diff
+	}
+	if isLocalCharmPath(bundleCharm) {
+		// We can only compare the charm name for local charms.
+		if modelURL.Schema == "local" && modelURL.Name == filepath.Base(bundleCharm) {
+			return nil
+		}
+		return diff
+	}
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return diff
+	}
+	switch {
+	case bundleURL.Schema != modelURL.Schema,
+		bundleURL.User != modelURL.User,
+		bundleURL.Name != modelURL.Name,
+		bundleURL.Series != "" && bundleURL.Series != modelURL.Series,
+		bundleURL.Revision >= 0 && bundleURL.Revision != modelURL.Revision:
+		return diff
+	}
+	return nil
+}
+
+// diffOptions compares the options in the bundle with the user-set
+// config values in the model.
+func diffOptions(bundleOptions, modelOptions map[string]interface{}) map[string]*opaqueDiff {
+	results := make(map[string]*opaqueDiff)
+	for key, bundleValue := range bundleOptions {
+		modelValue := modelOptions[key]
+		if !reflect.DeepEqual(normaliseOptionValue(bundleValue), normaliseOptionValue(modelValue)) {
+			results[key] = &opaqueDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key, modelValue := range modelOptions {
+		if _, found := bundleOptions[key]; !found {
+			results[key] = &opaqueDiff{Model: modelValue}
+		}
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
+
+// normaliseOptionValue converts numbers to float64, since values read
+// from the bundle YAML and from the API's JSON will have different
+// numeric types.
+func normaliseOptionValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	}
+	return value
+}
+
+func diffConstraints(bundleConstraints string, modelConstraints constraints.Value) *stringDiff {
+	// The bundle has been verified, so the constraints will parse.
+	bundleCons, _ := constraints.Parse(bundleConstraints)
+	if reflect.DeepEqual(bundleCons, modelConstraints) {
+		return nil
+	}
+	return &stringDiff{Bundle: bundleConstraints, Model: modelConstraints.String()}
+}
+
+func diffAnnotations(bundleAnnotations, modelAnnotations map[string]string) map[string]*stringDiff {
+	return diffStringMaps(bundleAnnotations, modelAnnotations, func(key string) (string, string) {
+		return bundleAnnotations[key], modelAnnotations[key]
+	})
+}
+
+// diffBindings compares the endpoint bindings in the bundle with those
+// of the application. An endpoint not mentioned in the bundle is
+// expected to be bound to the bundle's default space, given with the
+// empty endpoint name.
+func diffBindings(bundleBindings, modelBindings map[string]string) map[string]*stringDiff {
+	return diffStringMaps(bundleBindings, modelBindings, func(endpoint string) (string, string) {
+		bundleSpace, found := bundleBindings[endpoint]
+		if !found {
+			bundleSpace = bundleBindings[""]
+		}
+		modelSpace, found := modelBindings[endpoint]
+		if !found {
+			modelSpace = modelBindings[""]
+		}
+		return bundleSpace, modelSpace
+	})
+}
+
+// diffStringMaps compares the values returned by get for every key in
+// either map.
+func diffStringMaps(bundleMap, modelMap map[string]string, get func(string) (string, string)) map[string]*stringDiff {
+	results := make(map[string]*stringDiff)
+	check := func(key string) {
+		bundleValue, modelValue := get(key)
+		if bundleValue != modelValue {
+			results[key] = &stringDiff{Bundle: bundleValue, Model: modelValue}
+		}
+	}
+	for key := range bundleMap {
+		check(key)
+	}
+	for key := range modelMap {
+		check(key)
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	return results
+}
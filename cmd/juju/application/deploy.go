@@ -15,7 +15,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/romulus"
-	"github.com/juju/utils/featureflag"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charmrepo.v3"
 	"gopkg.in/juju/charmrepo.v3/csclient"
@@ -39,9 +38,9 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
-	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/resource/resourceadapters"
 	"github.com/juju/juju/storage"
@@ -332,6 +331,9 @@ type DeployCommand struct {
 	// deployed but just output the changes.
 	DryRun bool
 
+	// dryRunOut is used to write the changes for a dry run.
+	dryRunOut cmd.Output
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -519,6 +521,12 @@ for the bundle machine 3.
 Only top level machines can be mapped in this way, just as only top level
 machines can be defined in the machines section of the bundle.
 
+The changes that deploying a bundle would make can be shown, without
+making them, using --dry-run. The changes are shown as a summary by default;
+the --format option selects tabular, yaml or json output instead.
+
+  juju deploy some-bundle --dry-run --format yaml
+
 
 Examples:
     juju deploy mysql               (deploy to a new machine)
@@ -602,7 +610,12 @@ func (c *DeployCommand) Info() *cmd.Info {
 var (
 	// TODO(thumper): support dry-run for apps as well as bundles.
 	bundleOnlyFlags = []string{
-		"overlay", "dry-run", "map-machines",
+		"overlay", "dry-run", "map-machines", "format", "o", "output",
+	}
+
+	// dryRunOnlyFlags are the bundle flags that only apply to a dry run.
+	dryRunOnlyFlags = []string{
+		"format", "o", "output",
 	}
 )
 
@@ -635,6 +648,12 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	c.dryRunOut.AddFlags(f, "summary", map[string]cmd.Formatter{
+		"summary": formatBundleChangesSummary,
+		"tabular": formatBundleChangesTabular,
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+	})
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed to a machine running an unsupported series")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -768,7 +787,9 @@ func (c *DeployCommand) deployBundle(
 	}
 
 	for application, applicationSpec := range data.Applications {
-		if applicationSpec.Plan != "" {
+		// Metered charms are registered before deployment, which
+		// must not happen for a dry run.
+		if applicationSpec.Plan != "" && !c.DryRun {
 			for _, step := range c.Steps {
 				s := step
 				charmURL, err := charm.ParseURL(applicationSpec.Charm)
@@ -809,6 +830,7 @@ func (c *DeployCommand) deployBundle(
 		bundleStorage,
 		bundleDevices,
 		c.DryRun,
+		&c.dryRunOut,
 		c.UseExisting,
 		c.BundleMachines,
 	); err != nil {
@@ -1092,6 +1114,11 @@ func (c *DeployCommand) validateBundleFlags() error {
 	if flags := getFlags(c.flagSet, charmOnlyFlags()); len(flags) > 0 {
 		return errors.Errorf("flags provided but not supported when deploying a bundle: %s", strings.Join(flags, ", "))
 	}
+	if !c.DryRun {
+		if flags := getFlags(c.flagSet, dryRunOnlyFlags); len(flags) > 0 {
+			return errors.Errorf("flags provided but only supported with --dry-run: %s", strings.Join(flags, ", "))
+		}
+	}
 	return nil
 }
 
@@ -1111,29 +1138,17 @@ func (c *DeployCommand) validateCharmSeries(series string) error {
 }
 
 func (c *DeployCommand) validateCharmLXDProfile(ch charm.Charm) error {
-	if featureflag.Enabled(feature.LXDProfile) {
-		// Check if the charm conforms to the LXDProfiler, as it's optional and in
-		// theory the charm.Charm doesn't have to provider a LXDProfile method we
-		// can ignore it if it's missing and assume it is therefore valid.
-		if profiler, ok := ch.(charm.LXDProfiler); ok {
-			// Profile from the api could be nil, so check that it isn't
-			if profile := profiler.LXDProfile(); profile != nil {
-				err := profile.ValidateConfigDevices()
-				return errors.Trace(err)
-			}
-		}
+	// Check if the charm conforms to the LXDProfiler, as it's optional and in
+	// theory the charm.Charm doesn't have to provider a LXDProfile method we
+	// can ignore it if it's missing and assume it is therefore valid.
+	if profiler, ok := ch.(charm.LXDProfiler); ok {
+		return errors.Trace(lxdprofile.Validate(profiler.LXDProfile()))
 	}
 	return nil
 }
 
 func (c *DeployCommand) validateCharmInfoLXDProfile(info *apicharms.CharmInfo) error {
-	if featureflag.Enabled(feature.LXDProfile) {
-		if profile := info.LXDProfile; profile != nil {
-			err := profile.ValidateConfigDevices()
-			return errors.Trace(err)
-		}
-	}
-	return nil
+	return errors.Trace(lxdprofile.Validate(info.LXDProfile))
 }
 
 func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
@@ -0,0 +1,218 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/annotations"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/constraints"
+)
+
+const diffBundleDoc = `
+Bundle can be a local bundle file or directory. The bundle is compared
+with the applications, machines and relations in the current model,
+and any differences are shown. Overlays given with --overlay are
+applied to the bundle before it is compared, in the same way as for
+deploy.
+
+Each difference shows the value from the bundle and the value from
+the model. Applications and machines that only appear on one side are
+reported as missing from the other.
+
+Machines in the bundle are compared with the model machines with the
+same id. Use --map-machines to compare them with different machines,
+using the same syntax as for deploy.
+
+Annotations are not compared unless --annotations is given.
+
+Examples:
+    juju diff-bundle localbundle.yaml
+    juju diff-bundle ./mybundle --overlay production.yaml
+    juju diff-bundle localbundle.yaml --map-machines 3=4 --annotations
+
+See also:
+    deploy
+    export-bundle
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle with the
+// current model.
+func NewDiffBundleCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&diffBundleCommand{})
+}
+
+// DiffBundleAPI provides the methods the diff-bundle command uses to
+// read the current state of the model.
+type DiffBundleAPI interface {
+	Close() error
+	Status(patterns []string) (*params.FullStatus, error)
+	GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error)
+	GetConfig(appNames ...string) ([]map[string]interface{}, error)
+	GetConstraints(appNames ...string) ([]constraints.Value, error)
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api DiffBundleAPI
+
+	bundle         string
+	overlays       []string
+	machineMap     string
+	annotations    bool
+	bundleMachines map[string]string
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: "Compares a bundle with the current model.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.Var(cmd.NewAppendStringsValue(&c.overlays), "overlay", "Bundles to overlay on the primary bundle, applied in order")
+	f.StringVar(&c.machineMap, "map-machines", "", "Indicates how existing machines correspond to bundle machines")
+	f.BoolVar(&c.annotations, "annotations", false, "Include differences in annotations")
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle specified")
+	}
+	c.bundle = args[0]
+	useExisting, mapping, err := parseMachineMap(c.machineMap)
+	if err != nil {
+		return errors.Annotate(err, "error in --map-machines")
+	}
+	if useExisting {
+		return errors.New(`--map-machines "existing" not supported; bundle machines are compared with model machines of the same id by default`)
+	}
+	c.bundleMachines = mapping
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	data, err := c.readBundle(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	model, err := readDiffModel(client, c.annotations)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	diff := diffBundle(data, model, diffBundleConfig{
+		machineMap:  c.bundleMachines,
+		annotations: c.annotations,
+	})
+	return c.out.Write(ctx, diff)
+}
+
+// readBundle reads the local bundle named on the command line, then
+// processes its includes and any overlays before verifying it.
+func (c *diffBundleCommand) readBundle(ctx *cmd.Context) (*charm.BundleData, error) {
+	path := ctx.AbsPath(c.bundle)
+	bundleDir := filepath.Dir(path)
+	data, err := charmrepo.ReadBundleFile(path)
+	if err != nil {
+		// We may have been given a local bundle archive or exploded directory.
+		bundle, _, pathErr := charmrepo.NewBundleAtPath(path)
+		if pathErr != nil {
+			return nil, errors.Annotatef(pathErr, "cannot read bundle %q", c.bundle)
+		}
+		data = bundle.Data()
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			bundleDir = path
+		}
+	}
+	if err := processBundleIncludes(bundleDir, data); err != nil {
+		return nil, errors.Annotate(err, "unable to process includes")
+	}
+	overlays := make([]string, len(c.overlays))
+	for i, overlay := range c.overlays {
+		overlays[i] = ctx.AbsPath(overlay)
+	}
+	if err := processBundleOverlay(data, overlays...); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := verifyBundle(data, bundleDir); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return data, nil
+}
+
+func (c *diffBundleCommand) getAPI() (DiffBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &diffBundleAPIAdapter{
+		Connection:  root,
+		client:      root.Client(),
+		application: application.NewClient(root),
+		annotations: annotations.NewClient(root),
+	}, nil
+}
+
+// diffBundleAPIAdapter combines the API clients used by diff-bundle.
+type diffBundleAPIAdapter struct {
+	api.Connection
+	client      *api.Client
+	application *application.Client
+	annotations *annotations.Client
+}
+
+// Status is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) Status(patterns []string) (*params.FullStatus, error) {
+	return a.client.Status(patterns)
+}
+
+// GetAnnotations is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	return a.annotations.Get(tags)
+}
+
+// GetConfig is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) GetConfig(appNames ...string) ([]map[string]interface{}, error) {
+	return a.application.GetConfig(appNames...)
+}
+
+// GetConstraints is part of DiffBundleAPI.
+func (a *diffBundleAPIAdapter) GetConstraints(appNames ...string) ([]constraints.Value, error) {
+	return a.application.GetConstraints(appNames...)
+}
@@ -0,0 +1,492 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type DiffBundleSuite struct {
+	testing.IsolationSuite
+	api *fakeDiffBundleAPI
+	dir string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+const diffBundleYAML = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:mysql-57
+    num_units: 1
+  wordpress:
+    charm: cs:wordpress-47
+    num_units: 2
+    options:
+      blog-title: my blog
+machines:
+  "0": {}
+  "1": {}
+relations:
+- - wordpress:db
+  - mysql:server
+`
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.api = &fakeDiffBundleAPI{
+		status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "cs:xenial/mysql-57",
+					Series: "xenial",
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {Machine: "0"},
+					},
+				},
+				"wordpress": {
+					Charm:  "cs:xenial/wordpress-47",
+					Series: "xenial",
+					Units: map[string]params.UnitStatus{
+						"wordpress/0": {Machine: "1"},
+						"wordpress/1": {Machine: "1"},
+					},
+				},
+			},
+			Machines: map[string]params.MachineStatus{
+				"0": {Id: "0", Series: "xenial"},
+				"1": {Id: "1", Series: "xenial"},
+			},
+			Relations: []params.RelationStatus{{
+				Endpoints: []params.EndpointStatus{
+					{ApplicationName: "mysql", Name: "server"},
+					{ApplicationName: "wordpress", Name: "db"},
+				},
+			}, {
+				Endpoints: []params.EndpointStatus{
+					{ApplicationName: "mysql", Name: "cluster"},
+				},
+			}},
+		},
+		config: map[string]map[string]interface{}{
+			"wordpress": {
+				"blog-title": map[string]interface{}{"value": "my blog", "source": "user"},
+				"skin":       map[string]interface{}{"value": "light", "source": "default"},
+			},
+		},
+		annotations: map[string]map[string]string{},
+	}
+}
+
+func (s *DiffBundleSuite) writeFile(c *gc.C, name, content string) string {
+	path := filepath.Join(s.dir, name)
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *DiffBundleSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	command := NewDiffBundleCommandForTest(s.api, store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *DiffBundleSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no bundle specified",
+	}, {
+		args: []string{"bundle.yaml", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"bundle.yaml", "--map-machines", "existing"},
+		err:  `--map-machines "existing" not supported; .*`,
+	}, {
+		args: []string{"bundle.yaml", "--map-machines", "0/lxd/0=1"},
+		err:  `error in --map-machines: bundle-id "0/lxd/0" is not a top level machine id`,
+	}} {
+		c.Logf("%d: %v", i, test.args)
+		store := jujuclienttesting.MinimalStore()
+		err := cmdtesting.InitCommand(NewDiffBundleCommandForTest(s.api, store), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *DiffBundleSuite) TestNoDifferences(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", diffBundleYAML)
+	ctx, err := s.run(c, bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Status", []interface{}{[]string(nil)}},
+		{"GetConfig", []interface{}{[]string{"mysql", "wordpress"}}},
+		{"GetConstraints", []interface{}{[]string{"mysql", "wordpress"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *DiffBundleSuite) TestNoDifferencesJSON(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", diffBundleYAML)
+	ctx, err := s.run(c, bundle, "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestDifferences(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", `
+series: xenial
+applications:
+  haproxy:
+    charm: cs:haproxy
+    num_units: 1
+  mysql:
+    charm: cs:mysql-57
+    num_units: 1
+  wordpress:
+    charm: cs:wordpress-47
+    num_units: 2
+    options:
+      blog-title: my blog
+machines:
+  "0": {}
+  "1": {}
+relations:
+- - wordpress:db
+  - mysql:server
+- - haproxy:reverseproxy
+  - wordpress:website
+`[1:])
+
+	status := s.api.status
+	mysql := status.Applications["mysql"]
+	mysql.Charm = "cs:xenial/mysql-58"
+	status.Applications["mysql"] = mysql
+	wordpress := status.Applications["wordpress"]
+	wordpress.Units["wordpress/2"] = params.UnitStatus{Machine: "2"}
+	status.Applications["wordpress"] = wordpress
+	status.Applications["memcached"] = params.ApplicationStatus{
+		Charm:  "cs:xenial/memcached-10",
+		Series: "xenial",
+		Units: map[string]params.UnitStatus{
+			"memcached/0": {Machine: "2"},
+		},
+	}
+	status.Relations = append(status.Relations, params.RelationStatus{
+		Endpoints: []params.EndpointStatus{
+			{ApplicationName: "wordpress", Name: "cache"},
+			{ApplicationName: "memcached", Name: "cache"},
+		},
+	})
+	delete(status.Machines, "1")
+	status.Machines["2"] = params.MachineStatus{Id: "2", Series: "xenial"}
+	s.api.config["wordpress"]["blog-title"] = map[string]interface{}{"value": "other", "source": "user"}
+	s.api.config["wordpress"]["skin"] = map[string]interface{}{"value": "dark", "source": "user"}
+
+	ctx, err := s.run(c, bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  memcached:
+    missing: bundle
+  mysql:
+    charm:
+      bundle: cs:mysql-57
+      model: cs:xenial/mysql-58
+  wordpress:
+    num_units:
+      bundle: 2
+      model: 3
+    options:
+      blog-title:
+        bundle: my blog
+        model: other
+      skin:
+        bundle: null
+        model: dark
+machines:
+  "1":
+    missing: model
+  "2":
+    missing: bundle
+relations:
+  bundle-additions:
+  - - haproxy:reverseproxy
+    - wordpress:website
+  model-additions:
+  - - memcached:cache
+    - wordpress:cache
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestConstraintsAndExpose(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", `
+applications:
+  mysql:
+    charm: cs:mysql
+    series: bionic
+    num_units: 1
+    expose: true
+    constraints: mem=4G
+  wordpress:
+    charm: cs:wordpress
+    num_units: 2
+    constraints: cores=2 mem=2G
+    options:
+      blog-title: my blog
+machines:
+  "0":
+    constraints: arch=amd64
+  "1": {}
+relations:
+- - wordpress
+  - mysql
+`[1:])
+	s.api.constraints = map[string]constraints.Value{
+		"wordpress": constraints.MustParse("mem=2048M cores=2"),
+	}
+	ctx, err := s.run(c, bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    series:
+      bundle: bionic
+      model: xenial
+    expose:
+      bundle: true
+      model: false
+    constraints:
+      bundle: mem=4G
+      model: ""
+machines:
+  "0":
+    constraints:
+      bundle: arch=amd64
+      model: ""
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestOverlay(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", diffBundleYAML)
+	overlay := s.writeFile(c, "overlay.yaml", `
+applications:
+  wordpress:
+    options:
+      blog-title: other
+`[1:])
+	s.api.config["wordpress"]["blog-title"] = map[string]interface{}{"value": "other", "source": "user"}
+
+	ctx, err := s.run(c, bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  wordpress:
+    options:
+      blog-title:
+        bundle: my blog
+        model: other
+`[1:])
+
+	ctx, err = s.run(c, bundle, "--overlay", overlay)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestMapMachines(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", diffBundleYAML)
+	status := s.api.status
+	status.Machines["2"] = status.Machines["1"]
+	delete(status.Machines, "1")
+
+	ctx, err := s.run(c, bundle, "--map-machines", "1=2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+}
+
+func (s *DiffBundleSuite) TestAnnotations(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", `
+applications:
+  mysql:
+    charm: cs:mysql-57
+    num_units: 1
+    annotations:
+      gui-x: "10"
+  wordpress:
+    charm: cs:wordpress-47
+    num_units: 2
+    options:
+      blog-title: my blog
+machines:
+  "0": {}
+  "1":
+    annotations:
+      rack: a
+relations:
+- - wordpress:db
+  - mysql:server
+`[1:])
+	s.api.annotations = map[string]map[string]string{
+		"application-mysql": {"gui-x": "20"},
+		"machine-1":         {"rack": "a"},
+	}
+
+	// Annotations are ignored by default.
+	ctx, err := s.run(c, bundle)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "{}\n")
+	s.api.CheckCallNames(c, "Status", "GetConfig", "GetConstraints", "Close")
+
+	ctx, err = s.run(c, bundle, "--annotations")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+applications:
+  mysql:
+    annotations:
+      gui-x:
+        bundle: "10"
+        model: "20"
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestInvalidBundle(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", `
+applications:
+  mysql:
+    charm: cs:mysql
+    constraints: bad=wolf
+`[1:])
+	_, err := s.run(c, bundle)
+	c.Assert(err, gc.ErrorMatches, `(?s)the provided bundle has the following errors:.*bad=wolf.*`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *DiffBundleSuite) TestStatusError(c *gc.C) {
+	bundle := s.writeFile(c, "bundle.yaml", diffBundleYAML)
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, bundle)
+	c.Assert(err, gc.ErrorMatches, "getting model status: boom")
+}
+
+type diffHelpersSuite struct{}
+
+var _ = gc.Suite(&diffHelpersSuite{})
+
+func (*diffHelpersSuite) TestDiffCharms(c *gc.C) {
+	for i, test := range []struct {
+		bundle string
+		model  string
+		same   bool
+	}{
+		{"cs:mysql", "cs:xenial/mysql-57", true},
+		{"mysql", "cs:xenial/mysql-57", true},
+		{"cs:mysql-57", "cs:xenial/mysql-57", true},
+		{"cs:xenial/mysql-57", "cs:xenial/mysql-57", true},
+		{"cs:bionic/mysql", "cs:xenial/mysql-57", false},
+		{"cs:mysql-56", "cs:xenial/mysql-57", false},
+		{"cs:~bob/mysql", "cs:xenial/mysql-57", false},
+		{"cs:mariadb", "cs:xenial/mysql-57", false},
+		{"./charms/mysql", "local:xenial/mysql-0", true},
+		{"./charms/mysql", "cs:xenial/mysql-57", false},
+	} {
+		c.Logf("%d: %s %s", i, test.bundle, test.model)
+		diff := diffCharms(test.bundle, test.model)
+		if test.same {
+			c.Check(diff, gc.IsNil)
+		} else {
+			c.Check(diff, jc.DeepEquals, &stringDiff{Bundle: test.bundle, Model: test.model})
+		}
+	}
+}
+
+func (*diffHelpersSuite) TestDiffBindings(c *gc.C) {
+	modelBindings := map[string]string{
+		"":        "public",
+		"db":      "internal",
+		"website": "public",
+	}
+	c.Check(diffBindings(map[string]string{
+		"":   "public",
+		"db": "internal",
+	}, modelBindings), gc.IsNil)
+	c.Check(diffBindings(map[string]string{
+		"db": "internal",
+	}, modelBindings), jc.DeepEquals, map[string]*stringDiff{
+		"":        {Bundle: "", Model: "public"},
+		"website": {Bundle: "", Model: "public"},
+	})
+}
+
+func (*diffHelpersSuite) TestDiffOptionsNumbers(c *gc.C) {
+	c.Check(diffOptions(
+		map[string]interface{}{"port": 8080, "ratio": 0.5},
+		map[string]interface{}{"port": float64(8080), "ratio": 0.5},
+	), gc.IsNil)
+}
+
+type fakeDiffBundleAPI struct {
+	testing.Stub
+	status      *params.FullStatus
+	config      map[string]map[string]interface{}
+	constraints map[string]constraints.Value
+	annotations map[string]map[string]string
+}
+
+func (f *fakeDiffBundleAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeDiffBundleAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.AddCall("Status", patterns)
+	return f.status, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetAnnotations(tags []string) ([]params.AnnotationsGetResult, error) {
+	f.AddCall("GetAnnotations", tags)
+	results := make([]params.AnnotationsGetResult, len(tags))
+	for i, tag := range tags {
+		results[i] = params.AnnotationsGetResult{
+			EntityTag:   tag,
+			Annotations: f.annotations[tag],
+		}
+	}
+	return results, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetConfig(appNames ...string) ([]map[string]interface{}, error) {
+	f.AddCall("GetConfig", appNames)
+	results := make([]map[string]interface{}, len(appNames))
+	for i, name := range appNames {
+		results[i] = f.config[name]
+	}
+	return results, f.NextErr()
+}
+
+func (f *fakeDiffBundleAPI) GetConstraints(appNames ...string) ([]constraints.Value, error) {
+	f.AddCall("GetConstraints", appNames)
+	results := make([]constraints.Value, len(appNames))
+	for i, name := range appNames {
+		results[i] = f.constraints[name]
+	}
+	return results, f.NextErr()
+}
@@ -122,6 +122,24 @@ func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclie
 	return modelcmd.Wrap(cmd)
 }
 
+// NewResumeCharmUpgradeCommandForTest returns a ResumeCharmUpgradeCommand with the api provided as specified.
+func NewResumeCharmUpgradeCommandForTest(api RollingUpgradeAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &resumeCharmUpgradeCommand{newAPIFunc: func() (RollingUpgradeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAbortCharmUpgradeCommandForTest returns an AbortCharmUpgradeCommand with the api provided as specified.
+func NewAbortCharmUpgradeCommandForTest(api RollingUpgradeAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &abortCharmUpgradeCommand{newAPIFunc: func() (RollingUpgradeAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewRemoveSaasCommandForTest returns a RemoveSaasCommand with the api provided as specified.
 func NewRemoveSaasCommandForTest(api RemoveSaasAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &removeSaasCommand{newAPIFunc: func() (RemoveSaasAPI, error) {
@@ -139,3 +157,40 @@ func NewScaleCommandForTest(api scaleApplicationAPI, store jujuclient.ClientStor
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewDiffBundleCommandForTest returns a command to compare a bundle with
+// the model, using the api provided.
+func NewDiffBundleCommandForTest(api DiffBundleAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &diffBundleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAutoscaleCommandForTest returns an AutoscaleCommand with the api provided as specified.
+func NewAutoscaleCommandForTest(api autoscaleApplicationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &autoscaleApplicationCommand{newAPIFunc: func() (autoscaleApplicationAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewPinLeadershipCommandForTest returns a pin-leadership or
+// unpin-leadership command with the api provided as specified.
+func NewPinLeadershipCommandForTest(api leadershipPinningAPI, unpin bool, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &pinLeadershipCommand{unpin: unpin, newAPIFunc: func() (leadershipPinningAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewTransferLeadershipCommandForTest returns a transfer-leadership
+// command with the api provided as specified.
+func NewTransferLeadershipCommandForTest(api transferLeadershipAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &transferLeadershipCommand{newAPIFunc: func() (transferLeadershipAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -0,0 +1,171 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/leadershippinning"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewPinLeadershipCommand returns a command which pins the leadership
+// of applications to their current leaders.
+func NewPinLeadershipCommand() modelcmd.ModelCommand {
+	cmd := &pinLeadershipCommand{}
+	cmd.newAPIFunc = newLeadershipPinningAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewUnpinLeadershipCommand returns a command which removes the user's
+// pins on the leadership of applications.
+func NewUnpinLeadershipCommand() modelcmd.ModelCommand {
+	cmd := &pinLeadershipCommand{unpin: true}
+	cmd.newAPIFunc = newLeadershipPinningAPIFunc(&cmd.ModelCommandBase)
+	return modelcmd.Wrap(cmd)
+}
+
+func newLeadershipPinningAPIFunc(base *modelcmd.ModelCommandBase) func() (leadershipPinningAPI, error) {
+	return func() (leadershipPinningAPI, error) {
+		root, err := base.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return leadershippinning.NewClient(root), nil
+	}
+}
+
+type leadershipPinningAPI interface {
+	Close() error
+	PinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error)
+	UnpinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error)
+	ForceUnpinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error)
+}
+
+// pinLeadershipCommand is responsible for pinning and unpinning the
+// leadership of applications.
+type pinLeadershipCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc       func() (leadershipPinningAPI, error)
+	unpin            bool
+	force            bool
+	applicationNames []string
+}
+
+const pinLeadershipDoc = `
+Hold the leadership of the named applications with their current leaders.
+While an application's leadership is pinned, it does not move to another
+unit even if the leader's agent stops running, for example while the
+leader's machine is down for maintenance. The leadership of applications
+on a machine whose series is being upgraded is pinned automatically.
+
+Leadership stays pinned until unpin-leadership is run by the same user,
+or by a model admin with --force.
+Pinned applications are marked in the output of status.
+
+Examples:
+
+    juju pin-leadership mysql wordpress
+
+See also:
+    unpin-leadership
+    status
+`
+
+const unpinLeadershipDoc = `
+Remove your pins on the leadership of the named applications. Once no
+pins remain, leadership moves as usual if the leader stops claiming it.
+
+With --force, all pins on the leadership of the applications are removed,
+including those made by other users and by machines upgrading their
+series. This requires admin access to the model.
+
+Examples:
+
+    juju unpin-leadership mysql wordpress
+    juju unpin-leadership --force mysql
+
+See also:
+    pin-leadership
+    status
+`
+
+// Info implements cmd.Command.
+func (c *pinLeadershipCommand) Info() *cmd.Info {
+	if c.unpin {
+		return &cmd.Info{
+			Name:    "unpin-leadership",
+			Args:    "<application> [...]",
+			Purpose: "Remove pins on the leadership of applications.",
+			Doc:     unpinLeadershipDoc,
+		}
+	}
+	return &cmd.Info{
+		Name:    "pin-leadership",
+		Args:    "<application> [...]",
+		Purpose: "Hold the leadership of applications with their current leaders.",
+		Doc:     pinLeadershipDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *pinLeadershipCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	if c.unpin {
+		f.BoolVar(&c.force, "force", false, "Remove all pins on the leadership, not just your own")
+	}
+}
+
+func (c *pinLeadershipCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	for _, arg := range args {
+		if !names.IsValidApplication(arg) {
+			return errors.Errorf("invalid application name %q", arg)
+		}
+	}
+	c.applicationNames = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *pinLeadershipCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	op, action, done := client.PinApplicationLeaders, "pin", "pinned"
+	if c.unpin {
+		op, action, done = client.UnpinApplicationLeaders, "unpin", "unpinned"
+		if c.force {
+			op = client.ForceUnpinApplicationLeaders
+		}
+	}
+	results, err := op(c.applicationNames)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	failed := false
+	for _, result := range results {
+		if result.Error != nil {
+			ctx.Infof("cannot %s leadership of %v: %v", action, result.ApplicationName, result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("leadership of %v %s", result.ApplicationName, done)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
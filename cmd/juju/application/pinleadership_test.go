@@ -0,0 +1,111 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type PinLeadershipSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockLeadershipPinningAPI
+}
+
+var _ = gc.Suite(&PinLeadershipSuite{})
+
+type mockLeadershipPinningAPI struct {
+	*testing.Stub
+	results []params.PinApplicationResult
+}
+
+func (s mockLeadershipPinningAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockLeadershipPinningAPI) PinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error) {
+	s.MethodCall(s, "PinApplicationLeaders", appNames)
+	return s.results, s.NextErr()
+}
+
+func (s mockLeadershipPinningAPI) UnpinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error) {
+	s.MethodCall(s, "UnpinApplicationLeaders", appNames)
+	return s.results, s.NextErr()
+}
+
+func (s mockLeadershipPinningAPI) ForceUnpinApplicationLeaders(appNames []string) ([]params.PinApplicationResult, error) {
+	s.MethodCall(s, "ForceUnpinApplicationLeaders", appNames)
+	return s.results, s.NextErr()
+}
+
+func (s *PinLeadershipSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockLeadershipPinningAPI{Stub: &testing.Stub{}}
+}
+
+func (s *PinLeadershipSuite) run(c *gc.C, unpin bool, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewPinLeadershipCommandForTest(s.mockAPI, unpin, store), args...)
+}
+
+func (s *PinLeadershipSuite) TestPinLeadership(c *gc.C) {
+	s.mockAPI.results = []params.PinApplicationResult{
+		{ApplicationName: "mysql"},
+		{ApplicationName: "wordpress"},
+	}
+	ctx, err := s.run(c, false, "mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "leadership of mysql pinned\nleadership of wordpress pinned\n")
+	s.mockAPI.CheckCall(c, 0, "PinApplicationLeaders", []string{"mysql", "wordpress"})
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *PinLeadershipSuite) TestPinLeadershipFailure(c *gc.C) {
+	s.mockAPI.results = []params.PinApplicationResult{
+		{ApplicationName: "mysql"},
+		{ApplicationName: "wordpress", Error: &params.Error{Message: `application "wordpress" not found`}},
+	}
+	ctx, err := s.run(c, false, "mysql", "wordpress")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "leadership of mysql pinned\n"+
+		"cannot pin leadership of wordpress: application \"wordpress\" not found\n")
+}
+
+func (s *PinLeadershipSuite) TestUnpinLeadership(c *gc.C) {
+	s.mockAPI.results = []params.PinApplicationResult{{ApplicationName: "mysql"}}
+	ctx, err := s.run(c, true, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "leadership of mysql unpinned\n")
+	s.mockAPI.CheckCall(c, 0, "UnpinApplicationLeaders", []string{"mysql"})
+}
+
+func (s *PinLeadershipSuite) TestForceUnpinLeadership(c *gc.C) {
+	s.mockAPI.results = []params.PinApplicationResult{{ApplicationName: "mysql"}}
+	ctx, err := s.run(c, true, "--force", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "leadership of mysql unpinned\n")
+	s.mockAPI.CheckCall(c, 0, "ForceUnpinApplicationLeaders", []string{"mysql"})
+}
+
+func (s *PinLeadershipSuite) TestPinLeadershipNoForce(c *gc.C) {
+	_, err := s.run(c, false, "--force", "mysql")
+	c.Assert(err, gc.ErrorMatches, "option provided but not defined: --force")
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *PinLeadershipSuite) TestInitErrors(c *gc.C) {
+	_, err := s.run(c, false)
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+	_, err = s.run(c, true, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `invalid application name "mysql/0"`)
+	s.mockAPI.CheckNoCalls(c)
+}
@@ -0,0 +1,104 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var resumeCharmUpgradeHelpSummary = `
+Resumes a paused rolling charm upgrade.`[1:]
+
+var resumeCharmUpgradeHelpDetails = `
+A rolling charm upgrade, started with "juju upgrade-charm --batch-size", is
+paused when a batch of units goes into an error state or does not become
+active and idle within the batch timeout. Once the problem has been fixed,
+this command resumes the upgrade. The current batch of units is given the
+full batch timeout to become healthy before the next batch is released.
+
+Examples:
+    juju resume-charm-upgrade wordpress
+
+See also: 
+    abort-charm-upgrade
+    status
+    upgrade-charm`[1:]
+
+// RollingUpgradeAPI defines the API methods that the rolling upgrade
+// commands use.
+type RollingUpgradeAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ResumeRollingUpgrade(application string) error
+	AbortRollingUpgrade(application string) error
+}
+
+// NewResumeCharmUpgradeCommand returns a command to resume a paused
+// rolling charm upgrade.
+func NewResumeCharmUpgradeCommand() modelcmd.ModelCommand {
+	cmd := &resumeCharmUpgradeCommand{}
+	cmd.newAPIFunc = func() (RollingUpgradeAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type resumeCharmUpgradeCommand struct {
+	modelcmd.ModelCommandBase
+	applicationName string
+	newAPIFunc      func() (RollingUpgradeAPI, error)
+}
+
+func (c *resumeCharmUpgradeCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resume-charm-upgrade",
+		Args:    "<application name>",
+		Purpose: resumeCharmUpgradeHelpSummary,
+		Doc:     resumeCharmUpgradeHelpDetails,
+	}
+}
+
+func (c *resumeCharmUpgradeCommand) Init(args []string) error {
+	name, err := rollingUpgradeApplication(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.applicationName = name
+	return nil
+}
+
+func (c *resumeCharmUpgradeCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 9 {
+		return errors.New("resuming a charm upgrade is not supported by this version of Juju")
+	}
+	err = client.ResumeRollingUpgrade(c.applicationName)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+// rollingUpgradeApplication returns the application name given as
+// the only argument to a rolling upgrade command.
+func rollingUpgradeApplication(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return "", errors.Errorf("invalid application name %q", args[0])
+	}
+	return args[0], cmd.CheckEmpty(args[1:])
+}
@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RollingUpgradeCommandsSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockRollingUpgradeAPI
+}
+
+var _ = gc.Suite(&RollingUpgradeCommandsSuite{})
+
+func (s *RollingUpgradeCommandsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockRollingUpgradeAPI{Stub: &testing.Stub{}, version: 9}
+}
+
+func (s *RollingUpgradeCommandsSuite) run(
+	c *gc.C,
+	newCommand func(RollingUpgradeAPI, jujuclient.ClientStore) modelcmd.ModelCommand,
+	args ...string,
+) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, newCommand(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *RollingUpgradeCommandsSuite) TestInvalidArguments(c *gc.C) {
+	for _, newCommand := range []func(RollingUpgradeAPI, jujuclient.ClientStore) modelcmd.ModelCommand{
+		NewResumeCharmUpgradeCommandForTest,
+		NewAbortCharmUpgradeCommandForTest,
+	} {
+		err := s.run(c, newCommand)
+		c.Check(err, gc.ErrorMatches, "no application name specified")
+		err = s.run(c, newCommand, "Bad_Name")
+		c.Check(err, gc.ErrorMatches, `invalid application name "Bad_Name"`)
+		err = s.run(c, newCommand, "foo", "bar")
+		c.Check(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *RollingUpgradeCommandsSuite) TestResume(c *gc.C) {
+	err := s.run(c, NewResumeCharmUpgradeCommandForTest, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "ResumeRollingUpgrade", "Close")
+	s.mockAPI.CheckCall(c, 0, "ResumeRollingUpgrade", "foo")
+}
+
+func (s *RollingUpgradeCommandsSuite) TestResumeOldServer(c *gc.C) {
+	s.mockAPI.version = 8
+	err := s.run(c, NewResumeCharmUpgradeCommandForTest, "foo")
+	c.Assert(err, gc.ErrorMatches, "resuming a charm upgrade is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *RollingUpgradeCommandsSuite) TestAbort(c *gc.C) {
+	err := s.run(c, NewAbortCharmUpgradeCommandForTest, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "AbortRollingUpgrade", "Close")
+	s.mockAPI.CheckCall(c, 0, "AbortRollingUpgrade", "foo")
+}
+
+func (s *RollingUpgradeCommandsSuite) TestAbortFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`rolling upgrade of "foo" not found`))
+	err := s.run(c, NewAbortCharmUpgradeCommandForTest, "foo")
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "foo" not found`)
+}
+
+func (s *RollingUpgradeCommandsSuite) TestAbortBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestAbortBlocked"))
+	err := s.run(c, NewAbortCharmUpgradeCommandForTest, "foo")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestAbortBlocked.*")
+}
+
+type mockRollingUpgradeAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s *mockRollingUpgradeAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockRollingUpgradeAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockRollingUpgradeAPI) ResumeRollingUpgrade(application string) error {
+	s.MethodCall(s, "ResumeRollingUpgrade", application)
+	return s.NextErr()
+}
+
+func (s *mockRollingUpgradeAPI) AbortRollingUpgrade(application string) error {
+	s.MethodCall(s, "AbortRollingUpgrade", application)
+	return s.NextErr()
+}
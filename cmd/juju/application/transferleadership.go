@@ -0,0 +1,107 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewTransferLeadershipCommand returns a command which hands the
+// leadership of an application to one of its units.
+func NewTransferLeadershipCommand() modelcmd.ModelCommand {
+	cmd := &transferLeadershipCommand{}
+	cmd.newAPIFunc = func() (transferLeadershipAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type transferLeadershipAPI interface {
+	Close() error
+	BestAPIVersion() int
+	TransferLeadership(application, unitName string) error
+}
+
+// transferLeadershipCommand is responsible for handing the leadership
+// of an application to a chosen unit.
+type transferLeadershipCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc      func() (transferLeadershipAPI, error)
+	applicationName string
+	unitName        string
+}
+
+const transferLeadershipDoc = `
+Hand the leadership of an application to the given unit, which must be an
+alive unit of the application. The current leader is deposed as soon as
+it notices that it no longer holds the leadership, and only then does the
+new leader run its leader-elected hook; this can take up to a minute.
+
+Examples:
+
+    juju transfer-leadership mysql mysql/2
+
+See also:
+    pin-leadership
+    status
+`
+
+// Info implements cmd.Command.
+func (c *transferLeadershipCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "transfer-leadership",
+		Args:    "<application> <unit>",
+		Purpose: "Hand the leadership of an application to one of its units.",
+		Doc:     transferLeadershipDoc,
+	}
+}
+
+func (c *transferLeadershipCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.Errorf("no application specified")
+	case 1:
+		return errors.Errorf("no unit specified")
+	}
+	c.applicationName, c.unitName = args[0], args[1]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if !names.IsValidUnit(c.unitName) {
+		return errors.Errorf("invalid unit name %q", c.unitName)
+	}
+	if appName, _ := names.UnitApplication(c.unitName); appName != c.applicationName {
+		return errors.Errorf("unit %q is not a unit of %q", c.unitName, c.applicationName)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *transferLeadershipCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if client.BestAPIVersion() < 11 {
+		return errors.New("transferring leadership is not supported by this controller")
+	}
+	if err := client.TransferLeadership(c.applicationName, c.unitName); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("leadership of %v transferred to %v", c.applicationName, c.unitName)
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type TransferLeadershipSuite struct {
+	testing.IsolationSuite
+
+	mockAPI *mockTransferLeadershipAPI
+}
+
+var _ = gc.Suite(&TransferLeadershipSuite{})
+
+type mockTransferLeadershipAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockTransferLeadershipAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockTransferLeadershipAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s mockTransferLeadershipAPI) TransferLeadership(application, unitName string) error {
+	s.MethodCall(s, "TransferLeadership", application, unitName)
+	return s.NextErr()
+}
+
+func (s *TransferLeadershipSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockTransferLeadershipAPI{Stub: &testing.Stub{}, version: 11}
+}
+
+func (s *TransferLeadershipSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, NewTransferLeadershipCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadership(c *gc.C) {
+	ctx, err := s.run(c, "mysql", "mysql/2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "leadership of mysql transferred to mysql/2\n")
+	s.mockAPI.CheckCall(c, 0, "TransferLeadership", "mysql", "mysql/2")
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadershipFailure(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New(`unit "mysql/2" is not alive`))
+	_, err := s.run(c, "mysql", "mysql/2")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/2" is not alive`)
+}
+
+func (s *TransferLeadershipSuite) TestTransferLeadershipNotSupported(c *gc.C) {
+	s.mockAPI.version = 10
+	_, err := s.run(c, "mysql", "mysql/2")
+	c.Assert(err, gc.ErrorMatches, "transferring leadership is not supported by this controller")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *TransferLeadershipSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no application specified",
+	}, {
+		args: []string{"mysql"},
+		err:  "no unit specified",
+	}, {
+		args: []string{"mysql/0", "mysql/1"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "mysql"},
+		err:  `invalid unit name "mysql"`,
+	}, {
+		args: []string{"mysql", "wordpress/0"},
+		err:  `unit "wordpress/0" is not a unit of "mysql"`,
+	}, {
+		args: []string{"mysql", "mysql/0", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.mockAPI.CheckNoCalls(c)
+}
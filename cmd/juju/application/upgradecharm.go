@@ -6,6 +6,7 @@ package application
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -125,6 +126,18 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// BatchSize, if non-zero, is the number of units released to
+	// upgrade at a time.
+	BatchSize int
+
+	// BatchTimeout is how long each batch of units has to return
+	// to active/idle in a rolling upgrade.
+	BatchTimeout time.Duration
+
+	// OnError is what a rolling upgrade does when a batch of units
+	// fails: either "pause" or "abort".
+	OnError string
 }
 
 const upgradeCharmDoc = `
@@ -198,6 +211,24 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+By default every unit of the application is upgraded at once. The --batch-size
+flag instead releases the upgrade to that many units at a time. Each batch must
+return to an active workload status with an idle agent within --batch-timeout
+before the next batch is released. If a unit in the batch goes into an error
+state, or the batch times out, the upgrade is paused; use --on-error=abort to
+abort it instead. Units that have not been released keep running their current
+charm, and the progress of the upgrade is shown by "juju status".
+
+  juju upgrade-charm foo --batch-size 2 --batch-timeout 15m
+
+A paused upgrade is continued with "juju resume-charm-upgrade", and an upgrade
+may be stopped at any time with "juju abort-charm-upgrade". The model cannot
+be migrated while an application has a rolling upgrade, even an aborted one;
+upgrading the charm again without --batch-size releases it to all units and
+clears the rolling upgrade.
+
+--force-units and --batch-size are mutually exclusive.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -220,6 +251,9 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.IntVar(&c.BatchSize, "batch-size", 0, "Upgrade this many units at a time")
+	f.DurationVar(&c.BatchTimeout, "batch-timeout", 10*time.Minute, "How long each batch of units has to become active and idle")
+	f.StringVar(&c.OnError, "on-error", "pause", `Whether to "pause" or "abort" the upgrade when a batch fails`)
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -243,6 +277,18 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.BatchSize < 0 {
+		return errors.Errorf("--batch-size must be a positive number")
+	}
+	if c.BatchSize > 0 && c.ForceUnits {
+		return errors.Errorf("--force-units and --batch-size are mutually exclusive")
+	}
+	if c.BatchTimeout <= 0 {
+		return errors.Errorf("--batch-timeout must be a positive duration")
+	}
+	if c.OnError != "pause" && c.OnError != "abort" {
+		return errors.Errorf(`--on-error must be "pause" or "abort", got %q`, c.OnError)
+	}
 	return nil
 }
 
@@ -270,6 +316,13 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 			return errors.New(action + " at upgrade-charm time is not supported by " + suffix)
 		}
 	}
+	if c.BatchSize > 0 && apiRoot.BestFacadeVersion("Application") < 9 {
+		suffix := "this server"
+		if version, ok := apiRoot.ServerVersion(); ok {
+			suffix = fmt.Sprintf("server version %s", version)
+		}
+		return errors.New("rolling upgrades are not supported by " + suffix)
+	}
 
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
@@ -355,6 +408,13 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 	}
+	if c.BatchSize > 0 {
+		cfg.RollingUpgrade = &params.RollingUpgradeParams{
+			BatchSize:    c.BatchSize,
+			BatchTimeout: c.BatchTimeout,
+			OnError:      c.OnError,
+		}
+	}
 	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -246,6 +247,53 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestRollingUpgrade(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 9
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2", "--on-error", "abort")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+	s.charmUpgradeClient.CheckCall(c, 2, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		RollingUpgrade: &params.RollingUpgradeParams{
+			BatchSize:    2,
+			BatchTimeout: 10 * time.Minute,
+			OnError:      "abort",
+		},
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeMinFacadeVersion(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--batch-size", "2")
+	c.Assert(err, gc.ErrorMatches,
+		"rolling upgrades are not supported by server version 1.2.3")
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeInvalidArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--batch-size", "-1"},
+		err:  "--batch-size must be a positive number",
+	}, {
+		args: []string{"--batch-size", "1", "--force-units"},
+		err:  "--force-units and --batch-size are mutually exclusive",
+	}, {
+		args: []string{"--batch-size", "1", "--batch-timeout", "0s"},
+		err:  "--batch-timeout must be a positive duration",
+	}, {
+		args: []string{"--batch-size", "1", "--on-error", "ignore"},
+		err:  `--on-error must be "pause" or "abort", got "ignore"`,
+	}} {
+		_, err := s.runUpgradeCharm(c, append([]string{"foo"}, test.args...)...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -33,6 +33,9 @@ type APIClient interface {
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
 	List() (*params.BackupsListResult, error)
+	// ScheduleInfo gets the controller's backup schedule and
+	// retention policy.
+	ScheduleInfo() (*params.BackupsScheduleInfo, error)
 	// Download pulls the backup archive file.
 	Download(id string) (io.ReadCloser, error)
 	// Upload pushes a backup archive to storage.
@@ -108,6 +111,45 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
 	fmt.Fprintf(ctx.Stdout, "created on host: %q\n", result.Hostname)
 	fmt.Fprintf(ctx.Stdout, "juju version:    %v\n", result.Version)
+	fmt.Fprintf(ctx.Stdout, "scheduled:       %t\n", result.Scheduled)
+	if result.Scheduled {
+		fmt.Fprintf(ctx.Stdout, "retained:        %s\n", retentionString(result.Retention))
+	}
+	fmt.Fprintf(ctx.Stdout, "encrypted:       %t\n", result.Encrypted)
+}
+
+// retentionString describes why the retention policy keeps a
+// scheduled backup.
+func retentionString(retention string) string {
+	if retention == "" {
+		return "no, will be removed by the retention policy"
+	}
+	return retention
+}
+
+// dumpScheduleInfo writes the formatted backup schedule to stdout.
+func (c *CommandBase) dumpScheduleInfo(ctx *cmd.Context, info *params.BackupsScheduleInfo) {
+	schedule := "not scheduled"
+	if info.Schedule != "" {
+		schedule = fmt.Sprintf("%q", info.Schedule)
+	}
+	fmt.Fprintf(ctx.Stdout, "backup schedule: %s\n", schedule)
+	fmt.Fprintf(ctx.Stdout, "retention:       %d daily, %d weekly\n", info.RetainDaily, info.RetainWeekly)
+	fmt.Fprintf(ctx.Stdout, "encryption:      %s\n", enabledString(info.Encrypted))
+	if info.NextBackup != nil {
+		fmt.Fprintf(ctx.Stdout, "next backup:     %v\n", *info.NextBackup)
+	}
+	if info.LastBackup != nil {
+		fmt.Fprintf(ctx.Stdout, "last backup:     %q at %v\n", info.LastBackupID, *info.LastBackup)
+	}
+	fmt.Fprintf(ctx.Stdout, "scheduled:       %d stored\n", info.ScheduledBackups)
+}
+
+func enabledString(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
 }
 
 // ArchiveReader can read a backup archive.
@@ -34,6 +34,9 @@ Use --no-download to avoid getting a local copy of the backup downloaded
 at the end of the backup process.
 
 Use --keep-copy option to store a copy of backup remotely on the controller.
+The controller keeps the archive in its database, or in the directory or
+S3-compatible object store given by the "backup-storage" controller
+configuration.
 
 Use --verbose to see extra information about backup.
 
@@ -10,11 +10,17 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
 const listDoc = `
 backups provides the metadata associated with all backups.
+
+If the controller takes backups on a schedule (see the backup-schedule
+controller configuration), the schedule and the retention policy for the
+scheduled backups are shown too. With --verbose, each scheduled backup
+shows whether the retention policy keeps it.
 `
 
 // NewListCommand returns a command used to list metadata for backups.
@@ -69,12 +75,27 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	verbose := c.Log != nil && c.Log.Verbose
+	info, err := client.ScheduleInfo()
+	if err != nil && !errors.IsNotSupported(err) {
+		return errors.Trace(err)
+	}
+	if info != nil {
+		if verbose {
+			c.dumpScheduleInfo(ctx, info)
+			if len(result.List) > 0 {
+				fmt.Fprintln(ctx.Stdout)
+			}
+		} else {
+			ctx.Infof("%s", scheduleSummary(info))
+		}
+	}
+
 	if len(result.List) == 0 {
 		ctx.Infof("No backups to display.")
 		return nil
 	}
 
-	verbose := c.Log != nil && c.Log.Verbose
 	if verbose {
 		c.dumpMetadata(ctx, &result.List[0])
 	} else {
@@ -90,3 +111,17 @@ func (c *listCommand) Run(ctx *cmd.Context) error {
 	}
 	return nil
 }
+
+// scheduleSummary returns a one line description of the backup
+// schedule.
+func scheduleSummary(info *params.BackupsScheduleInfo) string {
+	if info.Schedule == "" {
+		return "Backups are not scheduled."
+	}
+	summary := fmt.Sprintf("Backups are scheduled %q, keeping %d daily and %d weekly",
+		info.Schedule, info.RetainDaily, info.RetainWeekly)
+	if info.NextBackup != nil {
+		summary += fmt.Sprintf("; next backup at %v", *info.NextBackup)
+	}
+	return summary + "."
+}
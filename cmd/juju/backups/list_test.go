@@ -4,12 +4,16 @@
 package backups_test
 
 import (
+	"strings"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 )
@@ -48,3 +52,54 @@ func (s *listSuite) TestError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.subcommand)
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *listSuite) setSchedule() {
+	next := time.Date(2018, 10, 18, 2, 0, 0, 0, time.UTC)
+	last := time.Date(2018, 10, 17, 2, 0, 0, 0, time.UTC)
+	s.metaresult.Scheduled = true
+	s.metaresult.Retention = "daily"
+	client := s.setSuccess()
+	client.scheduleInfo = &params.BackupsScheduleInfo{
+		Schedule:         "0 2 * * *",
+		RetainDaily:      7,
+		RetainWeekly:     4,
+		Encrypted:        true,
+		NextBackup:       &next,
+		LastBackupID:     "spam",
+		LastBackup:       &last,
+		ScheduledBackups: 1,
+	}
+}
+
+func (s *listSuite) TestScheduledVerbose(c *gc.C) {
+	s.setSchedule()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "--verbose")
+	c.Assert(err, jc.ErrorIsNil)
+
+	out := `
+backup schedule: "0 2 * * *"
+retention:       7 daily, 4 weekly
+encryption:      enabled
+next backup:     2018-10-18 02:00:00 +0000 UTC
+last backup:     "spam" at 2018-10-17 02:00:00 +0000 UTC
+scheduled:       1 stored
+
+`[1:] + strings.Replace(MetaResultString, "scheduled:       false\n", "scheduled:       true\nretained:        daily\n", 1)
+	s.checkStd(c, ctx, out, "")
+}
+
+func (s *listSuite) TestScheduledBrief(c *gc.C) {
+	s.setSchedule()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStd(c, ctx, "spam\n",
+		"Backups are scheduled \"0 2 * * *\", keeping 7 daily and 4 weekly; next backup at 2018-10-18 02:00:00 +0000 UTC.\n")
+}
+
+func (s *listSuite) TestNotScheduled(c *gc.C) {
+	client := s.setSuccess()
+	client.scheduleInfo = &params.BackupsScheduleInfo{RetainDaily: 7, RetainWeekly: 4}
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checkStd(c, ctx, "spam\n", "Backups are not scheduled.\n")
+}
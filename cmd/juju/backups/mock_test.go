@@ -201,6 +201,19 @@ func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2)
 }
 
+// ScheduleInfo mocks base method
+func (m *MockAPIClient) ScheduleInfo() (*params.BackupsScheduleInfo, error) {
+	ret := m.ctrl.Call(m, "ScheduleInfo")
+	ret0, _ := ret[0].(*params.BackupsScheduleInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleInfo indicates an expected call of ScheduleInfo
+func (mr *MockAPIClientMockRecorder) ScheduleInfo() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleInfo", reflect.TypeOf((*MockAPIClient)(nil).ScheduleInfo))
+}
+
 // Upload mocks base method
 func (m *MockAPIClient) Upload(arg0 io.ReadSeeker, arg1 params.BackupsMetadataResult) (string, error) {
 	ret := m.ctrl.Call(m, "Upload", arg0, arg1)
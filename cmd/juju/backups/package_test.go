@@ -37,6 +37,8 @@ model ID:        ""
 machine ID:      ""
 created on host: ""
 juju version:    0.0.0
+scheduled:       false
+encrypted:       false
 `[1:]
 
 func TestPackage(t *testing.T) {
@@ -138,9 +140,10 @@ func (s *BaseBackupsSuite) checkStd(c *gc.C, ctx *cmd.Context, out, err string)
 // TODO (hml) 2018-05-01
 // Replace this fakeAPIClient with MockAPIClient for all tests.
 type fakeAPIClient struct {
-	metaresult *params.BackupsMetadataResult
-	archive    io.ReadCloser
-	err        error
+	metaresult   *params.BackupsMetadataResult
+	scheduleInfo *params.BackupsScheduleInfo
+	archive      io.ReadCloser
+	err          error
 
 	calls []string
 	args  []string
@@ -194,6 +197,17 @@ func (c *fakeAPIClient) List() (*params.BackupsListResult, error) {
 	return &result, nil
 }
 
+func (c *fakeAPIClient) ScheduleInfo() (*params.BackupsScheduleInfo, error) {
+	c.calls = append(c.calls, "ScheduleInfo")
+	if c.err != nil {
+		return nil, c.err
+	}
+	if c.scheduleInfo == nil {
+		return nil, errors.NotSupportedf("backup schedules")
+	}
+	return c.scheduleInfo, nil
+}
+
 func (c *fakeAPIClient) Download(id string) (io.ReadCloser, error) {
 	c.calls = append(c.calls, "Download")
 	c.args = append(c.args, id)
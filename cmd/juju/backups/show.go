@@ -11,7 +11,10 @@ import (
 )
 
 const showDoc = `
-show-backup provides the metadata associated with a backup.
+show-backup provides the metadata associated with a backup, including
+whether it was taken on the controller's backup schedule, whether the
+controller's retention policy keeps it, and whether it is stored
+encrypted.
 `
 
 // NewShowCommand returns a command used to show metadata for a backup.
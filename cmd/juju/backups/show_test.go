@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
@@ -40,3 +42,21 @@ func (s *showSuite) TestError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *showSuite) TestScheduledEncrypted(c *gc.C) {
+	s.metaresult.Scheduled = true
+	s.metaresult.Encrypted = true
+	s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, s.metaresult.ID)
+	c.Check(err, jc.ErrorIsNil)
+
+	out := strings.Replace(MetaResultString, `
+scheduled:       false
+encrypted:       false
+`, `
+scheduled:       true
+retained:        no, will be removed by the retention policy
+encrypted:       true
+`, 1)
+	s.checkStd(c, ctx, out, "")
+}
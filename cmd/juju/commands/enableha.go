@@ -72,6 +72,21 @@ controller.
 
 An odd number of controllers is required.
 
+Which controllers vote in the database replica-set is decided
+automatically. The juju-ha-preferred-primaries controller config setting
+lists machines, most preferred first, to favour as the database primary;
+juju-ha-observers lists machines, such as those in a disaster recovery
+zone, to keep as non-voting members. The reason for each controller
+machine's membership of the replica-set computed from these is shown as
+its status message in "juju status -m controller", and in the
+peer-grouper section of the controller machine agents' engine report.
+
+Observers count towards the number of controllers, but not towards the
+voters, and an even number of voters has one more made non-voting. For
+example, 5 controllers with 1 observer leave 3 voters. The observers are
+ignored, with a warning in the controller logs, if fewer than 3 voters
+would be left, so observers need at least 5 controllers.
+
 Examples:
     # Ensure that the controller is still in highly available mode. If
     # there is only 1 controller running, this will ensure there
@@ -90,6 +105,10 @@ Examples:
     # server2 used first, and if necessary, newly created controller
     # machines having at least 8GB RAM.
     juju enable-ha -n 7 --to server1,server2 --constraints mem=8G
+
+    # With 5 controllers, prefer machine 0 as the database primary, and
+    # keep machine 3 as a non-voting observer.
+    juju controller-config juju-ha-preferred-primaries='["0"]' juju-ha-observers='["3"]'
 `
 
 // formatSimple marshals value to a yaml-formatted []byte, unless value is nil.
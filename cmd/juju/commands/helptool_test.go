@@ -53,6 +53,11 @@ Currently available charm hook tools are:
     relation-ids             list all relation ids with the given relation name
     relation-list            list relation units
     relation-set             set relation settings
+    secret-get               print the value of a secret
+    secret-set               create or update a secret
+    state-delete             delete keys from the unit's charm state
+    state-get                print the unit's charm state
+    state-set                write the unit's charm state
     status-get               print status information
     status-set               set status information
     storage-add              add storage instances
@@ -97,6 +102,11 @@ var expectedCommands = []string{
 	"relation-list",
 	"relation-set",
 	"resource-get",
+	"secret-get",
+	"secret-set",
+	"state-delete",
+	"state-get",
+	"state-set",
 	"status-get",
 	"status-set",
 	"storage-add",
@@ -40,12 +40,14 @@ import (
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
+	"github.com/juju/juju/cmd/juju/secrets"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
 	"github.com/juju/juju/cmd/juju/space"
 	"github.com/juju/juju/cmd/juju/status"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/juju/subnet"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/cmd/juju/waitfor"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
@@ -283,6 +285,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
+	r.Register(application.NewResumeCharmUpgradeCommand())
+	r.Register(application.NewAbortCharmUpgradeCommand())
 
 	// Firewall rule commands.
 	r.Register(firewall.NewSetFirewallRuleCommand())
@@ -298,6 +302,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(waitfor.NewWaitForCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
@@ -378,6 +383,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(model.NewDumpDBCommand())
 	}
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewExportModelCommand())
+	r.Register(application.NewDiffBundleCommand())
 
 	// Manage and control actions
 	r.Register(action.NewStatusCommand())
@@ -385,6 +392,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewAddScheduleCommand())
+	r.Register(action.NewListSchedulesCommand())
+	r.Register(action.NewRemoveScheduleCommand())
+
+	// Manage secrets
+	r.Register(secrets.NewAddSecretCommand())
+	r.Register(secrets.NewListSecretsCommand())
+	r.Register(secrets.NewShowSecretCommand())
+	r.Register(secrets.NewGrantSecretCommand())
+	r.Register(secrets.NewRevokeSecretCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -443,6 +460,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
+	r.Register(controller.NewImportModelCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -469,10 +488,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(caas.NewAddCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(application.NewScaleApplicationCommand())
+	r.Register(application.NewAutoscaleApplicationCommand())
 
 	// Manage Application Credential Access
 	r.Register(application.NewTrustCommand())
 
+	// Manage application leadership
+	r.Register(application.NewPinLeadershipCommand())
+	r.Register(application.NewUnpinLeadershipCommand())
+	r.Register(application.NewTransferLeadershipCommand())
+
 	// Juju GUI commands.
 	r.Register(gui.NewGUICommand())
 	r.Register(gui.NewUpgradeGUICommand())
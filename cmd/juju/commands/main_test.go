@@ -389,6 +389,7 @@ func assertNoArgs(c *gc.C, argChan <-chan []string) {
 }
 
 var commandNames = []string{
+	"abort-charm-upgrade",
 	"actions",
 	"add-cloud",
 	"add-credential",
@@ -396,6 +397,8 @@ var commandNames = []string{
 	"add-machine",
 	"add-model",
 	"add-relation",
+	"add-schedule",
+	"add-secret",
 	"add-space",
 	"add-ssh-key",
 	"add-storage",
@@ -407,7 +410,9 @@ var commandNames = []string{
 	"attach",
 	"attach-resource",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
+	"autoscale-application",
 	"backups",
 	"bootstrap",
 	"budget",
@@ -432,6 +437,7 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
@@ -441,18 +447,21 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
 	"firewall-rules",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
+	"grant-secret",
 	"gui",
 	"help",
 	"help-tool",
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
 	"kill-controller",
 	"list-actions",
@@ -472,6 +481,8 @@ var commandNames = []string{
 	"list-plans",
 	"list-regions",
 	"list-resources",
+	"list-schedules",
+	"list-secrets",
 	"list-spaces",
 	"list-ssh-keys",
 	"list-storage",
@@ -491,6 +502,7 @@ var commandNames = []string{
 	"offer",
 	"offers",
 	"payloads",
+	"pin-leadership",
 	"plans",
 	"regions",
 	"register",
@@ -507,6 +519,7 @@ var commandNames = []string{
 	"remove-offer",
 	"remove-relation",
 	"remove-saas",
+	"remove-schedule",
 	"remove-ssh-key",
 	"remove-storage",
 	"remove-unit",
@@ -515,12 +528,16 @@ var commandNames = []string{
 	"resolve",
 	"resources",
 	"restore-backup",
+	"resume-charm-upgrade",
 	"resume-relation",
 	"retry-provisioning",
 	"revoke",
+	"revoke-secret",
 	"run",
 	"run-action",
 	"scale-application",
+	"schedules",
+	"secrets",
 	"scp",
 	"set-constraints",
 	"set-default-credential",
@@ -541,6 +558,7 @@ var commandNames = []string{
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-secret",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -558,8 +576,10 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"transfer-leadership",
 	"trust",
 	"unexpose",
+	"unpin-leadership",
 	"unregister",
 	"update-clouds",
 	"update-credential",
@@ -571,6 +591,7 @@ var commandNames = []string{
 	"upload-backup",
 	"users",
 	"version",
+	"wait-for",
 	"wallets",
 	"whoami",
 }
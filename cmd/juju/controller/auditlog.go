@@ -0,0 +1,244 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apiauditlog "github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const defaultAuditLogLimit = 50
+
+// NewAuditLogCommand returns a command that searches the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	api AuditLogAPI
+	out cmd.Output
+
+	user   string
+	model  string
+	facade string
+	method string
+	from   string
+	to     string
+	limit  int
+
+	query params.AuditLogQuery
+}
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Query(params.AuditLogQuery) ([]params.AuditLogEntry, error)
+}
+
+const auditLogDoc = `
+Searches the API requests recorded in the controller's audit log and
+displays them, most recent first.
+
+Audit records can only be searched when the controller is writing them
+to its database, which is enabled by including "database" in the
+audit-log-backends controller config setting at bootstrap. Only
+controller superusers can search the audit log.
+
+Times given to --from and --to may be either RFC3339 timestamps or
+dates in YYYY-MM-DD form (meaning midnight UTC).
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --method DestroyApplication
+    juju audit-log --model admin/default --from 2018-05-01 --to 2018-05-08
+    juju audit-log --facade Application --limit 0 --format yaml
+
+See also:
+    controller-config
+`
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Searches the audit log of API requests made to the controller.",
+		Doc:     auditLogDoc,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show requests made against this model (owner/name or UUID)")
+	f.StringVar(&c.facade, "facade", "", "Only show requests to this API facade")
+	f.StringVar(&c.method, "method", "", "Only show requests to this API method")
+	f.StringVar(&c.from, "from", "", "Only show requests made at or after this time")
+	f.StringVar(&c.to, "to", "", "Only show requests made at or before this time")
+	f.IntVar(&c.limit, "limit", defaultAuditLogLimit, "The maximum number of requests to show (0 for all)")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	c.query = params.AuditLogQuery{
+		User:   c.user,
+		Model:  c.model,
+		Facade: c.facade,
+		Method: c.method,
+		Limit:  c.limit,
+	}
+	if c.from != "" {
+		from, err := parseAuditTime(c.from)
+		if err != nil {
+			return errors.Annotate(err, "invalid --from")
+		}
+		c.query.From = &from
+	}
+	if c.to != "" {
+		to, err := parseAuditTime(c.to)
+		if err != nil {
+			return errors.Annotate(err, "invalid --to")
+		}
+		c.query.To = &to
+	}
+	if c.query.From != nil && c.query.To != nil && c.query.To.Before(*c.query.From) {
+		return errors.New("--to must not be before --from")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.UTC(), nil
+	}
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	return time.Time{}, errors.Errorf("expected an RFC3339 time or YYYY-MM-DD date, got %q", value)
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return apiauditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No matching audit log entries.")
+		return nil
+	}
+	formatted := make([]auditLogEntry, len(entries))
+	for i, entry := range entries {
+		formatted[i] = formatAuditLogEntry(entry)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// auditLogEntry is the output representation of an audited request.
+type auditLogEntry struct {
+	When           time.Time       `yaml:"when" json:"when"`
+	User           string          `yaml:"user" json:"user"`
+	Model          string          `yaml:"model" json:"model"`
+	ModelUUID      string          `yaml:"model-uuid" json:"model-uuid"`
+	Command        string          `yaml:"command,omitempty" json:"command,omitempty"`
+	Facade         string          `yaml:"facade" json:"facade"`
+	Method         string          `yaml:"method" json:"method"`
+	Version        int             `yaml:"version" json:"version"`
+	Args           string          `yaml:"args,omitempty" json:"args,omitempty"`
+	ConversationID string          `yaml:"conversation-id" json:"conversation-id"`
+	RequestID      uint64          `yaml:"request-id" json:"request-id"`
+	Errors         []auditLogError `yaml:"errors,omitempty" json:"errors,omitempty"`
+}
+
+type auditLogError struct {
+	Message string `yaml:"message" json:"message"`
+	Code    string `yaml:"code,omitempty" json:"code,omitempty"`
+}
+
+func formatAuditLogEntry(entry params.AuditLogEntry) auditLogEntry {
+	result := auditLogEntry{
+		When:           entry.When.UTC(),
+		User:           entry.Who,
+		Model:          entry.ModelName,
+		ModelUUID:      entry.ModelUUID,
+		Command:        entry.What,
+		Facade:         entry.Facade,
+		Method:         entry.Method,
+		Version:        entry.Version,
+		Args:           entry.Args,
+		ConversationID: entry.ConversationID,
+		RequestID:      entry.RequestID,
+	}
+	for _, e := range entry.Errors {
+		result.Errors = append(result.Errors, auditLogError{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return result
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditLogEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Model", "Request", "Error", "Command")
+	for _, entry := range entries {
+		var errorMessages []string
+		for _, e := range entry.Errors {
+			errorMessages = append(errorMessages, e.Message)
+		}
+		w.Println(
+			entry.When.Format(time.RFC3339),
+			entry.User,
+			entry.Model,
+			fmt.Sprintf("%s.%s", entry.Facade, entry.Method),
+			strings.Join(errorMessages, "; "),
+			entry.Command,
+		)
+	}
+	return tw.Flush()
+}
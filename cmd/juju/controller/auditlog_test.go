@@ -0,0 +1,167 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api *fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      3,
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			ModelName:      "admin/default",
+			ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			When:           time.Date(2018, 5, 1, 10, 0, 1, 0, time.UTC),
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        8,
+			Errors: []params.AuditLogErrorInfo{
+				{Message: "blocked", Code: "operation is blocked"},
+			},
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "--limit must not be negative",
+	}, {
+		args: []string{"--from", "last tuesday"},
+		err:  `invalid --from: expected an RFC3339 time or YYYY-MM-DD date, got "last tuesday"`,
+	}, {
+		args: []string{"--from", "2018-05-02", "--to", "2018-05-01"},
+		err:  "--to must not be before --from",
+	}} {
+		c.Logf("%d: %v", i, test.args)
+		err := cmdtesting.InitCommand(controller.NewAuditLogCommandForTest(s.api, s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestQueryArgs(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "admin/default",
+		"--facade", "Application",
+		"--method", "DestroyApplication",
+		"--from", "2018-05-01",
+		"--to", "2018-05-01T12:00:00+02:00",
+		"--limit", "5",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	from := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2018, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Query", []interface{}{params.AuditLogQuery{
+			User:   "bob",
+			Model:  "admin/default",
+			Facade: "Application",
+			Method: "DestroyApplication",
+			From:   &from,
+			To:     &to,
+			Limit:  5,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestDefaultLimit(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogQuery{Limit: 50})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model          Request                         Error    Command
+2018-05-01T10:00:01Z  bob   admin/default  Application.DestroyApplication  blocked  juju remove-application mysql
+`[1:])
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- when: 2018-05-01T10:00:01Z
+  user: bob
+  model: admin/default
+  model-uuid: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  command: juju remove-application mysql
+  facade: Application
+  method: DestroyApplication
+  version: 8
+  conversation-id: aaaa
+  request-id: 3
+  errors:
+  - message: blocked
+    code: operation is blocked
+`[1:])
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No matching audit log entries.\n")
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeAuditLogAPI struct {
+	testing.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Query(query params.AuditLogQuery) ([]params.AuditLogEntry, error) {
+	f.AddCall("Query", query)
+	return f.entries, f.NextErr()
+}
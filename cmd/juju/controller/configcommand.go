@@ -233,8 +233,9 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 		// which makes the output messy.
 		valString := strings.TrimSuffix(out.String(), "\n")
 
-		// Special formatting for multiline exclude-methods lists.
-		if name == controller.AuditLogExcludeMethods {
+		// Special formatting for multiline exclude-methods and
+		// backends lists.
+		if name == controller.AuditLogExcludeMethods || name == controller.AuditLogBackends {
 			if strings.Contains(valString, "\n") {
 				valString = "\n" + valString
 			} else {
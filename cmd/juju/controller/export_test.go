@@ -171,6 +171,24 @@ func NewConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore) cm
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an audit-log command with the api
+// provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewImportModelCommandForTest returns an import-model command with the
+// api provided as specified.
+func NewImportModelCommandForTest(api ImportModelAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &importModelCommand{
+		newAPIFunc: func() (ImportModelAPI, error) { return api, nil },
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
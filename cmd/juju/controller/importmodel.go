@@ -0,0 +1,267 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/migrationtarget"
+	"github.com/juju/juju/cmd/modelcmd"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a command to import a model from an
+// archive file.
+func NewImportModelCommand() cmd.Command {
+	c := &importModelCommand{}
+	c.newAPIFunc = c.newAPI
+	return modelcmd.WrapController(c)
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func() (ImportModelAPI, error)
+	Filename   string
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive file written by export-model.
+
+The model is created in the controller with the same name, owner and
+UUID that it had when it was exported, along with its charms, resources
+and agent binaries. The cloud resources used by the model are handed
+over to this controller.
+
+Importing a model requires controller superuser access, and fails if a
+model with the same UUID, or with the same name and owner, already
+exists in the controller.
+
+Agents running on the model's machines are not redirected to this
+controller. Import a model when its original controller is gone, or
+has been otherwise separated from the model's machines.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "import-model",
+		Args:    "<filename>",
+		Purpose: "Imports a model from an archive file.",
+		Doc:     importModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no filename specified")
+	}
+	c.Filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ImportModelAPI specifies the MigrationTarget API used by the
+// import-model command.
+type ImportModelAPI interface {
+	Close() error
+	Prechecks(coremigration.ModelInfo) error
+	Import(coremigration.SerializedModel) error
+	Abort(modelUUID string) error
+	Activate(modelUUID string) error
+	CheckMachines(modelUUID string) ([]error, error)
+	AdoptResources(modelUUID string) error
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+type importModelAPI struct {
+	*migrationtarget.Client
+	root api.Connection
+}
+
+// Close is part of the ImportModelAPI interface.
+func (a *importModelAPI) Close() error {
+	return a.root.Close()
+}
+
+func (c *importModelCommand) newAPI() (ImportModelAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelAPI{
+		Client: migrationtarget.NewClient(root),
+		root:   root,
+	}, nil
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	file, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+	archive, err := migration.ReadModelArchive(file)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	modelInfo, err := archive.ModelInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.Prechecks(modelInfo); err != nil {
+		return errors.Annotate(err, "model cannot be imported")
+	}
+	ctx.Infof("Importing model %q", modelInfo.Name)
+	if err := client.Import(archive.Model); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	if err := c.completeImport(ctx, client, archive, modelInfo.UUID); err != nil {
+		if abortErr := client.Abort(modelInfo.UUID); abortErr != nil {
+			logger.Errorf("cannot remove partly imported model: %v", abortErr)
+		}
+		return errors.Trace(err)
+	}
+	if err := client.AdoptResources(modelInfo.UUID); err != nil {
+		// The model is usable, so report the problem but don't
+		// fail the import.
+		ctx.Warningf("cannot transfer ownership of cloud resources to the controller: %v", err)
+	}
+
+	if err := c.updateStore(archive, modelInfo); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Imported model %q", modelInfo.Name)
+	return nil
+}
+
+// completeImport uploads the binaries used by the imported model, and
+// activates the model once its machines have been checked.
+func (c *importModelCommand) completeImport(ctx *cmd.Context, client ImportModelAPI, archive *migration.ModelArchive, modelUUID string) error {
+	ctx.Infof("Uploading charms, resources and agent binaries")
+	uploader := &importUploader{client: client, modelUUID: modelUUID}
+	if err := migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             archive.Model.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              archive.Model.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	}); err != nil {
+		return errors.Annotate(err, "uploading binaries")
+	}
+
+	problems, err := client.CheckMachines(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(problems) > 0 {
+		for _, problem := range problems {
+			ctx.Warningf("%v", problem)
+		}
+		plural := "s"
+		if len(problems) == 1 {
+			plural = ""
+		}
+		return errors.Errorf("machine check failed, %d error%s found", len(problems), plural)
+	}
+	return errors.Annotate(client.Activate(modelUUID), "activating model")
+}
+
+// updateStore records the imported model in the client store, if it
+// is owned by the current user.
+func (c *importModelCommand) updateStore(archive *migration.ModelArchive, modelInfo coremigration.ModelInfo) error {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	store := c.ClientStore()
+	accountDetails, err := store.AccountDetails(controllerName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if accountDetails.User != modelInfo.Owner.Id() {
+		return nil
+	}
+	desc, err := description.Deserialize(archive.Model.Bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelType := model.IAAS
+	if desc.Type() != "" {
+		modelType = model.ModelType(desc.Type())
+	}
+	return errors.Trace(store.UpdateModel(
+		controllerName,
+		jujuclient.JoinOwnerModelName(modelInfo.Owner, modelInfo.Name),
+		jujuclient.ModelDetails{
+			ModelUUID: modelInfo.UUID,
+			ModelType: modelType,
+		},
+	))
+}
+
+// importUploader sends binaries for the imported model to the
+// controller.
+type importUploader struct {
+	client    ImportModelAPI
+	modelUUID string
+}
+
+func (u *importUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+func (u *importUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+func (u *importUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+func (u *importUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+func (u *importUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
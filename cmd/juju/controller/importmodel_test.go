@@ -0,0 +1,233 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type importModelSuite struct {
+	baseControllerSuite
+	api      *fakeImportModelAPI
+	store    *jujuclient.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&importModelSuite{})
+
+func (s *importModelSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeImportModelAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{User: "admin"}
+
+	bytes, err := description.Serialize(description.NewModel(description.ModelArgs{
+		Type:  "iaas",
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          coretesting.ModelTag.Id(),
+			"agent-version": "2.5.0",
+		},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	serialized, err := migration.NewSerializedModel(bytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	file, err := os.Create(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	err = migration.WriteModelArchive(file, migration.WriteModelArchiveConfig{
+		Metadata: migration.ModelArchiveMetadata{
+			ControllerAgentVersion: version.MustParse("2.5.0"),
+		},
+		Model:              serialized,
+		CharmDownloader:    noDownloader{},
+		ToolsDownloader:    noDownloader{},
+		ResourceDownloader: noDownloader{},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *importModelSuite) newCommand() cmd.Command {
+	return controller.NewImportModelCommandForTest(s.api, s.store)
+}
+
+func (s *importModelSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "no filename specified")
+
+	_, err = cmdtesting.RunCommand(c, s.newCommand(), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *importModelSuite) TestImportModel(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := coretesting.ModelTag.Id()
+	s.api.CheckCallNames(c, "Prechecks", "Import", "CheckMachines", "Activate", "AdoptResources", "Close")
+	s.api.CheckCall(c, 0, "Prechecks", coremigration.ModelInfo{
+		UUID:                   uuid,
+		Owner:                  names.NewUserTag("admin"),
+		Name:                   "mymodel",
+		AgentVersion:           version.MustParse("2.5.0"),
+		ControllerAgentVersion: version.MustParse("2.5.0"),
+	})
+	s.api.CheckCall(c, 3, "Activate", uuid)
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, `Imported model "mymodel"`)
+
+	details, err := s.store.ModelByName("fake", "admin/mymodel")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*details, jc.DeepEquals, jujuclient.ModelDetails{
+		ModelUUID: uuid,
+		ModelType: model.IAAS,
+	})
+}
+
+func (s *importModelSuite) TestImportModelOtherOwner(c *gc.C) {
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{User: "bob"}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.store.ModelByName("fake", "admin/mymodel")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *importModelSuite) TestPrechecksFail(c *gc.C) {
+	s.api.SetErrors(errors.New("model already exists"))
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, "model cannot be imported: model already exists")
+	s.api.CheckCallNames(c, "Prechecks", "Close")
+}
+
+func (s *importModelSuite) TestMachineCheckFailAborts(c *gc.C) {
+	s.api.machineErrors = []error{errors.New("machine 0 is missing")}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, "machine check failed, 1 error found")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "machine 0 is missing")
+	s.api.CheckCallNames(c, "Prechecks", "Import", "CheckMachines", "Abort", "Close")
+	s.api.CheckCall(c, 3, "Abort", coretesting.ModelTag.Id())
+}
+
+func (s *importModelSuite) TestActivateFailAborts(c *gc.C) {
+	s.api.SetErrors(nil, nil, nil, errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, "activating model: boom")
+	s.api.CheckCallNames(c, "Prechecks", "Import", "CheckMachines", "Activate", "Abort", "Close")
+}
+
+func (s *importModelSuite) TestMissingFile(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), filepath.Join(c.MkDir(), "missing.tar.gz"))
+	c.Assert(err, gc.ErrorMatches, ".* no such file or directory")
+	s.api.CheckNoCalls(c)
+}
+
+type fakeImportModelAPI struct {
+	testing.Stub
+	machineErrors []error
+}
+
+func (f *fakeImportModelAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Prechecks(info coremigration.ModelInfo) error {
+	f.MethodCall(f, "Prechecks", info)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Import(model coremigration.SerializedModel) error {
+	f.MethodCall(f, "Import", model)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) CheckMachines(modelUUID string) ([]error, error) {
+	f.MethodCall(f, "CheckMachines", modelUUID)
+	return f.machineErrors, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) AdoptResources(modelUUID string) error {
+	f.MethodCall(f, "AdoptResources", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	f.MethodCall(f, "UploadCharm", modelUUID, curl)
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	return tools.List{{Version: vers}}, f.NextErr()
+}
+
+func (f *fakeImportModelAPI) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelAPI) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res)
+	return f.NextErr()
+}
+
+// noDownloader is used to write archives of models that use no
+// binaries.
+type noDownloader struct{}
+
+func (noDownloader) OpenCharm(*charm.URL) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("OpenCharm")
+}
+
+func (noDownloader) OpenURI(string, url.Values) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("OpenURI")
+}
+
+func (noDownloader) OpenResource(string, string) (io.ReadCloser, error) {
+	return nil, errors.NotImplementedf("OpenResource")
+}
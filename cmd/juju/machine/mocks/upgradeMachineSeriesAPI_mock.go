@@ -6,6 +6,7 @@ package mocks
 
 import (
 	gomock "github.com/golang/mock/gomock"
+	params "github.com/juju/juju/apiserver/params"
 	watcher "github.com/juju/juju/core/watcher"
 	reflect "reflect"
 )
@@ -70,6 +71,84 @@ func (mr *MockUpgradeMachineSeriesAPIMockRecorder) GetUpgradeSeriesMessages(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpgradeSeriesMessages", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).GetUpgradeSeriesMessages), arg0, arg1)
 }
 
+// UpgradeSeriesApplicationComplete mocks base method
+func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesApplicationComplete(arg0 string) (*params.ApplicationUpgradeSeries, error) {
+	ret := m.ctrl.Call(m, "UpgradeSeriesApplicationComplete", arg0)
+	ret0, _ := ret[0].(*params.ApplicationUpgradeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeSeriesApplicationComplete indicates an expected call of UpgradeSeriesApplicationComplete
+func (mr *MockUpgradeMachineSeriesAPIMockRecorder) UpgradeSeriesApplicationComplete(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesApplicationComplete", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).UpgradeSeriesApplicationComplete), arg0)
+}
+
+// UpgradeSeriesApplicationPause mocks base method
+func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesApplicationPause(arg0 string) (*params.ApplicationUpgradeSeries, error) {
+	ret := m.ctrl.Call(m, "UpgradeSeriesApplicationPause", arg0)
+	ret0, _ := ret[0].(*params.ApplicationUpgradeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeSeriesApplicationPause indicates an expected call of UpgradeSeriesApplicationPause
+func (mr *MockUpgradeMachineSeriesAPIMockRecorder) UpgradeSeriesApplicationPause(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesApplicationPause", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).UpgradeSeriesApplicationPause), arg0)
+}
+
+// UpgradeSeriesApplicationPrepare mocks base method
+func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesApplicationPrepare(arg0, arg1 string, arg2 bool) (*params.ApplicationUpgradeSeries, error) {
+	ret := m.ctrl.Call(m, "UpgradeSeriesApplicationPrepare", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*params.ApplicationUpgradeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeSeriesApplicationPrepare indicates an expected call of UpgradeSeriesApplicationPrepare
+func (mr *MockUpgradeMachineSeriesAPIMockRecorder) UpgradeSeriesApplicationPrepare(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesApplicationPrepare", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).UpgradeSeriesApplicationPrepare), arg0, arg1, arg2)
+}
+
+// UpgradeSeriesApplicationResume mocks base method
+func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesApplicationResume(arg0 string) (*params.ApplicationUpgradeSeries, error) {
+	ret := m.ctrl.Call(m, "UpgradeSeriesApplicationResume", arg0)
+	ret0, _ := ret[0].(*params.ApplicationUpgradeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeSeriesApplicationResume indicates an expected call of UpgradeSeriesApplicationResume
+func (mr *MockUpgradeMachineSeriesAPIMockRecorder) UpgradeSeriesApplicationResume(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesApplicationResume", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).UpgradeSeriesApplicationResume), arg0)
+}
+
+// UpgradeSeriesApplicationRollback mocks base method
+func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesApplicationRollback(arg0 string) (*params.ApplicationUpgradeSeries, error) {
+	ret := m.ctrl.Call(m, "UpgradeSeriesApplicationRollback", arg0)
+	ret0, _ := ret[0].(*params.ApplicationUpgradeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeSeriesApplicationRollback indicates an expected call of UpgradeSeriesApplicationRollback
+func (mr *MockUpgradeMachineSeriesAPIMockRecorder) UpgradeSeriesApplicationRollback(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesApplicationRollback", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).UpgradeSeriesApplicationRollback), arg0)
+}
+
+// UpgradeSeriesApplicationStatus mocks base method
+func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesApplicationStatus(arg0 string) (*params.ApplicationUpgradeSeries, error) {
+	ret := m.ctrl.Call(m, "UpgradeSeriesApplicationStatus", arg0)
+	ret0, _ := ret[0].(*params.ApplicationUpgradeSeries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpgradeSeriesApplicationStatus indicates an expected call of UpgradeSeriesApplicationStatus
+func (mr *MockUpgradeMachineSeriesAPIMockRecorder) UpgradeSeriesApplicationStatus(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpgradeSeriesApplicationStatus", reflect.TypeOf((*MockUpgradeMachineSeriesAPI)(nil).UpgradeSeriesApplicationStatus), arg0)
+}
+
 // UpgradeSeriesComplete mocks base method
 func (m *MockUpgradeMachineSeriesAPI) UpgradeSeriesComplete(arg0 string) error {
 	ret := m.ctrl.Call(m, "UpgradeSeriesComplete", arg0)
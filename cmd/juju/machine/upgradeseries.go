@@ -26,6 +26,10 @@ import (
 const (
 	PrepareCommand  = "prepare"
 	CompleteCommand = "complete"
+	PauseCommand    = "pause"
+	ResumeCommand   = "resume"
+	RollbackCommand = "rollback"
+	StatusCommand   = "status"
 )
 
 var UpgradeSeriesConfirmationMsg = `
@@ -47,6 +51,23 @@ juju upgrade-series complete %s`
 const UpgradeSeriesCompleteFinishedMessage = `
 Upgrade series for machine %q has successfully completed`
 
+var UpgradeSeriesApplicationConfirmationMsg = `
+WARNING This command will upgrade the machines hosting application %q to
+series %q one at a time, with the machine hosting the leader last. Each
+machine is prepared in turn and its operating system must be upgraded before
+the next machine is prepared. The upgrade can be paused, resumed or rolled
+back between machines.
+
+Continue [y/N]?`[1:]
+
+const UpgradeSeriesApplicationPrepareFinishedMessage = `
+Machine %q is now ready for the series to be updated.
+Perform any manual steps required along with "do-release-upgrade".
+When ready run the following to complete its upgrade and move on to the
+next machine hosting the application:
+
+juju upgrade-series complete %s --application`
+
 // NewUpgradeSeriesCommand returns a command which upgrades the series of
 // an application or machine.
 func NewUpgradeSeriesCommand() cmd.Command {
@@ -62,6 +83,12 @@ type UpgradeMachineSeriesAPI interface {
 	UpgradeSeriesComplete(string) error
 	WatchUpgradeSeriesNotifications(string) (watcher.NotifyWatcher, string, error)
 	GetUpgradeSeriesMessages(string, string) ([]string, error)
+	UpgradeSeriesApplicationPrepare(string, string, bool) (*params.ApplicationUpgradeSeries, error)
+	UpgradeSeriesApplicationComplete(string) (*params.ApplicationUpgradeSeries, error)
+	UpgradeSeriesApplicationPause(string) (*params.ApplicationUpgradeSeries, error)
+	UpgradeSeriesApplicationResume(string) (*params.ApplicationUpgradeSeries, error)
+	UpgradeSeriesApplicationRollback(string) (*params.ApplicationUpgradeSeries, error)
+	UpgradeSeriesApplicationStatus(string) (*params.ApplicationUpgradeSeries, error)
 }
 
 // upgradeSeriesCommand is responsible for updating the series of an application or machine.
@@ -71,11 +98,13 @@ type upgradeSeriesCommand struct {
 
 	upgradeMachineSeriesClient UpgradeMachineSeriesAPI
 
-	prepCommand   string
-	force         bool
-	machineNumber string
-	series        string
-	agree         bool
+	prepCommand     string
+	force           bool
+	machineNumber   string
+	applicationMode bool
+	applicationName string
+	series          string
+	agree           bool
 
 	catacomb catacomb.Catacomb
 	plan     catacomb.Plan
@@ -121,6 +150,32 @@ necessary manual upgrade steps have completed successfully:
 
 	juju upgrade-series complete <machine>
 
+With the --application option the series of every machine hosting the units
+of <application> is upgraded, one machine at a time with the machine hosting
+the application's leader last. "prepare" prepares the first machine; once its
+operating system has been upgraded "complete" completes it, after which Juju
+prepares the next machine. Between machines the upgrade can be paused with
+"pause", continued with "resume" or abandoned with "rollback", leaving the
+machines that have not yet been upgraded on their original series. A machine
+being prepared can only be rolled back until its unit agents are stopped;
+after that it must be completed first. "status" shows the progress of the
+upgrade.
+
+Prepare the machines hosting <application> for upgrade to series <series>:
+
+	juju upgrade-series prepare <application> <series> --application
+
+Complete the upgrade of the prepared machine hosting <application>:
+
+	juju upgrade-series complete <application> --application
+
+Pause, resume, roll back or show the upgrade of <application>:
+
+	juju upgrade-series pause <application> --application
+	juju upgrade-series resume <application> --application
+	juju upgrade-series rollback <application> --application
+	juju upgrade-series status <application> --application
+
 See also:
     machines
     status
@@ -132,7 +187,7 @@ func (c *upgradeSeriesCommand) Info() *cmd.Info {
 	return &cmd.Info{
 		Name:    "upgrade-series",
 		Args:    "<action> [args]",
-		Purpose: "Upgrade a machine's series.",
+		Purpose: "Upgrade the series of a machine or of the machines hosting an application.",
 		Doc:     upgradeSeriesDoc,
 	}
 }
@@ -143,6 +198,7 @@ func (c *upgradeSeriesCommand) SetFlags(f *gnuflag.FlagSet) {
 	// TODO (hml) 2018-06-28
 	// agree should be hidden, or available only during initial testing?
 	f.BoolVar(&c.agree, "agree", false, "Agree this operation cannot be reverted or canceled once started.")
+	f.BoolVar(&c.applicationMode, "application", false, "Upgrade the machines hosting an application, one at a time.")
 }
 
 // Init implements cmd.Command.
@@ -154,13 +210,17 @@ func (c *upgradeSeriesCommand) Init(args []string) error {
 	}
 
 	prepCommandStrings := []string{PrepareCommand, CompleteCommand}
+	if c.applicationMode {
+		prepCommandStrings = append(prepCommandStrings,
+			PauseCommand, ResumeCommand, RollbackCommand, StatusCommand)
+	}
 	prepCommand, err := checkPrepCommands(prepCommandStrings, args[0])
 	if err != nil {
 		return errors.Annotate(err, "invalid argument")
 	}
 	c.prepCommand = prepCommand
 
-	if c.prepCommand == CompleteCommand {
+	if c.prepCommand != PrepareCommand {
 		numArguments = 2
 	}
 
@@ -168,7 +228,12 @@ func (c *upgradeSeriesCommand) Init(args []string) error {
 		return errors.Errorf("wrong number of arguments")
 	}
 
-	if names.IsValidMachine(args[1]) {
+	if c.applicationMode {
+		if !names.IsValidApplication(args[1]) {
+			return errors.Errorf("%q is an invalid application name", args[1])
+		}
+		c.applicationName = args[1]
+	} else if names.IsValidMachine(args[1]) {
 		c.machineNumber = args[1]
 	} else {
 		return errors.Errorf("%q is an invalid machine name", args[1])
@@ -187,6 +252,9 @@ func (c *upgradeSeriesCommand) Init(args []string) error {
 
 // Run implements cmd.Run.
 func (c *upgradeSeriesCommand) Run(ctx *cmd.Context) error {
+	if c.applicationMode {
+		return errors.Trace(c.UpgradeSeriesApplication(ctx))
+	}
 	if c.prepCommand == PrepareCommand {
 		err := c.UpgradeSeriesPrepare(ctx)
 		if err != nil {
@@ -0,0 +1,152 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// The statuses of the machines of an application's series upgrade that
+// the command follows the notifications of.
+const (
+	upgradeSeriesMachinePreparing  = "preparing"
+	upgradeSeriesMachineCompleting = "completing"
+)
+
+// UpgradeSeriesApplication runs the command's action against the series
+// upgrade of the machines hosting an application, and shows its progress.
+func (c *upgradeSeriesCommand) UpgradeSeriesApplication(ctx *cmd.Context) error {
+	var apiRoot api.Connection
+	var err error
+
+	if c.upgradeMachineSeriesClient == nil {
+		apiRoot, err = c.NewAPIRoot()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer apiRoot.Close()
+		c.upgradeMachineSeriesClient = machinemanager.NewClient(apiRoot)
+	}
+
+	client := c.upgradeMachineSeriesClient
+	var upgrade *params.ApplicationUpgradeSeries
+	switch c.prepCommand {
+	case PrepareCommand:
+		if err := c.promptApplicationConfirmation(ctx); err != nil {
+			return err
+		}
+		upgrade, err = client.UpgradeSeriesApplicationPrepare(c.applicationName, c.series, c.force)
+	case CompleteCommand:
+		upgrade, err = client.UpgradeSeriesApplicationComplete(c.applicationName)
+	case PauseCommand:
+		upgrade, err = client.UpgradeSeriesApplicationPause(c.applicationName)
+	case ResumeCommand:
+		upgrade, err = client.UpgradeSeriesApplicationResume(c.applicationName)
+	case RollbackCommand:
+		upgrade, err = client.UpgradeSeriesApplicationRollback(c.applicationName)
+	case StatusCommand:
+		upgrade, err = client.UpgradeSeriesApplicationStatus(c.applicationName)
+	default:
+		return errors.NotValidf("upgrade-series command %q", c.prepCommand)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	switch c.prepCommand {
+	case PrepareCommand:
+		if err := c.handleMachineNotifications(ctx, upgrade, upgradeSeriesMachinePreparing); err != nil {
+			return errors.Trace(err)
+		}
+		if err := writeApplicationUpgradeSeries(ctx.Stdout, upgrade); err != nil {
+			return errors.Trace(err)
+		}
+		if c.machineNumber != "" {
+			m := UpgradeSeriesApplicationPrepareFinishedMessage + "\n"
+			fmt.Fprintf(ctx.Stdout, m, c.machineNumber, c.applicationName)
+		}
+		return nil
+	case CompleteCommand:
+		if err := c.handleMachineNotifications(ctx, upgrade, upgradeSeriesMachineCompleting); err != nil {
+			return errors.Trace(err)
+		}
+		if c.machineNumber != "" {
+			m := UpgradeSeriesCompleteFinishedMessage + "\n"
+			fmt.Fprintf(ctx.Stdout, m, c.machineNumber)
+		}
+		// The completed machine has moved the upgrade on, so show
+		// where it has got to rather than where it was.
+		upgrade, err = client.UpgradeSeriesApplicationStatus(c.applicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(writeApplicationUpgradeSeries(ctx.Stdout, upgrade))
+}
+
+// handleMachineNotifications displays the upgrade series notifications of
+// the application's machine with the given status, if there is one.
+func (c *upgradeSeriesCommand) handleMachineNotifications(
+	ctx *cmd.Context, upgrade *params.ApplicationUpgradeSeries, status string,
+) error {
+	for _, m := range upgrade.Machines {
+		if m.Status != status {
+			continue
+		}
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.machineNumber = tag.Id()
+		return errors.Trace(c.handleNotifications(ctx))
+	}
+	return nil
+}
+
+func (c *upgradeSeriesCommand) promptApplicationConfirmation(ctx *cmd.Context) error {
+	if c.agree {
+		return nil
+	}
+
+	fmt.Fprintf(ctx.Stdout, UpgradeSeriesApplicationConfirmationMsg, c.applicationName, c.series)
+
+	if err := jujucmd.UserConfirmYes(ctx); err != nil {
+		return errors.Annotate(err, "upgrade series")
+	}
+	return nil
+}
+
+// writeApplicationUpgradeSeries writes the progress of an application's
+// series upgrade, machine by machine, in tabular form.
+func writeApplicationUpgradeSeries(writer io.Writer, upgrade *params.ApplicationUpgradeSeries) error {
+	tw := output.TabWriter(writer)
+	writeRow := func(cells ...string) {
+		// Leave out trailing empty cells so that lines are not padded.
+		fmt.Fprintln(tw, strings.TrimRight(strings.Join(cells, "\t"), "\t"))
+	}
+	writeRow("Application", "Series", "Status", "Message")
+	writeRow(upgrade.Application, upgrade.ToSeries, upgrade.Status, upgrade.Message)
+	writeRow()
+	writeRow("Machine", "From", "Status", "Upgrade status")
+	for _, m := range upgrade.Machines {
+		id := m.Tag
+		if tag, err := names.ParseMachineTag(m.Tag); err == nil {
+			id = tag.Id()
+		}
+		writeRow(id, m.FromSeries, m.Status, string(m.UpgradeSeriesStatus))
+	}
+	return tw.Flush()
+}
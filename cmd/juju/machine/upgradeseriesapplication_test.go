@@ -0,0 +1,167 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/golang/mock/gomock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/cmd/juju/machine/mocks"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/testing"
+)
+
+type UpgradeSeriesApplicationSuite struct {
+	testing.BaseSuite
+
+	api *mocks.MockUpgradeMachineSeriesAPI
+}
+
+var _ = gc.Suite(&UpgradeSeriesApplicationSuite{})
+
+const applicationArg = "mysql"
+
+var applicationStatusOutput = `
+Application  Series  Status   Message
+mysql        xenial  running
+
+Machine  From    Status     Upgrade status
+1        trusty  preparing  prepare completed
+0        trusty  pending
+`[1:]
+
+func upgradeSeriesApplication(first, second string) *params.ApplicationUpgradeSeries {
+	upgrade := &params.ApplicationUpgradeSeries{
+		Application: applicationArg,
+		ToSeries:    seriesArg,
+		Status:      "running",
+		Machines: []params.ApplicationUpgradeSeriesMachine{{
+			Tag:        "machine-1",
+			FromSeries: "trusty",
+			Status:     first,
+		}, {
+			Tag:        "machine-0",
+			FromSeries: "trusty",
+			Status:     second,
+		}},
+	}
+	if first == "preparing" {
+		upgrade.Machines[0].UpgradeSeriesStatus = model.UpgradeSeriesPrepareCompleted
+	}
+	return upgrade
+}
+
+func (s *UpgradeSeriesApplicationSuite) run(c *gc.C, confirmation string, setup func(), args ...string) (*cmd.Context, error) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	s.api = mocks.NewMockUpgradeMachineSeriesAPI(ctrl)
+	if setup != nil {
+		setup()
+	}
+
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = bytes.NewBufferString(confirmation)
+	com := machine.NewUpgradeSeriesCommandForTest(s.api)
+	if err := cmdtesting.InitCommand(com, args); err != nil {
+		return nil, err
+	}
+	return ctx, com.Run(ctx)
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestPrepare(c *gc.C) {
+	ctx, err := s.run(c, "y", func() {
+		s.api.EXPECT().UpgradeSeriesApplicationPrepare(applicationArg, seriesArg, false).Return(
+			upgradeSeriesApplication("preparing", "pending"), nil)
+	}, machine.PrepareCommand, applicationArg, seriesArg, "--application")
+	c.Assert(err, jc.ErrorIsNil)
+	confirmationMsg := fmt.Sprintf(machine.UpgradeSeriesApplicationConfirmationMsg, applicationArg, seriesArg)
+	finishedMsg := fmt.Sprintf(machine.UpgradeSeriesApplicationPrepareFinishedMessage, "1", applicationArg) + "\n"
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, confirmationMsg+applicationStatusOutput+finishedMsg)
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestPrepareForceAgree(c *gc.C) {
+	_, err := s.run(c, "n", func() {
+		s.api.EXPECT().UpgradeSeriesApplicationPrepare(applicationArg, seriesArg, true).Return(
+			upgradeSeriesApplication("preparing", "pending"), nil)
+	}, machine.PrepareCommand, applicationArg, seriesArg, "--application", "--force", "--agree")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestPrepareAbortsOnFailedConfirmation(c *gc.C) {
+	_, err := s.run(c, "n", nil, machine.PrepareCommand, applicationArg, seriesArg, "--application")
+	c.Assert(err, gc.ErrorMatches, "upgrade series: aborted")
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestPrepareError(c *gc.C) {
+	_, err := s.run(c, "y", func() {
+		s.api.EXPECT().UpgradeSeriesApplicationPrepare(applicationArg, seriesArg, false).Return(
+			nil, errors.New("boom"))
+	}, machine.PrepareCommand, applicationArg, seriesArg, "--application")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestComplete(c *gc.C) {
+	ctx, err := s.run(c, "", func() {
+		gomock.InOrder(
+			s.api.EXPECT().UpgradeSeriesApplicationComplete(applicationArg).Return(
+				upgradeSeriesApplication("completing", "pending"), nil),
+			s.api.EXPECT().UpgradeSeriesApplicationStatus(applicationArg).Return(
+				upgradeSeriesApplication("preparing", "pending"), nil),
+		)
+	}, machine.CompleteCommand, applicationArg, "--application")
+	c.Assert(err, jc.ErrorIsNil)
+	completedMsg := fmt.Sprintf(machine.UpgradeSeriesCompleteFinishedMessage, "1") + "\n"
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, completedMsg+applicationStatusOutput)
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestPauseResumeRollbackStatus(c *gc.C) {
+	for _, test := range []struct {
+		command string
+		expect  func(string) *gomock.Call
+	}{{
+		command: machine.PauseCommand,
+		expect:  func(app string) *gomock.Call { return s.api.EXPECT().UpgradeSeriesApplicationPause(app) },
+	}, {
+		command: machine.ResumeCommand,
+		expect:  func(app string) *gomock.Call { return s.api.EXPECT().UpgradeSeriesApplicationResume(app) },
+	}, {
+		command: machine.RollbackCommand,
+		expect:  func(app string) *gomock.Call { return s.api.EXPECT().UpgradeSeriesApplicationRollback(app) },
+	}, {
+		command: machine.StatusCommand,
+		expect:  func(app string) *gomock.Call { return s.api.EXPECT().UpgradeSeriesApplicationStatus(app) },
+	}} {
+		c.Logf("testing %s", test.command)
+		ctx, err := s.run(c, "", func() {
+			test.expect(applicationArg).Return(upgradeSeriesApplication("preparing", "pending"), nil)
+		}, test.command, applicationArg, "--application")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(cmdtesting.Stdout(ctx), gc.Equals, applicationStatusOutput)
+	}
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestInvalidApplication(c *gc.C) {
+	_, err := s.run(c, "", nil, machine.StatusCommand, "1", "--application")
+	c.Assert(err, gc.ErrorMatches, `"1" is an invalid application name`)
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestApplicationCommandsNeedApplicationOption(c *gc.C) {
+	_, err := s.run(c, "", nil, machine.PauseCommand, machineArg)
+	c.Assert(err, gc.ErrorMatches,
+		`.* "pause" is an invalid upgrade-series command; valid commands are: prepare, complete.`)
+}
+
+func (s *UpgradeSeriesApplicationSuite) TestPauseDoesNotAcceptSeries(c *gc.C) {
+	_, err := s.run(c, "", nil, machine.PauseCommand, applicationArg, seriesArg, "--application")
+	c.Assert(err, gc.ErrorMatches, "wrong number of arguments")
+}
@@ -5,10 +5,13 @@ package model
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/api/bundle"
 	"github.com/juju/juju/cmd/modelcmd"
@@ -28,6 +31,7 @@ type exportBundleCommand struct {
 	out        cmd.Output
 	newAPIFunc func() (ExportBundleAPI, error)
 	Filename   string
+	Overlay    string
 }
 
 const exportBundleHelpDoc = `
@@ -36,10 +40,20 @@ Exports the current model configuration as a reusable bundle.
 If --filename is not used, the configuration is printed to stdout.
  --filename specifies an output file.
 
+If --overlay is used, the settings that usually differ between
+deployments of the same bundle are written to the given overlay file
+instead of the bundle. These are the application options, constraints,
+unit counts and placements, and the machines. The remaining bundle can
+then be shared between models, and deployed with the overlay to
+recreate this model:
+
+    juju deploy ./mymodel.yaml --overlay ./overlay.yaml
+
 Examples:
 
     juju export-bundle
-	juju export-bundle --filename mymodel.yaml
+    juju export-bundle --filename mymodel.yaml
+    juju export-bundle --filename mymodel.yaml --overlay overlay.yaml
 
 `
 
@@ -56,6 +70,7 @@ func (c *exportBundleCommand) Info() *cmd.Info {
 func (c *exportBundleCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "filename", "", "Bundle file")
+	f.StringVar(&c.Overlay, "overlay", "", "Overlay file for deployment specific settings")
 }
 
 // Init implements Command.
@@ -91,23 +106,100 @@ func (c *exportBundleCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
+	if c.Overlay != "" {
+		base, overlay, err := splitBundleOverlay(result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := writeLocalFile(ctx.AbsPath(c.Overlay), overlay); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Bundle overlay successfully exported to %s", c.Overlay)
+		result = base
+	}
+
 	if c.Filename == "" {
 		_, err := fmt.Fprintf(ctx.Stdout, "%v", result)
 		return err
 	}
 	filename := c.Filename
+	if err := writeLocalFile(filename, result); err != nil {
+		return errors.Trace(err)
+	}
+
+	fmt.Fprintln(ctx.Stdout, "Bundle successfully exported to", filename)
+
+	return nil
+}
+
+func writeLocalFile(filename, content string) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return errors.Annotate(err, "while creating local file")
 	}
 	defer file.Close()
 
-	_, err = file.WriteString(result)
+	_, err = file.WriteString(content)
 	if err != nil {
 		return errors.Annotate(err, "while copying in local file")
 	}
+	return nil
+}
 
-	fmt.Fprintln(ctx.Stdout, "Bundle successfully exported to", filename)
+// overlayApplication holds the application settings that are moved
+// into the overlay when a bundle is split.
+type overlayApplication struct {
+	NumUnits    int                    `yaml:"num_units,omitempty"`
+	To          []string               `yaml:"to,omitempty"`
+	Options     map[string]interface{} `yaml:"options,omitempty"`
+	Constraints string                 `yaml:"constraints,omitempty"`
+}
 
-	return nil
+type bundleOverlay struct {
+	Applications map[string]*overlayApplication `yaml:"applications,omitempty"`
+	Machines     map[string]*charm.MachineSpec  `yaml:"machines,omitempty"`
+}
+
+// splitBundleOverlay splits the exported bundle YAML into a base bundle
+// and an overlay holding the deployment specific settings. Applying the
+// overlay to the base bundle gives the original bundle.
+func splitBundleOverlay(bundleYAML string) (string, string, error) {
+	data, err := charm.ReadBundleData(strings.NewReader(bundleYAML))
+	if err != nil {
+		return "", "", errors.Annotate(err, "cannot read exported bundle")
+	}
+	overlay := bundleOverlay{
+		Applications: make(map[string]*overlayApplication),
+		Machines:     data.Machines,
+	}
+	for name, app := range data.Applications {
+		if app == nil {
+			continue
+		}
+		overlayApp := &overlayApplication{
+			NumUnits:    app.NumUnits,
+			To:          app.To,
+			Options:     app.Options,
+			Constraints: app.Constraints,
+		}
+		app.NumUnits = 0
+		app.To = nil
+		app.Options = nil
+		app.Constraints = ""
+		if overlayApp.NumUnits != 0 || len(overlayApp.To) != 0 ||
+			len(overlayApp.Options) != 0 || overlayApp.Constraints != "" {
+			overlay.Applications[name] = overlayApp
+		}
+	}
+	data.Machines = nil
+
+	base, err := yaml.Marshal(data)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	overlayYAML, err := yaml.Marshal(overlay)
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return string(base), string(overlayYAML), nil
 }
@@ -6,13 +6,17 @@ package model_test
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/cmd/cmdtesting"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/core/bundle"
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/testing"
@@ -169,6 +173,87 @@ func (s *ExportBundleCommandSuite) TestExportBundleSuccesssOverwriteFilename(c *
 	c.Assert(string(output), gc.Equals, "fake-data")
 }
 
+func (s *ExportBundleCommandSuite) TestExportBundleOverlay(c *gc.C) {
+	s.fake.result = "applications:\n" +
+		"  mysql:\n" +
+		"    charm: cs:xenial/mysql-57\n" +
+		"    num_units: 1\n" +
+		"    to:\n" +
+		"    - \"0\"\n" +
+		"    constraints: mem=4096M\n" +
+		"  wordpress:\n" +
+		"    charm: cs:xenial/wordpress-47\n" +
+		"    expose: true\n" +
+		"    num_units: 2\n" +
+		"    to:\n" +
+		"    - \"0\"\n" +
+		"    - \"1\"\n" +
+		"    options:\n" +
+		"      blog-title: staging\n" +
+		"machines:\n" +
+		"  \"0\": {}\n" +
+		"  \"1\":\n" +
+		"    constraints: cores=4\n" +
+		"series: xenial\n" +
+		"relations:\n" +
+		"- - wordpress:db\n" +
+		"  - mysql:mysql\n"
+	overlayFile := filepath.Join(c.MkDir(), "overlay.yaml")
+
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store), "--overlay", overlayFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Bundle overlay successfully exported to "+overlayFile+"\n")
+	base := cmdtesting.Stdout(ctx)
+	c.Assert(base, gc.Equals, ""+
+		"applications:\n"+
+		"  mysql:\n"+
+		"    charm: cs:xenial/mysql-57\n"+
+		"  wordpress:\n"+
+		"    charm: cs:xenial/wordpress-47\n"+
+		"    expose: true\n"+
+		"series: xenial\n"+
+		"relations:\n"+
+		"- - wordpress:db\n"+
+		"  - mysql:mysql\n")
+	overlay, err := ioutil.ReadFile(overlayFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(overlay), gc.Equals, ""+
+		"applications:\n"+
+		"  mysql:\n"+
+		"    num_units: 1\n"+
+		"    to:\n"+
+		"    - \"0\"\n"+
+		"    constraints: mem=4096M\n"+
+		"  wordpress:\n"+
+		"    num_units: 2\n"+
+		"    to:\n"+
+		"    - \"0\"\n"+
+		"    - \"1\"\n"+
+		"    options:\n"+
+		"      blog-title: staging\n"+
+		"machines:\n"+
+		"  \"0\": {}\n"+
+		"  \"1\":\n"+
+		"    constraints: cores=4\n")
+
+	// Applying the overlay to the base gives back the exported bundle.
+	expected, err := charm.ReadBundleData(strings.NewReader(s.fake.result))
+	c.Assert(err, jc.ErrorIsNil)
+	merged, err := charm.ReadBundleData(strings.NewReader(base))
+	c.Assert(err, jc.ErrorIsNil)
+	parsed, err := bundle.ParseOverlay(overlay)
+	c.Assert(err, jc.ErrorIsNil)
+	parsed.Apply(merged)
+	c.Assert(merged, jc.DeepEquals, expected)
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleOverlayBadBundle(c *gc.C) {
+	s.fake.result = "applications: 42\n"
+	overlayFile := filepath.Join(c.MkDir(), "overlay.yaml")
+	_, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store), "--overlay", overlayFile)
+	c.Assert(err, gc.ErrorMatches, "cannot read exported bundle: .*")
+}
+
 func (f *fakeExportBundleClient) Close() error { return nil }
 
 func (f *fakeExportBundleClient) ExportBundle() (string, error) {
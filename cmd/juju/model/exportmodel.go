@@ -0,0 +1,188 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+// NewExportModelCommand returns a fully constructed export-model command.
+func NewExportModelCommand() cmd.Command {
+	cmd := &exportModelCommand{}
+	cmd.newAPIFunc = cmd.newAPI
+	return modelcmd.Wrap(cmd)
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (ExportModelAPI, error)
+	Filename   string
+}
+
+const exportModelHelpDoc = `
+Exports the model to an archive file, which can be imported into
+another controller with import-model.
+
+The archive holds the database agnostic representation of the model
+(as shown by dump-model), along with the charms, resources and agent
+binaries that the model uses. The charm state that units keep on the
+controller with the state-set hook tool is not included, and neither
+are the model's secrets.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m othermodel othermodel.tar.gz
+
+See also:
+    import-model
+    dump-model
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "export-model",
+		Args:    "<filename>",
+		Purpose: "Exports the model to an archive file.",
+		Doc:     exportModelHelpDoc,
+	}
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no filename specified")
+	}
+	c.Filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ExportModelAPI specifies the API used by the export-model command.
+type ExportModelAPI interface {
+	Close() error
+	ExportModel(names.ModelTag) ([]byte, error)
+	ControllerVersion() (version.Number, error)
+	migration.CharmDownloader
+	migration.ToolsDownloader
+	migration.ResourceDownloader
+}
+
+// exportModelAPI combines the controller's ModelManager facade, which
+// serializes the model, with the model's client, which downloads the
+// binaries used by the model.
+type exportModelAPI struct {
+	*api.Client
+	modelManager *modelmanager.Client
+	modelRoot    api.Connection
+}
+
+func (c *exportModelCommand) newAPI() (ExportModelAPI, error) {
+	modelManager, err := c.NewModelManagerAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelRoot, err := c.NewAPIRoot()
+	if err != nil {
+		modelManager.Close()
+		return nil, errors.Trace(err)
+	}
+	return &exportModelAPI{
+		Client:       modelRoot.Client(),
+		modelManager: modelManager,
+		modelRoot:    modelRoot,
+	}, nil
+}
+
+// ExportModel is part of the ExportModelAPI interface.
+func (a *exportModelAPI) ExportModel(model names.ModelTag) ([]byte, error) {
+	return a.modelManager.ExportModel(model)
+}
+
+// ControllerVersion is part of the ExportModelAPI interface.
+func (a *exportModelAPI) ControllerVersion() (version.Number, error) {
+	v, ok := a.modelRoot.ServerVersion()
+	if !ok {
+		return version.Number{}, errors.New("controller version not known")
+	}
+	return v, nil
+}
+
+// Close is part of the ExportModelAPI interface.
+func (a *exportModelAPI) Close() error {
+	a.modelManager.Close()
+	return a.modelRoot.Close()
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	modelName, modelDetails, err := c.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+	bytes, err := client.ExportModel(names.NewModelTag(modelDetails.ModelUUID))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	model, err := migration.NewSerializedModel(bytes)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerVersion, err := client.ControllerVersion()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	filename := ctx.AbsPath(c.Filename)
+	if err := writeModelArchive(filename, migration.WriteModelArchiveConfig{
+		Metadata: migration.ModelArchiveMetadata{
+			ControllerAgentVersion: controllerVersion,
+			Exported:               time.Now().UTC(),
+		},
+		Model:              model,
+		CharmDownloader:    client,
+		ToolsDownloader:    client,
+		ResourceDownloader: client,
+	}); err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	fmt.Fprintf(ctx.Stdout, "Model %q exported to %s\n", modelName, c.Filename)
+	return nil
+}
+
+// writeModelArchive writes the model archive to the named file, which
+// is removed if the archive can't be written.
+func writeModelArchive(filename string, config migration.WriteModelArchiveConfig) (err error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+	return errors.Trace(migration.WriteModelArchive(file, config))
+}
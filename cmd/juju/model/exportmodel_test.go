@@ -0,0 +1,146 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  fakeExportModelClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+type fakeExportModelClient struct {
+	gitjujutesting.Stub
+	model []byte
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelClient) ExportModel(model names.ModelTag) ([]byte, error) {
+	f.MethodCall(f, "ExportModel", model)
+	return f.model, f.NextErr()
+}
+
+func (f *fakeExportModelClient) ControllerVersion() (version.Number, error) {
+	f.MethodCall(f, "ControllerVersion")
+	return version.MustParse("2.5.0"), f.NextErr()
+}
+
+func (f *fakeExportModelClient) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	return nil, errors.NotImplementedf("OpenCharm")
+}
+
+func (f *fakeExportModelClient) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenURI", uri, query)
+	return nil, errors.NotImplementedf("OpenURI")
+}
+
+func (f *fakeExportModelClient) OpenResource(app, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", app, name)
+	return nil, errors.NotImplementedf("OpenResource")
+}
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake.ResetCalls()
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+
+	s.fake.model, err = description.Serialize(description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name":          "mymodel",
+			"uuid":          testing.ModelTag.Id(),
+			"agent-version": "2.5.0",
+		},
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ExportModelCommandSuite) TestInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "no filename specified")
+
+	_, err = cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), "a", "b")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["b"\]`)
+}
+
+func (s *ExportModelCommandSuite) TestExportModel(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ExportModel", []interface{}{testing.ModelTag}},
+		{"ControllerVersion", nil},
+		{"Close", nil},
+	})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `Model "admin/mymodel" exported to `+filename+"\n")
+
+	file, err := os.Open(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	archive, err := migration.ReadModelArchive(file)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.Metadata.ControllerAgentVersion, gc.Equals, version.MustParse("2.5.0"))
+	c.Check(archive.Model.Bytes, jc.DeepEquals, s.fake.model)
+}
+
+func (s *ExportModelCommandSuite) TestExportModelError(c *gc.C) {
+	s.fake.SetErrors(errors.New("boom"))
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), filename)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	_, err = os.Stat(filename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *ExportModelCommandSuite) TestExportModelInvalidModel(c *gc.C) {
+	s.fake.model = []byte("not a model")
+	filename := filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.fake, s.store), filename)
+	c.Assert(err, gc.NotNil)
+
+	_, err = os.Stat(filename)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
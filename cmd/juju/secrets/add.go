@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewAddSecretCommand returns a command to add a secret to a model.
+func NewAddSecretCommand() cmd.Command {
+	return modelcmd.Wrap(&addSecretCommand{})
+}
+
+// addSecretCommand adds a secret to a model.
+type addSecretCommand struct {
+	secretsCommandBase
+	name        string
+	description string
+	file        cmd.FileVar
+	data        map[string]string
+}
+
+const addSecretDoc = `
+Add a secret to the model. A secret is a set of key=value pairs that
+applications can read with the secret-get hook tool once they have been
+granted access with 'juju grant-secret'.
+
+The values may be given as arguments, or in a yaml file of keys and
+values with the --file option, which keeps them out of the shell
+history. Values given as arguments override those in the file.
+
+Examples:
+
+    juju add-secret db-password password=s3cret
+    juju add-secret api-credentials --file creds.yaml --description "API credentials"
+
+See also:
+    secrets
+    show-secret
+    grant-secret
+`
+
+// SetFlags implements cmd.Command.
+func (c *addSecretCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	f.StringVar(&c.description, "description", "", "A description of the secret")
+	f.Var(&c.file, "file", "Path to a yaml file of secret keys and values")
+}
+
+// Info implements cmd.Command.
+func (c *addSecretCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "add-secret",
+		Args:    "<secret name> [<key>=<value> ...]",
+		Purpose: "Add a secret to the model.",
+		Doc:     addSecretDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *addSecretCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	c.name, args = args[0], args[1:]
+	c.data = make(map[string]string)
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return errors.Errorf("expected key=value, got %q", arg)
+		}
+		c.data[parts[0]] = parts[1]
+	}
+	if len(c.data) == 0 && c.file.Path == "" {
+		return errors.New("no secret values specified")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *addSecretCommand) Run(ctx *cmd.Context) error {
+	data := make(map[string]string)
+	if c.file.Path != "" {
+		content, err := c.file.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := yaml.Unmarshal(content, &data); err != nil {
+			return errors.Annotatef(err, "cannot parse %q", c.file.Path)
+		}
+	}
+	for key, value := range c.data {
+		data[key] = value
+	}
+
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.AddSecret(params.AddSecretArg{
+		Name:        c.name,
+		Description: c.description,
+		Data:        data,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Added secret %q", c.name)
+	return nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type addSecretSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&addSecretSuite{})
+
+func (s *addSecretSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSecretsAPI{}
+}
+
+func (s *addSecretSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret name specified",
+	}, {
+		args: []string{"password"},
+		err:  "no secret values specified",
+	}, {
+		args: []string{"password", "foo"},
+		err:  `expected key=value, got "foo"`,
+	}, {
+		args: []string{"password", "=bar"},
+		err:  `expected key=value, got "=bar"`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secrets.NewAddSecretCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *addSecretSuite) TestAddSecret(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewAddSecretCommandForTest(s.api),
+		"db-password", "password=s3cr=t", "user=admin", "--description", "the db password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Added secret \"db-password\"\n")
+	s.api.CheckCallNames(c, "AddSecret", "Close")
+	s.api.CheckCall(c, 0, "AddSecret", params.AddSecretArg{
+		Name:        "db-password",
+		Description: "the db password",
+		Data:        map[string]string{"password": "s3cr=t", "user": "admin"},
+	})
+}
+
+func (s *addSecretSuite) TestAddSecretFromFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "creds.yaml")
+	err := ioutil.WriteFile(path, []byte("key: abc\nuser: admin\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = cmdtesting.RunCommand(c, secrets.NewAddSecretCommandForTest(s.api),
+		"api-credentials", "--file", path, "user=root")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "AddSecret", params.AddSecretArg{
+		Name: "api-credentials",
+		Data: map[string]string{"key": "abc", "user": "root"},
+	})
+}
+
+func (s *addSecretSuite) TestAddSecretError(c *gc.C) {
+	s.api.SetErrors(errors.AlreadyExistsf(`secret "password"`))
+	_, err := cmdtesting.RunCommand(c, secrets.NewAddSecretCommandForTest(s.api), "password", "a=b")
+	c.Assert(err, gc.ErrorMatches, `secret "password" already exists`)
+}
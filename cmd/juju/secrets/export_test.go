@@ -0,0 +1,41 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+func newCommandBaseForTest(api SecretsAPI) secretsCommandBase {
+	base := secretsCommandBase{
+		newAPIFunc: func() (SecretsAPI, error) {
+			return api, nil
+		},
+	}
+	base.SetClientStore(jujuclienttesting.MinimalStore())
+	return base
+}
+
+func NewAddSecretCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&addSecretCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+func NewListSecretsCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&listSecretsCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+func NewShowSecretCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&showSecretCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+func NewGrantSecretCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&grantSecretCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
+
+func NewRevokeSecretCommandForTest(api SecretsAPI) cmd.Command {
+	return modelcmd.Wrap(&revokeSecretCommand{secretsCommandBase: newCommandBaseForTest(api)})
+}
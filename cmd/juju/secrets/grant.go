@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewGrantSecretCommand returns a command to allow an application to
+// read a secret.
+func NewGrantSecretCommand() cmd.Command {
+	return modelcmd.Wrap(&grantSecretCommand{})
+}
+
+// grantSecretCommand allows an application to read a secret.
+type grantSecretCommand struct {
+	secretsCommandBase
+	name        string
+	application string
+}
+
+const grantSecretDoc = `
+Allow the units of an application to read a secret with the secret-get
+hook tool. The application that owns a secret can always read it. Access
+is removed when the application is removed, or with revoke-secret.
+
+Granting access to a secret needs admin access to the model.
+
+Examples:
+
+    juju grant-secret db-password wordpress
+
+See also:
+    add-secret
+    revoke-secret
+    show-secret
+`
+
+// Info implements cmd.Command.
+func (c *grantSecretCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "grant-secret",
+		Args:    "<secret name> <application>",
+		Purpose: "Allow an application to read a secret.",
+		Doc:     grantSecretDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *grantSecretCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no secret name specified")
+	case 1:
+		return errors.New("no application specified")
+	}
+	c.name, c.application = args[0], args[1]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *grantSecretCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.GrantSecret(c.name, c.application); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Granted %q access to secret %q", c.application, c.name)
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type grantSecretSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&grantSecretSuite{})
+
+func (s *grantSecretSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSecretsAPI{}
+}
+
+func (s *grantSecretSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret name specified",
+	}, {
+		args: []string{"password"},
+		err:  "no application specified",
+	}, {
+		args: []string{"password", "wordpress/0"},
+		err:  `application name "wordpress/0" not valid`,
+	}, {
+		args: []string{"password", "wordpress", "mysql"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secrets.NewGrantSecretCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *grantSecretSuite) TestGrantSecret(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewGrantSecretCommandForTest(s.api), "password", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Granted \"wordpress\" access to secret \"password\"\n")
+	s.api.CheckCallNames(c, "GrantSecret", "Close")
+	s.api.CheckCall(c, 0, "GrantSecret", "password", "wordpress")
+}
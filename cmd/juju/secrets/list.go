@@ -0,0 +1,162 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewListSecretsCommand returns a command to list the secrets in a
+// model.
+func NewListSecretsCommand() cmd.Command {
+	return modelcmd.Wrap(&listSecretsCommand{})
+}
+
+// listSecretsCommand lists the secrets in a model.
+type listSecretsCommand struct {
+	secretsCommandBase
+	out cmd.Output
+}
+
+const listSecretsDoc = `
+List the secrets in the model, with the application that owns each one
+and the applications that have been granted access to it. Secret values
+are never listed; use 'juju show-secret --reveal' to see them. All times
+are in UTC.
+
+See also:
+    add-secret
+    show-secret
+    grant-secret
+`
+
+// SetFlags implements cmd.Command.
+func (c *listSecretsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.printTabular,
+	})
+}
+
+// Info implements cmd.Command.
+func (c *listSecretsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "secrets",
+		Purpose: "List secrets.",
+		Doc:     listSecretsDoc,
+		Aliases: []string{"list-secrets"},
+	}
+}
+
+// Init implements cmd.Command.
+func (c *listSecretsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// secretOutput is the yaml and json output for a secret.
+type secretOutput struct {
+	Description    string            `yaml:"description,omitempty" json:"description,omitempty"`
+	Owner          string            `yaml:"owner,omitempty" json:"owner,omitempty"`
+	Revision       int               `yaml:"revision" json:"revision"`
+	RotateInterval string            `yaml:"rotate-interval,omitempty" json:"rotate-interval,omitempty"`
+	NextRotateTime string            `yaml:"next-rotate-time,omitempty" json:"next-rotate-time,omitempty"`
+	Grants         []string          `yaml:"grants,omitempty" json:"grants,omitempty"`
+	Created        string            `yaml:"created" json:"created"`
+	Updated        string            `yaml:"updated" json:"updated"`
+	Revisions      map[int]string    `yaml:"revisions,omitempty" json:"revisions,omitempty"`
+	Value          map[string]string `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+func toOutput(secret params.Secret) secretOutput {
+	out := secretOutput{
+		Description: secret.Description,
+		Owner:       secret.Owner,
+		Revision:    secret.Revision,
+		Grants:      secret.Grants,
+		Created:     formatSecretTime(secret.Created),
+		Updated:     formatSecretTime(secret.Updated),
+		Value:       secret.Value,
+	}
+	if secret.RotateInterval > 0 {
+		out.RotateInterval = secret.RotateInterval.String()
+	}
+	if secret.NextRotateTime != nil {
+		out.NextRotateTime = formatSecretTime(*secret.NextRotateTime)
+	}
+	if len(secret.Revisions) > 0 {
+		out.Revisions = make(map[int]string, len(secret.Revisions))
+		for _, revision := range secret.Revisions {
+			out.Revisions[revision.Revision] = formatSecretTime(revision.Created)
+		}
+	}
+	return out
+}
+
+// Run implements cmd.Command.
+func (c *listSecretsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	secrets, err := client.ListSecrets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.out.Name() == "tabular" {
+		if len(secrets) == 0 {
+			ctx.Infof("No secrets in the model.")
+			return nil
+		}
+		return c.out.Write(ctx, secrets)
+	}
+	result := make(map[string]secretOutput, len(secrets))
+	for _, secret := range secrets {
+		result[secret.Name] = toOutput(secret)
+	}
+	return c.out.Write(ctx, result)
+}
+
+// printTabular prints each secret with its owner, latest revision,
+// rotation interval and grants.
+func (c *listSecretsCommand) printTabular(writer io.Writer, value interface{}) error {
+	secrets, ok := value.([]params.Secret)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", secrets, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+		"Name", "Owner", "Revision", "Rotate", "Granted to", "Updated")
+	for _, secret := range secrets {
+		owner, rotate := secret.Owner, ""
+		if owner == "" {
+			owner = "-"
+		}
+		if secret.RotateInterval > 0 {
+			rotate = secret.RotateInterval.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			secret.Name,
+			owner,
+			secret.Revision,
+			rotate,
+			strings.Join(secret.Grants, ","),
+			formatSecretTime(secret.Updated),
+		)
+	}
+	return tw.Flush()
+}
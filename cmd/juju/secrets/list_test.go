@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type listSecretsSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&listSecretsSuite{})
+
+func (s *listSecretsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	next := created.Add(24 * time.Hour)
+	s.api = &fakeSecretsAPI{
+		secrets: []params.Secret{{
+			Name:     "api-key",
+			Revision: 1,
+			Grants:   []string{"wordpress", "haproxy"},
+			Created:  created,
+			Updated:  created,
+		}, {
+			Name:           "db-password",
+			Owner:          "mysql",
+			Revision:       3,
+			RotateInterval: 24 * time.Hour,
+			NextRotateTime: &next,
+			Created:        created,
+			Updated:        created,
+		}},
+	}
+}
+
+func (s *listSecretsSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Name         Owner  Revision  Rotate   Granted to         Updated
+api-key      -      1                  wordpress,haproxy  2018-10-01 12:00:00
+db-password  mysql  3         24h0m0s                     2018-10-01 12:00:00
+`[1:])
+	s.api.CheckCallNames(c, "ListSecrets", "Close")
+}
+
+func (s *listSecretsSuite) TestListYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+api-key:
+  revision: 1
+  grants:
+  - wordpress
+  - haproxy
+  created: "2018-10-01 12:00:00"
+  updated: "2018-10-01 12:00:00"
+db-password:
+  owner: mysql
+  revision: 3
+  rotate-interval: 24h0m0s
+  next-rotate-time: "2018-10-02 12:00:00"
+  created: "2018-10-01 12:00:00"
+  updated: "2018-10-01 12:00:00"
+`[1:])
+}
+
+func (s *listSecretsSuite) TestListEmpty(c *gc.C) {
+	s.api.secrets = nil
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewListSecretsCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No secrets in the model.\n")
+}
@@ -0,0 +1,55 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	stdtesting "testing"
+
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func TestAll(t *stdtesting.T) {
+	gc.TestingT(t)
+}
+
+type fakeSecretsAPI struct {
+	testing.Stub
+	secrets []params.Secret
+}
+
+func (f *fakeSecretsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeSecretsAPI) AddSecret(arg params.AddSecretArg) error {
+	f.MethodCall(f, "AddSecret", arg)
+	return f.NextErr()
+}
+
+func (f *fakeSecretsAPI) ListSecrets() ([]params.Secret, error) {
+	f.MethodCall(f, "ListSecrets")
+	return f.secrets, f.NextErr()
+}
+
+func (f *fakeSecretsAPI) ShowSecret(name string, revision int, reveal bool) (*params.Secret, error) {
+	f.MethodCall(f, "ShowSecret", name, revision, reveal)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return &f.secrets[0], nil
+}
+
+func (f *fakeSecretsAPI) GrantSecret(name, appName string) error {
+	f.MethodCall(f, "GrantSecret", name, appName)
+	return f.NextErr()
+}
+
+func (f *fakeSecretsAPI) RevokeSecret(name, appName string) error {
+	f.MethodCall(f, "RevokeSecret", name, appName)
+	return f.NextErr()
+}
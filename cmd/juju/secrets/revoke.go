@@ -0,0 +1,81 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRevokeSecretCommand returns a command to stop an application
+// from reading a secret.
+func NewRevokeSecretCommand() cmd.Command {
+	return modelcmd.Wrap(&revokeSecretCommand{})
+}
+
+// revokeSecretCommand stops an application from reading a secret.
+type revokeSecretCommand struct {
+	secretsCommandBase
+	name        string
+	application string
+}
+
+const revokeSecretDoc = `
+Stop the units of an application from reading a secret that it was
+granted access to with grant-secret. The application that owns a secret
+can't have its access revoked.
+
+Revoking access to a secret needs admin access to the model.
+
+Examples:
+
+    juju revoke-secret db-password wordpress
+
+See also:
+    grant-secret
+    show-secret
+`
+
+// Info implements cmd.Command.
+func (c *revokeSecretCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "revoke-secret",
+		Args:    "<secret name> <application>",
+		Purpose: "Stop an application from reading a secret.",
+		Doc:     revokeSecretDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *revokeSecretCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no secret name specified")
+	case 1:
+		return errors.New("no application specified")
+	}
+	c.name, c.application = args[0], args[1]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements cmd.Command.
+func (c *revokeSecretCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.RevokeSecret(c.name, c.application); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Revoked %q access to secret %q", c.application, c.name)
+	return nil
+}
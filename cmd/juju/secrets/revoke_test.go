@@ -0,0 +1,63 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type revokeSecretSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&revokeSecretSuite{})
+
+func (s *revokeSecretSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSecretsAPI{}
+}
+
+func (s *revokeSecretSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret name specified",
+	}, {
+		args: []string{"password"},
+		err:  "no application specified",
+	}, {
+		args: []string{"password", "wordpress/0"},
+		err:  `application name "wordpress/0" not valid`,
+	}, {
+		args: []string{"password", "wordpress", "mysql"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secrets.NewRevokeSecretCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *revokeSecretSuite) TestRevokeSecret(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewRevokeSecretCommandForTest(s.api), "password", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "Revoked \"wordpress\" access to secret \"password\"\n")
+	s.api.CheckCallNames(c, "RevokeSecret", "Close")
+	s.api.CheckCall(c, 0, "RevokeSecret", "password", "wordpress")
+}
+
+func (s *revokeSecretSuite) TestRevokeSecretError(c *gc.C) {
+	s.api.SetErrors(errors.New(`cannot revoke secret "password" from its owner`))
+	_, err := cmdtesting.RunCommand(c, secrets.NewRevokeSecretCommandForTest(s.api), "password", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `cannot revoke secret "password" from its owner`)
+}
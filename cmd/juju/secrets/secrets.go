@@ -0,0 +1,47 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the commands that manage the secrets that
+// applications in a model are granted access to.
+package secrets
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/secrets"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// SecretsAPI provides access to the secrets in a model.
+type SecretsAPI interface {
+	Close() error
+	AddSecret(params.AddSecretArg) error
+	ListSecrets() ([]params.Secret, error)
+	ShowSecret(name string, revision int, reveal bool) (*params.Secret, error)
+	GrantSecret(name, appName string) error
+	RevokeSecret(name, appName string) error
+}
+
+// secretsCommandBase is the base type of the secrets commands.
+type secretsCommandBase struct {
+	modelcmd.ModelCommandBase
+	newAPIFunc func() (SecretsAPI, error)
+}
+
+func (c *secretsCommandBase) newAPI() (SecretsAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return secrets.NewClient(root), nil
+}
+
+func formatSecretTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
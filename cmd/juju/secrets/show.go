@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewShowSecretCommand returns a command to show the details of a
+// secret.
+func NewShowSecretCommand() cmd.Command {
+	return modelcmd.Wrap(&showSecretCommand{})
+}
+
+// showSecretCommand shows the details of a secret.
+type showSecretCommand struct {
+	secretsCommandBase
+	out      cmd.Output
+	name     string
+	revision int
+	reveal   bool
+}
+
+const showSecretDoc = `
+Show the details of a secret, including when each of its revisions was
+created. The values of the secret are only shown with the --reveal
+option, which needs admin access to the model. --revision selects the
+revision whose values are shown; the latest revision is shown by
+default.
+
+Examples:
+
+    juju show-secret db-password
+    juju show-secret db-password --reveal --revision 2
+
+See also:
+    secrets
+    add-secret
+`
+
+// SetFlags implements cmd.Command.
+func (c *showSecretCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.secretsCommandBase.SetFlags(f)
+	f.IntVar(&c.revision, "revision", 0, "The revision whose values are revealed")
+	f.BoolVar(&c.reveal, "reveal", false, "Show the values of the secret")
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Info implements cmd.Command.
+func (c *showSecretCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-secret",
+		Args:    "<secret name>",
+		Purpose: "Show the details of a secret.",
+		Doc:     showSecretDoc,
+	}
+}
+
+// Init implements cmd.Command.
+func (c *showSecretCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	if c.revision < 0 {
+		return errors.NotValidf("negative revision")
+	}
+	if c.revision > 0 && !c.reveal {
+		return errors.New("--revision needs --reveal")
+	}
+	c.name = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *showSecretCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	secret, err := client.ShowSecret(c.name, c.revision, c.reveal)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, map[string]secretOutput{
+		secret.Name: toOutput(*secret),
+	})
+}
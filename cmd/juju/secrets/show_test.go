@@ -0,0 +1,90 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/secrets"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type showSecretSuite struct {
+	coretesting.FakeJujuXDGDataHomeSuite
+	api *fakeSecretsAPI
+}
+
+var _ = gc.Suite(&showSecretSuite{})
+
+func (s *showSecretSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	created := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeSecretsAPI{
+		secrets: []params.Secret{{
+			Name:        "api-key",
+			Description: "the api key",
+			Revision:    2,
+			Created:     created,
+			Updated:     created.Add(time.Hour),
+			Revisions: []params.SecretRevision{
+				{Revision: 1, Created: created},
+				{Revision: 2, Created: created.Add(time.Hour)},
+			},
+		}},
+	}
+}
+
+func (s *showSecretSuite) TestInit(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no secret name specified",
+	}, {
+		args: []string{"api-key", "--revision", "1"},
+		err:  "--revision needs --reveal",
+	}, {
+		args: []string{"api-key", "other"},
+		err:  `unrecognized args: \["other"\]`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, secrets.NewShowSecretCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *showSecretSuite) TestShowSecret(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowSecretCommandForTest(s.api), "api-key")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+api-key:
+  description: the api key
+  revision: 2
+  created: "2018-10-01 12:00:00"
+  updated: "2018-10-01 13:00:00"
+  revisions:
+    1: "2018-10-01 12:00:00"
+    2: "2018-10-01 13:00:00"
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"ShowSecret", []interface{}{"api-key", 0, false}},
+		{"Close", nil},
+	})
+}
+
+func (s *showSecretSuite) TestShowSecretReveal(c *gc.C) {
+	s.api.secrets[0].Value = map[string]string{"key": "abc"}
+	ctx, err := cmdtesting.RunCommand(c, secrets.NewShowSecretCommandForTest(s.api),
+		"api-key", "--reveal", "--revision", "1", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, `"value":{"key":"abc"}`)
+	s.api.CheckCall(c, 0, "ShowSecret", "api-key", 1, true)
+}
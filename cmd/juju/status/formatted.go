@@ -71,6 +71,7 @@ type machineStatus struct {
 	Containers        map[string]machineStatus    `json:"containers,omitempty" yaml:"containers,omitempty"`
 	Constraints       string                      `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 	Hardware          string                      `json:"hardware,omitempty" yaml:"hardware,omitempty"`
+	LXDProfiles       []string                    `json:"lxd-profiles,omitempty" yaml:"lxd-profiles,omitempty"`
 	HAStatus          string                      `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
 }
 
@@ -113,6 +114,26 @@ type applicationStatus struct {
 	Units            map[string]unitStatus `json:"units,omitempty" yaml:"units,omitempty"`
 	Version          string                `json:"version,omitempty" yaml:"version,omitempty"`
 	EndpointBindings map[string]string     `json:"endpoint-bindings,omitempty" yaml:"endpoint-bindings,omitempty"`
+	RollingUpgrade   *rollingUpgradeStatus `json:"rolling-upgrade,omitempty" yaml:"rolling-upgrade,omitempty"`
+	Autoscale        *autoscaleStatus      `json:"autoscale,omitempty" yaml:"autoscale,omitempty"`
+	LeadershipPinned bool                  `json:"leadership-pinned,omitempty" yaml:"leadership-pinned,omitempty"`
+}
+
+type autoscaleStatus struct {
+	MinUnits         int    `json:"min-units" yaml:"min-units"`
+	MaxUnits         int    `json:"max-units" yaml:"max-units"`
+	TargetCPUPercent int    `json:"target-cpu-percent,omitempty" yaml:"target-cpu-percent,omitempty"`
+	Metric           string `json:"metric,omitempty" yaml:"metric,omitempty"`
+	MetricTarget     string `json:"metric-target,omitempty" yaml:"metric-target,omitempty"`
+}
+
+type rollingUpgradeStatus struct {
+	Charm     string `json:"charm" yaml:"charm"`
+	Status    string `json:"status" yaml:"status"`
+	Message   string `json:"message,omitempty" yaml:"message,omitempty"`
+	BatchSize int    `json:"batch-size" yaml:"batch-size"`
+	Released  int    `json:"released" yaml:"released"`
+	Units     int    `json:"units" yaml:"units"`
 }
 
 type applicationStatusNoMarshal applicationStatus
@@ -143,6 +143,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Containers:        make(map[string]machineStatus),
 		Constraints:       machine.Constraints,
 		Hardware:          machine.Hardware,
+		LXDProfiles:       machine.LXDProfiles,
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -220,6 +221,26 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		StatusInfo:       sf.getApplicationStatusInfo(application),
 		Version:          application.WorkloadVersion,
 		EndpointBindings: application.EndpointBindings,
+		LeadershipPinned: application.LeadershipPinned,
+	}
+	if upgrade := application.RollingUpgrade; upgrade != nil {
+		out.RollingUpgrade = &rollingUpgradeStatus{
+			Charm:     upgrade.CharmURL,
+			Status:    upgrade.Status,
+			Message:   upgrade.Message,
+			BatchSize: upgrade.BatchSize,
+			Released:  upgrade.Released,
+			Units:     upgrade.Units,
+		}
+	}
+	if policy := application.Autoscale; policy != nil {
+		out.Autoscale = &autoscaleStatus{
+			MinUnits:         policy.MinUnits,
+			MaxUnits:         policy.MaxUnits,
+			TargetCPUPercent: policy.TargetCPUPercent,
+			Metric:           policy.Metric,
+			MetricTarget:     policy.MetricTarget,
+		}
 	}
 	for k, m := range application.Units {
 		out.Units[k] = sf.formatUnit(unitFormatInfo{
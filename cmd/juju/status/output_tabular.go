@@ -142,10 +142,22 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 		if len(version) > maxVersionWidth {
 			version = version[:truncatedWidth] + ellipsis
 		}
-		// Notes may well contain other things later.
-		notes := ""
+		var notes []string
 		if app.Exposed {
-			notes = "exposed"
+			notes = append(notes, "exposed")
+		}
+		if upgrade := app.RollingUpgrade; upgrade != nil {
+			if upgrade.Status == "running" {
+				notes = append(notes, fmt.Sprintf("upgrading %d/%d", upgrade.Released, upgrade.Units))
+			} else {
+				notes = append(notes, "upgrade "+upgrade.Status)
+			}
+		}
+		if policy := app.Autoscale; policy != nil {
+			notes = append(notes, fmt.Sprintf("autoscaled %d-%d", policy.MinUnits, policy.MaxUnits))
+		}
+		if app.LeadershipPinned {
+			notes = append(notes, "leadership pinned")
 		}
 		w.Print(appName, version)
 		w.PrintStatus(app.StatusInfo.Current)
@@ -171,7 +183,7 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 			w.Print(charmVersion)
 		}
 
-		w.Println(notes)
+		w.Println(strings.Join(notes, ", "))
 		for un, u := range app.Units {
 			units[un] = u
 			if u.MeterStatus != nil {
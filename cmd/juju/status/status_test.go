@@ -4771,6 +4771,90 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularRollingUpgrade(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Exposed: true,
+				RollingUpgrade: &rollingUpgradeStatus{
+					Charm:     "cs:quantal/foo-2",
+					Status:    "running",
+					BatchSize: 1,
+					Released:  1,
+					Units:     2,
+				},
+			},
+			"bar": {
+				RollingUpgrade: &rollingUpgradeStatus{
+					Charm:     "cs:quantal/bar-2",
+					Status:    "paused",
+					Message:   "bar/0 is in error: hook failed",
+					BatchSize: 1,
+					Released:  1,
+					Units:     2,
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Charm version  Notes
+bar                       0                  0                     upgrade paused
+foo                       0                  0                     exposed, upgrading 1/2
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatTabularAutoscale(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Exposed: true,
+				Autoscale: &autoscaleStatus{
+					MinUnits:         2,
+					MaxUnits:         5,
+					TargetCPUPercent: 80,
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Charm version  Notes
+foo                       0                  0                     exposed, autoscaled 2-5
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatTabularLeadershipPinned(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Exposed:          true,
+				LeadershipPinned: true,
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := FormatTabular(out, false, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Charm version  Notes
+foo                       0                  0                     exposed, leadership pinned
+`[1:])
+}
+
 func (s *StatusSuite) TestFormatTabularHookActionName(c *gc.C) {
 	status := formattedStatus{
 		Applications: map[string]applicationStatus{
@@ -0,0 +1,38 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// NewWaitForCommandForTest returns a wait-for command using the
+// supplied API and clock.
+func NewWaitForCommandForTest(api WaitForAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &waitForCommand{
+		newAPIFunc: func() (WaitForAPI, error) {
+			return api, nil
+		},
+		clock: clock,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// Unmet parses the query args, applies the deltas and returns the
+// description of the first unmet condition.
+func Unmet(args []string, deltas []multiwatcher.Delta) (string, error) {
+	q, err := parseQuery(args)
+	if err != nil {
+		return "", err
+	}
+	store := newEntityStore()
+	store.apply(deltas)
+	return q.unmet(store), nil
+}
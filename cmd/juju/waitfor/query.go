@@ -0,0 +1,277 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+const (
+	kindApplication = "application"
+	kindUnit        = "unit"
+	kindMachine     = "machine"
+)
+
+// validKeys holds the condition keys understood for each kind of
+// entity that can be waited for.
+var validKeys = map[string]set.Strings{
+	kindApplication: set.NewStrings("status", "life", "exposed", "workload", "agent", "units"),
+	kindUnit:        set.NewStrings("workload", "agent"),
+	kindMachine:     set.NewStrings("agent", "instance", "life"),
+}
+
+// defaultConditions are used when no conditions are supplied on the
+// command line.
+var defaultConditions = map[string][]condition{
+	kindApplication: {{"workload", "active"}, {"agent", "idle"}},
+	kindUnit:        {{"workload", "active"}, {"agent", "idle"}},
+	kindMachine:     {{"agent", "started"}},
+}
+
+// condition is a single key=value requirement on an entity.
+type condition struct {
+	key   string
+	value string
+}
+
+// query describes the state an entity in the model must reach.
+type query struct {
+	kind       string
+	name       string
+	conditions []condition
+}
+
+// parseQuery parses the positional arguments of wait-for into a query.
+// The expected form is <kind> <name> [<key>=<value>...].
+func parseQuery(args []string) (query, error) {
+	if len(args) == 0 {
+		return query{}, errors.New("no entity kind specified")
+	}
+	q := query{kind: args[0]}
+	keys, ok := validKeys[q.kind]
+	if !ok {
+		return query{}, errors.Errorf("invalid entity kind %q, expected one of application, unit or machine", q.kind)
+	}
+	if len(args) == 1 {
+		return query{}, errors.Errorf("no %s name specified", q.kind)
+	}
+	q.name = args[1]
+	if err := validateName(q.kind, q.name); err != nil {
+		return query{}, errors.Trace(err)
+	}
+	for _, arg := range args[2:] {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return query{}, errors.Errorf("invalid condition %q, expected <key>=<value>", arg)
+		}
+		if !keys.Contains(parts[0]) {
+			return query{}, errors.Errorf(
+				"invalid condition key %q for %s, expected one of %s",
+				parts[0], q.kind, strings.Join(keys.SortedValues(), ", "),
+			)
+		}
+		if parts[0] == "units" {
+			if n, err := strconv.Atoi(parts[1]); err != nil || n < 0 {
+				return query{}, errors.Errorf("invalid unit count %q", parts[1])
+			}
+		}
+		q.conditions = append(q.conditions, condition{key: parts[0], value: parts[1]})
+	}
+	if len(q.conditions) == 0 {
+		q.conditions = defaultConditions[q.kind]
+	}
+	return q, nil
+}
+
+func validateName(kind, name string) error {
+	var valid bool
+	switch kind {
+	case kindApplication:
+		valid = names.IsValidApplication(name)
+	case kindUnit:
+		valid = names.IsValidUnit(name)
+	case kindMachine:
+		valid = names.IsValidMachine(name)
+	}
+	if !valid {
+		return errors.NotValidf("%s name %q", kind, name)
+	}
+	return nil
+}
+
+// String returns a human readable description of the query.
+func (q query) String() string {
+	conds := make([]string, len(q.conditions))
+	for i, cond := range q.conditions {
+		conds[i] = cond.key + "=" + cond.value
+	}
+	return fmt.Sprintf("%s %q (%s)", q.kind, q.name, strings.Join(conds, " "))
+}
+
+// unmet returns a description of the first condition of the query
+// that does not hold for the entities in the store. An empty string is
+// returned when the query is satisfied.
+func (q query) unmet(store *entityStore) string {
+	switch q.kind {
+	case kindApplication:
+		return q.unmetApplication(store)
+	case kindUnit:
+		return q.unmetUnit(store)
+	case kindMachine:
+		return q.unmetMachine(store)
+	}
+	return fmt.Sprintf("unknown entity kind %q", q.kind)
+}
+
+func (q query) unmetApplication(store *entityStore) string {
+	app, ok := store.applications[q.name]
+	if !ok {
+		return fmt.Sprintf("application %q not found", q.name)
+	}
+	units := store.unitsOf(q.name)
+	for _, cond := range q.conditions {
+		var reason string
+		switch cond.key {
+		case "status":
+			reason = compare("application "+q.name+" status", string(app.Status.Current), cond.value)
+		case "life":
+			reason = compare("application "+q.name+" life", string(app.Life), cond.value)
+		case "exposed":
+			reason = compare("application "+q.name+" exposed", strconv.FormatBool(app.Exposed), cond.value)
+		case "units":
+			reason = compare("application "+q.name+" unit count", strconv.Itoa(len(units)), cond.value)
+		case "workload", "agent":
+			if len(units) == 0 {
+				return fmt.Sprintf("application %q has no units", q.name)
+			}
+			for _, unit := range units {
+				if reason = unmetUnitCondition(unit, cond); reason != "" {
+					break
+				}
+			}
+		}
+		if reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func (q query) unmetUnit(store *entityStore) string {
+	unit, ok := store.units[q.name]
+	if !ok {
+		return fmt.Sprintf("unit %q not found", q.name)
+	}
+	for _, cond := range q.conditions {
+		if reason := unmetUnitCondition(unit, cond); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func unmetUnitCondition(unit *multiwatcher.UnitInfo, cond condition) string {
+	switch cond.key {
+	case "workload":
+		return compare("unit "+unit.Name+" workload status", string(unit.WorkloadStatus.Current), cond.value)
+	case "agent":
+		return compare("unit "+unit.Name+" agent status", string(unit.AgentStatus.Current), cond.value)
+	}
+	return ""
+}
+
+func (q query) unmetMachine(store *entityStore) string {
+	machine, ok := store.machines[q.name]
+	if !ok {
+		return fmt.Sprintf("machine %q not found", q.name)
+	}
+	for _, cond := range q.conditions {
+		var reason string
+		switch cond.key {
+		case "agent":
+			reason = compare("machine "+q.name+" agent status", string(machine.AgentStatus.Current), cond.value)
+		case "instance":
+			reason = compare("machine "+q.name+" instance status", string(machine.InstanceStatus.Current), cond.value)
+		case "life":
+			reason = compare("machine "+q.name+" life", string(machine.Life), cond.value)
+		}
+		if reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+func compare(what, actual, expected string) string {
+	if actual == expected {
+		return ""
+	}
+	return fmt.Sprintf("%s is %q, want %q", what, actual, expected)
+}
+
+// entityStore holds the latest known state of the applications, units
+// and machines in the model, as reported by the all watcher.
+type entityStore struct {
+	applications map[string]*multiwatcher.ApplicationInfo
+	units        map[string]*multiwatcher.UnitInfo
+	machines     map[string]*multiwatcher.MachineInfo
+}
+
+func newEntityStore() *entityStore {
+	return &entityStore{
+		applications: make(map[string]*multiwatcher.ApplicationInfo),
+		units:        make(map[string]*multiwatcher.UnitInfo),
+		machines:     make(map[string]*multiwatcher.MachineInfo),
+	}
+}
+
+// apply updates the store with the supplied deltas. Deltas for entity
+// kinds the store does not track are ignored.
+func (s *entityStore) apply(deltas []multiwatcher.Delta) {
+	for _, delta := range deltas {
+		switch info := delta.Entity.(type) {
+		case *multiwatcher.ApplicationInfo:
+			if delta.Removed {
+				delete(s.applications, info.Name)
+			} else {
+				s.applications[info.Name] = info
+			}
+		case *multiwatcher.UnitInfo:
+			if delta.Removed {
+				delete(s.units, info.Name)
+			} else {
+				s.units[info.Name] = info
+			}
+		case *multiwatcher.MachineInfo:
+			if delta.Removed {
+				delete(s.machines, info.Id)
+			} else {
+				s.machines[info.Id] = info
+			}
+		}
+	}
+}
+
+// unitsOf returns the units of the named application, ordered by name.
+func (s *entityStore) unitsOf(application string) []*multiwatcher.UnitInfo {
+	var units []*multiwatcher.UnitInfo
+	for _, unit := range s.units {
+		if unit.Application == application {
+			units = append(units, unit)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name < units[j].Name
+	})
+	return units
+}
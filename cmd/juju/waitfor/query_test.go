@@ -0,0 +1,138 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+type querySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&querySuite{})
+
+func applicationDelta(name string, current status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.ApplicationInfo{
+		Name:   name,
+		Life:   "alive",
+		Status: multiwatcher.StatusInfo{Current: current},
+	}}
+}
+
+func unitDelta(name, app string, workload, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.UnitInfo{
+		Name:           name,
+		Application:    app,
+		WorkloadStatus: multiwatcher.StatusInfo{Current: workload},
+		AgentStatus:    multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func machineDelta(id string, agent status.Status) multiwatcher.Delta {
+	return multiwatcher.Delta{Entity: &multiwatcher.MachineInfo{
+		Id:          id,
+		Life:        "alive",
+		AgentStatus: multiwatcher.StatusInfo{Current: agent},
+	}}
+}
+
+func (s *querySuite) TestInvalidQueries(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no entity kind specified",
+	}, {
+		args: []string{"relation"},
+		err:  `invalid entity kind "relation", expected one of application, unit or machine`,
+	}, {
+		args: []string{"unit"},
+		err:  "no unit name specified",
+	}, {
+		args: []string{"unit", "mysql"},
+		err:  `unit name "mysql" not valid`,
+	}, {
+		args: []string{"application", "mysql", "workload"},
+		err:  `invalid condition "workload", expected <key>=<value>`,
+	}, {
+		args: []string{"machine", "0", "workload=active"},
+		err:  `invalid condition key "workload" for machine, expected one of agent, instance, life`,
+	}, {
+		args: []string{"application", "mysql", "units=many"},
+		err:  `invalid unit count "many"`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := waitfor.Unmet(test.args, nil)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *querySuite) TestApplicationDefaultConditions(c *gc.C) {
+	deltas := []multiwatcher.Delta{
+		applicationDelta("mysql", status.Active),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		unitDelta("mysql/1", "mysql", status.Maintenance, status.Executing),
+		unitDelta("wordpress/0", "wordpress", status.Blocked, status.Idle),
+	}
+	unmet, err := waitfor.Unmet([]string{"application", "mysql"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, `unit mysql/1 workload status is "maintenance", want "active"`)
+
+	deltas = append(deltas, unitDelta("mysql/1", "mysql", status.Active, status.Idle))
+	unmet, err = waitfor.Unmet([]string{"application", "mysql"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, "")
+}
+
+func (s *querySuite) TestApplicationConditions(c *gc.C) {
+	deltas := []multiwatcher.Delta{
+		applicationDelta("mysql", status.Active),
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+	}
+	unmet, err := waitfor.Unmet([]string{"application", "mysql", "status=active", "units=2"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, `application mysql unit count is "1", want "2"`)
+
+	unmet, err = waitfor.Unmet([]string{"application", "mysql", "status=active", "life=alive", "units=1"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, "")
+}
+
+func (s *querySuite) TestApplicationWithoutUnits(c *gc.C) {
+	deltas := []multiwatcher.Delta{applicationDelta("mysql", status.Waiting)}
+	unmet, err := waitfor.Unmet([]string{"application", "mysql"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, `application "mysql" has no units`)
+}
+
+func (s *querySuite) TestRemovedEntity(c *gc.C) {
+	removed := unitDelta("mysql/0", "mysql", status.Active, status.Idle)
+	removed.Removed = true
+	deltas := []multiwatcher.Delta{
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+		removed,
+	}
+	unmet, err := waitfor.Unmet([]string{"unit", "mysql/0"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, `unit "mysql/0" not found`)
+}
+
+func (s *querySuite) TestMachine(c *gc.C) {
+	deltas := []multiwatcher.Delta{machineDelta("0", status.Pending)}
+	unmet, err := waitfor.Unmet([]string{"machine", "0"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, `machine 0 agent status is "pending", want "started"`)
+
+	deltas = append(deltas, machineDelta("0", status.Started))
+	unmet, err = waitfor.Unmet([]string{"machine", "0", "life=alive"}, deltas)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unmet, gc.Equals, "")
+}
@@ -0,0 +1,180 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+var logger = loggo.GetLogger("juju.cmd.juju.waitfor")
+
+const defaultTimeout = 10 * time.Minute
+
+const waitForDoc = `
+Blocks until an application, unit or machine in the model reaches the
+requested state, or the timeout expires.
+
+The entity is identified by its kind (application, unit or machine) and
+its name. The state to wait for is given as one or more <key>=<value>
+conditions, all of which must hold at the same time:
+
+  application: status, life, exposed, units (exact unit count),
+               workload and agent (must hold for every unit)
+  unit:        workload, agent
+  machine:     agent, instance, life
+
+If no conditions are given, applications and units wait for
+workload=active and agent=idle, and machines wait for agent=started.
+
+The command exits with a non-zero status if the timeout expires before
+the conditions hold.
+
+Examples:
+
+    juju wait-for application mysql
+    juju wait-for application mysql workload=active agent=idle units=3
+    juju wait-for unit mysql/0 workload=blocked --timeout 5m
+    juju wait-for machine 0 agent=started
+`
+
+// NewWaitForCommand returns a command that waits for an entity in the
+// model to reach a requested state.
+func NewWaitForCommand() cmd.Command {
+	cmd := &waitForCommand{
+		clock: clock.WallClock,
+	}
+	cmd.newAPIFunc = func() (WaitForAPI, error) {
+		client, err := cmd.NewAPIClient()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return apiClientAdapter{client}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// WaitForAPI defines the API methods used by the wait-for command.
+type WaitForAPI interface {
+	Close() error
+	WatchAll() (AllWatcher, error)
+}
+
+// AllWatcher defines the methods of an all watcher used by the wait-for
+// command.
+type AllWatcher interface {
+	Next() ([]multiwatcher.Delta, error)
+	Stop() error
+}
+
+// apiClientAdapter adapts an *api.Client to the WaitForAPI interface.
+type apiClientAdapter struct {
+	*api.Client
+}
+
+// WatchAll is part of the WaitForAPI interface.
+func (a apiClientAdapter) WatchAll() (AllWatcher, error) {
+	w, err := a.Client.WatchAll()
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+type waitForCommand struct {
+	modelcmd.ModelCommandBase
+
+	newAPIFunc func() (WaitForAPI, error)
+	clock      clock.Clock
+
+	timeout time.Duration
+	query   query
+}
+
+// Info implements cmd.Command.
+func (c *waitForCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "wait-for",
+		Args:    "<application|unit|machine> <name> [<key>=<value>...]",
+		Purpose: "Wait for an entity in the model to reach a given state.",
+		Doc:     waitForDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *waitForCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.timeout, "timeout", defaultTimeout, "How long to wait before giving up")
+}
+
+// Init implements cmd.Command.
+func (c *waitForCommand) Init(args []string) error {
+	if c.timeout <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	q, err := parseQuery(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.query = q
+	return nil
+}
+
+type nextResult struct {
+	deltas []multiwatcher.Delta
+	err    error
+}
+
+// Run implements cmd.Command.
+func (c *waitForCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	watcher, err := client.WatchAll()
+	if err != nil {
+		return errors.Annotate(err, "watching model")
+	}
+	defer watcher.Stop()
+
+	store := newEntityStore()
+	reason := "no model information received"
+	timeout := c.clock.After(c.timeout)
+	for {
+		// Next blocks until there are changes; stopping the watcher
+		// on return unblocks it, so the buffered channel ensures the
+		// goroutine always completes.
+		results := make(chan nextResult, 1)
+		go func() {
+			deltas, err := watcher.Next()
+			results <- nextResult{deltas: deltas, err: err}
+		}()
+		select {
+		case <-timeout:
+			return errors.Errorf("timed out after %v waiting for %s: %s", c.timeout, c.query, reason)
+		case result := <-results:
+			if result.err != nil {
+				return errors.Annotate(result.err, "watching model")
+			}
+			store.apply(result.deltas)
+			reason = c.query.unmet(store)
+			if reason == "" {
+				ctx.Infof("%s reached", c.query)
+				return nil
+			}
+			logger.Debugf("waiting for %s: %s", c.query, reason)
+		}
+	}
+}
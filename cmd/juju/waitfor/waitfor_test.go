@@ -0,0 +1,140 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package waitfor_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/waitfor"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state/multiwatcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type waitForSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	watcher *mockAllWatcher
+	api     *mockWaitForAPI
+}
+
+var _ = gc.Suite(&waitForSuite{})
+
+func (s *waitForSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.watcher = &mockAllWatcher{
+		Stub:    &testing.Stub{},
+		deltas:  make(chan []multiwatcher.Delta, 10),
+		stopped: make(chan struct{}),
+	}
+	s.api = &mockWaitForAPI{Stub: &testing.Stub{}, watcher: s.watcher}
+}
+
+func (s *waitForSuite) runWaitFor(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := waitfor.NewWaitForCommandForTest(s.api, s.clock, jujuclienttesting.MinimalStore())
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *waitForSuite) TestInitErrors(c *gc.C) {
+	_, err := s.runWaitFor(c, "unit")
+	c.Assert(err, gc.ErrorMatches, "no unit name specified")
+	_, err = s.runWaitFor(c, "--timeout", "0s", "unit", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "timeout must be greater than zero")
+}
+
+func (s *waitForSuite) TestReachesState(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		applicationDelta("mysql", status.Waiting),
+		unitDelta("mysql/0", "mysql", status.Maintenance, status.Executing),
+	}
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "mysql", status.Active, status.Idle),
+	}
+	ctx, err := s.runWaitFor(c, "application", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `application "mysql" (workload=active agent=idle) reached`+"\n")
+	s.api.CheckCallNames(c, "WatchAll", "Close")
+	s.watcher.CheckCallNames(c, "Next", "Next", "Stop")
+}
+
+func (s *waitForSuite) TestTimeout(c *gc.C) {
+	s.watcher.deltas <- []multiwatcher.Delta{
+		unitDelta("mysql/0", "mysql", status.Blocked, status.Idle),
+	}
+	errc := make(chan error, 1)
+	go func() {
+		_, err := s.runWaitFor(c, "--timeout", "1m", "unit", "mysql/0")
+		errc <- err
+	}()
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, `timed out after 1m0s waiting for unit "mysql/0" \(workload=active agent=idle\): `+
+			`unit mysql/0 workload status is "blocked", want "active"`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	select {
+	case <-s.watcher.stopped:
+	default:
+		c.Fatalf("watcher was not stopped")
+	}
+}
+
+func (s *waitForSuite) TestWatchAllError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runWaitFor(c, "machine", "0")
+	c.Assert(err, gc.ErrorMatches, "watching model: boom")
+}
+
+type mockWaitForAPI struct {
+	*testing.Stub
+	watcher *mockAllWatcher
+}
+
+func (m *mockWaitForAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockWaitForAPI) WatchAll() (waitfor.AllWatcher, error) {
+	m.MethodCall(m, "WatchAll")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.watcher, nil
+}
+
+type mockAllWatcher struct {
+	*testing.Stub
+	deltas  chan []multiwatcher.Delta
+	stopped chan struct{}
+}
+
+func (m *mockAllWatcher) Next() ([]multiwatcher.Delta, error) {
+	m.MethodCall(m, "Next")
+	select {
+	case deltas := <-m.deltas:
+		return deltas, nil
+	case <-m.stopped:
+		return nil, errors.New("watcher stopped")
+	}
+}
+
+func (m *mockAllWatcher) Stop() error {
+	m.MethodCall(m, "Stop")
+	close(m.stopped)
+	return nil
+}
@@ -33,6 +33,7 @@ var (
 	}
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-scheduler",       // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
@@ -45,6 +46,7 @@ var (
 		"migration-master",        // secondary dependency: will be inactive because depends on model-upgrader
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
+		"rolling-upgrader",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
@@ -53,6 +55,7 @@ var (
 	}
 	aliveModelWorkers = []string{
 		"action-pruner",
+		"action-scheduler",
 		"charm-revision-updater",
 		"compute-provisioner",
 		"environ-tracker",
@@ -69,6 +72,7 @@ var (
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
+		"rolling-upgrader",
 		"log-forwarder",
 	}
 	migratingModelWorkers = []string{
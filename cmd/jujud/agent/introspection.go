@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"sync"
 
+	"github.com/hashicorp/raft"
 	"github.com/juju/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	names "gopkg.in/juju/names.v2"
@@ -38,6 +39,7 @@ type introspectionConfig struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	PresenceRecorder   presence.Recorder
+	RaftIntrospector   introspection.RaftIntrospector
 	NewSocketName      func(names.Tag) string
 	WorkerFunc         func(config introspection.Config) (worker.Worker, error)
 }
@@ -63,6 +65,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		MachineLock:        cfg.MachineLock,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 		Presence:           cfg.PresenceRecorder,
+		Raft:               cfg.RaftIntrospector,
 	})
 	if err != nil {
 		return errors.Trace(err)
@@ -115,3 +118,26 @@ func (h *statePoolIntrospectionReporter) IntrospectionReport() string {
 	}
 	return h.pool.IntrospectionReport()
 }
+
+// raftIntrospector wraps a (possibly nil) raft.Raft, which is set to
+// the raft node run by the raft worker in controller agents.
+type raftIntrospector struct {
+	mu   sync.Mutex
+	raft *raft.Raft
+}
+
+func (h *raftIntrospector) set(r *raft.Raft) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.raft = r
+}
+
+// Raft is part of the introspection.RaftIntrospector interface.
+func (h *raftIntrospector) Raft() introspection.RaftNode {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.raft == nil {
+		return nil
+	}
+	return h.raft
+}
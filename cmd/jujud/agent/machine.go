@@ -523,6 +523,18 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 		// which is set to the current StatePool managed by the state
 		// tracker in controller agents.
 		var statePoolReporter statePoolIntrospectionReporter
+
+		// raftNode is set to the raft node run by the raft worker in
+		// controller agents. It is only offered on the agent's
+		// introspection socket, and not through the API server, as it
+		// allows changing the raft cluster. Agents that aren't
+		// controllers when the engine starts don't run raft, so don't
+		// serve its endpoints.
+		var raftNode raftIntrospector
+		var raftSource introspection.RaftIntrospector
+		if _, ok := a.CurrentConfig().StateServingInfo(); ok {
+			raftSource = &raftNode
+		}
 		registerIntrospectionHandlers := func(handle func(path string, h http.Handler)) {
 			introspection.RegisterHTTPHandlers(introspection.ReportSources{
 				DependencyEngine:   engine,
@@ -578,6 +590,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			TransactionPruneInterval:          time.Hour,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.set,
+			SetRaft:                           raftNode.set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
 			NewModelWorker:                    a.startModelWorkers,
 			ControllerSupportsSpaces:          controllerSupportsSpaces,
@@ -597,6 +610,7 @@ func (a *MachineAgent) makeEngineCreator(previousAgentVersion version.Number) fu
 			NewSocketName:      a.newIntrospectionSocketName,
 			PrometheusGatherer: a.prometheusRegistry,
 			PresenceRecorder:   presenceRecorder,
+			RaftIntrospector:   raftSource,
 			WorkerFunc:         introspection.NewWorker,
 		}); err != nil {
 			// If the introspection worker failed to start, we just log error
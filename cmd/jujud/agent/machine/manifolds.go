@@ -8,6 +8,7 @@ import (
 	"runtime"
 	"time"
 
+	coreraft "github.com/hashicorp/raft"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/worker/apiservercertwatcher"
 	"github.com/juju/juju/worker/auditconfigupdater"
 	"github.com/juju/juju/worker/authenticationworker"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/centralhub"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/common"
@@ -215,6 +217,11 @@ type ManifoldsConfig struct {
 	// worker running outside of the dependency engine.
 	SetStatePool func(*state.StatePool)
 
+	// SetRaft is used by the raft worker for informing the agent of
+	// the raft node that it runs, so we can pass it to the introspection
+	// worker running outside of the dependency engine.
+	SetRaft func(*coreraft.Raft)
+
 	// RegisterIntrospectionHTTPHandlers is a function that calls the
 	// supplied function to register introspection HTTP handlers. The
 	// function will be passed a path and a handler; the function may
@@ -373,6 +380,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			AgentName:              agentName,
 			StateConfigWatcherName: stateConfigWatcherName,
 			OpenStatePool:          config.OpenStatePool,
+			Clock:                  config.Clock,
 			PrometheusRegisterer:   config.PrometheusRegisterer,
 			SetStatePool:           config.SetStatePool,
 		}),
@@ -702,6 +710,16 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		backupSchedulerName: ifNotMigrating(ifPrimaryController(backupscheduler.Manifold(
+			backupscheduler.ManifoldConfig{
+				AgentName:  agentName,
+				ClockName:  clockName,
+				StateName:  stateName,
+				NewBackend: backupscheduler.NewBackend,
+				NewWorker:  backupscheduler.NewWorker,
+			},
+		))),
+
 		httpServerName: httpserver.Manifold(httpserver.ManifoldConfig{
 			AgentName:             agentName,
 			CertWatcherName:       certificateWatcherName,
@@ -791,6 +809,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			FSM:           leaseFSM,
 			Logger:        loggo.GetLogger("juju.worker.raft"),
 			NewWorker:     raft.NewWorker,
+			SetRaft:       config.SetRaft,
 		}),
 
 		raftFlagName: raftflag.Manifold(raftflag.ManifoldConfig{
@@ -826,6 +845,8 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			Logger:         loggo.GetLogger("juju.worker.raft.raftforwarder"),
 			NewWorker:      raftforwarder.NewWorker,
 			NewTarget:      raftforwarder.NewTarget,
+
+			PrometheusRegisterer: config.PrometheusRegisterer,
 		})),
 
 		// The global lease manager tracks lease information in the raft
@@ -961,6 +982,7 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	backupSchedulerName           = "backup-scheduler"
 	certificateWatcherName        = "certificate-watcher"
 	modelWorkerManagerName        = "model-worker-manager"
 	peergrouperName               = "peer-grouper"
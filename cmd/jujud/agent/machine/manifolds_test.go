@@ -56,6 +56,7 @@ func (*ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"api-config-watcher",
 		"api-server",
 		"audit-config-updater",
+		"backup-scheduler",
 		"central-hub",
 		"certificate-updater",
 		"certificate-watcher",
@@ -197,6 +198,7 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"lease-manager",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"backup-scheduler",
 		"external-controller-updater",
 		"log-pruner",
 		"transaction-pruner",
@@ -319,6 +321,22 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state",
 		"state-config-watcher"},
 
+	"backup-scheduler": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"central-hub": {"agent", "state-config-watcher"},
 
 	"certificate-updater": {
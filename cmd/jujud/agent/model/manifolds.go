@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -53,6 +54,7 @@ import (
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
+	"github.com/juju/juju/worker/rollingupgrader"
 	"github.com/juju/juju/worker/singular"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -267,11 +269,32 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     actionpruner.NewFacade,
 			PruneInterval: config.ActionPrunerInterval,
 		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			NewFacade:     actionscheduler.NewFacade,
+			NewWorker:     actionscheduler.NewWorker,
+		})),
+		rollingUpgraderName: ifNotMigrating(rollingupgrader.Manifold(rollingupgrader.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			NewFacade:     rollingupgrader.NewFacade,
+			NewWorker:     rollingupgrader.NewWorker,
+		})),
 		logForwarderName: ifNotDead(logforwarder.Manifold(logforwarder.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Sinks: []logforwarder.LogSinkSpec{{
-				Name:   "juju-log-forward",
-				OpenFn: sinks.OpenSyslog,
+				Name:     "juju-log-forward",
+				ConfigFn: logforwarder.SyslogConfig,
+				OpenFn:   sinks.OpenSyslog,
+			}, {
+				Name:     "juju-log-forward-http",
+				ConfigFn: logforwarder.HTTPConfig,
+				OpenFn:   sinks.OpenHTTP,
+			}, {
+				Name:     "juju-log-forward-file",
+				ConfigFn: logforwarder.FileConfig,
+				OpenFn:   sinks.OpenFile,
 			}},
 		})),
 		// The model upgrader runs on all controller agents, and
@@ -588,6 +611,8 @@ const (
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
 	actionPrunerName         = "action-pruner"
+	actionSchedulerName      = "action-scheduler"
+	rollingUpgraderName      = "rolling-upgrader"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
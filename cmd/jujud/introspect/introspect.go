@@ -27,6 +27,7 @@ type IntrospectCommand struct {
 	agent   string
 	path    string
 	listen  string
+	post    bool
 
 	// IntrospectionSocketName returns the socket name
 	// for a given tag. If IntrospectionSocketName is nil,
@@ -54,6 +55,12 @@ unit agent on the machine, you can specify the
 agent using --agent. e.g.
 
     juju-introspect --agent=unit-mysql-0 metrics
+
+Paths that change the agent's state, such as
+raft/snapshot on a controller, must be requested
+with --post. e.g.
+
+    juju-introspect --post raft/snapshot
 `
 
 // Info returns usage information for the command.
@@ -71,6 +78,7 @@ func (c *IntrospectCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.dataDir, "data-dir", cmdutil.DataDir, "Juju base data directory")
 	f.StringVar(&c.agent, "agent", "", "agent to introspect (defaults to machine agent)")
 	f.StringVar(&c.listen, "listen", "", "address on which to expose the introspection socket")
+	f.BoolVar(&c.post, "post", false, "send the query as a POST request")
 }
 
 func (c *IntrospectCommand) Init(args []string) error {
@@ -83,6 +91,9 @@ func (c *IntrospectCommand) Init(args []string) error {
 	if c.path != "" && c.listen != "" {
 		return errors.New("a query path may not be specified with --listen")
 	}
+	if c.post && c.listen != "" {
+		return errors.New("--post may not be specified with --listen")
+	}
 	return c.CommandBase.Init(args)
 }
 
@@ -116,7 +127,12 @@ func (c *IntrospectCommand) Run(ctx *cmd.Context) error {
 
 	ctx.Infof("Querying %s introspection socket: %s", socketName, c.path)
 	client := unixSocketHTTPClient(socketName)
-	resp, err := client.Get(targetURL.String())
+	var resp *http.Response
+	if c.post {
+		resp, err = client.Post(targetURL.String(), "text/plain", nil)
+	} else {
+		resp, err = client.Get(targetURL.String())
+	}
 	if err != nil {
 		return err
 	}
@@ -47,6 +47,7 @@ func (s *IntrospectCommandSuite) TestInitErrors(c *gc.C) {
 	s.assertInitError(c, "either a query path or a --listen address must be specified")
 	s.assertInitError(c, "a query path may not be specified with --listen", "query-path", "--listen=foo")
 	s.assertInitError(c, `unrecognized args: \["path"\]`, "query", "path")
+	s.assertInitError(c, "--post may not be specified with --listen", "--post", "--listen=foo")
 }
 
 func (*IntrospectCommandSuite) assertInitError(c *gc.C, expect string, args ...string) {
@@ -99,6 +100,24 @@ func (s *IntrospectCommandSuite) TestQuery(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "hello")
 }
 
+func (s *IntrospectCommandSuite) TestQueryPost(c *gc.C) {
+	listener, err := net.Listen("unix", "@"+filepath.Join(cmdutil.DataDir, "jujud-machine-0"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer listener.Close()
+
+	srv := newServer(listener)
+	go srv.Serve(listener)
+	defer srv.Shutdown(context.Background())
+
+	ctx, err := s.run(c, "method", "--agent=machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "GET")
+
+	ctx, err = s.run(c, "method", "--post", "--agent=machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "POST")
+}
+
 func (s *IntrospectCommandSuite) TestQueryFails(c *gc.C) {
 	listener, err := net.Listen("unix", "@"+filepath.Join(cmdutil.DataDir, "jujud-machine-0"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -165,6 +184,9 @@ func newServer(l net.Listener) *http.Server {
 	mux.HandleFunc("/query", func(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte("hello"))
 	})
+	mux.HandleFunc("/method", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Method))
+	})
 	mux.HandleFunc("/badness", func(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "argh", http.StatusInternalServerError)
 	})
@@ -0,0 +1,234 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package raftleases provides a command for inspecting the leases held
+// in a controller's raft storage while the controller is down, and for
+// reviving a controller's raft node after the cluster has lost quorum.
+
+package raftleases
+
+import (
+	"io/ioutil"
+	"log"
+	"path/filepath"
+
+	"github.com/hashicorp/raft"
+	"github.com/hashicorp/raft-boltdb"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/cmd/jujud/util"
+	"github.com/juju/juju/core/raftlease"
+	jujuraft "github.com/juju/juju/worker/raft"
+	"github.com/juju/juju/worker/raft/raftutil"
+)
+
+var logger = loggo.GetLogger("juju.cmd.jujud.raftleases")
+
+// snapshotRetention is the number of snapshots kept when recovering;
+// it matches the raft worker's default.
+const snapshotRetention = 2
+
+// NewCommand returns a new Command instance which implements the
+// "jujud raft-leases" command.
+func NewCommand() cmd.Command {
+	return &raftLeasesCommand{}
+}
+
+type raftLeasesCommand struct {
+	cmd.CommandBase
+	out       cmd.Output
+	dataDir   string
+	machineId string
+	recover   bool
+	address   string
+	seedFile  string
+}
+
+// Info implements cmd.Command.
+func (c *raftLeasesCommand) Info() *cmd.Info {
+	doc := `
+This tool reads the raft storage of a Juju controller machine and shows
+the leases it holds, along with the raft cluster configuration. The
+lease state is rebuilt from the latest snapshot and the log entries
+after it. It must be run on the controller machine, with the machine
+agent stopped.
+
+With --recover, the raft storage is rewritten so that this machine is
+the only voting member of the cluster, keeping the leases as they are
+now. This allows a controller to be revived after the raft cluster has
+lost quorum; the other controllers must have their raft storage
+removed, or be recovered in the same way, before they rejoin.
+
+With --recover and --seed, the leases are replaced with the contents of
+the seed file, in the format this command shows. This can be used when
+the lease state in the raft storage is unusable.
+
+The raft address of this machine is taken from the current cluster
+configuration, and may be overridden with --address.
+`[1:]
+	return &cmd.Info{
+		Name:    "raft-leases",
+		Purpose: "show or recover the leases in the local raft storage",
+		Doc:     doc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *raftLeasesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+	})
+	f.StringVar(&c.dataDir, "data-dir", util.DataDir, "directory for juju data")
+	f.StringVar(&c.machineId, "machine-id", "", "id of the machine on this host (optional)")
+	f.BoolVar(&c.recover, "recover", false, "rewrite the raft storage as a single-node cluster")
+	f.StringVar(&c.address, "address", "", "raft address of this machine when recovering (optional)")
+	f.StringVar(&c.seedFile, "seed", "", "file of leases to recover with (optional)")
+}
+
+// Init implements cmd.Command.
+func (c *raftLeasesCommand) Init(args []string) error {
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	if c.dataDir == "" {
+		return util.RequiredError("data-dir")
+	}
+	if !c.recover {
+		if c.address != "" || c.seedFile != "" {
+			return errors.New("--address and --seed may only be used with --recover")
+		}
+		return nil
+	}
+	if c.machineId == "" {
+		machineId, err := c.findMachineId(c.dataDir)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.machineId = machineId
+	} else if !names.IsValidMachine(c.machineId) {
+		return errors.New("--machine-id option expects a non-negative integer")
+	}
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *raftLeasesCommand) Run(ctx *cmd.Context) error {
+	raftDir := filepath.Join(c.dataDir, "raft")
+	logs, err := openLogStore(raftDir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer logs.Close()
+
+	snapshots, err := jujuraft.NewSnapshotStore(raftDir, snapshotRetention, logger)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	state, err := readState(logs, snapshots)
+	if err != nil {
+		return errors.Annotate(err, "reading raft storage")
+	}
+	if c.recover {
+		return errors.Trace(c.recoverCluster(ctx, state, logs, snapshots))
+	}
+
+	info, err := state.leasesInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, info)
+}
+
+func (c *raftLeasesCommand) recoverCluster(
+	ctx *cmd.Context,
+	state *raftState,
+	logs *raftboltdb.BoltStore,
+	snapshots raft.SnapshotStore,
+) error {
+	localID := raft.ServerID(c.machineId)
+	address := raft.ServerAddress(c.address)
+	if address == "" {
+		for _, server := range state.Configuration.Servers {
+			if server.ID == localID {
+				address = server.Address
+				break
+			}
+		}
+	}
+	if address == "" {
+		return errors.Errorf("machine %s is not in the raft configuration, specify --address", localID)
+	}
+
+	// RecoverCluster replays the snapshot and logs into the FSM
+	// before taking the snapshot it recovers with.
+	var fsm raft.FSM = raftlease.NewFSM()
+	if c.seedFile != "" {
+		seed, err := readSeed(ctx.AbsPath(c.seedFile))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		fsm = seededFSM{seed: seed}
+	}
+
+	config := raft.DefaultConfig()
+	config.LocalID = localID
+	config.Logger = log.New(&raftutil.LoggoWriter{
+		Logger: logger,
+		Level:  loggo.DEBUG,
+	}, "", 0)
+
+	_, transport := raft.NewInmemTransport(address)
+	defer transport.Close()
+
+	configuration := raft.Configuration{
+		Servers: []raft.Server{{
+			ID:       localID,
+			Address:  address,
+			Suffrage: raft.Voter,
+		}},
+	}
+	if err := raft.RecoverCluster(config, fsm, logs, logs, snapshots, transport, configuration); err != nil {
+		return errors.Annotate(err, "recovering raft cluster")
+	}
+	ctx.Infof("raft storage recovered with machine %s at %s as the only voter", localID, address)
+	return nil
+}
+
+func readSeed(path string) (*raftlease.Snapshot, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading seed file")
+	}
+	var info LeasesInfo
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return nil, errors.Annotate(err, "parsing seed file")
+	}
+	snapshot, err := info.snapshot()
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing seed file")
+	}
+	return snapshot, nil
+}
+
+func (c *raftLeasesCommand) findMachineId(dataDir string) (string, error) {
+	entries, err := ioutil.ReadDir(agent.BaseDir(dataDir))
+	if err != nil {
+		return "", errors.Annotate(err, "failed to read agent configuration base directory")
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			tag, err := names.ParseMachineTag(entry.Name())
+			if err == nil {
+				return tag.Id(), nil
+			}
+		}
+	}
+	return "", errors.New("no machine agent configuration found")
+}
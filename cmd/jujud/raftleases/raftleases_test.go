@@ -0,0 +1,295 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftleases_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/raft"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/jujud/raftleases"
+	"github.com/juju/juju/core/raftlease"
+	"github.com/juju/juju/testing"
+	raftworker "github.com/juju/juju/worker/raft"
+)
+
+type RaftLeasesSuite struct {
+	testing.BaseSuite
+
+	dataDir string
+	start   time.Time
+}
+
+var _ = gc.Suite(&RaftLeasesSuite{})
+
+func (s *RaftLeasesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.dataDir = c.MkDir()
+	s.start = time.Date(2018, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	logs, err := raftworker.NewLogStore(filepath.Join(s.dataDir, "raft"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer logs.Close()
+
+	configuration := encodeConfiguration(c, raft.Configuration{
+		Servers: []raft.Server{
+			{ID: "0", Address: "10.0.0.1:17070", Suffrage: raft.Voter},
+			{ID: "1", Address: "10.0.0.2:17070", Suffrage: raft.Voter},
+			{ID: "2", Address: "10.0.0.3:17070", Suffrage: raft.Nonvoter},
+		},
+	})
+	err = logs.StoreLogs([]*raft.Log{{
+		Index: 1,
+		Term:  1,
+		Type:  raft.LogConfiguration,
+		Data:  configuration,
+	},
+		s.command(c, 2, raftlease.Command{
+			Operation: raftlease.OperationSetTime,
+			OldTime:   time.Time{},
+			NewTime:   s.start,
+		}),
+		s.command(c, 3, raftlease.Command{
+			Operation: raftlease.OperationClaim,
+			Namespace: "application-leadership",
+			ModelUUID: "model-uuid",
+			Lease:     "mysql",
+			Holder:    "mysql/0",
+			Duration:  time.Minute,
+		}),
+		s.command(c, 4, raftlease.Command{
+			Operation: raftlease.OperationClaim,
+			Namespace: "application-leadership",
+			ModelUUID: "model-uuid",
+			Lease:     "wordpress",
+			Holder:    "wordpress/1",
+			Duration:  time.Minute,
+		}),
+		s.command(c, 5, raftlease.Command{
+			Operation: raftlease.OperationPin,
+			Namespace: "application-leadership",
+			ModelUUID: "model-uuid",
+			Lease:     "wordpress",
+			PinEntity: "machine-0",
+		}),
+		// Rejected, since mysql is already held.
+		s.command(c, 6, raftlease.Command{
+			Operation: raftlease.OperationClaim,
+			Namespace: "application-leadership",
+			ModelUUID: "model-uuid",
+			Lease:     "mysql",
+			Holder:    "mysql/1",
+			Duration:  time.Minute,
+		}),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RaftLeasesSuite) command(c *gc.C, index uint64, command raftlease.Command) *raft.Log {
+	command.Version = raftlease.CommandVersion
+	data, err := yaml.Marshal(command)
+	c.Assert(err, jc.ErrorIsNil)
+	return &raft.Log{
+		Index: index,
+		Term:  1,
+		Type:  raft.LogCommand,
+		Data:  data,
+	}
+}
+
+func encodeConfiguration(c *gc.C, configuration raft.Configuration) []byte {
+	buf := bytes.NewBuffer(nil)
+	hd := codec.MsgpackHandle{}
+	enc := codec.NewEncoder(buf, &hd)
+	c.Assert(enc.Encode(configuration), jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *RaftLeasesSuite) run(c *gc.C, args ...string) (string, error) {
+	args = append([]string{"--data-dir", s.dataDir}, args...)
+	ctx, err := cmdtesting.RunCommand(c, raftleases.NewCommand(), args...)
+	return cmdtesting.Stdout(ctx), err
+}
+
+func (s *RaftLeasesSuite) leases(c *gc.C) raftleases.LeasesInfo {
+	out, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	var info raftleases.LeasesInfo
+	err = yaml.Unmarshal([]byte(out), &info)
+	c.Assert(err, jc.ErrorIsNil)
+	return info
+}
+
+func (s *RaftLeasesSuite) expectedLeases() []raftleases.LeaseInfo {
+	return []raftleases.LeaseInfo{{
+		Namespace: "application-leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "mysql",
+		Holder:    "mysql/0",
+		Start:     s.start,
+		Duration:  time.Minute,
+		Expiry:    s.start.Add(time.Minute),
+	}, {
+		Namespace: "application-leadership",
+		ModelUUID: "model-uuid",
+		Lease:     "wordpress",
+		Holder:    "wordpress/1",
+		Start:     s.start,
+		Duration:  time.Minute,
+		Expiry:    s.start.Add(time.Minute),
+		PinnedBy:  []string{"machine-0"},
+	}}
+}
+
+func (s *RaftLeasesSuite) TestInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--seed", "leases.yaml"},
+		err:  "--address and --seed may only be used with --recover",
+	}, {
+		args: []string{"--recover", "--machine-id", "foo"},
+		err:  "--machine-id option expects a non-negative integer",
+	}, {
+		args: []string{"--recover", "--data-dir", s.dataDir},
+		err:  "failed to read agent configuration base directory: .*",
+	}} {
+		err := cmdtesting.InitCommand(raftleases.NewCommand(), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RaftLeasesSuite) TestShowLeases(c *gc.C) {
+	info := s.leases(c)
+	c.Check(info.Index, gc.Equals, uint64(6))
+	c.Check(info.Term, gc.Equals, uint64(1))
+	c.Check(info.GlobalTime.Equal(s.start), jc.IsTrue)
+	c.Check(info.Servers, jc.DeepEquals, map[string]raftleases.ServerInfo{
+		"0": {Address: "10.0.0.1:17070", Suffrage: "Voter"},
+		"1": {Address: "10.0.0.2:17070", Suffrage: "Voter"},
+		"2": {Address: "10.0.0.3:17070", Suffrage: "Nonvoter"},
+	})
+	checkLeases(c, info.Leases, s.expectedLeases())
+}
+
+func (s *RaftLeasesSuite) TestNoRaftStorage(c *gc.C) {
+	s.dataDir = c.MkDir()
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, `raft log store in ".*" not found`)
+}
+
+func (s *RaftLeasesSuite) TestRecover(c *gc.C) {
+	_, err := s.run(c, "--recover", "--machine-id", "1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := s.leases(c)
+	c.Check(info.Index, gc.Equals, uint64(6))
+	c.Check(info.Servers, jc.DeepEquals, map[string]raftleases.ServerInfo{
+		"1": {Address: "10.0.0.2:17070", Suffrage: "Voter"},
+	})
+	checkLeases(c, info.Leases, s.expectedLeases())
+
+	// The log entries have been compacted into the snapshot.
+	logs, err := raftworker.NewLogStore(filepath.Join(s.dataDir, "raft"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer logs.Close()
+	last, err := logs.LastIndex()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(last, gc.Equals, uint64(0))
+}
+
+func (s *RaftLeasesSuite) TestRecoverWithAddress(c *gc.C) {
+	_, err := s.run(c, "--recover", "--machine-id", "2", "--address", "10.0.0.9:17070")
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := s.leases(c)
+	c.Check(info.Servers, jc.DeepEquals, map[string]raftleases.ServerInfo{
+		"2": {Address: "10.0.0.9:17070", Suffrage: "Voter"},
+	})
+}
+
+func (s *RaftLeasesSuite) TestRecoverUnknownMachine(c *gc.C) {
+	_, err := s.run(c, "--recover", "--machine-id", "5")
+	c.Assert(err, gc.ErrorMatches, "machine 5 is not in the raft configuration, specify --address")
+
+	// Nothing has changed.
+	info := s.leases(c)
+	c.Check(info.Servers, gc.HasLen, 3)
+}
+
+func (s *RaftLeasesSuite) TestRecoverWithSeed(c *gc.C) {
+	seed := raftleases.LeasesInfo{
+		GlobalTime: s.start,
+		Leases: []raftleases.LeaseInfo{{
+			Namespace: "singular-controller",
+			ModelUUID: "controller-uuid",
+			Lease:     "controller-uuid",
+			Holder:    "machine-0",
+			Start:     s.start,
+			Duration:  time.Minute,
+		}},
+	}
+	data, err := yaml.Marshal(seed)
+	c.Assert(err, jc.ErrorIsNil)
+	seedFile := filepath.Join(c.MkDir(), "seed.yaml")
+	err = ioutil.WriteFile(seedFile, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "--recover", "--machine-id", "0", "--seed", seedFile)
+	c.Assert(err, jc.ErrorIsNil)
+
+	info := s.leases(c)
+	c.Check(info.Servers, jc.DeepEquals, map[string]raftleases.ServerInfo{
+		"0": {Address: "10.0.0.1:17070", Suffrage: "Voter"},
+	})
+	checkLeases(c, info.Leases, []raftleases.LeaseInfo{{
+		Namespace: "singular-controller",
+		ModelUUID: "controller-uuid",
+		Lease:     "controller-uuid",
+		Holder:    "machine-0",
+		Start:     s.start,
+		Duration:  time.Minute,
+		Expiry:    s.start.Add(time.Minute),
+	}})
+}
+
+func (s *RaftLeasesSuite) TestRecoverWithInvalidSeed(c *gc.C) {
+	seedFile := filepath.Join(c.MkDir(), "seed.yaml")
+	err := ioutil.WriteFile(seedFile, []byte(`
+leases:
+- namespace: application-leadership
+  model-uuid: model-uuid
+  lease: mysql
+  holder: mysql/0
+`[1:]), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "--recover", "--machine-id", "0", "--seed", seedFile)
+	c.Assert(err, gc.ErrorMatches, `parsing seed file: lease "mysql" with non-positive duration not valid`)
+}
+
+// checkLeases compares leases, allowing for times that have lost
+// their location in the round trip through YAML.
+func checkLeases(c *gc.C, obtained, expected []raftleases.LeaseInfo) {
+	c.Assert(obtained, gc.HasLen, len(expected))
+	for i := range expected {
+		c.Check(obtained[i].Start.Equal(expected[i].Start), jc.IsTrue)
+		c.Check(obtained[i].Expiry.Equal(expected[i].Expiry), jc.IsTrue)
+		obtained[i].Start = expected[i].Start
+		obtained[i].Expiry = expected[i].Expiry
+	}
+	c.Check(obtained, jc.DeepEquals, expected)
+}
@@ -0,0 +1,277 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftleases
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/boltdb/bolt"
+	"github.com/hashicorp/go-msgpack/codec"
+	"github.com/hashicorp/raft"
+	"github.com/hashicorp/raft-boltdb"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/raftlease"
+)
+
+// openTimeout is how long to wait for the lock on the raft log
+// store. The lock is held by a running machine agent, so we don't
+// wait for it to be released.
+const openTimeout = 2 * time.Second
+
+// openLogStore opens the existing bolt log store in the raft storage
+// directory. Unlike the raft worker, it won't create a new one.
+func openLogStore(dir string) (*raftboltdb.BoltStore, error) {
+	path := filepath.Join(dir, "logs")
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, errors.NotFoundf("raft log store in %q", dir)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logs, err := raftboltdb.New(raftboltdb.Options{
+		Path:        path,
+		BoltOptions: &bolt.Options{Timeout: openTimeout},
+	})
+	if err == bolt.ErrTimeout {
+		return nil, errors.New("raft log store is locked, stop the machine agent first")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "opening raft log store")
+	}
+	return logs, nil
+}
+
+// raftState is the state of the raft node recorded on disk.
+type raftState struct {
+	// FSM holds the leases as at the last log entry.
+	FSM *raftlease.FSM
+
+	// Index and Term identify the last log entry.
+	Index uint64
+	Term  uint64
+
+	// Configuration is the latest cluster configuration.
+	Configuration raft.Configuration
+}
+
+// readState rebuilds the lease state by restoring the latest snapshot
+// and replaying the log entries after it. Entries that hadn't been
+// committed when the agent stopped are replayed too, since there's no
+// record of the commit index on disk.
+func readState(logs raft.LogStore, snapshots raft.SnapshotStore) (*raftState, error) {
+	state := &raftState{FSM: raftlease.NewFSM()}
+
+	metas, err := snapshots.List()
+	if err != nil {
+		return nil, errors.Annotate(err, "listing snapshots")
+	}
+	if len(metas) > 0 {
+		meta, reader, err := snapshots.Open(metas[0].ID)
+		if err != nil {
+			return nil, errors.Annotatef(err, "opening snapshot %q", metas[0].ID)
+		}
+		if err := state.FSM.Restore(reader); err != nil {
+			return nil, errors.Annotatef(err, "restoring snapshot %q", meta.ID)
+		}
+		state.Index = meta.Index
+		state.Term = meta.Term
+		state.Configuration = meta.Configuration
+	}
+
+	first, err := logs.FirstIndex()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting first log index")
+	}
+	last, err := logs.LastIndex()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting last log index")
+	}
+	if first <= state.Index {
+		first = state.Index + 1
+	}
+	for index := first; index != 0 && index <= last; index++ {
+		var entry raft.Log
+		if err := logs.GetLog(index, &entry); err != nil {
+			return nil, errors.Annotatef(err, "getting log entry %d", index)
+		}
+		switch entry.Type {
+		case raft.LogCommand:
+			// Commands the FSM rejected are in the log too; the
+			// response is only of interest to the original caller.
+			state.FSM.Apply(&entry)
+		case raft.LogConfiguration:
+			configuration, err := decodeConfiguration(entry.Data)
+			if err != nil {
+				return nil, errors.Annotatef(err, "decoding configuration in log entry %d", index)
+			}
+			state.Configuration = configuration
+		}
+		state.Index = entry.Index
+		state.Term = entry.Term
+	}
+	return state, nil
+}
+
+func decodeConfiguration(data []byte) (raft.Configuration, error) {
+	var configuration raft.Configuration
+	hd := codec.MsgpackHandle{}
+	dec := codec.NewDecoder(bytes.NewReader(data), &hd)
+	err := dec.Decode(&configuration)
+	return configuration, err
+}
+
+// LeasesInfo is the lease state shown by the command, and the format
+// of the seed file used when recovering.
+type LeasesInfo struct {
+	Index      uint64                `yaml:"index,omitempty"`
+	Term       uint64                `yaml:"term,omitempty"`
+	Servers    map[string]ServerInfo `yaml:"servers,omitempty"`
+	GlobalTime time.Time             `yaml:"global-time"`
+	Leases     []LeaseInfo           `yaml:"leases"`
+}
+
+// ServerInfo describes a member of the raft cluster.
+type ServerInfo struct {
+	Address  string `yaml:"address"`
+	Suffrage string `yaml:"suffrage"`
+}
+
+// LeaseInfo describes a single lease. Start and Expiry are in terms
+// of the global time. A lease that is pinned but not held has no
+// holder.
+type LeaseInfo struct {
+	Namespace string        `yaml:"namespace"`
+	ModelUUID string        `yaml:"model-uuid"`
+	Lease     string        `yaml:"lease"`
+	Holder    string        `yaml:"holder,omitempty"`
+	Start     time.Time     `yaml:"start,omitempty"`
+	Duration  time.Duration `yaml:"duration,omitempty"`
+	Expiry    time.Time     `yaml:"expiry,omitempty"`
+	PinnedBy  []string      `yaml:"pinned-by,omitempty"`
+}
+
+func (state *raftState) leasesInfo() (LeasesInfo, error) {
+	fsmSnapshot, err := state.FSM.Snapshot()
+	if err != nil {
+		return LeasesInfo{}, errors.Trace(err)
+	}
+	snapshot, ok := fsmSnapshot.(*raftlease.Snapshot)
+	if !ok {
+		return LeasesInfo{}, errors.Errorf("expected *raftlease.Snapshot, got %T", fsmSnapshot)
+	}
+	info := LeasesInfo{
+		Index:      state.Index,
+		Term:       state.Term,
+		Servers:    make(map[string]ServerInfo),
+		GlobalTime: snapshot.GlobalTime,
+	}
+	for _, server := range state.Configuration.Servers {
+		info.Servers[string(server.ID)] = ServerInfo{
+			Address:  string(server.Address),
+			Suffrage: server.Suffrage.String(),
+		}
+	}
+	for key, entry := range snapshot.Entries {
+		info.Leases = append(info.Leases, LeaseInfo{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+			Holder:    entry.Holder,
+			Start:     entry.Start,
+			Duration:  entry.Duration,
+			Expiry:    entry.Start.Add(entry.Duration),
+			PinnedBy:  snapshot.Pinned[key],
+		})
+	}
+	for key, entities := range snapshot.Pinned {
+		if _, held := snapshot.Entries[key]; held {
+			continue
+		}
+		info.Leases = append(info.Leases, LeaseInfo{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+			PinnedBy:  entities,
+		})
+	}
+	sort.Slice(info.Leases, func(i, j int) bool {
+		a, b := info.Leases[i], info.Leases[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.ModelUUID != b.ModelUUID {
+			return a.ModelUUID < b.ModelUUID
+		}
+		return a.Lease < b.Lease
+	})
+	return info, nil
+}
+
+// snapshot returns the FSM snapshot holding the leases described by
+// the info.
+func (info LeasesInfo) snapshot() (*raftlease.Snapshot, error) {
+	snapshot := &raftlease.Snapshot{
+		Version:    raftlease.SnapshotVersion,
+		Entries:    make(map[raftlease.SnapshotKey]raftlease.SnapshotEntry),
+		GlobalTime: info.GlobalTime,
+	}
+	seen := make(map[raftlease.SnapshotKey]bool)
+	for _, lease := range info.Leases {
+		if lease.Namespace == "" || lease.ModelUUID == "" || lease.Lease == "" {
+			return nil, errors.NotValidf("lease with empty namespace, model-uuid or lease")
+		}
+		key := raftlease.SnapshotKey{
+			Namespace: lease.Namespace,
+			ModelUUID: lease.ModelUUID,
+			Lease:     lease.Lease,
+		}
+		if seen[key] {
+			return nil, errors.NotValidf("duplicate lease %q", lease.Lease)
+		}
+		seen[key] = true
+		if lease.Holder != "" {
+			if lease.Duration <= 0 {
+				return nil, errors.NotValidf("lease %q with non-positive duration", lease.Lease)
+			}
+			snapshot.Entries[key] = raftlease.SnapshotEntry{
+				Holder:   lease.Holder,
+				Start:    lease.Start,
+				Duration: lease.Duration,
+			}
+		}
+		if len(lease.PinnedBy) > 0 {
+			if snapshot.Pinned == nil {
+				snapshot.Pinned = make(map[raftlease.SnapshotKey][]string)
+			}
+			snapshot.Pinned[key] = lease.PinnedBy
+		}
+	}
+	return snapshot, nil
+}
+
+// seededFSM is handed to raft.RecoverCluster when recovering from a
+// seed file. The snapshot and log entries already on disk are read
+// and discarded, and the seed becomes the recovered snapshot.
+type seededFSM struct {
+	seed *raftlease.Snapshot
+}
+
+// Apply is part of raft.FSM.
+func (seededFSM) Apply(*raft.Log) interface{} {
+	return nil
+}
+
+// Snapshot is part of raft.FSM.
+func (f seededFSM) Snapshot() (raft.FSMSnapshot, error) {
+	return f.seed, nil
+}
+
+// Restore is part of raft.FSM.
+func (seededFSM) Restore(reader io.ReadCloser) error {
+	return reader.Close()
+}
@@ -6,6 +6,7 @@ package container
 import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -50,6 +51,15 @@ type Manager interface {
 	Namespace() instance.Namespace
 }
 
+// LXDProfileManager is implemented by container managers that can apply
+// the LXD profiles supplied by charms to their containers.
+type LXDProfileManager interface {
+	// ReplaceCharmProfiles removes the charm profiles named in
+	// oldProfiles from the container identified by instance id, and
+	// applies newProfiles to it, creating them first if needed.
+	ReplaceCharmProfiles(id instance.Id, oldProfiles []string, newProfiles []lxdprofile.Profile) error
+}
+
 // Initialiser is responsible for performing the steps required to initialise
 // a host machine so it can run containers.
 type Initialiser interface {
@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -46,6 +47,9 @@ type containerManager struct {
 // containerManager implements container.Manager.
 var _ container.Manager = (*containerManager)(nil)
 
+// containerManager implements container.LXDProfileManager.
+var _ container.LXDProfileManager = (*containerManager)(nil)
+
 // NewContainerManager creates the entity that knows how to create and manage
 // LXD containers.
 // TODO(jam): This needs to grow support for things like LXC's ImageURLGetter
@@ -136,6 +140,13 @@ func (m *containerManager) IsInitialized() bool {
 	return m.server != nil
 }
 
+// ReplaceCharmProfiles implements container.LXDProfileManager.
+func (m *containerManager) ReplaceCharmProfiles(
+	id instance.Id, oldProfiles []string, newProfiles []lxdprofile.Profile,
+) error {
+	return errors.Trace(m.server.ReplaceCharmProfiles(string(id), oldProfiles, newProfiles))
+}
+
 // getContainerSpec generates a spec for creating a new container.
 // It sources an image based on the input series, and transforms the input
 // config objects into LXD configuration, including cloud init user data.
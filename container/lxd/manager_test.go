@@ -14,6 +14,7 @@ import (
 	lxdclient "github.com/lxc/lxd/client"
 	lxdapi "github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api"
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -375,6 +377,49 @@ func (s *managerSuite) TestIsInitialized(c *gc.C) {
 	c.Check(manager.IsInitialized(), gc.Equals, true)
 }
 
+func (s *managerSuite) TestReplaceCharmProfiles(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+	manager := s.makeManager(c, cSvr).(container.LXDProfileManager)
+
+	profile := &charm.LXDProfile{
+		Description: "test profile",
+		Config:      map[string]string{"security.nesting": "true"},
+	}
+	ctr := &lxdapi.Container{
+		Name: "juju-abc-0",
+		ContainerPut: lxdapi.ContainerPut{
+			Profiles: []string{"default", "juju-model-app-1"},
+		},
+	}
+	req := ctr.Writable()
+	req.Profiles = []string{"default", "juju-model-app-2"}
+
+	op := lxdtesting.NewMockOperation(ctrl)
+	op.EXPECT().Wait().Return(nil)
+	exp := cSvr.EXPECT()
+	exp.GetProfileNames().Return([]string{"default", "juju-model-app-1"}, nil)
+	exp.CreateProfile(lxdapi.ProfilesPost{
+		Name: "juju-model-app-2",
+		ProfilePut: lxdapi.ProfilePut{
+			Description: "test profile",
+			Config:      map[string]string{"security.nesting": "true"},
+		},
+	}).Return(nil)
+	exp.GetContainer("juju-abc-0").Return(ctr, lxdtesting.ETag, nil)
+	exp.UpdateContainer("juju-abc-0", req, lxdtesting.ETag).Return(op, nil)
+	exp.GetProfile("juju-model-app-1").Return(&lxdapi.Profile{Name: "juju-model-app-1"}, lxdtesting.ETag, nil)
+	exp.DeleteProfile("juju-model-app-1").Return(nil)
+
+	err := manager.ReplaceCharmProfiles(
+		"juju-abc-0",
+		[]string{"juju-model-app-1"},
+		[]lxdprofile.Profile{{Name: "juju-model-app-2", Profile: profile}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *managerSuite) TestNetworkDevicesFromConfigWithEmptyParentDevice(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
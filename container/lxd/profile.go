@@ -0,0 +1,105 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/lxc/lxd/shared/api"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+// EnsureProfile creates a profile with the input name and contents,
+// unless a profile with that name already exists.
+func (s *Server) EnsureProfile(name string, put api.ProfilePut) error {
+	has, err := s.HasProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if has {
+		return nil
+	}
+	req := api.ProfilesPost{
+		Name:       name,
+		ProfilePut: put,
+	}
+	return errors.Trace(s.CreateProfile(req))
+}
+
+// ReplaceContainerProfiles removes the profiles named in oldProfiles from
+// the container with the input name, and appends the profiles named in
+// newProfiles. Profiles not named in oldProfiles are left in place.
+func (s *Server) ReplaceContainerProfiles(name string, oldProfiles, newProfiles []string) error {
+	container, eTag, err := s.GetContainer(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	remove := set.NewStrings(oldProfiles...)
+	var profiles []string
+	for _, profile := range container.Profiles {
+		if !remove.Contains(profile) {
+			profiles = append(profiles, profile)
+		}
+	}
+	profiles = append(profiles, newProfiles...)
+
+	req := container.Writable()
+	req.Profiles = profiles
+	op, err := s.UpdateContainer(name, req, eTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(op.Wait())
+}
+
+// DeleteProfileIfUnused deletes the profile with the input name, unless
+// it is still applied to a container.
+func (s *Server) DeleteProfileIfUnused(name string) error {
+	profile, _, err := s.GetProfile(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(profile.UsedBy) != 0 {
+		return nil
+	}
+	return errors.Trace(s.DeleteProfile(name))
+}
+
+// ReplaceCharmProfiles removes the charm profiles named in oldProfiles
+// from the container with the input name, and applies newProfiles to it,
+// creating them first if needed. Profiles of superseded charm revisions
+// are deleted once no container uses them.
+func (s *Server) ReplaceCharmProfiles(name string, oldProfiles []string, newProfiles []lxdprofile.Profile) error {
+	newNames := make([]string, len(newProfiles))
+	for i, profile := range newProfiles {
+		put := api.ProfilePut{
+			Description: profile.Profile.Description,
+			Config:      profile.Profile.Config,
+			Devices:     profile.Profile.Devices,
+		}
+		if err := s.EnsureProfile(profile.Name, put); err != nil {
+			return errors.Annotatef(err, "creating profile %q", profile.Name)
+		}
+		newNames[i] = profile.Name
+	}
+
+	if err := s.ReplaceContainerProfiles(name, oldProfiles, newNames); err != nil {
+		return errors.Annotatef(err, "updating profiles of container %q", name)
+	}
+
+	// A failure to delete leaves an unused profile behind, which is not
+	// worth failing the change for.
+	keep := set.NewStrings(newNames...)
+	for _, profile := range oldProfiles {
+		if keep.Contains(profile) {
+			continue
+		}
+		if err := s.DeleteProfileIfUnused(profile); err != nil {
+			logger.Warningf("cannot delete profile %q: %v", profile, err)
+		}
+	}
+	return nil
+}
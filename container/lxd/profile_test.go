@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxd_test
+
+import (
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	"github.com/lxc/lxd/shared/api"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/container/lxd"
+	lxdtesting "github.com/juju/juju/container/lxd/testing"
+)
+
+type profileSuite struct {
+	lxdtesting.BaseSuite
+}
+
+var _ = gc.Suite(&profileSuite{})
+
+func (s *profileSuite) TestEnsureProfileCreates(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	put := api.ProfilePut{
+		Config: map[string]string{"security.nesting": "true"},
+	}
+	exp := cSvr.EXPECT()
+	exp.GetProfileNames().Return([]string{"default"}, nil)
+	exp.CreateProfile(api.ProfilesPost{Name: "juju-model-app-1", ProfilePut: put}).Return(nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.EnsureProfile("juju-model-app-1", put)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *profileSuite) TestEnsureProfileExists(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	cSvr.EXPECT().GetProfileNames().Return([]string{"default", "juju-model-app-1"}, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.EnsureProfile("juju-model-app-1", api.ProfilePut{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *profileSuite) TestReplaceContainerProfiles(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	container := &api.Container{
+		Name: "juju-abc-0",
+		ContainerPut: api.ContainerPut{
+			Profiles: []string{"default", "juju-model-app-1"},
+		},
+	}
+	req := container.Writable()
+	req.Profiles = []string{"default", "juju-model-app-2"}
+
+	op := lxdtesting.NewMockOperation(ctrl)
+	op.EXPECT().Wait().Return(nil)
+	exp := cSvr.EXPECT()
+	exp.GetContainer("juju-abc-0").Return(container, lxdtesting.ETag, nil)
+	exp.UpdateContainer("juju-abc-0", req, lxdtesting.ETag).Return(op, nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.ReplaceContainerProfiles(
+		"juju-abc-0", []string{"juju-model-app-1"}, []string{"juju-model-app-2"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *profileSuite) TestDeleteProfileIfUnused(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	cSvr := s.NewMockServer(ctrl)
+
+	used := &api.Profile{Name: "used", UsedBy: []string{"/1.0/containers/juju-abc-0"}}
+	unused := &api.Profile{Name: "unused"}
+	exp := cSvr.EXPECT()
+	exp.GetProfile("used").Return(used, lxdtesting.ETag, nil)
+	exp.GetProfile("unused").Return(unused, lxdtesting.ETag, nil)
+	exp.DeleteProfile("unused").Return(nil)
+
+	jujuSvr, err := lxd.NewServer(cSvr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.DeleteProfileIfUnused("used")
+	c.Assert(err, jc.ErrorIsNil)
+	err = jujuSvr.DeleteProfileIfUnused("unused")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -4,9 +4,12 @@
 package controller
 
 import (
+	"encoding/base64"
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/collections/set"
@@ -20,6 +23,7 @@ import (
 	"gopkg.in/macaroon-bakery.v2-unstable/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/core/resources"
 )
 
@@ -30,6 +34,16 @@ const (
 	MongoProfDefault = "default"
 )
 
+const (
+	// AuditLogBackendFile records audit entries in a rotated log file
+	// on each controller machine.
+	AuditLogBackendFile = "file"
+	// AuditLogBackendDatabase records audit entries in a capped
+	// collection in the controller database, where they can be
+	// queried controller-wide.
+	AuditLogBackendDatabase = "database"
+)
+
 const (
 	// APIPort is the port used for api connections.
 	APIPort = "api-port"
@@ -57,6 +71,10 @@ const (
 	// interesting calls though.)
 	AuditLogExcludeMethods = "audit-log-exclude-methods"
 
+	// AuditLogBackends is a list of the places audit records are
+	// written to: "file" and/or "database".
+	AuditLogBackends = "audit-log-backends"
+
 	// ReadOnlyMethodsWildcard is the special value that can be added
 	// to the exclude-methods list that represents all of the read
 	// only methods (see apiserver/observer/auditfilter.go). This
@@ -128,6 +146,66 @@ const (
 	// default value of 1M BatchSize and 100 passes will be used instead.
 	MaxPruneTxnPasses = "max-prune-txn-passes"
 
+	// BackupSchedule is the cron-style schedule on which the controller
+	// takes backups of itself, eg "0 2 * * *". Backups are only taken
+	// on a schedule if this is set.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetainDaily is the number of days for which the last
+	// scheduled backup of each day is kept.
+	BackupRetainDaily = "backup-retain-daily"
+
+	// BackupRetainWeekly is the number of weeks for which the last
+	// scheduled backup of each week is kept, in addition to those kept
+	// for BackupRetainDaily.
+	BackupRetainWeekly = "backup-retain-weekly"
+
+	// BackupEncryptionKey is the base64-encoded 256-bit key used to
+	// encrypt the backup archives stored by the controller. Archives
+	// are stored unencrypted if this is not set.
+	BackupEncryptionKey = "backup-encryption-key"
+
+	// BackupStorage is where the controller stores backup archives:
+	// "controller" (the default) stores them in the controller's
+	// database, "directory" in the BackupStorageDirectory directory,
+	// and "s3" in the BackupS3Bucket bucket of an S3-compatible object
+	// store. Backup metadata is always stored in the controller's
+	// database.
+	BackupStorage = "backup-storage"
+
+	// BackupStorageDirectory is the absolute path of the directory in
+	// which backup archives are stored when BackupStorage is
+	// "directory". In a highly available controller it should be a
+	// shared (eg NFS) mount, present on all controller machines.
+	BackupStorageDirectory = "backup-storage-directory"
+
+	// BackupS3Endpoint is the URL of the S3-compatible object store in
+	// which backup archives are stored when BackupStorage is "s3",
+	// eg "https://s3.amazonaws.com".
+	BackupS3Endpoint = "backup-s3-endpoint"
+
+	// BackupS3Region is the region used to sign requests to the object
+	// store.
+	BackupS3Region = "backup-s3-region"
+
+	// BackupS3Bucket is the object store bucket in which backup
+	// archives are stored.
+	BackupS3Bucket = "backup-s3-bucket"
+
+	// BackupS3AccessKey is the access key used to authenticate with the
+	// object store.
+	BackupS3AccessKey = "backup-s3-access-key"
+
+	// BackupS3SecretKey is the secret key used to authenticate with the
+	// object store.
+	BackupS3SecretKey = "backup-s3-secret-key"
+
+	// BackupStorageTypeController, BackupStorageTypeDirectory and
+	// BackupStorageTypeS3 are the valid values of BackupStorage.
+	BackupStorageTypeController = "controller"
+	BackupStorageTypeDirectory  = "directory"
+	BackupStorageTypeS3         = "s3"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -175,6 +253,18 @@ const (
 	// DefaultMaxPruneTxnPasses is the default number of batches we will process
 	DefaultMaxPruneTxnPasses = 100
 
+	// DefaultBackupRetainDaily is the default number of days for which
+	// a daily scheduled backup is kept.
+	DefaultBackupRetainDaily = 7
+
+	// DefaultBackupRetainWeekly is the default number of weeks for
+	// which a weekly scheduled backup is kept.
+	DefaultBackupRetainWeekly = 4
+
+	// DefaultBackupS3Region is the default region used to sign requests
+	// to the backup object store.
+	DefaultBackupS3Region = "us-east-1"
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -183,6 +273,15 @@ const (
 	// communicate with controllers.
 	JujuManagementSpace = "juju-mgmt-space"
 
+	// JujuHAPrimaries lists the ids of the controller machines
+	// that should be preferred as the MongoDB primary, most preferred
+	// first.
+	JujuHAPrimaries = "juju-ha-preferred-primaries"
+
+	// JujuHAObservers lists the ids of the controller machines that
+	// should be kept as non-voting members of the MongoDB replica-set.
+	JujuHAObservers = "juju-ha-observers"
+
 	// CAASOperatorImagePath sets the url of the docker image
 	// used for the application operator.
 	CAASOperatorImagePath = "caas-operator-image-path"
@@ -217,11 +316,25 @@ var (
 		MaxPruneTxnPasses,
 		JujuHASpace,
 		JujuManagementSpace,
+		JujuHAPrimaries,
+		JujuHAObservers,
+		BackupSchedule,
+		BackupRetainDaily,
+		BackupRetainWeekly,
+		BackupEncryptionKey,
+		BackupStorage,
+		BackupStorageDirectory,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogMaxSize,
 		AuditLogMaxBackups,
 		AuditLogExcludeMethods,
+		AuditLogBackends,
 		CAASOperatorImagePath,
 		Features,
 		MeteringURL,
@@ -238,8 +351,30 @@ var (
 		MaxPruneTxnPasses,
 		JujuHASpace,
 		JujuManagementSpace,
+		JujuHAPrimaries,
+		JujuHAObservers,
 		CAASOperatorImagePath,
 		Features,
+		BackupSchedule,
+		BackupRetainDaily,
+		BackupRetainWeekly,
+		BackupEncryptionKey,
+		BackupStorage,
+		BackupStorageDirectory,
+		BackupS3Endpoint,
+		BackupS3Region,
+		BackupS3Bucket,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
+	)
+
+	// SecretAttributes contains the controller config attributes that
+	// hold keys and credentials. They are only needed by the controllers
+	// themselves, and are never sent to clients or agents.
+	SecretAttributes = set.NewStrings(
+		BackupEncryptionKey,
+		BackupS3AccessKey,
+		BackupS3SecretKey,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -250,6 +385,10 @@ var (
 		ReadOnlyMethodsWildcard,
 	}
 
+	// DefaultAuditLogBackends is the default list of audit log
+	// backends.
+	DefaultAuditLogBackends = []string{AuditLogBackendFile}
+
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
 )
 
@@ -312,6 +451,20 @@ func (c Config) intOrDefault(name string, defaultVal int) int {
 	return defaultVal
 }
 
+// intOrDefaultAllowZero returns the named attribute as an integer,
+// or the default if it is not set. Unlike intOrDefault, zero is a
+// valid value.
+func (c Config) intOrDefaultAllowZero(name string, defaultVal int) int {
+	switch value := c[name].(type) {
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(value)
+	case int:
+		return value
+	}
+	return defaultVal
+}
+
 // asString is a private helper method to keep the ugly string casting
 // in once place. It returns the given named attribute as a string,
 // returning "" if it isn't found.
@@ -387,6 +540,20 @@ func (c Config) AuditLogExcludeMethods() set.Strings {
 	return set.NewStrings(DefaultAuditLogExcludeMethods...)
 }
 
+// AuditLogBackends returns the names of the backends audit records
+// should be written to.
+func (c Config) AuditLogBackends() set.Strings {
+	if value, ok := c[AuditLogBackends]; ok {
+		value := value.([]interface{})
+		items := set.NewStrings()
+		for _, item := range value {
+			items.Add(item.(string))
+		}
+		return items
+	}
+	return set.NewStrings(DefaultAuditLogBackends...)
+}
+
 // Features returns the controller config set features flags.
 func (c Config) Features() set.Strings {
 	features := set.NewStrings()
@@ -515,6 +682,96 @@ func (c Config) MaxPruneTxnPasses() int {
 	return c.intOrDefault(MaxPruneTxnPasses, DefaultMaxPruneTxnPasses)
 }
 
+// BackupSchedule returns the schedule on which the controller takes
+// backups of itself, or the empty string if it doesn't.
+func (c Config) BackupSchedule() string {
+	return c.asString(BackupSchedule)
+}
+
+// BackupRetainDaily returns the number of days for which a daily
+// scheduled backup is kept.
+func (c Config) BackupRetainDaily() int {
+	return c.intOrDefaultAllowZero(BackupRetainDaily, DefaultBackupRetainDaily)
+}
+
+// BackupRetainWeekly returns the number of weeks for which a weekly
+// scheduled backup is kept.
+func (c Config) BackupRetainWeekly() int {
+	return c.intOrDefaultAllowZero(BackupRetainWeekly, DefaultBackupRetainWeekly)
+}
+
+// BackupEncryptionKey returns the key used to encrypt stored backup
+// archives, or nil if they are stored unencrypted.
+func (c Config) BackupEncryptionKey() []byte {
+	// Value has already been validated.
+	key, _ := base64.StdEncoding.DecodeString(c.asString(BackupEncryptionKey))
+	if len(key) == 0 {
+		return nil
+	}
+	return key
+}
+
+// BackupStorage returns where the controller stores backup archives,
+// one of BackupStorageTypeController, BackupStorageTypeDirectory or
+// BackupStorageTypeS3.
+func (c Config) BackupStorage() string {
+	if v := c.asString(BackupStorage); v != "" {
+		return v
+	}
+	return BackupStorageTypeController
+}
+
+// BackupStorageDirectory returns the directory in which backup archives
+// are stored when the backup storage is a directory.
+func (c Config) BackupStorageDirectory() string {
+	return c.asString(BackupStorageDirectory)
+}
+
+// BackupS3Endpoint returns the URL of the object store in which backup
+// archives are stored when the backup storage is S3.
+func (c Config) BackupS3Endpoint() string {
+	return c.asString(BackupS3Endpoint)
+}
+
+// BackupS3Region returns the region used to sign requests to the backup
+// object store.
+func (c Config) BackupS3Region() string {
+	if v := c.asString(BackupS3Region); v != "" {
+		return v
+	}
+	return DefaultBackupS3Region
+}
+
+// BackupS3Bucket returns the bucket in which backup archives are stored
+// when the backup storage is S3.
+func (c Config) BackupS3Bucket() string {
+	return c.asString(BackupS3Bucket)
+}
+
+// BackupS3AccessKey returns the access key used to authenticate with
+// the backup object store.
+func (c Config) BackupS3AccessKey() string {
+	return c.asString(BackupS3AccessKey)
+}
+
+// BackupS3SecretKey returns the secret key used to authenticate with
+// the backup object store.
+func (c Config) BackupS3SecretKey() string {
+	return c.asString(BackupS3SecretKey)
+}
+
+// WithoutSecrets returns a copy of the config with the attributes in
+// SecretAttributes removed, suitable for sending over the API.
+func (c Config) WithoutSecrets() Config {
+	result := make(Config, len(c))
+	for key, value := range c {
+		if !SecretAttributes.Contains(key) {
+			result[key] = value
+		}
+	}
+	return result
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -527,6 +784,31 @@ func (c Config) JujuManagementSpace() string {
 	return c.asString(JujuManagementSpace)
 }
 
+// JujuHAPrimaries returns the ids of the controller machines
+// that should be preferred as the MongoDB primary, most preferred first.
+func (c Config) JujuHAPrimaries() []string {
+	return c.asStrings(JujuHAPrimaries)
+}
+
+// JujuHAObservers returns the ids of the controller machines that
+// should be kept as non-voting members of the MongoDB replica-set.
+func (c Config) JujuHAObservers() set.Strings {
+	return set.NewStrings(c.asStrings(JujuHAObservers)...)
+}
+
+// asStrings returns the list of strings held under the given name.
+func (c Config) asStrings(name string) []string {
+	value, ok := c[name].([]interface{})
+	if !ok {
+		return nil
+	}
+	items := make([]string, len(value))
+	for i, item := range value {
+		items[i] = item.(string)
+	}
+	return items
+}
+
 // CAASOperatorImagePath sets the url of the docker image
 // used for the application operator.
 func (c Config) CAASOperatorImagePath() string {
@@ -601,6 +883,32 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[BackupSchedule].(string); ok && v != "" {
+		if _, err := actions.ParseSchedule(v); err != nil {
+			return errors.Annotate(err, "invalid backup schedule in configuration")
+		}
+	}
+
+	for _, key := range []string{BackupRetainDaily, BackupRetainWeekly} {
+		if v, ok := c[key].(int); ok && v < 0 {
+			return errors.Errorf("invalid %s: expected a non-negative number, got %d", key, v)
+		}
+	}
+
+	if v, ok := c[BackupEncryptionKey].(string); ok && v != "" {
+		key, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid backup encryption key in configuration")
+		}
+		if len(key) != 32 {
+			return errors.Errorf("invalid backup encryption key in configuration: expected 32 bytes, got %d", len(key))
+		}
+	}
+
+	if err := c.validateBackupStorage(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -609,6 +917,10 @@ func Validate(c Config) error {
 		return errors.Trace(err)
 	}
 
+	if err := c.validateHAMachines(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if v, ok := c[CAASOperatorImagePath].(string); ok {
 		if err := resources.ValidateDockerRegistryPath(v); err != nil {
 			return errors.Trace(err)
@@ -649,6 +961,21 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AuditLogBackends].([]interface{}); ok {
+		if len(v) == 0 {
+			return errors.Errorf("invalid audit log backends: at least one backend must be specified")
+		}
+		for _, name := range v {
+			name := name.(string)
+			if name != AuditLogBackendFile && name != AuditLogBackendDatabase {
+				return errors.Errorf(
+					"invalid audit log backends: expected %q or %q, got %q",
+					AuditLogBackendFile, AuditLogBackendDatabase, name,
+				)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -668,6 +995,38 @@ func (c Config) validateSpaceConfig(key, topic string) error {
 	return nil
 }
 
+// validateHAMachines checks that the machines named in the HA member
+// policy settings are valid, and that none is named in both.
+func (c Config) validateHAMachines() error {
+	primaries, err := c.validateMachineList(JujuHAPrimaries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	observers, err := c.validateMachineList(JujuHAObservers)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if both := primaries.Intersection(observers); !both.IsEmpty() {
+		return errors.Errorf("machines %s cannot be in both %s and %s",
+			strings.Join(both.SortedValues(), ", "), JujuHAPrimaries, JujuHAObservers)
+	}
+	return nil
+}
+
+func (c Config) validateMachineList(key string) (set.Strings, error) {
+	ids := set.NewStrings()
+	for _, id := range c.asStrings(key) {
+		if !names.IsValidMachine(id) {
+			return nil, errors.Errorf("invalid %s: %q is not a valid machine id", key, id)
+		}
+		if ids.Contains(id) {
+			return nil, errors.Errorf("invalid %s: machine %q listed more than once", key, id)
+		}
+		ids.Add(id)
+	}
+	return ids, nil
+}
+
 // AsSpaceConstraints checks to see whether config has spaces names populated
 // for management and/or HA (Mongo).
 // Non-empty values are merged with any input spaces and returned as a new
@@ -703,12 +1062,54 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 	return cert.NewDefaultServer(caCert, caKey, hostAddresses)
 }
 
+// validateBackupStorage checks that the settings needed by the
+// configured backup storage are present and valid.
+func (c Config) validateBackupStorage() error {
+	switch storage := c.BackupStorage(); storage {
+	case BackupStorageTypeController:
+	case BackupStorageTypeDirectory:
+		dir := c.BackupStorageDirectory()
+		if dir == "" {
+			return errors.Errorf("%s must be set when %s is %q", BackupStorageDirectory, BackupStorage, storage)
+		}
+		if !filepath.IsAbs(dir) {
+			return errors.Errorf("invalid %s %q: expected an absolute path", BackupStorageDirectory, dir)
+		}
+	case BackupStorageTypeS3:
+		for _, key := range []string{
+			BackupS3Endpoint,
+			BackupS3Bucket,
+			BackupS3AccessKey,
+			BackupS3SecretKey,
+		} {
+			if c.asString(key) == "" {
+				return errors.Errorf("%s must be set when %s is %q", key, BackupStorage, storage)
+			}
+		}
+		endpoint := c.BackupS3Endpoint()
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return errors.Annotatef(err, "invalid %s", BackupS3Endpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("invalid %s %q: expected an http or https URL", BackupS3Endpoint, endpoint)
+		}
+	default:
+		return errors.Errorf("invalid %s %q: expected one of %q, %q or %q",
+			BackupStorage, storage,
+			BackupStorageTypeController, BackupStorageTypeDirectory, BackupStorageTypeS3,
+		)
+	}
+	return nil
+}
+
 var configChecker = schema.FieldMap(schema.Fields{
 	AuditingEnabled:         schema.Bool(),
 	AuditLogCaptureArgs:     schema.Bool(),
 	AuditLogMaxSize:         schema.String(),
 	AuditLogMaxBackups:      schema.ForceInt(),
 	AuditLogExcludeMethods:  schema.List(schema.String()),
+	AuditLogBackends:        schema.List(schema.String()),
 	APIPort:                 schema.ForceInt(),
 	StatePort:               schema.ForceInt(),
 	IdentityURL:             schema.String(),
@@ -723,8 +1124,21 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxTxnLogSize:           schema.String(),
 	MaxPruneTxnBatchSize:    schema.ForceInt(),
 	MaxPruneTxnPasses:       schema.ForceInt(),
+	BackupSchedule:          schema.String(),
+	BackupRetainDaily:       schema.ForceInt(),
+	BackupRetainWeekly:      schema.ForceInt(),
+	BackupEncryptionKey:     schema.String(),
+	BackupStorage:           schema.String(),
+	BackupStorageDirectory:  schema.String(),
+	BackupS3Endpoint:        schema.String(),
+	BackupS3Region:          schema.String(),
+	BackupS3Bucket:          schema.String(),
+	BackupS3AccessKey:       schema.String(),
+	BackupS3SecretKey:       schema.String(),
 	JujuHASpace:             schema.String(),
 	JujuManagementSpace:     schema.String(),
+	JujuHAPrimaries:         schema.List(schema.String()),
+	JujuHAObservers:         schema.List(schema.String()),
 	CAASOperatorImagePath:   schema.String(),
 	Features:                schema.List(schema.String()),
 	CharmStoreURL:           schema.String(),
@@ -736,6 +1150,7 @@ var configChecker = schema.FieldMap(schema.Fields{
 	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
 	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
 	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
+	AuditLogBackends:        DefaultAuditLogBackends,
 	StatePort:               DefaultStatePort,
 	IdentityURL:             schema.Omit,
 	IdentityPublicKey:       schema.Omit,
@@ -749,8 +1164,21 @@ var configChecker = schema.FieldMap(schema.Fields{
 	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
 	MaxPruneTxnBatchSize:    DefaultMaxPruneTxnBatchSize,
 	MaxPruneTxnPasses:       DefaultMaxPruneTxnPasses,
+	BackupSchedule:          schema.Omit,
+	BackupRetainDaily:       schema.Omit,
+	BackupRetainWeekly:      schema.Omit,
+	BackupEncryptionKey:     schema.Omit,
+	BackupStorage:           schema.Omit,
+	BackupStorageDirectory:  schema.Omit,
+	BackupS3Endpoint:        schema.Omit,
+	BackupS3Region:          schema.Omit,
+	BackupS3Bucket:          schema.Omit,
+	BackupS3AccessKey:       schema.Omit,
+	BackupS3SecretKey:       schema.Omit,
 	JujuHASpace:             schema.Omit,
 	JujuManagementSpace:     schema.Omit,
+	JujuHAPrimaries:         schema.Omit,
+	JujuHAObservers:         schema.Omit,
 	CAASOperatorImagePath:   schema.Omit,
 	Features:                schema.Omit,
 	CharmStoreURL:           csclient.ServerURL,
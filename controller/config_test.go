@@ -4,6 +4,7 @@
 package controller_test
 
 import (
+	"encoding/base64"
 	stdtesting "testing"
 	"time"
 
@@ -159,6 +160,28 @@ var validateTests = []struct {
 		controller.JujuHASpace: true,
 	},
 	expectError: `type for juju HA space name true not valid`,
+}, {
+	about: "invalid HA preferred primary",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.JujuHAPrimaries: []interface{}{"0", "machine-1"},
+	},
+	expectError: `invalid juju-ha-preferred-primaries: "machine-1" is not a valid machine id`,
+}, {
+	about: "duplicate HA observer",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.JujuHAObservers: []interface{}{"2", "2"},
+	},
+	expectError: `invalid juju-ha-observers: machine "2" listed more than once`,
+}, {
+	about: "HA preferred primary is an observer",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.JujuHAPrimaries: []interface{}{"0", "1"},
+		controller.JujuHAObservers: []interface{}{"2", "1"},
+	},
+	expectError: `machines 1 cannot be in both juju-ha-preferred-primaries and juju-ha-observers`,
 }, {
 	about: "invalid audit log max size",
 	config: controller.Config{
@@ -188,6 +211,20 @@ var validateTests = []struct {
 		controller.AuditLogExcludeMethods: []interface{}{"Dap.Kings", "ReadOnlyMethods", "Sharon Jones"},
 	},
 	expectError: `invalid audit log exclude methods: should be a list of "Facade.Method" names \(or "ReadOnlyMethods"\), got "Sharon Jones" at position 3`,
+}, {
+	about: "invalid audit log backend",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.AuditLogBackends: []interface{}{"file", "syslog"},
+	},
+	expectError: `invalid audit log backends: expected "file" or "database", got "syslog"`,
+}, {
+	about: "empty audit log backends",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.AuditLogBackends: []interface{}{},
+	},
+	expectError: `invalid audit log backends: at least one backend must be specified`,
 }, {
 	about: "invalid CAAS operator docker image path",
 	config: controller.Config{
@@ -230,6 +267,70 @@ var validateTests = []struct {
 		controller.CAASOperatorImagePath: "foo//bar",
 	},
 	expectError: `docker image path "foo//bar" not valid`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.BackupSchedule: "0 25 * * *",
+	},
+	expectError: `invalid backup schedule in configuration: schedule "0 25 \* \* \*": hour "25" not valid`,
+}, {
+	about: "negative backup retention",
+	config: controller.Config{
+		controller.CACertKey:          testing.CACert,
+		controller.BackupRetainWeekly: -1,
+	},
+	expectError: `invalid backup-retain-weekly: expected a non-negative number, got -1`,
+}, {
+	about: "invalid backup encryption key",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.BackupEncryptionKey: "c2hvcnQ=",
+	},
+	expectError: `invalid backup encryption key in configuration: expected 32 bytes, got 5`,
+}, {
+	about: "unknown backup storage",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "tape",
+	},
+	expectError: `invalid backup-storage "tape": expected one of "controller", "directory" or "s3"`,
+}, {
+	about: "backup storage directory missing",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.BackupStorage: "directory",
+	},
+	expectError: `backup-storage-directory must be set when backup-storage is "directory"`,
+}, {
+	about: "backup storage directory not absolute",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.BackupStorage:          "directory",
+		controller.BackupStorageDirectory: "backups",
+	},
+	expectError: `invalid backup-storage-directory "backups": expected an absolute path`,
+}, {
+	about: "backup storage s3 bucket missing",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "https://s3.example.com",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `backup-s3-bucket must be set when backup-storage is "s3"`,
+}, {
+	about: "backup storage s3 endpoint invalid",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.BackupStorage:     "s3",
+		controller.BackupS3Endpoint:  "s3.example.com",
+		controller.BackupS3Bucket:    "backups",
+		controller.BackupS3AccessKey: "access",
+		controller.BackupS3SecretKey: "secret",
+	},
+	expectError: `invalid backup-s3-endpoint "s3.example.com": expected an http or https URL`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -304,6 +405,99 @@ func (s *ConfigSuite) TestMaxPruneTxnConfigValue(c *gc.C) {
 	c.Check(cfg.MaxPruneTxnPasses(), gc.Equals, 10)
 }
 
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "")
+	c.Check(cfg.BackupRetainDaily(), gc.Equals, 7)
+	c.Check(cfg.BackupRetainWeekly(), gc.Equals, 4)
+	c.Check(cfg.BackupEncryptionKey(), gc.IsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, "controller")
+	c.Check(cfg.BackupS3Region(), gc.Equals, "us-east-1")
+}
+
+func (s *ConfigSuite) TestBackupConfigValues(c *gc.C) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":       "@daily",
+			"backup-retain-daily":   "3",
+			"backup-retain-weekly":  "0",
+			"backup-encryption-key": base64.StdEncoding.EncodeToString(key),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupSchedule(), gc.Equals, "@daily")
+	c.Check(cfg.BackupRetainDaily(), gc.Equals, 3)
+	c.Check(cfg.BackupRetainWeekly(), gc.Equals, 0)
+	c.Check(cfg.BackupEncryptionKey(), jc.DeepEquals, key)
+}
+
+func (s *ConfigSuite) TestWithoutSecrets(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":       "@daily",
+			"backup-encryption-key": base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")),
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	redacted := cfg.WithoutSecrets()
+	c.Check(redacted.BackupSchedule(), gc.Equals, "@daily")
+	c.Check(redacted.ControllerUUID(), gc.Equals, testing.ControllerTag.Id())
+	for _, key := range controller.SecretAttributes.Values() {
+		_, ok := redacted[key]
+		c.Check(ok, jc.IsFalse, gc.Commentf("%s", key))
+	}
+	// The original config is unchanged.
+	c.Check(cfg.BackupEncryptionKey(), gc.HasLen, 32)
+}
+
+func (s *ConfigSuite) TestBackupStorageConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":       "s3",
+			"backup-s3-endpoint":   "http://10.0.0.1:9000",
+			"backup-s3-region":     "eu-west-2",
+			"backup-s3-bucket":     "backups",
+			"backup-s3-access-key": "access",
+			"backup-s3-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.BackupStorage(), gc.Equals, "s3")
+	c.Check(cfg.BackupS3Endpoint(), gc.Equals, "http://10.0.0.1:9000")
+	c.Check(cfg.BackupS3Region(), gc.Equals, "eu-west-2")
+	c.Check(cfg.BackupS3Bucket(), gc.Equals, "backups")
+	c.Check(cfg.BackupS3AccessKey(), gc.Equals, "access")
+	c.Check(cfg.BackupS3SecretKey(), gc.Equals, "secret")
+}
+
+func (s *ConfigSuite) TestWithoutSecretsS3Credentials(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-storage":       "s3",
+			"backup-s3-endpoint":   "http://10.0.0.1:9000",
+			"backup-s3-bucket":     "backups",
+			"backup-s3-access-key": "access",
+			"backup-s3-secret-key": "secret",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	redacted := cfg.WithoutSecrets()
+	c.Check(redacted.BackupS3Endpoint(), gc.Equals, "http://10.0.0.1:9000")
+	c.Check(redacted.BackupS3Bucket(), gc.Equals, "backups")
+	c.Check(redacted.BackupS3AccessKey(), gc.Equals, "")
+	c.Check(redacted.BackupS3SecretKey(), gc.Equals, "")
+}
+
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
@@ -332,6 +526,27 @@ func (s *ConfigSuite) TestNetworkSpaceConfigDefaults(c *gc.C) {
 	c.Assert(cfg.JujuManagementSpace(), gc.Equals, "")
 }
 
+func (s *ConfigSuite) TestHAMemberPolicyConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.JujuHAPrimaries: []interface{}{"2", "0"},
+			controller.JujuHAObservers: []interface{}{"3"},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.JujuHAPrimaries(), jc.DeepEquals, []string{"2", "0"})
+	c.Assert(cfg.JujuHAObservers().SortedValues(), jc.DeepEquals, []string{"3"})
+}
+
+func (s *ConfigSuite) TestHAMemberPolicyConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.JujuHAPrimaries(), gc.HasLen, 0)
+	c.Assert(cfg.JujuHAObservers().IsEmpty(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -341,6 +556,7 @@ func (s *ConfigSuite) TestAuditLogDefaults(c *gc.C) {
 	c.Assert(cfg.AuditLogMaxBackups(), gc.Equals, 10)
 	c.Assert(cfg.AuditLogExcludeMethods(), gc.DeepEquals,
 		set.NewStrings(controller.DefaultAuditLogExcludeMethods...))
+	c.Assert(cfg.AuditLogBackends(), gc.DeepEquals, set.NewStrings("file"))
 }
 
 func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
@@ -353,6 +569,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 			"audit-log-max-size":        "100M",
 			"audit-log-max-backups":     10.0,
 			"audit-log-exclude-methods": []string{"Fleet.Foxes", "King.Gizzard", "ReadOnlyMethods"},
+			"audit-log-backends":        []string{"file", "database"},
 		},
 	)
 	c.Assert(err, jc.ErrorIsNil)
@@ -365,6 +582,7 @@ func (s *ConfigSuite) TestAuditLogValues(c *gc.C) {
 		"King.Gizzard",
 		"ReadOnlyMethods",
 	))
+	c.Assert(cfg.AuditLogBackends(), gc.DeepEquals, set.NewStrings("file", "database"))
 }
 
 func (s *ConfigSuite) TestAuditLogExcludeMethodsType(c *gc.C) {
@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Schedule is a parsed cron-style schedule, used to decide when a
+// scheduled action should next be run. All times are evaluated in UTC.
+type Schedule struct {
+	spec string
+
+	minutes, hours, days, months, weekdays uint64
+
+	// anyDay and anyWeekday record whether the day of month and day of
+	// week fields were unrestricted. As in cron, if both are
+	// restricted then a time matching either of them matches.
+	anyDay, anyWeekday bool
+}
+
+// scheduleDescriptors holds the shorthand schedules that can be used
+// in place of the five fields.
+var scheduleDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var (
+	monthNames   = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+	weekdayNames = []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}
+)
+
+type scheduleField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var scheduleFields = []scheduleField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	// 7 is accepted as Sunday, as in cron.
+	{name: "day of week", min: 0, max: 7, names: weekdayNames},
+}
+
+// ParseSchedule parses a schedule in the five field cron format
+// "minute hour day-of-month month day-of-week", or one of the
+// descriptors @yearly, @monthly, @weekly, @daily or @hourly.
+//
+// Each field may be "*", a value, a range "a-b" or a comma separated
+// list of them, and "*" and ranges may be followed by "/step". Months
+// and days of the week may also be given by their three letter
+// English names.
+func ParseSchedule(spec string) (Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	expanded := spec
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		expanded, ok = scheduleDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return Schedule{}, errors.NotValidf("schedule descriptor %q", spec)
+		}
+	}
+	fields := strings.Fields(expanded)
+	if len(fields) != len(scheduleFields) {
+		return Schedule{}, errors.NotValidf("schedule %q: expected %d fields, got %d", spec, len(scheduleFields), len(fields))
+	}
+	var values [5]uint64
+	for i, field := range scheduleFields {
+		bits, err := field.parse(strings.ToLower(fields[i]))
+		if err != nil {
+			return Schedule{}, errors.Annotatef(err, "schedule %q", spec)
+		}
+		values[i] = bits
+	}
+	// Sunday may be given as either 0 or 7.
+	if values[4]&(1<<7) != 0 {
+		values[4] |= 1
+	}
+	return Schedule{
+		spec:       spec,
+		minutes:    values[0],
+		hours:      values[1],
+		days:       values[2],
+		months:     values[3],
+		weekdays:   values[4],
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// String returns the schedule as it was given to ParseSchedule.
+func (s Schedule) String() string {
+	return s.spec
+}
+
+// maxScheduleSearch bounds the search for the next time a schedule
+// matches, so that schedules that can never match (such as the 30th
+// of February) don't search forever.
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// Next returns the first time after t, to the minute, that matches the
+// schedule. The zero time is returned if the schedule never matches.
+func (s Schedule) Next(t time.Time) time.Time {
+	t = t.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	for t.Before(limit) {
+		if !has(s.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !has(s.hours, t.Hour()) {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if !has(s.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s Schedule) matchesDay(t time.Time) bool {
+	day := has(s.days, t.Day())
+	weekday := has(s.weekdays, int(t.Weekday()))
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
+
+// parse returns the set of values matched by the field as a bit set.
+func (f scheduleField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			rangePart = part[:i]
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step <= 0 {
+				return 0, errors.NotValidf("%s step %q", f.name, part[i+1:])
+			}
+		}
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = f.value(bounds[0]); err != nil {
+				return 0, errors.Trace(err)
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = f.value(bounds[1]); err != nil {
+					return 0, errors.Trace(err)
+				}
+			} else if step != 1 {
+				// A single value with a step runs to the end of the range.
+				high = f.max
+			}
+			if high < low {
+				return 0, errors.NotValidf("%s range %q", f.name, rangePart)
+			}
+		}
+		for v := low; v <= high; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single value of the field, which may be a number or
+// a name.
+func (f scheduleField) value(value string) (int, error) {
+	for i, name := range f.names {
+		if value == name {
+			// Month names start at 1, weekday names at 0.
+			return i + f.min, nil
+		}
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil || v < f.min || v > f.max {
+		return 0, errors.NotValidf("%s %q", f.name, value)
+	}
+	return v, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actions_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/actions"
+)
+
+type scheduleSuite struct{}
+
+var _ = gc.Suite(&scheduleSuite{})
+
+// start is a Wednesday.
+var start = time.Date(2018, time.October, 17, 10, 30, 15, 0, time.UTC)
+
+func (s *scheduleSuite) TestNext(c *gc.C) {
+	for i, test := range []struct {
+		spec   string
+		expect time.Time
+	}{{
+		spec:   "* * * * *",
+		expect: time.Date(2018, time.October, 17, 10, 31, 0, 0, time.UTC),
+	}, {
+		spec:   "0 2 * * *",
+		expect: time.Date(2018, time.October, 18, 2, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "45 10 * * *",
+		expect: time.Date(2018, time.October, 17, 10, 45, 0, 0, time.UTC),
+	}, {
+		spec:   "*/20 * * * *",
+		expect: time.Date(2018, time.October, 17, 10, 40, 0, 0, time.UTC),
+	}, {
+		spec:   "0 9-17/4 * * *",
+		expect: time.Date(2018, time.October, 17, 13, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * sun",
+		expect: time.Date(2018, time.October, 21, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 * * 7",
+		expect: time.Date(2018, time.October, 21, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "0 0 1,15 * *",
+		expect: time.Date(2018, time.November, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		// Either the day of the month or the day of the week matches.
+		spec:   "0 0 1 * fri",
+		expect: time.Date(2018, time.October, 19, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "30 4 29 feb *",
+		expect: time.Date(2020, time.February, 29, 4, 30, 0, 0, time.UTC),
+	}, {
+		spec:   "@daily",
+		expect: time.Date(2018, time.October, 18, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@hourly",
+		expect: time.Date(2018, time.October, 17, 11, 0, 0, 0, time.UTC),
+	}, {
+		spec:   "@yearly",
+		expect: time.Date(2019, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}, {
+		spec: "0 0 30 feb *",
+	}} {
+		c.Logf("test %d: %s", i, test.spec)
+		schedule, err := actions.ParseSchedule(test.spec)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(schedule.String(), gc.Equals, test.spec)
+		c.Check(schedule.Next(start), gc.Equals, test.expect)
+	}
+}
+
+func (s *scheduleSuite) TestNextUsesUTC(c *gc.C) {
+	schedule, err := actions.ParseSchedule("0 2 * * *")
+	c.Assert(err, jc.ErrorIsNil)
+	local := start.In(time.FixedZone("UTC+5", 5*60*60))
+	c.Check(schedule.Next(local), gc.Equals, time.Date(2018, time.October, 18, 2, 0, 0, 0, time.UTC))
+}
+
+func (s *scheduleSuite) TestParseErrors(c *gc.C) {
+	for i, test := range []struct {
+		spec string
+		err  string
+	}{{
+		spec: "",
+		err:  `schedule "": expected 5 fields, got 0 not valid`,
+	}, {
+		spec: "* * * *",
+		err:  `schedule "\* \* \* \*": expected 5 fields, got 4 not valid`,
+	}, {
+		spec: "@fortnightly",
+		err:  `schedule descriptor "@fortnightly" not valid`,
+	}, {
+		spec: "60 * * * *",
+		err:  `schedule "60 \* \* \* \*": minute "60" not valid`,
+	}, {
+		spec: "* 24 * * *",
+		err:  `schedule "\* 24 \* \* \*": hour "24" not valid`,
+	}, {
+		spec: "* * 0 * *",
+		err:  `schedule "\* \* 0 \* \*": day of month "0" not valid`,
+	}, {
+		spec: "* * * foo *",
+		err:  `schedule "\* \* \* foo \*": month "foo" not valid`,
+	}, {
+		spec: "*/0 * * * *",
+		err:  `schedule "\*/0 \* \* \* \*": minute step "0" not valid`,
+	}, {
+		spec: "* * * * 5-1",
+		err:  `schedule "\* \* \* \* 5-1": day of week range "5-1" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.spec)
+		_, err := actions.ParseSchedule(test.spec)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
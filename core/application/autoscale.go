@@ -0,0 +1,62 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+)
+
+// AutoscalePolicy describes how the number of units of a CAAS
+// application follows the load on its pods. The substrate picks the
+// scale between MinUnits and MaxUnits which keeps the pods at the
+// target: either an average CPU utilisation, or an average value of
+// a custom per-pod metric.
+type AutoscalePolicy struct {
+	MinUnits int
+	MaxUnits int
+
+	// TargetCPUPercent is the average CPU utilisation, as a
+	// percentage of the CPU requested by the pods, to scale for.
+	TargetCPUPercent int
+
+	// Metric is the name of a custom per-pod metric to scale on,
+	// and MetricTarget the average value of it to scale for.
+	Metric       string
+	MetricTarget string
+}
+
+// Validate returns an error if the policy is not valid.
+func (p AutoscalePolicy) Validate() error {
+	if p.MinUnits < 1 {
+		return errors.NotValidf("min units %d", p.MinUnits)
+	}
+	if p.MaxUnits < p.MinUnits {
+		return errors.NotValidf("max units %d less than min units %d", p.MaxUnits, p.MinUnits)
+	}
+	if p.TargetCPUPercent < 0 {
+		return errors.NotValidf("target cpu percent %d", p.TargetCPUPercent)
+	}
+	if (p.TargetCPUPercent == 0) == (p.Metric == "") {
+		return errors.NotValidf("autoscale policy without exactly one of a cpu or metric target")
+	}
+	if p.Metric != "" && p.MetricTarget == "" {
+		return errors.NotValidf("metric %q without a target", p.Metric)
+	}
+	if p.Metric == "" && p.MetricTarget != "" {
+		return errors.NotValidf("metric target without a metric")
+	}
+	return nil
+}
+
+// Clamp returns the scale closest to the specified one
+// which is allowed by the policy.
+func (p AutoscalePolicy) Clamp(scale int) int {
+	if scale < p.MinUnits {
+		return p.MinUnits
+	}
+	if scale > p.MaxUnits {
+		return p.MaxUnits
+	}
+	return scale
+}
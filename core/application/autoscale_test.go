@@ -0,0 +1,65 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscaleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) TestValidate(c *gc.C) {
+	for i, test := range []struct {
+		policy application.AutoscalePolicy
+		err    string
+	}{{
+		policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 3, TargetCPUPercent: 80},
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 2, MaxUnits: 2, Metric: "requests", MetricTarget: "100"},
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 0, MaxUnits: 3, TargetCPUPercent: 80},
+		err:    "min units 0 not valid",
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 3, MaxUnits: 2, TargetCPUPercent: 80},
+		err:    "max units 2 less than min units 3 not valid",
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 3, TargetCPUPercent: -1},
+		err:    "target cpu percent -1 not valid",
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 3},
+		err:    "autoscale policy without exactly one of a cpu or metric target not valid",
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 3, TargetCPUPercent: 80, Metric: "requests", MetricTarget: "100"},
+		err:    "autoscale policy without exactly one of a cpu or metric target not valid",
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 3, Metric: "requests"},
+		err:    `metric "requests" without a target not valid`,
+	}, {
+		policy: application.AutoscalePolicy{MinUnits: 1, MaxUnits: 3, TargetCPUPercent: 80, MetricTarget: "100"},
+		err:    "metric target without a metric not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := test.policy.Validate()
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *AutoscaleSuite) TestClamp(c *gc.C) {
+	policy := application.AutoscalePolicy{MinUnits: 2, MaxUnits: 5, TargetCPUPercent: 80}
+	c.Assert(policy.Clamp(1), gc.Equals, 2)
+	c.Assert(policy.Clamp(3), gc.Equals, 3)
+	c.Assert(policy.Clamp(7), gc.Equals, 5)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -138,6 +139,42 @@ func (s *AuditLogSuite) TestRecorder(c *gc.C) {
 	})
 }
 
+func (s *AuditLogSuite) TestMultiLog(c *gc.C) {
+	var log1, log2 fakeLog
+	log1.stub.SetErrors(nil, errors.New("boom"))
+	multi := auditlog.NewMultiLog(&log1, &log2)
+
+	conversation := auditlog.Conversation{Who: "bob", ConversationID: "abc"}
+	err := multi.AddConversation(conversation)
+	c.Assert(err, jc.ErrorIsNil)
+	request := auditlog.Request{ConversationID: "abc", RequestID: 1}
+	err = multi.AddRequest(request)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	err = multi.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A failure writing to the first log doesn't stop the record
+	// reaching the second.
+	log1.stub.CheckCalls(c, []testing.StubCall{
+		{"AddConversation", []interface{}{conversation}},
+		{"AddRequest", []interface{}{request}},
+		{"Close", nil},
+	})
+	log2.stub.CheckCalls(c, []testing.StubCall{
+		{"AddConversation", []interface{}{conversation}},
+		{"AddRequest", []interface{}{request}},
+		{"Close", nil},
+	})
+}
+
+func (s *AuditLogSuite) TestQueryValidate(c *gc.C) {
+	from := time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC)
+	c.Check(auditlog.Query{}.Validate(), jc.ErrorIsNil)
+	c.Check(auditlog.Query{From: from, To: from.Add(time.Hour), Limit: 10}.Validate(), jc.ErrorIsNil)
+	c.Check(auditlog.Query{Limit: -1}.Validate(), gc.ErrorMatches, "negative limit -1 not valid")
+	c.Check(auditlog.Query{From: from, To: from.Add(-time.Hour)}.Validate(), gc.ErrorMatches, "time range ending before it starts not valid")
+}
+
 type fakeLog struct {
 	stub testing.Stub
 }
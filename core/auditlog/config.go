@@ -29,6 +29,11 @@ type Config struct {
 	// consists of these method calls we won't log it.
 	ExcludeMethods set.Strings
 
+	// Backends holds the names of the places audit records are
+	// stored: see controller.AuditLogBackendFile and
+	// controller.AuditLogBackendDatabase.
+	Backends set.Strings
+
 	// Target is the AuditLog entries should be written to.
 	Target AuditLog
 }
@@ -0,0 +1,61 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"github.com/juju/errors"
+)
+
+// NewMultiLog returns an AuditLog that writes every record to each of
+// the logs passed in. A failure to write to one log doesn't stop the
+// record being written to the others; the first error encountered is
+// returned.
+func NewMultiLog(logs ...AuditLog) AuditLog {
+	return &multiLog{logs: logs}
+}
+
+type multiLog struct {
+	logs []AuditLog
+}
+
+// AddConversation implements AuditLog.
+func (m *multiLog) AddConversation(c Conversation) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddConversation(c)
+	})
+}
+
+// AddRequest implements AuditLog.
+func (m *multiLog) AddRequest(r Request) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddRequest(r)
+	})
+}
+
+// AddResponse implements AuditLog.
+func (m *multiLog) AddResponse(r ResponseErrors) error {
+	return m.each(func(log AuditLog) error {
+		return log.AddResponse(r)
+	})
+}
+
+// Close implements AuditLog.
+func (m *multiLog) Close() error {
+	return m.each(func(log AuditLog) error {
+		return log.Close()
+	})
+}
+
+func (m *multiLog) each(f func(AuditLog) error) error {
+	var firstErr error
+	for _, log := range m.logs {
+		if err := f(log); err != nil {
+			logger.Errorf("audit log write failed: %v", err)
+			if firstErr == nil {
+				firstErr = errors.Trace(err)
+			}
+		}
+	}
+	return firstErr
+}
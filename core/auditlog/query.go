@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Query describes the audit records to be retrieved from an audit log
+// that supports querying. Empty fields match everything.
+type Query struct {
+	// User restricts the results to requests made by the named user
+	// (as recorded in Conversation.Who).
+	User string
+
+	// Model restricts the results to requests made against the
+	// model with the given name ("owner/name") or UUID.
+	Model string
+
+	// Facade restricts the results to calls to the named facade.
+	Facade string
+
+	// Method restricts the results to calls to the named method.
+	Method string
+
+	// From excludes requests made before this time.
+	From time.Time
+
+	// To excludes requests made after this time.
+	To time.Time
+
+	// Limit is the maximum number of entries to return, most recent
+	// first. Zero means no limit.
+	Limit int
+}
+
+// Validate checks that the query is sensible.
+func (q Query) Validate() error {
+	if q.Limit < 0 {
+		return errors.NotValidf("negative limit %d", q.Limit)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && q.To.Before(q.From) {
+		return errors.NotValidf("time range ending before it starts")
+	}
+	return nil
+}
+
+// Entry is a single API request from the audit log along with the
+// conversation it was part of and any errors it returned.
+type Entry struct {
+	Conversation Conversation
+	Request      Request
+	Errors       []*Error
+}
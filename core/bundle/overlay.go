@@ -0,0 +1,199 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle holds bundle handling that is shared between the client
+// and the API server.
+package bundle
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/yaml.v2"
+)
+
+// Overlay holds a bundle overlay: a partial bundle that is merged onto
+// a base bundle to change it.
+//
+// Applying an overlay to a bundle has the following effects:
+//   - an application with a null entry in the overlay is removed from
+//     the bundle, along with any relations that refer to it;
+//   - an application that is not in the bundle is added to it;
+//   - for an application in both, each field set in the overlay
+//     replaces the bundle value, except for options, annotations,
+//     resources, storage, devices and bindings which are merged key by
+//     key;
+//   - the default series is replaced if the overlay sets one;
+//   - relations in the overlay are added to the bundle unless the
+//     bundle already has them;
+//   - machines in the overlay replace all machines in the bundle.
+type Overlay struct {
+	// Data holds the parsed overlay. Callers may resolve any
+	// include directives in option and annotation values before the
+	// overlay is applied.
+	Data *charm.BundleData
+
+	// fields records which fields each application sets in the
+	// overlay YAML, so that zero values can override bundle values.
+	fields map[string]map[string]interface{}
+}
+
+type overlayFields struct {
+	Applications map[string]map[string]interface{} `yaml:"applications"`
+}
+
+// ParseOverlay parses the YAML content of a bundle overlay.
+func ParseOverlay(content []byte) (*Overlay, error) {
+	data, err := charm.ReadBundleData(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// If the content parsed as a bundle, this will also succeed.
+	var fields overlayFields
+	if err := yaml.Unmarshal(content, &fields); err != nil {
+		return nil, errors.Annotate(err, "unable to deserialize config structure")
+	}
+	// The bundle data also accepts the deprecated top level "services"
+	// key, which overlays do not support.
+	if len(fields.Applications) == 0 && len(data.Applications) > 0 {
+		return nil, errors.New("used deprecated 'services' key, this is not valid for bundle overlay files")
+	}
+	return &Overlay{
+		Data:   data,
+		fields: fields.Applications,
+	}, nil
+}
+
+// Apply merges the overlay onto the bundle data.
+func (o *Overlay) Apply(data *charm.BundleData) {
+	if data.Applications == nil {
+		data.Applications = make(map[string]*charm.ApplicationSpec)
+	}
+	for appName, spec := range o.Data.Applications {
+		if spec == nil {
+			delete(data.Applications, appName)
+			data.Relations = RemoveRelations(data.Relations, appName)
+			continue
+		}
+		app, found := data.Applications[appName]
+		if !found {
+			data.Applications[appName] = spec
+			continue
+		}
+		applyApplication(app, spec, o.fields[appName])
+	}
+
+	if o.Data.Series != "" {
+		data.Series = o.Data.Series
+	}
+	for _, relation := range o.Data.Relations {
+		if !hasRelation(data.Relations, relation) {
+			data.Relations = append(data.Relations, relation)
+		}
+	}
+	if o.Data.Machines != nil {
+		data.Machines = o.Data.Machines
+	}
+}
+
+func applyApplication(app, overlay *charm.ApplicationSpec, fields map[string]interface{}) {
+	isSet := func(field string) bool {
+		_, set := fields[field]
+		return set
+	}
+	if isSet("charm") {
+		app.Charm = overlay.Charm
+	}
+	if isSet("series") {
+		app.Series = overlay.Series
+	}
+	if isSet("resources") {
+		if app.Resources == nil {
+			app.Resources = make(map[string]interface{})
+		}
+		for key, value := range overlay.Resources {
+			app.Resources[key] = value
+		}
+	}
+	if isSet("num_units") {
+		app.NumUnits = overlay.NumUnits
+	}
+	if isSet("to") {
+		app.To = overlay.To
+	}
+	if isSet("expose") {
+		app.Expose = overlay.Expose
+	}
+	if isSet("options") {
+		if app.Options == nil {
+			app.Options = make(map[string]interface{})
+		}
+		for key, value := range overlay.Options {
+			app.Options[key] = value
+		}
+	}
+	if isSet("annotations") {
+		app.Annotations = mergeStrings(app.Annotations, overlay.Annotations)
+	}
+	if isSet("constraints") {
+		app.Constraints = overlay.Constraints
+	}
+	if isSet("storage") {
+		app.Storage = mergeStrings(app.Storage, overlay.Storage)
+	}
+	if isSet("devices") {
+		app.Devices = mergeStrings(app.Devices, overlay.Devices)
+	}
+	if isSet("bindings") {
+		app.EndpointBindings = mergeStrings(app.EndpointBindings, overlay.EndpointBindings)
+	}
+}
+
+func mergeStrings(base, overlay map[string]string) map[string]string {
+	if base == nil {
+		base = make(map[string]string)
+	}
+	for key, value := range overlay {
+		base[key] = value
+	}
+	return base
+}
+
+// hasRelation returns whether the relations include the given relation,
+// in either order.
+func hasRelation(relations [][]string, relation []string) bool {
+	if len(relation) != 2 {
+		return false
+	}
+	for _, existing := range relations {
+		if len(existing) != 2 {
+			continue
+		}
+		if (existing[0] == relation[0] && existing[1] == relation[1]) ||
+			(existing[0] == relation[1] && existing[1] == relation[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveRelations removes any relation that references the application
+// appName.
+func RemoveRelations(relations [][]string, appName string) [][]string {
+	var result [][]string
+	for _, relation := range relations {
+		// Keep the dud relation in the set, it will be caught by the bundle
+		// verify code.
+		if len(relation) == 2 {
+			left, right := relation[0], relation[1]
+			if left == appName || strings.HasPrefix(left, appName+":") ||
+				right == appName || strings.HasPrefix(right, appName+":") {
+				continue
+			}
+		}
+		result = append(result, relation)
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"strings"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/bundle"
+)
+
+type overlaySuite struct {
+	data *charm.BundleData
+}
+
+var _ = gc.Suite(&overlaySuite{})
+
+func (s *overlaySuite) SetUpTest(c *gc.C) {
+	var err error
+	s.data, err = charm.ReadBundleData(strings.NewReader(`
+applications:
+    django:
+        expose: true
+        charm: cs:django
+        num_units: 1
+        options:
+            general: good
+        to: ["1"]
+    memcached:
+        charm: xenial/mem-47
+        num_units: 1
+relations:
+    - - django
+      - memcached
+machines:
+    "1":
+`))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *overlaySuite) apply(c *gc.C, content string) {
+	overlay, err := bundle.ParseOverlay([]byte(content))
+	c.Assert(err, jc.ErrorIsNil)
+	overlay.Apply(s.data)
+}
+
+func (s *overlaySuite) TestParseBadYAML(c *gc.C) {
+	_, err := bundle.ParseOverlay([]byte("bad:\n\tindent"))
+	c.Assert(err, gc.ErrorMatches, `cannot unmarshal bundle data: yaml: line 2: found character that cannot start any token`)
+}
+
+func (s *overlaySuite) TestParseServices(c *gc.C) {
+	_, err := bundle.ParseOverlay([]byte(`
+services:
+    django:
+        charm: cs:django
+`))
+	c.Assert(err, gc.ErrorMatches, `used deprecated 'services' key, this is not valid for bundle overlay files`)
+}
+
+func (s *overlaySuite) TestOverrideFields(c *gc.C) {
+	s.apply(c, `
+applications:
+    django:
+        expose: false
+        num_units: 3
+        options:
+            extra: value
+`)
+	django := s.data.Applications["django"]
+	c.Check(django.Expose, jc.IsFalse)
+	c.Check(django.NumUnits, gc.Equals, 3)
+	c.Check(django.Charm, gc.Equals, "cs:django")
+	c.Check(django.To, jc.DeepEquals, []string{"1"})
+	c.Check(django.Options, jc.DeepEquals, map[string]interface{}{
+		"general": "good",
+		"extra":   "value",
+	})
+}
+
+func (s *overlaySuite) TestAddApplication(c *gc.C) {
+	s.apply(c, `
+applications:
+    postgresql:
+        charm: cs:postgresql
+        num_units: 1
+relations:
+    - - postgresql:db
+      - django:db
+`)
+	c.Check(s.data.Applications["postgresql"].Charm, gc.Equals, "cs:postgresql")
+	c.Check(s.data.Relations, jc.DeepEquals, [][]string{
+		{"django", "memcached"},
+		{"postgresql:db", "django:db"},
+	})
+}
+
+func (s *overlaySuite) TestRemoveApplication(c *gc.C) {
+	s.apply(c, `
+applications:
+    memcached:
+`)
+	_, found := s.data.Applications["memcached"]
+	c.Check(found, jc.IsFalse)
+	c.Check(s.data.Relations, gc.HasLen, 0)
+}
+
+func (s *overlaySuite) TestDuplicateRelationsIgnored(c *gc.C) {
+	s.apply(c, `
+relations:
+    - - memcached
+      - django
+`)
+	c.Check(s.data.Relations, jc.DeepEquals, [][]string{
+		{"django", "memcached"},
+	})
+}
+
+func (s *overlaySuite) TestSeriesAndMachines(c *gc.C) {
+	s.apply(c, `
+series: bionic
+machines:
+    "2":
+        constraints: mem=4G
+`)
+	c.Check(s.data.Series, gc.Equals, "bionic")
+	c.Check(s.data.Machines, jc.DeepEquals, map[string]*charm.MachineSpec{
+		"2": {Constraints: "mem=4G"},
+	})
+}
+
+type removeRelationsSuite struct{}
+
+var (
+	_ = gc.Suite(&removeRelationsSuite{})
+
+	sampleRelations = [][]string{
+		{"kubernetes-master:kube-control", "kubernetes-worker:kube-control"},
+		{"kubernetes-master:etcd", "etcd:db"},
+		{"kubernetes-worker:kube-api-endpoint", "kubeapi-load-balancer:website"},
+		{"flannel", "etcd"}, // removed :endpoint
+		{"flannel:cni", "kubernetes-master:cni"},
+		{"flannel:cni", "kubernetes-worker:cni"},
+	}
+)
+
+func (*removeRelationsSuite) TestNil(c *gc.C) {
+	result := bundle.RemoveRelations(nil, "foo")
+	c.Assert(result, gc.HasLen, 0)
+}
+
+func (*removeRelationsSuite) TestEmpty(c *gc.C) {
+	result := bundle.RemoveRelations([][]string{}, "foo")
+	c.Assert(result, gc.HasLen, 0)
+}
+
+func (*removeRelationsSuite) TestAppNotThere(c *gc.C) {
+	result := bundle.RemoveRelations(sampleRelations, "foo")
+	c.Assert(result, jc.DeepEquals, sampleRelations)
+}
+
+func (*removeRelationsSuite) TestAppBadRelationsKept(c *gc.C) {
+	badRelations := [][]string{{"single value"}, {"three", "string", "values"}}
+	result := bundle.RemoveRelations(badRelations, "foo")
+	c.Assert(result, jc.DeepEquals, badRelations)
+}
+
+func (*removeRelationsSuite) TestRemoveFromRight(c *gc.C) {
+	result := bundle.RemoveRelations(sampleRelations, "etcd")
+	c.Assert(result, jc.DeepEquals, [][]string{
+		{"kubernetes-master:kube-control", "kubernetes-worker:kube-control"},
+		{"kubernetes-worker:kube-api-endpoint", "kubeapi-load-balancer:website"},
+		{"flannel:cni", "kubernetes-master:cni"},
+		{"flannel:cni", "kubernetes-worker:cni"},
+	})
+}
+
+func (*removeRelationsSuite) TestRemoveFromLeft(c *gc.C) {
+	result := bundle.RemoveRelations(sampleRelations, "flannel")
+	c.Assert(result, jc.DeepEquals, [][]string{
+		{"kubernetes-master:kube-control", "kubernetes-worker:kube-control"},
+		{"kubernetes-master:etcd", "etcd:db"},
+		{"kubernetes-worker:kube-api-endpoint", "kubeapi-load-balancer:website"},
+	})
+}
@@ -38,6 +38,40 @@ type Claimer interface {
 	BlockUntilLeadershipReleased(applicationId string, cancel <-chan struct{}) (err error)
 }
 
+// Pinner exposes the ability to hold an application's leadership with
+// its current leader, regardless of whether the leader unit's agent
+// keeps claiming it.
+type Pinner interface {
+
+	// PinLeadership ensures that the leadership of the named application
+	// will not change until UnpinLeadership is called with the same
+	// entity. The entity is typically the tag of whatever requires the
+	// leadership to be stable, such as a machine being upgraded.
+	PinLeadership(applicationId string, entity string) error
+
+	// UnpinLeadership removes the named entity's pin on the leadership
+	// of the named application.
+	UnpinLeadership(applicationId string, entity string) error
+
+	// PinnedLeadership returns the names of applications with pinned
+	// leadership, each with the entities that pinned it.
+	PinnedLeadership() map[string][]string
+}
+
+// Transferrer exposes the ability to hand an application's leadership
+// to a chosen unit.
+type Transferrer interface {
+
+	// TransferLeadership hands the leadership of the named application
+	// to the named unit, which is then guaranteed to hold it for at
+	// least the supplied duration after the current leader's term runs
+	// out. The current leader discovers it has been deposed when it next
+	// tries to extend its claim, before the new leader is told that it
+	// has been elected. It returns an error satisfying errors.IsNotFound
+	// if the application has no leader.
+	TransferLeadership(applicationId string, unitId string, duration time.Duration) error
+}
+
 // Token represents a unit's leadership of its application.
 type Token interface {
 
@@ -0,0 +1,36 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadership
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lease"
+)
+
+// NewPinner returns a Pinner that pins application leadership by
+// pinning the leases held by the given lease.Pinner.
+func NewPinner(pinner lease.Pinner) Pinner {
+	return leasePinner{pinner}
+}
+
+// leasePinner implements Pinner by wrapping a lease.Pinner.
+type leasePinner struct {
+	pinner lease.Pinner
+}
+
+// PinLeadership is part of the Pinner interface.
+func (m leasePinner) PinLeadership(applicationname, entity string) error {
+	return errors.Trace(m.pinner.PinLease(applicationname, entity))
+}
+
+// UnpinLeadership is part of the Pinner interface.
+func (m leasePinner) UnpinLeadership(applicationname, entity string) error {
+	return errors.Trace(m.pinner.UnpinLease(applicationname, entity))
+}
+
+// PinnedLeadership is part of the Pinner interface.
+func (m leasePinner) PinnedLeadership() map[string][]string {
+	return m.pinner.Pinned()
+}
@@ -25,6 +25,10 @@ var ErrClaimDenied = errors.New("lease claim denied")
 // ErrNotHeld indicates that some holder does not hold some lease.
 var ErrNotHeld = errors.New("lease not held")
 
+// ErrPinned indicates that a lease cannot change hands because it is
+// pinned.
+var ErrPinned = errors.New("lease pinned")
+
 // ErrWaitCancelled is returned by Claimer.WaitUntilExpired if the
 // cancel channel is closed.
 var ErrWaitCancelled = errors.New("waiting for lease cancelled by client")
@@ -70,9 +74,42 @@ type Token interface {
 	Check(trapdoorKey interface{}) error
 }
 
-// Manager represents somewhere you can get Checkers and Claimers for
-// different models.
+// Pinner exposes the ability to hold leases regardless of the
+// liveness of their holders.
+type Pinner interface {
+
+	// PinLease ensures that the named lease will not expire until
+	// UnpinLease is called with the same entity. Pinning a lease that
+	// is already pinned by the entity is a no-op.
+	PinLease(leaseName, entity string) error
+
+	// UnpinLease removes the named entity's pin on the named lease.
+	// Unpinning a lease that the entity has not pinned is a no-op.
+	UnpinLease(leaseName, entity string) error
+
+	// Pinned returns the names of pinned leases, each with the
+	// entities that pinned it.
+	Pinned() map[string][]string
+}
+
+// Transferrer exposes the ability to hand a held lease to a new holder.
+type Transferrer interface {
+
+	// Transfer hands the named lease to the named holder, which is then
+	// guaranteed to keep it for at least duration after the previous
+	// holder's term runs out. Parties waiting for the lease are only
+	// released once that term has run out, so that the previous holder
+	// has the chance to discover it no longer holds the lease first. It
+	// returns ErrNotHeld if the lease is not held by anyone, and
+	// ErrPinned if the lease is pinned.
+	Transfer(leaseName, holderName string, duration time.Duration) error
+}
+
+// Manager represents somewhere you can get Checkers, Claimers,
+// Pinners and Transferrers for different models.
 type Manager interface {
 	Checker(namespace string, modelUUID string) (Checker, error)
 	Claimer(namespace string, modelUUID string) (Claimer, error)
+	Pinner(namespace string, modelUUID string) (Pinner, error)
+	Transferrer(namespace string, modelUUID string) (Transferrer, error)
 }
@@ -27,6 +27,12 @@ type Store interface {
 	// If it returns ErrInvalid, check Leases() for updated state.
 	ExtendLease(lease Key, request Request) error
 
+	// TransferLease records that the supplied lease, currently held by
+	// the supplied holder, is now held by the request's holder for the
+	// requested duration. If it returns ErrInvalid, check Leases() for
+	// updated state.
+	TransferLease(lease Key, holder string, request Request) error
+
 	// ExpireLease records the vacation of the supplied lease. It will fail if
 	// we cannot verify that the lease's writer considers the expiry time to
 	// have passed. If it returns ErrInvalid, check Leases() for updated state.
@@ -36,6 +42,20 @@ type Store interface {
 	// expressed according to the Clock the store was configured with.
 	Leases() map[Key]Info
 
+	// PinLease ensures that the supplied lease will not expire until
+	// UnpinLease is called for it by every entity that pinned it. The
+	// holder may continue to extend a pinned lease, but no other
+	// holder may claim it.
+	PinLease(lease Key, entity string) error
+
+	// UnpinLease removes the supplied entity's pin on the supplied
+	// lease. Once no pins remain the lease will expire normally.
+	UnpinLease(lease Key, entity string) error
+
+	// Pinned returns a snapshot of pinned leases, and the entities
+	// that pinned each of them.
+	Pinned() map[Key][]string
+
 	// TODO (jam) 2017-10-31: Many callers of Leases() actually only tant
 	// exactly 1 lease, we should have a way to do a query to return exactly
 	// that lease, instead of having to read all of them to pull one out of the
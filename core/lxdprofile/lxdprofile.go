@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package lxdprofile defines how the LXD profiles supplied by charms in
+// lxd-profile.yaml are named and which of their contents juju allows.
+package lxdprofile
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+)
+
+// Prefix is the prefix of the names of all LXD profiles created by juju
+// for charms.
+const Prefix = "juju-"
+
+var (
+	// allowedConfigKeys holds the LXD config keys a charm profile may
+	// set.
+	allowedConfigKeys = set.NewStrings(
+		"linux.kernel_modules",
+		"security.nesting",
+	)
+
+	// allowedConfigPrefixes holds the prefixes of the namespaced LXD
+	// config keys a charm profile may set.
+	allowedConfigPrefixes = []string{
+		"environment.",
+		"user.",
+	}
+
+	// allowedDeviceTypes holds the types of LXD device a charm profile
+	// may add.
+	allowedDeviceTypes = set.NewStrings(
+		"gpu",
+		"unix-block",
+		"unix-char",
+		"usb",
+	)
+)
+
+// Profile is a named LXD profile supplied by a charm.
+type Profile struct {
+	// Name is the name of the profile, as returned by Name.
+	Name string
+
+	// Profile holds the contents of the profile.
+	Profile *charm.LXDProfile
+}
+
+// Name returns the name of the LXD profile for the given revision of an
+// application's charm in the named model.
+func Name(modelName, appName string, revision int) string {
+	return fmt.Sprintf("%s%s-%s-%d", Prefix, modelName, appName, revision)
+}
+
+// IsEmpty reports whether the profile is missing or changes nothing.
+func IsEmpty(profile *charm.LXDProfile) bool {
+	return profile == nil || (len(profile.Config) == 0 && len(profile.Devices) == 0)
+}
+
+// Validate returns an error if the profile sets any config, or adds any
+// device, that is not in juju's allowlist. A nil profile is valid.
+func Validate(profile *charm.LXDProfile) error {
+	if profile == nil {
+		return nil
+	}
+	if err := profile.ValidateConfigDevices(); err != nil {
+		return errors.Trace(err)
+	}
+	keys := make([]string, 0, len(profile.Config))
+	for key := range profile.Config {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !allowedConfigKey(key) {
+			return errors.Errorf("invalid lxd-profile.yaml: contains config value %q", key)
+		}
+	}
+	names := make([]string, 0, len(profile.Devices))
+	for name := range profile.Devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if deviceType := profile.Devices[name]["type"]; !allowedDeviceTypes.Contains(deviceType) {
+			return errors.Errorf("invalid lxd-profile.yaml: contains device type %q", deviceType)
+		}
+	}
+	return nil
+}
+
+func allowedConfigKey(key string) bool {
+	if allowedConfigKeys.Contains(key) {
+		return true
+	}
+	for _, prefix := range allowedConfigPrefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,74 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lxdprofile_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/lxdprofile"
+)
+
+type LXDProfileSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LXDProfileSuite{})
+
+func (*LXDProfileSuite) TestName(c *gc.C) {
+	c.Assert(lxdprofile.Name("default", "mysql", 7), gc.Equals, "juju-default-mysql-7")
+}
+
+func (*LXDProfileSuite) TestIsEmpty(c *gc.C) {
+	c.Check(lxdprofile.IsEmpty(nil), jc.IsTrue)
+	c.Check(lxdprofile.IsEmpty(&charm.LXDProfile{Description: "nothing"}), jc.IsTrue)
+	c.Check(lxdprofile.IsEmpty(&charm.LXDProfile{
+		Config: map[string]string{"security.nesting": "true"},
+	}), jc.IsFalse)
+}
+
+func (*LXDProfileSuite) TestValidate(c *gc.C) {
+	err := lxdprofile.Validate(&charm.LXDProfile{
+		Config: map[string]string{
+			"security.nesting":       "true",
+			"linux.kernel_modules":   "openvswitch,nbd",
+			"environment.http_proxy": "",
+			"user.vendor-data":       "",
+		},
+		Devices: map[string]map[string]string{
+			"tun": {"type": "unix-char", "path": "/dev/net/tun"},
+			"gpu": {"type": "gpu"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lxdprofile.Validate(nil), jc.ErrorIsNil)
+}
+
+func (*LXDProfileSuite) TestValidateConfigNotAllowed(c *gc.C) {
+	err := lxdprofile.Validate(&charm.LXDProfile{
+		Config: map[string]string{
+			"security.nesting": "true",
+			"raw.lxc":          "lxc.aa_profile=unconfined",
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid lxd-profile.yaml: contains config value "raw.lxc"`)
+}
+
+func (*LXDProfileSuite) TestValidatePrivilegedNotAllowed(c *gc.C) {
+	err := lxdprofile.Validate(&charm.LXDProfile{
+		Config: map[string]string{"security.privileged": "true"},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid lxd-profile.yaml: contains config value "security.privileged"`)
+}
+
+func (*LXDProfileSuite) TestValidateDeviceNotAllowed(c *gc.C) {
+	err := lxdprofile.Validate(&charm.LXDProfile{
+		Devices: map[string]map[string]string{
+			"eth1": {"type": "nic", "nictype": "bridged", "parent": "br0"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid lxd-profile.yaml: contains device type "nic"`)
+}
@@ -51,6 +51,10 @@ type SerializedModel struct {
 
 	// Resources represents all the resources in use in the model.
 	Resources []SerializedModelResource
+
+	// CharmStates holds the charm state of each unit in the model
+	// that has any, keyed by unit name.
+	CharmStates map[string]map[string]string
 }
 
 // SerializedModelResource defines the resource revisions for a
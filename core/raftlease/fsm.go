@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/raft"
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/core/globalclock"
@@ -36,6 +37,17 @@ const (
 	// OperationSetTime denotes updating stored global time (which
 	// will also remove any expired leases).
 	OperationSetTime = "setTime"
+
+	// OperationPin denotes pinning a lease, so that it will not
+	// expire until unpinned.
+	OperationPin = "pin"
+
+	// OperationUnpin denotes removing an entity's pin on a lease.
+	OperationUnpin = "unpin"
+
+	// OperationTransfer denotes handing an already-held lease to a
+	// new holder.
+	OperationTransfer = "transfer"
 )
 
 // FSMResponse defines what will be available on the return value from
@@ -54,6 +66,7 @@ type FSMResponse interface {
 func NewFSM() *FSM {
 	return &FSM{
 		entries: make(map[lease.Key]*entry),
+		pinned:  make(map[lease.Key]set.Strings),
 	}
 }
 
@@ -62,6 +75,10 @@ type FSM struct {
 	mu         sync.Mutex
 	globalTime time.Time
 	entries    map[lease.Key]*entry
+
+	// pinned records, for each pinned lease, the entities that
+	// require it to be held regardless of its expiry.
+	pinned map[lease.Key]set.Strings
 }
 
 func (f *FSM) claim(key lease.Key, holder string, duration time.Duration) *response {
@@ -101,6 +118,28 @@ func (f *FSM) extend(key lease.Key, holder string, duration time.Duration) *resp
 	return &response{}
 }
 
+func (f *FSM) transfer(key lease.Key, holder, newHolder string, duration time.Duration) *response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entry, found := f.entries[key]
+	if !found {
+		return invalidResponse()
+	}
+	if entry.holder != holder {
+		return invalidResponse()
+	}
+	if _, pinned := f.pinned[key]; pinned {
+		// A pinned lease stays with its holder until unpinned.
+		return invalidResponse()
+	}
+	// entry is a pointer back into the f.entries map, so this update
+	// isn't lost.
+	entry.holder = newHolder
+	entry.start = f.globalTime
+	entry.duration = duration
+	return &response{claimed: key, claimer: newHolder}
+}
+
 func (f *FSM) setTime(oldTime, newTime time.Time) *response {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -110,6 +149,9 @@ func (f *FSM) setTime(oldTime, newTime time.Time) *response {
 	f.globalTime = newTime
 	var expired []lease.Key
 	for key, entry := range f.entries {
+		if _, pinned := f.pinned[key]; pinned {
+			continue
+		}
 		expiry := entry.start.Add(entry.duration)
 		if expiry.Before(newTime) {
 			delete(f.entries, key)
@@ -119,6 +161,30 @@ func (f *FSM) setTime(oldTime, newTime time.Time) *response {
 	return &response{expired: expired}
 }
 
+func (f *FSM) pin(key lease.Key, entity string) *response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.pinned[key] == nil {
+		f.pinned[key] = set.NewStrings()
+	}
+	f.pinned[key].Add(entity)
+	return &response{}
+}
+
+func (f *FSM) unpin(key lease.Key, entity string) *response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	entities, found := f.pinned[key]
+	if !found {
+		return &response{}
+	}
+	entities.Remove(entity)
+	if entities.IsEmpty() {
+		delete(f.pinned, key)
+	}
+	return &response{}
+}
+
 // GlobalTime returns the FSM's internal time.
 func (f *FSM) GlobalTime() time.Time {
 	f.mu.Lock()
@@ -143,6 +209,18 @@ func (f *FSM) Leases(localTime time.Time) map[lease.Key]lease.Info {
 	return results
 }
 
+// Pinned returns all of the currently pinned leases, with the
+// entities that pinned each of them.
+func (f *FSM) Pinned() map[lease.Key][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	results := make(map[lease.Key][]string, len(f.pinned))
+	for key, entities := range f.pinned {
+		results[key] = entities.SortedValues()
+	}
+	return results
+}
+
 // entry holds the details of a lease.
 type entry struct {
 	// holder identifies the current holder of the lease.
@@ -202,6 +280,12 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		return f.extend(command.LeaseKey(), command.Holder, command.Duration)
 	case OperationSetTime:
 		return f.setTime(command.OldTime, command.NewTime)
+	case OperationPin:
+		return f.pin(command.LeaseKey(), command.PinEntity)
+	case OperationUnpin:
+		return f.unpin(command.LeaseKey(), command.PinEntity)
+	case OperationTransfer:
+		return f.transfer(command.LeaseKey(), command.Holder, command.NewHolder, command.Duration)
 	default:
 		return &response{err: errors.NotValidf("operation %q", command.Operation)}
 	}
@@ -223,9 +307,21 @@ func (f *FSM) Snapshot() (raft.FSMSnapshot, error) {
 			Duration: entry.duration,
 		}
 	}
+	var pinned map[SnapshotKey][]string
+	if len(f.pinned) > 0 {
+		pinned = make(map[SnapshotKey][]string, len(f.pinned))
+		for key, entities := range f.pinned {
+			pinned[SnapshotKey{
+				Namespace: key.Namespace,
+				ModelUUID: key.ModelUUID,
+				Lease:     key.Lease,
+			}] = entities.SortedValues()
+		}
+	}
 	return &Snapshot{
 		Version:    SnapshotVersion,
 		Entries:    entries,
+		Pinned:     pinned,
 		GlobalTime: f.globalTime,
 	}, nil
 }
@@ -258,11 +354,23 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 		}
 	}
 
+	// Snapshots taken before pinning was introduced have no pinned
+	// leases, which is what a missing map represents.
+	newPinned := make(map[lease.Key]set.Strings, len(snapshot.Pinned))
+	for key, entities := range snapshot.Pinned {
+		newPinned[lease.Key{
+			Namespace: key.Namespace,
+			ModelUUID: key.ModelUUID,
+			Lease:     key.Lease,
+		}] = set.NewStrings(entities...)
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
 	f.globalTime = snapshot.GlobalTime
 	f.entries = newEntries
+	f.pinned = newPinned
 
 	return nil
 }
@@ -271,6 +379,7 @@ func (f *FSM) Restore(reader io.ReadCloser) error {
 type Snapshot struct {
 	Version    int                           `yaml:"version"`
 	Entries    map[SnapshotKey]SnapshotEntry `yaml:"entries"`
+	Pinned     map[SnapshotKey][]string      `yaml:"pinned,omitempty"`
 	GlobalTime time.Time                     `yaml:"global-time"`
 }
 
@@ -315,7 +424,8 @@ type Command struct {
 	// to handle multiple formats.
 	Version int `yaml:"version"`
 
-	// Operation is one of claim, extend, expire or setTime.
+	// Operation is one of claim, extend, setTime, pin, unpin or
+	// transfer.
 	Operation string `yaml:"operation"`
 
 	// Namespace is the kind of lease.
@@ -328,9 +438,12 @@ type Command struct {
 	Lease string `yaml:"lease,omitempty"`
 
 	// Holder is the name of the party claiming or extending the
-	// lease.
+	// lease, or its current holder when it is transferred.
 	Holder string `yaml:"holder,omitempty"`
 
+	// NewHolder is the name of the party a lease is transferred to.
+	NewHolder string `yaml:"new-holder,omitempty"`
+
 	// Duration is how long the lease should last.
 	Duration time.Duration `yaml:"duration,omitempty"`
 
@@ -340,6 +453,9 @@ type Command struct {
 
 	// NewTime is the time to store as the global time.
 	NewTime time.Time `yaml:"new-time,omitempty"`
+
+	// PinEntity is the entity pinning or unpinning the lease.
+	PinEntity string `yaml:"pin-entity,omitempty"`
 }
 
 // Validate checks that the command describes a valid state change.
@@ -372,6 +488,68 @@ func (c *Command) Validate() error {
 		if c.NewTime != zeroTime {
 			return errors.NotValidf("%s with new time", c.Operation)
 		}
+		if c.PinEntity != "" {
+			return errors.NotValidf("%s with pin entity", c.Operation)
+		}
+		if c.NewHolder != "" {
+			return errors.NotValidf("%s with new holder", c.Operation)
+		}
+	case OperationTransfer:
+		if c.Holder == "" {
+			return errors.NotValidf("transfer with empty holder")
+		}
+		if c.NewHolder == "" {
+			return errors.NotValidf("transfer with empty new holder")
+		}
+		if c.Duration == 0 {
+			return errors.NotValidf("transfer with zero duration")
+		}
+		if c.Namespace == "" {
+			return errors.NotValidf("transfer with empty namespace")
+		}
+		if c.ModelUUID == "" {
+			return errors.NotValidf("transfer with empty model UUID")
+		}
+		if c.Lease == "" {
+			return errors.NotValidf("transfer with empty lease")
+		}
+		if c.OldTime != zeroTime {
+			return errors.NotValidf("transfer with old time")
+		}
+		if c.NewTime != zeroTime {
+			return errors.NotValidf("transfer with new time")
+		}
+		if c.PinEntity != "" {
+			return errors.NotValidf("transfer with pin entity")
+		}
+	case OperationPin, OperationUnpin:
+		if c.PinEntity == "" {
+			return errors.NotValidf("%s with empty pin entity", c.Operation)
+		}
+		if c.Namespace == "" {
+			return errors.NotValidf("%s with empty namespace", c.Operation)
+		}
+		if c.ModelUUID == "" {
+			return errors.NotValidf("%s with empty model UUID", c.Operation)
+		}
+		if c.Lease == "" {
+			return errors.NotValidf("%s with empty lease", c.Operation)
+		}
+		if c.Holder != "" {
+			return errors.NotValidf("%s with holder", c.Operation)
+		}
+		if c.Duration != 0 {
+			return errors.NotValidf("%s with duration", c.Operation)
+		}
+		if c.OldTime != zeroTime {
+			return errors.NotValidf("%s with old time", c.Operation)
+		}
+		if c.NewTime != zeroTime {
+			return errors.NotValidf("%s with new time", c.Operation)
+		}
+		if c.NewHolder != "" {
+			return errors.NotValidf("%s with new holder", c.Operation)
+		}
 	case OperationSetTime:
 		// An old time of 0 is valid when starting up.
 		if c.NewTime == zeroTime {
@@ -392,6 +570,12 @@ func (c *Command) Validate() error {
 		if c.Lease != "" {
 			return errors.NotValidf("setTime with lease")
 		}
+		if c.PinEntity != "" {
+			return errors.NotValidf("setTime with pin entity")
+		}
+		if c.NewHolder != "" {
+			return errors.NotValidf("setTime with new holder")
+		}
 	default:
 		return errors.NotValidf("operation %q", c.Operation)
 	}
@@ -143,6 +143,103 @@ func (s *fsmSuite) TestExtend(c *gc.C) {
 	assertNoNotifications(c, resp)
 }
 
+func (s *fsmSuite) TestTransfer(c *gc.C) {
+	// Can't transfer a lease that isn't held.
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		NewHolder: "you",
+		Duration:  3 * time.Second,
+	}
+	resp := s.apply(c, command)
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+
+	resp = s.apply(c, command)
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertClaimed(c, resp, lease.Key{"ns", "model", "lease"}, "you")
+
+	c.Assert(s.fsm.Leases(zero), gc.DeepEquals,
+		map[lease.Key]lease.Info{
+			{"ns", "model", "lease"}: {
+				Holder: "you",
+				Expiry: offset(3 * time.Second),
+			},
+		},
+	)
+
+	// The previous holder can't transfer it again.
+	resp = s.apply(c, command)
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+}
+
+func (s *fsmSuite) TestTransferPinned(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}).Error(), jc.ErrorIsNil)
+	pin := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "machine-0",
+	}
+	c.Assert(s.apply(c, pin).Error(), jc.ErrorIsNil)
+
+	// A pinned lease can't be transferred.
+	transfer := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		NewHolder: "you",
+		Duration:  3 * time.Second,
+	}
+	resp := s.apply(c, transfer)
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+	c.Assert(s.fsm.Leases(zero), gc.DeepEquals,
+		map[lease.Key]lease.Info{
+			{"ns", "model", "lease"}: {
+				Holder: "me",
+				Expiry: offset(time.Second),
+			},
+		},
+	)
+
+	// Once unpinned, it can.
+	pin.Operation = raftlease.OperationUnpin
+	c.Assert(s.apply(c, pin).Error(), jc.ErrorIsNil)
+	resp = s.apply(c, transfer)
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertClaimed(c, resp, lease.Key{"ns", "model", "lease"}, "you")
+}
+
 func (s *fsmSuite) TestSetTime(c *gc.C) {
 	// Time always starts at 0.
 	resp := s.apply(c, raftlease.Command{
@@ -236,6 +333,93 @@ func (s *fsmSuite) TestSetTimeExpiresLeases(c *gc.C) {
 	)
 }
 
+func (s *fsmSuite) TestPinnedLeaseDoesNotExpire(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Second,
+	}).Error(), jc.ErrorIsNil)
+	pin := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "machine-0",
+	}
+	resp := s.apply(c, pin)
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertNoNotifications(c, resp)
+	// Pinning again is a no-op.
+	c.Assert(s.apply(c, pin).Error(), jc.ErrorIsNil)
+	pin.PinEntity = "user-admin"
+	c.Assert(s.apply(c, pin).Error(), jc.ErrorIsNil)
+
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"ns", "model", "lease"}: {"machine-0", "user-admin"},
+	})
+
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   zero,
+		NewTime:   offset(2 * time.Second),
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertNoNotifications(c, resp)
+	c.Assert(s.fsm.Leases(offset(2*time.Second)), gc.DeepEquals,
+		map[lease.Key]lease.Info{
+			{"ns", "model", "lease"}: {
+				Holder: "me",
+				Expiry: offset(time.Second),
+			},
+		},
+	)
+
+	// The lease stays pinned until every entity has unpinned it.
+	unpin := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationUnpin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "machine-0",
+	}
+	c.Assert(s.apply(c, unpin).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"ns", "model", "lease"}: {"user-admin"},
+	})
+	unpin.PinEntity = "user-admin"
+	c.Assert(s.apply(c, unpin).Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.HasLen, 0)
+
+	resp = s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationSetTime,
+		OldTime:   offset(2 * time.Second),
+		NewTime:   offset(3 * time.Second),
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertExpired(c, resp, lease.Key{"ns", "model", "lease"})
+}
+
+func (s *fsmSuite) TestUnpinNotPinned(c *gc.C) {
+	resp := s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationUnpin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "machine-0",
+	})
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	c.Assert(s.fsm.Pinned(), gc.HasLen, 0)
+}
+
 func (s *fsmSuite) TestLeases(c *gc.C) {
 	c.Assert(s.apply(c, raftlease.Command{
 		Version:   1,
@@ -422,6 +606,35 @@ func (s *fsmSuite) TestRestore(c *gc.C) {
 	c.Assert(actual, gc.DeepEquals, expected)
 }
 
+func (s *fsmSuite) TestSnapshotRestorePinned(c *gc.C) {
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "machine-0",
+	}).Error(), jc.ErrorIsNil)
+
+	snapshot, err := s.fsm.Snapshot()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot.(*raftlease.Snapshot).Pinned, gc.DeepEquals, map[raftlease.SnapshotKey][]string{
+		{"ns", "model", "lease"}: {"machine-0"},
+	})
+
+	var buffer bytes.Buffer
+	sink := fakeSnapshotSink{Writer: &buffer}
+	err = snapshot.Persist(&sink)
+	c.Assert(err, gc.ErrorMatches, "quam olim abrahe")
+
+	fsm := raftlease.NewFSM()
+	err = fsm.Restore(&closer{Reader: &buffer})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fsm.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"ns", "model", "lease"}: {"machine-0"},
+	})
+}
+
 func (s *fsmSuite) TestSnapshotPersist(c *gc.C) {
 	snapshot := &raftlease.Snapshot{
 		Version: 1,
@@ -505,6 +718,52 @@ func (s *fsmSuite) TestCommandValidationSetTime(c *gc.C) {
 	c.Assert(command.Validate(), gc.ErrorMatches, "setTime with zero new time not valid")
 }
 
+func (s *fsmSuite) TestCommandValidationPin(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationPin,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		PinEntity: "machine-0",
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Holder = "you"
+	c.Assert(command.Validate(), gc.ErrorMatches, "pin with holder not valid")
+	command.Holder = ""
+	command.PinEntity = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "pin with empty pin entity not valid")
+	command.Operation = raftlease.OperationUnpin
+	c.Assert(command.Validate(), gc.ErrorMatches, "unpin with empty pin entity not valid")
+	command.Operation = raftlease.OperationClaim
+	command.Holder = "you"
+	command.Duration = time.Second
+	command.PinEntity = "machine-0"
+	c.Assert(command.Validate(), gc.ErrorMatches, "claim with pin entity not valid")
+}
+
+func (s *fsmSuite) TestCommandValidationTransfer(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationTransfer,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		NewHolder: "you",
+		Duration:  time.Second,
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.NewHolder = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with empty new holder not valid")
+	command.NewHolder = "you"
+	command.Duration = 0
+	c.Assert(command.Validate(), gc.ErrorMatches, "transfer with zero duration not valid")
+	command.Operation = raftlease.OperationExtend
+	command.Duration = time.Second
+	c.Assert(command.Validate(), gc.ErrorMatches, "extend with new holder not valid")
+}
+
 func assertClaimed(c *gc.C, resp raftlease.FSMResponse, key lease.Key, holder string) {
 	var target fakeTarget
 	resp.Notify(&target)
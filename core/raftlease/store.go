@@ -41,6 +41,7 @@ type TrapdoorFunc func(lease.Key, string) lease.Trapdoor
 // - any writes must go through the hub.
 type ReadonlyFSM interface {
 	Leases(time.Time) map[lease.Key]lease.Info
+	Pinned() map[lease.Key][]string
 	GlobalTime() time.Time
 }
 
@@ -123,6 +124,47 @@ func (s *Store) Leases() map[lease.Key]lease.Info {
 	return result
 }
 
+// TransferLease is part of lease.Store.
+func (s *Store) TransferLease(key lease.Key, holder string, req lease.Request) error {
+	err := s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationTransfer,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		Holder:    holder,
+		NewHolder: req.Holder,
+		Duration:  req.Duration,
+	})
+	return errors.Trace(err)
+}
+
+// PinLease is part of lease.Store.
+func (s *Store) PinLease(key lease.Key, entity string) error {
+	return errors.Trace(s.pinOp(OperationPin, key, entity))
+}
+
+// UnpinLease is part of lease.Store.
+func (s *Store) UnpinLease(key lease.Key, entity string) error {
+	return errors.Trace(s.pinOp(OperationUnpin, key, entity))
+}
+
+// Pinned is part of lease.Store.
+func (s *Store) Pinned() map[lease.Key][]string {
+	return s.fsm.Pinned()
+}
+
+func (s *Store) pinOp(operation string, key lease.Key, entity string) error {
+	return s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: operation,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+		PinEntity: entity,
+	})
+}
+
 // Refresh is part of lease.Store.
 func (s *Store) Refresh() error {
 	return nil
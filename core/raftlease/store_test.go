@@ -224,6 +224,103 @@ func (s *storeSuite) TestLeases(c *gc.C) {
 	c.Assert(out, gc.Equals, "{la cry mosa} held by mozart")
 }
 
+func (s *storeSuite) TestTransfer(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.TransferLease(
+				lease.Key{"warframe", "frost", "prime"},
+				"rhino",
+				lease.Request{"loki", 2 * time.Minute},
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationTransfer,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			Holder:    "rhino",
+			NewHolder: "loki",
+			Duration:  2 * time.Minute,
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestPin(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.PinLease(
+				lease.Key{"warframe", "frost", "prime"},
+				"machine-0",
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationPin,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			PinEntity: "machine-0",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestUnpin(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.UnpinLease(
+				lease.Key{"warframe", "frost", "prime"},
+				"machine-0",
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationUnpin,
+			Namespace: "warframe",
+			ModelUUID: "frost",
+			Lease:     "prime",
+			PinEntity: "machine-0",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestPinned(c *gc.C) {
+	s.fsm.pinned = map[lease.Key][]string{
+		{"quam", "olim", "abrahe"}: {"machine-0", "user-admin"},
+	}
+	c.Assert(s.store.Pinned(), gc.DeepEquals, map[lease.Key][]string{
+		{"quam", "olim", "abrahe"}: {"machine-0", "user-admin"},
+	})
+	s.fsm.CheckCallNames(c, "Pinned")
+}
+
 // handleHubRequest takes the action that triggers the request, the
 // expected command, and a function that will be run to make checks on
 // the request and send the response back.
@@ -398,6 +495,7 @@ func (s *storeSuite) TestRecoverError(c *gc.C) {
 type fakeFSM struct {
 	testing.Stub
 	leases     map[lease.Key]lease.Info
+	pinned     map[lease.Key][]string
 	globalTime time.Time
 }
 
@@ -406,6 +504,11 @@ func (f *fakeFSM) Leases(t time.Time) map[lease.Key]lease.Info {
 	return f.leases
 }
 
+func (f *fakeFSM) Pinned() map[lease.Key][]string {
+	f.AddCall("Pinned")
+	return f.pinned
+}
+
 func (f *fakeFSM) GlobalTime() time.Time {
 	return f.globalTime
 }
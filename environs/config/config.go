@@ -26,6 +26,8 @@ import (
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/network"
 )
@@ -188,6 +190,26 @@ const (
 	// forwarding.
 	LogFwdSyslogClientKey = "syslog-client-key"
 
+	// LogFwdHTTPURL sets the URL of the HTTP collector to which
+	// batches of log records are posted as JSON.
+	LogFwdHTTPURL = "logforward-http-url"
+
+	// LogFwdHTTPCACert sets the certificate of the CA that signed the
+	// HTTP collector's server certificate.
+	LogFwdHTTPCACert = "logforward-http-ca-cert"
+
+	// LogFwdFilePath sets the path of the file on the controller to
+	// which log records are written.
+	LogFwdFilePath = "logforward-file-path"
+
+	// LogFwdFileMaxSize sets the size in megabytes at which the log
+	// forwarding file is rotated.
+	LogFwdFileMaxSize = "logforward-file-max-size"
+
+	// LogFwdFileMaxBackups sets the number of rotated log forwarding
+	// files to keep.
+	LogFwdFileMaxBackups = "logforward-file-max-backups"
+
 	// AutomaticallyRetryHooks determines whether the uniter will
 	// automatically retry a hook that has failed
 	AutomaticallyRetryHooks = "automatically-retry-hooks"
@@ -594,6 +616,16 @@ func Validate(cfg, old *Config) error {
 			return errors.Annotate(err, "invalid syslog forwarding config")
 		}
 	}
+	if lfCfg, ok := cfg.LogFwdHTTP(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid HTTP log forwarding config")
+		}
+	}
+	if lfCfg, ok := cfg.LogFwdFile(); ok {
+		if err := lfCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid file log forwarding config")
+		}
+	}
 
 	if uuid := cfg.UUID(); !utils.IsValidUUIDString(uuid) {
 		return errors.Errorf("uuid: expected UUID, got string(%q)", uuid)
@@ -1062,12 +1094,81 @@ func (c *Config) LogFwdSyslog() (*syslog.RawConfig, bool) {
 		lfCfg.ClientKey = s.(string)
 	}
 
+	// When only the other log forwarding targets are configured,
+	// syslog forwarding is not enabled.
+	if lfCfg.Enabled && lfCfg.Host == "" && c.hasOtherLogFwdTarget(LogFwdSyslogHost) {
+		lfCfg.Enabled = false
+	}
+
+	if !partial {
+		return nil, false
+	}
+	return &lfCfg, true
+}
+
+// LogFwdHTTP returns the HTTP log forwarding config.
+func (c *Config) LogFwdHTTP() (*httpjson.RawConfig, bool) {
+	partial := false
+	var lfCfg httpjson.RawConfig
+
+	if s, ok := c.defined[LogFwdHTTPURL]; ok && s != "" {
+		partial = true
+		lfCfg.URL = s.(string)
+	}
+
+	if s, ok := c.defined[LogFwdHTTPCACert]; ok && s != "" {
+		partial = true
+		lfCfg.CACert = s.(string)
+	}
+
+	if !partial {
+		return nil, false
+	}
+	lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
+	return &lfCfg, true
+}
+
+// LogFwdFile returns the file log forwarding config.
+func (c *Config) LogFwdFile() (*logfile.RawConfig, bool) {
+	partial := false
+	var lfCfg logfile.RawConfig
+
+	if s, ok := c.defined[LogFwdFilePath]; ok && s != "" {
+		partial = true
+		lfCfg.Path = s.(string)
+	}
+
+	if v, ok := c.defined[LogFwdFileMaxSize].(int); ok {
+		partial = true
+		lfCfg.MaxSize = v
+	}
+
+	if v, ok := c.defined[LogFwdFileMaxBackups].(int); ok {
+		partial = true
+		lfCfg.MaxBackups = v
+	}
+
 	if !partial {
 		return nil, false
 	}
+	lfCfg.Enabled, _ = c.defined[LogForwardEnabled].(bool)
 	return &lfCfg, true
 }
 
+// hasOtherLogFwdTarget reports whether a log forwarding target other
+// than the one identified by the given key is configured.
+func (c *Config) hasOtherLogFwdTarget(key string) bool {
+	for _, target := range []string{LogFwdSyslogHost, LogFwdHTTPURL, LogFwdFilePath} {
+		if target == key {
+			continue
+		}
+		if s, ok := c.defined[target].(string); ok && s != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // FirewallMode returns whether the firewall should
 // manage ports per machine, globally, or not at all.
 // (FwInstance, FwGlobal, or FwNone).
@@ -1442,6 +1543,11 @@ var alwaysOptional = schema.Defaults{
 	LogFwdSyslogCACert:     schema.Omit,
 	LogFwdSyslogClientCert: schema.Omit,
 	LogFwdSyslogClientKey:  schema.Omit,
+	LogFwdHTTPURL:          schema.Omit,
+	LogFwdHTTPCACert:       schema.Omit,
+	LogFwdFilePath:         schema.Omit,
+	LogFwdFileMaxSize:      schema.Omit,
+	LogFwdFileMaxBackups:   schema.Omit,
 
 	// Storage related config.
 	// Environ providers will specify their own defaults.
@@ -1874,7 +1980,7 @@ global or per instance security groups.`,
 		Group:       environschema.EnvironGroup,
 	},
 	LogForwardEnabled: {
-		Description: `Whether syslog forwarding is enabled.`,
+		Description: `Whether log forwarding is enabled.`,
 		Type:        environschema.Tbool,
 		Group:       environschema.EnvironGroup,
 	},
@@ -1898,6 +2004,31 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	LogFwdHTTPURL: {
+		Description: `The URL of an HTTP collector to which log records are posted as JSON.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdHTTPCACert: {
+		Description: `The certificate of the CA that signed the HTTP log collector certificate, in PEM format.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFilePath: {
+		Description: `The absolute path of a file on the controller to which log records are written.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxSize: {
+		Description: `The size in megabytes at which the log forwarding file is rotated.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	LogFwdFileMaxBackups: {
+		Description: `The number of rotated log forwarding files to keep.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"ssl-hostname-verification": {
 		Description: "Whether SSL hostname verification is enabled (default true)",
 		Type:        environschema.Tbool,
@@ -552,6 +552,39 @@ var configTests = []configTest{
 			"syslog-client-cert": testing.ServerCert,
 			"syslog-client-key":  testing.ServerKey,
 		}),
+	}, {
+		about:       "Valid HTTP log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":      true,
+			"logforward-http-url":     "https://logs.example.com/ingest",
+			"logforward-http-ca-cert": testing.CACert,
+		}),
+	}, {
+		about:       "Invalid HTTP log forwarding URL",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":  true,
+			"logforward-http-url": "ftp://logs.example.com",
+		}),
+		err: `invalid HTTP log forwarding config: URL scheme "ftp" not valid`,
+	}, {
+		about:       "Valid file log forwarding config values",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":          true,
+			"logforward-file-path":        "/var/log/juju-forward.log",
+			"logforward-file-max-size":    50,
+			"logforward-file-max-backups": 2,
+		}),
+	}, {
+		about:       "Invalid file log forwarding path",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"logforward-enabled":   true,
+			"logforward-file-path": "juju-forward.log",
+		}),
+		err: `invalid file log forwarding config: relative Path "juju-forward.log" not valid`,
 	}, {
 		about:       "Valid container-inherit-properties",
 		useDefaults: config.UseDefaults,
@@ -647,10 +680,30 @@ func (test configTest) check(c *gc.C, home *gitjujutesting.FakeHome) {
 	c.Assert(cfg.AuthorizedKeys(), gc.Equals, keys)
 
 	lfCfg, hasLogCfg := cfg.LogFwdSyslog()
+	_, hasHTTPTarget := test.attrs["logforward-http-url"]
+	_, hasFileTarget := test.attrs["logforward-file-path"]
 	if v, ok := test.attrs["logforward-enabled"].(bool); ok {
 		c.Assert(hasLogCfg, jc.IsTrue)
+		if hasHTTPTarget || hasFileTarget {
+			// Syslog forwarding is only enabled if it has a target.
+			_, hasSyslogTarget := test.attrs["syslog-host"]
+			v = v && hasSyslogTarget
+		}
 		c.Assert(lfCfg.Enabled, gc.Equals, v)
 	}
+	if v, ok := test.attrs["logforward-http-url"].(string); ok {
+		httpCfg, ok := cfg.LogFwdHTTP()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(httpCfg.URL, gc.Equals, v)
+		c.Assert(httpCfg.Enabled, gc.Equals, test.attrs["logforward-enabled"])
+	}
+	if v, ok := test.attrs["logforward-file-path"].(string); ok {
+		fileCfg, ok := cfg.LogFwdFile()
+		c.Assert(ok, jc.IsTrue)
+		c.Assert(fileCfg.Path, gc.Equals, v)
+		c.Assert(fileCfg.MaxSize, gc.Equals, test.attrs["logforward-file-max-size"])
+		c.Assert(fileCfg.MaxBackups, gc.Equals, test.attrs["logforward-file-max-backups"])
+	}
 	if v, ok := test.attrs["syslog-ca-cert"].(string); v != "" {
 		c.Assert(hasLogCfg, jc.IsTrue)
 		c.Assert(lfCfg.CACert, gc.Equals, v)
@@ -0,0 +1,213 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+
+	"github.com/juju/juju/logfwd"
+)
+
+const (
+	// DefaultAttempts is the number of times a batch is posted before
+	// giving up.
+	DefaultAttempts = 5
+
+	// DefaultDelay is the delay before the first retry; it doubles on
+	// each subsequent retry up to DefaultMaxDelay.
+	DefaultDelay = time.Second
+
+	// DefaultMaxDelay caps the delay between retries.
+	DefaultMaxDelay = 30 * time.Second
+
+	requestTimeout = 30 * time.Second
+)
+
+// Doer sends HTTP requests. It is satisfied by *http.Client.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Client posts batches of log records to an HTTP collector.
+type Client struct {
+	// URL is the endpoint to which records are posted.
+	URL string
+
+	// Doer is used to send the HTTP requests.
+	Doer Doer
+
+	// Clock is used to wait between retries.
+	Clock clock.Clock
+
+	// Attempts is the maximum number of times a batch is posted.
+	Attempts int
+
+	// Delay is the delay before the first retry.
+	Delay time.Duration
+
+	// MaxDelay is the maximum delay between retries.
+	MaxDelay time.Duration
+}
+
+// Open returns a client for the HTTP collector described by the config.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	tlsCfg, err := cfg.tlsConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "constructing TLS config")
+	}
+	transport := http.DefaultTransport.(*http.Transport)
+	if tlsCfg != nil {
+		transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsCfg,
+		}
+	}
+	doer := &http.Client{
+		Transport: transport,
+		Timeout:   requestTimeout,
+	}
+	return OpenForDoer(cfg, doer, clock.WallClock)
+}
+
+// OpenForDoer returns a client for the HTTP collector described by the
+// config, which sends its requests using the supplied Doer.
+func OpenForDoer(cfg RawConfig, doer Doer, clock clock.Clock) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Client{
+		URL:      cfg.URL,
+		Doer:     doer,
+		Clock:    clock,
+		Attempts: DefaultAttempts,
+		Delay:    DefaultDelay,
+		MaxDelay: DefaultMaxDelay,
+	}, nil
+}
+
+// Close is part of the logforwarder.SendCloser interface. There are no
+// persistent connections to close.
+func (client *Client) Close() error {
+	return nil
+}
+
+// Send posts the records to the collector as a single batch, retrying
+// with exponential backoff on failure.
+func (client *Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(batchFromRecords(records))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = retry.Call(retry.CallArgs{
+		Func: func() error {
+			return client.post(body)
+		},
+		IsFatalError: func(err error) bool {
+			_, ok := errors.Cause(err).(*permanentError)
+			return ok
+		},
+		Attempts:    client.Attempts,
+		Delay:       client.Delay,
+		MaxDelay:    client.MaxDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       client.Clock,
+	})
+	if err != nil {
+		return errors.Annotate(retry.LastError(err), "sending log records")
+	}
+	return nil
+}
+
+func (client *Client) post(body []byte) error {
+	req, err := http.NewRequest("POST", client.URL, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Doer.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused.
+	io.Copy(ioutil.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return errors.Errorf("collector returned %s", resp.Status)
+	default:
+		// Other client errors will not be fixed by retrying.
+		return &permanentError{errors.Errorf("collector rejected records: %s", resp.Status)}
+	}
+}
+
+// permanentError is returned for failures that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// Batch is the JSON document posted to the collector.
+type Batch struct {
+	Records []Record `json:"records"`
+}
+
+// Record is the JSON representation of a single log record.
+type Record struct {
+	ID             int64     `json:"id"`
+	Timestamp      time.Time `json:"timestamp"`
+	ControllerUUID string    `json:"controller-uuid"`
+	ModelUUID      string    `json:"model-uuid"`
+	Hostname       string    `json:"hostname,omitempty"`
+	OriginType     string    `json:"origin-type"`
+	OriginName     string    `json:"origin-name,omitempty"`
+	Software       string    `json:"software,omitempty"`
+	Version        string    `json:"version"`
+	Level          string    `json:"level"`
+	Module         string    `json:"module,omitempty"`
+	Location       string    `json:"location,omitempty"`
+	Message        string    `json:"message"`
+}
+
+func batchFromRecords(records []logfwd.Record) Batch {
+	batch := Batch{Records: make([]Record, len(records))}
+	for i, rec := range records {
+		batch.Records[i] = Record{
+			ID:             rec.ID,
+			Timestamp:      rec.Timestamp.UTC(),
+			ControllerUUID: rec.Origin.ControllerUUID,
+			ModelUUID:      rec.Origin.ModelUUID,
+			Hostname:       rec.Origin.Hostname,
+			OriginType:     rec.Origin.Type.String(),
+			OriginName:     rec.Origin.Name,
+			Software:       rec.Origin.Software.Name,
+			Version:        rec.Origin.Software.Version.String(),
+			Level:          rec.Level.String(),
+			Module:         rec.Location.Module,
+			Location:       rec.Location.String(),
+			Message:        rec.Message,
+		}
+	}
+	return batch
+}
@@ -0,0 +1,235 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	stub  *testing.Stub
+	doer  *stubDoer
+	clock *testclock.Clock
+	rec   logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.doer = &stubDoer{stub: s.stub}
+	s.clock = testclock.NewClock(time.Now())
+	s.rec = logfwd.Record{
+		ID:        10,
+		Timestamp: time.Date(2099, time.June, 1, 23, 2, 1, 23, time.UTC),
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "juju-machine-99",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.0.1"),
+			},
+		},
+		Level: loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) open(c *gc.C) *httpjson.Client {
+	client, err := httpjson.OpenForDoer(httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	}, s.doer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	return client
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := httpjson.OpenForDoer(httpjson.RawConfig{Enabled: true}, s.doer, s.clock)
+	c.Assert(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	s.doer.statuses = []int{http.StatusOK}
+	client := s.open(c)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Do")
+	c.Assert(s.doer.requests, gc.HasLen, 1)
+	req := s.doer.requests[0]
+	c.Check(req.method, gc.Equals, "POST")
+	c.Check(req.url, gc.Equals, "https://logs.example.com/ingest")
+	c.Check(req.contentType, gc.Equals, "application/json")
+
+	var batch httpjson.Batch
+	err = json.Unmarshal(req.body, &batch)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(batch, jc.DeepEquals, httpjson.Batch{
+		Records: []httpjson.Record{{
+			ID:             10,
+			Timestamp:      s.rec.Timestamp,
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "juju-machine-99",
+			OriginType:     "machine",
+			OriginName:     "99",
+			Software:       "jujud-machine-agent",
+			Version:        "2.0.1",
+			Level:          "ERROR",
+			Module:         "juju.x.y",
+			Location:       "x/y/spam.go:42",
+			Message:        "(╯°□°)╯︵ ┻━┻",
+		}},
+	})
+}
+
+func (s *ClientSuite) TestSendNoRecords(c *gc.C) {
+	client := s.open(c)
+
+	err := client.Send(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestSendRetriesServerErrors(c *gc.C) {
+	s.doer.statuses = []int{http.StatusServiceUnavailable, http.StatusOK}
+	client := s.open(c)
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- client.Send([]logfwd.Record{s.rec})
+	}()
+	err := s.clock.WaitAdvance(httpjson.DefaultDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-errc:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	s.stub.CheckCallNames(c, "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendRetriesConnectionErrors(c *gc.C) {
+	s.stub.SetErrors(errors.New("connection refused"))
+	s.doer.statuses = []int{0, http.StatusAccepted}
+	client := s.open(c)
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- client.Send([]logfwd.Record{s.rec})
+	}()
+	err := s.clock.WaitAdvance(httpjson.DefaultDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-errc:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	s.stub.CheckCallNames(c, "Do", "Do")
+}
+
+func (s *ClientSuite) TestSendClientErrorNotRetried(c *gc.C) {
+	s.doer.statuses = []int{http.StatusBadRequest}
+	client := s.open(c)
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, `sending log records: collector rejected records: 400 Bad Request`)
+
+	s.stub.CheckCallNames(c, "Do")
+}
+
+func (s *ClientSuite) TestSendGivesUp(c *gc.C) {
+	s.doer.statuses = []int{500, 500}
+	client := s.open(c)
+	client.Attempts = 2
+
+	errc := make(chan error, 1)
+	go func() {
+		errc <- client.Send([]logfwd.Record{s.rec})
+	}()
+	err := s.clock.WaitAdvance(httpjson.DefaultDelay, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, `sending log records: collector returned 500 Internal Server Error`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for send")
+	}
+	s.stub.CheckCallNames(c, "Do", "Do")
+}
+
+type stubRequest struct {
+	method      string
+	url         string
+	contentType string
+	body        []byte
+}
+
+type stubDoer struct {
+	stub     *testing.Stub
+	statuses []int
+	requests []stubRequest
+}
+
+func (d *stubDoer) Do(req *http.Request) (*http.Response, error) {
+	d.stub.AddCall("Do")
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	d.requests = append(d.requests, stubRequest{
+		method:      req.Method,
+		url:         req.URL.String(),
+		contentType: req.Header.Get("Content-Type"),
+		body:        body,
+	})
+	status := d.statuses[0]
+	d.statuses = d.statuses[1:]
+	if err := d.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: status,
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}, nil
+}
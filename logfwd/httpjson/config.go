@@ -0,0 +1,77 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/url"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/cert"
+)
+
+// RawConfig holds the raw configuration data for a connection to an
+// HTTP log collector.
+type RawConfig struct {
+	// Enabled is true if forwarding to the HTTP collector is enabled.
+	Enabled bool
+
+	// URL is the endpoint to which batches of log records are posted.
+	// The scheme must be either http or https.
+	URL string
+
+	// CACert is the TLS CA certificate (x.509, PEM-encoded) to use
+	// for validating the server certificate when connecting over
+	// https. If empty, the system roots are used.
+	CACert string
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if err := cfg.validateURL(); err != nil {
+		return errors.Trace(err)
+	}
+	if cfg.CACert != "" {
+		if _, err := cfg.tlsConfig(); err != nil {
+			return errors.Annotate(err, "validating TLS config")
+		}
+	}
+	return nil
+}
+
+func (cfg RawConfig) validateURL() error {
+	if cfg.URL == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("URL %q", cfg.URL)
+		}
+		return nil
+	}
+	u, err := url.Parse(cfg.URL)
+	if err != nil {
+		return errors.NewNotValid(err, "URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.NotValidf("URL scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.NotValidf("URL %q without host", cfg.URL)
+	}
+	return nil
+}
+
+func (cfg RawConfig) tlsConfig() (*tls.Config, error) {
+	if cfg.CACert == "" {
+		return nil, nil
+	}
+	caCert, err := cert.ParseCert(cfg.CACert)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing CA certificate")
+	}
+	rootCAs := x509.NewCertPool()
+	rootCAs.AddCert(caCert)
+	return &tls.Config{
+		RootCAs: rootCAs,
+	}, nil
+}
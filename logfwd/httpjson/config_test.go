@@ -0,0 +1,92 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package httpjson_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com:8443/ingest",
+		CACert:  coretesting.CACert,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateWithoutCACert(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+		URL:     "http://10.0.0.1/ingest",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg httpjson.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingURL(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadScheme(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+		URL:     "ftp://logs.example.com",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL scheme "ftp" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingHost(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https:///ingest",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `URL "https:///ingest" without host not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateBadCACert(c *gc.C) {
+	cfg := httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com",
+		CACert:  "abc",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `validating TLS config: parsing CA certificate: no certificates found`)
+}
@@ -0,0 +1,88 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/logfwd"
+)
+
+// Client writes log records to a local file.
+type Client struct {
+	// Writer is the (rotating) file writer this client wraps.
+	Writer io.WriteCloser
+}
+
+// Open returns a client that writes to the file described by the
+// config, creating its directory if necessary. The file is rotated
+// once it reaches the configured size.
+func Open(cfg RawConfig) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0755); err != nil {
+		return nil, errors.Annotate(err, "creating log directory")
+	}
+	client := &Client{
+		Writer: &lumberjack.Logger{
+			Filename:   cfg.Path,
+			MaxSize:    cfg.maxSize(),
+			MaxBackups: cfg.maxBackups(),
+			Compress:   true,
+		},
+	}
+	return client, nil
+}
+
+// Close closes the underlying file.
+func (client Client) Close() error {
+	err := client.Writer.Close()
+	return errors.Trace(err)
+}
+
+// Send writes the records to the file, one line per record.
+func (client Client) Send(records []logfwd.Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	// Write the batch in one call so that it is not split across
+	// files if the file is rotated.
+	var buf bytes.Buffer
+	for _, rec := range records {
+		buf.WriteString(formatRecord(rec))
+		buf.WriteByte('\n')
+	}
+	_, err := client.Writer.Write(buf.Bytes())
+	return errors.Trace(err)
+}
+
+// formatRecord renders the record in a form similar to that used by
+// debug-log, prefixed with the originating model.
+func formatRecord(rec logfwd.Record) string {
+	return fmt.Sprintf("%s %s %s %s %s %s %s",
+		rec.Origin.ModelUUID,
+		originName(rec.Origin),
+		rec.Timestamp.UTC().Format(time.RFC3339Nano),
+		rec.Level,
+		rec.Location.Module,
+		rec.Location,
+		rec.Message,
+	)
+}
+
+func originName(origin logfwd.Origin) string {
+	if origin.Name == "" {
+		return origin.Type.String()
+	}
+	return origin.Type.String() + "-" + origin.Name
+}
@@ -0,0 +1,135 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ClientSuite struct {
+	testing.IsolationSuite
+
+	rec logfwd.Record
+}
+
+var _ = gc.Suite(&ClientSuite{})
+
+func (s *ClientSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.rec = logfwd.Record{
+		ID:        10,
+		Timestamp: time.Date(2099, time.June, 1, 23, 2, 1, 23, time.UTC),
+		Origin: logfwd.Origin{
+			ControllerUUID: "9f484882-2f18-4fd2-967d-db9663db7bea",
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Hostname:       "juju-machine-99",
+			Type:           logfwd.OriginTypeMachine,
+			Name:           "99",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "jujud-machine-agent",
+				Version:                 version.MustParse("2.0.1"),
+			},
+		},
+		Level: loggo.ERROR,
+		Location: logfwd.SourceLocation{
+			Module:   "juju.x.y",
+			Filename: "x/y/spam.go",
+			Line:     42,
+		},
+		Message: "(╯°□°)╯︵ ┻━┻",
+	}
+}
+
+func (s *ClientSuite) TestOpenInvalidConfig(c *gc.C) {
+	_, err := logfile.Open(logfile.RawConfig{Enabled: true})
+	c.Assert(err, gc.ErrorMatches, `Path "" not valid`)
+}
+
+func (s *ClientSuite) TestSend(c *gc.C) {
+	writer := &stubWriter{stub: &testing.Stub{}}
+	client := logfile.Client{Writer: writer}
+
+	rec1 := s.rec
+	rec1.ID = 11
+	rec1.Location = logfwd.SourceLocation{}
+	rec1.Message = "second"
+	err := client.Send([]logfwd.Record{s.rec, rec1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	writer.stub.CheckCallNames(c, "Write")
+	c.Check(writer.buf.String(), gc.Equals, ""+
+		"deadbeef-2f18-4fd2-967d-db9663db7bea machine-99 2099-06-01T23:02:01.000000023Z ERROR juju.x.y x/y/spam.go:42 (╯°□°)╯︵ ┻━┻\n"+
+		"deadbeef-2f18-4fd2-967d-db9663db7bea machine-99 2099-06-01T23:02:01.000000023Z ERROR   second\n",
+	)
+}
+
+func (s *ClientSuite) TestSendError(c *gc.C) {
+	writer := &stubWriter{stub: &testing.Stub{}}
+	writer.stub.SetErrors(errors.New("disk full"))
+	client := logfile.Client{Writer: writer}
+
+	err := client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, gc.ErrorMatches, "disk full")
+}
+
+func (s *ClientSuite) TestClose(c *gc.C) {
+	writer := &stubWriter{stub: &testing.Stub{}}
+	client := logfile.Client{Writer: writer}
+
+	err := client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	writer.stub.CheckCallNames(c, "Close")
+}
+
+func (s *ClientSuite) TestOpenWritesFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "forward", "models.log")
+	client, err := logfile.Open(logfile.RawConfig{
+		Enabled: true,
+		Path:    path,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.Send([]logfwd.Record{s.rec})
+	c.Assert(err, jc.ErrorIsNil)
+	err = client.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), jc.Contains, "ERROR juju.x.y x/y/spam.go:42 (╯°□°)╯︵ ┻━┻\n")
+}
+
+type stubWriter struct {
+	stub *testing.Stub
+	buf  bytes.Buffer
+}
+
+func (w *stubWriter) Write(data []byte) (int, error) {
+	w.stub.AddCall("Write", data)
+	if err := w.stub.NextErr(); err != nil {
+		return 0, err
+	}
+	return w.buf.Write(data)
+}
+
+func (w *stubWriter) Close() error {
+	w.stub.AddCall("Close")
+	return w.stub.NextErr()
+}
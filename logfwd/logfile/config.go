@@ -0,0 +1,71 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile
+
+import (
+	"path/filepath"
+
+	"github.com/juju/errors"
+)
+
+const (
+	// DefaultMaxSize is the size in megabytes at which the log file
+	// is rotated, if not otherwise specified.
+	DefaultMaxSize = 300
+
+	// DefaultMaxBackups is the number of rotated log files to keep,
+	// if not otherwise specified.
+	DefaultMaxBackups = 10
+)
+
+// RawConfig holds the raw configuration data for forwarding logs to a
+// local file.
+type RawConfig struct {
+	// Enabled is true if forwarding to the file is enabled.
+	Enabled bool
+
+	// Path is the absolute path of the file to which log records
+	// are written.
+	Path string
+
+	// MaxSize is the maximum size in megabytes of the file before it
+	// is rotated. If zero, DefaultMaxSize is used.
+	MaxSize int
+
+	// MaxBackups is the maximum number of rotated files to keep. If
+	// zero, DefaultMaxBackups is used.
+	MaxBackups int
+}
+
+// Validate ensures that the config is currently valid.
+func (cfg RawConfig) Validate() error {
+	if cfg.Path == "" {
+		if cfg.Enabled {
+			return errors.NotValidf("Path %q", cfg.Path)
+		}
+	} else if !filepath.IsAbs(cfg.Path) {
+		return errors.NotValidf("relative Path %q", cfg.Path)
+	}
+	if cfg.MaxSize < 0 {
+		return errors.NotValidf("negative MaxSize")
+	}
+	if cfg.MaxBackups < 0 {
+		return errors.NotValidf("negative MaxBackups")
+	}
+	return nil
+}
+
+func (cfg RawConfig) maxSize() int {
+	if cfg.MaxSize == 0 {
+		return DefaultMaxSize
+	}
+	return cfg.MaxSize
+}
+
+func (cfg RawConfig) maxBackups() int {
+	if cfg.MaxBackups == 0 {
+		return DefaultMaxBackups
+	}
+	return cfg.MaxBackups
+}
@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logfile_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/logfile"
+)
+
+type ConfigSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestRawValidateFull(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Enabled:    true,
+		Path:       "/var/log/juju-forward/models.log",
+		MaxSize:    100,
+		MaxBackups: 3,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateZeroValue(c *gc.C) {
+	var cfg logfile.RawConfig
+	err := cfg.Validate()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *ConfigSuite) TestRawValidateMissingPath(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Enabled: true,
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `Path "" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateRelativePath(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Enabled: true,
+		Path:    "models.log",
+	}
+
+	err := cfg.Validate()
+
+	c.Check(err, gc.ErrorMatches, `relative Path "models.log" not valid`)
+}
+
+func (s *ConfigSuite) TestRawValidateNegativeSizes(c *gc.C) {
+	cfg := logfile.RawConfig{
+		Path:    "/var/log/models.log",
+		MaxSize: -1,
+	}
+	c.Check(cfg.Validate(), gc.ErrorMatches, `negative MaxSize not valid`)
+
+	cfg.MaxSize = 0
+	cfg.MaxBackups = -1
+	c.Check(cfg.Validate(), gc.ErrorMatches, `negative MaxBackups not valid`)
+}
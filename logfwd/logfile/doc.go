@@ -0,0 +1,6 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// The logfile package holds the tools needed to perform log forwarding
+// from Juju to a local file, which is rotated as it grows.
+package logfile
@@ -0,0 +1,499 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+)
+
+// A model archive is a gzipped tar file holding a serialized model and
+// the binaries needed to import it into a controller:
+//
+//	metadata.yaml                  ModelArchiveMetadata
+//	model.yaml                     the serialized model
+//	charms/<charm URL>             charm archives
+//	tools/<version>.tar.gz         agent binaries
+//	resources/<application>/<name> application resources
+//
+// Charm URLs, application and resource names are query-escaped so that
+// they are valid file names.
+const (
+	archiveMetadataFile = "metadata.yaml"
+	archiveModelFile    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveToolsDir     = "tools"
+	archiveResourcesDir = "resources"
+)
+
+// ModelArchiveMetadata describes when and from where a model archive
+// was exported.
+type ModelArchiveMetadata struct {
+	// ControllerAgentVersion is the version of the controller from
+	// which the model was exported.
+	ControllerAgentVersion version.Number `yaml:"controller-agent-version"`
+
+	// Exported is when the model was exported.
+	Exported time.Time `yaml:"exported"`
+}
+
+// NewSerializedModel returns the serialized model, along with the
+// charms, agent binaries and resources that it uses. The tools URIs are
+// relative to the model's API endpoint on the controller that hosts it.
+func NewSerializedModel(bytes []byte) (migration.SerializedModel, error) {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	resources, err := usedResources(model)
+	if err != nil {
+		return migration.SerializedModel{}, errors.Trace(err)
+	}
+	return migration.SerializedModel{
+		Bytes:     bytes,
+		Charms:    usedCharms(model),
+		Tools:     usedTools(model),
+		Resources: resources,
+	}, nil
+}
+
+func usedCharms(model description.Model) []string {
+	seen := make(map[string]bool)
+	var charms []string
+	for _, app := range model.Applications() {
+		if curl := app.CharmURL(); !seen[curl] {
+			seen[curl] = true
+			charms = append(charms, curl)
+		}
+	}
+	return charms
+}
+
+func usedTools(model description.Model) map[version.Binary]string {
+	tools := make(map[version.Binary]string)
+	var addMachine func(description.Machine)
+	addMachine = func(machine description.Machine) {
+		if t := machine.Tools(); t != nil {
+			tools[t.Version()] = toolsURI(t.Version())
+		}
+		for _, container := range machine.Containers() {
+			addMachine(container)
+		}
+	}
+	for _, machine := range model.Machines() {
+		addMachine(machine)
+	}
+	for _, app := range model.Applications() {
+		for _, unit := range app.Units() {
+			if t := unit.Tools(); t != nil {
+				tools[t.Version()] = toolsURI(t.Version())
+			}
+		}
+	}
+	return tools
+}
+
+func toolsURI(v version.Binary) string {
+	return fmt.Sprintf("/tools/%s", v)
+}
+
+func usedResources(model description.Model) ([]migration.SerializedModelResource, error) {
+	var out []migration.SerializedModelResource
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := resourceRevision(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			storeRev, err := resourceRevision(app.Name(), res.Name(), res.CharmStoreRevision())
+			if err != nil {
+				return nil, errors.Annotatef(err, "resource %s/%s", app.Name(), res.Name())
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := resourceRevision(app.Name(), res.Name(), unitRes.Revision())
+					if err != nil {
+						return nil, errors.Annotatef(err, "resource %s/%s of %s", app.Name(), res.Name(), unit.Name())
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			out = append(out, migration.SerializedModelResource{
+				ApplicationRevision: appRev,
+				CharmStoreRevision:  storeRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return out, nil
+}
+
+func resourceRevision(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{}, nil
+	}
+	resType, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        resType,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
+
+func charmArchivePath(curl string) string {
+	return path.Join(archiveCharmsDir, url.QueryEscape(curl))
+}
+
+func toolsArchivePath(v version.Binary) string {
+	return path.Join(archiveToolsDir, url.QueryEscape(v.String())+".tar.gz")
+}
+
+func resourceArchivePath(app, name string) string {
+	return path.Join(archiveResourcesDir, url.QueryEscape(app), url.QueryEscape(name))
+}
+
+// WriteModelArchiveConfig holds what WriteModelArchive needs to write a
+// model archive.
+type WriteModelArchiveConfig struct {
+	Metadata ModelArchiveMetadata
+	Model    migration.SerializedModel
+
+	CharmDownloader    CharmDownloader
+	ToolsDownloader    ToolsDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are set.
+func (c WriteModelArchiveConfig) Validate() error {
+	if len(c.Model.Bytes) == 0 {
+		return errors.NotValidf("empty Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ToolsDownloader == nil {
+		return errors.NotValidf("missing ToolsDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteModelArchive writes a model archive holding the serialized model
+// and the binaries that it uses, downloaded from the controller that
+// hosts it.
+func WriteModelArchive(w io.Writer, config WriteModelArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	metadata, err := yaml.Marshal(config.Metadata)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	if err := writeArchiveFile(tw, archiveMetadataFile, metadata); err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeArchiveFile(tw, archiveModelFile, config.Model.Bytes); err != nil {
+		return errors.Trace(err)
+	}
+
+	for _, curlStr := range config.Model.Charms {
+		logger.Debugf("archiving charm %s", curlStr)
+		curl, err := charm.ParseURL(curlStr)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open charm %s", curl)
+		}
+		err = copyToArchive(tw, charmArchivePath(curlStr), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %s", curl)
+		}
+	}
+
+	for v, uri := range config.Model.Tools {
+		logger.Debugf("archiving agent binaries %s", v)
+		reader, err := config.ToolsDownloader.OpenURI(uri, nil)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open agent binaries %s", v)
+		}
+		err = copyToArchive(tw, toolsArchivePath(v), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive agent binaries %s", v)
+		}
+	}
+
+	for _, res := range config.Model.Resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			// Placeholders have no content, and are created when
+			// the model is imported.
+			continue
+		}
+		logger.Debugf("archiving resource %s/%s", rev.ApplicationID, rev.Name)
+		reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotatef(err, "cannot open resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+		err = copyToArchive(tw, resourceArchivePath(rev.ApplicationID, rev.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive resource %s/%s", rev.ApplicationID, rev.Name)
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := tw.Write(data)
+	return errors.Trace(err)
+}
+
+// copyToArchive adds the content to the archive. The content is
+// streamed through a temporary file, as its size is needed before it
+// can be written.
+func copyToArchive(tw *tar.Writer, name string, r io.Reader) error {
+	content, cleanup, err := streamThroughTempFile(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	hdr := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0600,
+		Size:     size,
+		ModTime:  time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = io.Copy(tw, content)
+	return errors.Trace(err)
+}
+
+// ModelArchive is a model archive that has been extracted so that the
+// model can be imported into a controller. It implements
+// CharmDownloader, ToolsDownloader and ResourceDownloader, so that the
+// binaries it holds can be sent to the controller with UploadBinaries.
+type ModelArchive struct {
+	// Metadata describes when and from where the model was exported.
+	Metadata ModelArchiveMetadata
+
+	// Model is the serialized model, along with the charms, agent
+	// binaries and resources that it uses.
+	Model migration.SerializedModel
+
+	dir string
+}
+
+// ReadModelArchive extracts the model archive into a temporary
+// directory, which is removed when the returned ModelArchive is closed.
+func ReadModelArchive(r io.Reader) (_ *ModelArchive, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+	if err := extractArchive(r, dir); err != nil {
+		return nil, errors.Annotate(err, "extracting model archive")
+	}
+
+	archive := &ModelArchive{dir: dir}
+	metadata, err := ioutil.ReadFile(filepath.Join(dir, archiveMetadataFile))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model archive metadata")
+	}
+	if err := yaml.Unmarshal(metadata, &archive.Metadata); err != nil {
+		return nil, errors.Annotate(err, "reading model archive metadata")
+	}
+	bytes, err := ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading archived model")
+	}
+	if archive.Model, err = NewSerializedModel(bytes); err != nil {
+		return nil, errors.Annotate(err, "reading archived model")
+	}
+	return archive, nil
+}
+
+func extractArchive(r io.Reader, dir string) error {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer gzr.Close()
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if hdr.Typeflag != tar.TypeReg && hdr.Typeflag != tar.TypeRegA {
+			return errors.Errorf("unexpected archive entry %q", hdr.Name)
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return errors.Errorf("invalid archive entry %q", hdr.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return errors.Trace(err)
+		}
+		if err := extractArchiveFile(tr, target); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+func extractArchiveFile(r io.Reader, target string) error {
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return errors.Trace(err)
+	}
+	return errors.Trace(f.Close())
+}
+
+func (a *ModelArchive) open(name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(a.dir, filepath.FromSlash(name)))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%s in model archive", name)
+	}
+	return f, errors.Trace(err)
+}
+
+// ModelInfo returns the details of the archived model that are needed
+// to check whether it can be imported into a controller.
+func (a *ModelArchive) ModelInfo() (migration.ModelInfo, error) {
+	model, err := description.Deserialize(a.Model.Bytes)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Trace(err)
+	}
+	name, _ := model.Config()["name"].(string)
+	agentVersion, _ := model.Config()["agent-version"].(string)
+	v, err := version.Parse(agentVersion)
+	if err != nil {
+		return migration.ModelInfo{}, errors.Annotate(err, "invalid model agent version")
+	}
+	return migration.ModelInfo{
+		UUID:                   model.Tag().Id(),
+		Owner:                  model.Owner(),
+		Name:                   name,
+		AgentVersion:           v,
+		ControllerAgentVersion: a.Metadata.ControllerAgentVersion,
+	}, nil
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (a *ModelArchive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(charmArchivePath(curl.String()))
+}
+
+// OpenURI is part of the ToolsDownloader interface. The URI is one of
+// those in the archived model's Tools.
+func (a *ModelArchive) OpenURI(uri string, _ url.Values) (io.ReadCloser, error) {
+	for v, toolsURI := range a.Model.Tools {
+		if toolsURI == uri {
+			return a.open(toolsArchivePath(v))
+		}
+	}
+	return nil, errors.NotFoundf("agent binaries %q in model archive", uri)
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (a *ModelArchive) OpenResource(app, name string) (io.ReadCloser, error) {
+	return a.open(resourceArchivePath(app, name))
+}
+
+// Close removes the extracted archive.
+func (a *ModelArchive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
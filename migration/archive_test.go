@@ -0,0 +1,156 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/migration"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ArchiveSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) TestWriteModelArchiveConfigValidate(c *gc.C) {
+	type T migration.WriteModelArchiveConfig // alias for brevity
+
+	check := func(modify func(*T), expect string) {
+		config := T{
+			Model:              coremigration.SerializedModel{Bytes: []byte("model")},
+			CharmDownloader:    &fakeDownloader{},
+			ToolsDownloader:    &fakeDownloader{},
+			ResourceDownloader: &fakeDownloader{},
+		}
+		modify(&config)
+		realConfig := migration.WriteModelArchiveConfig(config)
+		c.Check(realConfig.Validate(), gc.ErrorMatches, expect)
+	}
+
+	check(func(c *T) { c.Model.Bytes = nil }, "empty Model not valid")
+	check(func(c *T) { c.CharmDownloader = nil }, "missing CharmDownloader not valid")
+	check(func(c *T) { c.ToolsDownloader = nil }, "missing ToolsDownloader not valid")
+	check(func(c *T) { c.ResourceDownloader = nil }, "missing ResourceDownloader not valid")
+}
+
+func (s *ArchiveSuite) TestNewSerializedModel(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	modelBytes, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := migration.NewSerializedModel(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	curl, _ := app.CharmURL()
+	c.Check(model.Bytes, jc.DeepEquals, modelBytes)
+	c.Check(model.Charms, jc.DeepEquals, []string{curl.String()})
+	c.Check(model.Resources, gc.HasLen, 0)
+	for v, uri := range model.Tools {
+		c.Check(uri, gc.Equals, "/tools/"+v.String())
+	}
+}
+
+func (s *ArchiveSuite) TestWriteAndReadModelArchive(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "wordpress"}),
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	modelBytes, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := migration.NewSerializedModel(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+
+	metadata := migration.ModelArchiveMetadata{
+		ControllerAgentVersion: version.MustParse("2.5.0"),
+		Exported:               time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	downloader := &fakeDownloader{}
+	var buf bytes.Buffer
+	err = migration.WriteModelArchive(&buf, migration.WriteModelArchiveConfig{
+		Metadata:           metadata,
+		Model:              model,
+		CharmDownloader:    downloader,
+		ToolsDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(downloader.charms, jc.DeepEquals, model.Charms)
+
+	archive, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.Metadata.ControllerAgentVersion, gc.Equals, metadata.ControllerAgentVersion)
+	c.Check(archive.Metadata.Exported.Equal(metadata.Exported), jc.IsTrue)
+	c.Check(archive.Model, jc.DeepEquals, model)
+
+	info, err := archive.ModelInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(info.UUID, gc.Equals, s.State.ModelUUID())
+	c.Check(info.Name, gc.Equals, s.Model.Name())
+	c.Check(info.Owner, gc.Equals, s.Model.Owner())
+	c.Check(info.ControllerAgentVersion, gc.Equals, metadata.ControllerAgentVersion)
+
+	// The archive provides the binaries for uploading to the
+	// controller into which the model is imported.
+	uploader := &fakeUploader{
+		tools:     make(map[version.Binary]string),
+		resources: make(map[string]string),
+	}
+	err = migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:             archive.Model.Charms,
+		CharmDownloader:    archive,
+		CharmUploader:      uploader,
+		Tools:              archive.Model.Tools,
+		ToolsDownloader:    archive,
+		ToolsUploader:      uploader,
+		Resources:          archive.Model.Resources,
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(uploader.charms, jc.DeepEquals, model.Charms)
+	c.Check(uploader.tools, jc.DeepEquals, model.Tools)
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveBadArchive(c *gc.C) {
+	_, err := migration.ReadModelArchive(bytes.NewBufferString("not an archive"))
+	c.Assert(err, gc.ErrorMatches, "extracting model archive: .*")
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveInvalidEntry(c *gc.C) {
+	for _, name := range []string{"../model.yaml", "/model.yaml", "charms/../../model.yaml"} {
+		var buf bytes.Buffer
+		gzw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gzw)
+		err := tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     5,
+			Typeflag: tar.TypeReg,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = tw.Write([]byte("model"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(tw.Close(), jc.ErrorIsNil)
+		c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+		_, err = migration.ReadModelArchive(&buf)
+		c.Check(err, gc.ErrorMatches, `extracting model archive: invalid archive entry ".*"`)
+	}
+}
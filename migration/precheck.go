@@ -25,6 +25,12 @@ import (
 type PrecheckBackend interface {
 	AgentVersion() (version.Number, error)
 	NeedsCleanup() (bool, error)
+	HasActionSchedules() (bool, error)
+	HasHeldActions() (bool, error)
+	HasSecrets() (bool, error)
+	HasRollingUpgrades() (bool, error)
+	HasCharmProfiles() (bool, error)
+	HasActiveUpgradeSeries() (bool, error)
 	Model() (PrecheckModel, error)
 	AllModelUUIDs() ([]string, error)
 	IsUpgrading() (bool, error)
@@ -143,6 +149,10 @@ func SourcePrecheck(
 		return errors.New("cleanup needed")
 	}
 
+	if err := ctx.checkUnmigratedState(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
@@ -183,6 +193,43 @@ func (ctx *precheckContext) checkModel() error {
 	return nil
 }
 
+// checkUnmigratedState checks that the model has none of the state
+// that is not yet included in the model description, and so would be
+// lost by migrating the model.
+func (ctx *precheckContext) checkUnmigratedState() error {
+	if hasSchedules, err := ctx.backend.HasActionSchedules(); err != nil {
+		return errors.Annotate(err, "checking action schedules")
+	} else if hasSchedules {
+		return errors.New("model has action schedules, which cannot be migrated")
+	}
+	if hasHeld, err := ctx.backend.HasHeldActions(); err != nil {
+		return errors.Annotate(err, "checking held actions")
+	} else if hasHeld {
+		return errors.New("model has actions held by a parallelism limit, which cannot be migrated")
+	}
+	if hasSecrets, err := ctx.backend.HasSecrets(); err != nil {
+		return errors.Annotate(err, "checking secrets")
+	} else if hasSecrets {
+		return errors.New("model has secrets, which cannot be migrated")
+	}
+	if hasUpgrades, err := ctx.backend.HasRollingUpgrades(); err != nil {
+		return errors.Annotate(err, "checking rolling upgrades")
+	} else if hasUpgrades {
+		return errors.New("model has rolling upgrades, which cannot be migrated")
+	}
+	if hasProfiles, err := ctx.backend.HasCharmProfiles(); err != nil {
+		return errors.Annotate(err, "checking charm profiles")
+	} else if hasProfiles {
+		return errors.New("model has machines with charm LXD profiles, which cannot be migrated")
+	}
+	if hasUpgrades, err := ctx.backend.HasActiveUpgradeSeries(); err != nil {
+		return errors.Annotate(err, "checking application series upgrades")
+	} else if hasUpgrades {
+		return errors.New("model has application series upgrades in progress, which cannot be migrated")
+	}
+	return nil
+}
+
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
 // backend provided must be for the target controller.
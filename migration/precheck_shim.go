@@ -64,6 +64,72 @@ func (s *precheckShim) AgentVersion() (version.Number, error) {
 	return vers, nil
 }
 
+// HasActionSchedules implements PrecheckBackend.
+func (s *precheckShim) HasActionSchedules() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	schedules, err := model.AllActionSchedules()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(schedules) > 0, nil
+}
+
+// HasHeldActions implements PrecheckBackend.
+func (s *precheckShim) HasHeldActions() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return model.HasHeldActions()
+}
+
+// HasSecrets implements PrecheckBackend.
+func (s *precheckShim) HasSecrets() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	secrets, err := model.AllSecrets()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(secrets) > 0, nil
+}
+
+// HasRollingUpgrades implements PrecheckBackend.
+func (s *precheckShim) HasRollingUpgrades() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	upgrades, err := model.AllRollingUpgrades()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return len(upgrades) > 0, nil
+}
+
+// HasCharmProfiles implements PrecheckBackend.
+func (s *precheckShim) HasCharmProfiles() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return model.HasCharmProfiles()
+}
+
+// HasActiveUpgradeSeries implements PrecheckBackend.
+func (s *precheckShim) HasActiveUpgradeSeries() (bool, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return model.HasActiveUpgradeSeries()
+}
+
 // AllMachines implements PrecheckBackend.
 func (s *precheckShim) AllMachines() ([]PrecheckMachine, error) {
 	machines, err := s.State.AllMachines()
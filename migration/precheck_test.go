@@ -107,6 +107,90 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestActionSchedulesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.actionSchedulesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking action schedules: boom")
+}
+
+func (*SourcePrecheckSuite) TestActionSchedules(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasActionSchedules = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has action schedules, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestHeldActionsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.heldActionsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking held actions: boom")
+}
+
+func (*SourcePrecheckSuite) TestHeldActions(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasHeldActions = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has actions held by a parallelism limit, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestSecretsError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.secretsErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking secrets: boom")
+}
+
+func (*SourcePrecheckSuite) TestSecrets(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasSecrets = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has secrets, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestRollingUpgradesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.rollingUpgradesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking rolling upgrades: boom")
+}
+
+func (*SourcePrecheckSuite) TestRollingUpgrades(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasRollingUpgrades = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has rolling upgrades, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestCharmProfilesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.charmProfilesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking charm profiles: boom")
+}
+
+func (*SourcePrecheckSuite) TestCharmProfiles(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasCharmProfiles = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has machines with charm LXD profiles, which cannot be migrated")
+}
+
+func (*SourcePrecheckSuite) TestActiveUpgradeSeriesError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.activeUpgradeSeriesErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking application series upgrades: boom")
+}
+
+func (*SourcePrecheckSuite) TestActiveUpgradeSeries(c *gc.C) {
+	backend := newFakeBackend()
+	backend.hasActiveUpgradeSeries = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has application series upgrades in progress, which cannot be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -742,6 +826,24 @@ type fakeBackend struct {
 	cleanupNeeded bool
 	cleanupErr    error
 
+	hasActionSchedules bool
+	actionSchedulesErr error
+
+	hasHeldActions bool
+	heldActionsErr error
+
+	hasSecrets bool
+	secretsErr error
+
+	hasRollingUpgrades bool
+	rollingUpgradesErr error
+
+	hasCharmProfiles bool
+	charmProfilesErr error
+
+	hasActiveUpgradeSeries bool
+	activeUpgradeSeriesErr error
+
 	isUpgrading    bool
 	isUpgradingErr error
 
@@ -778,6 +880,30 @@ func (b *fakeBackend) NeedsCleanup() (bool, error) {
 	return b.cleanupNeeded, b.cleanupErr
 }
 
+func (b *fakeBackend) HasActionSchedules() (bool, error) {
+	return b.hasActionSchedules, b.actionSchedulesErr
+}
+
+func (b *fakeBackend) HasHeldActions() (bool, error) {
+	return b.hasHeldActions, b.heldActionsErr
+}
+
+func (b *fakeBackend) HasSecrets() (bool, error) {
+	return b.hasSecrets, b.secretsErr
+}
+
+func (b *fakeBackend) HasRollingUpgrades() (bool, error) {
+	return b.hasRollingUpgrades, b.rollingUpgradesErr
+}
+
+func (b *fakeBackend) HasCharmProfiles() (bool, error) {
+	return b.hasCharmProfiles, b.charmProfilesErr
+}
+
+func (b *fakeBackend) HasActiveUpgradeSeries() (bool, error) {
+	return b.hasActiveUpgradeSeries, b.activeUpgradeSeriesErr
+}
+
 func (b *fakeBackend) AgentVersion() (version.Number, error) {
 	return backendVersion, b.agentVersionErr
 }
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/utils/set"
 
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/raftlease"
@@ -19,6 +20,7 @@ type leaseStore struct {
 	mu       sync.Mutex
 	clock    clock.Clock
 	entries  map[lease.Key]*entry
+	pinned   map[lease.Key]set.Strings
 	trapdoor raftlease.TrapdoorFunc
 	target   raftlease.NotifyTarget
 }
@@ -40,6 +42,7 @@ func newLeaseStore(clock clock.Clock, target raftlease.NotifyTarget, trapdoor ra
 	return &leaseStore{
 		clock:    clock,
 		entries:  make(map[lease.Key]*entry),
+		pinned:   make(map[lease.Key]set.Strings),
 		target:   target,
 		trapdoor: trapdoor,
 	}
@@ -86,6 +89,27 @@ func (s *leaseStore) ExtendLease(key lease.Key, req lease.Request) error {
 	return nil
 }
 
+// TransferLease is part of lease.Store.
+func (s *leaseStore) TransferLease(key lease.Key, holder string, req lease.Request) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, found := s.entries[key]
+	if !found {
+		return lease.ErrInvalid
+	}
+	if entry.holder != holder {
+		return lease.ErrInvalid
+	}
+	if _, pinned := s.pinned[key]; pinned {
+		return lease.ErrInvalid
+	}
+	entry.holder = req.Holder
+	entry.start = s.clock.Now()
+	entry.duration = req.Duration
+	s.target.Claimed(key, req.Holder)
+	return nil
+}
+
 // Expire is part of lease.Store.
 func (s *leaseStore) ExpireLease(key lease.Key) error {
 	s.mu.Lock()
@@ -94,6 +118,9 @@ func (s *leaseStore) ExpireLease(key lease.Key) error {
 	if !found {
 		return lease.ErrInvalid
 	}
+	if _, pinned := s.pinned[key]; pinned {
+		return lease.ErrInvalid
+	}
 	expiry := entry.start.Add(entry.duration)
 	if !s.clock.Now().After(expiry) {
 		return lease.ErrInvalid
@@ -118,6 +145,41 @@ func (s *leaseStore) Leases() map[lease.Key]lease.Info {
 	return results
 }
 
+// PinLease is part of lease.Store.
+func (s *leaseStore) PinLease(key lease.Key, entity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.pinned[key] == nil {
+		s.pinned[key] = set.NewStrings()
+	}
+	s.pinned[key].Add(entity)
+	return nil
+}
+
+// UnpinLease is part of lease.Store.
+func (s *leaseStore) UnpinLease(key lease.Key, entity string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entities, found := s.pinned[key]; found {
+		entities.Remove(entity)
+		if entities.IsEmpty() {
+			delete(s.pinned, key)
+		}
+	}
+	return nil
+}
+
+// Pinned is part of lease.Store.
+func (s *leaseStore) Pinned() map[lease.Key][]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make(map[lease.Key][]string, len(s.pinned))
+	for key, entities := range s.pinned {
+		results[key] = entities.SortedValues()
+	}
+	return results
+}
+
 // Refresh is part of lease.Store.
 func (s *leaseStore) Refresh() error {
 	return nil
@@ -13,7 +13,9 @@ import (
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/container"
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
@@ -23,6 +25,8 @@ import (
 	"github.com/juju/juju/tools"
 )
 
+var _ container.LXDProfileManager = (*environ)(nil)
+
 // MaintainInstance is specified in the InstanceBroker interface.
 func (*environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
 	return nil
@@ -337,3 +341,14 @@ func (env *environ) StopInstances(ctx context.ProviderCallContext, instances ...
 
 	return errors.Trace(env.server.RemoveContainers(names))
 }
+
+// ReplaceCharmProfiles implements container.LXDProfileManager.
+func (env *environ) ReplaceCharmProfiles(
+	id instance.Id, oldProfiles []string, newProfiles []lxdprofile.Profile,
+) error {
+	name := string(id)
+	if prefix := env.namespace.Prefix(); !strings.HasPrefix(name, prefix) {
+		return errors.Errorf("container %q is not in namespace %q", name, prefix)
+	}
+	return errors.Trace(env.server.ReplaceCharmProfiles(name, oldProfiles, newProfiles))
+}
@@ -12,10 +12,13 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/lxc/lxd/shared/api"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/container"
 	containerlxd "github.com/juju/juju/container/lxd"
 	lxdtesting "github.com/juju/juju/container/lxd/testing"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/provider/lxd"
 )
@@ -344,6 +347,32 @@ func (s *environBrokerSuite) TestStopInstances(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *environBrokerSuite) TestReplaceCharmProfiles(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	profiles := []lxdprofile.Profile{{
+		Name:    "juju-model-app-2",
+		Profile: &charm.LXDProfile{Config: map[string]string{"security.nesting": "true"}},
+	}}
+	svr.EXPECT().ReplaceCharmProfiles("juju-f75cba-1", []string{"juju-model-app-1"}, profiles).Return(nil)
+
+	env := s.NewEnviron(c, svr, nil).(container.LXDProfileManager)
+	err := env.ReplaceCharmProfiles("juju-f75cba-1", []string{"juju-model-app-1"}, profiles)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *environBrokerSuite) TestReplaceCharmProfilesNotInNamespace(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	svr := lxd.NewMockServer(ctrl)
+
+	env := s.NewEnviron(c, svr, nil).(container.LXDProfileManager)
+	err := env.ReplaceCharmProfiles("not-in-namespace", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `container "not-in-namespace" is not in namespace "juju-f75cba-"`)
+}
+
 func (s *environBrokerSuite) TestImageSourcesDefault(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
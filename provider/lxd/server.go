@@ -20,6 +20,7 @@ import (
 	"github.com/juju/utils"
 
 	"github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/utils/proxy"
@@ -52,6 +53,7 @@ type Server interface {
 	CreateProfileWithConfig(string, map[string]string) error
 	GetProfile(string) (*lxdapi.Profile, string, error)
 	HasProfile(string) (bool, error)
+	ReplaceCharmProfiles(string, []string, []lxdprofile.Profile) error
 	VerifyNetworkDevice(*lxdapi.Profile, string) error
 	EnsureDefaultStorage(*lxdapi.Profile, string) error
 	StorageSupported() bool
@@ -7,6 +7,7 @@ package lxd
 import (
 	gomock "github.com/golang/mock/gomock"
 	lxd "github.com/juju/juju/container/lxd"
+	lxdprofile "github.com/juju/juju/core/lxdprofile"
 	environs "github.com/juju/juju/environs"
 	network "github.com/juju/juju/network"
 	client "github.com/lxc/lxd/client"
@@ -435,6 +436,18 @@ func (mr *MockServerMockRecorder) RemoveContainers(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContainers", reflect.TypeOf((*MockServer)(nil).RemoveContainers), arg0)
 }
 
+// ReplaceCharmProfiles mocks base method
+func (m *MockServer) ReplaceCharmProfiles(arg0 string, arg1 []string, arg2 []lxdprofile.Profile) error {
+	ret := m.ctrl.Call(m, "ReplaceCharmProfiles", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceCharmProfiles indicates an expected call of ReplaceCharmProfiles
+func (mr *MockServerMockRecorder) ReplaceCharmProfiles(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCharmProfiles", reflect.TypeOf((*MockServer)(nil).ReplaceCharmProfiles), arg0, arg1, arg2)
+}
+
 // ServerCertificate mocks base method
 func (m *MockServer) ServerCertificate() string {
 	ret := m.ctrl.Call(m, "ServerCertificate")
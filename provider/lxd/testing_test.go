@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container/lxd"
 	containerlxd "github.com/juju/juju/container/lxd"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -650,6 +651,11 @@ func (conn *StubClient) CreateProfileWithConfig(name string, cfg map[string]stri
 	return conn.NextErr()
 }
 
+func (conn *StubClient) ReplaceCharmProfiles(name string, oldProfiles []string, newProfiles []lxdprofile.Profile) error {
+	conn.AddCall("ReplaceCharmProfiles", name, oldProfiles, newProfiles)
+	return conn.NextErr()
+}
+
 func (conn *StubClient) ServerCertificate() string {
 	conn.AddCall("ServerCertificate")
 	return conn.ServerCert
@@ -104,6 +104,11 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Queue is the id of the action queue that controls when the
+	// action is released to its receiver, if the action was queued
+	// with EnqueueActions.
+	Queue string `bson:"queue,omitempty"`
 }
 
 // action represents an instruction to do some "action" and is expected
@@ -225,7 +230,7 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 		return nil, errors.Trace(err)
 	}
 
-	err = m.st.db().RunTransaction([]txn.Op{
+	ops := []txn.Op{
 		{
 			C:  actionsC,
 			Id: a.doc.DocId,
@@ -245,7 +250,12 @@ func (a *action) removeAndLog(finalStatus ActionStatus, results map[string]inter
 			C:      actionNotificationsC,
 			Id:     m.st.docID(ensureActionMarker(a.Receiver()) + a.Id()),
 			Remove: true,
-		}})
+		}}
+	if a.doc.Queue != "" {
+		err = a.finishQueuedAction(m, ops)
+	} else {
+		err = m.st.db().RunTransaction(ops)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -324,6 +334,18 @@ func (m *Model) AllActions() ([]Action, error) {
 	return results, nil
 }
 
+// PendingActionCount returns the number of actions in the model that
+// are waiting to be run.
+func (m *Model) PendingActionCount() (int, error) {
+	actions, closer := m.st.db().GetCollection(actionsC)
+	defer closer()
+	count, err := actions.Find(bson.D{{"status", ActionPending}}).Count()
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot count pending actions")
+	}
+	return count, nil
+}
+
 // ActionByTag returns an Action given an ActionTag.
 func (m *Model) ActionByTag(tag names.ActionTag) (Action, error) {
 	return m.Action(tag.Id())
@@ -391,6 +391,26 @@ func (s *ActionSuite) TestAddActionFailsOnDeadUnitInTransaction(c *gc.C) {
 	c.Assert(err, gc.Equals, state.ErrDead)
 }
 
+func (s *ActionSuite) TestPendingActionCount(c *gc.C) {
+	count, err := s.model.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit2.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.model.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 2)
+
+	_, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.model.PendingActionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+}
+
 func (s *ActionSuite) TestFail(c *gc.C) {
 	// get unit, add an action, retrieve that action
 	unit, err := s.State.Unit(s.unit.Name())
@@ -0,0 +1,477 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ActionOrdering determines the order in which the actions in an action
+// queue are released to their receivers.
+type ActionOrdering string
+
+const (
+	// ActionOrderQueued releases actions in the order they were queued.
+	ActionOrderQueued ActionOrdering = ""
+
+	// ActionOrderLeaderFirst releases the action on the leader unit of
+	// the application before the actions on the other units.
+	ActionOrderLeaderFirst ActionOrdering = "leader-first"
+
+	// ActionOrderLeaderLast releases the action on the leader unit of
+	// the application only once all the other actions have been
+	// released.
+	ActionOrderLeaderLast ActionOrdering = "leader-last"
+)
+
+// Validate returns an error if the ordering is not known.
+func (o ActionOrdering) Validate() error {
+	switch o {
+	case ActionOrderQueued, ActionOrderLeaderFirst, ActionOrderLeaderLast:
+		return nil
+	}
+	return errors.NotValidf("action ordering %q", o)
+}
+
+// ActionQueuePolicy controls how a set of actions that are queued
+// together are released to their receivers.
+type ActionQueuePolicy struct {
+	// Parallelism is the maximum number of units of an application
+	// that may be running the same action at once, including actions
+	// queued by earlier requests; the most recent request sets the
+	// limit. For other receivers, it limits the number of the same
+	// action on that receiver. An action is released when it is queued
+	// or when another action in its queue finishes. Zero means that
+	// there is no limit.
+	Parallelism int
+
+	// Ordering determines the order in which the actions are released.
+	Ordering ActionOrdering
+}
+
+// Validate returns an error if the policy is not valid.
+func (p ActionQueuePolicy) Validate() error {
+	if p.Parallelism < 0 {
+		return errors.NotValidf("negative parallelism %d", p.Parallelism)
+	}
+	return errors.Trace(p.Ordering.Validate())
+}
+
+// EnqueueActionArgs holds the details of an action to be queued with
+// EnqueueActions.
+type EnqueueActionArgs struct {
+	Receiver   ActionReceiver
+	Name       string
+	Parameters map[string]interface{}
+}
+
+// actionPayloader is implemented by action receivers that validate
+// action payloads and insert their defaults.
+type actionPayloader interface {
+	actionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error)
+}
+
+// actionQueueDoc records the actions of the same name queued on the
+// units of an application with an ActionQueuePolicy that have not yet
+// finished. Actions are released to their receivers by adding their
+// notification documents, and the queue is removed once all of its
+// actions have finished.
+type actionQueueDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	TxnRevno  int64  `bson:"txn-revno"`
+
+	// Parallelism is the maximum number of the actions that may be
+	// released at once, or zero if there is no limit.
+	Parallelism int `bson:"parallelism"`
+
+	// Held holds the ids of the actions that have not been released to
+	// their receivers, in the order they are to be released.
+	Held []string `bson:"held"`
+
+	// Released holds the ids of the actions that have been released to
+	// their receivers and have not yet finished.
+	Released []string `bson:"released"`
+}
+
+// EnqueueActions queues a set of actions that are released to their
+// receivers according to the given policy. Either all of the actions
+// are queued or none of them are.
+func (m *Model) EnqueueActions(args []EnqueueActionArgs, policy ActionQueuePolicy) ([]Action, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+	if policy.Parallelism == 0 && policy.Ordering == ActionOrderQueued {
+		// There's nothing to hold back, so queue them as usual.
+		var actions []Action
+		for _, arg := range args {
+			action, err := arg.Receiver.AddAction(arg.Name, arg.Parameters)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			actions = append(actions, action)
+		}
+		return actions, nil
+	}
+	order, err := m.actionReleaseOrder(args, policy.Ordering)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	docs := make([]actionDoc, len(args))
+	notifications := make([]actionNotificationDoc, len(args))
+	for i, arg := range args {
+		payload := arg.Parameters
+		if payloader, ok := arg.Receiver.(actionPayloader); ok {
+			if payload, err = payloader.actionPayload(arg.Name, arg.Parameters); err != nil {
+				return nil, err
+			}
+		} else if arg.Name == "" {
+			return nil, errors.New("action name required")
+		}
+		receiverTag := arg.Receiver.Tag()
+		doc, ndoc, err := newActionDoc(m.st, receiverTag, arg.Name, payload)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc.Queue = m.st.docID(actionQueueId(receiverTag, arg.Name))
+		docs[i], notifications[i] = doc, ndoc
+	}
+
+	buildTxn := func(int) ([]txn.Op, error) {
+		var ops []txn.Op
+		for i, doc := range docs {
+			receiverTag := args[i].Receiver.Tag()
+			receiverCollectionName, receiverId, err := m.st.tagToCollectionAndId(receiverTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
+				return nil, errors.Trace(err)
+			} else if !notDead {
+				return nil, errors.Annotatef(ErrDead, "cannot queue action on %s", names.ReadableString(receiverTag))
+			}
+			ops = append(ops, txn.Op{
+				C:      receiverCollectionName,
+				Id:     receiverId,
+				Assert: notDeadDoc,
+			}, txn.Op{
+				C:      actionsC,
+				Id:     doc.DocId,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			})
+		}
+
+		// Add the actions to their queues, behind any actions already
+		// held there, releasing those the queues' parallelism allows.
+		var queues []*actionQueueDoc
+		queuesById := make(map[string]*actionQueueDoc)
+		for _, i := range order {
+			queue, ok := queuesById[docs[i].Queue]
+			if !ok {
+				var releaseOps []txn.Op
+				queue, releaseOps, err = m.openActionQueue(docs[i].Queue, policy.Parallelism)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, releaseOps...)
+				queues = append(queues, queue)
+				queuesById[queue.DocId] = queue
+			}
+			actionId := m.st.localID(docs[i].DocId)
+			if len(queue.Held) > 0 || !queue.canRelease() {
+				queue.Held = append(queue.Held, actionId)
+				continue
+			}
+			queue.Released = append(queue.Released, actionId)
+			ops = append(ops, txn.Op{
+				C:      actionNotificationsC,
+				Id:     notifications[i].DocId,
+				Assert: txn.DocMissing,
+				Insert: notifications[i],
+			})
+		}
+		for _, queue := range queues {
+			if queue.TxnRevno == 0 {
+				ops = append(ops, txn.Op{
+					C:      actionQueuesC,
+					Id:     queue.DocId,
+					Assert: txn.DocMissing,
+					Insert: queue,
+				})
+				continue
+			}
+			ops = append(ops, txn.Op{
+				C:      actionQueuesC,
+				Id:     queue.DocId,
+				Assert: bson.D{{"txn-revno", queue.TxnRevno}},
+				Update: bson.D{{"$set", bson.D{
+					{"parallelism", queue.Parallelism},
+					{"held", queue.Held},
+					{"released", queue.Released},
+				}}},
+			})
+		}
+		return ops, nil
+	}
+	if err := m.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Annotate(err, "cannot queue actions")
+	}
+	actions := make([]Action, len(docs))
+	for i, doc := range docs {
+		actions[i] = newAction(m.st, doc)
+	}
+	return actions, nil
+}
+
+// HasHeldActions returns whether any actions in the model are being
+// held in their queues until other actions finish.
+func (m *Model) HasHeldActions() (bool, error) {
+	queues, closer := m.st.db().GetCollection(actionQueuesC)
+	defer closer()
+
+	count, err := queues.Find(bson.D{{"held.0", bson.D{{"$exists", true}}}}).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count action queues")
+	}
+	return count > 0, nil
+}
+
+// actionQueueId returns the id of the queue for the named action on
+// the receiver. Actions on the units of an application share a queue.
+func actionQueueId(receiverTag names.Tag, name string) string {
+	queueTag := receiverTag
+	if unitTag, ok := receiverTag.(names.UnitTag); ok {
+		if appName, err := names.UnitApplication(unitTag.Id()); err == nil {
+			queueTag = names.NewApplicationTag(appName)
+		}
+	}
+	return queueTag.String() + "#" + name
+}
+
+// openActionQueue returns the action queue with the given id, or a new
+// one if it doesn't exist, with its parallelism set to that given. The
+// returned operations release the held actions that the parallelism
+// now allows.
+func (m *Model) openActionQueue(queueId string, parallelism int) (*actionQueueDoc, []txn.Op, error) {
+	queues, closer := m.st.db().GetCollection(actionQueuesC)
+	defer closer()
+
+	queue := actionQueueDoc{
+		DocId:     queueId,
+		ModelUUID: m.UUID(),
+	}
+	if err := queues.FindId(queueId).One(&queue); err != nil && err != mgo.ErrNotFound {
+		return nil, nil, errors.Annotate(err, "cannot get action queue")
+	}
+	queue.Parallelism = parallelism
+	ops, err := m.releaseHeldActionsOps(&queue)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return &queue, ops, nil
+}
+
+// actionReleaseOrder returns the indices of the actions in the order
+// they are to be released. Leader orderings move the actions on the
+// leader units of applications to the front or back of the queue.
+func (m *Model) actionReleaseOrder(args []EnqueueActionArgs, ordering ActionOrdering) ([]int, error) {
+	order := make([]int, len(args))
+	for i := range order {
+		order[i] = i
+	}
+	if ordering == ActionOrderQueued {
+		return order, nil
+	}
+	leaders, err := m.st.ApplicationLeaders()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	isLeader := func(i int) bool {
+		unitTag, ok := args[i].Receiver.Tag().(names.UnitTag)
+		if !ok {
+			return false
+		}
+		appName, err := names.UnitApplication(unitTag.Id())
+		return err == nil && leaders[appName] == unitTag.Id()
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		if ordering == ActionOrderLeaderFirst {
+			return isLeader(order[i]) && !isLeader(order[j])
+		}
+		return !isLeader(order[i]) && isLeader(order[j])
+	})
+	return order, nil
+}
+
+// finishQueuedActionOps returns the operations needed to record that
+// the action has finished in its queue, releasing the next held action
+// if the finished action had been released. Held actions whose
+// receivers have died are failed rather than released.
+func (m *Model) finishQueuedActionOps(queueId, actionId string) ([]txn.Op, error) {
+	queues, closer := m.st.db().GetCollection(actionQueuesC)
+	defer closer()
+
+	var queue actionQueueDoc
+	err := queues.FindId(queueId).One(&queue)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action queue")
+	}
+
+	// If the finished action had been released, the next held action
+	// is released in its place.
+	queue.Held = withoutActionId(queue.Held, actionId)
+	queue.Released = withoutActionId(queue.Released, actionId)
+	ops, err := m.releaseHeldActionsOps(&queue)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(queue.Held) == 0 && len(queue.Released) == 0 {
+		return append(ops, txn.Op{
+			C:      actionQueuesC,
+			Id:     queueId,
+			Assert: bson.D{{"txn-revno", queue.TxnRevno}},
+			Remove: true,
+		}), nil
+	}
+	return append(ops, txn.Op{
+		C:      actionQueuesC,
+		Id:     queueId,
+		Assert: bson.D{{"txn-revno", queue.TxnRevno}},
+		Update: bson.D{{"$set", bson.D{
+			{"held", queue.Held},
+			{"released", queue.Released},
+		}}},
+	}), nil
+}
+
+// releaseHeldActionsOps returns the operations needed to release the
+// queue's held actions, in order, while its parallelism allows,
+// updating the queue to match. Held actions whose receivers have died
+// are failed rather than released.
+func (m *Model) releaseHeldActionsOps(queue *actionQueueDoc) ([]txn.Op, error) {
+	var ops []txn.Op
+	for len(queue.Held) > 0 && queue.canRelease() {
+		next := queue.Held[0]
+		queue.Held = queue.Held[1:]
+		releaseOps, ok, err := m.releaseActionOps(next)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, releaseOps...)
+		if ok {
+			queue.Released = append(queue.Released, next)
+		}
+	}
+	return ops, nil
+}
+
+// canRelease returns whether the queue's parallelism allows another of
+// its actions to be released.
+func (q *actionQueueDoc) canRelease() bool {
+	return q.Parallelism == 0 || len(q.Released) < q.Parallelism
+}
+
+// releaseActionOps returns the operations needed to release a held
+// action to its receiver, and whether the action was released. If the
+// receiver is dead, the operations fail the action instead.
+func (m *Model) releaseActionOps(actionId string) ([]txn.Op, bool, error) {
+	action, err := m.Action(actionId)
+	if errors.IsNotFound(err) {
+		return nil, false, nil
+	} else if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	receiverTag, err := names.ActionReceiverTag(action.Receiver())
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	receiverCollectionName, receiverId, err := m.st.tagToCollectionAndId(receiverTag)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
+		return nil, false, errors.Trace(err)
+	} else if !notDead {
+		return []txn.Op{{
+			C:      actionsC,
+			Id:     m.st.docID(actionId),
+			Assert: bson.D{{"status", ActionPending}},
+			Update: bson.D{{"$set", bson.D{
+				{"status", ActionFailed},
+				{"message", "action receiver is dead"},
+				{"completed", m.st.nowToTheSecond()},
+			}}},
+		}}, false, nil
+	}
+	prefix := ensureActionMarker(receiverTag.Id())
+	return []txn.Op{{
+		C:      receiverCollectionName,
+		Id:     receiverId,
+		Assert: notDeadDoc,
+	}, {
+		C:      actionsC,
+		Id:     m.st.docID(actionId),
+		Assert: bson.D{{"status", ActionPending}},
+	}, {
+		C:      actionNotificationsC,
+		Id:     m.st.docID(prefix + actionId),
+		Assert: txn.DocMissing,
+		Insert: actionNotificationDoc{
+			DocId:     m.st.docID(prefix + actionId),
+			ModelUUID: m.UUID(),
+			Receiver:  receiverTag.Id(),
+			ActionID:  actionId,
+		},
+	}}, true, nil
+}
+
+// finishQueuedAction takes the action off of the pending queue as
+// removeAndLog does, and releases the next action held in the action's
+// queue.
+func (a *action) finishQueuedAction(m *Model, finishOps []txn.Op) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			current, err := m.Action(a.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			switch current.Status() {
+			case ActionCompleted, ActionCancelled, ActionFailed:
+				return nil, txn.ErrAborted
+			}
+		}
+		queueOps, err := m.finishQueuedActionOps(a.doc.Queue, a.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := append([]txn.Op(nil), finishOps...)
+		return append(ops, queueOps...), nil
+	}
+	return m.st.db().Run(buildTxn)
+}
+
+// withoutActionId returns the ids without the given id, preserving
+// their order.
+func withoutActionId(ids []string, id string) []string {
+	result := make([]string, 0, len(ids))
+	for _, v := range ids {
+		if v != id {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,219 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"sort"
+
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+)
+
+type ActionQueueSuite struct {
+	ConnSuite
+	units []*state.Unit
+	model *state.Model
+}
+
+var _ = gc.Suite(&ActionQueueSuite{})
+
+func (s *ActionQueueSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	application := s.AddTestingApplication(c, "dummy", ch)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(ch.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionQueueSuite) enqueue(c *gc.C, policy state.ActionQueuePolicy) []state.Action {
+	var args []state.EnqueueActionArgs
+	for _, unit := range s.units {
+		args = append(args, state.EnqueueActionArgs{
+			Receiver: unit,
+			Name:     "snapshot",
+		})
+	}
+	actions, err := s.model.EnqueueActions(args, policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, len(s.units))
+	for i, action := range actions {
+		c.Check(action.Receiver(), gc.Equals, s.units[i].Name())
+		c.Check(action.Status(), gc.Equals, state.ActionPending)
+	}
+	return actions
+}
+
+func (s *ActionQueueSuite) assertReleased(c *gc.C, actions ...state.Action) {
+	expected := []string{}
+	for _, action := range actions {
+		expected = append(expected, action.Id())
+	}
+	sort.Strings(expected)
+	released, err := state.ReleasedActionIds(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(released, jc.DeepEquals, expected)
+}
+
+func (s *ActionQueueSuite) assertQueueCount(c *gc.C, expected int) {
+	count, err := state.ActionQueueCount(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, expected)
+}
+
+func (s *ActionQueueSuite) finish(c *gc.C, action state.Action, status state.ActionStatus) {
+	_, err := action.Finish(state.ActionResults{Status: status})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionQueueSuite) TestEnqueueActionsNoPolicy(c *gc.C) {
+	actions := s.enqueue(c, state.ActionQueuePolicy{})
+	s.assertReleased(c, actions...)
+	s.assertQueueCount(c, 0)
+}
+
+func (s *ActionQueueSuite) TestEnqueueActionsInvalidPolicy(c *gc.C) {
+	_, err := s.model.EnqueueActions(nil, state.ActionQueuePolicy{Parallelism: -1})
+	c.Check(err, gc.ErrorMatches, "negative parallelism -1 not valid")
+	_, err = s.model.EnqueueActions(nil, state.ActionQueuePolicy{Ordering: "random"})
+	c.Check(err, gc.ErrorMatches, `action ordering "random" not valid`)
+}
+
+func (s *ActionQueueSuite) TestEnqueueActionsValidatesParams(c *gc.C) {
+	_, err := s.model.EnqueueActions([]state.EnqueueActionArgs{{
+		Receiver: s.units[0],
+		Name:     "no-such-action",
+	}}, state.ActionQueuePolicy{Parallelism: 1})
+	c.Check(err, gc.ErrorMatches, `action "no-such-action" not defined on unit "dummy/0"`)
+	s.assertReleased(c)
+	s.assertQueueCount(c, 0)
+}
+
+func (s *ActionQueueSuite) TestEnqueueActionsParallelism(c *gc.C) {
+	actions := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 2})
+	s.assertReleased(c, actions[0], actions[1])
+	s.assertQueueCount(c, 1)
+
+	s.finish(c, actions[1], state.ActionCompleted)
+	s.assertReleased(c, actions[0], actions[2])
+
+	s.finish(c, actions[0], state.ActionFailed)
+	s.assertReleased(c, actions[2])
+	s.assertQueueCount(c, 1)
+
+	s.finish(c, actions[2], state.ActionCompleted)
+	s.assertReleased(c)
+	s.assertQueueCount(c, 0)
+}
+
+func (s *ActionQueueSuite) TestEnqueueActionsParallelismAcrossRequests(c *gc.C) {
+	first := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 2})
+	s.assertReleased(c, first[0], first[1])
+
+	// The limit applies to all the application's units running the
+	// action, not just those in the same request.
+	second := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 2})
+	s.assertReleased(c, first[0], first[1])
+	s.assertQueueCount(c, 1)
+
+	s.finish(c, first[0], state.ActionCompleted)
+	s.assertReleased(c, first[1], first[2])
+	s.finish(c, first[1], state.ActionCompleted)
+	s.assertReleased(c, first[2], second[0])
+
+	// A later request sets the limit for the held actions too.
+	third := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 4})
+	s.assertReleased(c, first[2], second[0], second[1], second[2])
+
+	for _, action := range append(append(first[2:], second...), third...) {
+		s.finish(c, action, state.ActionCompleted)
+	}
+	s.assertReleased(c)
+	s.assertQueueCount(c, 0)
+}
+
+func (s *ActionQueueSuite) TestHasHeldActions(c *gc.C) {
+	held, err := s.model.HasHeldActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsFalse)
+
+	actions := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 2})
+	held, err = s.model.HasHeldActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsTrue)
+
+	s.finish(c, actions[0], state.ActionCompleted)
+	held, err = s.model.HasHeldActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(held, jc.IsFalse)
+}
+
+func (s *ActionQueueSuite) TestCancelHeldAction(c *gc.C) {
+	actions := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 1})
+	s.assertReleased(c, actions[0])
+
+	// Cancelling a held action doesn't release another.
+	s.finish(c, actions[1], state.ActionCancelled)
+	s.assertReleased(c, actions[0])
+
+	s.finish(c, actions[0], state.ActionCompleted)
+	s.assertReleased(c, actions[2])
+
+	s.finish(c, actions[2], state.ActionCompleted)
+	s.assertQueueCount(c, 0)
+}
+
+func (s *ActionQueueSuite) TestHeldActionOnDeadUnitFails(c *gc.C) {
+	actions := s.enqueue(c, state.ActionQueuePolicy{Parallelism: 1})
+	err := s.units[1].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.finish(c, actions[0], state.ActionCompleted)
+	s.assertReleased(c, actions[2])
+
+	action, err := s.model.Action(actions[1].Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(action.Status(), gc.Equals, state.ActionFailed)
+	_, message := action.Results()
+	c.Check(message, gc.Equals, "action receiver is dead")
+}
+
+func (s *ActionQueueSuite) TestEnqueueActionsLeaderOrdering(c *gc.C) {
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("actionqueue_test"))
+	target.Claimed(lease.Key{"application-leadership", s.State.ModelUUID(), "dummy"}, "dummy/1")
+
+	actions := s.enqueue(c, state.ActionQueuePolicy{
+		Parallelism: 1,
+		Ordering:    state.ActionOrderLeaderFirst,
+	})
+	s.assertReleased(c, actions[1])
+	s.finish(c, actions[1], state.ActionCompleted)
+	s.assertReleased(c, actions[0])
+	s.finish(c, actions[0], state.ActionCompleted)
+	s.assertReleased(c, actions[2])
+	s.finish(c, actions[2], state.ActionCompleted)
+
+	actions = s.enqueue(c, state.ActionQueuePolicy{
+		Parallelism: 1,
+		Ordering:    state.ActionOrderLeaderLast,
+	})
+	s.assertReleased(c, actions[0])
+	s.finish(c, actions[0], state.ActionCompleted)
+	s.assertReleased(c, actions[2])
+	s.finish(c, actions[2], state.ActionCompleted)
+	s.assertReleased(c, actions[1])
+}
@@ -0,0 +1,449 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/actions"
+)
+
+// maxActionScheduleRuns is the number of runs kept in the history of
+// each action schedule.
+const maxActionScheduleRuns = 20
+
+var validActionScheduleName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// actionScheduleDoc records an action that is queued periodically on
+// a set of units.
+type actionScheduleDoc struct {
+	DocId     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Name      string `bson:"name"`
+
+	// Schedule holds the cron-style schedule the action is run on.
+	Schedule string `bson:"schedule"`
+
+	// Units, Leaders and Applications hold the targets of the action:
+	// unit names, the names of applications whose leader runs the
+	// action, and the names of applications whose units all run it.
+	Units        []string `bson:"units,omitempty"`
+	Leaders      []string `bson:"leaders,omitempty"`
+	Applications []string `bson:"applications,omitempty"`
+
+	ActionName string                 `bson:"action-name"`
+	Parameters map[string]interface{} `bson:"parameters,omitempty"`
+
+	Created time.Time `bson:"created"`
+
+	// NextRun holds the time the action is next due to be queued.
+	NextRun time.Time `bson:"next-run"`
+
+	// Runs holds the most recent runs of the schedule, oldest first.
+	Runs []actionScheduleRunDoc `bson:"runs,omitempty"`
+}
+
+type actionScheduleRunDoc struct {
+	Time    time.Time `bson:"time"`
+	Actions []string  `bson:"actions,omitempty"`
+	Errors  []string  `bson:"errors,omitempty"`
+}
+
+// ActionSchedule represents an action that is queued periodically on a
+// set of units.
+type ActionSchedule struct {
+	doc actionScheduleDoc
+}
+
+// ActionScheduleRun records a run of an action schedule.
+type ActionScheduleRun struct {
+	// Time is when the schedule was run.
+	Time time.Time
+
+	// ActionIds holds the ids of the actions that were queued.
+	ActionIds []string
+
+	// Errors holds any errors finding the units to run the action on
+	// or queueing the action on them.
+	Errors []string
+}
+
+// Name returns the name of the schedule, unique within the model.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Schedule returns the cron-style schedule the action is run on.
+func (s *ActionSchedule) Schedule() string {
+	return s.doc.Schedule
+}
+
+// Units returns the names of the units the action is run on.
+func (s *ActionSchedule) Units() []string {
+	return s.doc.Units
+}
+
+// Leaders returns the names of the applications whose leader unit
+// the action is run on.
+func (s *ActionSchedule) Leaders() []string {
+	return s.doc.Leaders
+}
+
+// Applications returns the names of the applications on whose units
+// the action is run.
+func (s *ActionSchedule) Applications() []string {
+	return s.doc.Applications
+}
+
+// ActionName returns the name of the action that is run.
+func (s *ActionSchedule) ActionName() string {
+	return s.doc.ActionName
+}
+
+// Parameters returns the parameters the action is run with.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Created returns when the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns when the action is next due to be run. It is the
+// zero time if the schedule will never run again.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// Runs returns the most recent runs of the schedule, oldest first.
+func (s *ActionSchedule) Runs() []ActionScheduleRun {
+	runs := make([]ActionScheduleRun, len(s.doc.Runs))
+	for i, run := range s.doc.Runs {
+		runs[i] = ActionScheduleRun{
+			Time:      run.Time,
+			ActionIds: run.Actions,
+			Errors:    run.Errors,
+		}
+	}
+	return runs
+}
+
+// AddActionScheduleArgs holds the arguments for adding an action
+// schedule.
+type AddActionScheduleArgs struct {
+	// Name is the name of the schedule, unique within the model.
+	Name string
+
+	// Schedule is a cron-style schedule as accepted by
+	// actions.ParseSchedule.
+	Schedule string
+
+	// Units, Leaders and Applications select the units the action is
+	// run on; see ActionSchedule. At least one must be given.
+	Units        []string
+	Leaders      []string
+	Applications []string
+
+	ActionName string
+	Parameters map[string]interface{}
+}
+
+// Validate checks that the arguments are valid.
+func (args AddActionScheduleArgs) Validate() error {
+	if !validActionScheduleName.MatchString(args.Name) {
+		return errors.NotValidf("action schedule name %q", args.Name)
+	}
+	if _, err := actions.ParseSchedule(args.Schedule); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args.Units)+len(args.Leaders)+len(args.Applications) == 0 {
+		return errors.NotValidf("action schedule with no units")
+	}
+	for _, unitName := range args.Units {
+		if !names.IsValidUnit(unitName) {
+			return errors.NotValidf("unit name %q", unitName)
+		}
+	}
+	for _, appName := range append(args.Leaders, args.Applications...) {
+		if !names.IsValidApplication(appName) {
+			return errors.NotValidf("application name %q", appName)
+		}
+	}
+	if args.ActionName == "" {
+		return errors.NotValidf("empty action name")
+	}
+	return nil
+}
+
+// AddActionSchedule adds a schedule that queues an action on the
+// given units. The units and applications must exist when the schedule
+// is added, and the action and its parameters must be valid for their
+// charms; if they are later removed, runs of the schedule record an
+// error for them.
+func (m *Model) AddActionSchedule(args AddActionScheduleArgs) (*ActionSchedule, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, unitName := range args.Units {
+		unit, err := m.st.Unit(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := unit.actionPayload(args.ActionName, args.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	for _, appName := range append(args.Leaders, args.Applications...) {
+		app, err := m.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := validateApplicationAction(app, args.ActionName, args.Parameters); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	schedule, err := actions.ParseSchedule(args.Schedule)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := m.st.clock().Now().UTC().Round(time.Second)
+	doc := actionScheduleDoc{
+		DocId:        m.st.docID(args.Name),
+		ModelUUID:    m.st.ModelUUID(),
+		Name:         args.Name,
+		Schedule:     args.Schedule,
+		Units:        args.Units,
+		Leaders:      args.Leaders,
+		Applications: args.Applications,
+		ActionName:   args.ActionName,
+		Parameters:   args.Parameters,
+		Created:      now,
+		NextRun:      schedule.Next(now),
+	}
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, errors.AlreadyExistsf("action schedule %q", args.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot add action schedule %q", args.Name)
+	}
+	return &ActionSchedule{doc: doc}, nil
+}
+
+// validateApplicationAction checks that the named action is defined
+// for the application's charm, and that the payload is valid for it.
+func validateApplicationAction(app *Application, name string, payload map[string]interface{}) error {
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		ch, _, err := app.Charm()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if chActions := ch.Actions(); chActions != nil {
+			spec, ok = chActions.ActionSpecs[name]
+		}
+		if !ok {
+			return errors.Errorf("action %q not defined on application %q", name, app.Name())
+		}
+	}
+	return spec.ValidateParams(payload)
+}
+
+// ActionSchedule returns the action schedule with the given name.
+func (m *Model) ActionSchedule(name string) (*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", name)
+	}
+	return &ActionSchedule{doc: doc}, nil
+}
+
+// AllActionSchedules returns all of the action schedules in the model,
+// ordered by name.
+func (m *Model) AllActionSchedules() ([]*ActionSchedule, error) {
+	return m.findActionSchedules(nil)
+}
+
+func (m *Model) findActionSchedules(query bson.D) ([]*ActionSchedule, error) {
+	schedules, closer := m.st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	result := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		result[i] = &ActionSchedule{doc: doc}
+	}
+	return result, nil
+}
+
+// RemoveActionSchedule removes the action schedule with the given
+// name. Actions already queued by the schedule are not affected.
+func (m *Model) RemoveActionSchedule(name string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     m.st.docID(name),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", name)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", name)
+	}
+	return nil
+}
+
+// RunDueActionSchedules queues the actions of all schedules that are
+// due to run, recording each run in the schedule's history. If runs
+// were missed, for example while the controller was down, the action
+// is queued once and the schedule continues from the current time.
+func (m *Model) RunDueActionSchedules() error {
+	now := m.st.clock().Now().UTC().Round(time.Second)
+	due, err := m.findActionSchedules(bson.D{
+		{"next-run", bson.D{{"$gt", time.Time{}}, {"$lte", now}}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// A schedule that cannot be run doesn't hold up the others.
+	var failed []string
+	for _, schedule := range due {
+		if err := m.runActionSchedule(schedule, now); err != nil {
+			logger.Errorf("running action schedule %q in model %q: %v", schedule.Name(), m.UUID(), err)
+			failed = append(failed, schedule.Name())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("cannot run action schedules %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (m *Model) runActionSchedule(s *ActionSchedule, now time.Time) error {
+	schedule, err := actions.ParseSchedule(s.doc.Schedule)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Claim the run before queueing any actions, so that the actions
+	// are only queued once even if another run of the schedule was
+	// started concurrently.
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: bson.D{{"next-run", s.doc.NextRun}},
+		Update: bson.D{{"$set", bson.D{{"next-run", schedule.Next(now)}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The schedule was removed or run concurrently.
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	run := actionScheduleRunDoc{Time: now}
+	unitNames, errs := m.actionScheduleUnits(s)
+	run.Errors = errs
+	for _, unitName := range unitNames {
+		action, err := m.addScheduledAction(unitName, s.doc.ActionName, s.doc.Parameters)
+		if err != nil {
+			run.Errors = append(run.Errors, errors.Annotatef(err, "queueing action on %q", unitName).Error())
+			continue
+		}
+		run.Actions = append(run.Actions, action.Id())
+	}
+	if len(run.Errors) > 0 {
+		logger.Warningf("action schedule %q in model %q: %v", s.doc.Name, m.UUID(), run.Errors)
+	}
+
+	ops = []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     s.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$push", bson.D{{"runs", bson.D{
+			{"$each", []actionScheduleRunDoc{run}},
+			{"$slice", -maxActionScheduleRuns},
+		}}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Annotate(err, "cannot record run")
+	}
+	return nil
+}
+
+// addScheduledAction queues the action on the named unit, validated
+// against and with defaults from the unit's current charm.
+func (m *Model) addScheduledAction(unitName, name string, payload map[string]interface{}) (Action, error) {
+	unit, err := m.st.Unit(unitName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return unit.AddAction(name, payload)
+}
+
+// actionScheduleUnits returns the names of the units the schedule's
+// action should be queued on, along with any errors finding them.
+func (m *Model) actionScheduleUnits(s *ActionSchedule) ([]string, []string) {
+	var unitNames, errs []string
+	seen := make(map[string]bool)
+	add := func(unitName string) {
+		if !seen[unitName] {
+			seen[unitName] = true
+			unitNames = append(unitNames, unitName)
+		}
+	}
+	for _, unitName := range s.doc.Units {
+		add(unitName)
+	}
+	if len(s.doc.Leaders) > 0 {
+		leaders, err := m.st.ApplicationLeaders()
+		if err != nil {
+			errs = append(errs, errors.Annotate(err, "getting application leaders").Error())
+		}
+		for _, appName := range s.doc.Leaders {
+			if leader, ok := leaders[appName]; ok {
+				add(leader)
+			} else if err == nil {
+				errs = append(errs, errors.Errorf("application %q has no leader", appName).Error())
+			}
+		}
+	}
+	for _, appName := range s.doc.Applications {
+		app, err := m.st.Application(appName)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			errs = append(errs, errors.Annotatef(err, "getting units of %q", appName).Error())
+			continue
+		}
+		for _, unit := range units {
+			add(unit.Name())
+		}
+	}
+	return unitNames, errs
+}
@@ -0,0 +1,281 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+)
+
+type ActionScheduleSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+	unit2       *state.Unit
+	model       *state.Model
+}
+
+var _ = gc.Suite(&ActionScheduleSuite{})
+
+func (s *ActionScheduleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := s.AddTestingCharm(c, "dummy")
+	s.application = s.AddTestingApplication(c, "dummy", ch)
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.unit2, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionScheduleSuite) addSchedule(c *gc.C, name string, args state.AddActionScheduleArgs) *state.ActionSchedule {
+	args.Name = name
+	if args.Schedule == "" {
+		args.Schedule = "*/5 * * * *"
+	}
+	if args.ActionName == "" {
+		args.ActionName = "snapshot"
+	}
+	schedule, err := s.model.AddActionSchedule(args)
+	c.Assert(err, jc.ErrorIsNil)
+	return schedule
+}
+
+func (s *ActionScheduleSuite) TestAddActionSchedule(c *gc.C) {
+	now := s.Clock.Now().UTC().Round(time.Second)
+	added := s.addSchedule(c, "nightly-snapshot", state.AddActionScheduleArgs{
+		Schedule:     "0 2 * * *",
+		Units:        []string{"dummy/0"},
+		Leaders:      []string{"dummy"},
+		Applications: []string{"dummy"},
+		Parameters:   map[string]interface{}{"outfile": "snapshot.tar"},
+	})
+	c.Check(added.Name(), gc.Equals, "nightly-snapshot")
+
+	schedule, err := s.model.ActionSchedule("nightly-snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Name(), gc.Equals, "nightly-snapshot")
+	c.Check(schedule.Schedule(), gc.Equals, "0 2 * * *")
+	c.Check(schedule.Units(), jc.DeepEquals, []string{"dummy/0"})
+	c.Check(schedule.Leaders(), jc.DeepEquals, []string{"dummy"})
+	c.Check(schedule.Applications(), jc.DeepEquals, []string{"dummy"})
+	c.Check(schedule.ActionName(), gc.Equals, "snapshot")
+	c.Check(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "snapshot.tar"})
+	c.Check(schedule.Created().Equal(now), jc.IsTrue)
+	next := time.Date(now.Year(), now.Month(), now.Day(), 2, 0, 0, 0, time.UTC)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	c.Check(schedule.NextRun().Equal(next), jc.IsTrue)
+	c.Check(schedule.Runs(), gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleInvalid(c *gc.C) {
+	for i, test := range []struct {
+		args state.AddActionScheduleArgs
+		err  string
+	}{{
+		args: state.AddActionScheduleArgs{Name: "Bad_Name", Schedule: "@daily", ActionName: "snapshot", Units: []string{"dummy/0"}},
+		err:  `action schedule name "Bad_Name" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@never", ActionName: "snapshot", Units: []string{"dummy/0"}},
+		err:  `schedule descriptor "@never" not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@daily", ActionName: "snapshot"},
+		err:  `action schedule with no units not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@daily", Units: []string{"dummy/0"}},
+		err:  `empty action name not valid`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@daily", ActionName: "snapshot", Units: []string{"dummy/9"}},
+		err:  `unit "dummy/9" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@daily", ActionName: "snapshot", Leaders: []string{"missing"}},
+		err:  `application "missing" not found`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@daily", ActionName: "missing", Units: []string{"dummy/0"}},
+		err:  `action "missing" not defined on unit "dummy/0"`,
+	}, {
+		args: state.AddActionScheduleArgs{Name: "daily", Schedule: "@daily", ActionName: "missing", Applications: []string{"dummy"}},
+		err:  `action "missing" not defined on application "dummy"`,
+	}, {
+		args: state.AddActionScheduleArgs{
+			Name: "daily", Schedule: "@daily", ActionName: "snapshot", Leaders: []string{"dummy"},
+			Parameters: map[string]interface{}{"outfile": 5},
+		},
+		err: `validation failed: .*`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.model.AddActionSchedule(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ActionScheduleSuite) TestAddActionScheduleAlreadyExists(c *gc.C) {
+	s.addSchedule(c, "snapshot", state.AddActionScheduleArgs{Units: []string{"dummy/0"}})
+	_, err := s.model.AddActionSchedule(state.AddActionScheduleArgs{
+		Name:       "snapshot",
+		Schedule:   "@daily",
+		Units:      []string{"dummy/1"},
+		ActionName: "snapshot",
+	})
+	c.Assert(err, gc.ErrorMatches, `action schedule "snapshot" already exists`)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ActionScheduleSuite) TestAllAndRemoveActionSchedules(c *gc.C) {
+	s.addSchedule(c, "zzz", state.AddActionScheduleArgs{Units: []string{"dummy/0"}})
+	s.addSchedule(c, "aaa", state.AddActionScheduleArgs{Units: []string{"dummy/1"}})
+
+	schedules, err := s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 2)
+	c.Check(schedules[0].Name(), gc.Equals, "aaa")
+	c.Check(schedules[1].Name(), gc.Equals, "zzz")
+
+	err = s.model.RemoveActionSchedule("aaa")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.ActionSchedule("aaa")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	err = s.model.RemoveActionSchedule("aaa")
+	c.Check(err, gc.ErrorMatches, `action schedule "aaa" not found`)
+
+	schedules, err = s.model.AllActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Check(schedules[0].Name(), gc.Equals, "zzz")
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesNotDue(c *gc.C) {
+	s.addSchedule(c, "snapshot", state.AddActionScheduleArgs{
+		Schedule: "@yearly",
+		Units:    []string{"dummy/0"},
+	})
+	err := s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 0)
+	schedule, err := s.model.ActionSchedule("snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Runs(), gc.HasLen, 0)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedules(c *gc.C) {
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("actionschedule_test"))
+	target.Claimed(lease.Key{"application-leadership", s.State.ModelUUID(), "dummy"}, "dummy/1")
+
+	s.addSchedule(c, "snapshot", state.AddActionScheduleArgs{
+		Schedule:   "* * * * *",
+		Units:      []string{"dummy/0"},
+		Leaders:    []string{"dummy"},
+		Parameters: map[string]interface{}{"outfile": "snapshot.tar"},
+	})
+	s.Clock.Advance(5 * time.Minute)
+	err := s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	var actionIds []string
+	for _, unit := range []*state.Unit{s.unit, s.unit2} {
+		actions, err := unit.PendingActions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(actions, gc.HasLen, 1)
+		c.Check(actions[0].Name(), gc.Equals, "snapshot")
+		c.Check(actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "snapshot.tar"})
+		actionIds = append(actionIds, actions[0].Id())
+	}
+
+	schedule, err := s.model.ActionSchedule("snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	runs := schedule.Runs()
+	c.Assert(runs, gc.HasLen, 1)
+	now := s.Clock.Now().UTC().Round(time.Second)
+	c.Check(runs[0].Time.Equal(now), jc.IsTrue)
+	c.Check(runs[0].ActionIds, jc.SameContents, actionIds)
+	c.Check(runs[0].Errors, gc.HasLen, 0)
+	// Missed runs are not caught up.
+	c.Check(schedule.NextRun().Equal(now.Truncate(time.Minute).Add(time.Minute)), jc.IsTrue)
+
+	// Running again before the next run is due does nothing.
+	err = s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	schedule, err = s.model.ActionSchedule("snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Runs(), gc.HasLen, 1)
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesRecordsErrors(c *gc.C) {
+	s.addSchedule(c, "snapshot", state.AddActionScheduleArgs{
+		Schedule:     "* * * * *",
+		Leaders:      []string{"dummy"},
+		Applications: []string{"dummy"},
+	})
+	s.Clock.Advance(time.Minute)
+	err := s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	schedule, err := s.model.ActionSchedule("snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	runs := schedule.Runs()
+	c.Assert(runs, gc.HasLen, 1)
+	c.Check(runs[0].ActionIds, gc.HasLen, 2)
+	c.Check(runs[0].Errors, jc.DeepEquals, []string{`application "dummy" has no leader`})
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesInsertsDefaults(c *gc.C) {
+	s.addSchedule(c, "snapshot", state.AddActionScheduleArgs{
+		Schedule: "* * * * *",
+		Units:    []string{"dummy/0"},
+	})
+	s.Clock.Advance(time.Minute)
+	err := s.model.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(actions, gc.HasLen, 1)
+	c.Check(actions[0].Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "foo.bz2"})
+}
+
+func (s *ActionScheduleSuite) TestRunDueActionSchedulesContinuesAfterError(c *gc.C) {
+	s.addSchedule(c, "snapshot", state.AddActionScheduleArgs{
+		Schedule: "* * * * *",
+		Units:    []string{"dummy/0"},
+	})
+	// A schedule that cannot be run doesn't hold up the others.
+	schedules := s.State.MongoSession().DB("juju").C(state.ActionSchedulesC)
+	err := schedules.Insert(bson.M{
+		"_id":         s.State.ModelUUID() + ":broken",
+		"model-uuid":  s.State.ModelUUID(),
+		"name":        "broken",
+		"schedule":    "@never",
+		"units":       []string{"dummy/1"},
+		"action-name": "snapshot",
+		"next-run":    s.Clock.Now().UTC(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Minute)
+	err = s.model.RunDueActionSchedules()
+	c.Assert(err, gc.ErrorMatches, "cannot run action schedules broken")
+
+	actions, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(actions, gc.HasLen, 1)
+	schedule, err := s.model.ActionSchedule("snapshot")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(schedule.Runs(), gc.HasLen, 1)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"gopkg.in/mgo.v2"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state/bakerystorage"
 )
 
@@ -18,6 +19,14 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used by the database audit log backend holds
+// as much as a single audit log file does by default. It's reduced in
+// tests for the same reason as the txn log.
+var (
+	auditLogSize      = controller.DefaultAuditLogMaxSizeMB * 1024 * 1024
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -154,6 +163,24 @@ func allCollections() collectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the conversations, requests and
+		// response errors recorded by the database audit log
+		// backend. It's capped so the oldest records are discarded
+		// once it is full.
+		auditLogC: {
+			global:    true,
+			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"kind", "-when"},
+			}, {
+				Key: []string{"conversation-id"},
+			}},
+		},
+
 		// This collection is used as a unique key restraint. The _id field is
 		// a concatenation of multiple fields that form a compound index,
 		// allowing us to ensure users cannot have the same name for two
@@ -287,6 +314,10 @@ func allCollections() collectionSchema {
 		// meterStatusC is the collection used to store meter status information.
 		meterStatusC: {},
 
+		// This collection holds the key/value pairs that units' charms
+		// keep on the controller with the state-set hook tool.
+		unitStatesC: {},
+
 		// These collections hold reference counts which are used
 		// by the nsRefcounts struct.
 		refcountsC: {}, // Per model.
@@ -313,8 +344,9 @@ func allCollections() collectionSchema {
 		// -----
 
 		// These collections hold information associated with machines.
-		containerRefsC: {},
-		instanceDataC:  {},
+		charmProfileChangesC: {},
+		containerRefsC:       {},
+		instanceDataC:        {},
 		machinesC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "machineid"},
@@ -397,6 +429,39 @@ func allCollections() collectionSchema {
 		},
 		actionNotificationsC: {},
 
+		// This collection holds the queues that control when actions
+		// queued together are released to their receivers.
+		actionQueuesC: {},
+
+		// This collection holds the schedules that actions are
+		// periodically queued on.
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
+
+		// These collections hold the secrets that applications are
+		// granted access to, and the values of each secret revision.
+		secretsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "owner"},
+			}},
+		},
+		secretRevisionsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "name"},
+			}},
+		},
+
+		// This collection holds the progress of charm upgrades that
+		// are released to an application's units in batches.
+		rollingUpgradesC: {},
+
+		// This collection holds the progress of upgrading the series
+		// of the machines hosting an application, one at a time.
+		applicationUpgradeSeriesC: {},
+
 		// -----
 
 		// This collection holds information associated with charm payloads.
@@ -519,6 +584,8 @@ const (
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
+	actionQueuesC              = "actionqueues"
+	actionSchedulesC           = "actionschedules"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
@@ -526,6 +593,7 @@ const (
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
 	charmsC                    = "charms"
+	charmProfileChangesC       = "charmProfileChanges"
 	cleanupsC                  = "cleanups"
 	cloudimagemetadataC        = "cloudimagemetadata"
 	cloudsC                    = "clouds"
@@ -573,6 +641,7 @@ const (
 	restoreInfoC               = "restoreInfo"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
+	auditLogC                  = "auditlog"
 	endpointBindingsC          = "endpointbindings"
 	settingsC                  = "settings"
 	refcountsC                 = "refcounts"
@@ -591,7 +660,12 @@ const (
 	toolsmetadataC             = "toolsmetadata"
 	txnLogC                    = "txns.log"
 	txnsC                      = "txns"
+	secretsC                   = "secrets"
+	secretRevisionsC           = "secretRevisions"
+	rollingUpgradesC           = "rollingUpgrades"
+	applicationUpgradeSeriesC  = "applicationUpgradeSeries"
 	unitsC                     = "units"
+	unitStatesC                = "unitstates"
 	upgradeInfoC               = "upgradeInfo"
 	userLastLoginC             = "userLastLogin"
 	usermodelnameC             = "usermodelname"
@@ -67,6 +67,8 @@ type applicationDoc struct {
 	PasswordHash string `bson:"passwordhash"`
 	// Placement is the placement directive that should be used allocating units/pods.
 	Placement string `bson:"placement,omitempty"`
+	// Autoscale is the policy, if any, by which the substrate scales the application.
+	Autoscale *autoscalePolicyDoc `bson:"autoscale,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -419,6 +421,9 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 		removeSettingsOp(settingsC, a.applicationConfigKey()),
 		removeModelApplicationRefOp(a.st, name),
 		removePodSpecOp(a.ApplicationTag()),
+		a.removeRollingUpgradeOp(),
+		a.removeUpgradeSeriesOp(),
+		newCleanupOp(cleanupApplicationSecrets, name),
 	)
 	return ops, nil
 }
@@ -1024,6 +1029,10 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// RollingUpgrade, if set, releases the upgrade to the application's
+	// existing units in batches rather than all at once.
+	RollingUpgrade *RollingUpgradeArgs
 }
 
 // SetCharm changes the charm for the application.
@@ -1146,8 +1155,20 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
+
+			profileOps, err := a.upgradeCharmProfileOps(cfg.Charm)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, profileOps...)
 		}
 
+		rollingOps, err := a.rollingUpgradeOps(cfg.Charm.URL(), cfg.RollingUpgrade)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, rollingOps...)
+
 		return ops, nil
 	}
 	if err := a.st.db().Run(buildTxn); err != nil {
@@ -1504,6 +1525,17 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 			}),
 			Update: bson.D{{"$addToSet", bson.D{{"subordinates", name}}}},
 		})
+		pu, err := a.st.Unit(args.principalName)
+		if err != nil {
+			return "", nil, errors.Trace(err)
+		}
+		if machineId := pu.doc.MachineId; machineId != "" && model.Type() != ModelTypeCAAS {
+			profileOps, err := a.machineCharmProfileChangeOps(machineId)
+			if err != nil {
+				return "", nil, errors.Trace(err)
+			}
+			ops = append(ops, profileOps...)
+		}
 	} else {
 		ops = append(ops, createConstraintsOp(agentGlobalKey, args.cons))
 	}
@@ -1740,6 +1772,7 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 		removeStatusOp(a.st, u.globalKey()),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
+		removeCharmStateOp(a.st, u.globalKey()),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name),
 	}
 	ops = append(ops, portsOps...)
@@ -1764,6 +1797,12 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D) ([]txn.Op, error) {
 	}
 	ops = append(ops, storageInstanceOps...)
 
+	profileOps, err := a.unitCharmProfileChangeOps(u)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, profileOps...)
+
 	if u.doc.CharmURL != nil {
 		// If the unit has a different URL to the application, allow any final
 		// cleanup to happen; otherwise we just do it when the app itself is removed.
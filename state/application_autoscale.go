@@ -0,0 +1,116 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/application"
+)
+
+// autoscalePolicyDoc is the persistent form of an
+// application.AutoscalePolicy, held on the application document.
+type autoscalePolicyDoc struct {
+	MinUnits         int    `bson:"min-units"`
+	MaxUnits         int    `bson:"max-units"`
+	TargetCPUPercent int    `bson:"target-cpu-percent,omitempty"`
+	Metric           string `bson:"metric,omitempty"`
+	MetricTarget     string `bson:"metric-target,omitempty"`
+}
+
+// AutoscalePolicy returns the policy by which the substrate scales
+// the application, or nil if the application is scaled explicitly.
+// This is used on CAAS models.
+func (a *Application) AutoscalePolicy() *application.AutoscalePolicy {
+	doc := a.doc.Autoscale
+	if doc == nil {
+		return nil
+	}
+	return &application.AutoscalePolicy{
+		MinUnits:         doc.MinUnits,
+		MaxUnits:         doc.MaxUnits,
+		TargetCPUPercent: doc.TargetCPUPercent,
+		Metric:           doc.Metric,
+		MetricTarget:     doc.MetricTarget,
+	}
+}
+
+// SetAutoscalePolicy hands the scaling of the application over to
+// the substrate, according to the specified policy. The application's
+// scale is brought within the policy's bounds.
+// This is used on CAAS models.
+func (a *Application) SetAutoscalePolicy(policy application.AutoscalePolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set autoscale policy for application %q", a)
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := &autoscalePolicyDoc{
+		MinUnits:         policy.MinUnits,
+		MaxUnits:         policy.MaxUnits,
+		TargetCPUPercent: policy.TargetCPUPercent,
+		Metric:           policy.Metric,
+		MetricTarget:     policy.MetricTarget,
+	}
+	app := &Application{st: a.st, doc: a.doc}
+	var scale int
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, applicationNotAliveErr
+		}
+		scale = policy.Clamp(app.doc.DesiredScale)
+		return []txn.Op{{
+			C:  applicationsC,
+			Id: app.doc.DocID,
+			Assert: bson.D{{"life", Alive},
+				{"scale", app.doc.DesiredScale}},
+			Update: bson.D{{"$set", bson.D{
+				{"autoscale", doc},
+				{"scale", scale},
+			}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Autoscale = doc
+	a.doc.DesiredScale = scale
+	return nil
+}
+
+// RemoveAutoscalePolicy returns the scaling of the application to
+// the operator; the application keeps its current scale.
+// This is used on CAAS models.
+func (a *Application) RemoveAutoscalePolicy() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove autoscale policy for application %q", a)
+	app := &Application{st: a.st, doc: a.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Autoscale == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", bson.D{{"autoscale", nil}}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Autoscale = nil
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/state/testing"
+)
+
+var cpuPolicy = application.AutoscalePolicy{
+	MinUnits:         2,
+	MaxUnits:         5,
+	TargetCPUPercent: 80,
+}
+
+func (s *CAASApplicationSuite) TestAutoscalePolicyNotSet(c *gc.C) {
+	c.Assert(s.app.AutoscalePolicy(), gc.IsNil)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicy(c *gc.C) {
+	err := s.app.Scale(1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAutoscalePolicy(cpuPolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AutoscalePolicy(), jc.DeepEquals, &cpuPolicy)
+	c.Assert(s.app.GetScale(), gc.Equals, 2)
+
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AutoscalePolicy(), jc.DeepEquals, &cpuPolicy)
+	// The scale is brought within the policy's bounds.
+	c.Assert(s.app.GetScale(), gc.Equals, 2)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicyInvalid(c *gc.C) {
+	err := s.app.SetAutoscalePolicy(application.AutoscalePolicy{MinUnits: 1, MaxUnits: 2})
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "gitlab": autoscale policy without exactly one of a cpu or metric target not valid`)
+}
+
+func (s *CAASApplicationSuite) TestSetAutoscalePolicyNotAlive(c *gc.C) {
+	err := s.app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.SetAutoscalePolicy(cpuPolicy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "gitlab": .*`)
+}
+
+func (s *CAASApplicationSuite) TestRemoveAutoscalePolicy(c *gc.C) {
+	err := s.app.SetAutoscalePolicy(cpuPolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.app.Scale(4)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.app.RemoveAutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AutoscalePolicy(), gc.IsNil)
+
+	err = s.app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.app.AutoscalePolicy(), gc.IsNil)
+	c.Assert(s.app.GetScale(), gc.Equals, 4)
+
+	// Removing it again is a no-op.
+	err = s.app.RemoveAutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CAASApplicationSuite) TestWatchScaleAutoscalePolicy(c *gc.C) {
+	err := s.app.Scale(3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Empty initial event.
+	w := s.app.WatchScale()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.app.SetAutoscalePolicy(cpuPolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Set to the same policy, no change.
+	err = s.app.SetAutoscalePolicy(cpuPolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.app.RemoveAutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,684 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/model"
+)
+
+// ApplicationUpgradeSeriesStatus describes the progress of the series
+// upgrade of an application's machines.
+type ApplicationUpgradeSeriesStatus string
+
+const (
+	// ApplicationUpgradeSeriesRunning means that the next machine is
+	// prepared as soon as the previous one completes.
+	ApplicationUpgradeSeriesRunning ApplicationUpgradeSeriesStatus = "running"
+
+	// ApplicationUpgradeSeriesPaused means that no more machines will
+	// be prepared until the upgrade is resumed. A machine that was
+	// already prepared can still be completed.
+	ApplicationUpgradeSeriesPaused ApplicationUpgradeSeriesStatus = "paused"
+
+	// ApplicationUpgradeSeriesCompleted means that all the machines
+	// have been upgraded.
+	ApplicationUpgradeSeriesCompleted ApplicationUpgradeSeriesStatus = "completed"
+
+	// ApplicationUpgradeSeriesRolledBack means that the upgrade was
+	// abandoned. Machines that completed keep their new series.
+	ApplicationUpgradeSeriesRolledBack ApplicationUpgradeSeriesStatus = "rolled back"
+)
+
+// ApplicationUpgradeSeriesMachineStatus describes the progress of the
+// series upgrade of one of an application's machines.
+type ApplicationUpgradeSeriesMachineStatus string
+
+const (
+	// UpgradeSeriesMachinePending means that the machine has not been
+	// prepared yet.
+	UpgradeSeriesMachinePending ApplicationUpgradeSeriesMachineStatus = "pending"
+
+	// UpgradeSeriesMachinePreparing means that the machine is locked
+	// for series upgrade, and its units run the pre-series-upgrade
+	// hook. Once they have, the machine's operating system can be
+	// upgraded.
+	UpgradeSeriesMachinePreparing ApplicationUpgradeSeriesMachineStatus = "preparing"
+
+	// UpgradeSeriesMachineCompleting means that the machine's operating
+	// system has been upgraded, and its units run the
+	// post-series-upgrade hook.
+	UpgradeSeriesMachineCompleting ApplicationUpgradeSeriesMachineStatus = "completing"
+
+	// UpgradeSeriesMachineCompleted means that the machine has been
+	// upgraded.
+	UpgradeSeriesMachineCompleted ApplicationUpgradeSeriesMachineStatus = "completed"
+
+	// UpgradeSeriesMachineRolledBack means that the machine was not
+	// upgraded because the application's upgrade was rolled back.
+	UpgradeSeriesMachineRolledBack ApplicationUpgradeSeriesMachineStatus = "rolled back"
+)
+
+// applicationUpgradeSeriesDoc records the progress of upgrading the
+// series of the machines hosting an application, one machine at a time.
+type applicationUpgradeSeriesDoc struct {
+	DocId       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+	TxnRevno    int64  `bson:"txn-revno"`
+
+	ToSeries string `bson:"to-series"`
+	Force    bool   `bson:"force"`
+
+	Status  string `bson:"status"`
+	Message string `bson:"message,omitempty"`
+
+	// Machines holds the machines to upgrade, in the order they are
+	// upgraded. The machine hosting the application's leader is last.
+	Machines []applicationUpgradeSeriesMachineDoc `bson:"machines"`
+}
+
+type applicationUpgradeSeriesMachineDoc struct {
+	MachineId  string `bson:"machine-id"`
+	FromSeries string `bson:"from-series"`
+	Status     string `bson:"status"`
+}
+
+// ApplicationUpgradeSeries represents the series upgrade of the
+// machines hosting an application. The machines are upgraded one at a
+// time, using the same lock and hooks as upgrading a single machine,
+// with the machine hosting the application's leader upgraded last.
+type ApplicationUpgradeSeries struct {
+	doc applicationUpgradeSeriesDoc
+}
+
+// ApplicationUpgradeSeriesMachine holds the progress of upgrading one of
+// the machines hosting an application.
+type ApplicationUpgradeSeriesMachine struct {
+	MachineId  string
+	FromSeries string
+	Status     ApplicationUpgradeSeriesMachineStatus
+}
+
+// Application returns the name of the application whose machines are
+// being upgraded.
+func (u *ApplicationUpgradeSeries) Application() string {
+	return u.doc.Application
+}
+
+// ToSeries returns the series the machines are being upgraded to.
+func (u *ApplicationUpgradeSeries) ToSeries() string {
+	return u.doc.ToSeries
+}
+
+// Force returns whether the units' charms are upgraded to a series
+// they do not declare support for.
+func (u *ApplicationUpgradeSeries) Force() bool {
+	return u.doc.Force
+}
+
+// Status returns the status of the upgrade.
+func (u *ApplicationUpgradeSeries) Status() ApplicationUpgradeSeriesStatus {
+	return ApplicationUpgradeSeriesStatus(u.doc.Status)
+}
+
+// Message returns why the upgrade was paused, if it was not paused by
+// the user.
+func (u *ApplicationUpgradeSeries) Message() string {
+	return u.doc.Message
+}
+
+// Machines returns the progress of each of the machines being upgraded,
+// in the order they are upgraded.
+func (u *ApplicationUpgradeSeries) Machines() []ApplicationUpgradeSeriesMachine {
+	result := make([]ApplicationUpgradeSeriesMachine, len(u.doc.Machines))
+	for i, m := range u.doc.Machines {
+		result[i] = ApplicationUpgradeSeriesMachine{
+			MachineId:  m.MachineId,
+			FromSeries: m.FromSeries,
+			Status:     ApplicationUpgradeSeriesMachineStatus(m.Status),
+		}
+	}
+	return result
+}
+
+// Current returns the machine that is being prepared or completed, if
+// there is one.
+func (u *ApplicationUpgradeSeries) Current() (ApplicationUpgradeSeriesMachine, bool) {
+	if i := currentUpgradeSeriesMachine(&u.doc); i >= 0 {
+		return u.Machines()[i], true
+	}
+	return ApplicationUpgradeSeriesMachine{}, false
+}
+
+// IsActive returns whether the upgrade is running or paused.
+func (u *ApplicationUpgradeSeries) IsActive() bool {
+	return isActiveUpgradeSeries(&u.doc)
+}
+
+func isActiveUpgradeSeries(doc *applicationUpgradeSeriesDoc) bool {
+	switch ApplicationUpgradeSeriesStatus(doc.Status) {
+	case ApplicationUpgradeSeriesRunning, ApplicationUpgradeSeriesPaused:
+		return true
+	}
+	return false
+}
+
+// HasActiveUpgradeSeries reports whether any application in the model
+// has a series upgrade that is running or paused.
+func (m *Model) HasActiveUpgradeSeries() (bool, error) {
+	upgrades, closer := m.st.db().GetCollection(applicationUpgradeSeriesC)
+	defer closer()
+
+	count, err := upgrades.Find(bson.D{{"status", bson.D{{"$in", []string{
+		string(ApplicationUpgradeSeriesRunning),
+		string(ApplicationUpgradeSeriesPaused),
+	}}}}}).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count application series upgrades")
+	}
+	return count > 0, nil
+}
+
+// currentUpgradeSeriesMachine returns the index of the machine that is
+// being prepared or completed, or -1 if there isn't one.
+func currentUpgradeSeriesMachine(doc *applicationUpgradeSeriesDoc) int {
+	for i, m := range doc.Machines {
+		switch ApplicationUpgradeSeriesMachineStatus(m.Status) {
+		case UpgradeSeriesMachinePreparing, UpgradeSeriesMachineCompleting:
+			return i
+		}
+	}
+	return -1
+}
+
+// UpgradeSeries returns the series upgrade of the application's
+// machines.
+func (a *Application) UpgradeSeries() (*ApplicationUpgradeSeries, error) {
+	doc, err := a.upgradeSeriesDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ApplicationUpgradeSeries{doc: *doc}, nil
+}
+
+func (a *Application) upgradeSeriesDoc() (*applicationUpgradeSeriesDoc, error) {
+	upgrades, closer := a.st.db().GetCollection(applicationUpgradeSeriesC)
+	defer closer()
+
+	var doc applicationUpgradeSeriesDoc
+	err := upgrades.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("series upgrade of application %q", a.doc.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get series upgrade of application %q", a.doc.Name)
+	}
+	return &doc, nil
+}
+
+// StartUpgradeSeries starts upgrading the series of the machines hosting
+// the application's units, and prepares the first of them. Machines
+// already running the series are skipped. A previous upgrade of the
+// application's machines must have completed or been rolled back.
+func (a *Application) StartUpgradeSeries(toSeries string, force bool) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errors.Errorf("application %q is not alive", a.doc.Name)
+		}
+		if !a.IsPrincipal() {
+			return nil, errors.Errorf("application %q is a subordinate; upgrade the series of its principal's machines instead", a.doc.Name)
+		}
+		existing, err := a.upgradeSeriesDoc()
+		if errors.IsNotFound(err) {
+			existing = nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing != nil && isActiveUpgradeSeries(existing) {
+			return nil, errors.AlreadyExistsf("series upgrade of application %q", a.doc.Name)
+		}
+
+		machines, err := a.upgradeSeriesMachines(toSeries)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		doc := &applicationUpgradeSeriesDoc{
+			DocId:       a.st.docID(a.doc.Name),
+			ModelUUID:   a.st.ModelUUID(),
+			Application: a.doc.Name,
+			ToSeries:    toSeries,
+			Force:       force,
+			Status:      string(ApplicationUpgradeSeriesRunning),
+			Machines:    machines,
+		}
+		if err := a.checkUpgradeSeriesMachinesFree(doc); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := advanceUpgradeSeriesOps(a.st, doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing == nil {
+			return append(ops, txn.Op{
+				C:      applicationUpgradeSeriesC,
+				Id:     doc.DocId,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}), nil
+		}
+		doc.TxnRevno = existing.TxnRevno
+		return append(ops, updateUpgradeSeriesOp(doc)), nil
+	}
+	err := a.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot upgrade series of application %q", a.doc.Name)
+}
+
+// upgradeSeriesMachines returns the machines hosting the application's
+// units that are not running the given series, with the machine hosting
+// the leader last.
+func (a *Application) upgradeSeriesMachines(toSeries string) ([]applicationUpgradeSeriesMachineDoc, error) {
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return unitNumber(units[i].Name()) < unitNumber(units[j].Name())
+	})
+	leaders, err := a.st.ApplicationLeaders()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var ids []string
+	var leaderMachineId string
+	seen := set.NewStrings()
+	for _, unit := range units {
+		id, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if unit.Name() == leaders[a.doc.Name] {
+			leaderMachineId = id
+		}
+		if !seen.Contains(id) {
+			seen.Add(id)
+			ids = append(ids, id)
+		}
+	}
+	sort.SliceStable(ids, func(i, j int) bool {
+		return ids[j] == leaderMachineId && ids[i] != leaderMachineId
+	})
+
+	var result []applicationUpgradeSeriesMachineDoc
+	for _, id := range ids {
+		machine, err := a.st.Machine(id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machine.Series() == toSeries {
+			continue
+		}
+		result = append(result, applicationUpgradeSeriesMachineDoc{
+			MachineId:  id,
+			FromSeries: machine.Series(),
+			Status:     string(UpgradeSeriesMachinePending),
+		})
+	}
+	if len(result) == 0 {
+		return nil, errors.Errorf("machines of application %q are already running series %q", a.doc.Name, toSeries)
+	}
+	return result, nil
+}
+
+// unitNumber returns the number of the named unit, so that units can be
+// ordered as they were added.
+func unitNumber(unitName string) int {
+	n, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return n
+}
+
+// checkUpgradeSeriesMachinesFree returns an error if one of the machines
+// in the upgrade is part of another application's upgrade that is still
+// active.
+func (a *Application) checkUpgradeSeriesMachinesFree(doc *applicationUpgradeSeriesDoc) error {
+	upgrades, closer := a.st.db().GetCollection(applicationUpgradeSeriesC)
+	defer closer()
+
+	ids := make([]string, len(doc.Machines))
+	for i, m := range doc.Machines {
+		ids[i] = m.MachineId
+	}
+	var others []applicationUpgradeSeriesDoc
+	err := upgrades.Find(bson.D{
+		{"application", bson.D{{"$ne", a.doc.Name}}},
+		{"status", bson.D{{"$in", []string{
+			string(ApplicationUpgradeSeriesRunning),
+			string(ApplicationUpgradeSeriesPaused),
+		}}}},
+		{"machines.machine-id", bson.D{{"$in", ids}}},
+	}).All(&others)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(others) > 0 {
+		return errors.Errorf("machines are being upgraded with application %q", others[0].Application)
+	}
+	return nil
+}
+
+// CompleteUpgradeSeries starts the completion of the machine that has
+// been prepared, once its operating system has been upgraded, and
+// returns its id. When its units have run the post-series-upgrade hook
+// the next machine is prepared, unless the upgrade is paused.
+func (a *Application) CompleteUpgradeSeries() (string, error) {
+	var machineId string
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := a.activeUpgradeSeriesDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		i := currentUpgradeSeriesMachine(doc)
+		if i < 0 {
+			return nil, errors.Errorf("no machine of application %q is prepared for series upgrade", a.doc.Name)
+		}
+		machineId = doc.Machines[i].MachineId
+		if doc.Machines[i].Status != string(UpgradeSeriesMachinePreparing) {
+			return nil, errors.Errorf("machine %s is already completing its series upgrade", machineId)
+		}
+		machine, err := a.st.Machine(machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ready, err := machine.isReadyForCompletion()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !ready {
+			return nil, errors.Errorf("machine %s has not finished preparing for series upgrade", machineId)
+		}
+		doc.Machines[i].Status = string(UpgradeSeriesMachineCompleting)
+		message := newUpgradeSeriesMessage(machine.Tag().String(), "complete phase started", bson.Now())
+		ops := completeUpgradeSeriesTxnOps(machine.doc.Id, message)
+		return append(ops, updateUpgradeSeriesOp(doc)), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return "", errors.Annotatef(err, "cannot complete series upgrade of application %q", a.doc.Name)
+	}
+	return machineId, nil
+}
+
+// PauseUpgradeSeries stops any more of the application's machines from
+// being prepared for series upgrade. A machine that is already prepared
+// can still be completed.
+func (a *Application) PauseUpgradeSeries() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := a.activeUpgradeSeriesDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Status == string(ApplicationUpgradeSeriesPaused) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		doc.Status = string(ApplicationUpgradeSeriesPaused)
+		doc.Message = ""
+		return []txn.Op{updateUpgradeSeriesOp(doc)}, nil
+	}
+	err := a.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot pause series upgrade of application %q", a.doc.Name)
+}
+
+// ResumeUpgradeSeries resumes the paused series upgrade of the
+// application's machines, preparing the next machine if none is being
+// upgraded.
+func (a *Application) ResumeUpgradeSeries() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := a.activeUpgradeSeriesDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if doc.Status == string(ApplicationUpgradeSeriesRunning) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		doc.Status = string(ApplicationUpgradeSeriesRunning)
+		doc.Message = ""
+		ops, err := advanceUpgradeSeriesOps(a.st, doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, updateUpgradeSeriesOp(doc)), nil
+	}
+	err := a.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot resume series upgrade of application %q", a.doc.Name)
+}
+
+// RollbackUpgradeSeries abandons the series upgrade of the application's
+// machines. A machine that is being prepared is released from its
+// upgrade-series lock, and no more machines are upgraded. Machines that
+// have completed cannot be rolled back, and keep their new series; nor
+// can a machine whose unit agents have been stopped for the upgrade,
+// which must complete first.
+func (a *Application) RollbackUpgradeSeries() error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := a.activeUpgradeSeriesDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		if i := currentUpgradeSeriesMachine(doc); i >= 0 {
+			machineId := doc.Machines[i].MachineId
+			if doc.Machines[i].Status == string(UpgradeSeriesMachineCompleting) {
+				return nil, errors.Errorf(
+					"machine %s is completing its series upgrade; pause the upgrade and roll back once it has completed", machineId)
+			}
+			lockOps, err := rollbackUpgradeSeriesLockOps(a.st, machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, lockOps...)
+		}
+		for i, m := range doc.Machines {
+			switch ApplicationUpgradeSeriesMachineStatus(m.Status) {
+			case UpgradeSeriesMachinePending, UpgradeSeriesMachinePreparing:
+				doc.Machines[i].Status = string(UpgradeSeriesMachineRolledBack)
+			}
+		}
+		doc.Status = string(ApplicationUpgradeSeriesRolledBack)
+		doc.Message = ""
+		return append(ops, updateUpgradeSeriesOp(doc)), nil
+	}
+	err := a.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot roll back series upgrade of application %q", a.doc.Name)
+}
+
+// rollbackUpgradeSeriesStatuses are the statuses of a machine's
+// upgrade-series lock from which the machine can be rolled back. From
+// UpgradeSeriesPrepareMachine on, the machine's unit agents have been
+// stopped, and nothing would restart them if the lock were removed.
+var rollbackUpgradeSeriesStatuses = []model.UpgradeSeriesStatus{
+	model.UpgradeSeriesPrepareStarted,
+	model.UpgradeSeriesPrepareRunning,
+}
+
+// rollbackUpgradeSeriesLockOps returns the operations to remove a
+// machine's upgrade-series lock, provided its unit agents have not been
+// stopped for the upgrade.
+func rollbackUpgradeSeriesLockOps(st *State, machineId string) ([]txn.Op, error) {
+	machine, err := st.Machine(machineId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	status, err := machine.UpgradeSeriesStatus()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canRollbackUpgradeSeries(status) {
+		return nil, errors.Errorf(
+			"machine %s has stopped its unit agents for its series upgrade (%s); complete its upgrade, then roll back",
+			machineId, status)
+	}
+	return []txn.Op{{
+		C:  machineUpgradeSeriesLocksC,
+		Id: machineId,
+		Assert: bson.D{{"machine-status", bson.D{
+			{"$in", rollbackUpgradeSeriesStatuses},
+		}}},
+		Remove: true,
+	}}, nil
+}
+
+func canRollbackUpgradeSeries(status model.UpgradeSeriesStatus) bool {
+	for _, s := range rollbackUpgradeSeriesStatuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Application) activeUpgradeSeriesDoc() (*applicationUpgradeSeriesDoc, error) {
+	doc, err := a.upgradeSeriesDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isActiveUpgradeSeries(doc) {
+		return nil, errors.Errorf("series upgrade of application %q is %s", a.doc.Name, doc.Status)
+	}
+	return doc, nil
+}
+
+// removeUpgradeSeriesOp returns the operation to remove the series
+// upgrade of the application's machines, if it has one.
+func (a *Application) removeUpgradeSeriesOp() txn.Op {
+	return txn.Op{
+		C:      applicationUpgradeSeriesC,
+		Id:     a.st.docID(a.doc.Name),
+		Remove: true,
+	}
+}
+
+// updateUpgradeSeriesOp returns the operation to record the progress in
+// the given doc, asserting that the upgrade has not changed since it was
+// read.
+func updateUpgradeSeriesOp(doc *applicationUpgradeSeriesDoc) txn.Op {
+	return txn.Op{
+		C:      applicationUpgradeSeriesC,
+		Id:     doc.DocId,
+		Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+		Update: bson.D{{"$set", bson.D{
+			{"to-series", doc.ToSeries},
+			{"force", doc.Force},
+			{"status", doc.Status},
+			{"message", doc.Message},
+			{"machines", doc.Machines},
+		}}},
+	}
+}
+
+// advanceUpgradeSeriesOps updates the running upgrade in doc when none
+// of its machines is being upgraded, preparing the next pending machine
+// or marking the upgrade completed when there is none. It returns the
+// operations to lock the prepared machine for series upgrade.
+func advanceUpgradeSeriesOps(st *State, doc *applicationUpgradeSeriesDoc) ([]txn.Op, error) {
+	if doc.Status != string(ApplicationUpgradeSeriesRunning) || currentUpgradeSeriesMachine(doc) >= 0 {
+		return nil, nil
+	}
+	for i, m := range doc.Machines {
+		if m.Status != string(UpgradeSeriesMachinePending) {
+			continue
+		}
+		machine, err := st.Machine(m.MachineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops, err := machine.prepareUpgradeSeriesOps(doc.ToSeries, doc.Force)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot prepare machine %s", m.MachineId)
+		}
+		doc.Machines[i].Status = string(UpgradeSeriesMachinePreparing)
+		return ops, nil
+	}
+	doc.Status = string(ApplicationUpgradeSeriesCompleted)
+	logger.Infof("series upgrade of application %q to %q completed", doc.Application, doc.ToSeries)
+	return nil, nil
+}
+
+// prepareUpgradeSeriesOps returns the operations to lock the machine for
+// series upgrade, so that its units run the pre-series-upgrade hook.
+func (m *Machine) prepareUpgradeSeriesOps(toSeries string, force bool) ([]txn.Op, error) {
+	if err := m.isStillAlive(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	locked, err := m.IsLocked()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if locked {
+		return nil, errors.AlreadyExistsf("upgrade series lock for machine %q", m)
+	}
+	units, err := m.VerifyUnitsSeries(m.Principals(), toSeries, force)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, len(units))
+	for i, unit := range units {
+		unitNames[i] = unit.Name()
+	}
+	return createUpgradeSeriesLockTxnOps(m.doc.Id, m.prepareUpgradeSeriesLock(unitNames, toSeries)), nil
+}
+
+// completedUpgradeSeriesOps returns the operations to record that the
+// machine has completed its part of any application series upgrades,
+// and to prepare the next machine of those that are running. If the
+// next machine cannot be prepared the upgrade is paused, with the reason
+// recorded in its message.
+func (m *Machine) completedUpgradeSeriesOps() ([]txn.Op, error) {
+	upgrades, closer := m.st.db().GetCollection(applicationUpgradeSeriesC)
+	defer closer()
+
+	var docs []applicationUpgradeSeriesDoc
+	err := upgrades.Find(bson.D{{"machines", bson.D{{"$elemMatch", bson.D{
+		{"machine-id", m.doc.Id},
+		{"status", string(UpgradeSeriesMachineCompleting)},
+	}}}}}).All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		for i := range doc.Machines {
+			if doc.Machines[i].MachineId == m.doc.Id {
+				doc.Machines[i].Status = string(UpgradeSeriesMachineCompleted)
+			}
+		}
+		advanceOps, err := advanceUpgradeSeriesOps(m.st, &doc)
+		if err != nil {
+			logger.Warningf("pausing series upgrade of application %q: %v", doc.Application, err)
+			doc.Status = string(ApplicationUpgradeSeriesPaused)
+			doc.Message = fmt.Sprint(err)
+		}
+		ops = append(ops, advanceOps...)
+		ops = append(ops, updateUpgradeSeriesOp(&doc))
+	}
+	return ops, nil
+}
@@ -0,0 +1,316 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/state"
+)
+
+type ApplicationUpgradeSeriesSuite struct {
+	ConnSuite
+	application *state.Application
+	machines    []*state.Machine
+}
+
+var _ = gc.Suite(&ApplicationUpgradeSeriesSuite{})
+
+func (s *ApplicationUpgradeSeriesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	ch := state.AddTestingCharmMultiSeries(c, s.State, "multi-series")
+	s.application = state.AddTestingApplicationForSeries(c, s.State, "precise", "multi-series", ch)
+	s.machines = nil
+	for i := 0; i < 3; i++ {
+		machine, err := s.State.AddMachine("precise", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+		s.machines = append(s.machines, machine)
+	}
+
+	// The leader's machine is upgraded last.
+	target := s.State.LeaseNotifyTarget(ioutil.Discard, loggo.GetLogger("application_upgradeseries_test"))
+	target.Claimed(
+		lease.Key{"application-leadership", s.State.ModelUUID(), "multi-series"},
+		"multi-series/0",
+	)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) upgradeSeries(c *gc.C) *state.ApplicationUpgradeSeries {
+	upgrade, err := s.application.UpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	return upgrade
+}
+
+func (s *ApplicationUpgradeSeriesSuite) assertMachineStatuses(c *gc.C, expected ...state.ApplicationUpgradeSeriesMachineStatus) {
+	machines := s.upgradeSeries(c).Machines()
+	c.Assert(machines, gc.HasLen, len(expected))
+	for i, m := range machines {
+		c.Check(m.Status, gc.Equals, expected[i], gc.Commentf("machine %s", m.MachineId))
+	}
+}
+
+func (s *ApplicationUpgradeSeriesSuite) assertLocked(c *gc.C, machine *state.Machine, expected bool) {
+	locked, err := machine.IsLocked()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(locked, gc.Equals, expected)
+}
+
+// upgradeMachine takes the prepared machine through the rest of its
+// series upgrade, as its agents and the user would.
+func (s *ApplicationUpgradeSeriesSuite) upgradeMachine(c *gc.C, machine *state.Machine) {
+	err := machine.SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted, "prepared")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := s.application.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, machine.Id())
+	err = machine.SetUpgradeSeriesStatus(model.UpgradeSeriesCompleted, "completed")
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestStartUpgradeSeries(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade := s.upgradeSeries(c)
+	c.Check(upgrade.Application(), gc.Equals, "multi-series")
+	c.Check(upgrade.ToSeries(), gc.Equals, "xenial")
+	c.Check(upgrade.Force(), jc.IsFalse)
+	c.Check(upgrade.Status(), gc.Equals, state.ApplicationUpgradeSeriesRunning)
+	c.Check(upgrade.Machines(), jc.DeepEquals, []state.ApplicationUpgradeSeriesMachine{
+		{MachineId: s.machines[1].Id(), FromSeries: "precise", Status: state.UpgradeSeriesMachinePreparing},
+		{MachineId: s.machines[2].Id(), FromSeries: "precise", Status: state.UpgradeSeriesMachinePending},
+		{MachineId: s.machines[0].Id(), FromSeries: "precise", Status: state.UpgradeSeriesMachinePending},
+	})
+	current, ok := upgrade.Current()
+	c.Check(ok, jc.IsTrue)
+	c.Check(current.MachineId, gc.Equals, s.machines[1].Id())
+
+	s.assertLocked(c, s.machines[0], false)
+	s.assertLocked(c, s.machines[1], true)
+	target, err := s.machines[1].UpgradeSeriesTarget()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(target, gc.Equals, "xenial")
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestStartUpgradeSeriesAlreadyStarted(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.StartUpgradeSeries("trusty", false)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestStartUpgradeSeriesUnsupportedSeries(c *gc.C) {
+	err := s.application.StartUpgradeSeries("bionic", false)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade series of application "multi-series": cannot prepare machine 1: .*`)
+	_, err = s.application.UpgradeSeries()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	s.assertLocked(c, s.machines[1], false)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestCompleteUpgradeSeriesNotPrepared(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.CompleteUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `.*machine 1 has not finished preparing for series upgrade`)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestUpgradeSeriesMachineByMachine(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machines[1].SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted, "prepared")
+	c.Assert(err, jc.ErrorIsNil)
+	machineId, err := s.application.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineId, gc.Equals, s.machines[1].Id())
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleting,
+		state.UpgradeSeriesMachinePending,
+		state.UpgradeSeriesMachinePending,
+	)
+	lockStatus, err := s.machines[1].UpgradeSeriesStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lockStatus, gc.Equals, model.UpgradeSeriesCompleteStarted)
+
+	// The next machine is prepared once the machine's agent finishes
+	// its series upgrade.
+	err = s.machines[1].SetUpgradeSeriesStatus(model.UpgradeSeriesCompleted, "completed")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machines[1].RemoveUpgradeSeriesLock()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachinePreparing,
+		state.UpgradeSeriesMachinePending,
+	)
+	s.assertLocked(c, s.machines[2], true)
+
+	s.upgradeMachine(c, s.machines[2])
+	s.upgradeMachine(c, s.machines[0])
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachineCompleted,
+	)
+	upgrade := s.upgradeSeries(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.ApplicationUpgradeSeriesCompleted)
+	c.Assert(upgrade.IsActive(), jc.IsFalse)
+	_, ok := upgrade.Current()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestPauseResumeUpgradeSeries(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.PauseUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The prepared machine can be completed, but the next machine is
+	// not prepared while the upgrade is paused.
+	s.upgradeMachine(c, s.machines[1])
+	c.Assert(s.upgradeSeries(c).Status(), gc.Equals, state.ApplicationUpgradeSeriesPaused)
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachinePending,
+		state.UpgradeSeriesMachinePending,
+	)
+	s.assertLocked(c, s.machines[2], false)
+
+	err = s.application.ResumeUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.upgradeSeries(c).Status(), gc.Equals, state.ApplicationUpgradeSeriesRunning)
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachinePreparing,
+		state.UpgradeSeriesMachinePending,
+	)
+	s.assertLocked(c, s.machines[2], true)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestPausesWhenNextMachineCannotBePrepared(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machines[2].CreateUpgradeSeriesLock([]string{"multi-series/2"}, "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.upgradeMachine(c, s.machines[1])
+	upgrade := s.upgradeSeries(c)
+	c.Assert(upgrade.Status(), gc.Equals, state.ApplicationUpgradeSeriesPaused)
+	c.Assert(upgrade.Message(), gc.Matches, "cannot prepare machine 2: .* already exists")
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachinePending,
+		state.UpgradeSeriesMachinePending,
+	)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestRollbackUpgradeSeries(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	s.upgradeMachine(c, s.machines[1])
+
+	err = s.application.RollbackUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.upgradeSeries(c).Status(), gc.Equals, state.ApplicationUpgradeSeriesRolledBack)
+	s.assertMachineStatuses(c,
+		state.UpgradeSeriesMachineCompleted,
+		state.UpgradeSeriesMachineRolledBack,
+		state.UpgradeSeriesMachineRolledBack,
+	)
+	s.assertLocked(c, s.machines[2], false)
+
+	err = s.application.RollbackUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `.*series upgrade of application "multi-series" is rolled back`)
+
+	// A new upgrade can be started once the previous one was rolled
+	// back.
+	err = s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.upgradeSeries(c).Status(), gc.Equals, state.ApplicationUpgradeSeriesRunning)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestHasActiveUpgradeSeries(c *gc.C) {
+	active, err := s.Model.HasActiveUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, jc.IsFalse)
+
+	err = s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	active, err = s.Model.HasActiveUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, jc.IsTrue)
+
+	err = s.application.PauseUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	active, err = s.Model.HasActiveUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, jc.IsTrue)
+
+	err = s.application.RollbackUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	active, err = s.Model.HasActiveUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(active, jc.IsFalse)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestRollbackUpgradeSeriesCompleting(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machines[1].SetUpgradeSeriesStatus(model.UpgradeSeriesPrepareCompleted, "prepared")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.CompleteUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.RollbackUpgradeSeries()
+	c.Assert(err, gc.ErrorMatches, `.*machine 1 is completing its series upgrade; .*`)
+	s.assertLocked(c, s.machines[1], true)
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestRollbackUpgradeSeriesUnitsStopped(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, status := range []model.UpgradeSeriesStatus{
+		model.UpgradeSeriesPrepareMachine,
+		model.UpgradeSeriesPrepareCompleted,
+	} {
+		err = s.machines[1].SetUpgradeSeriesStatus(status, "")
+		c.Assert(err, jc.ErrorIsNil)
+
+		err = s.application.RollbackUpgradeSeries()
+		c.Check(err, gc.ErrorMatches, `.*machine 1 has stopped its unit agents for its series upgrade \(.*\); complete its upgrade, then roll back`)
+		s.assertLocked(c, s.machines[1], true)
+		c.Check(s.upgradeSeries(c).Status(), gc.Equals, state.ApplicationUpgradeSeriesRunning)
+	}
+}
+
+func (s *ApplicationUpgradeSeriesSuite) TestRemoveApplicationRemovesUpgradeSeries(c *gc.C) {
+	err := s.application.StartUpgradeSeries("xenial", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.RollbackUpgradeSeries()
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := s.application.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, unit := range units {
+		err = unit.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.UpgradeSeries()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,306 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/mongo"
+)
+
+const (
+	auditKindConversation = "conversation"
+	auditKindRequest      = "request"
+	auditKindErrors       = "errors"
+)
+
+// auditLogDoc is a single record written by the database audit log
+// backend. The kind field says which of auditlog.Conversation,
+// auditlog.Request and auditlog.ResponseErrors it holds; only the
+// fields relevant to that kind are set.
+type auditLogDoc struct {
+	Id             bson.ObjectId `bson:"_id"`
+	Kind           string        `bson:"kind"`
+	ConversationID string        `bson:"conversation-id"`
+	ConnectionID   string        `bson:"connection-id"`
+	When           time.Time     `bson:"when"`
+
+	// Conversation fields.
+	Who       string `bson:"who,omitempty"`
+	What      string `bson:"what,omitempty"`
+	ModelName string `bson:"model-name,omitempty"`
+	ModelUUID string `bson:"model-uuid,omitempty"`
+
+	// Request and errors fields.
+	RequestID int64 `bson:"request-id,omitempty"`
+
+	// Request fields.
+	Facade  string `bson:"facade,omitempty"`
+	Method  string `bson:"method,omitempty"`
+	Version int    `bson:"version,omitempty"`
+	Args    string `bson:"args,omitempty"`
+
+	// Errors fields.
+	Errors []auditLogErrorDoc `bson:"errors,omitempty"`
+}
+
+type auditLogErrorDoc struct {
+	Message string `bson:"message"`
+	Code    string `bson:"code,omitempty"`
+}
+
+// AuditLogger is an auditlog.AuditLog that writes records to the
+// controller database, where they can be queried with QueryAuditLog.
+type AuditLogger struct {
+	session *mgo.Session
+}
+
+// NewAuditLogger returns an audit log that writes to the controller's
+// audit log collection. It should be closed when no longer needed.
+func NewAuditLogger(st MongoSessioner) *AuditLogger {
+	return &AuditLogger{
+		session: st.MongoSession().Copy(),
+	}
+}
+
+// AddConversation is part of auditlog.AuditLog.
+func (l *AuditLogger) AddConversation(c auditlog.Conversation) error {
+	when, err := parseAuditTime(c.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(l.insert(auditLogDoc{
+		Kind:           auditKindConversation,
+		ConversationID: c.ConversationID,
+		ConnectionID:   c.ConnectionID,
+		When:           when,
+		Who:            c.Who,
+		What:           c.What,
+		ModelName:      c.ModelName,
+		ModelUUID:      c.ModelUUID,
+	}))
+}
+
+// AddRequest is part of auditlog.AuditLog.
+func (l *AuditLogger) AddRequest(r auditlog.Request) error {
+	when, err := parseAuditTime(r.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(l.insert(auditLogDoc{
+		Kind:           auditKindRequest,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		When:           when,
+		RequestID:      int64(r.RequestID),
+		Facade:         r.Facade,
+		Method:         r.Method,
+		Version:        r.Version,
+		Args:           r.Args,
+	}))
+}
+
+// AddResponse is part of auditlog.AuditLog.
+func (l *AuditLogger) AddResponse(r auditlog.ResponseErrors) error {
+	when, err := parseAuditTime(r.When)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	errorDocs := make([]auditLogErrorDoc, 0, len(r.Errors))
+	for _, e := range r.Errors {
+		if e == nil {
+			continue
+		}
+		errorDocs = append(errorDocs, auditLogErrorDoc{
+			Message: e.Message,
+			Code:    e.Code,
+		})
+	}
+	return errors.Trace(l.insert(auditLogDoc{
+		Kind:           auditKindErrors,
+		ConversationID: r.ConversationID,
+		ConnectionID:   r.ConnectionID,
+		When:           when,
+		RequestID:      int64(r.RequestID),
+		Errors:         errorDocs,
+	}))
+}
+
+// Close is part of auditlog.AuditLog.
+func (l *AuditLogger) Close() error {
+	l.session.Close()
+	return nil
+}
+
+func (l *AuditLogger) insert(doc auditLogDoc) error {
+	doc.Id = bson.NewObjectId()
+	return l.session.DB(jujuDB).C(auditLogC).Insert(doc)
+}
+
+func parseAuditTime(value string) (time.Time, error) {
+	when, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Annotatef(err, "parsing audit record time %q", value)
+	}
+	return when.UTC(), nil
+}
+
+// QueryAuditLog returns the audit log entries recorded by the
+// database backend that match the query, most recent first.
+func (st *State) QueryAuditLog(query auditlog.Query) ([]auditlog.Entry, error) {
+	if err := query.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	coll, closer := st.db().GetCollection(auditLogC)
+	defer closer()
+
+	requestFilter := bson.D{{"kind", auditKindRequest}}
+	if query.Facade != "" {
+		requestFilter = append(requestFilter, bson.DocElem{"facade", query.Facade})
+	}
+	if query.Method != "" {
+		requestFilter = append(requestFilter, bson.DocElem{"method", query.Method})
+	}
+	if timeFilter := auditTimeFilter(query.From, query.To); timeFilter != nil {
+		requestFilter = append(requestFilter, bson.DocElem{"when", timeFilter})
+	}
+	if query.User != "" || query.Model != "" {
+		// The user and model are only recorded against the
+		// conversation, so find the matching conversations first.
+		ids, err := matchingConversationIDs(coll, query)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+		requestFilter = append(requestFilter, bson.DocElem{"conversation-id", bson.D{{"$in", ids}}})
+	}
+
+	q := coll.Find(requestFilter).Sort("-when", "-_id")
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+	var requestDocs []auditLogDoc
+	if err := q.All(&requestDocs); err != nil {
+		return nil, errors.Annotate(err, "querying audit log requests")
+	}
+	if len(requestDocs) == 0 {
+		return nil, nil
+	}
+
+	conversationIDs := set.NewStrings()
+	for _, doc := range requestDocs {
+		conversationIDs.Add(doc.ConversationID)
+	}
+	var relatedDocs []auditLogDoc
+	err := coll.Find(bson.D{
+		{"kind", bson.D{{"$in", []string{auditKindConversation, auditKindErrors}}}},
+		{"conversation-id", bson.D{{"$in", conversationIDs.SortedValues()}}},
+	}).All(&relatedDocs)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying audit log conversations")
+	}
+	conversations := make(map[string]auditLogDoc)
+	responses := make(map[auditRequestKey]auditLogDoc)
+	for _, doc := range relatedDocs {
+		switch doc.Kind {
+		case auditKindConversation:
+			conversations[doc.ConversationID] = doc
+		case auditKindErrors:
+			responses[auditRequestKey{doc.ConversationID, doc.RequestID}] = doc
+		}
+	}
+
+	entries := make([]auditlog.Entry, len(requestDocs))
+	for i, doc := range requestDocs {
+		entries[i] = auditlog.Entry{
+			Request: auditlog.Request{
+				ConversationID: doc.ConversationID,
+				ConnectionID:   doc.ConnectionID,
+				RequestID:      uint64(doc.RequestID),
+				When:           doc.When.UTC().Format(time.RFC3339),
+				Facade:         doc.Facade,
+				Method:         doc.Method,
+				Version:        doc.Version,
+				Args:           doc.Args,
+			},
+		}
+		// The conversation may have been discarded from the capped
+		// collection already, leaving only its ID.
+		entries[i].Conversation = auditlog.Conversation{
+			ConversationID: doc.ConversationID,
+			ConnectionID:   doc.ConnectionID,
+		}
+		if conv, ok := conversations[doc.ConversationID]; ok {
+			entries[i].Conversation = auditlog.Conversation{
+				Who:            conv.Who,
+				What:           conv.What,
+				When:           conv.When.UTC().Format(time.RFC3339),
+				ModelName:      conv.ModelName,
+				ModelUUID:      conv.ModelUUID,
+				ConversationID: conv.ConversationID,
+				ConnectionID:   conv.ConnectionID,
+			}
+		}
+		if resp, ok := responses[auditRequestKey{doc.ConversationID, doc.RequestID}]; ok {
+			for _, e := range resp.Errors {
+				entries[i].Errors = append(entries[i].Errors, &auditlog.Error{
+					Message: e.Message,
+					Code:    e.Code,
+				})
+			}
+		}
+	}
+	return entries, nil
+}
+
+type auditRequestKey struct {
+	conversationID string
+	requestID      int64
+}
+
+// matchingConversationIDs returns the IDs of the conversations started
+// by the query's user against the query's model. Conversations started
+// after the end of the query's time range can't contain matching
+// requests, so they're skipped.
+func matchingConversationIDs(coll mongo.Collection, query auditlog.Query) ([]string, error) {
+	filter := bson.D{{"kind", auditKindConversation}}
+	if query.User != "" {
+		filter = append(filter, bson.DocElem{"who", query.User})
+	}
+	if query.Model != "" {
+		filter = append(filter, bson.DocElem{"$or", []bson.D{
+			{{"model-name", query.Model}},
+			{{"model-uuid", query.Model}},
+		}})
+	}
+	if !query.To.IsZero() {
+		filter = append(filter, bson.DocElem{"when", bson.D{{"$lte", query.To.UTC()}}})
+	}
+	var ids []string
+	if err := coll.Find(filter).Distinct("conversation-id", &ids); err != nil {
+		return nil, errors.Annotate(err, "querying audit log conversations")
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+func auditTimeFilter(from, to time.Time) bson.D {
+	var filter bson.D
+	if !from.IsZero() {
+		filter = append(filter, bson.DocElem{"$gte", from.UTC()})
+	}
+	if !to.IsZero() {
+		filter = append(filter, bson.DocElem{"$lte", to.UTC()})
+	}
+	return filter
+}
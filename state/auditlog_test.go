@@ -0,0 +1,215 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
+)
+
+type AuditLogSuite struct {
+	ConnSuite
+	logger *state.AuditLogger
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.logger = state.NewAuditLogger(s.State)
+
+	s.addConversation(c, auditlog.Conversation{
+		Who:            "bob",
+		What:           "juju remove-application mysql",
+		When:           "2018-05-01T10:00:00Z",
+		ModelName:      "admin/default",
+		ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+	})
+	s.addRequest(c, auditlog.Request{
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		RequestID:      1,
+		When:           "2018-05-01T10:00:01Z",
+		Facade:         "Application",
+		Method:         "DestroyApplication",
+		Version:        8,
+		Args:           `{"applications":[{"application-tag":"application-mysql"}]}`,
+	})
+	err := s.logger.AddResponse(auditlog.ResponseErrors{
+		ConversationID: "aaaa",
+		ConnectionID:   "A1",
+		RequestID:      1,
+		When:           "2018-05-01T10:00:02Z",
+		Errors: []*auditlog.Error{
+			{Message: "application is blocked", Code: "operation is blocked"},
+			nil,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.addConversation(c, auditlog.Conversation{
+		Who:            "mary",
+		What:           "juju deploy wordpress",
+		When:           "2018-05-02T10:00:00Z",
+		ModelName:      "mary/blog",
+		ModelUUID:      "cafef00d-0bad-400d-8000-4b1d0d06f00d",
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+	})
+	s.addRequest(c, auditlog.Request{
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+		RequestID:      1,
+		When:           "2018-05-02T10:00:01Z",
+		Facade:         "Application",
+		Method:         "Deploy",
+		Version:        8,
+	})
+	s.addRequest(c, auditlog.Request{
+		ConversationID: "bbbb",
+		ConnectionID:   "B2",
+		RequestID:      2,
+		When:           "2018-05-02T10:00:05Z",
+		Facade:         "Application",
+		Method:         "Expose",
+		Version:        8,
+	})
+}
+
+func (s *AuditLogSuite) TearDownTest(c *gc.C) {
+	if s.logger != nil {
+		s.logger.Close()
+	}
+	s.ConnSuite.TearDownTest(c)
+}
+
+func (s *AuditLogSuite) addConversation(c *gc.C, conv auditlog.Conversation) {
+	err := s.logger.AddConversation(conv)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) addRequest(c *gc.C, req auditlog.Request) {
+	err := s.logger.AddRequest(req)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AuditLogSuite) query(c *gc.C, q auditlog.Query) []auditlog.Entry {
+	entries, err := s.State.QueryAuditLog(q)
+	c.Assert(err, jc.ErrorIsNil)
+	return entries
+}
+
+func requestSummaries(entries []auditlog.Entry) []string {
+	result := make([]string, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Conversation.Who + " " + entry.Request.Facade + "." + entry.Request.Method
+	}
+	return result
+}
+
+func (s *AuditLogSuite) TestQueryAll(c *gc.C) {
+	entries := s.query(c, auditlog.Query{})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"mary Application.Expose",
+		"mary Application.Deploy",
+		"bob Application.DestroyApplication",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryEntryDetails(c *gc.C) {
+	entries := s.query(c, auditlog.Query{Method: "DestroyApplication"})
+	c.Assert(entries, jc.DeepEquals, []auditlog.Entry{{
+		Conversation: auditlog.Conversation{
+			Who:            "bob",
+			What:           "juju remove-application mysql",
+			When:           "2018-05-01T10:00:00Z",
+			ModelName:      "admin/default",
+			ModelUUID:      "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+		},
+		Request: auditlog.Request{
+			ConversationID: "aaaa",
+			ConnectionID:   "A1",
+			RequestID:      1,
+			When:           "2018-05-01T10:00:01Z",
+			Facade:         "Application",
+			Method:         "DestroyApplication",
+			Version:        8,
+			Args:           `{"applications":[{"application-tag":"application-mysql"}]}`,
+		},
+		Errors: []*auditlog.Error{
+			{Message: "application is blocked", Code: "operation is blocked"},
+		},
+	}})
+}
+
+func (s *AuditLogSuite) TestQueryByUser(c *gc.C) {
+	entries := s.query(c, auditlog.Query{User: "bob"})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"bob Application.DestroyApplication",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryByModelName(c *gc.C) {
+	entries := s.query(c, auditlog.Query{Model: "mary/blog"})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"mary Application.Expose",
+		"mary Application.Deploy",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryByModelUUID(c *gc.C) {
+	entries := s.query(c, auditlog.Query{Model: "deadbeef-0bad-400d-8000-4b1d0d06f00d"})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"bob Application.DestroyApplication",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryNoMatchingConversations(c *gc.C) {
+	entries := s.query(c, auditlog.Query{User: "nobody"})
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *AuditLogSuite) TestQueryByFacadeAndMethod(c *gc.C) {
+	entries := s.query(c, auditlog.Query{Facade: "Application", Method: "Deploy"})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"mary Application.Deploy",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryByTimeRange(c *gc.C) {
+	entries := s.query(c, auditlog.Query{
+		From: time.Date(2018, 5, 2, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2018, 5, 2, 10, 0, 2, 0, time.UTC),
+	})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"mary Application.Deploy",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryLimit(c *gc.C) {
+	entries := s.query(c, auditlog.Query{Limit: 2})
+	c.Assert(requestSummaries(entries), jc.DeepEquals, []string{
+		"mary Application.Expose",
+		"mary Application.Deploy",
+	})
+}
+
+func (s *AuditLogSuite) TestQueryInvalid(c *gc.C) {
+	_, err := s.State.QueryAuditLog(auditlog.Query{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit -1 not valid")
+}
+
+func (s *AuditLogSuite) TestAddInvalidTime(c *gc.C) {
+	err := s.logger.AddRequest(auditlog.Request{When: "yesterday"})
+	c.Assert(err, gc.ErrorMatches, `parsing audit record time "yesterday": .*`)
+}
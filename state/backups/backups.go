@@ -65,7 +65,8 @@ var (
 	finishMeta       = func(meta *Metadata, result *createResult) error {
 		return meta.MarkComplete(result.size, result.checksum)
 	}
-	storeArchive = StoreArchive
+	storeArchive          = StoreArchive
+	storeEncryptedArchive = StoreEncryptedArchive
 )
 
 // StoreArchive sends the backup archive and its metadata to storage.
@@ -109,17 +110,37 @@ type Backups interface {
 }
 
 type backups struct {
-	storage filestorage.FileStorage
+	storage   filestorage.FileStorage
+	keySource EncryptionKeySource
 }
 
 // NewBackups creates a new Backups value using the FileStorage provided.
 func NewBackups(stor filestorage.FileStorage) Backups {
+	return NewEncryptedBackups(stor, nil)
+}
+
+// NewEncryptedBackups creates a new Backups value using the FileStorage
+// provided. Archives created by the Backups value are encrypted before
+// they are stored if the key source returns a key, and stored archives
+// that were encrypted are decrypted with it when they are retrieved.
+func NewEncryptedBackups(stor filestorage.FileStorage, keySource EncryptionKeySource) Backups {
 	b := backups{
-		storage: stor,
+		storage:   stor,
+		keySource: keySource,
 	}
 	return &b
 }
 
+// encryptionKey returns the key with which archives are encrypted, or
+// nil if there isn't one.
+func (b *backups) encryptionKey() ([]byte, error) {
+	if b.keySource == nil {
+		return nil, nil
+	}
+	key, err := b.keySource()
+	return key, errors.Trace(err)
+}
+
 // Create creates and stores a new juju backup archive (based on arguments)
 // and updates the provided metadata.  A filename to download the backup is provided.
 func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy, noDownload bool) (string, error) {
@@ -162,7 +183,22 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, keepCopy,
 
 	// Store the archive if asked by user
 	if keepCopy {
-		err = storeArchive(b.storage, meta, result.archiveFile)
+		key, err := b.encryptionKey()
+		if err != nil {
+			return "", errors.Annotate(err, "while getting backup encryption key")
+		}
+		if key != nil {
+			// The archive is read twice to encrypt it, so it must
+			// be seekable; it is never stored unencrypted when
+			// there is a key.
+			archive, ok := result.archiveFile.(io.ReadSeeker)
+			if !ok {
+				return "", errors.New("cannot encrypt backup archive: archive is not seekable")
+			}
+			err = storeEncryptedArchive(b.storage, meta, archive, key)
+		} else {
+			err = storeArchive(b.storage, meta, result.archiveFile)
+		}
 		if err != nil {
 			return "", errors.Annotate(err, "while storing backup archive")
 		}
@@ -198,8 +234,24 @@ func (b *backups) Get(id string) (*Metadata, io.ReadCloser, error) {
 	if !ok {
 		return nil, nil, errors.New("did not get a backups.Metadata value from storage")
 	}
+	if meta.Encryption == nil || archiveFile == nil {
+		return meta, archiveFile, nil
+	}
 
-	return meta, archiveFile, nil
+	key, err := b.encryptionKey()
+	if err != nil {
+		archiveFile.Close()
+		return nil, nil, errors.Annotate(err, "while getting backup encryption key")
+	}
+	if key == nil {
+		return meta, unreadableArchive{archiveFile}, nil
+	}
+	decrypted, err := newDecryptingReader(archiveFile, meta.Encryption, key)
+	if err != nil {
+		archiveFile.Close()
+		return nil, nil, errors.Trace(err)
+	}
+	return meta, decrypted, nil
 }
 
 func (b *backups) getArchiveFromFilename(name string) (_ *Metadata, _ io.ReadCloser, err error) {
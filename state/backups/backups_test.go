@@ -6,6 +6,7 @@ package backups_test
 import (
 	"bytes"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"os"
 	"path"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/mongo"
@@ -165,6 +167,64 @@ func (s *backupsSuite) testCreateOkay(c *gc.C, keepCopy, noDownload bool) {
 	}
 }
 
+type readSeekCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekCloser) Close() error {
+	return nil
+}
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	archiveFile := readSeekCloser{bytes.NewReader([]byte("<compressed tarball>"))}
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>", "")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	var receivedKey []byte
+	s.PatchValue(backups.StoreEncryptedArchiveRef, func(_ filestorage.FileStorage, meta *backups.Metadata, _ io.ReadSeeker, key []byte) error {
+		receivedKey = key
+		return nil
+	})
+	s.PatchValue(backups.StoreArchiveRef, backups.NewTestArchiveStorer("should not be called"))
+
+	api := backups.NewEncryptedBackups(s.Storage, func() ([]byte, error) {
+		return []byte("key"), nil
+	})
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	_, err := api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo, true, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(receivedKey), gc.Equals, "key")
+}
+
+func (s *backupsSuite) TestCreateEncryptedNotSeekable(c *gc.C) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString("<compressed tarball>"))
+	result := backups.NewTestCreateResult(archiveFile, 10, "<checksum>", "")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+	s.PatchValue(backups.StoreArchiveRef, backups.NewTestArchiveStorer("should not be called"))
+
+	api := backups.NewEncryptedBackups(s.Storage, func() ([]byte, error) {
+		return []byte("key"), nil
+	})
+	paths := backups.Paths{BackupDir: c.MkDir(), DataDir: c.MkDir()}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	_, err := api.Create(backupstesting.NewMetadataStarted(), &paths, &dbInfo, true, true)
+	c.Assert(err, gc.ErrorMatches, "cannot encrypt backup archive: archive is not seekable")
+}
+
 func (s *backupsSuite) TestCreateFailToListFiles(c *gc.C) {
 	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
 		return nil, errors.New("failed!")
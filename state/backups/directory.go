@@ -0,0 +1,112 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+)
+
+// directoryStorage stores backup archives as files in a directory,
+// typically a shared (eg NFS) mount so that the archives outlive the
+// controller machines.
+type directoryStorage struct {
+	dir string
+}
+
+func newDirectoryStorage(dir string) filestorage.RawFileStorage {
+	return &directoryStorage{dir: dir}
+}
+
+// archiveName returns the name of the file holding the archive of the
+// identified backup, checking that the ID can't escape the directory.
+func archiveName(id string) (string, error) {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, `/\`) {
+		return "", errors.NotValidf("backup ID %q", id)
+	}
+	return "juju-backup-" + id + ".tar.gz", nil
+}
+
+func (s *directoryStorage) path(id string) (string, error) {
+	name, err := archiveName(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return filepath.Join(s.dir, name), nil
+}
+
+// File returns the identified file from storage.
+func (s *directoryStorage) File(id string) (io.ReadCloser, error) {
+	path, err := s.path(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to storage. The file is written under a
+// temporary name and then renamed, so that a partly written archive is
+// never mistaken for a complete one.
+func (s *directoryStorage) AddFile(id string, file io.Reader, size int64) (err error) {
+	path, err := s.path(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := os.Stat(s.dir); err != nil {
+		return errors.Annotate(err, "checking backup storage directory")
+	}
+	tmp, err := ioutil.TempFile(s.dir, ".juju-backup-")
+	if err != nil {
+		return errors.Annotate(err, "creating backup archive file")
+	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	written, err := io.Copy(tmp, file)
+	if err != nil {
+		return errors.Annotate(err, "writing backup archive")
+	}
+	if written != size {
+		return errors.Errorf("wrote %d bytes of backup archive, expected %d", written, size)
+	}
+	if err := tmp.Sync(); err != nil {
+		return errors.Annotate(err, "writing backup archive")
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Annotate(err, "writing backup archive")
+	}
+	return errors.Trace(os.Rename(tmp.Name(), path))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *directoryStorage) RemoveFile(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = os.Remove(path)
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("backup archive %q", id)
+	}
+	return errors.Trace(err)
+}
+
+// Close closes the storage.
+func (s *directoryStorage) Close() error {
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type directorySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&directorySuite{})
+
+func (s *directorySuite) TestAddFile(c *gc.C) {
+	dir := c.MkDir()
+	stor := backups.NewDirectoryStorage(dir)
+	defer stor.Close()
+
+	err := stor.AddFile("20180102-030405.uuid", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "juju-backup-20180102-030405.uuid.tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	file, err := stor.File("20180102-030405.uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	defer file.Close()
+	data, err = ioutil.ReadAll(file)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+}
+
+func (s *directorySuite) TestAddFileWrongSize(c *gc.C) {
+	dir := c.MkDir()
+	stor := backups.NewDirectoryStorage(dir)
+	defer stor.Close()
+
+	err := stor.AddFile("spam", bytes.NewBufferString("<archive>"), 42)
+	c.Assert(err, gc.ErrorMatches, "wrote 9 bytes of backup archive, expected 42")
+
+	// Nothing is left behind.
+	files, err := ioutil.ReadDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(files, gc.HasLen, 0)
+}
+
+func (s *directorySuite) TestAddFileMissingDirectory(c *gc.C) {
+	stor := backups.NewDirectoryStorage(filepath.Join(c.MkDir(), "missing"))
+	defer stor.Close()
+
+	err := stor.AddFile("spam", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, gc.ErrorMatches, "checking backup storage directory: .* no such file or directory")
+}
+
+func (s *directorySuite) TestFileNotFound(c *gc.C) {
+	stor := backups.NewDirectoryStorage(c.MkDir())
+	defer stor.Close()
+
+	_, err := stor.File("spam")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *directorySuite) TestRemoveFile(c *gc.C) {
+	dir := c.MkDir()
+	stor := backups.NewDirectoryStorage(dir)
+	defer stor.Close()
+	err := stor.AddFile("spam", bytes.NewBufferString("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = stor.RemoveFile("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stor.File("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = stor.RemoveFile("spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *directorySuite) TestInvalidID(c *gc.C) {
+	stor := backups.NewDirectoryStorage(c.MkDir())
+	defer stor.Close()
+
+	for _, id := range []string{"", ".", "..", "../spam", `..\spam`} {
+		_, err := stor.File(id)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
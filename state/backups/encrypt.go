@@ -0,0 +1,198 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+)
+
+// EncryptionInfo holds the details needed to decrypt and authenticate
+// a stored backup archive.
+//
+// Archives are encrypted with AES-256 in CTR mode, so the stored
+// archive is the same size as the original, and authenticated with
+// an HMAC-SHA256 of the IV and the encrypted archive. The keys used
+// for each are derived from the controller's backup encryption key.
+type EncryptionInfo struct {
+	// IV is the initialisation vector used to encrypt the archive.
+	IV []byte
+
+	// MAC authenticates the IV and the encrypted archive.
+	MAC []byte
+}
+
+// EncryptionKeySource returns the key with which stored backup
+// archives are encrypted, or nil if they are stored unencrypted.
+type EncryptionKeySource func() ([]byte, error)
+
+// ControllerEncryptionKey returns an EncryptionKeySource that reads
+// the key from the controller configuration.
+func ControllerEncryptionKey(db DB) EncryptionKeySource {
+	return func() ([]byte, error) {
+		controllerCfg, err := db.ControllerConfig()
+		if err != nil {
+			return nil, errors.Annotate(err, "could not get controller config")
+		}
+		return controllerCfg.BackupEncryptionKey(), nil
+	}
+}
+
+// encryptionKeys derives the cipher and MAC keys from the configured
+// backup encryption key, so that the same key is never used for both.
+func encryptionKeys(key []byte) (cipherKey, macKey []byte) {
+	derive := func(purpose string) []byte {
+		h := hmac.New(sha256.New, key)
+		h.Write([]byte(purpose))
+		return h.Sum(nil)
+	}
+	return derive("juju backup encryption"), derive("juju backup authentication")
+}
+
+func newArchiveStream(cipherKey, iv []byte) (cipher.Stream, error) {
+	block, err := aes.NewCipher(cipherKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewCTR(block, iv), nil
+}
+
+// StoreEncryptedArchive encrypts the backup archive with the key and
+// sends it and its metadata to storage. It sets the metadata's ID,
+// Stored and Encryption values.
+//
+// The archive is read twice: once to compute its MAC, which must be
+// stored with the metadata before the archive itself, and once to
+// store it.
+func StoreEncryptedArchive(stor filestorage.FileStorage, meta *Metadata, archive io.ReadSeeker, key []byte) error {
+	cipherKey, macKey := encryptionKeys(key)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
+		return errors.Annotate(err, "cannot generate IV")
+	}
+
+	stream, err := newArchiveStream(cipherKey, iv)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(iv)
+	if _, err := io.Copy(cipher.StreamWriter{S: stream, W: mac}, archive); err != nil {
+		return errors.Annotate(err, "cannot authenticate archive")
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+
+	stream, err = newArchiveStream(cipherKey, iv)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	meta.Encryption = &EncryptionInfo{
+		IV:  iv,
+		MAC: mac.Sum(nil),
+	}
+	return errors.Trace(StoreArchive(stor, meta, cipher.StreamReader{S: stream, R: archive}))
+}
+
+// newDecryptingReader returns a reader that decrypts the stored
+// archive. The archive is authenticated before any of it is decrypted:
+// the first read copies the encrypted archive to a temporary file while
+// checking its MAC, and fails if the archive does not match it.
+func newDecryptingReader(archive io.ReadCloser, info *EncryptionInfo, key []byte) (io.ReadCloser, error) {
+	cipherKey, macKey := encryptionKeys(key)
+	stream, err := newArchiveStream(cipherKey, info.IV)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(info.IV)
+	return &decryptingReader{
+		archive:  archive,
+		stream:   stream,
+		mac:      mac,
+		expected: info.MAC,
+	}, nil
+}
+
+type decryptingReader struct {
+	archive  io.ReadCloser
+	stream   cipher.Stream
+	mac      hash.Hash
+	expected []byte
+
+	// verified holds the encrypted archive once it has been
+	// authenticated, and err the error from authenticating it.
+	verified *os.File
+	err      error
+}
+
+// authenticate copies the encrypted archive to a temporary file,
+// checking it against its MAC.
+func (r *decryptingReader) authenticate() error {
+	f, err := ioutil.TempFile("", "juju-backup-")
+	if err != nil {
+		return errors.Annotate(err, "cannot create temporary file for backup archive")
+	}
+	r.verified = f
+	if _, err := io.Copy(io.MultiWriter(f, r.mac), r.archive); err != nil {
+		return errors.Annotate(err, "cannot read backup archive")
+	}
+	if !hmac.Equal(r.mac.Sum(nil), r.expected) {
+		return errors.New("backup archive failed authentication")
+	}
+	_, err = f.Seek(0, io.SeekStart)
+	return errors.Trace(err)
+}
+
+// Read is part of io.Reader.
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	if r.verified == nil && r.err == nil {
+		r.err = r.authenticate()
+	}
+	if r.err != nil {
+		return 0, r.err
+	}
+	n, err := r.verified.Read(p)
+	r.stream.XORKeyStream(p[:n], p[:n])
+	return n, err
+}
+
+// Close is part of io.Closer.
+func (r *decryptingReader) Close() error {
+	if r.verified != nil {
+		r.verified.Close()
+		if err := os.Remove(r.verified.Name()); err != nil {
+			logger.Warningf("cannot remove temporary backup archive: %v", err)
+		}
+	}
+	return r.archive.Close()
+}
+
+// unreadableArchive is returned in place of an encrypted archive when
+// there is no key with which to decrypt it, so that the backup's
+// metadata is still available.
+type unreadableArchive struct {
+	archive io.ReadCloser
+}
+
+// Read is part of io.Reader.
+func (r unreadableArchive) Read([]byte) (int, error) {
+	return 0, errors.New("backup archive is encrypted and no backup encryption key is configured")
+}
+
+// Close is part of io.Closer.
+func (r unreadableArchive) Close() error {
+	return r.archive.Close()
+}
@@ -0,0 +1,121 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	backupstesting "github.com/juju/juju/state/backups/testing"
+)
+
+type encryptSuite struct {
+	backupstesting.BaseSuite
+}
+
+var _ = gc.Suite(&encryptSuite{})
+
+var testEncryptionKey = []byte("0123456789abcdef0123456789abcdef")
+
+func keySource(key []byte) backups.EncryptionKeySource {
+	return func() ([]byte, error) {
+		return key, nil
+	}
+}
+
+// storeEncrypted stores the archive data encrypted in the fake storage
+// and returns the stored metadata and encrypted data.
+func (s *encryptSuite) storeEncrypted(c *gc.C, data string) (*backups.Metadata, []byte) {
+	s.Storage.ID = "spam"
+	s.Storage.Meta = backupstesting.NewMetadataStarted()
+	meta := backupstesting.NewMetadataStarted()
+	err := backups.StoreEncryptedArchive(s.Storage, meta, bytes.NewReader([]byte(data)), testEncryptionKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meta.Encryption, gc.NotNil)
+	c.Check(meta.Encryption.IV, gc.HasLen, 16)
+	c.Check(meta.Encryption.MAC, gc.HasLen, 32)
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Add", "Metadata"})
+
+	encrypted, err := ioutil.ReadAll(s.Storage.FileArg)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(encrypted, gc.HasLen, len(data))
+	c.Check(string(encrypted), gc.Not(gc.Equals), data)
+	return meta, encrypted
+}
+
+func (s *encryptSuite) get(c *gc.C, meta *backups.Metadata, encrypted []byte, key []byte) ([]byte, error) {
+	s.Storage.Meta = meta
+	s.Storage.File = ioutil.NopCloser(bytes.NewReader(encrypted))
+	api := backups.NewEncryptedBackups(s.Storage, keySource(key))
+	gotMeta, archive, err := api.Get("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(gotMeta, gc.Equals, meta)
+	defer archive.Close()
+	return ioutil.ReadAll(archive)
+}
+
+func (s *encryptSuite) TestRoundTrip(c *gc.C) {
+	meta, encrypted := s.storeEncrypted(c, "<compressed tarball>")
+	data, err := s.get(c, meta, encrypted, testEncryptionKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed tarball>")
+}
+
+func (s *encryptSuite) TestTamperedArchive(c *gc.C) {
+	meta, encrypted := s.storeEncrypted(c, "<compressed tarball>")
+	encrypted[3] ^= 0xff
+	_, err := s.get(c, meta, encrypted, testEncryptionKey)
+	c.Assert(err, gc.ErrorMatches, "backup archive failed authentication")
+}
+
+func (s *encryptSuite) TestTamperedArchiveNoData(c *gc.C) {
+	meta, encrypted := s.storeEncrypted(c, "<compressed tarball>")
+	encrypted[len(encrypted)-1] ^= 0xff
+	s.Storage.Meta = meta
+	s.Storage.File = ioutil.NopCloser(bytes.NewReader(encrypted))
+	api := backups.NewEncryptedBackups(s.Storage, keySource(testEncryptionKey))
+	_, archive, err := api.Get("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	// No data is returned before the whole archive is authenticated.
+	buf := make([]byte, 4)
+	n, err := archive.Read(buf)
+	c.Check(n, gc.Equals, 0)
+	c.Assert(err, gc.ErrorMatches, "backup archive failed authentication")
+}
+
+func (s *encryptSuite) TestWrongKey(c *gc.C) {
+	meta, encrypted := s.storeEncrypted(c, "<compressed tarball>")
+	_, err := s.get(c, meta, encrypted, []byte("fedcba9876543210fedcba9876543210"))
+	c.Assert(err, gc.ErrorMatches, "backup archive failed authentication")
+}
+
+func (s *encryptSuite) TestNoKey(c *gc.C) {
+	meta, encrypted := s.storeEncrypted(c, "<compressed tarball>")
+	_, err := s.get(c, meta, encrypted, nil)
+	c.Assert(err, gc.ErrorMatches, "backup archive is encrypted and no backup encryption key is configured")
+}
+
+func (s *encryptSuite) TestGetUnencrypted(c *gc.C) {
+	meta := backupstesting.NewMetadataStarted()
+	data, err := s.get(c, meta, []byte("<compressed tarball>"), testEncryptionKey)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed tarball>")
+}
+
+func (s *encryptSuite) TestGetKeyError(c *gc.C) {
+	s.Storage.Meta, _ = s.storeEncrypted(c, "<compressed tarball>")
+	s.Storage.File = ioutil.NopCloser(&bytes.Buffer{})
+	api := backups.NewEncryptedBackups(s.Storage, func() ([]byte, error) {
+		return nil, errors.New("boom")
+	})
+	_, _, err := api.Get("spam")
+	c.Assert(err, gc.ErrorMatches, "while getting backup encryption key: boom")
+}
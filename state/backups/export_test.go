@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"time" // Only used for time types.
 
 	"github.com/juju/errors"
@@ -20,20 +21,52 @@ var (
 	Create        = create
 	FileTimestamp = fileTimestamp
 
-	TestGetFilesToBackUp  = &getFilesToBackUp
-	GetDBDumper           = &getDBDumper
-	RunCreate             = &runCreate
-	FinishMeta            = &finishMeta
-	StoreArchiveRef       = &storeArchive
-	GetMongodumpPath      = &getMongodumpPath
-	GetMongorestorePath   = &getMongorestorePath
-	RunCommand            = &runCommandFn
-	ReplaceableFolders    = &replaceableFolders
-	MongoInstalledVersion = &mongoInstalledVersion
+	TestGetFilesToBackUp     = &getFilesToBackUp
+	GetDBDumper              = &getDBDumper
+	RunCreate                = &runCreate
+	FinishMeta               = &finishMeta
+	StoreArchiveRef          = &storeArchive
+	StoreEncryptedArchiveRef = &storeEncryptedArchive
+	GetMongodumpPath         = &getMongodumpPath
+	GetMongorestorePath      = &getMongorestorePath
+	RunCommand               = &runCommandFn
+	ReplaceableFolders       = &replaceableFolders
+	MongoInstalledVersion    = &mongoInstalledVersion
+	S3ResponseTimeout        = &s3ResponseTimeout
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
 var _ filestorage.RawFileStorage = (*backupBlobStorage)(nil)
+var _ filestorage.RawFileStorage = (*backupArchiveStorage)(nil)
+var _ filestorage.RawFileStorage = (*directoryStorage)(nil)
+var _ filestorage.RawFileStorage = (*s3Storage)(nil)
+
+// NewDirectoryStorage returns a raw file storage for backup archives
+// in the directory.
+func NewDirectoryStorage(dir string) filestorage.RawFileStorage {
+	return newDirectoryStorage(dir)
+}
+
+// NewS3Storage returns a raw file storage for backup archives in the
+// bucket of the object store.
+func NewS3Storage(endpoint, bucket, accessKey, secretKey string) filestorage.RawFileStorage {
+	return newS3Storage(s3Config{
+		endpoint:  endpoint,
+		region:    "us-east-1",
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+	})
+}
+
+// SignS3Request signs the request for the object store.
+func SignS3Request(req *http.Request, payloadHash, region, accessKey, secretKey string, now time.Time) {
+	signS3Request(req, payloadHash, s3Config{
+		region:    region,
+		accessKey: accessKey,
+		secretKey: secretKey,
+	}, now)
+}
 
 func getBackupDBWrapper(st *state.State) *storageDBWrapper {
 	db := st.MongoSession().DB(storageDBName)
@@ -68,6 +68,15 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Scheduled records whether the backup was taken by the backup
+	// scheduler rather than on request. Only scheduled backups are
+	// subject to the controller's backup retention policy.
+	Scheduled bool
+
+	// Encryption holds the details needed to decrypt the stored
+	// archive, or nil if the archive is stored unencrypted.
+	Encryption *EncryptionInfo
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -0,0 +1,103 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+	"sort"
+)
+
+// The reasons for which a scheduled backup is retained.
+const (
+	// RetainedDaily is the reason for retaining the last scheduled
+	// backup of a day.
+	RetainedDaily = "daily"
+
+	// RetainedWeekly is the reason for retaining the last scheduled
+	// backup of a week.
+	RetainedWeekly = "weekly"
+
+	// RetainedLatest is the reason for retaining the latest scheduled
+	// backup when no other reason applies; it is never removed.
+	RetainedLatest = "latest"
+)
+
+// RetentionPolicy determines which scheduled backups are kept. Backups
+// that were not taken by the backup scheduler are never removed by the
+// policy.
+//
+// Days and weeks are counted back from the latest scheduled backup,
+// and only days and weeks in which a scheduled backup was taken count,
+// so that backups are not lost while the scheduler is not running.
+type RetentionPolicy struct {
+	// Daily is the number of days for which the last scheduled backup
+	// of each day is kept.
+	Daily int
+
+	// Weekly is the number of weeks for which the last scheduled
+	// backup of each week is kept. Weeks start on Monday.
+	Weekly int
+}
+
+// Retained returns the reasons for which the scheduled backups in the
+// list are retained, keyed by backup ID. Scheduled backups with no
+// reason to be retained are not included.
+func (p RetentionPolicy) Retained(metas []*Metadata) map[string]string {
+	var scheduled []*Metadata
+	for _, meta := range metas {
+		if meta.Scheduled {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.SliceStable(scheduled, func(i, j int) bool {
+		return scheduled[i].Started.After(scheduled[j].Started)
+	})
+
+	retained := make(map[string]string)
+	retainLast := func(count int, period func(*Metadata) string, reason string) {
+		seen := make(map[string]bool)
+		for _, meta := range scheduled {
+			key := period(meta)
+			if seen[key] {
+				continue
+			}
+			if len(seen) == count {
+				return
+			}
+			seen[key] = true
+			if _, ok := retained[meta.ID()]; !ok {
+				retained[meta.ID()] = reason
+			}
+		}
+	}
+	retainLast(p.Daily, func(meta *Metadata) string {
+		return meta.Started.UTC().Format("2006-01-02")
+	}, RetainedDaily)
+	retainLast(p.Weekly, func(meta *Metadata) string {
+		year, week := meta.Started.UTC().ISOWeek()
+		return fmt.Sprintf("%d-%d", year, week)
+	}, RetainedWeekly)
+	if len(scheduled) > 0 {
+		if _, ok := retained[scheduled[0].ID()]; !ok {
+			retained[scheduled[0].ID()] = RetainedLatest
+		}
+	}
+	return retained
+}
+
+// Expired returns the scheduled backups in the list that the policy
+// does not retain, oldest first.
+func (p RetentionPolicy) Expired(metas []*Metadata) []*Metadata {
+	retained := p.Retained(metas)
+	var expired []*Metadata
+	for _, meta := range metas {
+		if _, ok := retained[meta.ID()]; meta.Scheduled && !ok {
+			expired = append(expired, meta)
+		}
+	}
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].Started.Before(expired[j].Started)
+	})
+	return expired
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type retentionSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&retentionSuite{})
+
+func backupAt(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+func ids(metas []*backups.Metadata) []string {
+	var result []string
+	for _, meta := range metas {
+		result = append(result, meta.ID())
+	}
+	return result
+}
+
+// dailyBackups returns scheduled backups taken at 02:00 and 14:00 on
+// each day from Monday 2018-10-01 to Wednesday 2018-10-17, newest
+// first, and a backup that was taken on request.
+func dailyBackups() []*backups.Metadata {
+	var metas []*backups.Metadata
+	day := time.Date(2018, 10, 17, 0, 0, 0, 0, time.UTC)
+	for ; day.Day() >= 1 && day.Month() == time.October; day = day.AddDate(0, 0, -1) {
+		metas = append(metas,
+			backupAt(day.Format("0102")+"-14", day.Add(14*time.Hour), true),
+			backupAt(day.Format("0102")+"-02", day.Add(2*time.Hour), true),
+		)
+	}
+	return append(metas, backupAt("manual", time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC), false))
+}
+
+func (s *retentionSuite) TestRetained(c *gc.C) {
+	policy := backups.RetentionPolicy{Daily: 3, Weekly: 3}
+	c.Check(policy.Retained(dailyBackups()), jc.DeepEquals, map[string]string{
+		"1017-14": backups.RetainedDaily,
+		"1016-14": backups.RetainedDaily,
+		"1015-14": backups.RetainedDaily,
+		// Sunday is the last day of the week.
+		"1014-14": backups.RetainedWeekly,
+		"1007-14": backups.RetainedWeekly,
+	})
+}
+
+func (s *retentionSuite) TestExpired(c *gc.C) {
+	metas := dailyBackups()
+	policy := backups.RetentionPolicy{Daily: 1, Weekly: 0}
+	expired := policy.Expired(metas)
+	c.Assert(expired, gc.HasLen, len(metas)-2)
+	c.Check(expired[0].ID(), gc.Equals, "1001-02")
+	c.Check(expired[len(expired)-1].ID(), gc.Equals, "1017-02")
+	for _, meta := range expired {
+		c.Check(meta.ID(), gc.Not(gc.Equals), "manual")
+		c.Check(meta.ID(), gc.Not(gc.Equals), "1017-14")
+	}
+}
+
+func (s *retentionSuite) TestKeepsLatest(c *gc.C) {
+	metas := []*backups.Metadata{
+		backupAt("old", time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC), true),
+		backupAt("new", time.Date(2018, 10, 2, 0, 0, 0, 0, time.UTC), true),
+		backupAt("manual", time.Date(2018, 10, 3, 0, 0, 0, 0, time.UTC), false),
+	}
+	policy := backups.RetentionPolicy{}
+	c.Check(policy.Retained(metas), jc.DeepEquals, map[string]string{
+		"new": backups.RetainedLatest,
+	})
+	c.Check(ids(policy.Expired(metas)), jc.DeepEquals, []string{"old"})
+}
+
+func (s *retentionSuite) TestNoScheduledBackups(c *gc.C) {
+	metas := []*backups.Metadata{
+		backupAt("manual", time.Date(2018, 10, 3, 0, 0, 0, 0, time.UTC), false),
+	}
+	policy := backups.RetentionPolicy{Daily: 7, Weekly: 4}
+	c.Check(policy.Retained(metas), gc.HasLen, 0)
+	c.Check(policy.Expired(metas), gc.HasLen, 0)
+}
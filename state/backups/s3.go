@@ -0,0 +1,229 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/filestorage"
+)
+
+const (
+	// s3Root is the prefix of the keys of the archive objects.
+	s3Root = "juju-backups"
+
+	// s3UnsignedPayload is used in place of the hash of request
+	// bodies, so that archives can be streamed to the object store.
+	s3UnsignedPayload = "UNSIGNED-PAYLOAD"
+
+	// s3EmptyPayload is the hash of an empty request body.
+	s3EmptyPayload = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	s3TimeFormat = "20060102T150405Z"
+	s3DateFormat = "20060102"
+
+	// s3DialTimeout bounds the time taken to connect to the object
+	// store.
+	s3DialTimeout = 30 * time.Second
+)
+
+// s3ResponseTimeout bounds the time taken by the object store to respond
+// to a request once it has been sent. There's no limit on the time taken
+// by the whole request, as archives can take a long time to transfer. It
+// is a variable so it can be replaced in tests.
+var s3ResponseTimeout = 2 * time.Minute
+
+// s3Config holds the details of the S3-compatible object store in which
+// backup archives are stored.
+type s3Config struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+}
+
+// s3Storage stores backup archives as objects in a bucket of an
+// S3-compatible object store. Requests use path-style addressing, which
+// is supported by AWS S3 and by the common self-hosted object stores.
+type s3Storage struct {
+	config s3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func newS3Storage(config s3Config) filestorage.RawFileStorage {
+	return &s3Storage{
+		config: config,
+		client: newS3HTTPClient(),
+		now:    time.Now,
+	}
+}
+
+// newS3HTTPClient returns the HTTP client used to send requests to the
+// object store, which gives up on unresponsive object stores rather than
+// waiting for them indefinitely.
+func newS3HTTPClient() *http.Client {
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   s3DialTimeout,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: s3ResponseTimeout,
+			ExpectContinueTimeout: time.Second,
+			IdleConnTimeout:       90 * time.Second,
+		},
+	}
+}
+
+func (s *s3Storage) url(id string) (string, error) {
+	name, err := archiveName(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	endpoint := strings.TrimSuffix(s.config.endpoint, "/")
+	return fmt.Sprintf("%s/%s/%s/%s", endpoint, s.config.bucket, s3Root, name), nil
+}
+
+// do sends a signed request for the identified archive object.
+func (s *s3Storage) do(method, id string, body io.Reader, size int64) (*http.Response, error) {
+	url, err := s.url(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	payloadHash := s3EmptyPayload
+	if body != nil {
+		req.ContentLength = size
+		payloadHash = s3UnsignedPayload
+	}
+	signS3Request(req, payloadHash, s.config, s.now())
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, errors.Annotate(err, "sending request to backup object store")
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errors.NotFoundf("backup archive %q", id)
+	}
+	msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+	return nil, errors.Errorf("backup object store: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+}
+
+// File returns the identified file from storage.
+func (s *s3Storage) File(id string) (io.ReadCloser, error) {
+	resp, err := s.do("GET", id, nil, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Body, nil
+}
+
+// AddFile adds the file to storage.
+func (s *s3Storage) AddFile(id string, file io.Reader, size int64) error {
+	resp, err := s.do("PUT", id, ioutil.NopCloser(file), size)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *s3Storage) RemoveFile(id string) error {
+	resp, err := s.do("DELETE", id, nil, 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// Close closes the storage.
+func (s *s3Storage) Close() error {
+	return nil
+}
+
+// signS3Request adds to the request the headers that authenticate it
+// with AWS signature version 4. All of the request's headers are signed,
+// along with the host.
+func signS3Request(req *http.Request, payloadHash string, config s3Config, now time.Time) {
+	now = now.UTC()
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(s3DateFormat), config.region, "s3", "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format(s3TimeFormat),
+		scope,
+		hexSHA256([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := []byte("AWS4" + config.secretKey)
+	for _, part := range []string{now.Format(s3DateFormat), config.region, "s3", "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		config.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+func hexSHA256(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
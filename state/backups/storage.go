@@ -48,6 +48,20 @@ type storageMetaDoc struct {
 	Finished int64  `bson:"finished,minsize"`
 	Notes    string `bson:"notes,omitempty"`
 
+	Scheduled     bool   `bson:"scheduled,omitempty"`
+	EncryptionIV  []byte `bson:"encryptioniv,omitempty"`
+	EncryptionMAC []byte `bson:"encryptionmac,omitempty"`
+
+	// Storage is the backup storage that holds the archive. It is
+	// empty for archives stored in the controller. The remaining
+	// Storage fields record where in that storage the archive is,
+	// so it can be found after the backup storage config changes.
+	Storage           string `bson:"storage,omitempty"`
+	StorageDirectory  string `bson:"storagedirectory,omitempty"`
+	StorageS3Endpoint string `bson:"storages3endpoint,omitempty"`
+	StorageS3Region   string `bson:"storages3region,omitempty"`
+	StorageS3Bucket   string `bson:"storages3bucket,omitempty"`
+
 	// origin
 
 	Model    string         `bson:"model"`
@@ -70,6 +84,33 @@ func (doc *storageMetaDoc) isFileInfoComplete() bool {
 	return true
 }
 
+// target returns the backup storage target that holds the archive.
+func (doc *storageMetaDoc) target() storageTarget {
+	target := storageTarget{
+		storage:    doc.Storage,
+		directory:  doc.StorageDirectory,
+		s3Endpoint: doc.StorageS3Endpoint,
+		s3Region:   doc.StorageS3Region,
+		s3Bucket:   doc.StorageS3Bucket,
+	}
+	if target.storage == "" {
+		target.storage = controller.BackupStorageTypeController
+	}
+	return target
+}
+
+// setTarget records the backup storage target that holds the archive.
+func (doc *storageMetaDoc) setTarget(target storageTarget) {
+	if target.storage == controller.BackupStorageTypeController {
+		return
+	}
+	doc.Storage = target.storage
+	doc.StorageDirectory = target.directory
+	doc.StorageS3Endpoint = target.s3Endpoint
+	doc.StorageS3Region = target.s3Region
+	doc.StorageS3Bucket = target.s3Bucket
+}
+
 func (doc *storageMetaDoc) validate() error {
 	if doc.ID == "" {
 		return errors.New("missing ID")
@@ -124,6 +165,13 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Scheduled = doc.Scheduled
+	if len(doc.EncryptionIV) > 0 {
+		meta.Encryption = &EncryptionInfo{
+			IV:  doc.EncryptionIV,
+			MAC: doc.EncryptionMAC,
+		}
+	}
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +223,11 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Scheduled = meta.Scheduled
+	if meta.Encryption != nil {
+		doc.EncryptionIV = meta.Encryption.IV
+		doc.EncryptionMAC = meta.Encryption.MAC
+	}
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
@@ -378,6 +431,10 @@ func setStorageStoredTime(dbWrap *storageDBWrapper, id string, stored time.Time)
 
 type backupsDocStorage struct {
 	dbWrap *storageDBWrapper
+
+	// target is the backup storage target in which the archives of
+	// newly added backups are stored.
+	target storageTarget
 }
 
 type backupsMetadataStorage struct {
@@ -386,10 +443,10 @@ type backupsMetadataStorage struct {
 	modelUUID string
 }
 
-func newMetadataStorage(dbWrap *storageDBWrapper) *backupsMetadataStorage {
+func newMetadataStorage(dbWrap *storageDBWrapper, target storageTarget) *backupsMetadataStorage {
 	dbWrap = dbWrap.Copy()
 
-	docStor := backupsDocStorage{dbWrap}
+	docStor := backupsDocStorage{dbWrap, target}
 	stor := backupsMetadataStorage{
 		MetadataDocStorage: filestorage.MetadataDocStorage{&docStor},
 		db:                 dbWrap.db,
@@ -405,6 +462,7 @@ func (s *backupsDocStorage) AddDoc(doc filestorage.Document) (string, error) {
 		return "", errors.Errorf("doc must be of type *backups.Metadata")
 	}
 	metaDoc := newStorageMetaDoc(metadata)
+	metaDoc.setTarget(s.target)
 
 	dbWrap := s.dbWrap.Copy()
 	defer dbWrap.Close()
@@ -521,6 +579,164 @@ func (s *backupBlobStorage) Close() error {
 	return s.dbWrap.Close()
 }
 
+// storageTarget identifies a location in backup storage that holds
+// backup archives.
+type storageTarget struct {
+	storage    string
+	directory  string
+	s3Endpoint string
+	s3Region   string
+	s3Bucket   string
+}
+
+// configuredTarget returns the backup storage target in the controller
+// config.
+func configuredTarget(cfg controller.Config) storageTarget {
+	target := storageTarget{storage: cfg.BackupStorage()}
+	switch target.storage {
+	case controller.BackupStorageTypeDirectory:
+		target.directory = cfg.BackupStorageDirectory()
+	case controller.BackupStorageTypeS3:
+		target.s3Endpoint = cfg.BackupS3Endpoint()
+		target.s3Region = cfg.BackupS3Region()
+		target.s3Bucket = cfg.BackupS3Bucket()
+	}
+	return target
+}
+
+// newTargetFileStorage returns the raw file storage for the backup
+// storage target outside the controller. The credentials for the
+// object store are taken from the controller config.
+func newTargetFileStorage(target storageTarget, cfg controller.Config) (filestorage.RawFileStorage, error) {
+	switch target.storage {
+	case controller.BackupStorageTypeDirectory:
+		return newDirectoryStorage(target.directory), nil
+	case controller.BackupStorageTypeS3:
+		return newS3Storage(s3Config{
+			endpoint:  target.s3Endpoint,
+			region:    target.s3Region,
+			bucket:    target.s3Bucket,
+			accessKey: cfg.BackupS3AccessKey(),
+			secretKey: cfg.BackupS3SecretKey(),
+		}), nil
+	default:
+		return nil, errors.NotSupportedf("backup storage %q", target.storage)
+	}
+}
+
+// backupArchiveStorage stores the archives of new backups in the
+// configured backup storage. Archives stored before the backup storage
+// was changed remain in the storage target that holds them, as recorded
+// in their metadata.
+type backupArchiveStorage struct {
+	dbWrap *storageDBWrapper
+
+	// target is the configured backup storage target, and cfg is
+	// the controller config it was read from.
+	target storageTarget
+	cfg    controller.Config
+
+	// controller stores archives in the controller's database.
+	controller filestorage.RawFileStorage
+
+	// err is the error, if any, from reading the backup storage
+	// config. It is returned by all operations on archives outside
+	// the controller.
+	err error
+}
+
+// newArchiveStorage returns the raw file storage for backup archives
+// and the backup storage target in which new archives are stored.
+func newArchiveStorage(st DB, dbWrap *storageDBWrapper) (*backupArchiveStorage, storageTarget) {
+	stor := &backupArchiveStorage{
+		dbWrap:     dbWrap.Copy(),
+		target:     storageTarget{storage: controller.BackupStorageTypeController},
+		controller: newFileStorage(dbWrap, backupStorageRoot),
+	}
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		stor.err = errors.Annotate(err, "getting backup storage config")
+		return stor, stor.target
+	}
+	stor.target = configuredTarget(cfg)
+	stor.cfg = cfg
+	return stor, stor.target
+}
+
+// withStorage calls f with the raw file storage for the backup storage
+// target.
+func (s *backupArchiveStorage) withStorage(target storageTarget, f func(filestorage.RawFileStorage) error) error {
+	if target.storage == controller.BackupStorageTypeController {
+		return f(s.controller)
+	}
+	if s.err != nil {
+		return s.err
+	}
+	stor, err := newTargetFileStorage(target, s.cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer stor.Close()
+	return f(stor)
+}
+
+// archiveTarget returns the backup storage target that holds the
+// identified archive.
+func (s *backupArchiveStorage) archiveTarget(id string) (storageTarget, error) {
+	dbWrap := s.dbWrap.Copy()
+	defer dbWrap.Close()
+
+	doc, err := getStorageMetadata(dbWrap, id)
+	if errors.IsNotFound(err) {
+		return s.target, nil
+	} else if err != nil {
+		return storageTarget{}, errors.Trace(err)
+	}
+	return doc.target(), nil
+}
+
+// File returns the identified file from storage.
+func (s *backupArchiveStorage) File(id string) (io.ReadCloser, error) {
+	target, err := s.archiveTarget(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var file io.ReadCloser
+	err = s.withStorage(target, func(stor filestorage.RawFileStorage) error {
+		file, err = stor.File(id)
+		return err
+	})
+	return file, errors.Trace(err)
+}
+
+// AddFile adds the file to the configured backup storage.
+func (s *backupArchiveStorage) AddFile(id string, file io.Reader, size int64) error {
+	if s.err != nil {
+		return s.err
+	}
+	return errors.Trace(s.withStorage(s.target, func(stor filestorage.RawFileStorage) error {
+		return stor.AddFile(id, file, size)
+	}))
+}
+
+// RemoveFile removes the identified file from storage.
+func (s *backupArchiveStorage) RemoveFile(id string) error {
+	target, err := s.archiveTarget(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(s.withStorage(target, func(stor filestorage.RawFileStorage) error {
+		return stor.RemoveFile(id)
+	}))
+}
+
+// Close closes the storage.
+func (s *backupArchiveStorage) Close() error {
+	err := s.controller.Close()
+	s.dbWrap.Close()
+	return errors.Trace(err)
+}
+
 //---------------------------
 // backup storage
 
@@ -550,14 +766,16 @@ type DB interface {
 }
 
 // NewStorage returns a new FileStorage to use for storing backup
-// archives (and metadata).
+// archives (and metadata). The metadata is stored in the controller's
+// database, and the archives in the backup storage configured for the
+// controller.
 func NewStorage(st DB) filestorage.FileStorage {
 	modelUUID := st.ModelTag().Id()
 	db := st.MongoSession().DB(storageDBName)
 	dbWrap := newStorageDBWrapper(db, storageMetaName, modelUUID)
 	defer dbWrap.Close()
 
-	files := newFileStorage(dbWrap, backupStorageRoot)
-	docs := newMetadataStorage(dbWrap)
+	files, target := newArchiveStorage(st, dbWrap)
+	docs := newMetadataStorage(dbWrap, target)
 	return filestorage.NewFileStorage(docs, files)
 }
@@ -4,12 +4,18 @@
 package backups_test
 
 import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
 	statetesting "github.com/juju/juju/state/testing"
 )
@@ -88,6 +94,24 @@ func (s *storageSuite) TestAddBackupMetadataSuccess(c *gc.C) {
 	s.checkMeta(c, meta, original, id)
 }
 
+func (s *storageSuite) TestAddBackupMetadataScheduledEncrypted(c *gc.C) {
+	original := s.metadata(c)
+	original.Scheduled = true
+	original.Encryption = &backups.EncryptionInfo{
+		IV:  []byte("0123456789abcdef"),
+		MAC: []byte("mac"),
+	}
+	id, err := backups.AddBackupMetadata(s.State, original)
+	c.Assert(err, jc.ErrorIsNil)
+
+	meta, err := backups.GetBackupMetadata(s.State, id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.checkMeta(c, meta, original, id)
+	c.Check(meta.Scheduled, jc.IsTrue)
+	c.Check(meta.Encryption, jc.DeepEquals, original.Encryption)
+}
+
 func (s *storageSuite) TestAddBackupMetadataGeneratedID(c *gc.C) {
 	original := s.metadata(c)
 	original.SetID("spam")
@@ -136,3 +160,134 @@ func (s *storageSuite) TestSetBackupStoredTimeNotFound(c *gc.C) {
 
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 }
+
+// addArchive adds a backup with the archive data using storage for the
+// current controller config, and returns its ID.
+func (s *storageSuite) addArchive(c *gc.C, data string) string {
+	stor := backups.NewStorage(struct {
+		*state.State
+		*state.Model
+	}{s.State, s.Model})
+	defer stor.Close()
+
+	meta := backups.NewMetadata()
+	meta.Origin.Model = s.State.ModelUUID()
+	meta.Origin.Machine = "0"
+	meta.Origin.Hostname = "localhost"
+	err := meta.MarkComplete(int64(len(data)), "some hash")
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := backups.NewBackups(stor).Add(bytes.NewBufferString(data), meta)
+	c.Assert(err, jc.ErrorIsNil)
+	return id
+}
+
+// getArchive returns the archive data of the backup, using storage for
+// the current controller config.
+func (s *storageSuite) getArchive(c *gc.C, id string) (string, error) {
+	stor := backups.NewStorage(struct {
+		*state.State
+		*state.Model
+	}{s.State, s.Model})
+	defer stor.Close()
+
+	_, archive, err := backups.NewBackups(stor).Get(id)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	return string(data), nil
+}
+
+func (s *storageSuite) TestDirectoryStorage(c *gc.C) {
+	dir := c.MkDir()
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-storage":           "directory",
+		"backup-storage-directory": dir,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	id := s.addArchive(c, "<archive>")
+	data, err := ioutil.ReadFile(filepath.Join(dir, "juju-backup-"+id+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	archive, err := s.getArchive(c, id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive, gc.Equals, "<archive>")
+}
+
+func (s *storageSuite) TestStorageChanged(c *gc.C) {
+	inController := s.addArchive(c, "<controller archive>")
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-storage":           "directory",
+		"backup-storage-directory": c.MkDir(),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	inDirectory := s.addArchive(c, "<directory archive>")
+
+	// Archives stay in the storage they were added to.
+	archive, err := s.getArchive(c, inController)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive, gc.Equals, "<controller archive>")
+	archive, err = s.getArchive(c, inDirectory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive, gc.Equals, "<directory archive>")
+
+	// Changing the backup storage doesn't orphan the archives in
+	// the storage targets used before.
+	otherDir := c.MkDir()
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-storage-directory": otherDir,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	inOtherDirectory := s.addArchive(c, "<other directory archive>")
+	_, err = os.Stat(filepath.Join(otherDir, "juju-backup-"+inOtherDirectory+".tar.gz"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"backup-storage": "controller",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err = s.getArchive(c, inDirectory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive, gc.Equals, "<directory archive>")
+	archive, err = s.getArchive(c, inOtherDirectory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive, gc.Equals, "<other directory archive>")
+}
+
+type controllerConfigErrorDB struct {
+	*state.State
+	*state.Model
+}
+
+func (controllerConfigErrorDB) ControllerConfig() (controller.Config, error) {
+	return nil, errors.New("boom")
+}
+
+func (s *storageSuite) TestControllerConfigError(c *gc.C) {
+	id := s.addArchive(c, "<archive>")
+
+	stor := backups.NewStorage(controllerConfigErrorDB{s.State, s.Model})
+	defer stor.Close()
+	api := backups.NewBackups(stor)
+
+	// Archives in the controller don't need the backup storage config.
+	_, archive, err := api.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(archive)
+	archive.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<archive>")
+
+	meta := s.metadata(c)
+	meta.Started = meta.Started.Add(time.Hour)
+	_, err = api.Add(bytes.NewBufferString("<archive>"), meta)
+	c.Check(err, gc.ErrorMatches, ".*getting backup storage config: boom")
+
+	err = api.Remove(id)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,252 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+const (
+	// MaxCharmStateKeySize is the maximum size, in bytes, of a key
+	// in a unit's charm state.
+	MaxCharmStateKeySize = 256
+
+	// MaxCharmStateSize is the maximum total size, in bytes, of the
+	// keys and values in a unit's charm state.
+	MaxCharmStateSize = 64 * 1024
+)
+
+// charmStateDoc holds the key/value pairs that a unit's charm keeps on
+// the controller with the state-set hook tool. Keys are escaped for
+// storage in mongo.
+type charmStateDoc struct {
+	DocId     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	TxnRevno  int64             `bson:"txn-revno"`
+	Unit      string            `bson:"unit"`
+	State     map[string]string `bson:"state"`
+}
+
+func (doc *charmStateDoc) unescapedState() map[string]string {
+	state := make(map[string]string, len(doc.State))
+	for key, value := range doc.State {
+		state[unescapeReplacer.Replace(key)] = value
+	}
+	return state
+}
+
+func escapeCharmState(state map[string]string) map[string]string {
+	escaped := make(map[string]string, len(state))
+	for key, value := range state {
+		escaped[escapeReplacer.Replace(key)] = value
+	}
+	return escaped
+}
+
+// validateCharmState returns an error if any key of the charm state is
+// invalid, or if the state exceeds the size limits.
+func validateCharmState(state map[string]string) error {
+	size := 0
+	for key, value := range state {
+		if key == "" {
+			return errors.NotValidf("empty charm state key")
+		}
+		if len(key) > MaxCharmStateKeySize {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"charm state key of %d bytes exceeds the limit of %d bytes",
+				len(key), MaxCharmStateKeySize,
+			))
+		}
+		size += len(key) + len(value)
+	}
+	if size > MaxCharmStateSize {
+		return errors.NewNotValid(nil, fmt.Sprintf(
+			"charm state of %d bytes exceeds the limit of %d bytes",
+			size, MaxCharmStateSize,
+		))
+	}
+	return nil
+}
+
+func (u *Unit) charmStateDoc() (*charmStateDoc, error) {
+	states, closer := u.st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var doc charmStateDoc
+	err := states.FindId(u.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("charm state for unit %q", u.Name())
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm state for unit %q", u.Name())
+	}
+	return &doc, nil
+}
+
+// CharmState returns the key/value pairs that the unit's charm keeps
+// on the controller.
+func (u *Unit) CharmState() (map[string]string, error) {
+	doc, err := u.charmStateDoc()
+	if errors.IsNotFound(err) {
+		return map[string]string{}, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.unescapedState(), nil
+}
+
+// UpdateCharmState sets the given keys of the unit's charm state, and
+// removes the unset keys. The update fails if the unit is dead, or if
+// the resulting state would exceed the size limits.
+func (u *Unit) UpdateCharmState(set map[string]string, unset []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.Life() == Dead {
+			return nil, errors.Errorf("unit %q is dead", u.Name())
+		}
+		doc, err := u.charmStateDoc()
+		if errors.IsNotFound(err) {
+			doc = nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		state := make(map[string]string)
+		if doc != nil {
+			state = doc.unescapedState()
+		}
+		for _, key := range unset {
+			delete(state, key)
+		}
+		for key, value := range set {
+			state[key] = value
+		}
+		if err := validateCharmState(state); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: notDeadDoc,
+		}}
+		switch {
+		case doc == nil && len(state) == 0:
+			return nil, jujutxn.ErrNoOperations
+		case doc == nil:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.globalKey(),
+				Assert: txn.DocMissing,
+				Insert: &charmStateDoc{
+					Unit:  u.Name(),
+					State: escapeCharmState(state),
+				},
+			})
+		case len(state) == 0:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.globalKey(),
+				Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+				Remove: true,
+			})
+		default:
+			ops = append(ops, txn.Op{
+				C:      unitStatesC,
+				Id:     u.globalKey(),
+				Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+				Update: bson.D{{"$set", bson.D{{"state", escapeCharmState(state)}}}},
+			})
+		}
+		return ops, nil
+	}
+	err := u.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot update charm state for unit %q", u.Name())
+}
+
+// removeCharmStateOp returns the operation needed to remove the charm
+// state of the unit with the given global key.
+func removeCharmStateOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      unitStatesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
+
+// AllCharmStates returns the charm state of each unit in the model
+// that has any, keyed by unit name. Charm state isn't included in the
+// model description, so it's transferred alongside it when the model
+// is migrated.
+func (st *State) AllCharmStates() (map[string]map[string]string, error) {
+	states, closer := st.db().GetCollection(unitStatesC)
+	defer closer()
+
+	var docs []charmStateDoc
+	if err := states.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get charm states")
+	}
+	result := make(map[string]map[string]string, len(docs))
+	for _, doc := range docs {
+		result[doc.Unit] = doc.unescapedState()
+	}
+	return result, nil
+}
+
+// ImportCharmStates sets the charm state of the units of a migrated
+// model, from the charm states obtained with AllCharmStates. The units
+// must not already have charm state.
+func (st *State) ImportCharmStates(states map[string]map[string]string) error {
+	unitNames := make([]string, 0, len(states))
+	for unitName := range states {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+
+	var ops []txn.Op
+	for _, unitName := range unitNames {
+		if !names.IsValidUnit(unitName) {
+			return errors.NotValidf("unit name %q", unitName)
+		}
+		state := states[unitName]
+		if err := validateCharmState(state); err != nil {
+			return errors.Annotatef(err, "unit %q", unitName)
+		}
+		if len(state) == 0 {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      unitsC,
+			Id:     unitName,
+			Assert: txn.DocExists,
+		}, txn.Op{
+			C:      unitStatesC,
+			Id:     unitGlobalKey(unitName),
+			Assert: txn.DocMissing,
+			Insert: &charmStateDoc{
+				Unit:  unitName,
+				State: escapeCharmState(state),
+			},
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.New("units missing or charm state already set")
+	}
+	return errors.Annotate(err, "cannot import charm states")
+}
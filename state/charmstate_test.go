@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type CharmStateSuite struct {
+	ConnSuite
+	application *state.Application
+	unit        *state.Unit
+}
+
+var _ = gc.Suite(&CharmStateSuite{})
+
+func (s *CharmStateSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	var err error
+	s.unit, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmStateSuite) TestCharmStateEmpty(c *gc.C) {
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+}
+
+func (s *CharmStateSuite) TestUpdateCharmState(c *gc.C) {
+	err := s.unit.UpdateCharmState(map[string]string{
+		"foo":     "bar",
+		"a.b":     "c",
+		"$dollar": "value",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.UpdateCharmState(map[string]string{"foo": "baz"}, []string{"a.b", "missing"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{
+		"foo":     "baz",
+		"$dollar": "value",
+	})
+}
+
+func (s *CharmStateSuite) TestUpdateCharmStateUnsetAll(c *gc.C) {
+	err := s.unit.UpdateCharmState(map[string]string{"foo": "bar"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateCharmState(nil, []string{"foo"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	// Unsetting keys that were never set is not an error.
+	err = s.unit.UpdateCharmState(nil, []string{"foo"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmStateSuite) TestUpdateCharmStateInvalidKey(c *gc.C) {
+	err := s.unit.UpdateCharmState(map[string]string{"": "bar"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update charm state for unit "wordpress/0": empty charm state key not valid`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+
+	key := strings.Repeat("k", state.MaxCharmStateKeySize+1)
+	err = s.unit.UpdateCharmState(map[string]string{key: "bar"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update charm state for unit "wordpress/0": charm state key of 257 bytes exceeds the limit of 256 bytes`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CharmStateSuite) TestUpdateCharmStateTooLarge(c *gc.C) {
+	value := strings.Repeat("v", state.MaxCharmStateSize/2)
+	err := s.unit.UpdateCharmState(map[string]string{"a": value}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.UpdateCharmState(map[string]string{"b": value}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update charm state for unit "wordpress/0": charm state of 65538 bytes exceeds the limit of 65536 bytes`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotValid)
+
+	// Replacing a value with a smaller one keeps the state in bounds.
+	err = s.unit.UpdateCharmState(map[string]string{"b": "small"}, []string{"a"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmStateSuite) TestUpdateCharmStateDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateCharmState(map[string]string{"foo": "bar"}, nil)
+	c.Assert(err, gc.ErrorMatches, `cannot update charm state for unit "wordpress/0": unit "wordpress/0" is dead`)
+}
+
+func (s *CharmStateSuite) TestCharmStateRemovedWithUnit(c *gc.C) {
+	err := s.unit.UpdateCharmState(map[string]string{"foo": "bar"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	states, err := s.State.AllCharmStates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(states, gc.HasLen, 0)
+}
+
+func (s *CharmStateSuite) TestAllCharmStates(c *gc.C) {
+	other, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.UpdateCharmState(map[string]string{"foo": "bar"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = other.UpdateCharmState(map[string]string{"a.b": "c"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	states, err := s.State.AllCharmStates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(states, jc.DeepEquals, map[string]map[string]string{
+		"wordpress/0": {"foo": "bar"},
+		"wordpress/1": {"a.b": "c"},
+	})
+}
+
+func (s *CharmStateSuite) TestImportCharmStates(c *gc.C) {
+	err := s.State.ImportCharmStates(map[string]map[string]string{
+		"wordpress/0": {"foo": "bar", "a.b": "c"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar", "a.b": "c"})
+
+	err = s.State.ImportCharmStates(map[string]map[string]string{
+		"wordpress/0": {"foo": "baz"},
+	})
+	c.Assert(err, gc.ErrorMatches, "units missing or charm state already set")
+}
+
+func (s *CharmStateSuite) TestImportCharmStatesMissingUnit(c *gc.C) {
+	err := s.State.ImportCharmStates(map[string]map[string]string{
+		"wordpress/5": {"foo": "bar"},
+	})
+	c.Assert(err, gc.ErrorMatches, "units missing or charm state already set")
+}
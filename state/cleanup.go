@@ -40,6 +40,7 @@ const (
 
 	cleanupResourceBlob         cleanupKind = "resourceBlob"
 	cleanupStorageForDyingModel cleanupKind = "modelStorage"
+	cleanupApplicationSecrets   cleanupKind = "applicationSecrets"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -101,6 +102,18 @@ func (st *State) NeedsCleanup() (bool, error) {
 	return count > 0, nil
 }
 
+// CleanupCount returns the number of documents marked for removal that
+// are waiting to be cleaned up.
+func (st *State) CleanupCount() (int, error) {
+	cleanups, closer := st.db().GetCollection(cleanupsC)
+	defer closer()
+	count, err := cleanups.Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count cleanups")
+	}
+	return count, nil
+}
+
 // Cleanup removes all documents that were previously marked for removal, if
 // any such exist. It should be called periodically by at least one element
 // of the system.
@@ -154,6 +167,8 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupResourceBlob(doc.Prefix)
 		case cleanupStorageForDyingModel:
 			err = st.cleanupStorageForDyingModel(args)
+		case cleanupApplicationSecrets:
+			err = st.cleanupApplicationSecrets(doc.Prefix)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -100,6 +100,19 @@ func (s *CleanupSuite) TestCleanupDyingApplicationCharm(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *CleanupSuite) TestCleanupCount(c *gc.C) {
+	count, err := s.State.CleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	count, err = s.State.CleanupCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Not(gc.Equals), 0)
+}
+
 func (s *CleanupSuite) TestCleanupRemoteApplication(c *gc.C) {
 	app, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
 		Name:        "remote-app",
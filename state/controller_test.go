@@ -36,8 +36,20 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.JujuHASpace,
 		controller.JujuManagementSpace,
 		controller.AuditLogExcludeMethods,
+		controller.AuditLogBackends,
 		controller.MaxPruneTxnBatchSize,
 		controller.MaxPruneTxnPasses,
+		controller.BackupSchedule,
+		controller.BackupRetainDaily,
+		controller.BackupRetainWeekly,
+		controller.BackupEncryptionKey,
+		controller.BackupStorage,
+		controller.BackupStorageDirectory,
+		controller.BackupS3Endpoint,
+		controller.BackupS3Region,
+		controller.BackupS3Bucket,
+		controller.BackupS3AccessKey,
+		controller.BackupS3SecretKey,
 		controller.CAASOperatorImagePath,
 		controller.CharmStoreURL,
 		controller.Features,
@@ -5,9 +5,16 @@ package state
 
 import "github.com/juju/errors"
 
+// dumpExcludedCollections holds the collections whose documents are
+// too sensitive to be included in a dump.
+var dumpExcludedCollections = map[string]bool{
+	secretsC:         true,
+	secretRevisionsC: true,
+}
+
 // DumpAll returns a map of collection names to a slice of documents
 // in that collection. Every document that is related to the current
-// model is returned in the map.
+// model is returned in the map, except for secrets.
 func (st *State) DumpAll() (map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	// Add in the model document itself.
@@ -17,7 +24,7 @@ func (st *State) DumpAll() (map[string]interface{}, error) {
 	}
 	result[modelsC] = doc
 	for name, info := range allCollections() {
-		if !info.global {
+		if !info.global && !dumpExcludedCollections[name] {
 			docs, err := getAllModelDocs(st, name)
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"sort"
 	"time" // Only used for time types.
 
 	"github.com/juju/clock/testclock"
@@ -45,6 +46,8 @@ const (
 	BlockDevicesC     = blockDevicesC
 	StorageInstancesC = storageInstancesC
 	GUISettingsC      = guisettingsC
+	RollingUpgradesC  = rollingUpgradesC
+	ActionSchedulesC  = actionSchedulesC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
 )
@@ -286,6 +289,7 @@ func addCharm(c *gc.C, st *State, series string, ch charm.Charm) *Charm {
 
 func init() {
 	txnLogSize = txnLogSizeTests
+	auditLogSize = auditLogSizeTests
 }
 
 // TxnRevno returns the txn-revno field of the document
@@ -303,6 +307,30 @@ func TxnRevno(st *State, collName string, id interface{}) (int64, error) {
 	return doc.TxnRevno, nil
 }
 
+// ReleasedActionIds returns the ids of the actions that have been
+// released to their receivers and have not yet finished.
+func ReleasedActionIds(st *State) ([]string, error) {
+	coll, closer := st.db().GetCollection(actionNotificationsC)
+	defer closer()
+	var docs []actionNotificationDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(docs))
+	for i, doc := range docs {
+		ids[i] = doc.ActionID
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// ActionQueueCount returns the number of action queues in the model.
+func ActionQueueCount(st *State) (int, error) {
+	coll, closer := st.db().GetCollection(actionQueuesC)
+	defer closer()
+	return coll.Count()
+}
+
 // MinUnitsRevno returns the Revno of the minUnits document
 // associated with the given application name.
 func MinUnitsRevno(st *State, applicationname string) (int, error) {
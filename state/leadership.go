@@ -45,6 +45,28 @@ func (st *State) LeadershipChecker() leadership.Checker {
 	}
 }
 
+// LeadershipPinner returns a leadership.Pinner for applications in the
+// state's model.
+func (st *State) LeadershipPinner() leadership.Pinner {
+	return leadership.NewPinner(
+		lazyLeasePinner{func() (lease.Pinner, error) {
+			manager := st.workers.leadershipManager()
+			return manager.Pinner(applicationLeadershipNamespace, st.modelUUID())
+		}},
+	)
+}
+
+// LeadershipTransferrer returns a leadership.Transferrer for
+// applications in the state's model.
+func (st *State) LeadershipTransferrer() leadership.Transferrer {
+	return leadershipTransferrer{
+		lazyLeaseTransferrer{func() (lease.Transferrer, error) {
+			manager := st.workers.leadershipManager()
+			return manager.Transferrer(applicationLeadershipNamespace, st.modelUUID())
+		}},
+	}
+}
+
 // buildTxnWithLeadership returns a transaction source that combines the supplied source
 // with checks and asserts on the supplied token.
 func buildTxnWithLeadership(buildTxn jujutxn.TransactionSource, token leadership.Token) jujutxn.TransactionSource {
@@ -116,3 +138,21 @@ func (m leadershipClaimer) BlockUntilLeadershipReleased(applicationname string,
 	}
 	return errors.Trace(err)
 }
+
+// leadershipTransferrer implements leadership.Transferrer by wrapping a
+// lease.Transferrer.
+type leadershipTransferrer struct {
+	transferrer lease.Transferrer
+}
+
+// TransferLeadership is part of the leadership.Transferrer interface.
+func (m leadershipTransferrer) TransferLeadership(applicationname, unitName string, duration time.Duration) error {
+	err := m.transferrer.Transfer(applicationname, unitName, duration)
+	switch errors.Cause(err) {
+	case lease.ErrNotHeld:
+		return errors.NotFoundf("leader of application %q", applicationname)
+	case lease.ErrPinned:
+		return errors.Errorf("leadership of application %q is pinned", applicationname)
+	}
+	return errors.Trace(err)
+}
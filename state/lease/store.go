@@ -188,6 +188,30 @@ func (store *store) ExpireLease(key lease.Key) error {
 	return nil
 }
 
+// TransferLease is part of the lease.Store interface. Transferring
+// leases is only supported by the raft lease store.
+func (store *store) TransferLease(key lease.Key, holder string, request lease.Request) error {
+	return errors.NotImplementedf("lease transfer")
+}
+
+// PinLease is part of the lease.Store interface. Pinning is only
+// supported by the raft lease store.
+func (store *store) PinLease(key lease.Key, entity string) error {
+	return errors.NotImplementedf("lease pinning")
+}
+
+// UnpinLease is part of the lease.Store interface. Pinning is only
+// supported by the raft lease store.
+func (store *store) UnpinLease(key lease.Key, entity string) error {
+	return errors.NotImplementedf("lease pinning")
+}
+
+// Pinned is part of the lease.Store interface. Leases are never
+// pinned in this store.
+func (store *store) Pinned() map[lease.Key][]string {
+	return map[lease.Key][]string{}
+}
+
 // Refresh is part of the Store interface.
 func (store *store) Refresh() error {
 	store.mu.Lock()
@@ -0,0 +1,424 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/instance"
+)
+
+// charmProfileChangeDoc records that the LXD profiles required by the
+// units on a machine may no longer match the profiles applied to its
+// instance. The doc is updated on every such change, so that watchers
+// are notified each time.
+type charmProfileChangeDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	MachineId string `bson:"machine-id"`
+	Changes   int    `bson:"changes"`
+}
+
+// CharmProfile is an LXD profile supplied by the charm of an
+// application with units on a machine.
+type CharmProfile struct {
+	// Name is the name of the profile, which identifies the model,
+	// application and charm revision it belongs to.
+	Name string
+
+	// Profile holds the contents of the profile.
+	Profile *charm.LXDProfile
+}
+
+// charmProfileChangeOps returns the operations needed to record that
+// the LXD profiles required by the units on the machine with the given
+// id may have changed.
+func charmProfileChangeOps(st *State, machineId string) ([]txn.Op, error) {
+	changes, closer := st.db().GetCollection(charmProfileChangesC)
+	defer closer()
+
+	docID := st.docID(machineId)
+	count, err := changes.FindId(docID).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count == 0 {
+		return []txn.Op{{
+			C:      charmProfileChangesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &charmProfileChangeDoc{
+				DocID:     docID,
+				ModelUUID: st.ModelUUID(),
+				MachineId: machineId,
+			},
+		}}, nil
+	}
+	return []txn.Op{{
+		C:      charmProfileChangesC,
+		Id:     docID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"changes", 1}}}},
+	}}, nil
+}
+
+// provisionedCharmProfileChangeOps returns the operations needed to
+// record that the LXD profiles required by the units on the machine with
+// the given id must be applied to its newly provisioned instance. Nothing
+// is recorded for machines that have never required a profile.
+func provisionedCharmProfileChangeOps(st *State, machineId string) ([]txn.Op, error) {
+	changes, closer := st.db().GetCollection(charmProfileChangesC)
+	defer closer()
+
+	docID := st.docID(machineId)
+	count, err := changes.FindId(docID).Count()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	return []txn.Op{{
+		C:      charmProfileChangesC,
+		Id:     docID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$inc", bson.D{{"changes", 1}}}},
+	}}, nil
+}
+
+func removeCharmProfileChangeOp(st *State, machineId string) txn.Op {
+	return txn.Op{
+		C:      charmProfileChangesC,
+		Id:     st.docID(machineId),
+		Remove: true,
+	}
+}
+
+// machineCharmProfileChangeOps returns the operations needed to record
+// that the LXD profiles required by the machine with the given id may
+// have changed, if the application's charm supplies one.
+func (a *Application) machineCharmProfileChangeOps(machineId string) ([]txn.Op, error) {
+	ch, _, err := a.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if lxdprofile.IsEmpty(ch.LXDProfile()) {
+		return nil, nil
+	}
+	return charmProfileChangeOps(a.st, machineId)
+}
+
+// unitCharmProfileChangeOps returns the operations needed to record
+// that the LXD profiles required by the machine hosting the unit may
+// have changed, if the application's charm supplies one.
+func (a *Application) unitCharmProfileChangeOps(u *Unit) ([]txn.Op, error) {
+	if u.modelType == ModelTypeCAAS {
+		return nil, nil
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) || errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a.machineCharmProfileChangeOps(machineId)
+}
+
+// upgradeCharmProfileOps returns the operations needed to record that
+// the LXD profiles required by the machines hosting the application's
+// units have changed, if either its current charm or the given new
+// charm supplies one.
+func (a *Application) upgradeCharmProfileOps(newCharm *Charm) ([]txn.Op, error) {
+	ch, _, err := a.Charm()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if lxdprofile.IsEmpty(ch.LXDProfile()) && lxdprofile.IsEmpty(newCharm.LXDProfile()) {
+		return nil, nil
+	}
+	model, err := a.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() == ModelTypeCAAS {
+		return nil, nil
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineIds := make(map[string]bool)
+	var ops []txn.Op
+	for _, u := range units {
+		machineId, err := u.AssignedMachineId()
+		if errors.IsNotAssigned(err) || errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineIds[machineId] {
+			continue
+		}
+		machineIds[machineId] = true
+		changeOps, err := charmProfileChangeOps(a.st, machineId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, changeOps...)
+	}
+	return ops, nil
+}
+
+// RequiredCharmProfiles returns the LXD profiles supplied by the charms
+// of the applications with units on the machine, sorted by name.
+func (m *Machine) RequiredCharmProfiles() ([]CharmProfile, error) {
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := m.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := make(map[string]bool)
+	var profiles []CharmProfile
+	for _, u := range units {
+		appName := u.ApplicationName()
+		if seen[appName] {
+			continue
+		}
+		seen[appName] = true
+		app, err := u.Application()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ch, _, err := app.Charm()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if lxdprofile.IsEmpty(ch.LXDProfile()) {
+			continue
+		}
+		profiles = append(profiles, CharmProfile{
+			Name:    lxdprofile.Name(model.Name(), appName, ch.Revision()),
+			Profile: ch.LXDProfile(),
+		})
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Name < profiles[j].Name
+	})
+	return profiles, nil
+}
+
+// CharmProfiles returns the names of the charm LXD profiles applied to
+// the machine's instance, or a NotProvisionedError if the machine has
+// no instance.
+func (m *Machine) CharmProfiles() ([]string, error) {
+	instData, err := getInstanceData(m.st, m.Id())
+	if errors.IsNotFound(err) {
+		return nil, errors.NotProvisionedf("machine %v", m.Id())
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return instData.CharmProfiles, nil
+}
+
+// SetCharmProfiles records the names of the charm LXD profiles applied
+// to the machine's instance.
+func (m *Machine) SetCharmProfiles(profiles []string) error {
+	ops := []txn.Op{{
+		C:      instanceDataC,
+		Id:     m.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"charm-profiles", profiles}}}},
+	}}
+	err := m.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotProvisionedf("machine %v", m.Id())
+	}
+	return errors.Annotatef(err, "cannot set charm profiles of machine %v", m)
+}
+
+// WatchCharmProfiles returns a NotifyWatcher that notifies when the
+// charm LXD profiles applied to the machine's instance change.
+func (m *Machine) WatchCharmProfiles() NotifyWatcher {
+	return newEntityWatcher(m.st, instanceDataC, m.doc.DocID)
+}
+
+// supportsCharmProfiles reports whether charm LXD profiles are applied
+// to the machine's instance: LXD containers, and machines provided by
+// the LXD provider other than manually provisioned ones.
+func (m *Machine) supportsCharmProfiles() (bool, error) {
+	switch m.ContainerType() {
+	case instance.LXD:
+		return true, nil
+	case "", instance.NONE:
+	default:
+		return false, nil
+	}
+	manual, err := m.IsManual()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if manual {
+		return false, nil
+	}
+	model, err := m.st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cloud, err := m.st.Cloud(model.Cloud())
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return cloud.Type == "lxd", nil
+}
+
+// CharmProfileApplied reports whether the LXD profile supplied by the
+// unit's application's current charm has been applied to the instance
+// of the machine hosting the unit. It is always true for charms that
+// supply no profile, and for units whose machines do not use profiles.
+func (u *Unit) CharmProfileApplied() (bool, error) {
+	if u.modelType == ModelTypeCAAS {
+		return true, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if lxdprofile.IsEmpty(ch.LXDProfile()) {
+		return true, nil
+	}
+	machineId, err := u.AssignedMachineId()
+	if errors.IsNotAssigned(err) {
+		return true, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	machine, err := u.st.Machine(machineId)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	supported, err := machine.supportsCharmProfiles()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if !supported {
+		return true, nil
+	}
+	applied, err := machine.CharmProfiles()
+	if errors.IsNotProvisioned(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	model, err := u.st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	name := lxdprofile.Name(model.Name(), app.Name(), ch.Revision())
+	for _, profile := range applied {
+		if profile == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// WatchContainerCharmProfileChanges returns a StringsWatcher that
+// notifies of the ids of the LXD containers hosted by the machine
+// whose required charm profiles may have changed.
+func (m *Machine) WatchContainerCharmProfileChanges() StringsWatcher {
+	prefix := m.st.docID(m.Id() + "/" + string(instance.LXD) + "/")
+	filter := func(key interface{}) bool {
+		id, ok := key.(string)
+		if !ok || !strings.HasPrefix(id, prefix) {
+			return false
+		}
+		// Containers nested in this machine's containers are
+		// the responsibility of their own hosts.
+		return !strings.Contains(id[len(prefix):], "/")
+	}
+	return newCollectionWatcher(m.st, colWCfg{
+		col:    charmProfileChangesC,
+		filter: filter,
+	})
+}
+
+// WatchModelMachineCharmProfileChanges returns a StringsWatcher that
+// notifies of the ids of the machines (but not containers) in the model
+// whose required charm profiles may have changed.
+func (st *State) WatchModelMachineCharmProfileChanges() StringsWatcher {
+	filter := func(key interface{}) bool {
+		id, err := st.strictLocalID(key.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(id, "/")
+	}
+	return newCollectionWatcher(st, colWCfg{
+		col:    charmProfileChangesC,
+		filter: filter,
+	})
+}
+
+// HasCharmProfiles reports whether any machine in the model has charm
+// LXD profiles applied to its instance, or has units whose charms
+// supply profiles that are yet to be applied.
+func (m *Model) HasCharmProfiles() (bool, error) {
+	instances, closer := m.st.db().GetCollection(instanceDataC)
+	defer closer()
+
+	applied, err := instances.Find(bson.D{
+		{"charm-profiles.0", bson.D{{"$exists", true}}},
+	}).Count()
+	if err != nil {
+		return false, errors.Annotate(err, "cannot count applied charm profiles")
+	}
+	if applied > 0 {
+		return true, nil
+	}
+
+	changes, closer := m.st.db().GetCollection(charmProfileChangesC)
+	defer closer()
+
+	var docs []charmProfileChangeDoc
+	if err := changes.Find(nil).All(&docs); err != nil {
+		return false, errors.Annotate(err, "cannot get charm profile changes")
+	}
+	for _, doc := range docs {
+		machine, err := m.st.Machine(doc.MachineId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return false, errors.Trace(err)
+		}
+		supported, err := machine.supportsCharmProfiles()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if !supported {
+			continue
+		}
+		required, err := machine.RequiredCharmProfiles()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		if len(required) > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,273 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+const lxdProfileYaml = `
+description: test profile
+config:
+  security.nesting: "true"
+`
+
+type LXDProfileSuite struct {
+	ConnSuite
+	host        *state.Machine
+	container   *state.Machine
+	application *state.Application
+}
+
+var _ = gc.Suite(&LXDProfileSuite{})
+
+func (s *LXDProfileSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	var err error
+	s.host, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	s.container, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.host.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	ch := s.AddLXDProfileCharm(c, "lxd-profile", lxdProfileYaml, 1)
+	s.application = s.AddTestingApplication(c, "lxd-profile", ch)
+}
+
+func (s *LXDProfileSuite) addUnit(c *gc.C, m *state.Machine) *state.Unit {
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *LXDProfileSuite) TestRequiredCharmProfiles(c *gc.C) {
+	s.addUnit(c, s.container)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.container)
+	c.Assert(err, jc.ErrorIsNil)
+
+	profiles, err := s.container.RequiredCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, []state.CharmProfile{{
+		Name: "juju-testmodel-lxd-profile-1",
+		Profile: &charm.LXDProfile{
+			Description: "test profile",
+			Config:      map[string]string{"security.nesting": "true"},
+			Devices:     map[string]map[string]string{},
+		},
+	}})
+
+	profiles, err = s.host.RequiredCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, gc.HasLen, 0)
+}
+
+func (s *LXDProfileSuite) TestSetCharmProfiles(c *gc.C) {
+	err := s.container.SetCharmProfiles([]string{"juju-testmodel-lxd-profile-1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+	_, err = s.container.CharmProfiles()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	profiles, err := s.container.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, gc.HasLen, 0)
+
+	err = s.container.SetCharmProfiles([]string{"juju-testmodel-lxd-profile-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	profiles, err = s.container.CharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(profiles, jc.DeepEquals, []string{"juju-testmodel-lxd-profile-1"})
+}
+
+func (s *LXDProfileSuite) TestCharmProfileApplied(c *gc.C) {
+	unit := s.addUnit(c, s.container)
+	applied, err := unit.CharmProfileApplied()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.IsFalse)
+
+	err = s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	applied, err = unit.CharmProfileApplied()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.IsFalse)
+
+	err = s.container.SetCharmProfiles([]string{"juju-testmodel-lxd-profile-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	applied, err = unit.CharmProfileApplied()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.IsTrue)
+
+	// Upgrading the charm requires its profile to be applied.
+	ch := s.AddLXDProfileCharm(c, "lxd-profile", lxdProfileYaml, 2)
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	applied, err = unit.CharmProfileApplied()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.IsFalse)
+}
+
+func (s *LXDProfileSuite) TestCharmProfileAppliedNotNeeded(c *gc.C) {
+	// Machines not provided by LXD have no charm profiles.
+	unit := s.addUnit(c, s.host)
+	applied, err := unit.CharmProfileApplied()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.IsTrue)
+
+	// Nor do charms without a profile need one.
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err = mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.container)
+	c.Assert(err, jc.ErrorIsNil)
+	applied, err = unit.CharmProfileApplied()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applied, jc.IsTrue)
+}
+
+func (s *LXDProfileSuite) TestWatchCharmProfiles(c *gc.C) {
+	err := s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	w := s.container.WatchCharmProfiles()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err = s.container.SetCharmProfiles([]string{"juju-testmodel-lxd-profile-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *LXDProfileSuite) TestWatchContainerCharmProfileChanges(c *gc.C) {
+	w := s.host.WatchContainerCharmProfileChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Assigning a unit to the container flags it.
+	unit := s.addUnit(c, s.container)
+	wc.AssertChange(s.container.Id())
+	wc.AssertNoChange()
+
+	// Units on the host itself, or on containers nested in its
+	// containers, are not reported.
+	s.addUnit(c, s.host)
+	nested, err := s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, s.container.Id(), instance.LXD)
+	c.Assert(err, jc.ErrorIsNil)
+	s.addUnit(c, nested)
+	wc.AssertNoChange()
+
+	// Upgrading the charm flags the container again.
+	ch := s.AddLXDProfileCharm(c, "lxd-profile", lxdProfileYaml, 2)
+	err = s.application.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.container.Id())
+	wc.AssertNoChange()
+
+	// So does removing the unit.
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.container.Id())
+	wc.AssertNoChange()
+}
+
+func (s *LXDProfileSuite) TestWatchModelMachineCharmProfileChanges(c *gc.C) {
+	w := s.State.WatchModelMachineCharmProfileChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+	wc.AssertNoChange()
+
+	// Assigning a unit to a machine flags it.
+	s.addUnit(c, s.host)
+	wc.AssertChange(s.host.Id())
+	wc.AssertNoChange()
+
+	// Units on containers are the responsibility of their hosts.
+	s.addUnit(c, s.container)
+	wc.AssertNoChange()
+
+	// Upgrading the charm flags the machine again.
+	ch := s.AddLXDProfileCharm(c, "lxd-profile", lxdProfileYaml, 2)
+	err := s.application.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.host.Id())
+	wc.AssertNoChange()
+}
+
+func (s *LXDProfileSuite) TestProvisioningFlagsContainer(c *gc.C) {
+	s.addUnit(c, s.container)
+	w := s.host.WatchContainerCharmProfileChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(s.container.Id())
+	wc.AssertNoChange()
+
+	err := s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.container.Id())
+	wc.AssertNoChange()
+}
+
+func (s *LXDProfileSuite) TestNoProfileNoChanges(c *gc.C) {
+	w := s.host.WatchContainerCharmProfileChanges()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange()
+
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	unit, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.AssignToMachine(s.container)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *LXDProfileSuite) TestHasCharmProfiles(c *gc.C) {
+	has, err := s.Model.HasCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(has, jc.IsFalse)
+
+	// A container with a unit whose charm supplies a profile
+	// requires the profile to be applied.
+	s.addUnit(c, s.container)
+	has, err = s.Model.HasCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(has, jc.IsTrue)
+
+	err = s.container.SetProvisioned("inst-0", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.container.SetCharmProfiles([]string{"juju-testmodel-lxd-profile-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	has, err = s.Model.HasCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(has, jc.IsTrue)
+}
+
+func (s *LXDProfileSuite) TestHasCharmProfilesNotNeeded(c *gc.C) {
+	// Machines not provided by LXD have no charm profiles.
+	s.addUnit(c, s.host)
+	has, err := s.Model.HasCharmProfiles()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(has, jc.IsFalse)
+}
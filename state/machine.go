@@ -212,6 +212,10 @@ type instanceData struct {
 	// KeepInstance is set to true if, on machine removal from Juju,
 	// the cloud instance should be retained.
 	KeepInstance bool `bson:"keep-instance,omitempty"`
+
+	// CharmProfiles holds the names of the charm LXD profiles
+	// applied to the instance.
+	CharmProfiles []string `bson:"charm-profiles,omitempty"`
 }
 
 func hardwareCharacteristics(instData instanceData) *instance.HardwareCharacteristics {
@@ -949,6 +953,7 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
+		removeCharmProfileChangeOp(m.st, m.Id()),
 	}
 	linkLayerDevicesOps, err := m.removeAllLinkLayerDevicesOps()
 	if err != nil {
@@ -1313,6 +1318,11 @@ func (m *Machine) SetProvisioned(
 			Insert: instData,
 		},
 	}
+	profileOps, err := provisionedCharmProfileChangeOps(m.st, m.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, profileOps...)
 
 	if err = m.st.db().RunTransaction(ops); err == nil {
 		m.doc.Nonce = nonce
@@ -1928,17 +1938,7 @@ func (m *Machine) VolumeAttachments() ([]VolumeAttachment, error) {
 
 // AddAction is part of the ActionReceiver interface.
 func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action, error) {
-	spec, ok := actions.PredefinedActionsSpec[name]
-	if !ok {
-		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
-	}
-
-	// Reject bad payloads before attempting to insert defaults.
-	err := spec.ValidateParams(payload)
-	if err != nil {
-		return nil, err
-	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
+	payloadWithDefaults, err := m.actionPayload(name, payload)
 	if err != nil {
 		return nil, err
 	}
@@ -1951,6 +1951,22 @@ func (m *Machine) AddAction(name string, payload map[string]interface{}) (Action
 	return model.EnqueueAction(m.Tag(), name, payloadWithDefaults)
 }
 
+// actionPayload validates the payload of the named predefined action,
+// and returns it with the action's defaults inserted.
+func (m *Machine) actionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
+	spec, ok := actions.PredefinedActionsSpec[name]
+	if !ok {
+		return nil, errors.Errorf("cannot add action %q to a machine; only predefined actions allowed", name)
+	}
+
+	// Reject bad payloads before attempting to insert defaults.
+	err := spec.ValidateParams(payload)
+	if err != nil {
+		return nil, err
+	}
+	return spec.InsertDefaults(payload)
+}
+
 // CancelAction is part of the ActionReceiver interface.
 func (m *Machine) CancelAction(action Action) (Action, error) {
 	return action.Finish(ActionResults{Status: ActionCancelled})
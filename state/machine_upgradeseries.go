@@ -292,14 +292,23 @@ func (m *Machine) RemoveUpgradeSeriesLock() error {
 				return nil, errors.Trace(err)
 			}
 		}
-		locked, err := m.IsLocked()
-		if err != nil {
+		lock, err := m.getUpgradeSeriesLock()
+		if errors.IsBadRequest(err) {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
-		if !locked {
-			return nil, jujutxn.ErrNoOperations
+		ops := removeUpgradeSeriesLockTxnOps(m.doc.Id)
+		if lock.MachineStatus == model.UpgradeSeriesCompleted {
+			// The machine has finished its series upgrade, so any
+			// application upgrades it is part of move on.
+			completedOps, err := m.completedUpgradeSeriesOps()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, completedOps...)
 		}
-		return removeUpgradeSeriesLockTxnOps(m.doc.Id), nil
+		return ops, nil
 	}
 	err := m.st.db().Run(buildTxn)
 	if err != nil {
@@ -104,6 +104,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		// The audit log is controller-wide and stays with the
+		// controller that recorded it.
+		auditLogC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
@@ -190,6 +193,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// we include the name of the leader unit. On import, a new lease
 		// is created for the leader unit.
 		leasesC,
+
+		// Charm state isn't in the model description; it is transferred
+		// alongside the serialized model and imported separately.
+		unitStatesC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -209,6 +216,39 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// sure the leader units' leases are claimed in the target
 		// controller when leases are managed in raft.
 		leaseHoldersC,
+		// TODO(actionschedules)
+		// Action schedules need to be added to the model
+		// description before they can be migrated; until then
+		// the migration prechecks refuse models with schedules.
+		actionSchedulesC,
+		// TODO(actionqueues)
+		// Action queues need to be added to the model description
+		// before they can be migrated; until then the migration
+		// prechecks refuse models with held actions.
+		actionQueuesC,
+		// TODO(secrets)
+		// Secrets need to be added to the model description
+		// before they can be migrated; until then the migration
+		// prechecks refuse models with secrets.
+		secretsC,
+		secretRevisionsC,
+		// TODO(rollingupgrades)
+		// Rolling upgrades need to be added to the model
+		// description before they can be migrated; until then
+		// the migration prechecks refuse models with a rolling
+		// upgrade, including an aborted one.
+		rollingUpgradesC,
+		// Application series upgrades are driven by the user, and
+		// the migration prechecks refuse models with one that is
+		// running or paused; a model is migrated once they have
+		// completed or been rolled back.
+		applicationUpgradeSeriesC,
+		// TODO(lxdprofile)
+		// Charm profile changes need to be added to the model
+		// description before they can be migrated; until then
+		// the migration prechecks refuse models with machines
+		// that require charm profiles.
+		charmProfileChangesC,
 	)
 
 	modelCollections := set.NewStrings()
@@ -340,6 +380,12 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		// KeepInstance is only set when a machine is
 		// dying/dead (to be removed).
 		"KeepInstance",
+		// TODO(lxdprofile)
+		// CharmProfiles need to be added to the model
+		// description before they can be migrated; until then
+		// the migration prechecks refuse models with machines
+		// that have charm profiles applied.
+		"CharmProfiles",
 	)
 	migrated := set.NewStrings(
 		// DocID is the model + machine id
@@ -380,6 +426,7 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// TODO(caas)
 		"DesiredScale",
 		"Placement",
+		"Autoscale",
 	)
 	migrated := set.NewStrings(
 		"Name",
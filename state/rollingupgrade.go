@@ -0,0 +1,593 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/watcher"
+)
+
+// RollingUpgradeStatus describes the progress of a rolling upgrade.
+type RollingUpgradeStatus string
+
+const (
+	// RollingUpgradeRunning means that batches of units are being
+	// released to upgrade.
+	RollingUpgradeRunning RollingUpgradeStatus = "running"
+
+	// RollingUpgradePaused means that a batch of units failed to
+	// become healthy, and no more units will be released until the
+	// upgrade is resumed.
+	RollingUpgradePaused RollingUpgradeStatus = "paused"
+
+	// RollingUpgradeAborted means that no more units will be
+	// released. Units that were not released keep running their
+	// current charm until the application's charm is next changed.
+	RollingUpgradeAborted RollingUpgradeStatus = "aborted"
+)
+
+// RollingUpgradeOnError describes what a rolling upgrade does when a
+// batch of units fails to become healthy.
+type RollingUpgradeOnError string
+
+const (
+	// RollingUpgradePause pauses the upgrade until it is resumed.
+	RollingUpgradePause RollingUpgradeOnError = "pause"
+
+	// RollingUpgradeAbort aborts the upgrade.
+	RollingUpgradeAbort RollingUpgradeOnError = "abort"
+)
+
+// rollingUpgradeDoc records the progress of an application's charm
+// upgrade that is released to its units in batches.
+type rollingUpgradeDoc struct {
+	DocId       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Application string `bson:"application"`
+
+	// CharmURL is the charm the units are being upgraded to.
+	CharmURL string `bson:"charm-url"`
+
+	BatchSize    int           `bson:"batch-size"`
+	BatchTimeout time.Duration `bson:"batch-timeout"`
+	OnError      string        `bson:"on-error"`
+
+	Status  string `bson:"status"`
+	Message string `bson:"message,omitempty"`
+
+	// Released holds the names of the units that have been released
+	// to upgrade, in the order they were released.
+	Released []string `bson:"released,omitempty"`
+
+	// BatchStarted holds when the latest batch of units was released.
+	BatchStarted time.Time `bson:"batch-started"`
+}
+
+// RollingUpgrade represents a charm upgrade of an application that is
+// released to its units in batches. Each batch must become healthy,
+// with the units' agents idle and workloads active, before the next is
+// released. Units that have not been released keep running the charm
+// they are currently running.
+type RollingUpgrade struct {
+	doc rollingUpgradeDoc
+}
+
+// Application returns the name of the application being upgraded.
+func (u *RollingUpgrade) Application() string {
+	return u.doc.Application
+}
+
+// CharmURL returns the URL of the charm the units are being upgraded
+// to.
+func (u *RollingUpgrade) CharmURL() string {
+	return u.doc.CharmURL
+}
+
+// BatchSize returns the number of units released to upgrade at a time.
+func (u *RollingUpgrade) BatchSize() int {
+	return u.doc.BatchSize
+}
+
+// BatchTimeout returns how long a batch of units has to become healthy.
+func (u *RollingUpgrade) BatchTimeout() time.Duration {
+	return u.doc.BatchTimeout
+}
+
+// OnError returns what the upgrade does when a batch of units fails to
+// become healthy.
+func (u *RollingUpgrade) OnError() RollingUpgradeOnError {
+	return RollingUpgradeOnError(u.doc.OnError)
+}
+
+// Status returns the status of the upgrade.
+func (u *RollingUpgrade) Status() RollingUpgradeStatus {
+	return RollingUpgradeStatus(u.doc.Status)
+}
+
+// Message returns why the upgrade was paused or aborted.
+func (u *RollingUpgrade) Message() string {
+	return u.doc.Message
+}
+
+// Released returns the names of the units that have been released to
+// upgrade.
+func (u *RollingUpgrade) Released() []string {
+	return u.doc.Released
+}
+
+// BatchStarted returns when the latest batch of units was released.
+func (u *RollingUpgrade) BatchStarted() time.Time {
+	return u.doc.BatchStarted
+}
+
+// IsReleased returns whether the named unit has been released to
+// upgrade.
+func (u *RollingUpgrade) IsReleased(unitName string) bool {
+	for _, name := range u.doc.Released {
+		if name == unitName {
+			return true
+		}
+	}
+	return false
+}
+
+// RollingUpgradeArgs holds the arguments for upgrading an
+// application's charm in batches.
+type RollingUpgradeArgs struct {
+	// BatchSize is the number of units released to upgrade at a time.
+	BatchSize int
+
+	// BatchTimeout is how long a batch of units has to become
+	// healthy.
+	BatchTimeout time.Duration
+
+	// OnError is what the upgrade does when a batch of units fails
+	// to become healthy.
+	OnError RollingUpgradeOnError
+}
+
+// Validate checks that the arguments are valid.
+func (args RollingUpgradeArgs) Validate() error {
+	if args.BatchSize < 1 {
+		return errors.NotValidf("batch size %d", args.BatchSize)
+	}
+	if args.BatchTimeout <= 0 {
+		return errors.NotValidf("batch timeout %v", args.BatchTimeout)
+	}
+	switch args.OnError {
+	case RollingUpgradePause, RollingUpgradeAbort:
+	default:
+		return errors.NotValidf("on-error action %q", args.OnError)
+	}
+	return nil
+}
+
+// rollingUpgradeOps returns the operations to record a charm upgrade
+// of the application. If args is nil the upgrade is released to all
+// units at once, replacing any rolling upgrade; otherwise it replaces
+// an aborted rolling upgrade, but not one that is still in progress.
+func (a *Application) rollingUpgradeOps(curl *charm.URL, args *RollingUpgradeArgs) ([]txn.Op, error) {
+	docID := a.st.docID(a.doc.Name)
+	existing, err := a.RollingUpgrade()
+	if errors.IsNotFound(err) {
+		existing = nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args == nil {
+		if existing == nil {
+			return nil, nil
+		}
+		return []txn.Op{{
+			C:      rollingUpgradesC,
+			Id:     docID,
+			Remove: true,
+		}}, nil
+	}
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := rollingUpgradeDoc{
+		DocId:        docID,
+		ModelUUID:    a.st.ModelUUID(),
+		Application:  a.doc.Name,
+		CharmURL:     curl.String(),
+		BatchSize:    args.BatchSize,
+		BatchTimeout: args.BatchTimeout,
+		OnError:      string(args.OnError),
+		Status:       string(RollingUpgradeRunning),
+	}
+	if existing == nil {
+		return []txn.Op{{
+			C:      rollingUpgradesC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if existing.Status() != RollingUpgradeAborted {
+		return nil, errors.Errorf("rolling upgrade of %q to %q is %s", a.doc.Name, existing.CharmURL(), existing.Status())
+	}
+	return []txn.Op{{
+		C:      rollingUpgradesC,
+		Id:     docID,
+		Assert: bson.D{{"status", existing.doc.Status}},
+		Update: bson.D{
+			{"$set", bson.D{
+				{"charm-url", doc.CharmURL},
+				{"batch-size", doc.BatchSize},
+				{"batch-timeout", doc.BatchTimeout},
+				{"on-error", doc.OnError},
+				{"status", doc.Status},
+				{"batch-started", doc.BatchStarted},
+			}},
+			{"$unset", bson.D{{"message", nil}, {"released", nil}}},
+		},
+	}}, nil
+}
+
+// removeRollingUpgradeOp returns the operation to remove the
+// application's rolling upgrade, if it has one.
+func (a *Application) removeRollingUpgradeOp() txn.Op {
+	return txn.Op{
+		C:      rollingUpgradesC,
+		Id:     a.st.docID(a.doc.Name),
+		Remove: true,
+	}
+}
+
+// RollingUpgrade returns the application's rolling upgrade.
+func (a *Application) RollingUpgrade() (*RollingUpgrade, error) {
+	upgrades, closer := a.st.db().GetCollection(rollingUpgradesC)
+	defer closer()
+
+	var doc rollingUpgradeDoc
+	err := upgrades.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("rolling upgrade of %q", a.doc.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get rolling upgrade of %q", a.doc.Name)
+	}
+	return &RollingUpgrade{doc: doc}, nil
+}
+
+// WatchRollingUpgrade returns a watcher that notifies when the
+// application's rolling upgrade changes.
+func (a *Application) WatchRollingUpgrade() NotifyWatcher {
+	return newEntityWatcher(a.st, rollingUpgradesC, a.st.docID(a.doc.Name))
+}
+
+// ResumeRollingUpgrade resumes the application's paused rolling
+// upgrade. The current batch of units is given the full batch timeout
+// to become healthy.
+func (a *Application) ResumeRollingUpgrade() error {
+	return a.setRollingUpgradeStatus(
+		RollingUpgradePaused, RollingUpgradeRunning, "",
+	)
+}
+
+// AbortRollingUpgrade aborts the application's rolling upgrade. Units
+// that have not been released keep running their current charm until
+// the application's charm is next changed.
+func (a *Application) AbortRollingUpgrade() error {
+	return a.setRollingUpgradeStatus(
+		RollingUpgradeRunning, RollingUpgradeAborted, "aborted by user",
+		RollingUpgradePaused,
+	)
+}
+
+func (a *Application) setRollingUpgradeStatus(
+	from, to RollingUpgradeStatus, message string, alsoFrom ...RollingUpgradeStatus,
+) error {
+	valid := []string{string(from)}
+	for _, status := range alsoFrom {
+		valid = append(valid, string(status))
+	}
+	set := bson.D{{"status", string(to)}, {"message", message}}
+	if to == RollingUpgradeRunning {
+		set = append(set, bson.DocElem{"batch-started", a.st.clock().Now().UTC()})
+	}
+	ops := []txn.Op{{
+		C:      rollingUpgradesC,
+		Id:     a.st.docID(a.doc.Name),
+		Assert: bson.D{{"status", bson.D{{"$in", valid}}}},
+		Update: bson.D{{"$set", set}},
+	}}
+	err := a.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		upgrade, err := a.RollingUpgrade()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Errorf("rolling upgrade of %q is %s", a.doc.Name, upgrade.Status())
+	}
+	return errors.Annotatef(err, "cannot update rolling upgrade of %q", a.doc.Name)
+}
+
+// CharmURLForUnit returns the charm URL the given unit of the
+// application should run. This is the application's charm URL, unless
+// the unit has been held back by a rolling upgrade, in which case it
+// is the unit's current charm URL.
+func (a *Application) CharmURLForUnit(unit *Unit) (*charm.URL, bool, error) {
+	curl, force := a.CharmURL()
+	unitURL, ok := unit.CharmURL()
+	if !ok {
+		// Units that haven't installed a charm yet install the
+		// application's current charm.
+		return curl, force, nil
+	}
+	upgrade, err := a.RollingUpgrade()
+	if errors.IsNotFound(err) {
+		return curl, force, nil
+	} else if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if upgrade.IsReleased(unit.Name()) {
+		return curl, force, nil
+	}
+	return unitURL, false, nil
+}
+
+// AllRollingUpgrades returns the rolling upgrades of all applications
+// in the model, ordered by application name.
+func (m *Model) AllRollingUpgrades() ([]*RollingUpgrade, error) {
+	upgrades, closer := m.st.db().GetCollection(rollingUpgradesC)
+	defer closer()
+
+	var docs []rollingUpgradeDoc
+	if err := upgrades.Find(nil).Sort("application").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get rolling upgrades")
+	}
+	result := make([]*RollingUpgrade, len(docs))
+	for i, doc := range docs {
+		result[i] = &RollingUpgrade{doc: doc}
+	}
+	return result, nil
+}
+
+// ProgressRollingUpgrades checks the health of the units released by
+// each running rolling upgrade in the model. When a batch of units is
+// healthy the next batch is released, and when all units have been
+// upgraded the rolling upgrade is removed. A batch that has a unit in
+// error, or that does not become healthy within the batch timeout,
+// pauses or aborts the upgrade.
+func (m *Model) ProgressRollingUpgrades() error {
+	upgrades, err := m.AllRollingUpgrades()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// An upgrade that cannot be progressed is left as it is, and does
+	// not hold up the upgrades of other applications.
+	var failed []string
+	for _, upgrade := range upgrades {
+		if upgrade.Status() != RollingUpgradeRunning {
+			continue
+		}
+		if err := m.progressRollingUpgrade(upgrade); err != nil {
+			logger.Errorf("cannot progress rolling upgrade of %q: %v", upgrade.Application(), err)
+			failed = append(failed, upgrade.Application())
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("cannot progress rolling upgrades of %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// WatchRollingUpgrades returns a NotifyWatcher that triggers when the
+// model's rolling upgrades may need to be progressed: when a rolling
+// upgrade, or the charm or status of a unit, changes, and when the
+// latest batch of a running upgrade times out.
+func (m *Model) WatchRollingUpgrades() NotifyWatcher {
+	return newRollingUpgradesWatcher(m)
+}
+
+// rollingUpgradesWatcher implements Model.WatchRollingUpgrades.
+type rollingUpgradesWatcher struct {
+	commonWatcher
+	model *Model
+	out   chan struct{}
+}
+
+func newRollingUpgradesWatcher(m *Model) NotifyWatcher {
+	w := &rollingUpgradesWatcher{
+		commonWatcher: newCommonWatcher(m.st),
+		model:         m,
+		out:           make(chan struct{}),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Changes returns the event channel for this watcher.
+func (w *rollingUpgradesWatcher) Changes() <-chan struct{} {
+	return w.out
+}
+
+func (w *rollingUpgradesWatcher) loop() error {
+	in := make(chan watcher.Change)
+	isUnitStatus := func(id interface{}) bool {
+		key, ok := id.(string)
+		if !ok {
+			return false
+		}
+		localID, err := w.backend.strictLocalID(key)
+		return err == nil && strings.HasPrefix(localID, "u#")
+	}
+	w.watcher.WatchCollectionWithFilter(rollingUpgradesC, in, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(rollingUpgradesC, in)
+	w.watcher.WatchCollectionWithFilter(unitsC, in, isLocalID(w.backend))
+	defer w.watcher.UnwatchCollection(unitsC, in)
+	w.watcher.WatchCollectionWithFilter(statusesC, in, isUnitStatus)
+	defer w.watcher.UnwatchCollection(statusesC, in)
+
+	timeout, err := w.batchTimeout()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := w.out // out set so that initial event is sent.
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-w.watcher.Dead():
+			return stateWatcherDeadError(w.watcher.Err())
+		case change := <-in:
+			if _, ok := collect(change, in, w.tomb.Dying()); !ok {
+				return tomb.ErrDying
+			}
+			if timeout, err = w.batchTimeout(); err != nil {
+				return errors.Trace(err)
+			}
+			out = w.out
+		case <-timeout:
+			timeout = nil
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
+
+// batchTimeout returns a channel that receives when the earliest batch
+// of units released by a running rolling upgrade times out, or nil if
+// no running upgrade has released any units.
+func (w *rollingUpgradesWatcher) batchTimeout() (<-chan time.Time, error) {
+	upgrades, err := w.model.AllRollingUpgrades()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var deadline time.Time
+	for _, upgrade := range upgrades {
+		if upgrade.Status() != RollingUpgradeRunning || len(upgrade.Released()) == 0 {
+			continue
+		}
+		batchDeadline := upgrade.BatchStarted().Add(upgrade.BatchTimeout())
+		if deadline.IsZero() || batchDeadline.Before(deadline) {
+			deadline = batchDeadline
+		}
+	}
+	if deadline.IsZero() {
+		return nil, nil
+	}
+	clock := w.model.st.clock()
+	return clock.After(deadline.Sub(clock.Now())), nil
+}
+
+func (m *Model) progressRollingUpgrade(upgrade *RollingUpgrade) error {
+	app, err := m.st.Application(upgrade.Application())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return units[i].Name() < units[j].Name()
+	})
+
+	var unreleased, pending []string
+	for _, unit := range units {
+		unitURL, _ := unit.CharmURL()
+		upgraded := unitURL != nil && unitURL.String() == upgrade.CharmURL()
+		if !upgrade.IsReleased(unit.Name()) && !upgraded {
+			unreleased = append(unreleased, unit.Name())
+			continue
+		}
+		healthy, err := rollingUpgradeUnitHealthy(unit, upgraded)
+		if err != nil {
+			return m.failRollingUpgrade(upgrade, err.Error())
+		}
+		if !healthy {
+			pending = append(pending, unit.Name())
+		}
+	}
+
+	now := m.st.clock().Now().UTC()
+	if len(pending) > 0 {
+		if now.Before(upgrade.BatchStarted().Add(upgrade.BatchTimeout())) {
+			return nil
+		}
+		return m.failRollingUpgrade(upgrade, "timed out waiting for "+strings.Join(pending, ", "))
+	}
+
+	ops := []txn.Op{{
+		C:      rollingUpgradesC,
+		Id:     upgrade.doc.DocId,
+		Assert: bson.D{{"status", string(RollingUpgradeRunning)}},
+	}}
+	if len(unreleased) == 0 {
+		logger.Infof("rolling upgrade of %q to %q completed", upgrade.Application(), upgrade.CharmURL())
+		ops[0].Remove = true
+	} else {
+		if len(unreleased) > upgrade.BatchSize() {
+			unreleased = unreleased[:upgrade.BatchSize()]
+		}
+		logger.Infof("rolling upgrade of %q releasing %v", upgrade.Application(), unreleased)
+		ops[0].Update = bson.D{
+			{"$push", bson.D{{"released", bson.D{{"$each", unreleased}}}}},
+			{"$set", bson.D{{"batch-started", now}}},
+		}
+	}
+	if err := m.st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// rollingUpgradeUnitHealthy returns whether a unit released by a rolling
+// upgrade has upgraded and settled, or an error if the unit is in error.
+func rollingUpgradeUnitHealthy(unit *Unit, upgraded bool) (bool, error) {
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if agentStatus.Status == status.Error {
+		return false, errors.Errorf("%s is in error: %s", unit.Name(), agentStatus.Message)
+	}
+	workloadStatus, err := unit.Status()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if workloadStatus.Status == status.Error {
+		return false, errors.Errorf("%s is in error: %s", unit.Name(), workloadStatus.Message)
+	}
+	return upgraded && agentStatus.Status == status.Idle && workloadStatus.Status == status.Active, nil
+}
+
+func (m *Model) failRollingUpgrade(upgrade *RollingUpgrade, message string) error {
+	to := RollingUpgradePaused
+	if upgrade.OnError() == RollingUpgradeAbort {
+		to = RollingUpgradeAborted
+	}
+	logger.Warningf("rolling upgrade of %q %s: %s", upgrade.Application(), to, message)
+	ops := []txn.Op{{
+		C:      rollingUpgradesC,
+		Id:     upgrade.doc.DocId,
+		Assert: bson.D{{"status", string(RollingUpgradeRunning)}},
+		Update: bson.D{{"$set", bson.D{
+			{"status", string(to)},
+			{"message", message},
+		}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	return nil
+}
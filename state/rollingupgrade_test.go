@@ -0,0 +1,310 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type RollingUpgradeSuite struct {
+	ConnSuite
+	oldCharm    *state.Charm
+	newCharm    *state.Charm
+	application *state.Application
+	units       []*state.Unit
+	model       *state.Model
+}
+
+var _ = gc.Suite(&RollingUpgradeSuite{})
+
+func (s *RollingUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.oldCharm = s.AddConfigCharm(c, "mysql", stringConfig, 1)
+	s.newCharm = s.AddConfigCharm(c, "mysql", stringConfig, 2)
+	s.application = s.AddTestingApplication(c, "mysql", s.oldCharm)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.SetCharmURL(s.oldCharm.URL())
+		c.Assert(err, jc.ErrorIsNil)
+		s.units = append(s.units, unit)
+	}
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RollingUpgradeSuite) setCharm(c *gc.C, onError state.RollingUpgradeOnError) {
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm: s.newCharm,
+		RollingUpgrade: &state.RollingUpgradeArgs{
+			BatchSize:    2,
+			BatchTimeout: 10 * time.Minute,
+			OnError:      onError,
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RollingUpgradeSuite) upgradeUnit(c *gc.C, unit *state.Unit, workload status.Status) {
+	now := s.Clock.Now()
+	message := ""
+	if workload == status.Error {
+		message = "bad"
+	}
+	err := unit.SetCharmURL(s.newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: workload, Message: message, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RollingUpgradeSuite) rollingUpgrade(c *gc.C) *state.RollingUpgrade {
+	upgrade, err := s.application.RollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	return upgrade
+}
+
+func (s *RollingUpgradeSuite) assertUnitCharm(c *gc.C, unit *state.Unit, expected *state.Charm) {
+	curl, _, err := s.application.CharmURLForUnit(unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(curl, jc.DeepEquals, expected.URL())
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmRolling(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+
+	upgrade := s.rollingUpgrade(c)
+	c.Check(upgrade.Application(), gc.Equals, "mysql")
+	c.Check(upgrade.CharmURL(), gc.Equals, s.newCharm.URL().String())
+	c.Check(upgrade.BatchSize(), gc.Equals, 2)
+	c.Check(upgrade.BatchTimeout(), gc.Equals, 10*time.Minute)
+	c.Check(upgrade.OnError(), gc.Equals, state.RollingUpgradePause)
+	c.Check(upgrade.Status(), gc.Equals, state.RollingUpgradeRunning)
+	c.Check(upgrade.Released(), gc.HasLen, 0)
+
+	for _, unit := range s.units {
+		s.assertUnitCharm(c, unit, s.oldCharm)
+	}
+	// New units start with the application's charm.
+	unit, err := s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitCharm(c, unit, s.newCharm)
+
+	upgrades, err := s.model.AllRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(upgrades, gc.HasLen, 1)
+	c.Check(upgrades[0].Application(), gc.Equals, "mysql")
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmRollingInvalid(c *gc.C) {
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCharm,
+		RollingUpgrade: &state.RollingUpgradeArgs{BatchTimeout: time.Minute, OnError: state.RollingUpgradePause},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-2": batch size 0 not valid`)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmRollingInProgress(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	err := s.application.SetCharm(state.SetCharmConfig{
+		Charm:          s.newCharm,
+		RollingUpgrade: &state.RollingUpgradeArgs{BatchSize: 1, BatchTimeout: time.Minute, OnError: state.RollingUpgradePause},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-2": rolling upgrade of "mysql" to "local:quantal/quantal-mysql-2" is running`)
+}
+
+func (s *RollingUpgradeSuite) TestSetCharmNotRollingRemovesUpgrade(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	err := s.application.SetCharm(state.SetCharmConfig{Charm: s.oldCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.RollingUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RollingUpgradeSuite) TestProgressReleasesBatches(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+
+	err := s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade := s.rollingUpgrade(c)
+	c.Check(upgrade.Released(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+	s.assertUnitCharm(c, s.units[0], s.newCharm)
+	s.assertUnitCharm(c, s.units[1], s.newCharm)
+	s.assertUnitCharm(c, s.units[2], s.oldCharm)
+
+	// The next batch waits for the first to become healthy.
+	s.upgradeUnit(c, s.units[0], status.Active)
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.rollingUpgrade(c).Released(), gc.HasLen, 2)
+
+	s.upgradeUnit(c, s.units[1], status.Active)
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.rollingUpgrade(c).Released(), jc.DeepEquals, []string{"mysql/0", "mysql/1", "mysql/2"})
+	s.assertUnitCharm(c, s.units[2], s.newCharm)
+
+	s.upgradeUnit(c, s.units[2], status.Active)
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.RollingUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RollingUpgradeSuite) TestProgressErrorPauses(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	err := s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.upgradeUnit(c, s.units[0], status.Error)
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade := s.rollingUpgrade(c)
+	c.Check(upgrade.Status(), gc.Equals, state.RollingUpgradePaused)
+	c.Check(upgrade.Message(), gc.Equals, "mysql/0 is in error: bad")
+
+	// Paused upgrades release no more units.
+	s.upgradeUnit(c, s.units[0], status.Active)
+	s.upgradeUnit(c, s.units[1], status.Active)
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.rollingUpgrade(c).Released(), gc.HasLen, 2)
+
+	err = s.application.ResumeRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade = s.rollingUpgrade(c)
+	c.Check(upgrade.Status(), gc.Equals, state.RollingUpgradeRunning)
+	c.Check(upgrade.Message(), gc.Equals, "")
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.rollingUpgrade(c).Released(), gc.HasLen, 3)
+}
+
+func (s *RollingUpgradeSuite) TestProgressTimeoutAborts(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradeAbort)
+	err := s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	s.upgradeUnit(c, s.units[0], status.Active)
+
+	s.Clock.Advance(11 * time.Minute)
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade := s.rollingUpgrade(c)
+	c.Check(upgrade.Status(), gc.Equals, state.RollingUpgradeAborted)
+	c.Check(upgrade.Message(), gc.Equals, "timed out waiting for mysql/1")
+	s.assertUnitCharm(c, s.units[2], s.oldCharm)
+}
+
+func (s *RollingUpgradeSuite) TestProgressContinuesAfterError(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	// The upgrade of an application that no longer exists cannot be
+	// progressed, but doesn't hold up other upgrades.
+	upgrades := s.State.MongoSession().DB("juju").C(state.RollingUpgradesC)
+	err := upgrades.Insert(bson.M{
+		"_id":           s.State.ModelUUID() + ":ghost",
+		"model-uuid":    s.State.ModelUUID(),
+		"application":   "ghost",
+		"charm-url":     "cs:quantal/ghost-1",
+		"batch-size":    1,
+		"batch-timeout": int64(time.Minute),
+		"on-error":      string(state.RollingUpgradePause),
+		"status":        string(state.RollingUpgradeRunning),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.model.ProgressRollingUpgrades()
+	c.Assert(err, gc.ErrorMatches, "cannot progress rolling upgrades of ghost")
+	c.Check(s.rollingUpgrade(c).Released(), jc.DeepEquals, []string{"mysql/0", "mysql/1"})
+}
+
+func (s *RollingUpgradeSuite) TestWatchRollingUpgrades(c *gc.C) {
+	w := s.model.WatchRollingUpgrades()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.setCharm(c, state.RollingUpgradePause)
+	wc.AssertOneChange()
+	err := s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes to the units' statuses may make a batch healthy.
+	now := s.Clock.Now()
+	err = s.units[0].SetAgentStatus(status.StatusInfo{Status: status.Idle, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// The batch timing out may pause or abort the upgrade.
+	err = s.Clock.WaitAdvance(10*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *RollingUpgradeSuite) TestResumeNotPaused(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	err := s.application.ResumeRollingUpgrade()
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "mysql" is running`)
+}
+
+func (s *RollingUpgradeSuite) TestAbort(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	err := s.application.AbortRollingUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade := s.rollingUpgrade(c)
+	c.Check(upgrade.Status(), gc.Equals, state.RollingUpgradeAborted)
+	c.Check(upgrade.Message(), gc.Equals, "aborted by user")
+
+	err = s.application.AbortRollingUpgrade()
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "mysql" is aborted`)
+
+	// An aborted upgrade can be replaced by a new one.
+	s.setCharm(c, state.RollingUpgradePause)
+	c.Check(s.rollingUpgrade(c).Status(), gc.Equals, state.RollingUpgradeRunning)
+}
+
+func (s *RollingUpgradeSuite) TestAbortNotFound(c *gc.C) {
+	err := s.application.AbortRollingUpgrade()
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "mysql" not found`)
+}
+
+func (s *RollingUpgradeSuite) TestWatchRollingUpgrade(c *gc.C) {
+	w := s.application.WatchRollingUpgrade()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.setCharm(c, state.RollingUpgradePause)
+	wc.AssertOneChange()
+
+	err := s.model.ProgressRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *RollingUpgradeSuite) TestRemoveApplicationRemovesUpgrade(c *gc.C) {
+	s.setCharm(c, state.RollingUpgradePause)
+	for _, unit := range s.units {
+		err := unit.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrades, err := s.model.AllRollingUpgrades()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrades, gc.HasLen, 0)
+}
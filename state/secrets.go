@@ -0,0 +1,587 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+var validSecretName = regexp.MustCompile("^[a-z][a-z0-9]*(-[a-z0-9]+)*$")
+
+// IsValidSecretName returns whether name is a valid secret name.
+func IsValidSecretName(name string) bool {
+	return validSecretName.MatchString(name)
+}
+
+// secretDoc records the metadata of a secret. The secret's values are
+// held in a separate document for each revision.
+type secretDoc struct {
+	DocId       string `bson:"_id"`
+	ModelUUID   string `bson:"model-uuid"`
+	Name        string `bson:"name"`
+	Description string `bson:"description,omitempty"`
+
+	// Owner holds the name of the application that owns the secret,
+	// or is empty if the secret was added by a user.
+	Owner string `bson:"owner,omitempty"`
+
+	// Revision is the latest revision of the secret's values.
+	Revision int `bson:"revision"`
+
+	// RotateInterval holds how often the owner is asked to rotate the
+	// secret, and NextRotateTime when it is next asked to. They are
+	// zero if the secret isn't rotated.
+	RotateInterval time.Duration `bson:"rotate-interval,omitempty"`
+	NextRotateTime time.Time     `bson:"next-rotate-time,omitempty"`
+
+	// Grants holds the names of the applications, other than the
+	// owner, that may read the secret.
+	Grants []string `bson:"grants,omitempty"`
+
+	Created time.Time `bson:"created"`
+	Updated time.Time `bson:"updated"`
+}
+
+// secretRevisionDoc holds the values of a revision of a secret. Keys
+// are escaped for storage in mongo.
+type secretRevisionDoc struct {
+	DocId     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	Name      string            `bson:"name"`
+	Revision  int               `bson:"revision"`
+	Data      map[string]string `bson:"data"`
+	Created   time.Time         `bson:"created"`
+}
+
+func secretRevisionId(name string, revision int) string {
+	return fmt.Sprintf("%s#%d", name, revision)
+}
+
+// Secret represents a named set of sensitive key/value pairs, which
+// applications may be granted access to.
+type Secret struct {
+	doc secretDoc
+}
+
+// SecretRevision describes a revision of a secret's values.
+type SecretRevision struct {
+	Revision int
+	Created  time.Time
+}
+
+// Name returns the name of the secret, unique within the model.
+func (s *Secret) Name() string {
+	return s.doc.Name
+}
+
+// Description returns the description of the secret.
+func (s *Secret) Description() string {
+	return s.doc.Description
+}
+
+// Owner returns the name of the application that owns the secret, or
+// an empty string if the secret was added by a user.
+func (s *Secret) Owner() string {
+	return s.doc.Owner
+}
+
+// Revision returns the latest revision of the secret's values.
+func (s *Secret) Revision() int {
+	return s.doc.Revision
+}
+
+// RotateInterval returns how often the owner of the secret is asked
+// to rotate it, or zero if it isn't.
+func (s *Secret) RotateInterval() time.Duration {
+	return s.doc.RotateInterval
+}
+
+// NextRotateTime returns when the owner of the secret is next asked to
+// rotate it. It is the zero time if the secret isn't rotated.
+func (s *Secret) NextRotateTime() time.Time {
+	return s.doc.NextRotateTime
+}
+
+// Grants returns the names of the applications, other than the owner,
+// that may read the secret.
+func (s *Secret) Grants() []string {
+	return s.doc.Grants
+}
+
+// Created returns when the secret was added.
+func (s *Secret) Created() time.Time {
+	return s.doc.Created
+}
+
+// Updated returns when the secret was last changed.
+func (s *Secret) Updated() time.Time {
+	return s.doc.Updated
+}
+
+// CanRead returns whether the named application may read the secret.
+func (s *Secret) CanRead(appName string) bool {
+	if appName == s.doc.Owner {
+		return true
+	}
+	for _, grant := range s.doc.Grants {
+		if grant == appName {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSecretData(data map[string]string) error {
+	if len(data) == 0 {
+		return errors.NotValidf("empty secret")
+	}
+	for key := range data {
+		if key == "" {
+			return errors.NotValidf("empty secret key")
+		}
+	}
+	return nil
+}
+
+// AddSecretArgs holds the arguments for adding a secret.
+type AddSecretArgs struct {
+	// Name is the name of the secret, unique within the model.
+	Name        string
+	Description string
+
+	// Owner is the name of the application that owns the secret, or
+	// empty if the secret is added by a user.
+	Owner string
+
+	// Data holds the secret's key/value pairs.
+	Data map[string]string
+
+	// RotateInterval is how often the owner is asked to rotate the
+	// secret. It may only be set for secrets owned by an application.
+	RotateInterval time.Duration
+}
+
+// Validate checks that the arguments are valid.
+func (args AddSecretArgs) Validate() error {
+	if !IsValidSecretName(args.Name) {
+		return errors.NotValidf("secret name %q", args.Name)
+	}
+	if args.Owner != "" && !names.IsValidApplication(args.Owner) {
+		return errors.NotValidf("application name %q", args.Owner)
+	}
+	if args.RotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	if args.RotateInterval > 0 && args.Owner == "" {
+		return errors.NotValidf("rotate interval for secret with no owner")
+	}
+	return errors.Trace(validateSecretData(args.Data))
+}
+
+// AddSecret adds a secret to the model, with the given values as its
+// first revision.
+func (m *Model) AddSecret(args AddSecretArgs) (*Secret, error) {
+	if err := args.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	now := m.st.clock().Now().UTC().Round(time.Second)
+	doc := secretDoc{
+		DocId:          m.st.docID(args.Name),
+		ModelUUID:      m.st.ModelUUID(),
+		Name:           args.Name,
+		Description:    args.Description,
+		Owner:          args.Owner,
+		Revision:       1,
+		RotateInterval: args.RotateInterval,
+		Created:        now,
+		Updated:        now,
+	}
+	if args.RotateInterval > 0 {
+		doc.NextRotateTime = now.Add(args.RotateInterval)
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, m.addSecretRevisionOp(args.Name, 1, args.Data, now)}
+	if args.Owner != "" {
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     m.st.docID(args.Owner),
+			Assert: isAliveDoc,
+		})
+	}
+	if err := m.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if args.Owner != "" {
+			if _, err := m.st.Application(args.Owner); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		return nil, errors.AlreadyExistsf("secret %q", args.Name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot add secret %q", args.Name)
+	}
+	return &Secret{doc: doc}, nil
+}
+
+func (m *Model) addSecretRevisionOp(name string, revision int, data map[string]string, now time.Time) txn.Op {
+	escaped := make(map[string]string, len(data))
+	for key, value := range data {
+		escaped[escapeReplacer.Replace(key)] = value
+	}
+	return txn.Op{
+		C:      secretRevisionsC,
+		Id:     m.st.docID(secretRevisionId(name, revision)),
+		Assert: txn.DocMissing,
+		Insert: &secretRevisionDoc{
+			Name:     name,
+			Revision: revision,
+			Data:     escaped,
+			Created:  now,
+		},
+	}
+}
+
+// Secret returns the secret with the given name.
+func (m *Model) Secret(name string) (*Secret, error) {
+	secrets, closer := m.st.db().GetCollection(secretsC)
+	defer closer()
+
+	var doc secretDoc
+	err := secrets.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q", name)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", name)
+	}
+	return &Secret{doc: doc}, nil
+}
+
+// AllSecrets returns all of the secrets in the model, ordered by name.
+func (m *Model) AllSecrets() ([]*Secret, error) {
+	return m.findSecrets(nil)
+}
+
+// ApplicationSecrets returns the secrets owned by the named
+// application, ordered by name.
+func (m *Model) ApplicationSecrets(appName string) ([]*Secret, error) {
+	return m.findSecrets(bson.D{{"owner", appName}})
+}
+
+func (m *Model) findSecrets(query bson.D) ([]*Secret, error) {
+	secrets, closer := m.st.db().GetCollection(secretsC)
+	defer closer()
+
+	var docs []secretDoc
+	if err := secrets.Find(query).Sort("name").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get secrets")
+	}
+	result := make([]*Secret, len(docs))
+	for i, doc := range docs {
+		result[i] = &Secret{doc: doc}
+	}
+	return result, nil
+}
+
+// SecretValue returns the values of the given revision of the named
+// secret. If revision is zero, the latest revision is returned.
+func (m *Model) SecretValue(name string, revision int) (map[string]string, error) {
+	if revision == 0 {
+		secret, err := m.Secret(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revision = secret.Revision()
+	}
+	revisions, closer := m.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var doc secretRevisionDoc
+	err := revisions.FindId(secretRevisionId(name, revision)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("secret %q revision %d", name, revision)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get secret %q", name)
+	}
+	data := make(map[string]string, len(doc.Data))
+	for key, value := range doc.Data {
+		data[unescapeReplacer.Replace(key)] = value
+	}
+	return data, nil
+}
+
+// SecretRevisions returns the revisions of the named secret, oldest
+// first.
+func (m *Model) SecretRevisions(name string) ([]SecretRevision, error) {
+	if _, err := m.Secret(name); err != nil {
+		return nil, errors.Trace(err)
+	}
+	revisions, closer := m.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var docs []secretRevisionDoc
+	err := revisions.Find(bson.D{{"name", name}}).Select(bson.D{
+		{"revision", 1}, {"created", 1},
+	}).Sort("revision").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get revisions of secret %q", name)
+	}
+	result := make([]SecretRevision, len(docs))
+	for i, doc := range docs {
+		result[i] = SecretRevision{
+			Revision: doc.Revision,
+			Created:  doc.Created,
+		}
+	}
+	return result, nil
+}
+
+// UpdateSecretArgs holds the changes to make to a secret. Nil fields
+// are left unchanged.
+type UpdateSecretArgs struct {
+	Description *string
+
+	// Data, if set, holds the values of a new revision of the secret.
+	Data map[string]string
+
+	// RotateInterval, if set, changes how often the owner is asked to
+	// rotate the secret.
+	RotateInterval *time.Duration
+}
+
+// UpdateSecret changes the named secret. Setting new values adds a
+// revision to the secret, and restarts its rotation interval.
+func (m *Model) UpdateSecret(name string, args UpdateSecretArgs) error {
+	if args.Data != nil {
+		if err := validateSecretData(args.Data); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if args.RotateInterval != nil && *args.RotateInterval < 0 {
+		return errors.NotValidf("negative rotate interval")
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		secret, err := m.Secret(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		now := m.st.clock().Now().UTC().Round(time.Second)
+		set := bson.D{{"updated", now}}
+		if args.Description != nil && *args.Description != secret.doc.Description {
+			set = append(set, bson.DocElem{"description", *args.Description})
+		}
+		interval := secret.doc.RotateInterval
+		if args.RotateInterval != nil && *args.RotateInterval != interval {
+			interval = *args.RotateInterval
+			if interval > 0 && secret.doc.Owner == "" {
+				return nil, errors.NotValidf("rotate interval for secret with no owner")
+			}
+			set = append(set, bson.DocElem{"rotate-interval", interval})
+		}
+		revision := secret.doc.Revision
+		if args.Data != nil {
+			revision++
+			set = append(set, bson.DocElem{"revision", revision})
+		}
+		if len(set) == 1 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if args.Data != nil || interval != secret.doc.RotateInterval {
+			var next time.Time
+			if interval > 0 {
+				next = now.Add(interval)
+			}
+			set = append(set, bson.DocElem{"next-rotate-time", next})
+		}
+		ops := []txn.Op{{
+			C:      secretsC,
+			Id:     secret.doc.DocId,
+			Assert: bson.D{{"revision", secret.doc.Revision}},
+			Update: bson.D{{"$set", set}},
+		}}
+		if args.Data != nil {
+			ops = append(ops, m.addSecretRevisionOp(name, revision, args.Data, now))
+		}
+		return ops, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot update secret %q", name)
+}
+
+// GrantSecret allows the named application to read the secret.
+func (m *Model) GrantSecret(name, appName string) error {
+	if !names.IsValidApplication(appName) {
+		return errors.NotValidf("application name %q", appName)
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		secret, err := m.Secret(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if secret.CanRead(appName) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		app, err := m.st.Application(appName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app.Life() != Alive {
+			return nil, errors.Errorf("application %q is not alive", appName)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      secretsC,
+			Id:     secret.doc.DocId,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$addToSet", bson.D{{"grants", appName}}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot grant secret %q to %q", name, appName)
+}
+
+// RevokeSecret stops the named application from reading the secret.
+// The secret's owner can't be revoked.
+func (m *Model) RevokeSecret(name, appName string) error {
+	if !names.IsValidApplication(appName) {
+		return errors.NotValidf("application name %q", appName)
+	}
+	secret, err := m.Secret(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if appName == secret.doc.Owner {
+		return errors.Errorf("cannot revoke secret %q from its owner", name)
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     secret.doc.DocId,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$pull", bson.D{{"grants", appName}}}},
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Annotatef(err, "cannot revoke secret %q from %q", name, appName)
+	}
+	return nil
+}
+
+// SecretRotated records that the owner of the named secret has been
+// asked to rotate it, and schedules the next rotation.
+func (m *Model) SecretRotated(name string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		secret, err := m.Secret(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if secret.doc.RotateInterval == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		now := m.st.clock().Now().UTC().Round(time.Second)
+		if secret.doc.NextRotateTime.After(now) {
+			// The secret was updated since the rotation was due.
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      secretsC,
+			Id:     secret.doc.DocId,
+			Assert: bson.D{{"next-rotate-time", secret.doc.NextRotateTime}},
+			Update: bson.D{{"$set", bson.D{
+				{"next-rotate-time", now.Add(secret.doc.RotateInterval)},
+			}}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot record rotation of secret %q", name)
+}
+
+// RemoveSecret removes the named secret and all of its revisions.
+func (m *Model) RemoveSecret(name string) error {
+	buildTxn := func(int) ([]txn.Op, error) {
+		secret, err := m.Secret(name)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return m.removeSecretOps(secret)
+	}
+	err := m.st.db().Run(buildTxn)
+	if errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(err, "cannot remove secret %q", name)
+}
+
+// removeSecretOps returns the operations needed to remove the secret
+// and its revisions. They assert that no revision has been added since
+// the secret was read, so that none is left behind.
+func (m *Model) removeSecretOps(secret *Secret) ([]txn.Op, error) {
+	revisions, closer := m.st.db().GetCollection(secretRevisionsC)
+	defer closer()
+
+	var docs []secretRevisionDoc
+	err := revisions.Find(bson.D{{"name", secret.doc.Name}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get revisions of secret %q", secret.doc.Name)
+	}
+	ops := []txn.Op{{
+		C:      secretsC,
+		Id:     secret.doc.DocId,
+		Assert: bson.D{{"revision", secret.doc.Revision}},
+		Remove: true,
+	}}
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      secretRevisionsC,
+			Id:     doc.DocId,
+			Remove: true,
+		})
+	}
+	return ops, nil
+}
+
+// WatchSecrets returns a NotifyWatcher that triggers whenever a
+// secret in the model is changed.
+func (m *Model) WatchSecrets() NotifyWatcher {
+	return newNotifyCollWatcher(m.st, secretsC, isLocalID(m.st))
+}
+
+// cleanupApplicationSecrets removes the secrets owned by a removed
+// application, and revokes its access to other secrets.
+func (st *State) cleanupApplicationSecrets(appName string) error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	owned, err := model.ApplicationSecrets(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, secret := range owned {
+		if err := model.RemoveSecret(secret.Name()); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	granted, err := model.findSecrets(bson.D{{"grants", appName}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, secret := range granted {
+		if err := model.RevokeSecret(secret.Name(), appName); err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
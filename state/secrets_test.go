@@ -0,0 +1,307 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type SecretsSuite struct {
+	ConnSuite
+	application *state.Application
+	model       *state.Model
+}
+
+var _ = gc.Suite(&SecretsSuite{})
+
+func (s *SecretsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	var err error
+	s.model, err = s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecretsSuite) addSecret(c *gc.C, name, owner string) *state.Secret {
+	secret, err := s.model.AddSecret(state.AddSecretArgs{
+		Name:  name,
+		Owner: owner,
+		Data:  map[string]string{"password": "secret"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return secret
+}
+
+func (s *SecretsSuite) TestAddSecret(c *gc.C) {
+	now := s.Clock.Now().UTC().Round(time.Second)
+	added, err := s.model.AddSecret(state.AddSecretArgs{
+		Name:           "db-password",
+		Description:    "the database password",
+		Owner:          "wordpress",
+		Data:           map[string]string{"password": "secret", "user.name": "admin"},
+		RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(added.Name(), gc.Equals, "db-password")
+	c.Check(added.Description(), gc.Equals, "the database password")
+	c.Check(added.Owner(), gc.Equals, "wordpress")
+	c.Check(added.Revision(), gc.Equals, 1)
+	c.Check(added.RotateInterval(), gc.Equals, time.Hour)
+	c.Check(added.NextRotateTime(), gc.Equals, now.Add(time.Hour))
+	c.Check(added.Created(), gc.Equals, now)
+
+	secret, err := s.model.Secret("db-password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret, jc.DeepEquals, added)
+
+	value, err := s.model.SecretValue("db-password", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "secret", "user.name": "admin"})
+}
+
+func (s *SecretsSuite) TestAddSecretInvalid(c *gc.C) {
+	for _, test := range []struct {
+		args state.AddSecretArgs
+		err  string
+	}{{
+		args: state.AddSecretArgs{Name: "Bad_Name", Data: map[string]string{"a": "b"}},
+		err:  `secret name "Bad_Name" not valid`,
+	}, {
+		args: state.AddSecretArgs{Name: "password"},
+		err:  `empty secret not valid`,
+	}, {
+		args: state.AddSecretArgs{Name: "password", Data: map[string]string{"": "b"}},
+		err:  `empty secret key not valid`,
+	}, {
+		args: state.AddSecretArgs{Name: "password", Data: map[string]string{"a": "b"}, RotateInterval: time.Hour},
+		err:  `rotate interval for secret with no owner not valid`,
+	}} {
+		_, err := s.model.AddSecret(test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *SecretsSuite) TestAddSecretAlreadyExists(c *gc.C) {
+	s.addSecret(c, "password", "")
+	_, err := s.model.AddSecret(state.AddSecretArgs{
+		Name: "password",
+		Data: map[string]string{"a": "b"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *SecretsSuite) TestAddSecretMissingOwner(c *gc.C) {
+	_, err := s.model.AddSecret(state.AddSecretArgs{
+		Name:  "password",
+		Owner: "missing",
+		Data:  map[string]string{"a": "b"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestSecretNotFound(c *gc.C) {
+	_, err := s.model.Secret("missing")
+	c.Assert(err, gc.ErrorMatches, `secret "missing" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestAllSecrets(c *gc.C) {
+	s.addSecret(c, "zebra", "")
+	s.addSecret(c, "apple", "wordpress")
+	secrets, err := s.model.AllSecrets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(secrets, gc.HasLen, 2)
+	c.Check(secrets[0].Name(), gc.Equals, "apple")
+	c.Check(secrets[1].Name(), gc.Equals, "zebra")
+
+	owned, err := s.model.ApplicationSecrets("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(owned, gc.HasLen, 1)
+	c.Check(owned[0].Name(), gc.Equals, "apple")
+}
+
+func (s *SecretsSuite) TestUpdateSecretAddsRevision(c *gc.C) {
+	s.addSecret(c, "password", "wordpress")
+	err := s.model.UpdateSecret("password", state.UpdateSecretArgs{
+		Data: map[string]string{"password": "changed"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Revision(), gc.Equals, 2)
+
+	value, err := s.model.SecretValue("password", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "changed"})
+	value, err = s.model.SecretValue("password", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, jc.DeepEquals, map[string]string{"password": "secret"})
+	_, err = s.model.SecretValue("password", 3)
+	c.Check(err, gc.ErrorMatches, `secret "password" revision 3 not found`)
+
+	revisions, err := s.model.SecretRevisions("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Check(revisions[0].Revision, gc.Equals, 1)
+	c.Check(revisions[1].Revision, gc.Equals, 2)
+}
+
+func (s *SecretsSuite) TestUpdateSecretDescription(c *gc.C) {
+	s.addSecret(c, "password", "")
+	description := "new description"
+	err := s.model.UpdateSecret("password", state.UpdateSecretArgs{Description: &description})
+	c.Assert(err, jc.ErrorIsNil)
+
+	secret, err := s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Description(), gc.Equals, "new description")
+	c.Check(secret.Revision(), gc.Equals, 1)
+}
+
+func (s *SecretsSuite) TestUpdateSecretNotFound(c *gc.C) {
+	err := s.model.UpdateSecret("missing", state.UpdateSecretArgs{
+		Data: map[string]string{"a": "b"},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot update secret "missing": secret "missing" not found`)
+}
+
+func (s *SecretsSuite) TestGrantRevokeSecret(c *gc.C) {
+	s.addSecret(c, "password", "wordpress")
+	secret, err := s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead("wordpress"), jc.IsTrue)
+	c.Check(secret.CanRead("mysql"), jc.IsFalse)
+
+	err = s.model.GrantSecret("password", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.Grants(), jc.DeepEquals, []string{"mysql"})
+	c.Check(secret.CanRead("mysql"), jc.IsTrue)
+
+	err = s.model.RevokeSecret("password", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.CanRead("mysql"), jc.IsFalse)
+
+	err = s.model.RevokeSecret("password", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `cannot revoke secret "password" from its owner`)
+
+	err = s.model.RevokeSecret("password", "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *SecretsSuite) TestGrantSecretMissingApplication(c *gc.C) {
+	s.addSecret(c, "password", "")
+	err := s.model.GrantSecret("password", "missing")
+	c.Assert(err, gc.ErrorMatches, `cannot grant secret "password" to "missing": application "missing" not found`)
+}
+
+func (s *SecretsSuite) TestSecretRotated(c *gc.C) {
+	_, err := s.model.AddSecret(state.AddSecretArgs{
+		Name:           "password",
+		Owner:          "wordpress",
+		Data:           map[string]string{"a": "b"},
+		RotateInterval: time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Rotation isn't recorded before it is due.
+	err = s.model.SecretRotated("password")
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err := s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	due := secret.NextRotateTime()
+
+	s.Clock.Advance(2 * time.Hour)
+	err = s.model.SecretRotated("password")
+	c.Assert(err, jc.ErrorIsNil)
+	secret, err = s.model.Secret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(secret.NextRotateTime(), gc.Equals, due.Add(2*time.Hour))
+}
+
+func (s *SecretsSuite) TestRemoveSecret(c *gc.C) {
+	s.addSecret(c, "password", "")
+	err := s.model.RemoveSecret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.Secret("password")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.model.SecretValue("password", 1)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.model.RemoveSecret("password")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *SecretsSuite) TestRemoveSecretConcurrentUpdate(c *gc.C) {
+	s.addSecret(c, "password", "")
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.model.UpdateSecret("password", state.UpdateSecretArgs{
+			Data: map[string]string{"password": "changed"},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.model.RemoveSecret("password")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.model.SecretValue("password", 2)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+
+	// No revision is left behind to stop the secret being added again.
+	s.addSecret(c, "password", "")
+}
+
+func (s *SecretsSuite) TestSecretsCleanedUpWithApplication(c *gc.C) {
+	s.addSecret(c, "owned", "wordpress")
+	s.addSecret(c, "shared", "mysql")
+	err := s.model.GrantSecret("shared", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.model.Secret("owned")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	shared, err := s.model.Secret("shared")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(shared.Grants(), gc.HasLen, 0)
+}
+
+func (s *SecretsSuite) TestWatchSecrets(c *gc.C) {
+	w := s.model.WatchSecrets()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.addSecret(c, "password", "")
+	wc.AssertOneChange()
+
+	err := s.model.GrantSecret("password", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *SecretsSuite) TestSecretsNotDumped(c *gc.C) {
+	s.addSecret(c, "password", "")
+	dump, err := s.State.DumpAll()
+	c.Assert(err, jc.ErrorIsNil)
+	_, found := dump["secrets"]
+	c.Check(found, jc.IsFalse)
+	_, found = dump["secretRevisions"]
+	c.Check(found, jc.IsFalse)
+}
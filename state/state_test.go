@@ -4461,6 +4461,27 @@ func (s *StateSuite) TestRunTransactionObserver(c *gc.C) {
 	c.Assert(found, jc.IsTrue)
 }
 
+func (s *StateSuite) TestPendingTransactionCount(c *gc.C) {
+	count, err := s.State.PendingTransactionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 0)
+
+	// Fake up a prepared transaction, and an applied one that
+	// shouldn't be counted.
+	txns := s.State.MongoSession().DB("juju").C("txns")
+	pendingId, appliedId := bson.NewObjectId(), bson.NewObjectId()
+	err = txns.Insert(bson.M{"_id": pendingId, "s": 2}, bson.M{"_id": appliedId, "s": 6})
+	c.Assert(err, jc.ErrorIsNil)
+	defer func() {
+		c.Check(txns.RemoveId(pendingId), jc.ErrorIsNil)
+		c.Check(txns.RemoveId(appliedId), jc.ErrorIsNil)
+	}()
+
+	count, err = s.State.PendingTransactionCount()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(count, gc.Equals, 1)
+}
+
 type SetAdminMongoPasswordSuite struct {
 	testing.BaseSuite
 }
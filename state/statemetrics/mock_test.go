@@ -86,9 +86,10 @@ type mockState struct {
 	statemetrics.State
 	testing.Stub
 
-	model      *mockModel
-	modelUUIDs []string
-	users      []*mockUser
+	model       *mockModel
+	modelUUIDs  []string
+	users       []*mockUser
+	pendingTxns int
 }
 
 func (m *mockState) AllModelUUIDs() ([]string, error) {
@@ -111,6 +112,34 @@ func (m *mockState) AllUsers() ([]statemetrics.User, error) {
 	return out, nil
 }
 
+func (m *mockState) AllUnits() ([]statemetrics.Unit, error) {
+	m.MethodCall(m, "AllUnits")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	out := make([]statemetrics.Unit, len(m.model.units))
+	for i, u := range m.model.units {
+		out[i] = u
+	}
+	return out, nil
+}
+
+func (m *mockState) CleanupCount() (int, error) {
+	m.MethodCall(m, "CleanupCount")
+	if err := m.NextErr(); err != nil {
+		return 0, err
+	}
+	return m.model.cleanups, nil
+}
+
+func (m *mockState) PendingTransactionCount() (int, error) {
+	m.MethodCall(m, "PendingTransactionCount")
+	if err := m.NextErr(); err != nil {
+		return 0, err
+	}
+	return m.pendingTxns, nil
+}
+
 func (m *mockState) ControllerTag() names.ControllerTag {
 	m.MethodCall(m, "ControllerTag")
 	return coretesting.ControllerTag
@@ -152,6 +181,10 @@ type mockModel struct {
 	life     state.Life
 	status   status.StatusInfo
 	machines []*mockMachine
+	units    []*mockUnit
+
+	pendingActions int
+	cleanups       int
 }
 
 func (m *mockModel) Life() state.Life {
@@ -164,6 +197,14 @@ func (m *mockModel) ModelTag() names.ModelTag {
 	return m.tag
 }
 
+func (m *mockModel) PendingActionCount() (int, error) {
+	m.MethodCall(m, "PendingActionCount")
+	if err := m.NextErr(); err != nil {
+		return 0, err
+	}
+	return m.pendingActions, nil
+}
+
 func (m *mockModel) Status() (status.StatusInfo, error) {
 	m.MethodCall(m, "Status")
 	if err := m.NextErr(); err != nil {
@@ -222,3 +263,25 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	}
 	return m.agentStatus, nil
 }
+
+type mockUnit struct {
+	testing.Stub
+	agentStatus    status.StatusInfo
+	workloadStatus status.StatusInfo
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.agentStatus, nil
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	u.MethodCall(u, "Status")
+	if err := u.NextErr(); err != nil {
+		return status.StatusInfo{}, err
+	}
+	return u.workloadStatus, nil
+}
@@ -15,8 +15,11 @@ import (
 type State interface {
 	AllMachines() ([]Machine, error)
 	AllModelUUIDs() ([]string, error)
+	AllUnits() ([]Unit, error)
 	AllUsers() ([]User, error)
+	CleanupCount() (int, error)
 	ControllerTag() names.ControllerTag
+	PendingTransactionCount() (int, error)
 	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
 }
 
@@ -45,6 +48,13 @@ type Machine interface {
 type Model interface {
 	Life() state.Life
 	ModelTag() names.ModelTag
+	PendingActionCount() (int, error)
+	Status() (status.StatusInfo, error)
+}
+
+// Unit represents a unit in a Juju model.
+type Unit interface {
+	AgentStatus() (status.StatusInfo, error)
 	Status() (status.StatusInfo, error)
 }
 
@@ -115,6 +125,32 @@ func allMachines(st *state.State) ([]Machine, error) {
 	return out, nil
 }
 
+func (s stateShim) AllUnits() ([]Unit, error) {
+	return allUnits(s.State)
+}
+
+func (s pooledStateShim) AllUnits() ([]Unit, error) {
+	return allUnits(s.State)
+}
+
+func allUnits(st *state.State) ([]Unit, error) {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var out []Unit
+	for _, app := range applications {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, u := range units {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
 func (s stateShim) AllUsers() ([]User, error) {
 	return allUsers(s.State)
 }
@@ -4,6 +4,10 @@
 package statemetrics
 
 import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +24,8 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	modelLabel            = "model"
+	workloadStatusLabel   = "workload_status"
 )
 
 var (
@@ -34,6 +40,16 @@ var (
 		statusLabel,
 	}
 
+	unitLabelNames = []string{
+		agentStatusLabel,
+		modelLabel,
+		workloadStatusLabel,
+	}
+
+	modelUUIDLabelNames = []string{
+		modelLabel,
+	}
+
 	userLabelNames = []string{
 		controllerAccessLabel,
 		deletedLabel,
@@ -44,10 +60,16 @@ var (
 	logger = loggo.GetLogger("juju.state.statemetrics")
 )
 
+// pendingTxnsInterval is the minimum time between counts of the
+// pending transactions. Counting them means scanning the controller's
+// transaction queue, which is too expensive to do on every scrape.
+const pendingTxnsInterval = time.Minute
+
 // Collector is a prometheus.Collector that collects metrics about
 // the Juju global state.
 type Collector struct {
-	pool StatePool
+	pool  StatePool
+	clock clock.Clock
 
 	scrapeDuration prometheus.Gauge
 	scrapeErrors   prometheus.Gauge
@@ -55,12 +77,22 @@ type Collector struct {
 	models   *prometheus.GaugeVec
 	machines *prometheus.GaugeVec
 	users    *prometheus.GaugeVec
+
+	units          *prometheus.GaugeVec
+	pendingActions *prometheus.GaugeVec
+	cleanups       *prometheus.GaugeVec
+	txnQueueLength *prometheus.GaugeVec
+
+	mu                 sync.Mutex
+	pendingTxns        int
+	pendingTxnsCounted time.Time
 }
 
 // New returns a new Collector.
-func New(pool StatePool) *Collector {
+func New(pool StatePool, clock clock.Clock) *Collector {
 	return &Collector{
-		pool: pool,
+		pool:  pool,
+		clock: clock,
 		scrapeDuration: prometheus.NewGauge(
 			prometheus.GaugeOpts{
 				Namespace: metricsNamespace,
@@ -100,6 +132,39 @@ func New(pool StatePool) *Collector {
 			},
 			userLabelNames,
 		),
+
+		units: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "units",
+				Help:      "Number of units in each model.",
+			},
+			unitLabelNames,
+		),
+		pendingActions: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "pending_actions",
+				Help:      "Number of actions waiting to be run in each model.",
+			},
+			modelUUIDLabelNames,
+		),
+		cleanups: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "cleanups",
+				Help:      "Number of cleanups waiting to be run in each model.",
+			},
+			modelUUIDLabelNames,
+		),
+		txnQueueLength: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "txn_queue_length",
+				Help:      "Number of mgo/txn transactions that have been neither applied nor aborted.",
+			},
+			nil,
+		),
 	}
 }
 
@@ -108,6 +173,10 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.machines.Describe(ch)
 	c.models.Describe(ch)
 	c.users.Describe(ch)
+	c.units.Describe(ch)
+	c.pendingActions.Describe(ch)
+	c.cleanups.Describe(ch)
+	c.txnQueueLength.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
 	c.scrapeDuration.Describe(ch)
@@ -124,12 +193,20 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.machines.Reset()
 	c.models.Reset()
 	c.users.Reset()
+	c.units.Reset()
+	c.pendingActions.Reset()
+	c.cleanups.Reset()
+	c.txnQueueLength.Reset()
 
 	c.updateMetrics()
 
 	c.machines.Collect(ch)
 	c.models.Collect(ch)
 	c.users.Collect(ch)
+	c.units.Collect(ch)
+	c.pendingActions.Collect(ch)
+	c.cleanups.Collect(ch)
+	c.txnQueueLength.Collect(ch)
 }
 
 func (c *Collector) updateMetrics() {
@@ -178,6 +255,31 @@ func (c *Collector) updateMetrics() {
 			domainLabel:           userTag.Domain(),
 		}).Inc()
 	}
+
+	pendingTxns, err := c.pendingTransactionCount(st)
+	if err != nil {
+		logger.Debugf("error getting pending transactions: %v", err)
+		c.scrapeErrors.Inc()
+	} else {
+		c.txnQueueLength.With(prometheus.Labels{}).Set(float64(pendingTxns))
+	}
+}
+
+// pendingTransactionCount returns the number of pending transactions,
+// counted at most once every pendingTxnsInterval.
+func (c *Collector) pendingTransactionCount(st State) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if !c.pendingTxnsCounted.IsZero() && now.Before(c.pendingTxnsCounted.Add(pendingTxnsInterval)) {
+		return c.pendingTxns, nil
+	}
+	count, err := st.PendingTransactionCount()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	c.pendingTxns, c.pendingTxnsCounted = count, now
+	return count, nil
 }
 
 func (c *Collector) updateModelMetrics(modelUUID string) {
@@ -244,6 +346,58 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 		}).Inc()
 	}
 
+	units, err := st.AllUnits()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting units: %v", err)
+		units = nil
+	}
+	for _, u := range units {
+		agentStatus, err := u.AgentStatus()
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit agent status: %v", err)
+			continue
+		}
+
+		workloadStatus, err := u.Status()
+		if errors.IsNotFound(err) {
+			continue // Unit removed
+		} else if err != nil {
+			c.scrapeErrors.Inc()
+			logger.Debugf("error getting unit workload status: %v", err)
+			continue
+		}
+
+		c.units.With(prometheus.Labels{
+			agentStatusLabel:    string(agentStatus.Status),
+			modelLabel:          modelTag.Id(),
+			workloadStatusLabel: string(workloadStatus.Status),
+		}).Inc()
+	}
+
+	pendingActions, err := model.PendingActionCount()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting pending actions: %v", err)
+	} else {
+		c.pendingActions.With(prometheus.Labels{
+			modelLabel: modelTag.Id(),
+		}).Set(float64(pendingActions))
+	}
+
+	cleanups, err := st.CleanupCount()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting cleanups: %v", err)
+	} else {
+		c.cleanups.With(prometheus.Labels{
+			modelLabel: modelTag.Id(),
+		}).Set(float64(cleanups))
+	}
+
 	c.models.With(prometheus.Labels{
 		lifeLabel:   model.Life().String(),
 		statusLabel: string(modelStatus.Status),
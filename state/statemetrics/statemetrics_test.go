@@ -6,7 +6,10 @@ package statemetrics_test
 import (
 	"errors"
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,6 +25,7 @@ import (
 
 type collectorSuite struct {
 	testing.IsolationSuite
+	clock     *testclock.Clock
 	pool      *mockStatePool
 	collector *statemetrics.Collector
 }
@@ -57,6 +61,17 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 				agentStatus:    status.StatusInfo{Status: status.Started},
 				instanceStatus: status.StatusInfo{Status: status.Running},
 			}},
+			units: []*mockUnit{{
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				agentStatus:    status.StatusInfo{Status: status.Idle},
+				workloadStatus: status.StatusInfo{Status: status.Active},
+			}, {
+				agentStatus:    status.StatusInfo{Status: status.Executing},
+				workloadStatus: status.StatusInfo{Status: status.Maintenance},
+			}},
+			pendingActions: 2,
 		}, {
 			tag:    names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
 			life:   state.Dying,
@@ -66,13 +81,16 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 				agentStatus:    status.StatusInfo{Status: status.Error},
 				instanceStatus: status.StatusInfo{Status: status.ProvisioningError},
 			}},
+			cleanups: 3,
 		}},
 	}
 	s.pool.system = &mockState{
-		users:      users,
-		modelUUIDs: s.pool.modelUUIDs(),
+		users:       users,
+		modelUUIDs:  s.pool.modelUUIDs(),
+		pendingTxns: 7,
 	}
-	s.collector = statemetrics.New(s.pool)
+	s.clock = testclock.NewClock(time.Now())
+	s.collector = statemetrics.New(s.pool, s.clock)
 }
 
 func (s *collectorSuite) TestDescribe(c *gc.C) {
@@ -89,6 +107,10 @@ func (s *collectorSuite) TestDescribe(c *gc.C) {
 		`.*fqName: "juju_state_machines".*`,
 		`.*fqName: "juju_state_models".*`,
 		`.*fqName: "juju_state_users".*`,
+		`.*fqName: "juju_state_units".*`,
+		`.*fqName: "juju_state_pending_actions".*`,
+		`.*fqName: "juju_state_cleanups".*`,
+		`.*fqName: "juju_state_txn_queue_length".*`,
 		`.*fqName: "juju_state_scrape_errors".*`,
 		`.*fqName: "juju_state_scrape_duration_seconds".*`,
 	}
@@ -214,6 +236,57 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 			},
 		},
 
+		// juju_state_units
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "idle"),
+				labelpair("model", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("workload_status", "active"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(1)},
+			Label: []*dto.LabelPair{
+				labelpair("agent_status", "executing"),
+				labelpair("model", "b266dff7-eee8-4297-b03a-4692796ec193"),
+				labelpair("workload_status", "maintenance"),
+			},
+		},
+
+		// juju_state_pending_actions
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_cleanups
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(0)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(3)},
+			Label: []*dto.LabelPair{
+				labelpair("model", "1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
+			},
+		},
+
+		// juju_state_txn_queue_length
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(7)},
+		},
+
 		// juju_state_scrape_errors
 		{
 			Gauge: &dto.Gauge{Value: float64ptr(0)},
@@ -230,6 +303,7 @@ func (s *collectorSuite) TestCollectErrors(c *gc.C) {
 	s.pool.system.SetErrors(
 		errors.New("no models for you"),
 		errors.New("no users for you"),
+		errors.New("no txns for you"),
 	)
 	_, dtoMetrics := s.collect(c)
 
@@ -242,7 +316,7 @@ func (s *collectorSuite) TestCollectErrors(c *gc.C) {
 	s.checkExpected(c, dtoMetrics, []dto.Metric{
 		// juju_state_scrape_errors
 		{
-			Gauge: &dto.Gauge{Value: float64ptr(2)},
+			Gauge: &dto.Gauge{Value: float64ptr(3)},
 		},
 
 		// juju_state_scrape_interval_seconds
@@ -251,3 +325,33 @@ func (s *collectorSuite) TestCollectErrors(c *gc.C) {
 		},
 	})
 }
+
+func (s *collectorSuite) txnQueueLength(c *gc.C) float64 {
+	metrics, dtoMetrics := s.collect(c)
+	for i, metric := range metrics {
+		if strings.Contains(metric.Desc().String(), `"juju_state_txn_queue_length"`) {
+			return dtoMetrics[i].Gauge.GetValue()
+		}
+	}
+	c.Fatalf("juju_state_txn_queue_length not collected")
+	return 0
+}
+
+func (s *collectorSuite) TestCollectCachesPendingTransactionCount(c *gc.C) {
+	c.Assert(s.txnQueueLength(c), gc.Equals, float64(7))
+
+	// The pending transactions are not counted again until the
+	// interval has passed.
+	s.pool.system.pendingTxns = 9
+	c.Assert(s.txnQueueLength(c), gc.Equals, float64(7))
+	s.clock.Advance(time.Minute)
+	c.Assert(s.txnQueueLength(c), gc.Equals, float64(9))
+
+	var counts int
+	for _, call := range s.pool.system.Calls() {
+		if call.FuncName == "PendingTransactionCount" {
+			counts++
+		}
+	}
+	c.Assert(counts, gc.Equals, 2)
+}
@@ -36,6 +36,22 @@ func (st *State) ResumeTransactions() error {
 	return runner.ResumeTransactions()
 }
 
+// PendingTransactionCount returns the number of transactions, across
+// all models, that have been neither applied nor aborted.
+func (st *State) PendingTransactionCount() (int, error) {
+	txns, closer := st.db().GetRawCollection(txnsC)
+	defer closer()
+	// mgo/txn doesn't export its transaction states; 5 and 6 are
+	// aborted and applied respectively. The query is served by the
+	// index on "s", but still scans every pending transaction, so
+	// callers should not count them too often.
+	count, err := txns.Find(bson.D{{"s", bson.D{{"$lt", 5}}}}).Count()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot count pending transactions")
+	}
+	return count, nil
+}
+
 // MaybePruneTransactions removes data for completed transactions.
 func (st *State) MaybePruneTransactions() error {
 	runner, closer := st.database.TransactionRunner()
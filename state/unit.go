@@ -1652,6 +1652,16 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
 	ops = append(ops, storageOps...)
+
+	app, err := u.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	profileOps, err := app.machineCharmProfileChangeOps(m.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, profileOps...)
 	return ops, nil
 }
 
@@ -2601,6 +2611,22 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	payloadWithDefaults, err := u.actionPayload(name, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := u.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+}
+
+// actionPayload validates the payload of the named action against the
+// action's spec, and returns it with the spec's defaults inserted.
+func (u *Unit) actionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2622,17 +2648,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
-	}
-
-	model, err := u.st.Model()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-
-	return model.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec.InsertDefaults(payload)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -413,9 +413,11 @@ func (a *Application) WatchUnits() StringsWatcher {
 }
 
 // WatchScale returns a new NotifyWatcher watching for
-// changes to the specified application's scale value.
+// changes to the specified application's scale value
+// or autoscale policy.
 func (a *Application) WatchScale() NotifyWatcher {
 	currentScale := -1
+	var currentAutoscale *autoscalePolicyDoc
 	filter := func(id interface{}) bool {
 		k, err := a.st.strictLocalID(id.(string))
 		if err != nil {
@@ -427,13 +429,14 @@ func (a *Application) WatchScale() NotifyWatcher {
 		applications, closer := a.st.db().GetCollection(applicationsC)
 		defer closer()
 
-		var scaleField = bson.D{{"scale", 1}}
+		var scaleFields = bson.D{{"scale", 1}, {"autoscale", 1}}
 		var doc *applicationDoc
-		if err := applications.FindId(k).Select(scaleField).One(&doc); err != nil {
+		if err := applications.FindId(k).Select(scaleFields).One(&doc); err != nil {
 			return false
 		}
-		match := doc.DesiredScale != currentScale
+		match := doc.DesiredScale != currentScale || !reflect.DeepEqual(doc.Autoscale, currentAutoscale)
 		currentScale = doc.DesiredScale
+		currentAutoscale = doc.Autoscale
 		return match
 	}
 	return newNotifyCollWatcher(a.st, applicationsC, filter)
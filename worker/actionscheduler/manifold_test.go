@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config actionscheduler.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = actionscheduler.ManifoldConfig{
+		APICallerName: "api-caller",
+		ClockName:     "clock",
+		NewFacade: func(base.APICaller) actionscheduler.Facade {
+			return &fakeFacade{}
+		},
+		NewWorker: func(config actionscheduler.Config) (worker.Worker, error) {
+			return nil, errors.New("no worker")
+		},
+	}
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := actionscheduler.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"api-caller", "clock"})
+}
+
+func (s *ManifoldSuite) TestStartMissingAPICaller(c *gc.C) {
+	manifold := actionscheduler.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": dependency.ErrMissing,
+		"clock":      testclock.NewClock(time.Time{}),
+	})
+	_, err := manifold.Start(context)
+	c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestStartInvalidConfig(c *gc.C) {
+	s.config.NewWorker = nil
+	manifold := actionscheduler.Manifold(s.config)
+	_, err := manifold.Start(dt.StubContext(nil, nil))
+	c.Check(err, gc.ErrorMatches, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	facade := &fakeFacade{}
+	clock := testclock.NewClock(time.Time{})
+	s.config.NewFacade = func(base.APICaller) actionscheduler.Facade {
+		return facade
+	}
+	var gotConfig actionscheduler.Config
+	s.config.NewWorker = func(config actionscheduler.Config) (worker.Worker, error) {
+		gotConfig = config
+		return nil, errors.New("no worker")
+	}
+	manifold := actionscheduler.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"api-caller": struct{ base.APICaller }{},
+		"clock":      clock,
+	})
+	_, err := manifold.Start(context)
+	c.Check(err, gc.ErrorMatches, "no worker")
+	c.Check(gotConfig.Facade, gc.Equals, facade)
+	c.Check(gotConfig.Clock, gc.Equals, clock)
+}
@@ -0,0 +1,95 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package actionscheduler provides a worker that queues the actions of
+// a model's action schedules when they are due.
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+var logger = loggo.GetLogger("juju.worker.actionscheduler")
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	RunDueSchedules() error
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Worker queues scheduled actions at the start of every minute, the
+// granularity of action schedules.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a Worker that queues scheduled actions until it is
+// stopped.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	// Schedules are run straight away to catch up with any that were
+	// due while the worker wasn't running.
+	for {
+		if err := w.config.Facade.RunDueSchedules(); err != nil {
+			// The next run of a schedule is claimed before its
+			// actions are queued, so a failed run is skipped
+			// rather than retried; the schedule runs again at
+			// its next scheduled time.
+			logger.Errorf("cannot run action schedules: %v", err)
+		}
+		now := w.config.Clock.Now()
+		delay := now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,98 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{calls: make(chan struct{}, 10)}
+	s.clock = testclock.NewClock(time.Date(2018, 10, 17, 10, 0, 40, 0, time.UTC))
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := actionscheduler.NewWorker(actionscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = actionscheduler.NewWorker(actionscheduler.Config{Facade: s.facade})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestRunsEveryMinute(c *gc.C) {
+	// Errors are logged, and don't stop the worker.
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The schedules are run when the worker starts, then at the
+	// start of each minute.
+	s.waitCall(c)
+	err = s.clock.WaitAdvance(20*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	err = s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	s.facade.CheckCallNames(c, "RunDueSchedules", "RunDueSchedules", "RunDueSchedules")
+}
+
+func (s *WorkerSuite) TestNoRunBeforeMinute(c *gc.C) {
+	w, err := actionscheduler.NewWorker(actionscheduler.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.waitCall(c)
+	err = s.clock.WaitAdvance(19*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected run")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schedules to run")
+	}
+}
+
+type fakeFacade struct {
+	testing.Stub
+	calls chan struct{}
+}
+
+func (f *fakeFacade) RunDueSchedules() error {
+	f.MethodCall(f, "RunDueSchedules")
+	f.calls <- struct{}{}
+	return f.NextErr()
+}
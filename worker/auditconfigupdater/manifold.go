@@ -9,7 +9,9 @@ import (
 	"gopkg.in/juju/worker.v1/dependency"
 
 	jujuagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/common"
 	workerstate "github.com/juju/juju/worker/state"
 )
@@ -78,7 +80,7 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	st := statePool.SystemState()
 
 	logFactory := func(cfg auditlog.Config) auditlog.AuditLog {
-		return auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups)
+		return newAuditLog(cfg, logDir, st)
 	}
 	auditConfig, err := initialConfig(st)
 	if err != nil {
@@ -95,6 +97,22 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
 }
 
+// newAuditLog returns an audit log writing to each of the backends
+// named in the config. The file backend is used if none are named.
+func newAuditLog(cfg auditlog.Config, logDir string, st *state.State) auditlog.AuditLog {
+	var targets []auditlog.AuditLog
+	if cfg.Backends.IsEmpty() || cfg.Backends.Contains(controller.AuditLogBackendFile) {
+		targets = append(targets, auditlog.NewLogFile(logDir, cfg.MaxSizeMB, cfg.MaxBackups))
+	}
+	if cfg.Backends.Contains(controller.AuditLogBackendDatabase) {
+		targets = append(targets, state.NewAuditLogger(st))
+	}
+	if len(targets) == 1 {
+		return targets[0]
+	}
+	return auditlog.NewMultiLog(targets...)
+}
+
 type withCurrentConfig interface {
 	CurrentConfig() auditlog.Config
 }
@@ -126,6 +144,7 @@ func initialConfig(source ConfigSource) (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Backends:       cfg.AuditLogBackends(),
 	}
 	return result, nil
 }
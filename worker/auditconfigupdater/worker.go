@@ -95,6 +95,7 @@ func (u *updater) newConfig() (auditlog.Config, error) {
 		MaxSizeMB:      cfg.AuditLogMaxSizeMB(),
 		MaxBackups:     cfg.AuditLogMaxBackups(),
 		ExcludeMethods: cfg.AuditLogExcludeMethods(),
+		Backends:       cfg.AuditLogBackends(),
 	}
 	if result.Enabled && u.current.Target == nil {
 		result.Target = u.logFactory(result)
@@ -0,0 +1,117 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// NewBackend returns a Backend that takes backups of the controller
+// running on the agent's machine. The state must be that of the
+// controller model.
+func NewBackend(st *state.State, agentConfig agent.Config) (Backend, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &stateBackend{
+		State:       st,
+		model:       model,
+		agentConfig: agentConfig,
+	}, nil
+}
+
+type stateBackend struct {
+	*state.State
+	model       *state.Model
+	agentConfig agent.Config
+}
+
+// backupsDB is the backups.DB for the controller model.
+type backupsDB struct {
+	*state.State
+	*state.Model
+}
+
+func (b *stateBackend) backups() (backups.Backups, func()) {
+	db := backupsDB{b.State, b.model}
+	stor := backups.NewStorage(db)
+	return backups.NewEncryptedBackups(stor, backups.ControllerEncryptionKey(db)), func() { stor.Close() }
+}
+
+// CreateBackup is part of the Backend interface.
+func (b *stateBackend) CreateBackup() (string, error) {
+	backupsMethods, closer := b.backups()
+	defer closer()
+
+	session := b.MongoSession().Copy()
+	defer session.Close()
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return "", errors.Annotate(err, "HA not ready")
+	}
+
+	mgoInfo, ok := b.agentConfig.MongoInfo()
+	if !ok {
+		return "", errors.New("no mongo info in agent config")
+	}
+	v, err := b.MongoVersion()
+	if err != nil {
+		return "", errors.Annotate(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(mgoInfo, session, mongoVersion)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	machineID := b.agentConfig.Tag().Id()
+	machine, err := b.Machine(machineID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(backupsDB{b.State, b.model}, machineID, machine.Series())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	meta.Scheduled = true
+	meta.Notes = "scheduled backup"
+
+	modelConfig, err := b.model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	paths := backups.Paths{
+		BackupDir: modelConfig.BackupDir(),
+		DataDir:   b.agentConfig.DataDir(),
+		LogsDir:   b.agentConfig.LogDir(),
+	}
+	if _, err := backupsMethods.Create(meta, &paths, dbInfo, true, true); err != nil {
+		return "", errors.Trace(err)
+	}
+	return meta.ID(), nil
+}
+
+// ListBackups is part of the Backend interface.
+func (b *stateBackend) ListBackups() ([]*backups.Metadata, error) {
+	backupsMethods, closer := b.backups()
+	defer closer()
+	metaList, err := backupsMethods.List()
+	return metaList, errors.Trace(err)
+}
+
+// RemoveBackup is part of the Backend interface.
+func (b *stateBackend) RemoveBackup(id string) error {
+	backupsMethods, closer := b.backups()
+	defer closer()
+	return errors.Trace(backupsMethods.Remove(id))
+}
@@ -0,0 +1,154 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type ManifoldSuite struct {
+	statetesting.StateSuite
+
+	agent        *mockAgent
+	clock        *testclock.Clock
+	stateTracker stubStateTracker
+	backend      *fakeBackend
+	stub         testing.Stub
+	config       backupscheduler.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.StateSuite.SetUpTest(c)
+	s.agent = &mockAgent{}
+	s.clock = testclock.NewClock(time.Time{})
+	s.stateTracker = stubStateTracker{pool: s.StatePool}
+	s.backend = &fakeBackend{}
+	s.stub.ResetCalls()
+	s.config = backupscheduler.ManifoldConfig{
+		AgentName: "agent",
+		ClockName: "clock",
+		StateName: "state",
+		NewBackend: func(st *state.State, config agent.Config) (backupscheduler.Backend, error) {
+			s.stub.MethodCall(s, "NewBackend", st, config)
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			return s.backend, nil
+		},
+		NewWorker: func(config backupscheduler.Config) (worker.Worker, error) {
+			s.stub.MethodCall(s, "NewWorker", config)
+			if err := s.stub.NextErr(); err != nil {
+				return nil, err
+			}
+			return workertest.NewErrorWorker(nil), nil
+		},
+	}
+}
+
+func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Context {
+	resources := map[string]interface{}{
+		"agent": s.agent,
+		"clock": s.clock,
+		"state": &s.stateTracker,
+	}
+	for k, v := range overlay {
+		resources[k] = v
+	}
+	return dt.StubContext(nil, resources)
+}
+
+var expectedInputs = []string{"agent", "clock", "state"}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := backupscheduler.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, expectedInputs)
+}
+
+func (s *ManifoldSuite) TestMissingInputs(c *gc.C) {
+	manifold := backupscheduler.Manifold(s.config)
+	for _, input := range expectedInputs {
+		context := s.newContext(map[string]interface{}{
+			input: dependency.ErrMissing,
+		})
+		_, err := manifold.Start(context)
+		c.Check(errors.Cause(err), gc.Equals, dependency.ErrMissing)
+	}
+}
+
+func (s *ManifoldSuite) TestStartInvalidConfig(c *gc.C) {
+	s.config.NewBackend = nil
+	manifold := backupscheduler.Manifold(s.config)
+	_, err := manifold.Start(s.newContext(nil))
+	c.Check(err, gc.ErrorMatches, "nil NewBackend not valid")
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	manifold := backupscheduler.Manifold(s.config)
+	w, err := manifold.Start(s.newContext(nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "NewBackend", "NewWorker")
+	s.stub.CheckCall(c, 0, "NewBackend", s.State, &s.agent.conf)
+	s.stub.CheckCall(c, 1, "NewWorker", backupscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+
+	s.stateTracker.CheckCallNames(c, "Use")
+	workertest.CleanKill(c, w)
+	s.stateTracker.CheckCallNames(c, "Use", "Done")
+}
+
+func (s *ManifoldSuite) TestStartBackendError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	manifold := backupscheduler.Manifold(s.config)
+	_, err := manifold.Start(s.newContext(nil))
+	c.Check(err, gc.ErrorMatches, "boom")
+	s.stateTracker.CheckCallNames(c, "Use", "Done")
+}
+
+type mockAgent struct {
+	agent.Agent
+	conf mockAgentConfig
+}
+
+func (ma *mockAgent) CurrentConfig() agent.Config {
+	return &ma.conf
+}
+
+type mockAgentConfig struct {
+	agent.Config
+}
+
+type stubStateTracker struct {
+	testing.Stub
+	pool *state.StatePool
+}
+
+func (s *stubStateTracker) Use() (*state.StatePool, error) {
+	s.MethodCall(s, "Use")
+	return s.pool, s.NextErr()
+}
+
+func (s *stubStateTracker) Done() error {
+	s.MethodCall(s, "Done")
+	return s.NextErr()
+}
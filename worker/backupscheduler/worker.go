@@ -0,0 +1,204 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that takes backups of the
+// controller on the schedule in its configuration, and removes the
+// scheduled backups that its retention policy no longer keeps.
+package backupscheduler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// Backend exposes the controller functionality used by the worker.
+type Backend interface {
+	// WatchControllerConfig returns a watcher that notifies of changes
+	// to the controller configuration.
+	WatchControllerConfig() state.NotifyWatcher
+
+	// ControllerConfig returns the controller configuration.
+	ControllerConfig() (controller.Config, error)
+
+	// CreateBackup takes and stores a scheduled backup of the
+	// controller, and returns its ID.
+	CreateBackup() (string, error)
+
+	// ListBackups returns the metadata for all stored backups.
+	ListBackups() ([]*backups.Metadata, error)
+
+	// RemoveBackup removes the stored backup.
+	RemoveBackup(id string) error
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional Worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Worker takes backups of the controller when they are due according
+// to the backup-schedule controller configuration. After each backup it
+// removes the scheduled backups that are no longer retained according
+// to the backup-retain-daily and backup-retain-weekly configuration.
+//
+// Backups that were due while the worker wasn't running are not taken.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a Worker that takes scheduled backups until it is
+// stopped.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	watcher := w.config.Backend.WatchControllerConfig()
+	if err := w.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		schedule *actions.Schedule
+		due      <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("controller config watcher closed")
+			}
+			var err error
+			if schedule, err = w.schedule(); err != nil {
+				return errors.Trace(err)
+			}
+		case <-due:
+			w.backup()
+		}
+		due = nil
+		if schedule != nil {
+			now := w.config.Clock.Now()
+			next := schedule.Next(now)
+			if next.IsZero() {
+				logger.Warningf("backup schedule %q never matches", schedule)
+				continue
+			}
+			logger.Debugf("next scheduled backup at %v", next)
+			due = w.config.Clock.After(next.Sub(now))
+		}
+	}
+}
+
+// schedule returns the configured backup schedule, or nil if backups
+// are not scheduled.
+func (w *Worker) schedule() (*actions.Schedule, error) {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spec := cfg.BackupSchedule()
+	if spec == "" {
+		return nil, nil
+	}
+	schedule, err := actions.ParseSchedule(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &schedule, nil
+}
+
+// backup takes a scheduled backup and removes the expired ones. Errors
+// are logged rather than stopping the worker, so that the next
+// scheduled backup is still taken.
+func (w *Worker) backup() {
+	id, err := w.config.Backend.CreateBackup()
+	if err != nil {
+		logger.Errorf("cannot create scheduled backup: %v", err)
+		return
+	}
+	logger.Infof("created scheduled backup %q", id)
+	if err := w.prune(); err != nil {
+		logger.Errorf("cannot remove expired backups: %v", err)
+	}
+}
+
+// prune removes the scheduled backups that the retention policy no
+// longer keeps.
+func (w *Worker) prune() error {
+	cfg, err := w.config.Backend.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	policy := backups.RetentionPolicy{
+		Daily:  cfg.BackupRetainDaily(),
+		Weekly: cfg.BackupRetainWeekly(),
+	}
+	metaList, err := w.config.Backend.ListBackups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// A backup that cannot be removed doesn't stop the others from
+	// being removed; it is tried again at the next prune.
+	var failed []string
+	for _, meta := range policy.Expired(metaList) {
+		if err := w.config.Backend.RemoveBackup(meta.ID()); err != nil {
+			logger.Errorf("cannot remove expired backup %q: %v", meta.ID(), err)
+			failed = append(failed, meta.ID())
+			continue
+		}
+		logger.Infof("removed expired backup %q", meta.ID())
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("cannot remove backups %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,249 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	backend *fakeBackend
+	clock   *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		changes: make(chan struct{}, 1),
+		created: make(chan struct{}, 10),
+		cfg: controller.Config{
+			controller.BackupSchedule:     "0 2 * * *",
+			controller.BackupRetainDaily:  1,
+			controller.BackupRetainWeekly: 0,
+		},
+	}
+	s.backend.changes <- struct{}{}
+	s.clock = testclock.NewClock(time.Date(2018, 10, 17, 1, 59, 30, 0, time.UTC))
+}
+
+func (s *WorkerSuite) newWorker(c *gc.C) *backupscheduler.Worker {
+	w, err := backupscheduler.NewWorker(backupscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w.(*backupscheduler.Worker)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.NewWorker(backupscheduler.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+	_, err = backupscheduler.NewWorker(backupscheduler.Config{Backend: s.backend})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestBackupOnSchedule(c *gc.C) {
+	s.backend.metas = []*backups.Metadata{
+		newBackup("manual", time.Date(2018, 10, 16, 12, 0, 0, 0, time.UTC), false),
+		newBackup("yesterday", time.Date(2018, 10, 16, 2, 0, 0, 0, time.UTC), true),
+		newBackup("today", time.Date(2018, 10, 17, 2, 0, 0, 0, time.UTC), true),
+	}
+	w := s.newWorker(c)
+
+	err := s.clock.WaitAdvance(29*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoBackup(c)
+
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitBackup(c)
+
+	// The next backup is due a day later.
+	err = s.clock.WaitAdvance(24*time.Hour-time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNoBackup(c)
+
+	workertest.CleanKill(c, w)
+	s.backend.CheckCallNames(c,
+		"WatchControllerConfig", "ControllerConfig",
+		"CreateBackup", "ControllerConfig", "ListBackups", "RemoveBackup",
+	)
+	s.backend.CheckCall(c, 5, "RemoveBackup", "yesterday")
+}
+
+func (s *WorkerSuite) TestRemoveBackupErrorNotFatal(c *gc.C) {
+	s.backend.metas = []*backups.Metadata{
+		newBackup("two days ago", time.Date(2018, 10, 15, 2, 0, 0, 0, time.UTC), true),
+		newBackup("yesterday", time.Date(2018, 10, 16, 2, 0, 0, 0, time.UTC), true),
+		newBackup("today", time.Date(2018, 10, 17, 2, 0, 0, 0, time.UTC), true),
+	}
+	s.backend.SetErrors(
+		nil, // ControllerConfig
+		nil, // CreateBackup
+		nil, // ControllerConfig
+		nil, // ListBackups
+		errors.New("boom"),
+	)
+	w := s.newWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitBackup(c)
+
+	// Wait for the next backup timer, which is set after pruning.
+	err = s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+	s.backend.CheckCallNames(c,
+		"WatchControllerConfig", "ControllerConfig",
+		"CreateBackup", "ControllerConfig", "ListBackups", "RemoveBackup", "RemoveBackup",
+	)
+	// The second expired backup is removed despite the first failing.
+	calls := s.backend.Calls()
+	removed := []interface{}{calls[5].Args[0], calls[6].Args[0]}
+	c.Check(removed, jc.SameContents, []interface{}{"two days ago", "yesterday"})
+}
+
+func (s *WorkerSuite) TestNotScheduled(c *gc.C) {
+	s.backend.cfg = controller.Config{}
+	s.newWorker(c)
+
+	// There's no backup timer to wait for.
+	s.clock.Advance(48 * time.Hour)
+	s.assertNoBackup(c)
+}
+
+func (s *WorkerSuite) TestScheduleChanged(c *gc.C) {
+	s.backend.cfg = controller.Config{}
+	s.newWorker(c)
+
+	// Wait for the initial config to be read before changing it.
+	for a := coretesting.LongAttempt.Start(); len(s.backend.Calls()) < 2; {
+		if !a.Next() {
+			c.Fatalf("timed out waiting for controller config to be read")
+		}
+	}
+	s.backend.setConfig(controller.Config{controller.BackupSchedule: "@hourly"})
+	s.backend.changes <- struct{}{}
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitBackup(c)
+}
+
+func (s *WorkerSuite) TestCreateBackupErrorNotFatal(c *gc.C) {
+	s.backend.SetErrors(
+		nil, // ControllerConfig
+		errors.New("boom"),
+	)
+	w := s.newWorker(c)
+
+	err := s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitBackup(c)
+
+	err = s.clock.WaitAdvance(24*time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitBackup(c)
+
+	workertest.CleanKill(c, w)
+	s.backend.CheckCallNames(c,
+		"WatchControllerConfig", "ControllerConfig",
+		"CreateBackup",
+		"CreateBackup", "ControllerConfig", "ListBackups",
+	)
+}
+
+func (s *WorkerSuite) TestControllerConfigError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	w := s.newWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerSuite) waitBackup(c *gc.C) {
+	select {
+	case <-s.backend.created:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for backup")
+	}
+}
+
+func (s *WorkerSuite) assertNoBackup(c *gc.C) {
+	select {
+	case <-s.backend.created:
+		c.Fatalf("unexpected backup")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func newBackup(id string, started time.Time, scheduled bool) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Scheduled = scheduled
+	return meta
+}
+
+type fakeBackend struct {
+	testing.Stub
+
+	mu      sync.Mutex
+	cfg     controller.Config
+	metas   []*backups.Metadata
+	changes chan struct{}
+	created chan struct{}
+}
+
+func (b *fakeBackend) setConfig(cfg controller.Config) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.cfg = cfg
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	b.MethodCall(b, "WatchControllerConfig")
+	return watchertest.NewNotifyWatcher(b.changes)
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	b.MethodCall(b, "ControllerConfig")
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.cfg, b.NextErr()
+}
+
+func (b *fakeBackend) CreateBackup() (string, error) {
+	b.MethodCall(b, "CreateBackup")
+	b.created <- struct{}{}
+	return "new", b.NextErr()
+}
+
+func (b *fakeBackend) ListBackups() ([]*backups.Metadata, error) {
+	b.MethodCall(b, "ListBackups")
+	return b.metas, b.NextErr()
+}
+
+func (b *fakeBackend) RemoveBackup(id string) error {
+	b.MethodCall(b, "RemoveBackup", id)
+	return b.NextErr()
+}
@@ -4,6 +4,8 @@
 package caasunitprovisioner
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -69,8 +72,9 @@ func (w *deploymentWorker) loop() error {
 		cw       watcher.NotifyWatcher
 		specChan watcher.NotifyChannel
 
-		currentScale int
-		currentSpec  string
+		currentScale     int
+		currentSpec      string
+		currentAutoscale *application.AutoscalePolicy
 	)
 
 	gotSpecNotify := false
@@ -131,12 +135,14 @@ func (w *deploymentWorker) loop() error {
 		}
 		specStr := info.PodSpec
 
-		if scale == currentScale && specStr == currentSpec {
+		if scale == currentScale && specStr == currentSpec &&
+			reflect.DeepEqual(info.Autoscale, currentAutoscale) {
 			continue
 		}
 
 		currentScale = scale
 		currentSpec = specStr
+		currentAutoscale = info.Autoscale
 
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
@@ -160,6 +166,7 @@ func (w *deploymentWorker) loop() error {
 			ResourceTags: info.Tags,
 			Filesystems:  info.Filesystems,
 			Devices:      info.Devices,
+			Autoscale:    info.Autoscale,
 		}
 		err = w.broker.EnsureService(w.application, serviceParams, currentScale, appConfig)
 		if err != nil {
@@ -357,6 +357,40 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestAutoscalePolicyChange(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	policy := &application.AutoscalePolicy{MinUnits: 1, MaxUnits: 5, TargetCPUPercent: 70}
+	s.podSpecGetter.setProvisioningInfo(apicaasunitprovisioner.ProvisioningInfo{
+		PodSpec:     containerSpec,
+		Tags:        map[string]string{"foo": "bar"},
+		Placement:   "placement",
+		Constraints: constraints.MustParse("mem=4G"),
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+		}},
+		Autoscale: policy,
+	})
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	expectedParams := *expectedServiceParams
+	expectedParams.Autoscale = policy
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", &expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func (s *WorkerSuite) TestNewPodSpecChangeCrd(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -0,0 +1,256 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// removeVoterTimeout is how long the raft leader is given to commit
+// the configuration change removing a voter.
+const removeVoterTimeout = 10 * time.Second
+
+// RaftNode captures the part of the *raft.Raft API needed by the
+// introspection worker.
+type RaftNode interface {
+	State() raft.RaftState
+	Leader() raft.ServerAddress
+	Stats() map[string]string
+	GetConfiguration() raft.ConfigurationFuture
+	Snapshot() raft.SnapshotFuture
+	RemoveServer(id raft.ServerID, prevIndex uint64, timeout time.Duration) raft.IndexFuture
+}
+
+// RaftIntrospector provides access to the raft node run by a controller
+// agent.
+type RaftIntrospector interface {
+	// Raft returns the agent's raft node, or nil if it isn't
+	// currently running one.
+	Raft() RaftNode
+}
+
+// raftStatus is the report served on the /raft/ endpoint.
+type raftStatus struct {
+	State        string                `yaml:"state"`
+	Term         string                `yaml:"term"`
+	Leader       string                `yaml:"leader"`
+	LastContact  string                `yaml:"last-contact,omitempty"`
+	Index        raftIndexStatus       `yaml:"index"`
+	LastSnapshot raftSnapshotStatus    `yaml:"last-snapshot"`
+	Servers      map[string]raftServer `yaml:"servers"`
+}
+
+type raftIndexStatus struct {
+	LastLog string `yaml:"last-log"`
+	Commit  string `yaml:"commit"`
+	Applied string `yaml:"applied"`
+}
+
+type raftSnapshotStatus struct {
+	Index string `yaml:"index"`
+	Term  string `yaml:"term"`
+}
+
+type raftServer struct {
+	Address  string `yaml:"address"`
+	Suffrage string `yaml:"suffrage"`
+}
+
+type raftHandler struct {
+	raft RaftIntrospector
+}
+
+// node returns the agent's raft node, having written an error response
+// if there isn't one.
+func (h raftHandler) node(w http.ResponseWriter) RaftNode {
+	var node RaftNode
+	if h.raft != nil {
+		node = h.raft.Raft()
+	}
+	if node == nil {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintln(w, "agent is not running raft")
+	}
+	return node
+}
+
+// requirePost writes an error response and returns false if the request
+// isn't a POST; raft operations that change state must not be triggered
+// by a stray GET.
+func requirePost(w http.ResponseWriter, r *http.Request) bool {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		fmt.Fprintf(w, "method %s not allowed, use POST\n", r.Method)
+		return false
+	}
+	return true
+}
+
+type raftStatusHandler struct {
+	raftHandler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h raftStatusHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	node := h.node(w)
+	if node == nil {
+		return
+	}
+	status, err := newRaftStatus(node)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+	bytes, err := yaml.Marshal(status)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	fmt.Fprint(w, "Raft Status\n\n")
+	w.Write(bytes)
+}
+
+func newRaftStatus(node RaftNode) (raftStatus, error) {
+	stats := node.Stats()
+	status := raftStatus{
+		State:  node.State().String(),
+		Term:   stats["term"],
+		Leader: string(node.Leader()),
+		Index: raftIndexStatus{
+			LastLog: stats["last_log_index"],
+			Commit:  stats["commit_index"],
+			Applied: stats["applied_index"],
+		},
+		LastSnapshot: raftSnapshotStatus{
+			Index: stats["last_snapshot_index"],
+			Term:  stats["last_snapshot_term"],
+		},
+		Servers: make(map[string]raftServer),
+	}
+	if status.State != raft.Leader.String() {
+		status.LastContact = stats["last_contact"]
+	}
+	future := node.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return raftStatus{}, errors.Annotate(err, "getting raft configuration")
+	}
+	for _, server := range future.Configuration().Servers {
+		status.Servers[string(server.ID)] = raftServer{
+			Address:  string(server.Address),
+			Suffrage: server.Suffrage.String(),
+		}
+	}
+	return status, nil
+}
+
+type raftSnapshotHandler struct {
+	raftHandler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h raftSnapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !requirePost(w, r) {
+		return
+	}
+	node := h.node(w)
+	if node == nil {
+		return
+	}
+	if err := node.Snapshot().Error(); err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, "snapshot taken")
+}
+
+type raftRemoveVoterHandler struct {
+	raftHandler
+}
+
+// ServeHTTP is part of the http.Handler interface.
+func (h raftRemoveVoterHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !requirePost(w, r) {
+		return
+	}
+	id := raft.ServerID(r.URL.Query().Get("id"))
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintln(w, "missing id")
+		return
+	}
+	node := h.node(w)
+	if node == nil {
+		return
+	}
+	if err := removeVoter(node, id); err != nil {
+		if errors.IsNotValid(err) || errors.IsNotFound(err) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		fmt.Fprintf(w, "error: %v\n", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "removed voter %s\n", id)
+}
+
+// removeVoter removes the identified voter from the raft cluster. It
+// only does so on the leader, and refuses to remove the leader itself
+// or a server that isn't a voter, so that a mistyped id can't take
+// away the cluster's quorum.
+//
+// The raft clusterer adds every controller machine to the cluster, so
+// a voter whose machine is still a controller is added straight back.
+// This is for clearing out a server whose machine has already been
+// removed from the controller but was left behind in the cluster.
+func removeVoter(node RaftNode, id raft.ServerID) error {
+	if node.State() != raft.Leader {
+		leader := node.Leader()
+		if leader == "" {
+			return errors.New("cluster has no leader")
+		}
+		return errors.Errorf("not the raft leader, run this on %q", leader)
+	}
+	future := node.GetConfiguration()
+	if err := future.Error(); err != nil {
+		return errors.Annotate(err, "getting raft configuration")
+	}
+	var target *raft.Server
+	for _, server := range future.Configuration().Servers {
+		if server.ID == id {
+			server := server
+			target = &server
+			break
+		}
+	}
+	switch {
+	case target == nil:
+		return errors.NotFoundf("raft server %q", id)
+	case target.Suffrage != raft.Voter:
+		return errors.NotValidf("removing %s server %q", target.Suffrage, id)
+	case target.Address == node.Leader():
+		return errors.NotValidf("removing the leader %q", id)
+	}
+	if err := node.RemoveServer(id, 0, removeVoterTimeout).Error(); err != nil {
+		return errors.Annotatef(err, "removing raft server %q", id)
+	}
+	return nil
+}
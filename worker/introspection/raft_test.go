@@ -0,0 +1,257 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection_test
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"runtime"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/worker/introspection"
+)
+
+type raftSuite struct {
+	testing.IsolationSuite
+
+	name string
+	node *fakeRaftNode
+}
+
+var _ = gc.Suite(&raftSuite{})
+
+func (s *raftSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS != "linux" {
+		c.Skip("introspection worker not supported on non-linux")
+	}
+	s.IsolationSuite.SetUpTest(c)
+	s.node = &fakeRaftNode{
+		state:  raft.Leader,
+		leader: "0",
+		stats: map[string]string{
+			"term":                "3",
+			"last_log_index":      "42",
+			"commit_index":        "41",
+			"applied_index":       "40",
+			"last_snapshot_index": "30",
+			"last_snapshot_term":  "2",
+			"last_contact":        "10ms",
+		},
+		servers: []raft.Server{
+			{ID: "0", Address: "0", Suffrage: raft.Voter},
+			{ID: "1", Address: "1", Suffrage: raft.Voter},
+			{ID: "2", Address: "2", Suffrage: raft.Nonvoter},
+		},
+	}
+	s.name = fmt.Sprintf("introspection-raft-test-%d", os.Getpid())
+	w, err := introspection.NewWorker(introspection.Config{
+		SocketName:         s.name,
+		PrometheusGatherer: prometheus.NewRegistry(),
+		Raft:               fakeRaftIntrospector{s.node},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) {
+		workertest.CheckKill(c, w)
+	})
+}
+
+func (s *raftSuite) call(c *gc.C, method, url string) []byte {
+	conn, err := net.Dial("unix", "@"+s.name)
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn.Close()
+
+	_, err = fmt.Fprintf(conn, "%s %s HTTP/1.0\r\n\r\n", method, url)
+	c.Assert(err, jc.ErrorIsNil)
+
+	buf, err := ioutil.ReadAll(conn)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf
+}
+
+func (s *raftSuite) TestStatus(c *gc.C) {
+	buf := s.call(c, "GET", "/raft/")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "Raft Status")
+	matches(c, buf, "state: Leader")
+	matches(c, buf, "term: \"3\"")
+	matches(c, buf, "leader: \"0\"")
+	matches(c, buf, "last-log: \"42\"")
+	matches(c, buf, "applied: \"40\"")
+	matches(c, buf, "index: \"30\"")
+	matches(c, buf, "suffrage: Nonvoter")
+}
+
+func (s *raftSuite) TestStatusFollowerShowsLastContact(c *gc.C) {
+	s.node.state = raft.Follower
+	buf := s.call(c, "GET", "/raft/")
+	matches(c, buf, "state: Follower")
+	matches(c, buf, "last-contact: 10ms")
+}
+
+func (s *raftSuite) TestSnapshot(c *gc.C) {
+	buf := s.call(c, "POST", "/raft/snapshot")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "snapshot taken")
+	c.Assert(s.node.snapshots, gc.Equals, 1)
+}
+
+func (s *raftSuite) TestSnapshotRequiresPost(c *gc.C) {
+	buf := s.call(c, "GET", "/raft/snapshot")
+	matches(c, buf, "405 Method Not Allowed")
+	c.Assert(s.node.snapshots, gc.Equals, 0)
+}
+
+func (s *raftSuite) TestSnapshotError(c *gc.C) {
+	s.node.err = errors.New("disk full")
+	buf := s.call(c, "POST", "/raft/snapshot")
+	matches(c, buf, "500 Internal Server Error")
+	matches(c, buf, "error: disk full")
+}
+
+func (s *raftSuite) TestRemoveVoter(c *gc.C) {
+	buf := s.call(c, "POST", "/raft/remove-voter?id=1")
+	matches(c, buf, "200 OK")
+	matches(c, buf, "removed voter 1")
+	c.Assert(s.node.removed, jc.DeepEquals, []raft.ServerID{"1"})
+}
+
+func (s *raftSuite) TestRemoveVoterRefused(c *gc.C) {
+	for _, test := range []struct {
+		url    string
+		status string
+		err    string
+	}{{
+		url:    "/raft/remove-voter",
+		status: "400 Bad Request",
+		err:    "missing id",
+	}, {
+		url:    "/raft/remove-voter?id=9",
+		status: "400 Bad Request",
+		err:    `error: raft server "9" not found`,
+	}, {
+		url:    "/raft/remove-voter?id=2",
+		status: "400 Bad Request",
+		err:    `error: removing Nonvoter server "2" not valid`,
+	}, {
+		url:    "/raft/remove-voter?id=0",
+		status: "400 Bad Request",
+		err:    `error: removing the leader "0" not valid`,
+	}} {
+		buf := s.call(c, "POST", test.url)
+		matches(c, buf, test.status)
+		matches(c, buf, test.err)
+	}
+	c.Assert(s.node.removed, gc.HasLen, 0)
+}
+
+func (s *raftSuite) TestRemoveVoterNotLeader(c *gc.C) {
+	s.node.state = raft.Follower
+	buf := s.call(c, "POST", "/raft/remove-voter?id=1")
+	matches(c, buf, "500 Internal Server Error")
+	matches(c, buf, `error: not the raft leader, run this on "0"`)
+	c.Assert(s.node.removed, gc.HasLen, 0)
+}
+
+func (s *raftSuite) TestNotRunningRaft(c *gc.C) {
+	s.node.stopped = true
+	buf := s.call(c, "GET", "/raft/")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "agent is not running raft")
+}
+
+type fakeRaftIntrospector struct {
+	node *fakeRaftNode
+}
+
+func (r fakeRaftIntrospector) Raft() introspection.RaftNode {
+	if r.node.stopped {
+		return nil
+	}
+	return r.node
+}
+
+type fakeRaftNode struct {
+	state   raft.RaftState
+	leader  raft.ServerAddress
+	stats   map[string]string
+	servers []raft.Server
+	err     error
+	stopped bool
+
+	snapshots int
+	removed   []raft.ServerID
+}
+
+func (n *fakeRaftNode) State() raft.RaftState {
+	return n.state
+}
+
+func (n *fakeRaftNode) Leader() raft.ServerAddress {
+	return n.leader
+}
+
+func (n *fakeRaftNode) Stats() map[string]string {
+	return n.stats
+}
+
+func (n *fakeRaftNode) GetConfiguration() raft.ConfigurationFuture {
+	return fakeConfigurationFuture{
+		fakeFuture:    fakeFuture{},
+		configuration: raft.Configuration{Servers: n.servers},
+	}
+}
+
+func (n *fakeRaftNode) Snapshot() raft.SnapshotFuture {
+	if n.err == nil {
+		n.snapshots++
+	}
+	return fakeSnapshotFuture{fakeFuture{n.err}}
+}
+
+func (n *fakeRaftNode) RemoveServer(id raft.ServerID, prevIndex uint64, timeout time.Duration) raft.IndexFuture {
+	if n.err == nil {
+		n.removed = append(n.removed, id)
+	}
+	return fakeFuture{n.err}
+}
+
+type fakeFuture struct {
+	err error
+}
+
+func (f fakeFuture) Error() error {
+	return f.err
+}
+
+func (f fakeFuture) Index() uint64 {
+	return 0
+}
+
+type fakeConfigurationFuture struct {
+	fakeFuture
+	configuration raft.Configuration
+}
+
+func (f fakeConfigurationFuture) Configuration() raft.Configuration {
+	return f.configuration
+}
+
+type fakeSnapshotFuture struct {
+	fakeFuture
+}
+
+func (f fakeSnapshotFuture) Open() (*raft.SnapshotMeta, io.ReadCloser, error) {
+	return nil, nil, errors.NotImplementedf("opening snapshot")
+}
@@ -107,6 +107,24 @@ juju_statetracker_report () {
   juju_machine_or_unit debug/pprof/juju/state/tracker?debug=1 $@
 }
 
+juju_raft_status () {
+  juju_agent_call $(juju_machine_agent_name) raft/
+}
+
+juju_raft_snapshot () {
+  juju-introspect --post --agent=$(juju_machine_agent_name) raft/snapshot
+}
+
+juju_raft_remove_voter () {
+  if [ "$#" -ne 1 ]; then
+    echo "expected the id of the voter to remove"
+    echo "the voter's machine must already have been removed from the controller,"
+    echo "otherwise it is added back to the raft cluster straight away"
+    return 1
+  fi
+  juju-introspect --post --agent=$(juju_machine_agent_name) "raft/remove-voter?id=$1"
+}
+
 juju_machine_lock () {
   for agent in $(ls /var/lib/juju/agents); do
     juju_machine_or_unit machinelock $agent 2> /dev/null
@@ -130,5 +148,8 @@ if [ "$shell" = "bash" ]; then
   export -f juju_pubsub_report
   export -f juju_presence_report
   export -f juju_machine_lock
+  export -f juju_raft_status
+  export -f juju_raft_snapshot
+  export -f juju_raft_remove_voter
 fi
 `
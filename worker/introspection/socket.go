@@ -48,6 +48,7 @@ type Config struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
+	Raft               RaftIntrospector
 }
 
 // Validate checks the config values to assert they are valid to create the worker.
@@ -71,6 +72,7 @@ type socketListener struct {
 	machineLock        machinelock.Lock
 	prometheusGatherer prometheus.Gatherer
 	presence           presence.Recorder
+	raft               RaftIntrospector
 	done               chan struct{}
 }
 
@@ -104,6 +106,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		machineLock:        config.MachineLock,
 		prometheusGatherer: config.PrometheusGatherer,
 		presence:           config.Presence,
+		raft:               config.Raft,
 		done:               make(chan struct{}),
 	}
 	go w.serve()
@@ -121,6 +124,7 @@ func (w *socketListener) serve() {
 			MachineLock:        w.machineLock,
 			PrometheusGatherer: w.prometheusGatherer,
 			Presence:           w.presence,
+			Raft:               w.raft,
 		}, mux.Handle)
 
 	srv := http.Server{Handler: mux}
@@ -159,6 +163,7 @@ type ReportSources struct {
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
+	Raft               RaftIntrospector
 }
 
 // AddHandlers calls the given function with http.Handlers
@@ -189,6 +194,13 @@ func RegisterHTTPHandlers(
 		handle("/presence/", presenceHandler{sources.Presence})
 	}
 	handle("/machinelock/", machineLockHandler{sources.MachineLock})
+	// Only controller agents run raft, and the operations that change
+	// the cluster are only offered on the agent's own socket.
+	if sources.Raft != nil {
+		handle("/raft/", raftStatusHandler{raftHandler{sources.Raft}})
+		handle("/raft/snapshot", raftSnapshotHandler{raftHandler{sources.Raft}})
+		handle("/raft/remove-voter", raftRemoveVoterHandler{raftHandler{sources.Raft}})
+	}
 }
 
 type depengineHandler struct {
@@ -11,15 +11,9 @@ import (
 	"github.com/juju/juju/core/lease"
 )
 
-// checkerClaimer combines the lease.Checker and lease.Claimer
-// interfaces.
-type checkerClaimer interface {
-	lease.Checker
-	lease.Claimer
-}
-
-// boundManager implements lease.Claimer and lease.Checker - it
-// represents a lease manager for a specific namespace and model.
+// boundManager implements lease.Claimer, lease.Checker, lease.Pinner
+// and lease.Transferrer - it represents a lease manager for a specific
+// namespace and model.
 type boundManager struct {
 	manager   *Manager
 	secretary Secretary
@@ -85,3 +79,71 @@ func (b *boundManager) Token(leaseName, holderName string) lease.Token {
 		stop:       b.manager.catacomb.Dying(),
 	}
 }
+
+// Transfer is part of the lease.Transferrer interface.
+func (b *boundManager) Transfer(leaseName, holderName string, duration time.Duration) error {
+	key := lease.Key{
+		Namespace: b.namespace,
+		ModelUUID: b.modelUUID,
+		Lease:     leaseName,
+	}
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease %q", leaseName)
+	}
+	if err := b.secretary.CheckHolder(holderName); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease to holder %q", holderName)
+	}
+	if err := b.secretary.CheckDuration(duration); err != nil {
+		return errors.Annotatef(err, "cannot transfer lease for %s", duration)
+	}
+	return transfer{
+		leaseKey:   key,
+		holderName: holderName,
+		duration:   duration,
+		response:   make(chan error),
+		stop:       b.manager.catacomb.Dying(),
+	}.invoke(b.manager.transfers)
+}
+
+// PinLease is part of the lease.Pinner interface.
+func (b *boundManager) PinLease(leaseName, entity string) error {
+	return errors.Annotatef(b.pinOp(leaseName, entity, false), "cannot pin lease %q", leaseName)
+}
+
+// UnpinLease is part of the lease.Pinner interface.
+func (b *boundManager) UnpinLease(leaseName, entity string) error {
+	return errors.Annotatef(b.pinOp(leaseName, entity, true), "cannot unpin lease %q", leaseName)
+}
+
+// Pinned is part of the lease.Pinner interface.
+func (b *boundManager) Pinned() map[string][]string {
+	result := make(map[string][]string)
+	for key, entities := range b.manager.config.Store.Pinned() {
+		if key.Namespace != b.namespace || key.ModelUUID != b.modelUUID {
+			continue
+		}
+		result[key.Lease] = entities
+	}
+	return result
+}
+
+func (b *boundManager) pinOp(leaseName, entity string, unpin bool) error {
+	key := lease.Key{
+		Namespace: b.namespace,
+		ModelUUID: b.modelUUID,
+		Lease:     leaseName,
+	}
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Trace(err)
+	}
+	if entity == "" {
+		return errors.NotValidf("empty pin entity")
+	}
+	return pin{
+		leaseKey: key,
+		entity:   entity,
+		unpin:    unpin,
+		response: make(chan error),
+		stop:     b.manager.catacomb.Dying(),
+	}.invoke(b.manager.pins)
+}
@@ -70,6 +70,10 @@ type Fixture struct {
 	// test starts up.
 	leases map[corelease.Key]corelease.Info
 
+	// pinned contains the pinned leases the corelease.Store should
+	// report.
+	pinned map[corelease.Key][]string
+
 	// expectCalls contains the calls that should be made to the corelease.Store
 	// in the course of a test. By specifying a callback you can cause the
 	// reported leases to change.
@@ -86,6 +90,7 @@ type Fixture struct {
 func (fix *Fixture) RunTest(c *gc.C, test func(*lease.Manager, *testclock.Clock)) {
 	clock := testclock.NewClock(defaultClockStart)
 	store := NewStore(fix.leases, fix.expectCalls)
+	store.pinned = fix.pinned
 	manager, err := lease.NewManager(lease.ManagerConfig{
 		Clock: clock,
 		Store: store,
@@ -72,6 +72,9 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		claims:     make(chan claim),
 		checks:     make(chan check),
 		blocks:     make(chan block),
+		pins:       make(chan pin),
+		transfers:  make(chan transfer),
+		releases:   make(chan release),
 		errors:     make(chan error),
 		logContext: logContext,
 	}
@@ -111,6 +114,16 @@ type Manager struct {
 	// blocks is used to deliver expiry block requests to the loop.
 	blocks chan block
 
+	// pins is used to deliver lease pin and unpin requests to the loop.
+	pins chan pin
+
+	// transfers is used to deliver lease transfer requests to the loop.
+	transfers chan transfer
+
+	// releases is used by background transfer goroutines to tell the
+	// loop when blocks on a transferred lease can be released.
+	releases chan release
+
 	// errors is used to send errors from background claim or tick
 	// goroutines back to the main loop.
 	errors chan error
@@ -134,8 +147,9 @@ func (manager *Manager) Wait() error {
 func (manager *Manager) loop() error {
 	defer manager.wg.Wait()
 	blocks := make(blocks)
+	releases := make(releases)
 	for {
-		if err := manager.choose(blocks); err != nil {
+		if err := manager.choose(blocks, releases); err != nil {
 			return errors.Trace(err)
 		}
 
@@ -146,11 +160,24 @@ func (manager *Manager) loop() error {
 				blocks.unblock(leaseName)
 			}
 		}
+
+		// Waiters on a transferred lease are only unblocked once the
+		// previous holder's term has run out; by then it will have
+		// discovered that it no longer holds the lease.
+		now := manager.config.Clock.Now()
+		for leaseName, at := range releases {
+			if at.After(now) {
+				continue
+			}
+			manager.config.Logger.Tracef("[%s] unblocking transferred: %s", manager.logContext, leaseName)
+			delete(releases, leaseName)
+			blocks.unblock(leaseName)
+		}
 	}
 }
 
 // choose breaks the select out of loop to make the blocking logic clearer.
-func (manager *Manager) choose(blocks blocks) error {
+func (manager *Manager) choose(blocks blocks, releases releases) error {
 	select {
 	case <-manager.catacomb.Dying():
 		return manager.catacomb.ErrDying()
@@ -158,12 +185,20 @@ func (manager *Manager) choose(blocks blocks) error {
 		return errors.Trace(err)
 	case check := <-manager.checks:
 		return manager.handleCheck(check)
-	case manager.now = <-manager.nextTick(manager.now):
+	case manager.now = <-manager.nextTick(manager.now, releases):
 		manager.wg.Add(1)
 		go manager.retryingTick(manager.now)
 	case claim := <-manager.claims:
 		manager.wg.Add(1)
 		go manager.retryingClaim(claim)
+	case pin := <-manager.pins:
+		manager.wg.Add(1)
+		go manager.retryingPin(pin)
+	case transfer := <-manager.transfers:
+		manager.wg.Add(1)
+		go manager.retryingTransfer(transfer)
+	case release := <-manager.releases:
+		releases.add(release)
 	case block := <-manager.blocks:
 		// TODO(raftlease): Include the other key items.
 		manager.config.Logger.Tracef("[%s] adding block for: %s", manager.logContext, block.leaseKey.Lease)
@@ -172,7 +207,7 @@ func (manager *Manager) choose(blocks blocks) error {
 	return nil
 }
 
-func (manager *Manager) bind(namespace, modelUUID string) (checkerClaimer, error) {
+func (manager *Manager) bind(namespace, modelUUID string) (*boundManager, error) {
 	secretary, err := manager.config.Secretary(namespace)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -195,6 +230,118 @@ func (manager *Manager) Claimer(namespace, modelUUID string) (lease.Claimer, err
 	return manager.bind(namespace, modelUUID)
 }
 
+// Pinner returns a lease.Pinner for the specified namespace and model.
+func (manager *Manager) Pinner(namespace, modelUUID string) (lease.Pinner, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
+// Transferrer returns a lease.Transferrer for the specified namespace
+// and model.
+func (manager *Manager) Transferrer(namespace, modelUUID string) (lease.Transferrer, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
+// retryingPin handles timeouts when pinning or unpinning, and responds
+// to the requesting party when the operation eventually succeeds or
+// fails. Failures are reported only to the requesting party; they don't
+// stop the manager.
+func (manager *Manager) retryingPin(pin pin) {
+	defer manager.wg.Done()
+	store := manager.config.Store
+	var err error
+	for a := manager.startRetry(); a.Next(); {
+		if pin.unpin {
+			manager.config.Logger.Tracef("[%s] %s unpinning lease %s", manager.logContext, pin.entity, pin.leaseKey.Lease)
+			err = store.UnpinLease(pin.leaseKey, pin.entity)
+		} else {
+			manager.config.Logger.Tracef("[%s] %s pinning lease %s", manager.logContext, pin.entity, pin.leaseKey.Lease)
+			err = store.PinLease(pin.leaseKey, pin.entity)
+		}
+		if !lease.IsTimeout(err) {
+			break
+		}
+		if a.More() {
+			manager.config.Logger.Tracef("[%s] timed out handling pin, retrying...", manager.logContext)
+		}
+	}
+	if lease.IsTimeout(err) {
+		manager.config.Logger.Warningf("[%s] retrying timed out while handling pin", manager.logContext)
+	}
+	pin.respond(errors.Trace(err))
+}
+
+// retryingTransfer handles timeouts when transferring a lease. Once the
+// lease has changed hands, the loop is told when to release any blocks
+// on it before the requesting party is answered. Failures are reported
+// only to the requesting party; they don't stop the manager.
+func (manager *Manager) retryingTransfer(transfer transfer) {
+	defer manager.wg.Done()
+	var (
+		releaseAt time.Time
+		err       error
+	)
+	for a := manager.startRetry(); a.Next(); {
+		releaseAt, err = manager.handleTransfer(transfer)
+		if !lease.IsTimeout(err) {
+			break
+		}
+		if a.More() {
+			manager.config.Logger.Tracef("[%s] timed out handling transfer, retrying...", manager.logContext)
+		}
+	}
+	if lease.IsTimeout(err) {
+		manager.config.Logger.Warningf("[%s] retrying timed out while handling transfer", manager.logContext)
+	}
+	if err == nil && !releaseAt.IsZero() {
+		select {
+		case <-manager.catacomb.Dying():
+			return
+		case manager.releases <- release{leaseKey: transfer.leaseKey, at: releaseAt}:
+		}
+	}
+	transfer.respond(errors.Trace(err))
+}
+
+// handleTransfer hands the lease to the transfer's holder, and returns
+// the time at which the previous holder's term runs out. The new holder
+// is granted the requested duration on top of that term, so that it has
+// the chance to extend the lease once it has been told it holds it.
+func (manager *Manager) handleTransfer(transfer transfer) (time.Time, error) {
+	store := manager.config.Store
+	err := lease.ErrInvalid
+	var info lease.Info
+	for lease.IsInvalid(err) {
+		select {
+		case <-manager.catacomb.Dying():
+			return time.Time{}, manager.catacomb.ErrDying()
+		default:
+			var found bool
+			info, found = store.Leases()[transfer.leaseKey]
+			switch {
+			case !found:
+				return time.Time{}, lease.ErrNotHeld
+			case info.Holder == transfer.holderName:
+				manager.config.Logger.Tracef("[%s] %s already holds lease %s", manager.logContext, transfer.holderName, transfer.leaseKey.Lease)
+				return time.Time{}, nil
+			}
+			if _, pinned := store.Pinned()[transfer.leaseKey]; pinned {
+				return time.Time{}, lease.ErrPinned
+			}
+			remaining := info.Expiry.Sub(manager.config.Clock.Now())
+			if remaining < 0 {
+				remaining = 0
+			}
+			manager.config.Logger.Tracef("[%s] transferring lease %s from %s to %s", manager.logContext, transfer.leaseKey.Lease, info.Holder, transfer.holderName)
+			request := lease.Request{transfer.holderName, remaining + transfer.duration}
+			err = store.TransferLease(transfer.leaseKey, info.Holder, request)
+		}
+	}
+	if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return info.Expiry, nil
+}
+
 // retryingClaim handles timeouts when claiming, and responds to the
 // claiming party when it eventually succeeds or fails, or if it times
 // out after a number of retries.
@@ -308,9 +455,10 @@ func (manager *Manager) handleCheck(check check) error {
 
 // nextTick returns a channel that will send a value at some point when
 // we expect to have to do some work; either because at least one lease
-// may be ready to expire, or because enough enough time has passed that
-// it's worth checking for stalled collaborators.
-func (manager *Manager) nextTick(lastTick time.Time) <-chan time.Time {
+// may be ready to expire or be released after a transfer, or because
+// enough enough time has passed that it's worth checking for stalled
+// collaborators.
+func (manager *Manager) nextTick(lastTick time.Time, releases releases) <-chan time.Time {
 	now := manager.config.Clock.Now()
 	nextTick := now.Add(manager.config.MaxSleep)
 	leases := manager.config.Store.Leases()
@@ -327,6 +475,11 @@ func (manager *Manager) nextTick(lastTick time.Time) <-chan time.Time {
 		}
 		nextTick = info.Expiry
 	}
+	for _, at := range releases {
+		if at.Before(nextTick) {
+			nextTick = at
+		}
+	}
 	return clock.Alarm(manager.config.Clock, nextTick)
 }
 
@@ -0,0 +1,139 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/lease"
+)
+
+type PinSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&PinSuite{})
+
+func (s *PinSuite) TestPinLease_Success(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "machine-0"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).PinLease("redis", "machine-0")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPinLease_Timeout(c *gc.C) {
+	// When a pin times out we retry.
+	pinCalls := make(chan struct{})
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "machine-0"},
+			err:    corelease.ErrTimeout,
+			callback: func(_ leaseMap) {
+				select {
+				case pinCalls <- struct{}{}:
+				case <-time.After(coretesting.LongWait):
+					c.Fatalf("timed out sending pin")
+				}
+			},
+		}, {
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "machine-0"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testclock.Clock) {
+		result := make(chan error)
+		go func() {
+			result <- getPinner(c, manager).PinLease("redis", "machine-0")
+		}()
+
+		select {
+		case <-pinCalls:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for pin")
+		}
+
+		// As with claims, there are alarms from two passes around the
+		// main loop as well as the pin retry timer.
+		err := clock.WaitAdvance(50*time.Millisecond, coretesting.LongWait, 3)
+		c.Assert(err, jc.ErrorIsNil)
+
+		select {
+		case err := <-result:
+			c.Assert(err, jc.ErrorIsNil)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for response")
+		}
+	})
+}
+
+func (s *PinSuite) TestPinLease_Error(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "PinLease",
+			args:   []interface{}{key("redis"), "machine-0"},
+			err:    errors.NotImplementedf("lease pinning"),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).PinLease("redis", "machine-0")
+		c.Check(err, gc.ErrorMatches, `cannot pin lease "redis": lease pinning not implemented`)
+		c.Check(err, jc.Satisfies, errors.IsNotImplemented)
+	})
+}
+
+func (s *PinSuite) TestPinLease_InvalidLease(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).PinLease("INVALID", "machine-0")
+		c.Check(err, gc.ErrorMatches, `cannot pin lease "INVALID": name not valid`)
+	})
+}
+
+func (s *PinSuite) TestUnpinLease_Success(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "UnpinLease",
+			args:   []interface{}{key("redis"), "machine-0"},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getPinner(c, manager).UnpinLease("redis", "machine-0")
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *PinSuite) TestPinned(c *gc.C) {
+	fix := &Fixture{
+		pinned: map[corelease.Key][]string{
+			key("redis"):                           {"machine-0", "user-admin"},
+			key("namespace", "otherUUID", "pgsql"): {"machine-1"},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		c.Check(getPinner(c, manager).Pinned(), jc.DeepEquals, map[string][]string{
+			"redis": {"machine-0", "user-admin"},
+		})
+	})
+}
+
+func getPinner(c *gc.C, manager *lease.Manager) corelease.Pinner {
+	pinner, err := manager.Pinner("namespace", "modelUUID")
+	c.Assert(err, jc.ErrorIsNil)
+	return pinner
+}
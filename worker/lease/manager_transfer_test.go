@@ -0,0 +1,185 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type TransferSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&TransferSuite{})
+
+func (s *TransferSuite) TestTransfer_Success(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute + time.Second},
+			},
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Minute + time.Second),
+				}
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_ReleasesBlocksAfterPreviousTerm(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute + time.Second},
+			},
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/1",
+					Expiry: offset(time.Minute + time.Second),
+				}
+			},
+		}, {
+			method: "Refresh",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, clock *testclock.Clock) {
+		blockTest := newBlockTest(manager, key("redis"))
+		blockTest.assertBlocked(c)
+
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+		blockTest.assertBlocked(c)
+
+		// Once the previous holder's term has run out, waiters are
+		// released even though the lease is still held.
+		clock.Advance(time.Second)
+		err = blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_AlreadyHolder(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/1",
+				Expiry: offset(time.Second),
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_NotHeld(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_Pinned(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		pinned: map[corelease.Key][]string{
+			key("redis"): {"machine-0"},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrPinned)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_HolderChanged(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/0",
+				corelease.Request{"redis/1", time.Minute + time.Second},
+			},
+			err: corelease.ErrInvalid,
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				leases[key("redis")] = corelease.Info{
+					Holder: "redis/2",
+					Expiry: offset(2 * time.Second),
+				}
+			},
+		}, {
+			method: "TransferLease",
+			args: []interface{}{
+				key("redis"),
+				"redis/2",
+				corelease.Request{"redis/1", time.Minute + 2*time.Second},
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "redis/1", time.Minute)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *TransferSuite) TestTransfer_InvalidHolder(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getTransferrer(c, manager).Transfer("redis", "INVALID", time.Minute)
+		c.Check(err, gc.ErrorMatches, `cannot transfer lease to holder "INVALID": name not valid`)
+	})
+}
+
+func getTransferrer(c *gc.C, manager *lease.Manager) corelease.Transferrer {
+	transferrer, err := manager.Transferrer("namespace", "modelUUID")
+	c.Assert(err, jc.ErrorIsNil)
+	return transferrer
+}
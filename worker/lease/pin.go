@@ -0,0 +1,40 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"github.com/juju/juju/core/lease"
+)
+
+// pin is used to deliver lease pin and unpin requests to a manager's
+// loop goroutine on behalf of PinLease and UnpinLease.
+type pin struct {
+	leaseKey lease.Key
+	entity   string
+	unpin    bool
+	response chan error
+	stop     <-chan struct{}
+}
+
+// invoke sends the pin on the supplied channel and waits for a response.
+func (p pin) invoke(ch chan<- pin) error {
+	for {
+		select {
+		case <-p.stop:
+			return errStopped
+		case ch <- p:
+			ch = nil
+		case err := <-p.response:
+			return err
+		}
+	}
+}
+
+// respond causes the supplied error to be sent back to invoke.
+func (p pin) respond(err error) {
+	select {
+	case <-p.stop:
+	case p.response <- err:
+	}
+}
@@ -47,6 +47,7 @@ func (Secretary) CheckDuration(duration time.Duration) error {
 type Store struct {
 	mu           sync.Mutex
 	leases       map[lease.Key]lease.Info
+	pinned       map[lease.Key][]string
 	expect       []call
 	failed       chan error
 	runningCalls int
@@ -166,6 +167,32 @@ func (store *Store) ExpireLease(key lease.Key) error {
 	return store.call("ExpireLease", []interface{}{key})
 }
 
+// TransferLease is part of the corelease.Store interface.
+func (store *Store) TransferLease(key lease.Key, holder string, request lease.Request) error {
+	return store.call("TransferLease", []interface{}{key, holder, request})
+}
+
+// PinLease is part of the corelease.Store interface.
+func (store *Store) PinLease(key lease.Key, entity string) error {
+	return store.call("PinLease", []interface{}{key, entity})
+}
+
+// UnpinLease is part of the corelease.Store interface.
+func (store *Store) UnpinLease(key lease.Key, entity string) error {
+	return store.call("UnpinLease", []interface{}{key, entity})
+}
+
+// Pinned is part of the corelease.Store interface.
+func (store *Store) Pinned() map[lease.Key][]string {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	result := make(map[lease.Key][]string)
+	for k, v := range store.pinned {
+		result[k] = v
+	}
+	return result
+}
+
 // Refresh is part of the lease.Store interface.
 func (store *Store) Refresh() error {
 	return store.call("Refresh", nil)
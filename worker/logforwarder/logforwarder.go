@@ -40,9 +40,6 @@ type sender interface {
 	Send([]logfwd.Record) error
 }
 
-// TODO(ericsnow) It is likely that eventually we will want to support
-// multiplexing to multiple senders, each in its own goroutine (or worker).
-
 // LogForwarder is a worker that forwards log records from a source
 // to a sender.
 type LogForwarder struct {
@@ -67,6 +64,10 @@ type OpenLogForwarderArgs struct {
 	// Name is the name given to the log sink.
 	Name string
 
+	// SinkConfig is the function that returns the configuration of
+	// the log sink. If nil, the syslog configuration is used.
+	SinkConfig LogSinkConfigFn
+
 	// OpenSink is the function that opens the underlying log sink that
 	// will be wrapped.
 	OpenSink LogSinkFn
@@ -76,7 +77,7 @@ type OpenLogForwarderArgs struct {
 	OpenLogStream LogStreamFn
 }
 
-// processNewConfig acts on a new syslog forward config change.
+// processNewConfig acts on a new log forward config change.
 func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser, error) {
 	lf.mu.Lock()
 	defer lf.mu.Unlock()
@@ -91,13 +92,17 @@ func (lf *LogForwarder) processNewConfig(currentSender SendCloser) (SendCloser,
 	}
 
 	// Get the new config and set up log forwarding if enabled.
-	cfg, ok, err := lf.args.LogForwardConfig.LogForwardConfig()
+	sinkConfig := lf.args.SinkConfig
+	if sinkConfig == nil {
+		sinkConfig = SyslogConfig
+	}
+	cfg, enabled, err := sinkConfig(lf.args.LogForwardConfig)
 	if err != nil {
 		closeExisting()
 		return nil, errors.Trace(err)
 	}
-	if !ok || !cfg.Enabled {
-		logger.Infof("config change - log forwarding not enabled")
+	if !enabled {
+		logger.Infof("config change - log forwarding to %s not enabled", lf.args.Name)
 		return nil, closeExisting()
 	}
 	// If the config is not valid, we don't want to exit with an error
@@ -145,7 +150,7 @@ func (lf *LogForwarder) waitForEnabled() (bool, error) {
 	defer lf.mu.Unlock()
 
 	if !lf.enabled && enabled {
-		logger.Infof("log forward enabled, starting to stream logs to syslog sink")
+		logger.Infof("log forward enabled, starting to stream logs to %s sink", lf.args.Name)
 	}
 	lf.enabled = enabled
 	return enabled, nil
@@ -230,7 +235,7 @@ func (lf *LogForwarder) loop() error {
 			return lf.catacomb.ErrDying()
 		case _, ok := <-configWatcher.Changes():
 			if !ok {
-				return errors.New("syslog configuration watcher closed")
+				return errors.New("log forward configuration watcher closed")
 			}
 			if sender, err = lf.processNewConfig(sender); err != nil {
 				return errors.Trace(err)
@@ -17,6 +17,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/logfwd"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
@@ -85,8 +87,8 @@ func (s *LogForwarderSuite) newLogForwarderArgsWithAPI(
 		Caller:           &mockCaller{},
 		LogForwardConfig: configAPI,
 		ControllerUUID:   "feebdaed-2f18-4fd2-967d-db9663db7bea",
-		OpenSink: func(cfg *syslog.RawConfig) (*logforwarder.LogSink, error) {
-			sender.host = cfg.Host
+		OpenSink: func(cfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+			sender.host = cfg.(*syslog.RawConfig).Host
 			sink := &logforwarder.LogSink{
 				sender,
 			}
@@ -165,6 +167,44 @@ func (s *LogForwarderSuite) TestNotEnabled(c *gc.C) {
 	s.sender.stub.CheckCallNames(c)
 }
 
+func (s *LogForwarderSuite) TestHTTPSink(c *gc.C) {
+	s.stream.addRecords(c, s.rec)
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.Name = "juju-log-forward-http"
+	args.SinkConfig = logforwarder.HTTPConfig
+	args.OpenSink = func(cfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+		s.sender.host = cfg.(*httpjson.RawConfig).URL
+		return &logforwarder.LogSink{s.sender}, nil
+	}
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, lf)
+
+	s.sender.waitForSend(c)
+	workertest.CleanKill(c, lf)
+
+	rec := s.rec
+	rec.Message = "send to https://10.0.0.1/ingest"
+	s.sender.stub.CheckCalls(c, []testing.StubCall{
+		{"Send", []interface{}{[]logfwd.Record{rec}}},
+		{"Close", nil},
+	})
+}
+
+func (s *LogForwarderSuite) TestSinkNotConfigured(c *gc.C) {
+	args := s.newLogForwarderArgs(c, s.stream, s.sender)
+	args.SinkConfig = logforwarder.FileConfig
+	lf, err := logforwarder.NewLogForwarder(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	time.Sleep(coretesting.ShortWait)
+	workertest.CleanKill(c, lf)
+
+	// There is no file forwarding config, so nothing is sent.
+	s.stream.stub.CheckCallNames(c)
+	s.sender.stub.CheckCallNames(c)
+}
+
 func (s *LogForwarderSuite) TestStreamError(c *gc.C) {
 	failure := errors.New("<failure>")
 	s.stream.stub.SetErrors(nil, failure)
@@ -258,6 +298,17 @@ func (c *mockLogForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, erro
 	}, true, nil
 }
 
+func (c *mockLogForwardConfig) LogForwardHTTPConfig() (*httpjson.RawConfig, bool, error) {
+	return &httpjson.RawConfig{
+		Enabled: c.enabled,
+		URL:     "https://" + c.host + "/ingest",
+	}, true, nil
+}
+
+func (c *mockLogForwardConfig) LogForwardFileConfig() (*logfile.RawConfig, bool, error) {
+	return nil, false, nil
+}
+
 type stubStream struct {
 	stub     *testing.Stub
 	nextRecs chan logfwd.Record
@@ -5,12 +5,15 @@ package logforwarder
 
 import (
 	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/api/base"
 )
 
+// orchestrator runs a log forwarder for each configured log sink.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -38,21 +41,51 @@ type OrchestratorArgs struct {
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
-	if len(args.Sinks) == 0 {
-		return nil, nil
+	// Each sink gets its own forwarder, and so its own log stream and
+	// last-sent tracking, keyed by the sink name.
+	var forwarders []worker.Worker
+	for _, spec := range args.Sinks {
+		lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   args.ControllerUUID,
+			LogForwardConfig: args.LogForwardConfig,
+			Caller:           args.Caller,
+			Name:             spec.Name,
+			SinkConfig:       spec.ConfigFn,
+			OpenSink:         spec.OpenFn,
+			OpenLogStream:    args.OpenLogStream,
+		})
+		if err != nil {
+			for _, w := range forwarders {
+				worker.Stop(w)
+			}
+			return nil, errors.Annotatef(err, "opening log forwarder %q", spec.Name)
+		}
+		forwarders = append(forwarders, lf)
 	}
-	if len(args.Sinks) > 1 {
-		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
-	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+
+	o := &orchestrator{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	<-o.catacomb.Dying()
+	return o.catacomb.ErrDying()
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
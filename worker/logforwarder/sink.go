@@ -5,6 +5,8 @@ package logforwarder
 
 import (
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
 	"github.com/juju/juju/logfwd/syslog"
 )
 
@@ -14,22 +16,72 @@ type LogForwardConfig interface {
 	// log forward configuration to change.
 	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
 
-	// LogForwardConfig returns the current log forward configuration.
+	// LogForwardConfig returns the current syslog log forward configuration.
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
+
+	// LogForwardHTTPConfig returns the current HTTP log forward configuration.
+	LogForwardHTTPConfig() (*httpjson.RawConfig, bool, error)
+
+	// LogForwardFileConfig returns the current file log forward configuration.
+	LogForwardFileConfig() (*logfile.RawConfig, bool, error)
+}
+
+// SinkConfig is the configuration of a single log sink.
+type SinkConfig interface {
+	// Validate ensures that the config is currently valid.
+	Validate() error
 }
 
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
 
+	// ConfigFn is a function that extracts the sink's configuration
+	// from the log forward configuration.
+	ConfigFn LogSinkConfigFn
+
 	// OpenFn is a function that opens a log sink.
 	OpenFn LogSinkFn
 }
 
+// LogSinkConfigFn is a function that returns the configuration of a log
+// sink, and whether forwarding to that sink is enabled.
+type LogSinkConfigFn func(LogForwardConfig) (SinkConfig, bool, error)
+
 // LogSinkFn is a function that opens a log sink.
-type LogSinkFn func(cfg *syslog.RawConfig) (*LogSink, error)
+type LogSinkFn func(cfg SinkConfig) (*LogSink, error)
 
 // LogSink is a single log sink, to which log records may be sent.
 type LogSink struct {
 	SendCloser
 }
+
+// SyslogConfig is a LogSinkConfigFn that returns the syslog forwarding
+// configuration.
+func SyslogConfig(api LogForwardConfig) (SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardConfig()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return cfg, cfg.Enabled, nil
+}
+
+// HTTPConfig is a LogSinkConfigFn that returns the HTTP forwarding
+// configuration.
+func HTTPConfig(api LogForwardConfig) (SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardHTTPConfig()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return cfg, cfg.Enabled, nil
+}
+
+// FileConfig is a LogSinkConfigFn that returns the file forwarding
+// configuration.
+func FileConfig(api LogForwardConfig) (SinkConfig, bool, error) {
+	cfg, ok, err := api.LogForwardFileConfig()
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return cfg, cfg.Enabled, nil
+}
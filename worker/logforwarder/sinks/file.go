@@ -0,0 +1,30 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/worker/logforwarder"
+)
+
+// OpenFile returns a sink that writes log records to a rotating file
+// on the controller.
+func OpenFile(sinkCfg logforwarder.SinkConfig) (*logforwarder.LogSink, error) {
+	cfg, ok := sinkCfg.(*logfile.RawConfig)
+	if !ok {
+		return nil, errors.Errorf("expected file log forwarding config, got %T", sinkCfg)
+	}
+	if !cfg.Enabled {
+		return nil, errors.New("log forwarding not enabled")
+	}
+	client, err := logfile.Open(*cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &logforwarder.LogSink{
+		SendCloser: client,
+	}, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sinks_test
+
+import (
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/logfwd/httpjson"
+	"github.com/juju/juju/logfwd/logfile"
+	"github.com/juju/juju/logfwd/syslog"
+	"github.com/juju/juju/worker/logforwarder/sinks"
+)
+
+type SinksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SinksSuite{})
+
+func (s *SinksSuite) TestOpenHTTP(c *gc.C) {
+	sink, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		Enabled: true,
+		URL:     "https://logs.example.com/ingest",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.SendCloser, gc.FitsTypeOf, &httpjson.Client{})
+}
+
+func (s *SinksSuite) TestOpenHTTPNotEnabled(c *gc.C) {
+	_, err := sinks.OpenHTTP(&httpjson.RawConfig{
+		URL: "https://logs.example.com/ingest",
+	})
+	c.Assert(err, gc.ErrorMatches, "log forwarding not enabled")
+}
+
+func (s *SinksSuite) TestOpenHTTPWrongConfig(c *gc.C) {
+	_, err := sinks.OpenHTTP(&syslog.RawConfig{})
+	c.Assert(err, gc.ErrorMatches, `expected HTTP log forwarding config, got \*syslog.RawConfig`)
+}
+
+func (s *SinksSuite) TestOpenFile(c *gc.C) {
+	sink, err := sinks.OpenFile(&logfile.RawConfig{
+		Enabled: true,
+		Path:    filepath.Join(c.MkDir(), "models.log"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sink.SendCloser, gc.FitsTypeOf, &logfile.Client{})
+	c.Assert(sink.Close(), jc.ErrorIsNil)
+}
+
+func (s *SinksSuite) TestOpenFileWrongConfig(c *gc.C) {
+	_, err := sinks.OpenFile(&httpjson.RawConfig{})
+	c.Assert(err, gc.ErrorMatches, `expected file log forwarding config, got \*httpjson.RawConfig`)
+}
+
+func (s *SinksSuite) TestOpenSyslogWrongConfig(c *gc.C) {
+	_, err := sinks.OpenSyslog(&logfile.RawConfig{})
+	c.Assert(err, gc.ErrorMatches, `expected syslog config, got \*logfile.RawConfig`)
+}
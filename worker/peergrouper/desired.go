@@ -10,9 +10,11 @@ import (
 	"strings"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/replicaset"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
 )
@@ -40,6 +42,32 @@ type peerGroupInfo struct {
 	maxMemberId int
 	mongoPort   int
 	haSpace     network.SpaceName
+	policy      memberPolicy
+}
+
+// memberPolicy holds the controller configuration that shapes the
+// voting membership of the peer group.
+type memberPolicy struct {
+	// preferredPrimaries holds the ids of machines that should be
+	// preferred as primary, most preferred first.
+	preferredPrimaries []string
+
+	// observers holds the ids of machines that should be kept as
+	// non-voting members.
+	observers set.Strings
+}
+
+// priority returns the Mongo priority for the machine with the given id
+// when it is voting. Preferred primaries are given priorities above the
+// default of 1, in order of preference; nil is returned for the rest.
+func (p memberPolicy) priority(id string) *float64 {
+	for i, preferred := range p.preferredPrimaries {
+		if preferred == id {
+			priority := float64(len(p.preferredPrimaries)-i) + 1
+			return &priority
+		}
+	}
+	return nil
 }
 
 // desiredChanges tracks the specific changes we are asking to be made to the peer group.
@@ -58,6 +86,9 @@ type desiredChanges struct {
 	// time. Also, when machines are first added to the replicaset, we wait to give them voting rights for when they
 	// have managed to sync the data from the current primary.
 	machineVoting map[string]bool
+
+	// reasons records, for each machine, why it has the membership it does.
+	reasons map[string]string
 }
 
 // peerGroupChanges tracks the process of computing the desiredChanges to the peer group.
@@ -82,6 +113,7 @@ func newPeerGroupInfo(
 	members []replicaset.Member,
 	mongoPort int,
 	haSpace network.SpaceName,
+	policy memberPolicy,
 ) (*peerGroupInfo, error) {
 	if len(members) == 0 {
 		return nil, fmt.Errorf("current member set is empty")
@@ -94,6 +126,7 @@ func newPeerGroupInfo(
 		maxMemberId: -1,
 		mongoPort:   mongoPort,
 		haSpace:     haSpace,
+		policy:      policy,
 	}
 
 	// Iterate over the input members and associate them with a machine if
@@ -193,6 +226,7 @@ func desiredPeerGroup(info *peerGroupInfo) (desiredChanges, error) {
 			stepDownPrimary: false,
 			machineVoting:   map[string]bool{},
 			members:         map[string]*replicaset.Member{},
+			reasons:         map[string]string{},
 		},
 	}
 	return peerChanges.computeDesiredPeerGroup()
@@ -225,6 +259,7 @@ func (p *peerGroupChanges) computeDesiredPeerGroup() (desiredChanges, error) {
 	// this will trigger a peer group election.
 	p.getMachinesVoting()
 	p.adjustVotes()
+	p.adjustPriorities()
 
 	if err := p.updateAddresses(); err != nil {
 		return desiredChanges{}, errors.Trace(err)
@@ -295,28 +330,35 @@ func (p *peerGroupChanges) possiblePeerGroupChanges() {
 		machineIds = append(machineIds, id)
 	}
 	sortAsInts(machineIds)
+	observers := p.observers()
 	logger.Debugf("assessing possible peer group changes:")
 	for _, id := range machineIds {
 		m := p.info.machines[id]
 		member := p.desired.members[id]
 		isVoting := member != nil && isVotingMember(member)
-		wantsVote := m.WantsVote()
+		isObserver := observers.Contains(id)
+		wantsVote := m.WantsVote() && !isObserver
 		switch {
 		case wantsVote && isVoting:
 			logger.Debugf("machine %q is already voting", id)
 			p.toKeepVoting = append(p.toKeepVoting, id)
+			p.desired.reasons[id] = "voting"
 		case wantsVote && !isVoting:
 			if status, ok := p.info.statuses[id]; ok && isReady(status) {
 				logger.Debugf("machine %q is a potential voter", id)
 				p.toAddVote = append(p.toAddVote, id)
+				p.desired.reasons[id] = "ready, given the vote"
 			} else if member != nil {
 				logger.Debugf("machine %q exists but is not ready (status: %v, healthy: %v)",
 					id, status.State, status.Healthy)
 				p.toKeepNonVoting = append(p.toKeepNonVoting, id)
+				p.desired.reasons[id] = fmt.Sprintf("not voting until ready (state: %v, healthy: %v)",
+					status.State, status.Healthy)
 			} else {
 				logger.Debugf("machine %q does not exist and is not ready (status: %v, healthy: %v)",
 					id, status.State, status.Healthy)
 				p.toKeepCreateNonVotingMember = append(p.toKeepCreateNonVotingMember, id)
+				p.desired.reasons[id] = "added as a non-voting member until ready"
 			}
 		case !wantsVote && isVoting:
 			p.toRemoveVote = append(p.toRemoveVote, id)
@@ -326,14 +368,69 @@ func (p *peerGroupChanges) possiblePeerGroupChanges() {
 			} else {
 				logger.Debugf("machine %q is a potential non-voter", id)
 			}
+			p.desired.reasons[id] = notWantingVoteReason(isObserver)
 		case !wantsVote && !isVoting:
 			logger.Debugf("machine %q does not want the vote", id)
 			p.toKeepNonVoting = append(p.toKeepNonVoting, id)
+			p.desired.reasons[id] = notWantingVoteReason(isObserver)
+		}
+		if p.info.policy.observers.Contains(id) && !isObserver {
+			p.desired.reasons[id] += ", " + ignoredObserverMessage
 		}
 	}
 	logger.Debugf("assessed")
 }
 
+// minObserverVoters is the fewest machines that must be left wanting the
+// vote for the observers set by the controller config to be honoured.
+// With fewer, keeping an odd number of voters could leave a single one,
+// and the controller would no longer survive the loss of a machine.
+const minObserverVoters = 3
+
+// observers returns the ids of the machines to keep as non-voting
+// observers. The observers set by the controller config are ignored if
+// making them observers would leave fewer than minObserverVoters of the
+// machines that want the vote.
+func (p *peerGroupChanges) observers() set.Strings {
+	observers := p.info.policy.observers
+	if observers.IsEmpty() {
+		return observers
+	}
+	wanting, voters := 0, 0
+	for id, m := range p.info.machines {
+		if !m.WantsVote() {
+			continue
+		}
+		wanting++
+		if !observers.Contains(id) {
+			voters++
+		}
+	}
+	if voters == wanting || voters >= minObserverVoters {
+		return observers
+	}
+	logger.Warningf(
+		"ignoring %s: only %d of the %d controller machines would be left voting, at least %d are needed",
+		controller.JujuHAObservers, voters, wanting, minObserverVoters,
+	)
+	return set.NewStrings()
+}
+
+// observerMessage describes a machine that has been made an observer
+// by the controller config.
+const observerMessage = "non-voting observer, set by " + controller.JujuHAObservers
+
+// ignoredObserverMessage describes a machine that was not made an
+// observer, as too few voters would be left.
+const ignoredObserverMessage = controller.JujuHAObservers + " ignored, as fewer than 3 machines would be left voting"
+
+func notWantingVoteReason(isObserver bool) string {
+	if isObserver {
+		return observerMessage
+	}
+	return "does not want the vote"
+}
+
 func isReady(status replicaset.MemberStatus) bool {
 	return status.Healthy && (status.State == replicaset.PrimaryState ||
 		status.State == replicaset.SecondaryState)
@@ -363,6 +460,7 @@ func (p *peerGroupChanges) reviewPeerGroupChanges() {
 			} else {
 				logger.Debugf("asked to remove all voters, preserving primary voter %q", id)
 				p.desired.stepDownPrimary = false
+				p.desired.reasons[id] = "primary, keeping the vote until there is another voter"
 			}
 		}
 		p.toRemoveVote = tempToRemove
@@ -377,6 +475,7 @@ func (p *peerGroupChanges) reviewPeerGroupChanges() {
 		last := p.toAddVote[len(p.toAddVote)-1]
 		logger.Debugf("number of voters would be even, not adding %q to maintain odd", last)
 		p.toAddVote = p.toAddVote[:len(p.toAddVote)-1]
+		p.desired.reasons[last] = "ready, but not voting to keep an odd number of voters"
 		return
 	}
 	// we must remove an extra peer
@@ -385,6 +484,7 @@ func (p *peerGroupChanges) reviewPeerGroupChanges() {
 		if !isPrimaryMember(p.info, id) {
 			p.toRemoveVote = append(p.toRemoveVote, id)
 			logger.Debugf("removing vote from %q to maintain odd number of voters", id)
+			p.desired.reasons[id] = "vote removed to keep an odd number of voters"
 			if i == len(p.toKeepVoting)-1 {
 				p.toKeepVoting = p.toKeepVoting[:i]
 			} else {
@@ -435,6 +535,37 @@ func (p *peerGroupChanges) adjustVotes() {
 	setVoting(p.toKeepCreateNonVotingMember, false)
 }
 
+// adjustPriorities gives the voting members the priorities set by the
+// member policy, so that Mongo elects the most preferred available
+// machine as primary. Non-voting members always have priority 0.
+func (p *peerGroupChanges) adjustPriorities() {
+	for id, member := range p.desired.members {
+		if !p.desired.machineVoting[id] {
+			continue
+		}
+		priority := p.info.policy.priority(id)
+		if priority != nil {
+			p.desired.reasons[id] += fmt.Sprintf(", preferred primary (priority %v)", *priority)
+		}
+		if !samePriority(member.Priority, priority) {
+			member.Priority = priority
+			p.desired.isChanged = true
+		}
+	}
+}
+
+// samePriority reports whether two member priorities are the same,
+// treating nil as the default of 1.
+func samePriority(a, b *float64) bool {
+	value := func(priority *float64) float64 {
+		if priority == nil {
+			return 1
+		}
+		return *priority
+	}
+	return value(a) == value(b)
+}
+
 // createMembers from a list of member IDs, instantiate a new replica-set
 // member and add it to members map with the given ID.
 func (p *peerGroupChanges) createNonVotingMember() {
@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/replicaset"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -34,6 +35,7 @@ type desiredPeerGroupTest struct {
 	machines []*machineTracker
 	statuses []replicaset.MemberStatus
 	members  []replicaset.Member
+	policy   memberPolicy
 
 	expectChanged  bool
 	expectStepDown bool
@@ -329,10 +331,76 @@ func desiredPeerGroupTests(ipVersion TestIPVersion) []desiredPeerGroupTest {
 			expectMembers:  mkMembers("1 2 3v", ipVersion),
 			expectStepDown: true,
 			expectChanged:  true,
+		}, {
+			about:         "observer loses its vote",
+			machines:      mkMachines("11v 12v 13v 14v 15v", ipVersion),
+			members:       mkMembers("1v 2v 3v 4v 5v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4s 5s", ipVersion),
+			policy:        memberPolicy{observers: set.NewStrings("15")},
+			expectVoting:  []bool{true, false, true, true, false},
+			expectMembers: mkMembers("1v 2 3v 4v 5", ipVersion),
+			expectChanged: true,
+		}, {
+			about:          "primary made an observer steps down",
+			machines:       mkMachines("11v 12v 13v 14v 15v", ipVersion),
+			members:        mkMembers("1v 2v 3v 4v 5v", ipVersion),
+			statuses:       mkStatuses("1p 2s 3s 4s 5s", ipVersion),
+			policy:         memberPolicy{observers: set.NewStrings("11")},
+			expectVoting:   []bool{false, false, true, true, true},
+			expectMembers:  mkMembers("1 2 3v 4v 5v", ipVersion),
+			expectStepDown: true,
+			expectChanged:  true,
+		}, {
+			about:         "observers are not given the vote when ready",
+			machines:      mkMachines("11v 12v 13v 14v 15v", ipVersion),
+			members:       mkMembers("1v 2 3 4 5", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s 4s 5s", ipVersion),
+			policy:        memberPolicy{observers: set.NewStrings("14", "15")},
+			expectVoting:  []bool{true, true, true, false, false},
+			expectMembers: mkMembers("1v 2v 3v 4 5", ipVersion),
+			expectChanged: true,
+		}, {
+			about:         "observer keeps its vote when fewer than three voters would be left",
+			machines:      mkMachines("11v 12v 13v", ipVersion),
+			members:       mkMembers("1v 2v 3v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s", ipVersion),
+			policy:        memberPolicy{observers: set.NewStrings("13")},
+			expectVoting:  []bool{true, true, true},
+			expectMembers: mkMembers("1v 2v 3v", ipVersion),
+			expectChanged: false,
+		}, {
+			about:         "preferred primaries are given priorities in order",
+			machines:      mkMachines("11v 12v 13v", ipVersion),
+			members:       mkMembers("1v 2v 3v", ipVersion),
+			statuses:      mkStatuses("1p 2s 3s", ipVersion),
+			policy:        memberPolicy{preferredPrimaries: []string{"13", "12"}},
+			expectVoting:  []bool{true, true, true},
+			expectMembers: withPriorities(mkMembers("1v 2v 3v", ipVersion), map[int]float64{2: 2, 3: 3}),
+			expectChanged: true,
+		}, {
+			about:         "non-voting preferred primary is not given a priority",
+			machines:      mkMachines("11v 12v 13v", ipVersion),
+			members:       mkMembers("1v 2 3", ipVersion),
+			statuses:      mkStatuses("1p 2s 3sH", ipVersion),
+			policy:        memberPolicy{preferredPrimaries: []string{"13"}},
+			expectVoting:  []bool{true, false, false},
+			expectMembers: mkMembers("1v 2 3", ipVersion),
+			expectChanged: false,
 		},
 	}
 }
 
+// withPriorities sets the priorities of the members with the given
+// replica-set ids.
+func withPriorities(members []replicaset.Member, priorities map[int]float64) []replicaset.Member {
+	for i := range members {
+		if priority, ok := priorities[members[i].Id]; ok {
+			members[i].Priority = newFloat64(priority)
+		}
+	}
+	return members
+}
+
 func (s *desiredPeerGroupSuite) TestDesiredPeerGroupIPv4(c *gc.C) {
 	s.doTestDesiredPeerGroup(c, testIPv4)
 }
@@ -350,7 +418,7 @@ func (s *desiredPeerGroupSuite) doTestDesiredPeerGroup(c *gc.C, ipVersion TestIP
 			trackerMap[m.Id()] = m
 		}
 
-		info, err := newPeerGroupInfo(trackerMap, test.statuses, test.members, mongoPort, network.SpaceName(""))
+		info, err := newPeerGroupInfo(trackerMap, test.statuses, test.members, mongoPort, network.SpaceName(""), test.policy)
 		c.Assert(err, jc.ErrorIsNil)
 
 		desired, err := desiredPeerGroup(info)
@@ -384,7 +452,7 @@ func (s *desiredPeerGroupSuite) doTestDesiredPeerGroup(c *gc.C, ipVersion TestIP
 
 		// Make sure that when the members are set as required, that there
 		// is no further change if desiredPeerGroup is called again.
-		info, err = newPeerGroupInfo(trackerMap, test.statuses, members, mongoPort, network.SpaceName(""))
+		info, err = newPeerGroupInfo(trackerMap, test.statuses, members, mongoPort, network.SpaceName(""), test.policy)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(info, gc.NotNil)
 
@@ -407,7 +475,7 @@ func (s *desiredPeerGroupSuite) doTestDesiredPeerGroup(c *gc.C, ipVersion TestIP
 }
 
 func (s *desiredPeerGroupSuite) TestNewPeerGroupInfoErrWhenNoMembers(c *gc.C) {
-	_, err := newPeerGroupInfo(nil, nil, nil, 666, network.SpaceName(""))
+	_, err := newPeerGroupInfo(nil, nil, nil, 666, network.SpaceName(""), memberPolicy{})
 	c.Check(err, gc.ErrorMatches, "current member set is empty")
 }
 
@@ -439,6 +507,108 @@ func (s *desiredPeerGroupSuite) TestCheckExtraMembersReturnsFalseWhenEmpty(c *gc
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *desiredPeerGroupSuite) TestDesiredPeerGroupReasons(c *gc.C) {
+	trackerMap := make(map[string]*machineTracker)
+	for _, m := range mkMachines("11v 12v 13v 14v 15 16v", testIPv4) {
+		trackerMap[m.Id()] = m
+	}
+	members := mkMembers("1v 2v 3 4 5", testIPv4)
+	statuses := mkStatuses("1p 2s 3s 4sH 5s", testIPv4)
+	policy := memberPolicy{
+		preferredPrimaries: []string{"12"},
+		observers:          set.NewStrings("13"),
+	}
+	info, err := newPeerGroupInfo(trackerMap, statuses, members, mongoPort, network.SpaceName(""), policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(desired.reasons, jc.DeepEquals, map[string]string{
+		"11": "voting",
+		"12": "vote removed to keep an odd number of voters",
+		"13": "non-voting observer, set by juju-ha-observers",
+		"14": "not voting until ready (state: SECONDARY, healthy: false)",
+		"15": "does not want the vote",
+		"16": "added as a non-voting member until ready",
+	})
+
+	// Once another machine is ready, the preferred primary keeps its
+	// vote and gets a priority.
+	statuses = mkStatuses("1p 2s 3s 4s 5s", testIPv4)
+	info, err = newPeerGroupInfo(trackerMap, statuses, members, mongoPort, network.SpaceName(""), policy)
+	c.Assert(err, jc.ErrorIsNil)
+	desired, err = desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(desired.reasons["11"], gc.Equals, "voting")
+	c.Check(desired.reasons["12"], gc.Equals, "voting, preferred primary (priority 2)")
+	c.Check(desired.reasons["14"], gc.Equals, "ready, given the vote")
+}
+
+func (s *desiredPeerGroupSuite) TestDesiredPeerGroupObserversIgnored(c *gc.C) {
+	trackerMap := make(map[string]*machineTracker)
+	for _, m := range mkMachines("11v 12v 13v", testIPv4) {
+		trackerMap[m.Id()] = m
+	}
+	members := mkMembers("1v 2v 3v", testIPv4)
+	statuses := mkStatuses("1p 2s 3s", testIPv4)
+	policy := memberPolicy{observers: set.NewStrings("13")}
+	info, err := newPeerGroupInfo(trackerMap, statuses, members, mongoPort, network.SpaceName(""), policy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(desired.reasons, jc.DeepEquals, map[string]string{
+		"11": "voting",
+		"12": "voting",
+		"13": "voting, juju-ha-observers ignored, as fewer than 3 machines would be left voting",
+	})
+}
+
+func (s *desiredPeerGroupSuite) TestDesiredReport(c *gc.C) {
+	trackerMap := make(map[string]*machineTracker)
+	for _, m := range mkMachines("11v 12v 13v 14v", testIPv4) {
+		trackerMap[m.Id()] = m
+	}
+	policy := memberPolicy{
+		preferredPrimaries: []string{"12"},
+		observers:          set.NewStrings("13"),
+	}
+	members := withPriorities(mkMembers("1v 2v 3 4v", testIPv4), map[int]float64{2: 2})
+	info, err := newPeerGroupInfo(
+		trackerMap, mkStatuses("1p 2s 3s 4s", testIPv4), members,
+		mongoPort, network.SpaceName(""), policy,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	desired, err := desiredPeerGroup(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	report := desiredReport(info, desired)
+	c.Check(report["preferred-primaries"], jc.DeepEquals, []string{"12"})
+	c.Check(report["observers"], jc.DeepEquals, []string{"13"})
+	c.Check(report["changed"], jc.IsFalse)
+	reported := report["members"].(map[string]interface{})
+	c.Check(reported, gc.HasLen, 4)
+	c.Check(reported["11"], jc.DeepEquals, map[string]interface{}{
+		"address":  "0.1.2.11:1234",
+		"voting":   true,
+		"priority": 1.0,
+		"reason":   "voting",
+		"primary":  true,
+	})
+	c.Check(reported["12"], jc.DeepEquals, map[string]interface{}{
+		"address":  "0.1.2.12:1234",
+		"voting":   true,
+		"priority": 2.0,
+		"reason":   "voting, preferred primary (priority 2)",
+	})
+	c.Check(reported["13"], jc.DeepEquals, map[string]interface{}{
+		"address":  "0.1.2.13:1234",
+		"voting":   false,
+		"priority": 0.0,
+		"reason":   "non-voting observer, set by juju-ha-observers",
+	})
+}
+
 func countVotes(members []replicaset.Member) int {
 	tot := 0
 	for _, m := range members {
@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package peergrouper
+
+import (
+	"gopkg.in/juju/worker.v1/dependency"
+)
+
+// Report is part of the dependency.Reporter interface. It shows the
+// peer group computed on the last update, with the reason for each
+// machine's membership.
+func (w *pgWorker) Report() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.report
+}
+
+func (w *pgWorker) setReport(report map[string]interface{}) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.report = report
+}
+
+// desiredReport describes the desired peer group for the worker's
+// report.
+func desiredReport(info *peerGroupInfo, desired desiredChanges) map[string]interface{} {
+	members := make(map[string]interface{}, len(desired.members))
+	for id, m := range desired.members {
+		priority := 1.0
+		if m.Priority != nil {
+			priority = *m.Priority
+		}
+		member := map[string]interface{}{
+			"address":  m.Address,
+			"voting":   desired.machineVoting[id],
+			"priority": priority,
+			"reason":   desired.reasons[id],
+		}
+		if isPrimaryMember(info, id) {
+			member["primary"] = true
+		}
+		members[id] = member
+	}
+	out := map[string]interface{}{
+		"members": members,
+		"changed": desired.isChanged,
+	}
+	if len(info.policy.preferredPrimaries) > 0 {
+		out["preferred-primaries"] = info.policy.preferredPrimaries
+	}
+	if !info.policy.observers.IsEmpty() {
+		out["observers"] = info.policy.observers.SortedValues()
+	}
+	if desired.stepDownPrimary {
+		out[dependency.KeyState] = "stepping down primary"
+	}
+	return out
+}
@@ -10,6 +10,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/juju/clock"
@@ -19,6 +20,7 @@ import (
 	"github.com/kr/pretty"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
+	"gopkg.in/juju/worker.v1/dependency"
 
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/status"
@@ -117,6 +119,13 @@ type pgWorker struct {
 	// serverDetails holds the last server information broadcast via pub/sub.
 	// It is used to detect changes since the last publish.
 	serverDetails apiserver.Details
+
+	// mu guards report.
+	mu sync.Mutex
+
+	// report holds the last computed peer group, for the worker's
+	// Report method.
+	report map[string]interface{}
 }
 
 // Config holds the configuration for a peergrouper worker.
@@ -513,8 +522,10 @@ func (w *pgWorker) updateReplicaSet() (map[string]*replicaset.Member, error) {
 	desired, err := desiredPeerGroup(info)
 	// membersChanged, members, voting, err
 	if err != nil {
+		w.setReport(map[string]interface{}{dependency.KeyError: err.Error()})
 		return nil, errors.Annotate(err, "computing desired peer group")
 	}
+	w.setReport(desiredReport(info, desired))
 	if logger.IsDebugEnabled() {
 		if desired.isChanged {
 			logger.Debugf("desired peer group members: \n%s", prettyReplicaSetMembers(desired.members))
@@ -604,11 +615,13 @@ func (w *pgWorker) updateReplicaSet() (map[string]*replicaset.Member, error) {
 		return nil, errors.Annotate(err, "removing non-voters")
 	}
 
-	// Reset machine status for members of the changed peer-group.
-	// Any previous peer-group determination errors result in status
-	// warning messages.
+	// Reset machine status for members of the changed peer-group,
+	// replacing any previous peer-group determination errors with the
+	// reason for each machine's membership, so that the desired
+	// replica-set is visible in the controller model's status.
 	for id := range desired.members {
-		if err := w.machineTrackers[id].stm.SetStatus(getStatusInfo("")); err != nil {
+		msg := desired.reasons[id]
+		if err := w.machineTrackers[id].stm.SetStatus(getStatusInfo(msg)); err != nil {
 			return nil, errors.Trace(err)
 		}
 	}
@@ -660,23 +673,19 @@ func (w *pgWorker) peerGroupInfo() (*peerGroupInfo, error) {
 		return nil, errors.Annotate(err, "cannot get replica set members")
 	}
 
-	haSpace, err := w.getHASpaceFromConfig()
+	config, err := w.config.State.ControllerConfig()
 	if err != nil {
 		return nil, err
 	}
+	// The HA space is empty ("") if unset.
+	haSpace := network.SpaceName(config.JujuHASpace())
+	policy := memberPolicy{
+		preferredPrimaries: config.JujuHAPrimaries(),
+		observers:          config.JujuHAObservers(),
+	}
 
 	logger.Tracef("read peer group info: %# v\n%# v", pretty.Formatter(sts), pretty.Formatter(members))
-	return newPeerGroupInfo(w.machineTrackers, sts.Members, members, w.config.MongoPort, haSpace)
-}
-
-// getHASpaceFromConfig returns a SpaceName from the controller config for
-// HA space. If unset, the empty space ("") will be returned.
-func (w *pgWorker) getHASpaceFromConfig() (network.SpaceName, error) {
-	config, err := w.config.State.ControllerConfig()
-	if err != nil {
-		return network.SpaceName(""), err
-	}
-	return network.SpaceName(config.JujuHASpace()), nil
+	return newPeerGroupInfo(w.machineTrackers, sts.Members, members, w.config.MongoPort, haSpace, policy)
 }
 
 // setHasVote sets the HasVote status of all the given machines to hasVote.
@@ -612,7 +612,7 @@ func (s *workerSuite) TestUsesConfiguredHASpaceIPv6(c *gc.C) {
 func (s *workerSuite) doTestUsesConfiguredHASpace(c *gc.C, ipVersion TestIPVersion) {
 	st := haSpaceTestCommonSetup(c, ipVersion, "0v 1v 2v")
 
-	// Set one of the statuses to ensure it is cleared upon determination
+	// Set one of the statuses to ensure it is replaced upon determination
 	// of a new peer group.
 	now := time.Now()
 	err := st.machine("11").SetStatus(status.StatusInfo{
@@ -629,7 +629,7 @@ func (s *workerSuite) doTestUsesConfiguredHASpace(c *gc.C, ipVersion TestIPVersi
 	sInfo, err := st.machine("11").Status()
 	c.Assert(err, gc.IsNil)
 	c.Check(sInfo.Status, gc.Equals, status.Started)
-	c.Check(sInfo.Message, gc.Equals, "")
+	c.Check(sInfo.Message, gc.Equals, "voting")
 }
 
 // runUntilPublish runs a worker until addresses are published over the pub/sub
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
@@ -254,6 +255,11 @@ func (m *fakeContainerManager) Namespace() instance.Namespace {
 	return ns
 }
 
+func (m *fakeContainerManager) ReplaceCharmProfiles(id instance.Id, oldProfiles []string, newProfiles []lxdprofile.Profile) error {
+	m.MethodCall(m, "ReplaceCharmProfiles", id, oldProfiles, newProfiles)
+	return m.NextErr()
+}
+
 func (m *fakeContainerManager) IsInitialized() bool {
 	m.MethodCall(m, "IsInitialized")
 	m.PopNoErr()
@@ -0,0 +1,109 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner
+
+import (
+	"fmt"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/instance"
+)
+
+// processCharmProfileChanges updates the charm LXD profiles of the
+// machines with the given ids.
+func processCharmProfileChanges(
+	st *apiprovisioner.State, manager container.LXDProfileManager, ids []string,
+) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tags := make([]names.MachineTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewMachineTag(id)
+	}
+	results, err := st.Machines(tags...)
+	if err != nil {
+		return errors.Annotate(err, "cannot get machines")
+	}
+	for i, result := range results {
+		if result.Err != nil {
+			if params.IsCodeNotFound(result.Err) {
+				continue
+			}
+			return errors.Annotatef(result.Err, "cannot get machine %v", ids[i])
+		}
+		if err := updateCharmProfiles(manager, result.Machine); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// updateCharmProfiles applies the charm LXD profiles required by the
+// units on the machine to its instance, replacing those applied before.
+// Failing to apply them is reported in the machine's instance status
+// rather than returned, so that one broken profile does not stop the
+// provisioner; the next change to the machine's profiles retries.
+func updateCharmProfiles(manager container.LXDProfileManager, machine apiprovisioner.MachineProvisioner) error {
+	info, err := machine.CharmProfiles()
+	if params.IsCodeNotProvisioned(err) || params.IsCodeNotFound(err) {
+		// Profiles are applied once the machine is provisioned.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot get charm profiles for machine %v", machine)
+	}
+
+	required := make([]lxdprofile.Profile, len(info.Required))
+	profileNames := make([]string, len(info.Required))
+	for i, profile := range info.Required {
+		required[i] = lxdprofile.Profile{
+			Name: profile.Name,
+			Profile: &charm.LXDProfile{
+				Description: profile.Profile.Description,
+				Config:      profile.Profile.Config,
+				Devices:     profile.Profile.Devices,
+			},
+		}
+		profileNames[i] = profile.Name
+	}
+	wanted, applied := set.NewStrings(profileNames...), set.NewStrings(info.Applied...)
+	if wanted.Difference(applied).IsEmpty() && applied.Difference(wanted).IsEmpty() {
+		return nil
+	}
+
+	logger.Infof("applying charm profiles %v to machine %v", profileNames, machine)
+	id := instance.Id(info.InstanceId)
+	if err := manager.ReplaceCharmProfiles(id, info.Applied, required); err != nil {
+		logger.Errorf("cannot apply charm profiles to machine %v: %v", machine, err)
+		message := fmt.Sprintf("cannot apply LXD profiles: %v", err)
+		if err := machine.SetInstanceStatus(status.ProvisioningError, message, nil); err != nil {
+			return errors.Annotatef(err, "cannot set instance status for machine %v", machine)
+		}
+		return nil
+	}
+	if err := machine.SetCharmProfiles(profileNames); err != nil {
+		return errors.Annotatef(err, "cannot record charm profiles for machine %v", machine)
+	}
+
+	// Clear any error left by an earlier failure.
+	instStatus, _, err := machine.InstanceStatus()
+	if err != nil {
+		return errors.Annotatef(err, "cannot get instance status for machine %v", machine)
+	}
+	if instStatus == status.ProvisioningError {
+		if err := machine.SetInstanceStatus(status.Running, "", nil); err != nil {
+			return errors.Annotatef(err, "cannot set instance status for machine %v", machine)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provisioner_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/instance"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/provisioner"
+)
+
+type charmProfileSuite struct {
+	coretesting.BaseSuite
+	machine *profileMachine
+	manager *fakeContainerManager
+}
+
+var _ = gc.Suite(&charmProfileSuite{})
+
+func (s *charmProfileSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.machine = &profileMachine{
+		Stub: &testing.Stub{},
+		profiles: &params.CharmProfilesResult{
+			InstanceId: "juju-abc-0",
+			Applied:    []string{"juju-model-app-1"},
+			Required: []params.CharmProfile{{
+				Name: "juju-model-app-2",
+				Profile: params.CharmLXDProfile{
+					Config: map[string]string{"security.nesting": "true"},
+				},
+			}},
+		},
+		instanceStatus: status.Running,
+	}
+	s.manager = &fakeContainerManager{}
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfiles(c *gc.C) {
+	err := provisioner.UpdateCharmProfiles(s.manager, s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.manager.CheckCall(c, 0, "ReplaceCharmProfiles",
+		instance.Id("juju-abc-0"),
+		[]string{"juju-model-app-1"},
+		[]lxdprofile.Profile{{
+			Name: "juju-model-app-2",
+			Profile: &charm.LXDProfile{
+				Config: map[string]string{"security.nesting": "true"},
+			},
+		}},
+	)
+	s.machine.CheckCallNames(c, "CharmProfiles", "SetCharmProfiles", "InstanceStatus")
+	s.machine.CheckCall(c, 1, "SetCharmProfiles", []string{"juju-model-app-2"})
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfilesClearsError(c *gc.C) {
+	s.machine.instanceStatus = status.ProvisioningError
+	err := provisioner.UpdateCharmProfiles(s.manager, s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.machine.CheckCallNames(c, "CharmProfiles", "SetCharmProfiles", "InstanceStatus", "SetInstanceStatus")
+	s.machine.CheckCall(c, 3, "SetInstanceStatus", status.Running, "")
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfilesUpToDate(c *gc.C) {
+	s.machine.profiles.Applied = []string{"juju-model-app-2"}
+	err := provisioner.UpdateCharmProfiles(s.manager, s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.manager.CheckNoCalls(c)
+	s.machine.CheckCallNames(c, "CharmProfiles")
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfilesNotProvisioned(c *gc.C) {
+	s.machine.SetErrors(&params.Error{Code: params.CodeNotProvisioned})
+	err := provisioner.UpdateCharmProfiles(s.manager, s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.manager.CheckNoCalls(c)
+	s.machine.CheckCallNames(c, "CharmProfiles")
+}
+
+func (s *charmProfileSuite) TestUpdateCharmProfilesFailure(c *gc.C) {
+	s.manager.SetErrors(errors.New("boom"))
+	err := provisioner.UpdateCharmProfiles(s.manager, s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.machine.CheckCallNames(c, "CharmProfiles", "SetInstanceStatus")
+	s.machine.CheckCall(c, 1, "SetInstanceStatus",
+		status.ProvisioningError, "cannot apply LXD profiles: boom")
+}
+
+type profileMachine struct {
+	*apiprovisioner.Machine
+	*testing.Stub
+
+	profiles       *params.CharmProfilesResult
+	instanceStatus status.Status
+}
+
+func (m *profileMachine) String() string {
+	return "0/lxd/0"
+}
+
+func (m *profileMachine) CharmProfiles() (*params.CharmProfilesResult, error) {
+	m.AddCall("CharmProfiles")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.profiles, nil
+}
+
+func (m *profileMachine) SetCharmProfiles(profiles []string) error {
+	m.AddCall("SetCharmProfiles", profiles)
+	return m.NextErr()
+}
+
+func (m *profileMachine) InstanceStatus() (status.Status, string, error) {
+	m.AddCall("InstanceStatus")
+	return m.instanceStatus, "", m.NextErr()
+}
+
+func (m *profileMachine) SetInstanceStatus(st status.Status, message string, data map[string]interface{}) error {
+	m.AddCall("SetInstanceStatus", st, message)
+	return m.NextErr()
+}
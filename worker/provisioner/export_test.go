@@ -47,6 +47,8 @@ var (
 
 var ClassifyMachine = classifyMachine
 
+var UpdateCharmProfiles = updateCharmProfiles
+
 // GetCopyAvailabilityZoneMachines returns a copy of p.(*provisionerTask).availabilityZoneMachines
 func GetCopyAvailabilityZoneMachines(p ProvisionerTask) []AvailabilityZoneMachine {
 	task := p.(*provisionerTask)
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/instance"
@@ -179,3 +180,14 @@ func (broker *lxdBroker) MaintainInstance(ctx context.ProviderCallContext, args
 	)
 	return err
 }
+
+// ReplaceCharmProfiles implements container.LXDProfileManager.
+func (broker *lxdBroker) ReplaceCharmProfiles(
+	id instance.Id, oldProfiles []string, newProfiles []lxdprofile.Profile,
+) error {
+	manager, ok := broker.manager.(container.LXDProfileManager)
+	if !ok {
+		return errors.NotSupportedf("charm LXD profiles")
+	}
+	return errors.Trace(manager.ReplaceCharmProfiles(id, oldProfiles, newProfiles))
+}
@@ -12,12 +12,16 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/container"
+	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
 	coretesting "github.com/juju/juju/testing"
 	coretools "github.com/juju/juju/tools"
@@ -224,3 +228,19 @@ func (s *lxdBrokerSuite) TestStartInstanceWithContainerInheritProperties(c *gc.C
 		},
 	}, c)
 }
+
+func (s *lxdBrokerSuite) TestReplaceCharmProfiles(c *gc.C) {
+	broker, brokerErr := s.newLXDBroker(c)
+	c.Assert(brokerErr, jc.ErrorIsNil)
+	manager, ok := broker.(container.LXDProfileManager)
+	c.Assert(ok, jc.IsTrue)
+
+	profiles := []lxdprofile.Profile{{
+		Name:    "juju-model-app-2",
+		Profile: &charm.LXDProfile{Config: map[string]string{"security.nesting": "true"}},
+	}}
+	err := manager.ReplaceCharmProfiles("juju-abc-0", []string{"juju-model-app-1"}, profiles)
+	c.Assert(err, jc.ErrorIsNil)
+	s.manager.CheckCall(c, 0, "ReplaceCharmProfiles",
+		instance.Id("juju-abc-0"), []string{"juju-model-app-1"}, profiles)
+}
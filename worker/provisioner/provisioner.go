@@ -15,6 +15,8 @@ import (
 
 	"github.com/juju/juju/agent"
 	apiprovisioner "github.com/juju/juju/api/provisioner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/container"
 	"github.com/juju/juju/controller/authentication"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
@@ -245,6 +247,17 @@ func (p *environProvisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// Machines provided by LXD have the profiles supplied by their
+	// units' charms applied whenever those change, as containers do.
+	var profileChanges watcher.StringsChannel
+	profileManager, ok := p.environ.(container.LXDProfileManager)
+	if ok {
+		profileChanges, err = p.getCharmProfileChanges()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -261,10 +274,34 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+		case ids, ok := <-profileChanges:
+			if !ok {
+				return errors.New("charm profile watch closed")
+			}
+			if err := processCharmProfileChanges(p.st, profileManager, ids); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
 
+// getCharmProfileChanges returns the channel notifying of machines
+// whose charm profiles may have changed, or nil if the controller does
+// not support charm profiles.
+func (p *environProvisioner) getCharmProfileChanges() (watcher.StringsChannel, error) {
+	w, err := p.st.WatchModelMachineCharmProfileChanges()
+	if params.IsCodeNotImplemented(err) {
+		logger.Infof("controller does not support charm profiles")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.catacomb.Add(w); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w.Changes(), nil
+}
+
 func (p *environProvisioner) getMachineWatcher() (watcher.StringsWatcher, error) {
 	return p.st.WatchModelMachines()
 }
@@ -343,6 +380,17 @@ func (p *containerProvisioner) loop() error {
 		return errors.Trace(err)
 	}
 
+	// LXD containers have the profiles supplied by their units' charms
+	// applied whenever those change.
+	var profileChanges watcher.StringsChannel
+	profileManager, ok := p.broker.(container.LXDProfileManager)
+	if ok && p.containerType == instance.LXD {
+		profileChanges, err = p.getCharmProfileChanges()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	for {
 		select {
 		case <-p.catacomb.Dying():
@@ -357,10 +405,38 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+		case ids, ok := <-profileChanges:
+			if !ok {
+				return errors.New("charm profile watch closed")
+			}
+			if err := processCharmProfileChanges(p.st, profileManager, ids); err != nil {
+				return errors.Trace(err)
+			}
 		}
 	}
 }
 
+// getCharmProfileChanges returns the channel notifying of containers
+// whose charm profiles may have changed, or nil if the controller does
+// not support charm profiles.
+func (p *containerProvisioner) getCharmProfileChanges() (watcher.StringsChannel, error) {
+	machine, err := p.getMachine()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := machine.WatchContainerCharmProfileChanges()
+	if params.IsCodeNotImplemented(err) {
+		logger.Infof("controller does not support charm profiles")
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := p.catacomb.Add(w); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w.Changes(), nil
+}
+
 func (p *containerProvisioner) getMachine() (apiprovisioner.MachineProvisioner, error) {
 	if p.machine == nil {
 		tag := p.agentConfig.Tag()
@@ -25,6 +25,9 @@ type ManifoldConfig struct {
 	FSM       raft.FSM
 	Logger    Logger
 	NewWorker func(Config) (worker.Worker, error)
+
+	// SetRaft is passed through to the worker's Config.
+	SetRaft func(*raft.Raft)
 }
 
 // Validate validates the manifold configuration.
@@ -97,6 +100,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		LocalID:    raft.ServerID(agentConfig.Tag().Id()),
 		Transport:  transport,
 		Clock:      clk,
+		SetRaft:    config.SetRaft,
 	})
 }
 
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"github.com/juju/utils"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 	"gopkg.in/natefinch/lumberjack.v2"
@@ -44,6 +45,11 @@ type ManifoldConfig struct {
 	Logger       Logger
 	NewWorker    func(Config) (worker.Worker, error)
 	NewTarget    func(*state.State, io.Writer, Logger) raftlease.NotifyTarget
+
+	// PrometheusRegisterer, if non-nil, is used to register a
+	// collector counting the lease holder changes applied by the
+	// worker.
+	PrometheusRegisterer prometheus.Registerer
 }
 
 // Validate checks that the config has all the required values.
@@ -114,6 +120,16 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	}
 
 	notifyTarget := config.NewTarget(st, makeLogger(logPath), config.Logger)
+	unregister := func() {}
+	if config.PrometheusRegisterer != nil {
+		target := newMetricsTarget(notifyTarget)
+		if err := config.PrometheusRegisterer.Register(target); err != nil {
+			stTracker.Done()
+			return nil, errors.Annotate(err, "registering lease metrics collector")
+		}
+		unregister = func() { config.PrometheusRegisterer.Unregister(target) }
+		notifyTarget = target
+	}
 	w, err := config.NewWorker(Config{
 		Raft:   r,
 		Hub:    hub,
@@ -122,10 +138,14 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Target: notifyTarget,
 	})
 	if err != nil {
+		unregister()
 		stTracker.Done()
 		return nil, errors.Trace(err)
 	}
-	return common.NewCleanupWorker(w, func() { stTracker.Done() }), nil
+	return common.NewCleanupWorker(w, func() {
+		unregister()
+		stTracker.Done()
+	}), nil
 }
 
 // Manifold builds a dependency.Manifold for running a raftforwarder
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/pubsub"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -203,6 +204,26 @@ func (s *manifoldSuite) TestStart(c *gc.C) {
 	})
 }
 
+func (s *manifoldSuite) TestStartRegistersLeaseMetrics(c *gc.C) {
+	registry := prometheus.NewRegistry()
+	s.config.PrometheusRegisterer = registry
+	s.manifold = raftforwarder.Manifold(s.config)
+
+	w, err := s.manifold.Start(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "NewTarget", "NewWorker")
+	config := s.stub.Calls()[1].Args[0].(raftforwarder.Config)
+	c.Assert(config.Target, gc.Not(gc.Equals), s.target)
+
+	// The collector is only unregistered once the worker stops, so
+	// registering another one fails until then.
+	target := raftforwarder.NewMetricsTarget(s.target)
+	c.Assert(registry.Register(target), gc.FitsTypeOf, prometheus.AlreadyRegisteredError{})
+	workertest.CleanKill(c, w)
+	c.Assert(registry.Register(target), jc.ErrorIsNil)
+}
+
 func (s *manifoldSuite) TestStoppingWorkerReleasesState(c *gc.C) {
 	w, err := s.manifold.Start(s.context)
 	c.Assert(err, jc.ErrorIsNil)
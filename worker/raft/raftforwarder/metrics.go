@@ -0,0 +1,68 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/raftlease"
+)
+
+const (
+	metricsNamespace = "juju_raftlease"
+
+	namespaceLabel = "namespace"
+	changeLabel    = "change"
+)
+
+// metricsTarget is a raftlease.NotifyTarget that counts the lease
+// holder changes it is notified of before passing them on to the
+// wrapped target. It is also a prometheus.Collector for those counts.
+type metricsTarget struct {
+	raftlease.NotifyTarget
+	holderChanges *prometheus.CounterVec
+}
+
+func newMetricsTarget(target raftlease.NotifyTarget) *metricsTarget {
+	return &metricsTarget{
+		NotifyTarget: target,
+		holderChanges: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Name:      "holder_changes_total",
+				Help:      "Number of lease holder changes applied by this controller.",
+			},
+			[]string{namespaceLabel, changeLabel},
+		),
+	}
+}
+
+// Claimed is part of raftlease.NotifyTarget.
+func (t *metricsTarget) Claimed(key lease.Key, holder string) {
+	t.holderChanges.With(prometheus.Labels{
+		namespaceLabel: key.Namespace,
+		changeLabel:    "claimed",
+	}).Inc()
+	t.NotifyTarget.Claimed(key, holder)
+}
+
+// Expired is part of raftlease.NotifyTarget.
+func (t *metricsTarget) Expired(key lease.Key) {
+	t.holderChanges.With(prometheus.Labels{
+		namespaceLabel: key.Namespace,
+		changeLabel:    "expired",
+	}).Inc()
+	t.NotifyTarget.Expired(key)
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (t *metricsTarget) Describe(ch chan<- *prometheus.Desc) {
+	t.holderChanges.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (t *metricsTarget) Collect(ch chan<- prometheus.Metric) {
+	t.holderChanges.Collect(ch)
+}
@@ -0,0 +1,72 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftforwarder_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/raft/raftforwarder"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) TestCountsHolderChanges(c *gc.C) {
+	var target recordingTarget
+	metricsTarget := raftforwarder.NewMetricsTarget(&target)
+	registry := prometheus.NewRegistry()
+	err := registry.Register(metricsTarget)
+	c.Assert(err, jc.ErrorIsNil)
+
+	leadership := lease.Key{"application-leadership", "model-uuid", "mysql"}
+	singular := lease.Key{"singular-controller", "model-uuid", "model-uuid"}
+	metricsTarget.Claimed(leadership, "mysql/0")
+	metricsTarget.Expired(leadership)
+	metricsTarget.Claimed(leadership, "mysql/1")
+	metricsTarget.Claimed(singular, "machine-0")
+
+	target.CheckCalls(c, []testing.StubCall{
+		{"Claimed", []interface{}{leadership, "mysql/0"}},
+		{"Expired", []interface{}{leadership}},
+		{"Claimed", []interface{}{leadership, "mysql/1"}},
+		{"Claimed", []interface{}{singular, "machine-0"}},
+	})
+
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(families, gc.HasLen, 1)
+	c.Assert(families[0].GetName(), gc.Equals, "juju_raftlease_holder_changes_total")
+	counts := make(map[string]float64)
+	for _, metric := range families[0].GetMetric() {
+		labels := make(map[string]string)
+		for _, pair := range metric.GetLabel() {
+			labels[pair.GetName()] = pair.GetValue()
+		}
+		counts[labels["namespace"]+" "+labels["change"]] = metric.GetCounter().GetValue()
+	}
+	c.Assert(counts, jc.DeepEquals, map[string]float64{
+		"application-leadership claimed": 2,
+		"application-leadership expired": 1,
+		"singular-controller claimed":    1,
+	})
+}
+
+type recordingTarget struct {
+	testing.Stub
+}
+
+func (t *recordingTarget) Claimed(key lease.Key, holder string) {
+	t.MethodCall(t, "Claimed", key, holder)
+}
+
+func (t *recordingTarget) Expired(key lease.Key) {
+	t.MethodCall(t, "Expired", key)
+}
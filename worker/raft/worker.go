@@ -128,6 +128,13 @@ type Config struct {
 	// SnapshotRetention is the non-negative number of snapshots
 	// to retain on disk. If zero, defaults to 2.
 	SnapshotRetention int
+
+	// SetRaft, if non-nil, is called with the raft.Raft once it has
+	// been created, and again with nil just before it is shut down.
+	// This is used for publishing the raft to the agent's
+	// introspection worker, which runs outside of the dependency
+	// engine; hence the manifold's Output cannot be relied upon.
+	SetRaft func(*raft.Raft)
 }
 
 // Validate validates the raft worker configuration.
@@ -322,6 +329,11 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 		}
 	}()
 
+	if w.config.SetRaft != nil {
+		w.config.SetRaft(r)
+		defer w.config.SetRaft(nil)
+	}
+
 	shutdown := make(chan raft.Observation)
 	observer := raft.NewObserver(shutdown, true, func(o *raft.Observation) bool {
 		return o.Data == raft.Shutdown
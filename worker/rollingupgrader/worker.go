@@ -0,0 +1,113 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package rollingupgrader provides a worker that releases a model's
+// rolling charm upgrades to units in batches, as the units released
+// previously become healthy.
+package rollingupgrader
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/watcher"
+)
+
+var logger = loggo.GetLogger("juju.worker.rollingupgrader")
+
+// retryDelay is how long to wait before trying again to progress
+// rolling upgrades that could not be progressed, if nothing changes in
+// the meantime.
+const retryDelay = 30 * time.Second
+
+// Facade exposes the controller functionality used by the worker.
+type Facade interface {
+	ProgressRollingUpgrades() error
+	WatchRollingUpgrades() (watcher.NotifyWatcher, error)
+}
+
+// Config holds the dependencies and configuration for a Worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+}
+
+// Validate returns an error if the config cannot be expected to drive
+// a functional Worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Worker progresses the model's rolling upgrades whenever they, or the
+// units they upgrade, change, and when their batches time out.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a Worker that progresses rolling upgrades until it
+// is stopped.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+func (w *Worker) loop() error {
+	watch, err := w.config.Facade.WatchRollingUpgrades()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(watch); err != nil {
+		return errors.Trace(err)
+	}
+
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-watch.Changes():
+			if !ok {
+				return errors.New("rolling upgrades watcher closed")
+			}
+		case <-retry:
+		}
+		retry = nil
+		if err := w.config.Facade.ProgressRollingUpgrades(); err != nil {
+			// Upgrades that could not be progressed are left as
+			// they were, and retried even if nothing changes.
+			logger.Errorf("cannot progress rolling upgrades: %v", err)
+			retry = w.config.Clock.After(retryDelay)
+		}
+	}
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
@@ -0,0 +1,150 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package rollingupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/rollingupgrader"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		calls:   make(chan struct{}, 10),
+		changes: make(chan struct{}),
+	}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := rollingupgrader.NewWorker(rollingupgrader.Config{Clock: s.clock})
+	c.Check(err, gc.ErrorMatches, "nil Facade not valid")
+	_, err = rollingupgrader.NewWorker(rollingupgrader.Config{Facade: s.facade})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *WorkerSuite) TestProgressesOnChange(c *gc.C) {
+	w, err := rollingupgrader.NewWorker(rollingupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.waitCall(c)
+	s.sendChange(c)
+	s.waitCall(c)
+	s.facade.CheckCallNames(c,
+		"WatchRollingUpgrades",
+		"ProgressRollingUpgrades",
+		"ProgressRollingUpgrades",
+	)
+}
+
+func (s *WorkerSuite) TestRetriesAfterError(c *gc.C) {
+	// Errors are logged, and don't stop the worker.
+	s.facade.SetErrors(nil, errors.New("boom"))
+	w, err := rollingupgrader.NewWorker(rollingupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.waitCall(c)
+	err = s.clock.WaitAdvance(30*time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitCall(c)
+	s.facade.CheckCallNames(c,
+		"WatchRollingUpgrades",
+		"ProgressRollingUpgrades",
+		"ProgressRollingUpgrades",
+	)
+}
+
+func (s *WorkerSuite) TestNoProgressWithoutChange(c *gc.C) {
+	w, err := rollingupgrader.NewWorker(rollingupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.waitCall(c)
+	s.clock.Advance(time.Hour)
+	select {
+	case <-s.facade.calls:
+		c.Fatalf("unexpected progress")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestWatchError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := rollingupgrader.NewWorker(rollingupgrader.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerSuite) sendChange(c *gc.C) {
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rolling upgrades to progress")
+	}
+}
+
+type fakeFacade struct {
+	testing.Stub
+	calls   chan struct{}
+	changes chan struct{}
+}
+
+func (f *fakeFacade) WatchRollingUpgrades() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchRollingUpgrades")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *fakeFacade) ProgressRollingUpgrades() error {
+	f.MethodCall(f, "ProgressRollingUpgrades")
+	f.calls <- struct{}{}
+	return f.NextErr()
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/prometheus/client_golang/prometheus"
@@ -27,6 +28,7 @@ type ManifoldConfig struct {
 	AgentName              string
 	StateConfigWatcherName string
 	OpenStatePool          func(coreagent.Config) (*state.StatePool, error)
+	Clock                  clock.Clock
 	PingInterval           time.Duration
 	PrometheusRegisterer   prometheus.Registerer
 
@@ -49,6 +51,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.OpenStatePool == nil {
 		return errors.NotValidf("nil OpenStatePool")
 	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
 	if config.PrometheusRegisterer == nil {
 		return errors.NotValidf("nil PrometheusRegisterer")
 	}
@@ -104,6 +109,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			w := &stateWorker{
 				stTracker:            stTracker,
 				pingInterval:         pingInterval,
+				clock:                config.Clock,
 				prometheusRegisterer: config.PrometheusRegisterer,
 				setStatePool:         config.SetStatePool,
 			}
@@ -142,6 +148,7 @@ type stateWorker struct {
 	catacomb             catacomb.Catacomb
 	stTracker            StateTracker
 	pingInterval         time.Duration
+	clock                clock.Clock
 	prometheusRegisterer prometheus.Registerer
 	setStatePool         func(*state.StatePool)
 	cleanupOnce          sync.Once
@@ -154,7 +161,7 @@ func (w *stateWorker) loop() error {
 	}
 	defer w.stTracker.Done()
 
-	collector := statemetrics.New(statemetrics.NewStatePool(pool))
+	collector := statemetrics.New(statemetrics.NewStatePool(pool), w.clock)
 	w.prometheusRegisterer.Register(collector)
 	defer w.prometheusRegisterer.Unregister(collector)
 
@@ -6,6 +6,7 @@ package state_test
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -47,6 +48,7 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		AgentName:              "agent",
 		StateConfigWatcherName: "state-config-watcher",
 		OpenStatePool:          s.fakeOpenState,
+		Clock:                  clock.WallClock,
 		PingInterval:           10 * time.Millisecond,
 		PrometheusRegisterer:   prometheus.NewRegistry(),
 		SetStatePool: func(pool *state.StatePool) {
@@ -95,6 +97,11 @@ func (s *ManifoldSuite) TestStartOpenStateNil(c *gc.C) {
 	s.startManifoldInvalidConfig(c, s.config, "nil OpenStatePool not valid")
 }
 
+func (s *ManifoldSuite) TestStartClockNil(c *gc.C) {
+	s.config.Clock = nil
+	s.startManifoldInvalidConfig(c, s.config, "nil Clock not valid")
+}
+
 func (s *ManifoldSuite) TestStartPrometheusRegistererNil(c *gc.C) {
 	s.config.PrometheusRegisterer = nil
 	s.startManifoldInvalidConfig(c, s.config, "nil PrometheusRegisterer not valid")
@@ -208,6 +208,8 @@ type mockUnit struct {
 	storageWatcher                   *mockStringsWatcher
 	actionWatcher                    *mockStringsWatcher
 	relationsWatcher                 *mockStringsWatcher
+	secretRotationsWatcher           *mockNotifyWatcher
+	secretRotations                  []params.SecretRotation
 }
 
 func (u *mockUnit) Life() params.Life {
@@ -270,6 +272,14 @@ func (u *mockUnit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	return model.UpgradeSeriesPrepareStarted, nil
 }
 
+func (u *mockUnit) SecretRotations() ([]params.SecretRotation, error) {
+	return u.secretRotations, nil
+}
+
+func (u *mockUnit) WatchSecretRotations() (watcher.NotifyWatcher, error) {
+	return u.secretRotationsWatcher, nil
+}
+
 func (m *mockUnit) SetUpgradeSeriesStatus(status model.UpgradeSeriesStatus) error {
 	return nil
 }
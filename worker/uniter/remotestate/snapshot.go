@@ -75,6 +75,10 @@ type Snapshot struct {
 
 	// UpgradeSeriesStatus is the preparation status of any currently running series upgrade
 	UpgradeSeriesStatus model.UpgradeSeriesStatus
+
+	// SecretRotations is the list of names of the secrets owned
+	// by the unit's application that are due to be rotated.
+	SecretRotations []string
 }
 
 type RelationSnapshot struct {
@@ -50,6 +50,12 @@ type Unit interface {
 	// relevant for this unit change.
 	WatchRelations() (watcher.StringsWatcher, error)
 	UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error)
+	// SecretRotations returns when each of the secrets owned by the
+	// unit's application is next due to be rotated.
+	SecretRotations() ([]params.SecretRotation, error)
+	// WatchSecretRotations returns a watcher that fires when the
+	// secrets in the model change.
+	WatchSecretRotations() (watcher.NotifyWatcher, error)
 }
 
 type Application interface {
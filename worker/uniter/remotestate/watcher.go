@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -39,9 +40,14 @@ type RemoteStateWatcher struct {
 	commandChannel            <-chan string
 	retryHookChannel          watcher.NotifyChannel
 	applicationChannel        watcher.NotifyChannel
+	clock                     clock.Clock
 
 	catacomb catacomb.Catacomb
 
+	// secretRotations holds the rotations of the secrets owned
+	// by the unit's application, as last read from the controller.
+	secretRotations []params.SecretRotation
+
 	out     chan struct{}
 	mu      sync.Mutex
 	current Snapshot
@@ -58,12 +64,16 @@ type WatcherConfig struct {
 	ApplicationChannel  watcher.NotifyChannel
 	UnitTag             names.UnitTag
 	ModelType           model.ModelType
+	Clock               clock.Clock
 }
 
 func (w WatcherConfig) validate() error {
 	if w.ModelType == model.CAAS && w.ApplicationChannel == nil {
 		return errors.NotValidf("watcher config for CAAS model with nil application channel")
 	}
+	if w.Clock == nil {
+		return errors.NotValidf("watcher config with nil clock")
+	}
 	return nil
 }
 
@@ -85,6 +95,7 @@ func NewWatcher(config WatcherConfig) (*RemoteStateWatcher, error) {
 		retryHookChannel:          config.RetryHookChannel,
 		applicationChannel:        config.ApplicationChannel,
 		modelType:                 config.ModelType,
+		clock:                     config.Clock,
 		// Note: it is important that the out channel be buffered!
 		// The remote state watcher will perform a non-blocking send
 		// on the channel to wake up the observer. It is non-blocking
@@ -145,6 +156,8 @@ func (w *RemoteStateWatcher) Snapshot() Snapshot {
 	copy(snapshot.Actions, w.current.Actions)
 	snapshot.Commands = make([]string, len(w.current.Commands))
 	copy(snapshot.Commands, w.current.Commands)
+	snapshot.SecretRotations = make([]string, len(w.current.SecretRotations))
+	copy(snapshot.SecretRotations, w.current.SecretRotations)
 	return snapshot
 }
 
@@ -169,6 +182,30 @@ func (w *RemoteStateWatcher) CommandCompleted(completed string) {
 	}
 }
 
+// SecretRotated records that the named secret has been rotated, so
+// that it is no longer due for rotation until its rotate interval has
+// elapsed again.
+func (w *RemoteStateWatcher) SecretRotated(name string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, rotation := range w.secretRotations {
+		if rotation.Name == name {
+			w.secretRotations[i].NextRotateTime = w.clock.Now().Add(rotation.RotateInterval)
+			break
+		}
+	}
+	for i, due := range w.current.SecretRotations {
+		if due != name {
+			continue
+		}
+		w.current.SecretRotations = append(
+			w.current.SecretRotations[:i],
+			w.current.SecretRotations[i+1:]...,
+		)
+		break
+	}
+}
+
 func (w *RemoteStateWatcher) setUp(unitTag names.UnitTag) error {
 	// TODO(axw) move this logic
 	var err error
@@ -323,6 +360,22 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 	}
 	requiredEvents++
 
+	// Secrets are not supported by older controllers, so the
+	// secret rotations watcher is not required for the initial
+	// snapshot.
+	var secretRotationsChanges watcher.NotifyChannel
+	secretRotationsw, err := w.unit.WatchSecretRotations()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("secrets not supported by the controller")
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := w.catacomb.Add(secretRotationsw); err != nil {
+			return errors.Trace(err)
+		}
+		secretRotationsChanges = secretRotationsw.Changes()
+	}
+
 	var seenLeadershipChange bool
 	// There's no watcher for this per se; we wait on a channel
 	// returned by the leadership tracker.
@@ -366,6 +419,7 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		observedEvent(&seenLeadershipChange)
 	}
 
+	var secretRotateTimer <-chan time.Time
 	var updateStatusInterval time.Duration
 	var updateStatusTimer <-chan time.Time
 	resetUpdateStatusTimer := func() {
@@ -526,6 +580,24 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 				return errors.Trace(err)
 			}
 
+		case _, ok := <-secretRotationsChanges:
+			logger.Debugf("got secret rotations change: ok=%t", ok)
+			if !ok {
+				return errors.New("secret rotations watcher closed")
+			}
+			rotations, err := w.unit.SecretRotations()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			w.mu.Lock()
+			w.secretRotations = rotations
+			w.mu.Unlock()
+			secretRotateTimer = w.secretRotationsChanged()
+
+		case <-secretRotateTimer:
+			logger.Debugf("secret rotate timer triggered")
+			secretRotateTimer = w.secretRotationsChanged()
+
 		case <-updateStatusTimer:
 			logger.Debugf("update status timer triggered")
 			if err := w.updateStatusChanged(); err != nil {
@@ -604,6 +676,29 @@ func (w *RemoteStateWatcher) updateStatusChanged() error {
 	return nil
 }
 
+// secretRotationsChanged records the secrets that are due to be
+// rotated, and returns a channel that will be signalled when the next
+// secret falls due, or nil if no rotations are scheduled.
+func (w *RemoteStateWatcher) secretRotationsChanged() <-chan time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.clock.Now()
+	var due []string
+	var next time.Time
+	for _, rotation := range w.secretRotations {
+		if !rotation.NextRotateTime.After(now) {
+			due = append(due, rotation.Name)
+		} else if next.IsZero() || rotation.NextRotateTime.Before(next) {
+			next = rotation.NextRotateTime
+		}
+	}
+	w.current.SecretRotations = due
+	if next.IsZero() {
+		return nil
+	}
+	return w.clock.After(next.Sub(now))
+}
+
 // commandsChanged is called when a command is enqueued.
 func (w *RemoteStateWatcher) commandsChanged(id string) error {
 	w.mu.Lock()
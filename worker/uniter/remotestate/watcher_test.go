@@ -63,6 +63,7 @@ func (s *WatcherSuite) SetUpTest(c *gc.C) {
 			storageWatcher:                   newMockStringsWatcher(),
 			actionWatcher:                    newMockStringsWatcher(),
 			relationsWatcher:                 newMockStringsWatcher(),
+			secretRotationsWatcher:           newMockNotifyWatcher(),
 		},
 		relations:                   make(map[names.RelationTag]*mockRelation),
 		storageAttachment:           make(map[params.StorageAttachmentId]params.StorageAttachment),
@@ -96,6 +97,7 @@ func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
 		LeadershipTracker:   s.leadership,
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
+		Clock:               s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -115,6 +117,7 @@ func (s *WatcherSuiteCAAS) SetUpTest(c *gc.C) {
 		UnitTag:             s.st.unit.tag,
 		UpdateStatusChannel: statusTicker,
 		ApplicationChannel:  s.applicationWatcher.Changes(),
+		Clock:               s.clock,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	s.watcher = w
@@ -704,6 +707,36 @@ func (s *WatcherSuite) TestUpdateStatusIntervalChanges(c *gc.C) {
 // a specific number of loop iterations; it's currently 9, but waiting
 // for a specific number is very likely to start failing intermittently
 // again, as in lp:1604955, if the SUT undergoes even subtle changes.
+func (s *WatcherSuite) TestSecretRotations(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	now := s.clock.Now()
+	s.st.unit.secretRotations = []params.SecretRotation{{
+		Name:           "due",
+		RotateInterval: time.Hour,
+		NextRotateTime: now.Add(-time.Minute),
+	}, {
+		Name:           "later",
+		RotateInterval: time.Hour,
+		NextRotateTime: now.Add(2 * time.Minute),
+	}}
+	s.st.unit.secretRotationsWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{"due"})
+
+	// Once rotated, a secret is not due again until its
+	// interval has elapsed.
+	s.watcher.SecretRotated("due")
+	c.Assert(s.watcher.Snapshot().SecretRotations, gc.HasLen, 0)
+
+	s.waitAlarmsStable(c)
+	s.clock.Advance(2 * time.Minute)
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().SecretRotations, jc.DeepEquals, []string{"later"})
+}
+
 func (s *WatcherSuite) waitAlarmsStable(c *gc.C) {
 	timeout := time.After(coretesting.LongWait)
 	for i := 0; ; i++ {
@@ -724,3 +757,10 @@ func (s *WatcherSuiteCAAS) TestWatcherConfig(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, "watcher config for CAAS model with nil application channel not valid")
 }
+
+func (s *WatcherSuiteIAAS) TestWatcherConfigNilClock(c *gc.C) {
+	_, err := remotestate.NewWatcher(remotestate.WatcherConfig{
+		ModelType: model.IAAS,
+	})
+	c.Assert(err, gc.ErrorMatches, "watcher config with nil clock not valid")
+}
@@ -28,6 +28,7 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+	Secrets             resolver.Resolver
 }
 
 type uniterResolver struct {
@@ -104,6 +105,11 @@ func (s *uniterResolver) NextOp(
 		return op, err
 	}
 
+	op, err = s.config.Secrets.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+
 	switch localState.Kind {
 	case operation.RunHook:
 		switch localState.Step {
@@ -91,6 +91,7 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Relations:           relation.NewRelationsResolver(&dummyRelations{}),
 		Storage:             storage.NewResolver(attachments, s.modelType),
 		Commands:            nopResolver{},
+		Secrets:             nopResolver{},
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -135,6 +135,10 @@ type HookContext struct {
 	// goalState holds the goal state struct
 	goalState application.GoalState
 
+	// charmState holds the unit's charm state once it has been read,
+	// along with any changes made to it by the hook.
+	charmState map[string]string
+
 	// id identifies the context.
 	id string
 
@@ -207,6 +211,10 @@ type HookContext struct {
 	// storageId is the tag of the storage instance associated with the running hook.
 	storageTag names.StorageTag
 
+	// secretName is the name of the secret associated with the running
+	// secret-rotate hook.
+	secretName string
+
 	// hasRunSetStatus is true if a call to the status-set was made during the
 	// invocation of a hook.
 	// This attribute is persisted to local uniter state at the end of the hook
@@ -671,6 +679,9 @@ func (context *HookContext) HookVars(paths Paths) ([]string, error) {
 	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	if context.secretName != "" {
+		vars = append(vars, "JUJU_SECRET_NAME="+context.secretName)
+	}
 	if context.actionData != nil {
 		vars = append(vars,
 			"JUJU_ACTION_NAME="+context.actionData.Name,
@@ -904,6 +915,54 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 	return result.OneError()
 }
 
+// GetSecret returns the values of the given revision of the named
+// secret, or of its latest revision if revision is zero.
+func (ctx *HookContext) GetSecret(name string, revision int) (map[string]string, error) {
+	value, err := ctx.unit.GetSecretValue(name, revision)
+	return value, errors.Trace(err)
+}
+
+// SetSecret creates or updates a secret owned by the unit's
+// application. The changes are written to the controller immediately.
+func (ctx *HookContext) SetSecret(arg params.SetSecretArg) error {
+	return errors.Trace(ctx.unit.SetSecret(arg))
+}
+
+// CharmState returns the unit's charm state, which is read from the
+// controller the first time it's requested.
+func (ctx *HookContext) CharmState() (map[string]string, error) {
+	if ctx.charmState == nil {
+		charmState, err := ctx.unit.CharmState()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ctx.charmState = charmState
+	}
+	result := make(map[string]string, len(ctx.charmState))
+	for key, value := range ctx.charmState {
+		result[key] = value
+	}
+	return result, nil
+}
+
+// UpdateCharmState sets and unsets keys of the unit's charm state. The
+// changes are written to the controller immediately.
+func (ctx *HookContext) UpdateCharmState(set map[string]string, unset []string) error {
+	if err := ctx.unit.UpdateCharmState(set, unset); err != nil {
+		return errors.Trace(err)
+	}
+	if ctx.charmState == nil {
+		return nil
+	}
+	for _, key := range unset {
+		delete(ctx.charmState, key)
+	}
+	for key, value := range set {
+		ctx.charmState[key] = value
+	}
+	return nil
+}
+
 // NetworkInfo returns the network info for the given bindings on the given relation.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
 	var relId *int
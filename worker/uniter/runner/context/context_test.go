@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
@@ -160,6 +161,46 @@ func (s *InterfaceSuite) TestGetSetWorkloadVersion(c *gc.C) {
 	c.Assert(result, gc.Equals, "Pipey")
 }
 
+func (s *InterfaceSuite) TestGetUpdateCharmState(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	charmState, err := ctx.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, gc.HasLen, 0)
+
+	err = ctx.UpdateCharmState(map[string]string{"foo": "bar", "baz": "qux"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ctx.UpdateCharmState(nil, []string{"baz"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	charmState, err = ctx.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(charmState, jc.DeepEquals, map[string]string{"foo": "bar"})
+
+	// The changes were written straight to the controller.
+	stored, err := s.unit.CharmState()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, map[string]string{"foo": "bar"})
+}
+
+func (s *InterfaceSuite) TestGetSecret(c *gc.C) {
+	m, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m.AddSecret(state.AddSecretArgs{
+		Name:  "password",
+		Owner: "u",
+		Data:  map[string]string{"foo": "bar"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := s.GetContext(c, -1, "")
+	value, err := ctx.GetSecret("password", 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(value, jc.DeepEquals, map[string]string{"foo": "bar"})
+
+	_, err = ctx.GetSecret("missing", 0)
+	c.Assert(err, gc.ErrorMatches, `secret "missing" not found`)
+}
+
 func (s *InterfaceSuite) TestUnitStatusCaching(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	unitStatus, err := ctx.UnitStatus()
@@ -187,3 +187,21 @@ func (s *EnvSuite) TestEnvUbuntu(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, relationVars)
 }
+
+func (s *EnvSuite) TestEnvSecretRotate(c *gc.C) {
+	s.PatchValue(&jujuos.HostOS, func() jujuos.OSType { return jujuos.Ubuntu })
+	s.PatchValue(&jujuversion.Current, version.MustParse("1.2.3"))
+	os.Setenv("PATH", "foo:bar")
+	ubuntuVars := []string{
+		"PATH=path-to-tools:foo:bar",
+		"APT_LISTCHANGES_FRONTEND=none",
+		"DEBIAN_FRONTEND=noninteractive",
+	}
+
+	ctx, contextVars := s.getContext(false)
+	paths, pathsVars := s.getPaths()
+	context.SetEnvironmentHookContextSecret(ctx, "password")
+	actualVars, err := ctx.HookVars(paths)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVars(c, actualVars, contextVars, pathsVars, ubuntuVars, []string{"JUJU_SECRET_NAME=password"})
+}
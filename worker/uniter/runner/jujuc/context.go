@@ -36,6 +36,8 @@ type HookContext interface {
 	ContextInstance
 	ContextNetworking
 	ContextLeadership
+	ContextCharmState
+	ContextSecrets
 	ContextMetrics
 	ContextStorage
 	ContextComponents
@@ -180,6 +182,32 @@ type ContextLeadership interface {
 	WriteLeaderSettings(map[string]string) error
 }
 
+// ContextCharmState is the part of a hook context related to the
+// key/value pairs that the unit's charm keeps on the controller.
+type ContextCharmState interface {
+	// CharmState returns the unit's charm state. Once the charm state
+	// has been read in a given context, it will not be updated other
+	// than via successful calls to UpdateCharmState.
+	CharmState() (map[string]string, error)
+
+	// UpdateCharmState sets and unsets keys of the unit's charm state,
+	// writing the changes directly to the controller.
+	UpdateCharmState(set map[string]string, unset []string) error
+}
+
+// ContextSecrets is the part of a hook context related to the secrets
+// that the unit's application owns or has been granted.
+type ContextSecrets interface {
+	// GetSecret returns the values of the given revision of the named
+	// secret, or of its latest revision if revision is zero.
+	GetSecret(name string, revision int) (map[string]string, error)
+
+	// SetSecret creates or updates a secret owned by the unit's
+	// application, writing the changes directly to the controller.
+	// Only the leader of the application may set secrets.
+	SetSecret(arg params.SetSecretArg) error
+}
+
 // ContextMetrics is the part of a hook context related to metrics.
 type ContextMetrics interface {
 	// AddMetric records a metric to return after hook execution.
@@ -0,0 +1,46 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+)
+
+// CharmState holds the values for the hook context.
+type CharmState struct {
+	CharmState map[string]string
+}
+
+// ContextCharmState is a test double for jujuc.ContextCharmState.
+type ContextCharmState struct {
+	contextBase
+	info *CharmState
+}
+
+// CharmState implements jujuc.ContextCharmState.
+func (c *ContextCharmState) CharmState() (map[string]string, error) {
+	c.stub.AddCall("CharmState")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.info.CharmState, nil
+}
+
+// UpdateCharmState implements jujuc.ContextCharmState.
+func (c *ContextCharmState) UpdateCharmState(set map[string]string, unset []string) error {
+	c.stub.AddCall("UpdateCharmState", set, unset)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if c.info.CharmState == nil {
+		c.info.CharmState = make(map[string]string)
+	}
+	for _, key := range unset {
+		delete(c.info.CharmState, key)
+	}
+	for key, value := range set {
+		c.info.CharmState[key] = value
+	}
+	return nil
+}
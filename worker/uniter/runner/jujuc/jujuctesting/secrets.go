@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Secrets holds the values for the hook context.
+type Secrets struct {
+	Secrets map[string]map[string]string
+}
+
+// ContextSecrets is a test double for jujuc.ContextSecrets.
+type ContextSecrets struct {
+	contextBase
+	info *Secrets
+}
+
+// GetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) GetSecret(name string, revision int) (map[string]string, error) {
+	c.stub.AddCall("GetSecret", name, revision)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	value, ok := c.info.Secrets[name]
+	if !ok {
+		return nil, errors.NotFoundf("secret %q", name)
+	}
+	return value, nil
+}
+
+// SetSecret implements jujuc.ContextSecrets.
+func (c *ContextSecrets) SetSecret(arg params.SetSecretArg) error {
+	c.stub.AddCall("SetSecret", arg)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Data == nil {
+		return nil
+	}
+	if c.info.Secrets == nil {
+		c.info.Secrets = make(map[string]map[string]string)
+	}
+	c.info.Secrets[arg.Name] = arg.Data
+	return nil
+}
@@ -101,6 +101,24 @@ func (*RestrictedContext) LeaderSettings() (map[string]string, error) {
 // WriteLeaderSettings implements hooks.Context.
 func (*RestrictedContext) WriteLeaderSettings(map[string]string) error { return ErrRestrictedContext }
 
+// CharmState implements hooks.Context.
+func (*RestrictedContext) CharmState() (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// UpdateCharmState implements hooks.Context.
+func (*RestrictedContext) UpdateCharmState(map[string]string, []string) error {
+	return ErrRestrictedContext
+}
+
+// GetSecret implements hooks.Context.
+func (*RestrictedContext) GetSecret(string, int) (map[string]string, error) {
+	return nil, ErrRestrictedContext
+}
+
+// SetSecret implements hooks.Context.
+func (*RestrictedContext) SetSecret(params.SetSecretArg) error { return ErrRestrictedContext }
+
 // AddMetric implements hooks.Context.
 func (*RestrictedContext) AddMetric(string, string, time.Time) error { return ErrRestrictedContext }
 
@@ -0,0 +1,78 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// secretGetCommand implements the secret-get command.
+type secretGetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	name     string
+	key      string
+	revision int
+	out      cmd.Output
+}
+
+// NewSecretGetCommand returns a new secretGetCommand with the given context.
+func NewSecretGetCommand(ctx Context) (cmd.Command, error) {
+	return &secretGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretGetCommand) Info() *cmd.Info {
+	doc := `
+secret-get prints the value of a key in a secret that the unit's application
+owns or has been granted access to. If no key is given, all keys and values
+will be printed. The latest revision of the secret is read unless --revision
+is specified.
+`
+	return &cmd.Info{
+		Name:    "secret-get",
+		Args:    "<name> [<key>]",
+		Purpose: "print the value of a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.IntVar(&c.revision, "revision", 0, "the revision of the secret to read")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretGetCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	if c.revision < 0 {
+		return errors.Errorf("revision %d not valid", c.revision)
+	}
+	c.name, args = args[0], args[1:]
+	c.key = ""
+	if len(args) > 0 {
+		c.key, args = args[0], args[1:]
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretGetCommand) Run(ctx *cmd.Context) error {
+	value, err := c.ctx.GetSecret(c.name, c.revision)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read secret %q", c.name)
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, value)
+	}
+	if v, ok := value[c.key]; ok {
+		return c.out.Write(ctx, v)
+	}
+	return c.out.Write(ctx, nil)
+}
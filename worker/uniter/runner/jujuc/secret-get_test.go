@@ -0,0 +1,97 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretGetSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&secretGetSuite{})
+
+func (s *secretGetSuite) run(c *gc.C, ctx jujuc.Context, args ...string) (int, *cmd.Context) {
+	command, err := jujuc.NewSecretGetCommand(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, args)
+	return code, runContext
+}
+
+func (s *secretGetSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no secret name specified",
+	}, {
+		args: []string{"password", "foo", "bar"},
+		err:  `unrecognized args: \["bar"\]`,
+	}} {
+		command, err := jujuc.NewSecretGetCommand(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		err = command.Init(t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *secretGetSuite) TestGetKey(c *gc.C) {
+	ctx := &secretsContext{value: map[string]string{"foo": "bar"}}
+	code, runContext := s.run(c, ctx, "password", "foo")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(runContext.Stdout), gc.Equals, "bar\n")
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "")
+	c.Check(ctx.gotName, gc.Equals, "password")
+	c.Check(ctx.gotRevision, gc.Equals, 0)
+}
+
+func (s *secretGetSuite) TestGetMissingKey(c *gc.C) {
+	code, runContext := s.run(c, &secretsContext{value: map[string]string{"foo": "bar"}}, "password", "baz")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(runContext.Stdout), gc.Equals, "")
+}
+
+func (s *secretGetSuite) TestGetAllRevision(c *gc.C) {
+	ctx := &secretsContext{value: map[string]string{"foo": "bar", "baz": "qux"}}
+	code, runContext := s.run(c, ctx, "--format", "yaml", "--revision", "2", "password")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(runContext.Stdout), gc.Equals, "baz: qux\nfoo: bar\n")
+	c.Check(ctx.gotRevision, gc.Equals, 2)
+}
+
+func (s *secretGetSuite) TestGetError(c *gc.C) {
+	code, runContext := s.run(c, &secretsContext{err: errors.New("splat")}, "password")
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "ERROR cannot read secret \"password\": splat\n")
+}
+
+type secretsContext struct {
+	jujuc.Context
+	value       map[string]string
+	gotName     string
+	gotRevision int
+	gotSet      params.SetSecretArg
+	err         error
+}
+
+func (s *secretsContext) GetSecret(name string, revision int) (map[string]string, error) {
+	s.gotName = name
+	s.gotRevision = revision
+	return s.value, s.err
+}
+
+func (s *secretsContext) SetSecret(arg params.SetSecretArg) error {
+	s.gotSet = arg
+	return s.err
+}
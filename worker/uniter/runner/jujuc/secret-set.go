@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/keyvalues"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// secretSetCommand implements the secret-set command.
+type secretSetCommand struct {
+	cmd.CommandBase
+	ctx         Context
+	name        string
+	data        map[string]string
+	description string
+	rotate      time.Duration
+}
+
+// NewSecretSetCommand returns a new secretSetCommand with the given context.
+func NewSecretSetCommand(ctx Context) (cmd.Command, error) {
+	return &secretSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *secretSetCommand) Info() *cmd.Info {
+	doc := `
+secret-set creates or updates a secret owned by the unit's application. Each
+update of a secret's values creates a new revision of the secret. Only the
+leader of the application may set secrets.
+
+If --rotate is specified, the leader will run the secret-rotate hook each
+time the interval elapses, so that the charm can update the secret.
+`
+	return &cmd.Info{
+		Name:    "secret-set",
+		Args:    "<name> [<key>=<value> ...]",
+		Purpose: "create or update a secret",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *secretSetCommand) SetFlags(f *gnuflag.FlagSet) {
+	f.StringVar(&c.description, "description", "", "the secret description")
+	f.DurationVar(&c.rotate, "rotate", 0, "how often the secret should be rotated")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *secretSetCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no secret name specified")
+	}
+	if c.rotate < 0 {
+		return errors.Errorf("rotate interval %v not valid", c.rotate)
+	}
+	c.name = args[0]
+	c.data, err = keyvalues.Parse(args[1:], false)
+	return errors.Trace(err)
+}
+
+// Run is part of the cmd.Command interface.
+func (c *secretSetCommand) Run(ctx *cmd.Context) error {
+	arg := params.SetSecretArg{Name: c.name}
+	if len(c.data) > 0 {
+		arg.Data = c.data
+	}
+	if c.description != "" {
+		description := c.description
+		arg.Description = &description
+	}
+	if c.rotate != 0 {
+		rotate := c.rotate
+		arg.RotateInterval = &rotate
+	}
+	err := c.ctx.SetSecret(arg)
+	return errors.Annotatef(err, "cannot set secret %q", c.name)
+}
@@ -0,0 +1,85 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type secretSetSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&secretSetSuite{})
+
+func (s *secretSetSuite) TestInitErrors(c *gc.C) {
+	for _, t := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no secret name specified",
+	}, {
+		args: []string{"password", "nonsense"},
+		err:  `expected "key=value", got "nonsense"`,
+	}} {
+		command, err := jujuc.NewSecretSetCommand(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		err = command.Init(t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *secretSetSuite) TestSetValues(c *gc.C) {
+	jujucContext := &secretsContext{}
+	command, err := jujuc.NewSecretSetCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, []string{
+		"--description", "the password", "--rotate", "24h", "password", "user=admin", "pass=s3cret",
+	})
+	c.Check(code, gc.Equals, 0)
+	description := "the password"
+	rotate := 24 * time.Hour
+	c.Check(jujucContext.gotSet, jc.DeepEquals, params.SetSecretArg{
+		Name:           "password",
+		Description:    &description,
+		Data:           map[string]string{"user": "admin", "pass": "s3cret"},
+		RotateInterval: &rotate,
+	})
+	c.Check(bufferString(runContext.Stdout), gc.Equals, "")
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "")
+}
+
+func (s *secretSetSuite) TestSetDescriptionOnly(c *gc.C) {
+	jujucContext := &secretsContext{}
+	command, err := jujuc.NewSecretSetCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	code := cmd.Main(command, cmdtesting.Context(c), []string{"--description", "new", "password"})
+	c.Check(code, gc.Equals, 0)
+	description := "new"
+	c.Check(jujucContext.gotSet, jc.DeepEquals, params.SetSecretArg{
+		Name:        "password",
+		Description: &description,
+	})
+}
+
+func (s *secretSetSuite) TestSetError(c *gc.C) {
+	jujucContext := &secretsContext{err: errors.New("splat")}
+	command, err := jujuc.NewSecretSetCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, []string{"password", "foo=bar"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "ERROR cannot set secret \"password\": splat\n")
+}
@@ -80,6 +80,17 @@ var leaderCommands = map[string]creator{
 	"leader-set" + cmdSuffix: NewLeaderSetCommand,
 }
 
+var charmStateCommands = map[string]creator{
+	"state-delete" + cmdSuffix: NewStateDeleteCommand,
+	"state-get" + cmdSuffix:    NewStateGetCommand,
+	"state-set" + cmdSuffix:    NewStateSetCommand,
+}
+
+var secretCommands = map[string]creator{
+	"secret-get" + cmdSuffix: NewSecretGetCommand,
+	"secret-set" + cmdSuffix: NewSecretSetCommand,
+}
+
 func allEnabledCommands() map[string]creator {
 	all := map[string]creator{}
 	add := func(m map[string]creator) {
@@ -90,6 +101,8 @@ func allEnabledCommands() map[string]creator {
 	add(baseCommands)
 	add(storageCommands)
 	add(leaderCommands)
+	add(charmStateCommands)
+	add(secretCommands)
 	add(registeredCommands)
 	return all
 }
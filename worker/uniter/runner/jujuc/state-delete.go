@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+)
+
+// stateDeleteCommand implements the state-delete command.
+type stateDeleteCommand struct {
+	cmd.CommandBase
+	ctx  Context
+	keys []string
+}
+
+// NewStateDeleteCommand returns a new stateDeleteCommand with the given context.
+func NewStateDeleteCommand(ctx Context) (cmd.Command, error) {
+	return &stateDeleteCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Info() *cmd.Info {
+	doc := `
+state-delete immediately removes the supplied keys from the unit's charm state
+on the controller. Deleting a key that is not set is not an error.
+`
+	return &cmd.Info{
+		Name:    "state-delete",
+		Args:    "<key> [...]",
+		Purpose: "delete keys from the unit's charm state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no keys specified")
+	}
+	c.keys = args
+	return nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateDeleteCommand) Run(_ *cmd.Context) error {
+	err := c.ctx.UpdateCharmState(nil, c.keys)
+	return errors.Annotate(err, "cannot delete charm state")
+}
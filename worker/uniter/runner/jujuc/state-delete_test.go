@@ -0,0 +1,50 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateDeleteSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&stateDeleteSuite{})
+
+func (s *stateDeleteSuite) TestInitNoKeys(c *gc.C) {
+	command, err := jujuc.NewStateDeleteCommand(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Init(nil)
+	c.Check(err, gc.ErrorMatches, "no keys specified")
+}
+
+func (s *stateDeleteSuite) TestDelete(c *gc.C) {
+	jujucContext := &charmStateContext{}
+	command, err := jujuc.NewStateDeleteCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, []string{"foo", "bar"})
+	c.Check(code, gc.Equals, 0)
+	c.Check(jujucContext.gotSet, gc.HasLen, 0)
+	c.Check(jujucContext.gotUnset, jc.DeepEquals, []string{"foo", "bar"})
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "")
+}
+
+func (s *stateDeleteSuite) TestDeleteError(c *gc.C) {
+	jujucContext := &charmStateContext{err: errors.New("splat")}
+	command, err := jujuc.NewStateDeleteCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, []string{"foo"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "ERROR cannot delete charm state: splat\n")
+}
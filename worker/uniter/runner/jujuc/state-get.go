@@ -0,0 +1,70 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+)
+
+// stateGetCommand implements the state-get command.
+type stateGetCommand struct {
+	cmd.CommandBase
+	ctx Context
+	key string
+	out cmd.Output
+}
+
+// NewStateGetCommand returns a new stateGetCommand with the given context.
+func NewStateGetCommand(ctx Context) (cmd.Command, error) {
+	return &stateGetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateGetCommand) Info() *cmd.Info {
+	doc := `
+state-get prints the value of a key in the unit's charm state, which is kept
+on the controller and survives the loss of the unit's machine. If no key is
+given, or if the key is "-", all keys and values will be printed.
+`
+	return &cmd.Info{
+		Name:    "state-get",
+		Args:    "[<key>]",
+		Purpose: "print the unit's charm state",
+		Doc:     doc,
+	}
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *stateGetCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateGetCommand) Init(args []string) error {
+	c.key = ""
+	if len(args) == 0 {
+		return nil
+	}
+	if key := args[0]; key != "-" {
+		c.key = key
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateGetCommand) Run(ctx *cmd.Context) error {
+	charmState, err := c.ctx.CharmState()
+	if err != nil {
+		return errors.Annotate(err, "cannot read charm state")
+	}
+	if c.key == "" {
+		return c.out.Write(ctx, charmState)
+	}
+	if value, ok := charmState[c.key]; ok {
+		return c.out.Write(ctx, value)
+	}
+	return c.out.Write(ctx, nil)
+}
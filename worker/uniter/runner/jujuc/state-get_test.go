@@ -0,0 +1,86 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateGetSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&stateGetSuite{})
+
+func (s *stateGetSuite) run(c *gc.C, ctx jujuc.Context, args ...string) (int, *cmd.Context) {
+	command, err := jujuc.NewStateGetCommand(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, args)
+	return code, runContext
+}
+
+func (s *stateGetSuite) TestInitTooManyArgs(c *gc.C) {
+	command, err := jujuc.NewStateGetCommand(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Init([]string{"foo", "bar"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["bar"\]`)
+}
+
+func (s *stateGetSuite) TestGetKey(c *gc.C) {
+	code, ctx := s.run(c, &charmStateContext{state: map[string]string{"foo": "bar"}}, "foo")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "bar\n")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "")
+}
+
+func (s *stateGetSuite) TestGetMissingKey(c *gc.C) {
+	code, ctx := s.run(c, &charmStateContext{state: map[string]string{"foo": "bar"}}, "baz")
+	c.Check(code, gc.Equals, 0)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+}
+
+func (s *stateGetSuite) TestGetAll(c *gc.C) {
+	for _, args := range [][]string{nil, {"-"}} {
+		code, ctx := s.run(c, &charmStateContext{
+			state: map[string]string{"foo": "bar", "baz": "qux"},
+		}, append([]string{"--format", "yaml"}, args...)...)
+		c.Check(code, gc.Equals, 0)
+		c.Check(bufferString(ctx.Stdout), gc.Equals, "baz: qux\nfoo: bar\n")
+	}
+}
+
+func (s *stateGetSuite) TestGetError(c *gc.C) {
+	code, ctx := s.run(c, &charmStateContext{err: errors.New("zap")})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(ctx.Stdout), gc.Equals, "")
+	c.Check(bufferString(ctx.Stderr), gc.Equals, "ERROR cannot read charm state: zap\n")
+}
+
+// charmStateContext is a jujuc.Context that records the changes made
+// to the unit's charm state.
+type charmStateContext struct {
+	jujuc.Context
+	state    map[string]string
+	gotSet   map[string]string
+	gotUnset []string
+	err      error
+}
+
+func (s *charmStateContext) CharmState() (map[string]string, error) {
+	return s.state, s.err
+}
+
+func (s *charmStateContext) UpdateCharmState(set map[string]string, unset []string) error {
+	s.gotSet = set
+	s.gotUnset = unset
+	return s.err
+}
@@ -0,0 +1,59 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils/keyvalues"
+)
+
+// stateSetCommand implements the state-set command.
+type stateSetCommand struct {
+	cmd.CommandBase
+	ctx      Context
+	settings map[string]string
+}
+
+// NewStateSetCommand returns a new stateSetCommand with the given context.
+func NewStateSetCommand(ctx Context) (cmd.Command, error) {
+	return &stateSetCommand{ctx: ctx}, nil
+}
+
+// Info is part of the cmd.Command interface.
+func (c *stateSetCommand) Info() *cmd.Info {
+	doc := `
+state-set immediately writes the supplied key/value pairs to the unit's charm
+state on the controller. Setting a key to an empty value removes it. The keys
+may be up to 256 bytes long, and the charm state, keys and values included,
+may not grow beyond 64KiB.
+`
+	return &cmd.Info{
+		Name:    "state-set",
+		Args:    "<key>=<value> [...]",
+		Purpose: "write the unit's charm state",
+		Doc:     doc,
+	}
+}
+
+// Init is part of the cmd.Command interface.
+func (c *stateSetCommand) Init(args []string) (err error) {
+	c.settings, err = keyvalues.Parse(args, true)
+	return
+}
+
+// Run is part of the cmd.Command interface.
+func (c *stateSetCommand) Run(_ *cmd.Context) error {
+	set := make(map[string]string)
+	var unset []string
+	for key, value := range c.settings {
+		if value == "" {
+			unset = append(unset, key)
+		} else {
+			set[key] = value
+		}
+	}
+	err := c.ctx.UpdateCharmState(set, unset)
+	return errors.Annotate(err, "cannot write charm state")
+}
@@ -0,0 +1,57 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuc_test
+
+import (
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+type stateSetSuite struct {
+	jujutesting.IsolationSuite
+}
+
+var _ = gc.Suite(&stateSetSuite{})
+
+func (s *stateSetSuite) TestInitError(c *gc.C) {
+	command, err := jujuc.NewStateSetCommand(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = command.Init([]string{"nonsense"})
+	c.Check(err, gc.ErrorMatches, `expected "key=value", got "nonsense"`)
+}
+
+func (s *stateSetSuite) TestSetValues(c *gc.C) {
+	jujucContext := &charmStateContext{}
+	command, err := jujuc.NewStateSetCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, []string{"foo=bar", "baz=", "a.b=c", "qux="})
+	c.Check(code, gc.Equals, 0)
+	c.Check(jujucContext.gotSet, jc.DeepEquals, map[string]string{
+		"foo": "bar",
+		"a.b": "c",
+	})
+	sort.Strings(jujucContext.gotUnset)
+	c.Check(jujucContext.gotUnset, jc.DeepEquals, []string{"baz", "qux"})
+	c.Check(bufferString(runContext.Stdout), gc.Equals, "")
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "")
+}
+
+func (s *stateSetSuite) TestSetError(c *gc.C) {
+	jujucContext := &charmStateContext{err: errors.New("splat")}
+	command, err := jujuc.NewStateSetCommand(jujucContext)
+	c.Assert(err, jc.ErrorIsNil)
+	runContext := cmdtesting.Context(c)
+	code := cmd.Main(command, runContext, []string{"foo=bar"})
+	c.Check(code, gc.Equals, 1)
+	c.Check(bufferString(runContext.Stderr), gc.Equals, "ERROR cannot write charm state: splat\n")
+}
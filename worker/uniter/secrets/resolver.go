@@ -0,0 +1,82 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package secrets provides the resolver that runs the secret-rotate hook
+// for secrets owned by the unit's application.
+package secrets
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+// secretsResolver is a Resolver that returns operations to run the
+// secret-rotate hook. When the hook is committed, the "secretRotated"
+// callback is invoked to record the rotation.
+type secretsResolver struct {
+	secretRotated func(name string) error
+}
+
+// NewSecretsResolver returns a new Resolver that returns operations to
+// run the secret-rotate hook for the secrets owned by the unit's
+// application.
+//
+// The returned resolver's NextOp method will return an operation to run
+// the secret-rotate hook whenever the unit is the leader and the remote
+// state's "SecretRotations" is non-empty, for the first name in the
+// sequence. When the hook operation is committed, the name of the secret
+// is passed to the "secretRotated" callback.
+func NewSecretsResolver(secretRotated func(string) error) resolver.Resolver {
+	return &secretsResolver{secretRotated}
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (s *secretsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	if !localState.Installed || localState.Kind != operation.Continue {
+		return nil, resolver.ErrNoOperation
+	}
+	// Only the leader rotates the application's secrets.
+	if !localState.Leader || !remoteState.Leader || remoteState.Life == params.Dying {
+		return nil, resolver.ErrNoOperation
+	}
+	if len(remoteState.SecretRotations) == 0 {
+		return nil, resolver.ErrNoOperation
+	}
+	name := remoteState.SecretRotations[0]
+	op, err := opFactory.NewRunHook(hook.Info{
+		Kind:       hook.SecretRotate,
+		SecretName: name,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	secretRotated := func() error {
+		return s.secretRotated(name)
+	}
+	return &secretRotator{op, secretRotated}, nil
+}
+
+type secretRotator struct {
+	operation.Operation
+	secretRotated func() error
+}
+
+func (r *secretRotator) Commit(st operation.State) (*operation.State, error) {
+	result, err := r.Operation.Commit(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := r.secretRotated(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result, nil
+}
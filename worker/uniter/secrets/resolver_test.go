@@ -0,0 +1,148 @@
+// Copyright 2018 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package secrets_test
+
+import (
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/secrets"
+)
+
+type resolverSuite struct {
+	jujutesting.IsolationSuite
+
+	rotated    []string
+	rotatedErr error
+	opFactory  *mockOpFactory
+	resolver   resolver.Resolver
+}
+
+var _ = gc.Suite(&resolverSuite{})
+
+func (s *resolverSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.rotated = nil
+	s.rotatedErr = nil
+	s.opFactory = &mockOpFactory{}
+	s.resolver = secrets.NewSecretsResolver(func(name string) error {
+		s.rotated = append(s.rotated, name)
+		return s.rotatedErr
+	})
+}
+
+func (s *resolverSuite) localState() resolver.LocalState {
+	return resolver.LocalState{
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Leader:    true,
+		},
+	}
+}
+
+func (s *resolverSuite) remoteState() remotestate.Snapshot {
+	return remotestate.Snapshot{
+		Life:            params.Alive,
+		Leader:          true,
+		SecretRotations: []string{"password", "api-key"},
+	}
+}
+
+func (s *resolverSuite) TestRotate(c *gc.C) {
+	op, err := s.resolver.NextOp(s.localState(), s.remoteState(), s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.opFactory.hookInfo, jc.DeepEquals, hook.Info{
+		Kind:       hook.SecretRotate,
+		SecretName: "password",
+	})
+	c.Assert(s.rotated, gc.HasLen, 0)
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.rotated, jc.DeepEquals, []string{"password"})
+}
+
+func (s *resolverSuite) TestRotatedError(c *gc.C) {
+	s.rotatedErr = errors.New("splat")
+	op, err := s.resolver.NextOp(s.localState(), s.remoteState(), s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, gc.ErrorMatches, "splat")
+}
+
+func (s *resolverSuite) TestNoOperation(c *gc.C) {
+	for i, t := range []struct {
+		about  string
+		local  func(*resolver.LocalState)
+		remote func(*remotestate.Snapshot)
+	}{{
+		about: "nothing due",
+		remote: func(rs *remotestate.Snapshot) {
+			rs.SecretRotations = nil
+		},
+	}, {
+		about: "not installed",
+		local: func(ls *resolver.LocalState) {
+			ls.Installed = false
+		},
+	}, {
+		about: "not the leader",
+		local: func(ls *resolver.LocalState) {
+			ls.Leader = false
+		},
+	}, {
+		about: "losing leadership",
+		remote: func(rs *remotestate.Snapshot) {
+			rs.Leader = false
+		},
+	}, {
+		about: "dying",
+		remote: func(rs *remotestate.Snapshot) {
+			rs.Life = params.Dying
+		},
+	}, {
+		about: "hook pending",
+		local: func(ls *resolver.LocalState) {
+			ls.Kind = operation.RunHook
+		},
+	}} {
+		c.Logf("test %d: %s", i, t.about)
+		localState := s.localState()
+		remoteState := s.remoteState()
+		if t.local != nil {
+			t.local(&localState)
+		}
+		if t.remote != nil {
+			t.remote(&remoteState)
+		}
+		_, err := s.resolver.NextOp(localState, remoteState, s.opFactory)
+		c.Check(err, gc.Equals, resolver.ErrNoOperation)
+	}
+}
+
+type mockOpFactory struct {
+	operation.Factory
+	hookInfo hook.Info
+}
+
+func (f *mockOpFactory) NewRunHook(info hook.Info) (operation.Operation, error) {
+	f.hookInfo = info
+	return &mockOp{}, nil
+}
+
+type mockOp struct {
+	operation.Operation
+}
+
+func (op *mockOp) Commit(st operation.State) (*operation.State, error) {
+	return &st, nil
+}
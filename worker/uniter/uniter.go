@@ -41,6 +41,7 @@ import (
 	"github.com/juju/juju/worker/uniter/runner"
 	"github.com/juju/juju/worker/uniter/runner/context"
 	"github.com/juju/juju/worker/uniter/runner/jujuc"
+	"github.com/juju/juju/worker/uniter/secrets"
 	"github.com/juju/juju/worker/uniter/storage"
 )
 
@@ -277,6 +278,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				RetryHookChannel:    retryHookChan,
 				ApplicationChannel:  u.applicationChannel,
 				ModelType:           u.modelType,
+				Clock:               u.clock,
 			})
 		if err != nil {
 			return errors.Trace(err)
@@ -307,6 +309,14 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 		return nil
 	}
 
+	secretRotated := func(name string) error {
+		if err := u.unit.SecretRotated(name); err != nil {
+			return errors.Annotatef(err, "recording rotation of secret %q", name)
+		}
+		watcher.SecretRotated(name)
+		return nil
+	}
+
 	for {
 		if err = restartWatcher(); err != nil {
 			err = errors.Annotate(err, "(re)starting watcher")
@@ -327,6 +337,7 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			Secrets: secrets.NewSecretsResolver(secretRotated),
 		}
 		uniterResolver := NewUniterResolver(cfg)
 